@@ -10,7 +10,6 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
-	"time"
 
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/runtime"
@@ -67,8 +66,8 @@ import (
 	// Service Discovery
 	"github.com/dapr/components-contrib/servicediscovery"
 	servicediscovery_kubernetes "github.com/dapr/components-contrib/servicediscovery/kubernetes"
-	"github.com/dapr/components-contrib/servicediscovery/mdns"
 	servicediscovery_loader "github.com/dapr/dapr/pkg/components/servicediscovery"
+	"github.com/dapr/dapr/pkg/discovery"
 
 	// Bindings
 	"github.com/dapr/components-contrib/bindings"
@@ -222,7 +221,7 @@ func main() {
 		),
 		runtime.WithServiceDiscovery(
 			servicediscovery_loader.New("mdns", func() servicediscovery.Resolver {
-				return mdns.NewMDNSResolver(logContrib)
+				return discovery.NewMDNSResolver()
 			}),
 			servicediscovery_loader.New("kubernetes", func() servicediscovery.Resolver {
 				return servicediscovery_kubernetes.NewKubernetesResolver(logContrib)
@@ -361,9 +360,9 @@ func main() {
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
-	<-stop
-	gracefulShutdownDuration := 5 * time.Second
-	log.Info("dapr shutting down. Waiting 5 seconds to finish outstanding operations")
+	select {
+	case <-stop:
+	case <-rt.ShutdownRequested():
+	}
 	rt.Stop()
-	<-time.After(gracefulShutdownDuration)
 }
@@ -30,6 +30,9 @@ const (
 
 func main() {
 	port := flag.String("port", "50005", "")
+	replicationFactor := flag.Int("replication-factor", placement.DefaultReplicationFactor, "The number of virtual nodes of each host on the hash ring of an actor type")
+	loadFactor := flag.Float64("load-factor", placement.DefaultLoadFactor, "The maximum load of a host, relative to the average load of the hosts of an actor type")
+	drainTimeout := flag.Duration("drain-timeout", placement.DefaultDrainTimeout, "How long the hosts are left locked after a tables update to drain their rebalanced actors, 0 unlocks them right away")
 
 	loggerOptions := logger.DefaultOptions()
 	loggerOptions.AttachCmdFlags(flag.StringVar, flag.BoolVar)
@@ -41,6 +44,13 @@ func main() {
 	flag.BoolVar(&tlsEnabled, "tls-enabled", false, "Should TLS be enabled for the placement gRPC server")
 	flag.Parse()
 
+	if *replicationFactor <= 0 {
+		log.Fatalf("replication-factor must be greater than 0, got %d", *replicationFactor)
+	}
+	if *loadFactor < 1 {
+		log.Fatalf("load-factor must be at least 1, got %v", *loadFactor)
+	}
+
 	// Apply options to all loggers
 	if err := logger.ApplyOptionsToLoggers(&loggerOptions); err != nil {
 		log.Fatal(err)
@@ -91,7 +101,8 @@ func main() {
 		log.Info("tls certificates loaded successfully")
 	}
 
-	p := placement.NewPlacementService()
+	log.Infof("hash tables built with %d virtual nodes per host and a load factor of %v", *replicationFactor, *loadFactor)
+	p := placement.NewPlacementService(*replicationFactor, *loadFactor, *drainTimeout)
 	go p.Run(*port, certChain)
 
 	log.Infof("placement Service started on port %s", *port)
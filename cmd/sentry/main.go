@@ -18,7 +18,7 @@ import (
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/metrics"
 	"github.com/dapr/dapr/pkg/sentry"
-	"github.com/dapr/dapr/pkg/sentry/config"
+	sentry_config "github.com/dapr/dapr/pkg/sentry/config"
 	"github.com/dapr/dapr/pkg/sentry/monitoring"
 	"github.com/dapr/dapr/pkg/signals"
 	"github.com/dapr/dapr/pkg/version"
@@ -34,6 +34,16 @@ func main() {
 	configName := flag.String("config", "default", "Path to config file, or name of a configuration object")
 	credsPath := flag.String("issuer-credentials", defaultCredentialsPath, "Path to the credentials directory holding the issuer data")
 	trustDomain := flag.String("trust-domain", "localhost", "The CA trust domain")
+	workloadCertTTL := flag.String("workload-cert-ttl", "", "Lifetime of the workload certificates, such as 24h, overrides the workloadCertTTL of the configuration")
+	allowedClockSkew := flag.String("allowed-clock-skew", "", "Clock drift tolerated between the hosts, such as 15m, overrides the allowedClockSkew of the configuration")
+	tokenValidator := flag.String("token-validator", "", "Validator of the CSR tokens: kubernetes, jwt or insecure. Defaults to kubernetes in Kubernetes and insecure otherwise")
+	jwtIssuer := flag.String("jwt-issuer", "", "Issuer of the JWT tokens accepted by the jwt validator")
+	jwtAudience := flag.String("jwt-audience", "", "Audience of the JWT tokens accepted by the jwt validator")
+	jwksURL := flag.String("jwks-url", "", "URL of the keys signing the JWT tokens, read from the OIDC discovery document of the issuer when empty")
+	externalCA := flag.Bool("external-ca", false, "Load the root and issuer certs of an external CA from the credentials directory instead of generating them")
+	rootCertFilename := flag.String("issuer-ca-filename", credentials.RootCertFilename, "Filename of the root certs in the credentials directory")
+	issuerCertFilename := flag.String("issuer-certificate-filename", credentials.IssuerCertFilename, "Filename of the issuer cert, followed by its intermediate certs, in the credentials directory")
+	issuerKeyFilename := flag.String("issuer-key-filename", credentials.IssuerKeyFilename, "Filename of the issuer key in the credentials directory")
 
 	loggerOptions := logger.DefaultOptions()
 	loggerOptions.AttachCmdFlags(flag.StringVar, flag.BoolVar)
@@ -60,15 +70,15 @@ func main() {
 		log.Fatal(err)
 	}
 
-	issuerCertPath := filepath.Join(*credsPath, credentials.IssuerCertFilename)
-	issuerKeyPath := filepath.Join(*credsPath, credentials.IssuerKeyFilename)
-	rootCertPath := filepath.Join(*credsPath, credentials.RootCertFilename)
+	issuerCertPath := filepath.Join(*credsPath, *issuerCertFilename)
+	issuerKeyPath := filepath.Join(*credsPath, *issuerKeyFilename)
+	rootCertPath := filepath.Join(*credsPath, *rootCertFilename)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
 	ctx := signals.Context()
-	config, err := config.FromConfigName(*configName)
+	config, err := sentry_config.FromConfigName(*configName)
 	if err != nil {
 		log.Warn(err)
 	}
@@ -76,6 +86,15 @@ func main() {
 	config.IssuerKeyPath = issuerKeyPath
 	config.RootCertPath = rootCertPath
 	config.TrustDomain = *trustDomain
+	config.ExternalCA = *externalCA
+	config.TokenValidator = *tokenValidator
+	config.JWTIssuer = *jwtIssuer
+	config.JWTAudience = *jwtAudience
+	config.JWKSURL = *jwksURL
+	config, err = sentry_config.WithCertificateDurations(config, *workloadCertTTL, *allowedClockSkew)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	watchDir := filepath.Dir(config.IssuerCertPath)
 
@@ -6,12 +6,11 @@
 package actors
 
 import (
-	"sync"
 	"time"
 )
 
 type actor struct {
-	lock         *sync.RWMutex
+	lock         *actorLock
 	lastUsedTime time.Time
 	busy         bool
 	busyCh       chan (bool)
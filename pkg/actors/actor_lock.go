@@ -0,0 +1,68 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrMaxStackDepthExceeded is returned when a call chain re-enters an actor more times than allowed
+var ErrMaxStackDepthExceeded = errors.New("maximum stack depth exceeded")
+
+// actorLock serializes the calls of an actor. A call carrying the reentrancy id of the call chain
+// holding the lock re-enters the actor instead of waiting for the chain to release the lock.
+type actorLock struct {
+	methodLock  sync.Mutex
+	requestLock sync.Mutex
+	// activeRequest is the reentrancy id of the call chain holding the lock, if any
+	activeRequest string
+	stackDepth    int
+	maxStackDepth int
+}
+
+func newActorLock(maxStackDepth int) *actorLock {
+	return &actorLock{maxStackDepth: maxStackDepth}
+}
+
+// Lock waits for the lock unless requestID is the non empty reentrancy id of the call chain holding it
+func (l *actorLock) Lock(requestID string) error {
+	l.requestLock.Lock()
+	if requestID != "" && requestID == l.activeRequest {
+		defer l.requestLock.Unlock()
+		if l.stackDepth >= l.maxStackDepth {
+			return ErrMaxStackDepthExceeded
+		}
+		l.stackDepth++
+		return nil
+	}
+	l.requestLock.Unlock()
+
+	l.methodLock.Lock()
+	l.requestLock.Lock()
+	l.activeRequest = requestID
+	l.stackDepth = 1
+	l.requestLock.Unlock()
+	return nil
+}
+
+// Unlock releases the lock once the outermost call of the call chain holding it returns
+func (l *actorLock) Unlock() {
+	l.requestLock.Lock()
+	defer l.requestLock.Unlock()
+	l.stackDepth--
+	if l.stackDepth == 0 {
+		l.activeRequest = ""
+		l.methodLock.Unlock()
+	}
+}
+
+// depth returns the number of nested calls of the call chain holding the lock
+func (l *actorLock) depth() int {
+	l.requestLock.Lock()
+	defer l.requestLock.Unlock()
+	return l.stackDepth
+}
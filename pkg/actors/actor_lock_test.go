@@ -0,0 +1,70 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorLock(t *testing.T) {
+	t.Run("same call chain re-enters", func(t *testing.T) {
+		lock := newActorLock(3)
+		assert.NoError(t, lock.Lock("chain1"))
+		assert.NoError(t, lock.Lock("chain1"))
+		assert.NoError(t, lock.Lock("chain1"))
+		assert.Equal(t, 3, lock.depth())
+		assert.Equal(t, ErrMaxStackDepthExceeded, lock.Lock("chain1"))
+
+		lock.Unlock()
+		lock.Unlock()
+		lock.Unlock()
+		assert.Equal(t, 0, lock.depth())
+	})
+
+	t.Run("other call chains wait", func(t *testing.T) {
+		lock := newActorLock(3)
+		assert.NoError(t, lock.Lock("chain1"))
+
+		locked := make(chan struct{})
+		go func() {
+			lock.Lock("chain2")
+			close(locked)
+		}()
+		select {
+		case <-locked:
+			assert.Fail(t, "call chain entered a locked actor")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		lock.Unlock()
+		select {
+		case <-locked:
+		case <-time.After(time.Second):
+			assert.Fail(t, "call chain did not enter the unlocked actor")
+		}
+	})
+
+	t.Run("calls without reentrancy id wait", func(t *testing.T) {
+		lock := newActorLock(3)
+		assert.NoError(t, lock.Lock(""))
+
+		locked := make(chan struct{})
+		go func() {
+			lock.Lock("")
+			close(locked)
+		}()
+		select {
+		case <-locked:
+			assert.Fail(t, "call entered a locked actor")
+		case <-time.After(10 * time.Millisecond):
+		}
+		lock.Unlock()
+		<-locked
+	})
+}
@@ -0,0 +1,163 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/google/uuid"
+)
+
+const (
+	metadataKeySuffix  = "metadata"
+	remindersKeyPrefix = "reminders"
+)
+
+// ActorMetadata is the metadata record of an actor type, it describes how its reminders are stored
+type ActorMetadata struct {
+	// ID identifies the storage layout of the reminders, the partition keys of each layout are distinct
+	ID                string                 `json:"id"`
+	RemindersMetadata ActorRemindersMetadata `json:"actorRemindersMetadata"`
+
+	etag string
+}
+
+// ActorRemindersMetadata describes the partitions of the reminders of an actor type.
+// The reminders of stores without partitions are all saved under the key of the actor type.
+type ActorRemindersMetadata struct {
+	PartitionCount int `json:"partitionCount"`
+}
+
+// partitions returns the ids of the reminder partitions, 0 is the key of the actor type
+func (m *ActorMetadata) partitions() []int {
+	if m.RemindersMetadata.PartitionCount <= 0 {
+		return []int{0}
+	}
+	partitions := make([]int, 0, m.RemindersMetadata.PartitionCount)
+	for p := 1; p <= m.RemindersMetadata.PartitionCount; p++ {
+		partitions = append(partitions, p)
+	}
+	return partitions
+}
+
+// partition returns the id of the partition of the reminders of an actor
+func (m *ActorMetadata) partition(actorID string) int {
+	if m.RemindersMetadata.PartitionCount <= 0 {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(actorID))
+	return int(h.Sum32()%uint32(m.RemindersMetadata.PartitionCount)) + 1
+}
+
+func (a *actorsRuntime) remindersKey(actorType string, metadata *ActorMetadata, partition int) string {
+	if partition == 0 {
+		return a.constructCompositeKey("actors", actorType)
+	}
+	return a.constructCompositeKey("actors", actorType, metadata.ID, remindersKeyPrefix, strconv.Itoa(partition))
+}
+
+// getActorTypeMetadata returns the metadata record of the actor type. If migrate is true and the reminders
+// are not partitioned as configured, they are first moved to partitions of a new layout.
+func (a *actorsRuntime) getActorTypeMetadata(actorType string, migrate bool) (*ActorMetadata, error) {
+	resp, err := a.store.Get(&state.GetRequest{
+		Key: a.constructCompositeKey("actors", actorType, metadataKeySuffix),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &ActorMetadata{}
+	if len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, metadata); err != nil {
+			return nil, err
+		}
+		metadata.etag = resp.ETag
+	}
+
+	if !migrate || metadata.RemindersMetadata.PartitionCount == a.config.RemindersStoragePartitions {
+		return metadata, nil
+	}
+	return a.migrateReminders(actorType, metadata)
+}
+
+// migrateReminders moves the reminders of the actor type to the configured number of partitions.
+// The reminders are written to the keys of a new layout before the metadata record points to it,
+// so that readers see either the previous or the new layout.
+func (a *actorsRuntime) migrateReminders(actorType string, previous *ActorMetadata) (*ActorMetadata, error) {
+	reminders, err := a.getRemindersForLayout(actorType, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &ActorMetadata{
+		ID:                uuid.New().String(),
+		RemindersMetadata: ActorRemindersMetadata{PartitionCount: a.config.RemindersStoragePartitions},
+	}
+	partitions := map[int][]Reminder{}
+	for _, r := range reminders {
+		p := metadata.partition(r.ActorID)
+		partitions[p] = append(partitions[p], r)
+	}
+	for p, rs := range partitions {
+		err := a.store.Set(&state.SetRequest{
+			Key:   a.remindersKey(actorType, metadata, p),
+			Value: rs,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = a.store.Set(&state.SetRequest{
+		Key:   a.constructCompositeKey("actors", actorType, metadataKeySuffix),
+		Value: metadata,
+		ETag:  previous.etag,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("migrated %d reminders of actor type %s from %d to %d partitions", len(reminders), actorType,
+		previous.RemindersMetadata.PartitionCount, metadata.RemindersMetadata.PartitionCount)
+
+	// the layouts don't share keys, as partitioned layouts have distinct ids
+	for _, p := range previous.partitions() {
+		err := a.store.Delete(&state.DeleteRequest{Key: a.remindersKey(actorType, previous, p)})
+		if err != nil {
+			log.Warnf("error deleting reminder partition %d of actor type %s: %s", p, actorType, err)
+		}
+	}
+	return metadata, nil
+}
+
+// getRemindersForLayout returns the reminders saved in all the partitions of the layout
+func (a *actorsRuntime) getRemindersForLayout(actorType string, metadata *ActorMetadata) ([]Reminder, error) {
+	var reminders []Reminder
+	for _, p := range metadata.partitions() {
+		rs, err := a.getRemindersPartition(a.remindersKey(actorType, metadata, p))
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, rs...)
+	}
+	return reminders, nil
+}
+
+func (a *actorsRuntime) getRemindersPartition(key string) ([]Reminder, error) {
+	resp, err := a.store.Get(&state.GetRequest{
+		Key: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var reminders []Reminder
+	json.Unmarshal(resp.Data, &reminders)
+	return reminders, nil
+}
@@ -0,0 +1,99 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderPartition(t *testing.T) {
+	metadata := &ActorMetadata{ID: "layout1"}
+	assert.Equal(t, []int{0}, metadata.partitions())
+	assert.Equal(t, 0, metadata.partition("actor1"))
+
+	metadata.RemindersMetadata.PartitionCount = 3
+	assert.Equal(t, []int{1, 2, 3}, metadata.partitions())
+	for i := 0; i < 10; i++ {
+		p := metadata.partition(fmt.Sprintf("actor%d", i))
+		assert.True(t, p >= 1 && p <= 3)
+		assert.Equal(t, p, metadata.partition(fmt.Sprintf("actor%d", i)))
+	}
+}
+
+func TestRemindersStoragePartitions(t *testing.T) {
+	ctx := context.Background()
+	actorType := "cat"
+
+	t.Run("reminders are saved in partitions", func(t *testing.T) {
+		testActorsRuntime := newTestActorsRuntime()
+		testActorsRuntime.config.RemindersStoragePartitions = 3
+		for i := 0; i < 10; i++ {
+			reminder := createReminderData(fmt.Sprintf("actor%d", i), actorType, "reminder1", "1h", "1h", "")
+			assert.NoError(t, testActorsRuntime.CreateReminder(ctx, &reminder))
+		}
+
+		metadata, err := testActorsRuntime.getActorTypeMetadata(actorType, false)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, metadata.RemindersMetadata.PartitionCount)
+		reminders, err := testActorsRuntime.getRemindersForActorType(actorType)
+		assert.NoError(t, err)
+		assert.Len(t, reminders, 10)
+		assert.Len(t, testActorsRuntime.reminders[actorType], 10)
+
+		partition, err := testActorsRuntime.getRemindersPartition(testActorsRuntime.remindersKey(actorType, metadata, metadata.partition("actor1")))
+		assert.NoError(t, err)
+		assert.Contains(t, partition, reminders[1])
+		assert.True(t, len(partition) < 10)
+
+		err = testActorsRuntime.DeleteReminder(ctx, &DeleteReminderRequest{ActorID: "actor1", ActorType: actorType, Name: "reminder1"})
+		assert.NoError(t, err)
+		reminders, _ = testActorsRuntime.getRemindersForActorType(actorType)
+		assert.Len(t, reminders, 9)
+	})
+
+	t.Run("reminders of a single key are migrated", func(t *testing.T) {
+		testActorsRuntime := newTestActorsRuntime()
+		for i := 0; i < 5; i++ {
+			reminder := createReminderData(fmt.Sprintf("actor%d", i), actorType, "reminder1", "1h", "1h", "")
+			assert.NoError(t, testActorsRuntime.CreateReminder(ctx, &reminder))
+		}
+		legacyKey := testActorsRuntime.constructCompositeKey("actors", actorType)
+		store := testActorsRuntime.store.(*fakeStateStore)
+		assert.NotEmpty(t, store.items[legacyKey])
+
+		testActorsRuntime.config.RemindersStoragePartitions = 2
+		metadata, err := testActorsRuntime.getActorTypeMetadata(actorType, true)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, metadata.RemindersMetadata.PartitionCount)
+		assert.NotContains(t, store.items, legacyKey)
+
+		reminders, err := testActorsRuntime.getRemindersForActorType(actorType)
+		assert.NoError(t, err)
+		assert.Len(t, reminders, 5)
+	})
+
+	t.Run("partitioned reminders are migrated back to a single key", func(t *testing.T) {
+		testActorsRuntime := newTestActorsRuntime()
+		testActorsRuntime.config.RemindersStoragePartitions = 2
+		reminder := createReminderData("actor1", actorType, "reminder1", "1h", "1h", "")
+		assert.NoError(t, testActorsRuntime.CreateReminder(ctx, &reminder))
+
+		testActorsRuntime.config.RemindersStoragePartitions = 0
+		_, err := testActorsRuntime.getActorTypeMetadata(actorType, true)
+		assert.NoError(t, err)
+
+		store := testActorsRuntime.store.(*fakeStateStore)
+		var reminders []Reminder
+		json.Unmarshal(store.items[testActorsRuntime.constructCompositeKey("actors", actorType)], &reminders)
+		assert.Len(t, reminders, 1)
+		assert.Equal(t, "actor1", reminders[0].ActorID)
+	})
+}
@@ -0,0 +1,201 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	nethttp "net/http"
+	"sync"
+
+	"github.com/dapr/dapr/pkg/channel"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"github.com/golang/protobuf/ptypes/any"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamChunkSize is the maximum size of the body chunks of a response streamed between Dapr runtimes
+const streamChunkSize = 32 * 1024
+
+// SendResponseStream sends a streamed actor method response over the stream. The first message holds
+// the status and headers of the response, the next ones the chunks of its body.
+func SendResponseStream(stream internalv1pb.DaprActorStreaming_CallActorStreamServer, resp *invokev1.InvokeMethodResponse, body io.Reader) error {
+	if err := stream.Send(resp.Proto()); err != nil {
+		return err
+	}
+
+	buf := make([]byte, streamChunkSize)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			// Send serializes the message before returning, so the buffer can be reused
+			chunk := &internalv1pb.InternalInvokeResponse{
+				Message: &commonv1pb.InvokeResponse{Data: &any.Any{Value: buf[:n]}},
+			}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (a *actorsRuntime) CallStream(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	if a.placementBlock {
+		<-a.placementSignal
+	}
+
+	actor := req.Actor()
+	targetActorAddress, appID := a.lookupActorAddress(actor.GetActorType(), actor.GetActorId())
+	if targetActorAddress == "" {
+		return nil, nil, fmt.Errorf("error finding address for actor type %s with id %s", actor.GetActorType(), actor.GetActorId())
+	}
+
+	if a.isActorLocal(targetActorAddress, a.config.HostAddress, a.config.Port) {
+		return a.callLocalActorStream(ctx, req)
+	}
+
+	for i := 0; i < callRemoteActorRetryCount; i++ {
+		resp, body, err := a.callRemoteActorStream(ctx, targetActorAddress, appID, req)
+		code := status.Code(err)
+		if code != codes.Unavailable && code != codes.Unauthenticated {
+			return resp, body, err
+		}
+		if _, err := a.grpcConnectionFn(targetActorAddress, appID, false, true); err != nil {
+			return nil, nil, err
+		}
+	}
+	return nil, nil, fmt.Errorf("failed to invoke target %s after %v retries", targetActorAddress, callRemoteActorRetryCount)
+}
+
+func (a *actorsRuntime) callLocalActorStream(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	release, err := a.acquireActorTurn(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	streamingChannel, ok := a.appChannel.(channel.StreamingAppChannel)
+	if !ok {
+		// the app channel buffers the response, which is then sent as a single chunk
+		resp, err := a.appChannel.InvokeMethod(ctx, req)
+		release()
+		if err != nil {
+			return nil, nil, err
+		}
+		contentType, data := resp.RawData()
+		if resp.Status().Code != nethttp.StatusOK {
+			return nil, nil, fmt.Errorf("error from actor service: %s", string(data))
+		}
+		resp.WithRawData(nil, contentType)
+		return resp, ioutil.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	resp, body, err := streamingChannel.InvokeMethodStream(ctx, req)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	if resp.Status().Code != nethttp.StatusOK {
+		data, _ := ioutil.ReadAll(body)
+		body.Close()
+		release()
+		return nil, nil, fmt.Errorf("error from actor service: %s", string(data))
+	}
+	return resp, &actorTurnBody{ReadCloser: body, release: release}, nil
+}
+
+func (a *actorsRuntime) callRemoteActorStream(
+	ctx context.Context,
+	targetAddress, targetID string,
+	req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	conn, err := a.grpcConnectionFn(targetAddress, targetID, false, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var span *trace.Span
+	ctx, span = diag.StartTracingClientSpanFromGRPCContext(ctx, req.Message().Method, a.tracingSpec)
+	ctx = diag.AppendToOutgoingGRPCContext(ctx, span.SpanContext())
+	ctx, cancel := context.WithCancel(ctx)
+	body := &responseStreamBody{cancel: cancel, span: span}
+
+	resp, err := body.open(ctx, conn, req)
+	diag.UpdateSpanPairStatusesFromError(span, err, req.Message().Method)
+	if err != nil {
+		body.Close()
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// actorTurnBody ends the actor turn once the body of the response is closed
+type actorTurnBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *actorTurnBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// responseStreamBody reads the body of an actor method response streamed by another Dapr runtime
+type responseStreamBody struct {
+	stream internalv1pb.DaprActorStreaming_CallActorStreamClient
+	buf    []byte
+	cancel context.CancelFunc
+	span   *trace.Span
+}
+
+// open calls the actor method and receives the status and headers of its response
+func (b *responseStreamBody) open(ctx context.Context, conn *grpc.ClientConn, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	stream, err := internalv1pb.NewDaprActorStreamingClient(conn).CallActorStream(ctx, req.Proto())
+	if err != nil {
+		return nil, err
+	}
+	b.stream = stream
+
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return invokev1.InternalInvokeResponse(first)
+}
+
+func (b *responseStreamBody) Read(p []byte) (int, error) {
+	for len(b.buf) == 0 {
+		chunk, err := b.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		b.buf = chunk.GetMessage().GetData().GetValue()
+	}
+	n := copy(p, b.buf)
+	b.buf = b.buf[n:]
+	return n, nil
+}
+
+func (b *responseStreamBody) Close() error {
+	b.cancel()
+	b.span.End()
+	return nil
+}
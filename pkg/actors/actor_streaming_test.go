@@ -0,0 +1,114 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+type fakeStreamingAppChannel struct {
+	*channelt.MockAppChannel
+	body []byte
+}
+
+func (c *fakeStreamingAppChannel) InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	resp := invokev1.NewInvokeMethodResponse(200, "OK", nil).WithRawData(nil, "text/plain")
+	return resp, ioutil.NopCloser(bytes.NewReader(c.body)), nil
+}
+
+type fakeActorStreamingServer struct {
+	body []byte
+}
+
+func (s *fakeActorStreamingServer) CallActorStream(in *internalv1pb.InternalInvokeRequest, stream internalv1pb.DaprActorStreaming_CallActorStreamServer) error {
+	resp := invokev1.NewInvokeMethodResponse(200, "OK", nil).WithRawData(nil, "text/plain")
+	return SendResponseStream(stream, resp, bytes.NewReader(s.body))
+}
+
+func newStreamingActors(appChannel *channelt.MockAppChannel) *actorsRuntime {
+	appChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil).WithRawData([]byte("buffered"), "text/plain"), nil)
+	c := NewConfig(NewConfigOpts{AppID: TestAppID})
+	return NewActors(fakeStore(), appChannel, nil, c, nil, config.TracingSpec{}, nil).(*actorsRuntime)
+}
+
+func TestCallActorStream(t *testing.T) {
+	ctx := context.Background()
+	actorType, actorID := getTestActorTypeAndID()
+
+	t.Run("buffered responses are streamed as a single chunk", func(t *testing.T) {
+		a := newStreamingActors(new(channelt.MockAppChannel))
+		req := invokev1.NewInvokeMethodRequest("report").WithActor(actorType, actorID)
+
+		resp, body, err := a.callLocalActorStream(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "text/plain", resp.Message().GetContentType())
+		data, _ := ioutil.ReadAll(body)
+		assert.Equal(t, "buffered", string(data))
+		assert.NoError(t, body.Close())
+	})
+
+	t.Run("the actor turn lasts until the body is closed", func(t *testing.T) {
+		appChannel := new(channelt.MockAppChannel)
+		a := newStreamingActors(appChannel)
+		a.appChannel = &fakeStreamingAppChannel{MockAppChannel: appChannel, body: []byte("streamed")}
+		req := invokev1.NewInvokeMethodRequest("report").WithActor(actorType, actorID)
+
+		_, body, err := a.callLocalActorStream(ctx, req)
+		assert.NoError(t, err)
+		val, _ := a.actorsTable.Load(a.constructCompositeKey(actorType, actorID))
+		act := val.(*actor)
+		assert.Equal(t, 1, act.lock.depth())
+
+		data, _ := ioutil.ReadAll(body)
+		assert.Equal(t, "streamed", string(data))
+		assert.NoError(t, body.Close())
+		assert.NoError(t, body.Close())
+		assert.Equal(t, 0, act.lock.depth())
+		assert.False(t, act.busy)
+	})
+
+	t.Run("responses of remote actors are streamed in chunks", func(t *testing.T) {
+		payload := bytes.Repeat([]byte("0123456789"), 10000)
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		server := grpc.NewServer()
+		internalv1pb.RegisterDaprActorStreamingServer(server, &fakeActorStreamingServer{body: payload})
+		go server.Serve(lis)
+		defer server.Stop()
+
+		conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		a := newStreamingActors(new(channelt.MockAppChannel))
+		a.grpcConnectionFn = func(address, id string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error) {
+			return conn, nil
+		}
+		req := invokev1.NewInvokeMethodRequest("report").WithActor(actorType, actorID)
+
+		resp, body, err := a.callRemoteActorStream(ctx, lis.Addr().String(), TestAppID, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(200), resp.Status().Code)
+		assert.Equal(t, "text/plain", resp.Message().GetContentType())
+		data, err := ioutil.ReadAll(body)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, data)
+		assert.NoError(t, body.Close())
+	})
+}
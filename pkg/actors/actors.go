@@ -10,7 +10,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	nethttp "net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -27,18 +31,20 @@ import (
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
 	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
-	"github.com/dapr/dapr/pkg/runtime/security"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 const (
 	daprSeparator             = "||"
 	callRemoteActorRetryCount = 3
+	// reentrancyIDHeader is the header carrying the id of a reentrant actor call chain
+	reentrancyIDHeader = "Dapr-Reentrancy-Id"
 )
 
 var log = logger.NewLogger("dapr.runtime.actor")
@@ -46,18 +52,28 @@ var log = logger.NewLogger("dapr.runtime.actor")
 // Actors allow calling into virtual actors as well as actor state management
 type Actors interface {
 	Call(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error)
+	// CallStream invokes an actor method and returns the body of its response as the actor sends it.
+	// The actor turn lasts until the caller closes the body.
+	CallStream(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, io.ReadCloser, error)
 	Init() error
 	GetState(ctx context.Context, req *GetStateRequest) (*StateResponse, error)
 	SaveState(ctx context.Context, req *SaveStateRequest) error
 	DeleteState(ctx context.Context, req *DeleteStateRequest) error
 	TransactionalStateOperation(ctx context.Context, req *TransactionalRequest) error
 	GetReminder(ctx context.Context, req *GetReminderRequest) (*Reminder, error)
+	// ListReminders returns the reminders of an actor type or of an actor, a page at a time
+	ListReminders(ctx context.Context, req *ListRemindersRequest) (*ListRemindersResponse, error)
 	CreateReminder(ctx context.Context, req *CreateReminderRequest) error
 	DeleteReminder(ctx context.Context, req *DeleteReminderRequest) error
 	CreateTimer(ctx context.Context, req *CreateTimerRequest) error
 	DeleteTimer(ctx context.Context, req *DeleteTimerRequest) error
 	IsActorHosted(ctx context.Context, req *ActorHostedRequest) bool
 	GetActiveActorsCount(ctx context.Context) []ActiveActorsCount
+	// Stop leaves the placement of actors and deactivates the active actors
+	Stop()
+	// SetAppHealthy reports the health of the app. While the app is unhealthy, the app instance is not a placement
+	// candidate and no actor is activated.
+	SetAppHealthy(healthy bool)
 }
 
 type actorsRuntime struct {
@@ -67,17 +83,23 @@ type actorsRuntime struct {
 	placementTables     *placement.ConsistentHashTables
 	placementSignal     chan struct{}
 	placementBlock      bool
+	placement           PlacementClient
 	operationUpdateLock *sync.Mutex
 	grpcConnectionFn    func(address, id string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error)
 	config              Config
 	actorsTable         *sync.Map
 	activeTimers        *sync.Map
+	durableTimersLock   *sync.Mutex
 	activeReminders     *sync.Map
 	remindersLock       *sync.RWMutex
 	reminders           map[string][]Reminder
+	countsLock          *sync.Mutex
+	counts              *actorsCounts
+	firings             *firingQueue
 	evaluationLock      *sync.RWMutex
 	evaluationBusy      bool
 	evaluationChan      chan bool
+	appHealthLock       *sync.RWMutex
 	appHealthy          bool
 	certChain           *dapr_credentials.CertChain
 	tracingSpec         config.TracingSpec
@@ -89,6 +111,20 @@ type ActiveActorsCount struct {
 	Count int    `json:"count"`
 }
 
+const (
+	// DeactivationReasonIdle is the reason of the deactivation of actors which reached their idle timeout
+	DeactivationReasonIdle = "idle"
+	// DeactivationReasonRebalance is the reason of the deactivation of actors moved to another app instance
+	DeactivationReasonRebalance = "rebalance"
+	// DeactivationReasonShutdown is the reason of the deactivation of actors when the sidecar shuts down
+	DeactivationReasonShutdown = "shutdown"
+)
+
+// DeactivationRequest is the body of the deactivate callback of actors
+type DeactivationRequest struct {
+	Reason string `json:"reason"`
+}
+
 const (
 	idHeader               = "id"
 	lockOperation          = "lock"
@@ -104,23 +140,30 @@ func NewActors(
 	grpcConnectionFn func(address, id string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error),
 	config Config,
 	certChain *dapr_credentials.CertChain,
-	tracingSpec config.TracingSpec) Actors {
+	tracingSpec config.TracingSpec,
+	placementClient PlacementClient) Actors {
 	return &actorsRuntime{
 		appChannel:          appChannel,
 		config:              config,
 		store:               stateStore,
 		placementTableLock:  &sync.RWMutex{},
 		placementTables:     &placement.ConsistentHashTables{Entries: make(map[string]*placement.Consistent)},
+		placement:           placementClient,
 		operationUpdateLock: &sync.Mutex{},
 		grpcConnectionFn:    grpcConnectionFn,
 		actorsTable:         &sync.Map{},
 		activeTimers:        &sync.Map{},
+		durableTimersLock:   &sync.Mutex{},
 		activeReminders:     &sync.Map{},
 		remindersLock:       &sync.RWMutex{},
 		reminders:           map[string][]Reminder{},
+		countsLock:          &sync.Mutex{},
+		counts:              newActorsCounts(),
+		firings:             newFiringQueue(config.MaxConcurrentReminderFirings),
 		evaluationLock:      &sync.RWMutex{},
 		evaluationBusy:      false,
 		evaluationChan:      make(chan bool),
+		appHealthLock:       &sync.RWMutex{},
 		appHealthy:          true,
 		certChain:           certChain,
 		tracingSpec:         tracingSpec,
@@ -128,8 +171,11 @@ func NewActors(
 }
 
 func (a *actorsRuntime) Init() error {
-	if a.config.PlacementServiceAddress == "" {
-		return errors.New("actors: couldn't connect to placement service: address is empty")
+	if a.placement == nil {
+		if a.config.PlacementServiceAddress == "" {
+			return errors.New("actors: couldn't connect to placement service: address is empty")
+		}
+		a.placement = newDaprPlacementClient(a.config.PlacementServiceAddress, a.config.HeartbeatInterval, a.certChain)
 	}
 	if a.store == nil {
 		log.Warn("actors: state store must be present to initialize the actor runtime")
@@ -140,13 +186,21 @@ func (a *actorsRuntime) Init() error {
 		return errors.New(incompatibleStateStore)
 	}
 
-	go a.connectToPlacementService(a.config.PlacementServiceAddress, a.config.HostAddress, a.config.HeartbeatInterval)
-	a.startDeactivationTicker(a.config.ActorDeactivationScanInterval, a.config.ActorIdleTimeout)
+	go a.placement.Start(a.placementHost(), a.isAppHealthy, a.onPlacementOrder)
+	for _, interval := range a.config.deactivationScanIntervals() {
+		a.startDeactivationTicker(interval)
+	}
 
 	log.Infof("actor runtime started. actor idle timeout: %s. actor scan interval: %s",
 		a.config.ActorIdleTimeout.String(), a.config.ActorDeactivationScanInterval.String())
+	for actorType, e := range a.config.EntitiesConfig {
+		log.Infof("actor type %s idle timeout: %s. actor scan interval: %s. drain ongoing call timeout: %s",
+			actorType, e.ActorIdleTimeout, e.ActorDeactivationScanInterval, e.DrainOngoingCallTimeout)
+	}
 
-	go a.startAppHealthCheck()
+	if !a.config.ExternalAppHealth {
+		go a.startAppHealthCheck()
+	}
 	return nil
 }
 
@@ -157,11 +211,23 @@ func (a *actorsRuntime) startAppHealthCheck(opts ...health.Option) {
 
 	healthAddress := fmt.Sprintf("%s/healthz", a.appChannel.GetBaseAddress())
 	ch := health.StartEndpointHealthCheck(healthAddress, opts...)
-	for {
-		a.appHealthy = <-ch
+	for healthy := range ch {
+		a.SetAppHealthy(healthy)
 	}
 }
 
+func (a *actorsRuntime) SetAppHealthy(healthy bool) {
+	a.appHealthLock.Lock()
+	defer a.appHealthLock.Unlock()
+	a.appHealthy = healthy
+}
+
+func (a *actorsRuntime) isAppHealthy() bool {
+	a.appHealthLock.RLock()
+	defer a.appHealthLock.RUnlock()
+	return a.appHealthy
+}
+
 func (a *actorsRuntime) constructCompositeKey(keys ...string) string {
 	return strings.Join(keys, daprSeparator)
 }
@@ -170,10 +236,21 @@ func (a *actorsRuntime) decomposeCompositeKey(compositeKey string) []string {
 	return strings.Split(compositeKey, daprSeparator)
 }
 
-func (a *actorsRuntime) deactivateActor(actorType, actorID string) error {
-	req := invokev1.NewInvokeMethodRequest(fmt.Sprintf("actors/%s/%s", actorType, actorID))
-	req.WithHTTPExtension(nethttp.MethodDelete, "")
-	req.WithRawData(nil, invokev1.JSONContentType)
+func (a *actorsRuntime) deactivateActor(actorType, actorID, reason string) error {
+	var req *invokev1.InvokeMethodRequest
+	if a.config.LifecycleCallbacks {
+		b, err := json.Marshal(&DeactivationRequest{Reason: reason})
+		if err != nil {
+			return err
+		}
+		req = invokev1.NewInvokeMethodRequest(fmt.Sprintf("actors/%s/%s/deactivate", actorType, actorID))
+		req.WithHTTPExtension(nethttp.MethodPost, "")
+		req.WithRawData(b, invokev1.JSONContentType)
+	} else {
+		req = invokev1.NewInvokeMethodRequest(fmt.Sprintf("actors/%s/%s", actorType, actorID))
+		req.WithHTTPExtension(nethttp.MethodDelete, "")
+		req.WithRawData(nil, invokev1.JSONContentType)
+	}
 
 	// TODO Propagate context
 	ctx := context.Background()
@@ -190,7 +267,7 @@ func (a *actorsRuntime) deactivateActor(actorType, actorID string) error {
 	}
 
 	actorKey := a.constructCompositeKey(actorType, actorID)
-	a.actorsTable.Delete(actorKey)
+	a.removeActor(actorKey)
 	diag.DefaultMonitoring.ActorDeactivated(actorType)
 	return nil
 }
@@ -200,7 +277,8 @@ func (a *actorsRuntime) getActorTypeAndIDFromKey(key string) (string, string) {
 	return arr[0], arr[1]
 }
 
-func (a *actorsRuntime) startDeactivationTicker(interval, actorIdleTimeout time.Duration) {
+// startDeactivationTicker deactivates the idle actors of the actor types scanned at the given interval
+func (a *actorsRuntime) startDeactivationTicker(interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	go func() {
 		for t := range ticker.C {
@@ -211,11 +289,17 @@ func (a *actorsRuntime) startDeactivationTicker(interval, actorIdleTimeout time.
 					return true
 				}
 
+				actorType, _ := a.getActorTypeAndIDFromKey(key.(string))
+				entityConfig := a.config.entityConfig(actorType)
+				if entityConfig.ActorDeactivationScanInterval != interval {
+					return true
+				}
+
 				durationPassed := t.Sub(actorInstance.lastUsedTime)
-				if durationPassed >= actorIdleTimeout {
+				if durationPassed >= entityConfig.ActorIdleTimeout {
 					go func(actorKey string) {
 						actorType, actorID := a.getActorTypeAndIDFromKey(actorKey)
-						err := a.deactivateActor(actorType, actorID)
+						err := a.deactivateActor(actorType, actorID, DeactivationReasonIdle)
 						if err != nil {
 							log.Warnf("failed to deactivate actor %s: %s", actorKey, err)
 						}
@@ -229,16 +313,17 @@ func (a *actorsRuntime) startDeactivationTicker(interval, actorIdleTimeout time.
 }
 
 func (a *actorsRuntime) Call(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	// wait for the tables being updated, so that calls aren't sent to hosts which are draining the actor
+	if a.placementBlock {
+		<-a.placementSignal
+	}
+
 	actor := req.Actor()
 	targetActorAddress, appID := a.lookupActorAddress(actor.GetActorType(), actor.GetActorId())
 	if targetActorAddress == "" {
 		return nil, fmt.Errorf("error finding address for actor type %s with id %s", actor.GetActorType(), actor.GetActorId())
 	}
 
-	if a.placementBlock {
-		<-a.placementSignal
-	}
-
 	var resp *invokev1.InvokeMethodResponse
 	var err error
 
@@ -280,28 +365,66 @@ func (a *actorsRuntime) callRemoteActorWithRetry(
 }
 
 func (a *actorsRuntime) callLocalActor(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	release, err := a.acquireActorTurn(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	release()
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, respData := resp.RawData()
+
+	if resp.Status().Code != nethttp.StatusOK {
+		return nil, fmt.Errorf("error from actor service: %s", string(respData))
+	}
+
+	return resp, nil
+}
+
+// acquireActorTurn activates the actor of the request if needed, waits for its turn and
+// points the request to the actor method. The returned function ends the turn.
+func (a *actorsRuntime) acquireActorTurn(req *invokev1.InvokeMethodRequest) (func(), error) {
 	actorTypeID := req.Actor()
 	key := a.constructCompositeKey(actorTypeID.GetActorType(), actorTypeID.GetActorId())
 
+	if _, ok := a.actorsTable.Load(key); !ok && !a.isAppHealthy() {
+		return nil, fmt.Errorf("error activating actor type %s with id %s: the app is unhealthy", actorTypeID.GetActorType(), actorTypeID.GetActorId())
+	}
+
 	val, exists := a.actorsTable.LoadOrStore(key, &actor{
-		lock:         &sync.RWMutex{},
+		lock:         newActorLock(a.config.maxStackDepth()),
 		busy:         true,
 		lastUsedTime: time.Now().UTC(),
 		busyCh:       make(chan bool, 1),
 	})
 
+	if !exists {
+		a.actorActivated(actorTypeID.GetActorType())
+	}
+
 	act := val.(*actor)
-	lock := act.lock
-	lock.Lock()
-	defer lock.Unlock()
+	a.pendingCallsChanged(actorTypeID.GetActorType(), 1)
+	err := act.lock.Lock(a.getReentrancyID(req))
+	a.pendingCallsChanged(actorTypeID.GetActorType(), -1)
+	if err != nil {
+		return nil, err
+	}
+	// only the outermost call of a reentrant call chain marks the actor busy
+	outermost := act.lock.depth() == 1
 
 	if !exists {
 		err := a.tryActivateActor(actorTypeID.GetActorType(), actorTypeID.GetActorId())
 		if err != nil {
-			a.actorsTable.Delete(key)
+			a.removeActor(key)
+			act.lock.Unlock()
 			return nil, err
 		}
-	} else {
+	} else if outermost {
 		act.busy = true
 		act.busyCh = make(chan bool, 1)
 		act.lastUsedTime = time.Now().UTC()
@@ -315,24 +438,40 @@ func (a *actorsRuntime) callLocalActor(ctx context.Context, req *invokev1.Invoke
 	} else {
 		req.Message().HttpExtension.Verb = commonv1pb.HTTPExtension_PUT
 	}
-	resp, err := a.appChannel.InvokeMethod(ctx, req)
 
-	if act.busy {
-		act.busy = false
-		close(act.busyCh)
-	}
+	return func() {
+		if outermost && act.busy {
+			act.busy = false
+			close(act.busyCh)
+		}
+		act.lock.Unlock()
+	}, nil
+}
 
-	if err != nil {
-		return nil, err
+// getReentrancyID returns the reentrancy id of the call chain of the request. If reentrancy is enabled
+// and the request starts a new call chain, a new id is set in the request metadata so that the app
+// propagates it to the calls it makes while handling the request.
+func (a *actorsRuntime) getReentrancyID(req *invokev1.InvokeMethodRequest) string {
+	if !a.config.Reentrancy.Enabled {
+		return ""
 	}
 
-	_, respData := resp.RawData()
-
-	if resp.Status().Code != nethttp.StatusOK {
-		return nil, fmt.Errorf("error from actor service: %s", string(respData))
+	md := req.Metadata()
+	for k, v := range md {
+		if strings.EqualFold(k, reentrancyIDHeader) && len(v.GetValues()) > 0 {
+			return v.GetValues()[0].GetStringValue()
+		}
 	}
 
-	return resp, nil
+	id := uuid.New().String()
+	if md == nil {
+		req.WithMetadata(map[string][]string{reentrancyIDHeader: {id}})
+	} else {
+		md[reentrancyIDHeader] = &structpb.ListValue{
+			Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{StringValue: id}}},
+		}
+	}
+	return id
 }
 
 func (a *actorsRuntime) callRemoteActor(
@@ -364,7 +503,11 @@ func (a *actorsRuntime) callRemoteActor(
 
 func (a *actorsRuntime) tryActivateActor(actorType, actorID string) error {
 	// Send the activation signal to the app
-	req := invokev1.NewInvokeMethodRequest(fmt.Sprintf("actors/%s/%s", actorType, actorID))
+	method := fmt.Sprintf("actors/%s/%s", actorType, actorID)
+	if a.config.LifecycleCallbacks {
+		method += "/activate"
+	}
+	req := invokev1.NewInvokeMethodRequest(method)
 	req.WithHTTPExtension(nethttp.MethodPost, "")
 	req.WithRawData(nil, invokev1.JSONContentType)
 
@@ -378,7 +521,7 @@ func (a *actorsRuntime) tryActivateActor(actorType, actorID string) error {
 	if resp.Status().Code != nethttp.StatusOK {
 		diag.DefaultMonitoring.ActorActivationFailed(actorType, fmt.Sprintf("status_code_%d", resp.Status().Code))
 		key := a.constructCompositeKey(actorType, actorID)
-		a.actorsTable.Delete(key)
+		a.removeActor(key)
 		return fmt.Errorf("error activating actor type %s with id %s: %s", actorType, actorID, err)
 	}
 
@@ -389,7 +532,8 @@ func (a *actorsRuntime) tryActivateActor(actorType, actorID string) error {
 
 func (a *actorsRuntime) isActorLocal(targetActorAddress, hostAddress string, grpcPort int) bool {
 	return strings.Contains(targetActorAddress, "localhost") || strings.Contains(targetActorAddress, "127.0.0.1") ||
-		targetActorAddress == fmt.Sprintf("%s:%v", hostAddress, grpcPort)
+		strings.Contains(targetActorAddress, "[::1]") ||
+		targetActorAddress == net.JoinHostPort(hostAddress, strconv.Itoa(grpcPort))
 }
 
 func (a *actorsRuntime) GetState(ctx context.Context, req *GetStateRequest) (*StateResponse, error) {
@@ -491,101 +635,6 @@ func (a *actorsRuntime) constructActorStateKey(actorType, actorID, key string) s
 	return a.constructCompositeKey(a.config.AppID, actorType, actorID, key)
 }
 
-func (a *actorsRuntime) connectToPlacementService(placementAddress, hostAddress string, heartbeatInterval time.Duration) {
-	log.Infof("starting connection attempt to placement service at %s", placementAddress)
-	stream := a.getPlacementClientPersistently(placementAddress, hostAddress)
-	log.Infof("established connection to placement service at %s", placementAddress)
-
-	go func() {
-		for {
-			host := placementv1pb.Host{
-				Name:     hostAddress,
-				Load:     1,
-				Entities: a.config.HostedActorTypes,
-				Port:     int64(a.config.Port),
-				Id:       a.config.AppID,
-			}
-
-			if stream != nil {
-				if !a.appHealthy {
-					// app is unresponsive, close the stream and disconnect from the placement service
-					err := stream.CloseSend()
-					if err != nil {
-						log.Errorf("error closing stream to placement service: %s", err)
-					}
-					continue
-				}
-
-				if err := stream.Send(&host); err != nil {
-					diag.DefaultMonitoring.ActorStatusReportFailed("send", "status")
-					log.Warnf("failed to report status to placement service : %v", err)
-					stream = a.getPlacementClientPersistently(placementAddress, hostAddress)
-				}
-			}
-			time.Sleep(heartbeatInterval)
-		}
-	}()
-
-	go func() {
-		for {
-			resp, err := stream.Recv()
-			if err != nil {
-				diag.DefaultMonitoring.ActorStatusReportFailed("recv", "status")
-				log.Warnf("failed to receive the response of status report from placement service: %v", err)
-				stream = a.getPlacementClientPersistently(placementAddress, hostAddress)
-			} else {
-				diag.DefaultMonitoring.ActorStatusReported("recv")
-			}
-			if resp != nil {
-				a.onPlacementOrder(resp)
-			}
-		}
-	}()
-}
-
-func (a *actorsRuntime) getPlacementClientPersistently(placementAddress, hostAddress string) placementv1pb.PlacementService_ReportDaprStatusClient {
-	for {
-		retryInterval := time.Millisecond * 250
-
-		opts, err := dapr_credentials.GetClientOptions(a.certChain, security.TLSServerName)
-		if err != nil {
-			log.Errorf("failed to establish TLS credentials for actor placement service: %s", err)
-			return nil
-		}
-
-		if diag.DefaultGRPCMonitoring.IsEnabled() {
-			opts = append(
-				opts,
-				grpc.WithUnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryClientInterceptor()))
-		}
-
-		conn, err := grpc.Dial(
-			placementAddress,
-			opts...,
-		)
-		if err != nil {
-			log.Warnf("error connecting to placement service: %v", err)
-			diag.DefaultMonitoring.ActorStatusReportFailed("dial", "placement")
-			time.Sleep(retryInterval)
-			conn.Close()
-			continue
-		}
-
-		header := metadata.New(map[string]string{idHeader: hostAddress})
-		ctx := metadata.NewOutgoingContext(context.Background(), header)
-		client := placementv1pb.NewPlacementServiceClient(conn)
-		stream, err := client.ReportDaprStatus(ctx)
-		if err != nil {
-			log.Warnf("error establishing client to placement service: %v", err)
-			diag.DefaultMonitoring.ActorStatusReportFailed("establish", "status")
-			time.Sleep(retryInterval)
-			conn.Close()
-			continue
-		}
-		return stream
-	}
-}
-
 func (a *actorsRuntime) onPlacementOrder(in *placementv1pb.PlacementOrder) {
 	log.Infof("placement order received: %s", in.Operation)
 	diag.DefaultMonitoring.ActorPlacementTableOperationReceived(in.Operation)
@@ -600,7 +649,7 @@ func (a *actorsRuntime) onPlacementOrder(in *placementv1pb.PlacementOrder) {
 			a.blockPlacements()
 
 			go func() {
-				time.Sleep(time.Second * 5)
+				time.Sleep(a.config.placementLockTimeout())
 				a.unblockPlacements()
 			}()
 		}
@@ -611,6 +660,7 @@ func (a *actorsRuntime) onPlacementOrder(in *placementv1pb.PlacementOrder) {
 	case updateOperation:
 		{
 			a.updatePlacements(in.Tables)
+			a.reportDrained(in.Tables.GetVersion())
 		}
 	}
 }
@@ -679,7 +729,7 @@ func (a *actorsRuntime) drainRebalancedActors() {
 					// wait until actor isn't busy or timeout hits
 					if actor.busy {
 						select {
-						case <-time.After(a.config.DrainOngoingCallTimeout):
+						case <-time.After(a.config.entityConfig(actorType).DrainOngoingCallTimeout):
 							break
 						case <-actor.busyCh:
 							// if a call comes in from the actor for state changes, that's still allowed
@@ -689,14 +739,14 @@ func (a *actorsRuntime) drainRebalancedActors() {
 				}
 
 				// don't allow state changes
-				a.actorsTable.Delete(key)
+				a.removeActor(actorKey)
 
 				diag.DefaultMonitoring.ActorRebalanced(actorType)
 
 				for {
 					// wait until actor is not busy, then deactivate
 					if !actor.busy {
-						err := a.deactivateActor(actorType, actorID)
+						err := a.deactivateActor(actorType, actorID, DeactivationReasonRebalance)
 						if err != nil {
 							log.Warnf("failed to deactivate actor %s: %s", actorKey, err)
 						}
@@ -708,6 +758,48 @@ func (a *actorsRuntime) drainRebalancedActors() {
 		}(key, value, &wg)
 		return true
 	})
+	wg.Wait()
+}
+
+// reportDrained tells the placement service that the actors which moved to other hosts in the tables version
+// are deactivated, so that it can let their new hosts activate them
+func (a *actorsRuntime) reportDrained(version string) {
+	err := a.placement.ReportDrained(a.placementHost(), version)
+	if err != nil {
+		log.Warnf("error reporting drained actors to placement service: %s", err)
+	}
+}
+
+// placementHost returns the host reported to the placement service
+func (a *actorsRuntime) placementHost() *placementv1pb.Host {
+	return &placementv1pb.Host{
+		Name:     a.config.HostAddress,
+		Load:     1,
+		Entities: a.placementEntities(),
+		Port:     int64(a.config.Port),
+		Id:       a.config.AppID,
+	}
+}
+
+// placementEntities returns the actor types hosted by the app, as reported to the placement service
+func (a *actorsRuntime) placementEntities() []string {
+	if a.config.Namespace == "" {
+		return a.config.HostedActorTypes
+	}
+	entities := make([]string, 0, len(a.config.HostedActorTypes))
+	for _, actorType := range a.config.HostedActorTypes {
+		entities = append(entities, a.placementEntity(actorType))
+	}
+	return entities
+}
+
+// placementEntity returns the entry of an actor type in the placement tables, which is scoped to the namespace of
+// the app when namespaces are isolated
+func (a *actorsRuntime) placementEntity(actorType string) string {
+	if a.config.Namespace == "" {
+		return actorType
+	}
+	return a.config.Namespace + "/" + actorType
 }
 
 func (a *actorsRuntime) evaluateReminders() {
@@ -719,6 +811,10 @@ func (a *actorsRuntime) evaluateReminders() {
 
 	var wg sync.WaitGroup
 	for _, t := range a.config.HostedActorTypes {
+		// move the reminders to the configured partitions before they are loaded
+		if _, err := a.getActorTypeMetadata(t, true); err != nil {
+			log.Warnf("error migrating reminders of actor type %s: %s", t, err)
+		}
 		vals, err := a.getRemindersForActorType(t)
 		if err != nil {
 			log.Debugf("error getting reminders for actor type %s: %s", t, err)
@@ -727,6 +823,8 @@ func (a *actorsRuntime) evaluateReminders() {
 			a.reminders[t] = vals
 			a.remindersLock.Unlock()
 
+			a.startDurableTimers(t)
+
 			wg.Add(1)
 			go func(wg *sync.WaitGroup, reminders []Reminder) {
 				defer wg.Done()
@@ -763,7 +861,7 @@ func (a *actorsRuntime) lookupActorAddress(actorType, actorID string) (string, s
 	a.placementTableLock.RLock()
 	defer a.placementTableLock.RUnlock()
 
-	t := a.placementTables.Entries[actorType]
+	t := a.placementTables.Entries[a.placementEntity(actorType)]
 	if t == nil {
 		return "", ""
 	}
@@ -771,7 +869,7 @@ func (a *actorsRuntime) lookupActorAddress(actorType, actorID string) (string, s
 	if err != nil || host == nil {
 		return "", ""
 	}
-	return fmt.Sprintf("%s:%v", host.Name, host.Port), host.AppID
+	return net.JoinHostPort(host.Name, strconv.FormatInt(host.Port, 10)), host.AppID
 }
 
 func (a *actorsRuntime) getReminderTrack(actorKey, name string) (*ReminderTrack, error) {
@@ -855,14 +953,19 @@ func (a *actorsRuntime) startReminder(reminder *Reminder) error {
 	if err != nil {
 		return err
 	}
+	policy := a.config.entityConfig(reminder.ActorType).ReminderCatchUpPolicy
+	nextInvokeTime, firings := getReminderCatchUp(reminder, policy, nextInvokeTime, time.Now().UTC())
 
 	go func() {
 		now := time.Now().UTC()
 		initialDuration := nextInvokeTime.Sub(now)
 		time.Sleep(initialDuration)
-		err = a.executeReminder(reminder.ActorType, reminder.ActorID, reminder.DueTime, reminder.Period, reminder.Name, reminder.Data)
-		if err != nil {
-			log.Errorf("error executing reminder: %s", err)
+		diag.DefaultMonitoring.ActorReminderFired(reminder.ActorType, time.Since(nextInvokeTime))
+		for i := 0; i < firings; i++ {
+			err = a.executeReminder(reminder.ActorType, reminder.ActorID, reminder.DueTime, reminder.Period, reminder.Name, reminder.Data)
+			if err != nil {
+				log.Errorf("error executing reminder: %s", err)
+			}
 		}
 
 		if reminder.Period != "" {
@@ -878,7 +981,8 @@ func (a *actorsRuntime) startReminder(reminder *Reminder) error {
 			go func(ticker *time.Ticker, stop chan (bool), actorType, actorID, reminder, dueTime, period string, data interface{}) {
 				for {
 					select {
-					case <-ticker.C:
+					case tick := <-ticker.C:
+						diag.DefaultMonitoring.ActorReminderFired(actorType, time.Since(tick))
 						err := a.executeReminder(actorType, actorID, dueTime, period, reminder, data)
 						if err != nil {
 							log.Debugf("error invoking reminder on actor %s: %s", a.constructCompositeKey(actorType, actorID), err)
@@ -903,6 +1007,28 @@ func (a *actorsRuntime) startReminder(reminder *Reminder) error {
 	return nil
 }
 
+// getReminderCatchUp returns when an overdue reminder fires next and how many times, following the catch up policy.
+// Reminders without period fire once.
+func getReminderCatchUp(reminder *Reminder, policy string, nextInvokeTime, now time.Time) (time.Time, int) {
+	if reminder.Period == "" || !nextInvokeTime.Before(now) {
+		return nextInvokeTime, 1
+	}
+	period, err := time.ParseDuration(reminder.Period)
+	if err != nil || period <= 0 {
+		return nextInvokeTime, 1
+	}
+
+	missed := int(now.Sub(nextInvokeTime)/period) + 1
+	switch policy {
+	case ReminderCatchUpFireAll:
+		return nextInvokeTime, missed
+	case ReminderCatchUpSkip:
+		return nextInvokeTime.Add(time.Duration(missed) * period), 1
+	default:
+		return nextInvokeTime, 1
+	}
+}
+
 func (a *actorsRuntime) executeReminder(actorType, actorID, dueTime, period, reminder string, data interface{}) error {
 	r := ReminderResponse{
 		DueTime: dueTime,
@@ -919,7 +1045,9 @@ func (a *actorsRuntime) executeReminder(actorType, actorID, dueTime, period, rem
 	req.WithActor(actorType, actorID)
 	req.WithRawData(b, invokev1.JSONContentType)
 
+	release := a.firings.acquire(actorType)
 	_, err = a.callLocalActor(context.Background(), req)
+	release()
 	if err == nil {
 		key := a.constructCompositeKey(actorType, actorID)
 		a.updateReminderTrack(key, reminder)
@@ -988,7 +1116,12 @@ func (a *actorsRuntime) CreateReminder(ctx context.Context, req *CreateReminderR
 		RegisteredTime: time.Now().UTC().Format(time.RFC3339),
 	}
 
-	reminders, err := a.getRemindersForActorType(req.ActorType)
+	metadata, err := a.getActorTypeMetadata(req.ActorType, true)
+	if err != nil {
+		return err
+	}
+	key := a.remindersKey(req.ActorType, metadata, metadata.partition(req.ActorID))
+	reminders, err := a.getRemindersPartition(key)
 	if err != nil {
 		return err
 	}
@@ -996,7 +1129,7 @@ func (a *actorsRuntime) CreateReminder(ctx context.Context, req *CreateReminderR
 	reminders = append(reminders, reminder)
 
 	err = a.store.Set(&state.SetRequest{
-		Key:   a.constructCompositeKey("actors", req.ActorType),
+		Key:   key,
 		Value: reminders,
 	})
 	if err != nil {
@@ -1004,7 +1137,7 @@ func (a *actorsRuntime) CreateReminder(ctx context.Context, req *CreateReminderR
 	}
 
 	a.remindersLock.Lock()
-	a.reminders[req.ActorType] = reminders
+	a.reminders[req.ActorType] = append(removeReminder(a.reminders[req.ActorType], req.ActorID, req.Name), reminder)
 	a.remindersLock.Unlock()
 
 	err = a.startReminder(&reminder)
@@ -1016,13 +1149,30 @@ func (a *actorsRuntime) CreateReminder(ctx context.Context, req *CreateReminderR
 
 func (a *actorsRuntime) CreateTimer(ctx context.Context, req *CreateTimerRequest) error {
 	actorKey := a.constructCompositeKey(req.ActorType, req.ActorID)
-	timerKey := a.constructCompositeKey(actorKey, req.Name)
 
 	_, exists := a.actorsTable.Load(actorKey)
 	if !exists {
 		return fmt.Errorf("can't create timer for actor %s: actor not activated", actorKey)
 	}
 
+	if _, err := time.ParseDuration(req.Period); err != nil {
+		return err
+	}
+
+	if req.Durable {
+		if err := a.saveDurableTimer(*req); err != nil {
+			return err
+		}
+	}
+	return a.startTimer(req)
+}
+
+// startTimer fires the timer until it is deleted. Timers stop once their actor is deactivated,
+// except durable timers which keep firing, and activating their actor, while it is hosted locally.
+func (a *actorsRuntime) startTimer(req *CreateTimerRequest) error {
+	actorKey := a.constructCompositeKey(req.ActorType, req.ActorID)
+	timerKey := a.constructCompositeKey(actorKey, req.Name)
+
 	stopChan, exists := a.activeTimers.Load(timerKey)
 	if exists {
 		close(stopChan.(chan bool))
@@ -1037,7 +1187,7 @@ func (a *actorsRuntime) CreateTimer(ctx context.Context, req *CreateTimerRequest
 	stop := make(chan bool, 1)
 	a.activeTimers.Store(timerKey, stop)
 
-	go func(ticker *time.Ticker, stop chan (bool), actorType, actorID, name, dueTime, period, callback string, data interface{}) {
+	go func(ticker *time.Ticker, stop chan (bool), actorType, actorID, name, dueTime, period, callback string, data interface{}, durable bool) {
 		if dueTime != "" {
 			d, err := time.ParseDuration(dueTime)
 			if err == nil {
@@ -1051,13 +1201,17 @@ func (a *actorsRuntime) CreateTimer(ctx context.Context, req *CreateTimerRequest
 			select {
 			case <-ticker.C:
 				_, exists := a.actorsTable.Load(actorKey)
-				if exists {
+				if exists || durable && a.isActorHostedLocally(actorType, actorID) {
 					err := a.executeTimer(actorType, actorID, name, dueTime, period, callback, data)
 					if err != nil {
 						log.Debugf("error invoking timer on actor %s: %s", actorKey, err)
 					}
+				} else if durable {
+					// the actor moved to another app instance, which starts the timer
+					a.stopTimer(timerKey, stop)
+					return
 				} else {
-					a.DeleteTimer(ctx, &DeleteTimerRequest{
+					a.DeleteTimer(context.Background(), &DeleteTimerRequest{
 						Name:      name,
 						ActorID:   actorID,
 						ActorType: actorType,
@@ -1067,10 +1221,17 @@ func (a *actorsRuntime) CreateTimer(ctx context.Context, req *CreateTimerRequest
 				return
 			}
 		}
-	}(t, stop, req.ActorType, req.ActorID, req.Name, req.DueTime, req.Period, req.Callback, req.Data)
+	}(t, stop, req.ActorType, req.ActorID, req.Name, req.DueTime, req.Period, req.Callback, req.Data, req.Durable)
 	return nil
 }
 
+// stopTimer removes the timer from the active timers of this app instance unless it was replaced
+func (a *actorsRuntime) stopTimer(timerKey string, stop chan bool) {
+	if stopChan, exists := a.activeTimers.Load(timerKey); exists && stopChan.(chan bool) == stop {
+		a.activeTimers.Delete(timerKey)
+	}
+}
+
 func (a *actorsRuntime) configureTicker(d time.Duration) *time.Ticker {
 	if d == 0 {
 		// NewTicker cannot take in 0.  The ticker is not exact anyways since it fires
@@ -1098,7 +1259,9 @@ func (a *actorsRuntime) executeTimer(actorType, actorID, name, dueTime, period,
 	req := invokev1.NewInvokeMethodRequest(fmt.Sprintf("timer/%s", name))
 	req.WithActor(actorType, actorID)
 	req.WithRawData(b, invokev1.JSONContentType)
+	release := a.firings.acquire(actorType)
 	_, err = a.callLocalActor(context.Background(), req)
+	release()
 	if err != nil {
 		log.Debugf("error execution of timer %s for actor type %s with id %s: %s", name, actorType, actorID, err)
 	}
@@ -1106,17 +1269,11 @@ func (a *actorsRuntime) executeTimer(actorType, actorID, name, dueTime, period,
 }
 
 func (a *actorsRuntime) getRemindersForActorType(actorType string) ([]Reminder, error) {
-	key := a.constructCompositeKey("actors", actorType)
-	resp, err := a.store.Get(&state.GetRequest{
-		Key: key,
-	})
+	metadata, err := a.getActorTypeMetadata(actorType, false)
 	if err != nil {
 		return nil, err
 	}
-
-	var reminders []Reminder
-	json.Unmarshal(resp.Data, &reminders)
-	return reminders, nil
+	return a.getRemindersForLayout(actorType, metadata)
 }
 
 func (a *actorsRuntime) DeleteReminder(ctx context.Context, req *DeleteReminderRequest) error {
@@ -1129,7 +1286,6 @@ func (a *actorsRuntime) DeleteReminder(ctx context.Context, req *DeleteReminderR
 		}
 	}
 
-	key := a.constructCompositeKey("actors", req.ActorType)
 	actorKey := a.constructCompositeKey(req.ActorType, req.ActorID)
 	reminderKey := a.constructCompositeKey(actorKey, req.Name)
 
@@ -1139,27 +1295,26 @@ func (a *actorsRuntime) DeleteReminder(ctx context.Context, req *DeleteReminderR
 		a.activeReminders.Delete(reminderKey)
 	}
 
-	reminders, err := a.getRemindersForActorType(req.ActorType)
+	metadata, err := a.getActorTypeMetadata(req.ActorType, true)
 	if err != nil {
 		return err
 	}
-
-	for i := len(reminders) - 1; i >= 0; i-- {
-		if reminders[i].ActorType == req.ActorType && reminders[i].ActorID == req.ActorID && reminders[i].Name == req.Name {
-			reminders = append(reminders[:i], reminders[i+1:]...)
-		}
+	key := a.remindersKey(req.ActorType, metadata, metadata.partition(req.ActorID))
+	reminders, err := a.getRemindersPartition(key)
+	if err != nil {
+		return err
 	}
 
 	err = a.store.Set(&state.SetRequest{
 		Key:   key,
-		Value: reminders,
+		Value: removeReminder(reminders, req.ActorID, req.Name),
 	})
 	if err != nil {
 		return err
 	}
 
 	a.remindersLock.Lock()
-	a.reminders[req.ActorType] = reminders
+	a.reminders[req.ActorType] = removeReminder(a.reminders[req.ActorType], req.ActorID, req.Name)
 	a.remindersLock.Unlock()
 
 	err = a.store.Delete(&state.DeleteRequest{
@@ -1172,6 +1327,17 @@ func (a *actorsRuntime) DeleteReminder(ctx context.Context, req *DeleteReminderR
 	return nil
 }
 
+// removeReminder returns the reminders without the reminder of the actor with the given name
+func removeReminder(reminders []Reminder, actorID, name string) []Reminder {
+	kept := make([]Reminder, 0, len(reminders))
+	for _, r := range reminders {
+		if r.ActorID != actorID || r.Name != name {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
 func (a *actorsRuntime) GetReminder(ctx context.Context, req *GetReminderRequest) (*Reminder, error) {
 	reminders, err := a.getRemindersForActorType(req.ActorType)
 	if err != nil {
@@ -1190,6 +1356,43 @@ func (a *actorsRuntime) GetReminder(ctx context.Context, req *GetReminderRequest
 	return nil, nil
 }
 
+// ListReminders returns the reminders ordered by actor ID and name. The continuation token of a page is
+// the key of its last reminder, so reminders created or deleted between the pages don't shift the next page.
+func (a *actorsRuntime) ListReminders(ctx context.Context, req *ListRemindersRequest) (*ListRemindersResponse, error) {
+	reminders, err := a.getRemindersForActorType(req.ActorType)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]Reminder{}
+	for _, r := range reminders {
+		if req.ActorID != "" && r.ActorID != req.ActorID {
+			continue
+		}
+		key := a.constructCompositeKey(r.ActorID, r.Name)
+		if req.ContinuationToken != "" && key <= req.ContinuationToken {
+			continue
+		}
+		keys[key] = r
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	resp := &ListRemindersResponse{Reminders: []Reminder{}}
+	for i, key := range sorted {
+		if req.Limit > 0 && i == req.Limit {
+			resp.ContinuationToken = sorted[i-1]
+			break
+		}
+		resp.Reminders = append(resp.Reminders, keys[key])
+	}
+	return resp, nil
+}
+
 func (a *actorsRuntime) DeleteTimer(ctx context.Context, req *DeleteTimerRequest) error {
 	actorKey := a.constructCompositeKey(req.ActorType, req.ActorID)
 	timerKey := a.constructCompositeKey(actorKey, req.Name)
@@ -1200,11 +1403,36 @@ func (a *actorsRuntime) DeleteTimer(ctx context.Context, req *DeleteTimerRequest
 		a.activeTimers.Delete(timerKey)
 	}
 
-	return nil
+	return a.deleteDurableTimer(req.ActorType, req.ActorID, req.Name)
+}
+
+func (a *actorsRuntime) Stop() {
+	if a.placement != nil {
+		a.placement.Stop()
+	}
+
+	var wg sync.WaitGroup
+	a.actorsTable.Range(func(key, value interface{}) bool {
+		wg.Add(1)
+		go func(actorKey string) {
+			defer wg.Done()
+			actorType, actorID := a.getActorTypeAndIDFromKey(actorKey)
+			err := a.deactivateActor(actorType, actorID, DeactivationReasonShutdown)
+			if err != nil {
+				log.Warnf("failed to deactivate actor %s: %s", actorKey, err)
+			}
+		}(key.(string))
+		return true
+	})
+	wg.Wait()
 }
 
 func (a *actorsRuntime) GetActiveActorsCount(ctx context.Context) []ActiveActorsCount {
 	var actorCountMap = map[string]int{}
+	// the actor types hosted by the app are listed even without active actors
+	for _, t := range a.config.HostedActorTypes {
+		actorCountMap[t] = 0
+	}
 	a.actorsTable.Range(func(key, value interface{}) bool {
 		actorType, _ := a.getActorTypeAndIDFromKey(key.(string))
 		actorCountMap[actorType]++
@@ -1216,6 +1444,7 @@ func (a *actorsRuntime) GetActiveActorsCount(ctx context.Context) []ActiveActors
 	for actorType, count := range actorCountMap {
 		activeActorsCount = append(activeActorsCount, ActiveActorsCount{Type: actorType, Count: count})
 	}
+	sort.Slice(activeActorsCount, func(i, j int) bool { return activeActorsCount[i].Type < activeActorsCount[j].Type })
 
 	return activeActorsCount
 }
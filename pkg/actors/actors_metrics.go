@@ -0,0 +1,52 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+// actorsCounts keeps the per actor type counts reported as gauges
+type actorsCounts struct {
+	activeActors map[string]int64
+	pendingCalls map[string]int64
+}
+
+func newActorsCounts() *actorsCounts {
+	return &actorsCounts{
+		activeActors: map[string]int64{},
+		pendingCalls: map[string]int64{},
+	}
+}
+
+// actorActivated counts an actor added to the actors table
+func (a *actorsRuntime) actorActivated(actorType string) {
+	a.countsLock.Lock()
+	defer a.countsLock.Unlock()
+	a.counts.activeActors[actorType]++
+	diag.DefaultMonitoring.ActorActiveCount(actorType, a.counts.activeActors[actorType])
+}
+
+// removeActor removes an actor from the actors table, an actor removed twice is counted once
+func (a *actorsRuntime) removeActor(actorKey string) {
+	a.countsLock.Lock()
+	defer a.countsLock.Unlock()
+	if _, exists := a.actorsTable.Load(actorKey); !exists {
+		return
+	}
+	a.actorsTable.Delete(actorKey)
+	actorType, _ := a.getActorTypeAndIDFromKey(actorKey)
+	a.counts.activeActors[actorType]--
+	diag.DefaultMonitoring.ActorActiveCount(actorType, a.counts.activeActors[actorType])
+}
+
+// pendingCallsChanged adds delta to the number of calls waiting for the turn lock of the actors of the type
+func (a *actorsRuntime) pendingCallsChanged(actorType string, delta int64) {
+	a.countsLock.Lock()
+	defer a.countsLock.Unlock()
+	a.counts.pendingCalls[actorType] += delta
+	diag.DefaultMonitoring.ActorPendingCalls(actorType, a.counts.pendingCalls[actorType])
+}
@@ -0,0 +1,38 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"context"
+	"testing"
+
+	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestActorsCounts(t *testing.T) {
+	appChannel := new(channelt.MockAppChannel)
+	appChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil)
+	c := NewConfig(NewConfigOpts{AppID: TestAppID})
+	a := NewActors(fakeStore(), appChannel, nil, c, nil, config.TracingSpec{}, nil).(*actorsRuntime)
+
+	for _, id := range []string{"1", "2", "1"} {
+		req := invokev1.NewInvokeMethodRequest("method").WithActor("cat", id)
+		_, err := a.callLocalActor(context.Background(), req)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int64(2), a.counts.activeActors["cat"])
+	assert.Equal(t, int64(0), a.counts.pendingCalls["cat"])
+
+	// an actor removed by the rebalancing and by its deactivation is counted once
+	actorKey := a.constructCompositeKey("cat", "1")
+	a.removeActor(actorKey)
+	assert.NoError(t, a.deactivateActor("cat", "1", DeactivationReasonRebalance))
+	assert.Equal(t, int64(1), a.counts.activeActors["cat"])
+}
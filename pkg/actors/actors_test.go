@@ -8,6 +8,7 @@ package actors
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 	"sync"
@@ -19,6 +20,7 @@ import (
 	"github.com/dapr/dapr/pkg/config"
 	"github.com/dapr/dapr/pkg/health"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -83,8 +85,8 @@ func newTestActorsRuntime() *actorsRuntime {
 		mock.AnythingOfType("*v1.InvokeMethodRequest")).Return(fakeResp, nil)
 
 	store := fakeStore()
-	config := NewConfig("", TestAppID, "", nil, 0, "", "", "", false)
-	a := NewActors(store, mockAppChannel, nil, config, nil, spec)
+	config := NewConfig(NewConfigOpts{AppID: TestAppID})
+	a := NewActors(store, mockAppChannel, nil, config, nil, spec, nil)
 
 	return a.(*actorsRuntime)
 }
@@ -103,7 +105,7 @@ func fakeStore() state.Store {
 func fakeCallAndActivateActor(actors *actorsRuntime, actorKey string) {
 	actors.actorsTable.LoadOrStore(actorKey, &actor{
 		lastUsedTime: time.Now().UTC(),
-		lock:         &sync.RWMutex{},
+		lock:         newActorLock(actors.config.maxStackDepth()),
 		busy:         false,
 		busyCh:       make(chan bool, 1),
 	})
@@ -111,8 +113,9 @@ func fakeCallAndActivateActor(actors *actorsRuntime, actorKey string) {
 
 func deactivateActorWithDuration(testActorsRuntime *actorsRuntime, actorKey string, actorIdleTimeout time.Duration) {
 	fakeCallAndActivateActor(testActorsRuntime, actorKey)
-	scanInterval := time.Second * 1
-	testActorsRuntime.startDeactivationTicker(scanInterval, actorIdleTimeout)
+	testActorsRuntime.config.ActorDeactivationScanInterval = time.Second * 1
+	testActorsRuntime.config.ActorIdleTimeout = actorIdleTimeout
+	testActorsRuntime.startDeactivationTicker(testActorsRuntime.config.ActorDeactivationScanInterval)
 }
 
 func createReminderData(actorID, actorType, name, period, dueTime, data string) CreateReminderRequest {
@@ -166,6 +169,85 @@ func TestActorIsNotDeactivated(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestActorTypeIdleTimeout(t *testing.T) {
+	appChannel := new(channelt.MockAppChannel)
+	appChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil)
+	c := NewConfig(NewConfigOpts{
+		AppID:             TestAppID,
+		ActorScanInterval: "1h",
+		ActorIdleTimeout:  "1h",
+		EntitiesConfig: map[string]config.EntityConfig{
+			"dog": {ActorIdleTimeout: "10ms", ActorScanInterval: "10ms"},
+		},
+	})
+	a := NewActors(fakeStore(), appChannel, nil, c, nil, config.TracingSpec{}, nil).(*actorsRuntime)
+	catKey := a.constructCompositeKey("cat", "1")
+	dogKey := a.constructCompositeKey("dog", "1")
+	fakeCallAndActivateActor(a, catKey)
+	fakeCallAndActivateActor(a, dogKey)
+
+	for _, interval := range a.config.deactivationScanIntervals() {
+		a.startDeactivationTicker(interval)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	_, exists := a.actorsTable.Load(dogKey)
+	assert.False(t, exists)
+	_, exists = a.actorsTable.Load(catKey)
+	assert.True(t, exists)
+}
+
+func TestActorLifecycleCallbacks(t *testing.T) {
+	newLifecycleActors := func(callbacks bool) (*actorsRuntime, *[]*invokev1.InvokeMethodRequest) {
+		var requests []*invokev1.InvokeMethodRequest
+		appChannel := new(channelt.MockAppChannel)
+		appChannel.On("InvokeMethod", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			requests = append(requests, args.Get(1).(*invokev1.InvokeMethodRequest))
+		}).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil)
+		c := NewConfig(NewConfigOpts{AppID: TestAppID, LifecycleCallbacks: callbacks})
+		return NewActors(fakeStore(), appChannel, nil, c, nil, config.TracingSpec{}, nil).(*actorsRuntime), &requests
+	}
+	actorType, actorID := getTestActorTypeAndID()
+
+	t.Run("activate and deactivate endpoints are invoked with the reason", func(t *testing.T) {
+		a, requests := newLifecycleActors(true)
+		assert.NoError(t, a.tryActivateActor(actorType, actorID))
+		assert.NoError(t, a.deactivateActor(actorType, actorID, DeactivationReasonIdle))
+
+		assert.Len(t, *requests, 2)
+		assert.Equal(t, fmt.Sprintf("actors/%s/%s/activate", actorType, actorID), (*requests)[0].Message().Method)
+		assert.Equal(t, commonv1pb.HTTPExtension_POST, (*requests)[0].Message().HttpExtension.Verb)
+		assert.Equal(t, fmt.Sprintf("actors/%s/%s/deactivate", actorType, actorID), (*requests)[1].Message().Method)
+		assert.Equal(t, commonv1pb.HTTPExtension_POST, (*requests)[1].Message().HttpExtension.Verb)
+		_, body := (*requests)[1].RawData()
+		assert.JSONEq(t, `{"reason":"idle"}`, string(body))
+	})
+
+	t.Run("legacy endpoints are invoked without callbacks", func(t *testing.T) {
+		a, requests := newLifecycleActors(false)
+		assert.NoError(t, a.tryActivateActor(actorType, actorID))
+		assert.NoError(t, a.deactivateActor(actorType, actorID, DeactivationReasonIdle))
+
+		assert.Len(t, *requests, 2)
+		assert.Equal(t, fmt.Sprintf("actors/%s/%s", actorType, actorID), (*requests)[0].Message().Method)
+		assert.Equal(t, fmt.Sprintf("actors/%s/%s", actorType, actorID), (*requests)[1].Message().Method)
+		assert.Equal(t, commonv1pb.HTTPExtension_DELETE, (*requests)[1].Message().HttpExtension.Verb)
+	})
+
+	t.Run("stop deactivates the active actors", func(t *testing.T) {
+		a, requests := newLifecycleActors(true)
+		actorKey := a.constructCompositeKey(actorType, actorID)
+		fakeCallAndActivateActor(a, actorKey)
+
+		a.Stop()
+		_, exists := a.actorsTable.Load(actorKey)
+		assert.False(t, exists)
+		assert.Len(t, *requests, 1)
+		_, body := (*requests)[0].RawData()
+		assert.JSONEq(t, `{"reason":"shutdown"}`, string(body))
+	})
+}
+
 func TestTimerExecution(t *testing.T) {
 	testActorsRuntime := newTestActorsRuntime()
 	actorType, actorID := getTestActorTypeAndID()
@@ -324,6 +406,54 @@ func TestGetReminder(t *testing.T) {
 	assert.Equal(t, r.DueTime, "1s")
 }
 
+func TestListReminders(t *testing.T) {
+	testActorsRuntime := newTestActorsRuntime()
+	actorType, actorID := getTestActorTypeAndID()
+	ctx := context.Background()
+	reminders := []CreateReminderRequest{
+		createReminderData(actorID, actorType, "reminder2", "1h", "1h", "a"),
+		createReminderData(actorID, actorType, "reminder1", "1h", "1h", "a"),
+		createReminderData("otherActor", actorType, "reminder1", "1h", "1h", "a"),
+	}
+	for i := range reminders {
+		assert.NoError(t, testActorsRuntime.CreateReminder(ctx, &reminders[i]))
+	}
+
+	t.Run("reminders of an actor", func(t *testing.T) {
+		resp, err := testActorsRuntime.ListReminders(ctx, &ListRemindersRequest{
+			ActorType: actorType,
+			ActorID:   actorID,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Reminders, 2)
+		assert.Equal(t, "reminder1", resp.Reminders[0].Name)
+		assert.Equal(t, "reminder2", resp.Reminders[1].Name)
+		assert.Empty(t, resp.ContinuationToken)
+	})
+
+	t.Run("reminders of an actor type by page", func(t *testing.T) {
+		req := &ListRemindersRequest{ActorType: actorType, Limit: 2}
+		resp, err := testActorsRuntime.ListReminders(ctx, req)
+		assert.NoError(t, err)
+		assert.Len(t, resp.Reminders, 2)
+		assert.Equal(t, actorID, resp.Reminders[0].ActorID)
+		assert.NotEmpty(t, resp.ContinuationToken)
+
+		req.ContinuationToken = resp.ContinuationToken
+		resp, err = testActorsRuntime.ListReminders(ctx, req)
+		assert.NoError(t, err)
+		assert.Len(t, resp.Reminders, 1)
+		assert.Equal(t, "otherActor", resp.Reminders[0].ActorID)
+		assert.Empty(t, resp.ContinuationToken)
+	})
+
+	t.Run("no reminders", func(t *testing.T) {
+		resp, err := testActorsRuntime.ListReminders(ctx, &ListRemindersRequest{ActorType: "unknown"})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Reminders)
+	})
+}
+
 func TestDeleteTimer(t *testing.T) {
 	testActorsRuntime := newTestActorsRuntime()
 	actorType, actorID := getTestActorTypeAndID()
@@ -410,6 +540,41 @@ func TestReminderPeriod(t *testing.T) {
 	assert.NotEqual(t, track.LastFiredTime, track2.LastFiredTime)
 }
 
+func TestReminderCatchUp(t *testing.T) {
+	now := time.Now().UTC()
+	overdue := now.Add(-25 * time.Minute)
+	reminder := &Reminder{Period: "10m"}
+
+	t.Run("fire once", func(t *testing.T) {
+		next, firings := getReminderCatchUp(reminder, ReminderCatchUpFireOnce, overdue, now)
+		assert.Equal(t, overdue, next)
+		assert.Equal(t, 1, firings)
+	})
+
+	t.Run("fire all", func(t *testing.T) {
+		next, firings := getReminderCatchUp(reminder, ReminderCatchUpFireAll, overdue, now)
+		assert.Equal(t, overdue, next)
+		assert.Equal(t, 3, firings)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		next, firings := getReminderCatchUp(reminder, ReminderCatchUpSkip, overdue, now)
+		assert.Equal(t, now.Add(5*time.Minute), next)
+		assert.Equal(t, 1, firings)
+	})
+
+	t.Run("reminders which are not overdue or without period fire once", func(t *testing.T) {
+		upcoming := now.Add(time.Minute)
+		next, firings := getReminderCatchUp(reminder, ReminderCatchUpFireAll, upcoming, now)
+		assert.Equal(t, upcoming, next)
+		assert.Equal(t, 1, firings)
+
+		next, firings = getReminderCatchUp(&Reminder{}, ReminderCatchUpSkip, overdue, now)
+		assert.Equal(t, overdue, next)
+		assert.Equal(t, 1, firings)
+	})
+}
+
 func TestReminderFiresOnceWithEmptyPeriod(t *testing.T) {
 	testActorsRuntime := newTestActorsRuntime()
 	actorType, actorID := getTestActorTypeAndID()
@@ -654,6 +819,71 @@ func TestTransactionalState(t *testing.T) {
 	})
 }
 
+func TestReentrancy(t *testing.T) {
+	actorType, actorID := getTestActorTypeAndID()
+	spec := config.TracingSpec{SamplingRate: "1"}
+	fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+
+	// newReentrantActors returns actors whose app calls the actor again from each call, up to the given depth
+	newReentrantActors := func(reentrancy config.ReentrancyConfig, depth int) (*actorsRuntime, *[]error) {
+		appChannel := new(channelt.MockAppChannel)
+		a := NewActors(fakeStore(), appChannel, nil, Config{Reentrancy: reentrancy}, nil, spec, nil).(*actorsRuntime)
+		fakeCallAndActivateActor(a, a.constructCompositeKey(actorType, actorID))
+
+		var errs []error
+		calls := 0
+		appChannel.On("InvokeMethod", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			calls++
+			if calls >= depth {
+				return
+			}
+			incoming := args.Get(1).(*invokev1.InvokeMethodRequest)
+			req := invokev1.NewInvokeMethodRequest("method1").WithActor(actorType, actorID)
+			req.WithMetadata(map[string][]string{
+				reentrancyIDHeader: {incoming.Metadata()[reentrancyIDHeader].GetValues()[0].GetStringValue()},
+			})
+			_, err := a.callLocalActor(context.Background(), req)
+			errs = append(errs, err)
+		}).Return(fakeResp, nil)
+		return a, &errs
+	}
+
+	t.Run("call chain re-enters the actor", func(t *testing.T) {
+		a, errs := newReentrantActors(config.ReentrancyConfig{Enabled: true}, 3)
+		req := invokev1.NewInvokeMethodRequest("method1").WithActor(actorType, actorID)
+
+		_, err := a.callLocalActor(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, []error{nil, nil}, *errs)
+	})
+
+	t.Run("max stack depth exceeded", func(t *testing.T) {
+		maxStackDepth := 2
+		a, errs := newReentrantActors(config.ReentrancyConfig{Enabled: true, MaxStackDepth: &maxStackDepth}, 3)
+		req := invokev1.NewInvokeMethodRequest("method1").WithActor(actorType, actorID)
+
+		_, err := a.callLocalActor(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, []error{ErrMaxStackDepthExceeded, nil}, *errs)
+	})
+
+	t.Run("reentrancy id is kept", func(t *testing.T) {
+		a, _ := newReentrantActors(config.ReentrancyConfig{Enabled: true}, 0)
+		req := invokev1.NewInvokeMethodRequest("method1").WithActor(actorType, actorID)
+		req.WithMetadata(map[string][]string{"dapr-reentrancy-id": {"chain1"}})
+
+		assert.Equal(t, "chain1", a.getReentrancyID(req))
+	})
+
+	t.Run("reentrancy disabled", func(t *testing.T) {
+		a, _ := newReentrantActors(config.ReentrancyConfig{}, 0)
+		req := invokev1.NewInvokeMethodRequest("method1").WithActor(actorType, actorID)
+
+		assert.Equal(t, "", a.getReentrancyID(req))
+		assert.Empty(t, req.Metadata())
+	})
+}
+
 func TestActiveActorsCount(t *testing.T) {
 	ctx := context.Background()
 	t.Run("Actors Count", func(t *testing.T) {
@@ -672,6 +902,15 @@ func TestActiveActorsCount(t *testing.T) {
 		assert.ElementsMatch(t, expectedCounts, actualCounts)
 	})
 
+	t.Run("Registered actor types without active actors", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+		testActorRuntime.config.HostedActorTypes = []string{"dog", "bird"}
+		fakeCallAndActivateActor(testActorRuntime, testActorRuntime.constructCompositeKey("dog", "xyz"))
+
+		actualCounts := testActorRuntime.GetActiveActorsCount(ctx)
+		assert.Equal(t, []ActiveActorsCount{{Type: "bird", Count: 0}, {Type: "dog", Count: 1}}, actualCounts)
+	})
+
 	t.Run("Actors Count empty", func(t *testing.T) {
 		expectedCounts := []ActiveActorsCount{}
 
@@ -688,5 +927,43 @@ func TestActorsAppHealthCheck(t *testing.T) {
 	go testActorRuntime.startAppHealthCheck(health.WithFailureThreshold(1), health.WithInterval(1))
 
 	time.Sleep(time.Second * 2)
-	assert.False(t, testActorRuntime.appHealthy)
+	assert.False(t, testActorRuntime.isAppHealthy())
+}
+
+func TestActivationWhileAppUnhealthy(t *testing.T) {
+	appChannel := new(channelt.MockAppChannel)
+	appChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil)
+	testActorRuntime := NewActors(fakeStore(), appChannel, nil, Config{}, nil, config.TracingSpec{}, nil).(*actorsRuntime)
+	activeKey := testActorRuntime.constructCompositeKey("cat", "active")
+	fakeCallAndActivateActor(testActorRuntime, activeKey)
+
+	testActorRuntime.SetAppHealthy(false)
+	_, err := testActorRuntime.callLocalActor(context.Background(), invokev1.NewInvokeMethodRequest("method1").WithActor("cat", "new"))
+	assert.Error(t, err)
+	_, ok := testActorRuntime.actorsTable.Load(testActorRuntime.constructCompositeKey("cat", "new"))
+	assert.False(t, ok)
+
+	// active actors keep receiving calls
+	_, err = testActorRuntime.callLocalActor(context.Background(), invokev1.NewInvokeMethodRequest("method1").WithActor("cat", "active"))
+	assert.NoError(t, err)
+
+	testActorRuntime.SetAppHealthy(true)
+	_, err = testActorRuntime.callLocalActor(context.Background(), invokev1.NewInvokeMethodRequest("method1").WithActor("cat", "new"))
+	assert.NoError(t, err)
+}
+
+func TestPlacementEntities(t *testing.T) {
+	t.Run("actor types aren't scoped", func(t *testing.T) {
+		c := NewConfig(NewConfigOpts{AppID: TestAppID, HostedActorTypes: []string{"cat"}})
+		a := NewActors(fakeStore(), nil, nil, c, nil, config.TracingSpec{}, nil).(*actorsRuntime)
+		assert.Equal(t, []string{"cat"}, a.placementHost().Entities)
+		assert.Equal(t, "cat", a.placementEntity("cat"))
+	})
+
+	t.Run("actor types are scoped to the namespace", func(t *testing.T) {
+		c := NewConfig(NewConfigOpts{AppID: TestAppID, HostedActorTypes: []string{"cat"}, Namespace: "ns1"})
+		a := NewActors(fakeStore(), nil, nil, c, nil, config.TracingSpec{}, nil).(*actorsRuntime)
+		assert.Equal(t, []string{"ns1/cat"}, a.placementHost().Entities)
+		assert.Equal(t, "ns1/cat", a.placementEntity("cat"))
+	})
 }
@@ -5,7 +5,11 @@
 
 package actors
 
-import "time"
+import (
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+)
 
 // Config is the actor runtime configuration
 type Config struct {
@@ -19,45 +23,183 @@ type Config struct {
 	ActorIdleTimeout              time.Duration
 	DrainOngoingCallTimeout       time.Duration
 	DrainRebalancedActors         bool
+	Reentrancy                    config.ReentrancyConfig
+	// RemindersStoragePartitions is the number of keys the reminders of each actor type are saved under,
+	// 0 saves them under a single key
+	RemindersStoragePartitions int
+	// EntitiesConfig overrides the configuration of the actor types which have their own lifecycle
+	EntitiesConfig map[string]EntityConfig
+	// LifecycleCallbacks activates and deactivates actors through the activate and deactivate app endpoints,
+	// which receive the reason of the deactivation
+	LifecycleCallbacks bool
+	// MaxConcurrentReminderFirings caps the reminders and timers firing at once, 0 doesn't cap them
+	MaxConcurrentReminderFirings int
+	// ExternalAppHealth stops the actors runtime from probing the healthz endpoint of the app, the health of the
+	// app is reported through SetAppHealthy instead
+	ExternalAppHealth bool
+	// Namespace scopes the actor types in the placement tables, so the hosts of other namespaces don't host the
+	// actors of the app. Actor types aren't scoped when empty.
+	Namespace string
+}
+
+// EntityConfig is the configuration of an actor type
+type EntityConfig struct {
+	ActorDeactivationScanInterval time.Duration
+	ActorIdleTimeout              time.Duration
+	DrainOngoingCallTimeout       time.Duration
+	// ReminderCatchUpPolicy is how the reminders which are overdue when they start catch up
+	ReminderCatchUpPolicy string
 }
 
+const (
+	// ReminderCatchUpFireOnce fires an overdue reminder once, then resumes its period
+	ReminderCatchUpFireOnce = "fireOnce"
+	// ReminderCatchUpFireAll fires an overdue reminder once for each of its missed periods
+	ReminderCatchUpFireAll = "fireAll"
+	// ReminderCatchUpSkip skips the missed periods of an overdue reminder and fires it at its next schedule
+	ReminderCatchUpSkip = "skip"
+)
+
 const (
 	defaultActorIdleTimeout   = time.Minute * 60
 	defaultHeartbeatInterval  = time.Second * 1
 	defaultActorScanInterval  = time.Second * 30
 	defaultOngoingCallTimeout = time.Second * 60
+	defaultMaxStackDepth      = 32
+	// defaultPlacementLockTimeout is how long calls wait for the placement service to unlock updated tables
+	defaultPlacementLockTimeout = time.Second * 5
 )
 
+// NewConfigOpts are the settings of the actor runtime configuration, read from the configuration of the app
+type NewConfigOpts struct {
+	HostAddress                  string
+	AppID                        string
+	PlacementAddress             string
+	HostedActorTypes             []string
+	Port                         int
+	ActorScanInterval            string
+	ActorIdleTimeout             string
+	DrainOngoingCallTimeout      string
+	DrainRebalancedActors        bool
+	Reentrancy                   config.ReentrancyConfig
+	RemindersStoragePartitions   int
+	EntitiesConfig               map[string]config.EntityConfig
+	LifecycleCallbacks           bool
+	MaxConcurrentReminderFirings int
+	ExternalAppHealth            bool
+	Namespace                    string
+}
+
 // NewConfig returns the actor runtime configuration
-func NewConfig(hostAddress, appID, placementAddress string, hostedActors []string, port int,
-	actorScanInterval, actorIdleTimeout, ongoingCallTimeout string, drainRebalancedActors bool) Config {
+func NewConfig(opts NewConfigOpts) Config {
 	c := Config{
-		HostAddress:                   hostAddress,
-		AppID:                         appID,
-		PlacementServiceAddress:       placementAddress,
-		HostedActorTypes:              hostedActors,
-		Port:                          port,
+		HostAddress:                   opts.HostAddress,
+		AppID:                         opts.AppID,
+		PlacementServiceAddress:       opts.PlacementAddress,
+		HostedActorTypes:              opts.HostedActorTypes,
+		Port:                          opts.Port,
 		HeartbeatInterval:             defaultHeartbeatInterval,
 		ActorDeactivationScanInterval: defaultActorScanInterval,
 		ActorIdleTimeout:              defaultActorIdleTimeout,
 		DrainOngoingCallTimeout:       defaultOngoingCallTimeout,
-		DrainRebalancedActors:         drainRebalancedActors,
+		DrainRebalancedActors:         opts.DrainRebalancedActors,
+		Reentrancy:                    opts.Reentrancy,
+		RemindersStoragePartitions:    opts.RemindersStoragePartitions,
+		LifecycleCallbacks:            opts.LifecycleCallbacks,
+		MaxConcurrentReminderFirings:  opts.MaxConcurrentReminderFirings,
+		ExternalAppHealth:             opts.ExternalAppHealth,
+		Namespace:                     opts.Namespace,
 	}
 
-	scanDuration, err := time.ParseDuration(actorScanInterval)
+	scanDuration, err := time.ParseDuration(opts.ActorScanInterval)
 	if err == nil {
 		c.ActorDeactivationScanInterval = scanDuration
 	}
 
-	idleDuration, err := time.ParseDuration(actorIdleTimeout)
+	idleDuration, err := time.ParseDuration(opts.ActorIdleTimeout)
 	if err == nil {
 		c.ActorIdleTimeout = idleDuration
 	}
 
-	drainCallDuration, err := time.ParseDuration(ongoingCallTimeout)
+	drainCallDuration, err := time.ParseDuration(opts.DrainOngoingCallTimeout)
 	if err == nil {
 		c.DrainOngoingCallTimeout = drainCallDuration
 	}
 
+	for actorType, e := range opts.EntitiesConfig {
+		if c.EntitiesConfig == nil {
+			c.EntitiesConfig = map[string]EntityConfig{}
+		}
+		entityConfig := c.entityConfig(actorType)
+		if scanDuration, err := time.ParseDuration(e.ActorScanInterval); err == nil {
+			entityConfig.ActorDeactivationScanInterval = scanDuration
+		}
+		if idleDuration, err := time.ParseDuration(e.ActorIdleTimeout); err == nil {
+			entityConfig.ActorIdleTimeout = idleDuration
+		}
+		if drainCallDuration, err := time.ParseDuration(e.DrainOngoingCallTimeout); err == nil {
+			entityConfig.DrainOngoingCallTimeout = drainCallDuration
+		}
+		switch e.ReminderCatchUpPolicy {
+		case ReminderCatchUpFireOnce, ReminderCatchUpFireAll, ReminderCatchUpSkip:
+			entityConfig.ReminderCatchUpPolicy = e.ReminderCatchUpPolicy
+		}
+		c.EntitiesConfig[actorType] = entityConfig
+	}
+
 	return c
 }
+
+// entityConfig returns the configuration of the actor type
+func (c Config) entityConfig(actorType string) EntityConfig {
+	if e, ok := c.EntitiesConfig[actorType]; ok {
+		return e
+	}
+	return EntityConfig{
+		ActorDeactivationScanInterval: c.ActorDeactivationScanInterval,
+		ActorIdleTimeout:              c.ActorIdleTimeout,
+		DrainOngoingCallTimeout:       c.DrainOngoingCallTimeout,
+		ReminderCatchUpPolicy:         ReminderCatchUpFireOnce,
+	}
+}
+
+// deactivationScanIntervals returns the distinct scan intervals of the actor types, starting with the default one
+func (c Config) deactivationScanIntervals() []time.Duration {
+	intervals := []time.Duration{c.ActorDeactivationScanInterval}
+	for _, e := range c.EntitiesConfig {
+		found := false
+		for _, i := range intervals {
+			if i == e.ActorDeactivationScanInterval {
+				found = true
+				break
+			}
+		}
+		if !found {
+			intervals = append(intervals, e.ActorDeactivationScanInterval)
+		}
+	}
+	return intervals
+}
+
+// maxStackDepth returns the maximum number of nested calls of an actor within a reentrant call chain
+func (c Config) maxStackDepth() int {
+	if c.Reentrancy.MaxStackDepth == nil {
+		return defaultMaxStackDepth
+	}
+	return *c.Reentrancy.MaxStackDepth
+}
+
+// placementLockTimeout returns how long calls wait for the placement service to unlock updated tables.
+// When rebalanced actors are drained, the tables stay locked while the ongoing calls complete.
+func (c Config) placementLockTimeout() time.Duration {
+	if !c.DrainRebalancedActors {
+		return defaultPlacementLockTimeout
+	}
+	drainTimeout := c.DrainOngoingCallTimeout
+	for _, e := range c.EntitiesConfig {
+		if e.DrainOngoingCallTimeout > drainTimeout {
+			drainTimeout = e.DrainOngoingCallTimeout
+		}
+	}
+	return drainTimeout + defaultPlacementLockTimeout
+}
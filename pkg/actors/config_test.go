@@ -0,0 +1,63 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntitiesConfig(t *testing.T) {
+	c := NewConfig(NewConfigOpts{
+		HostAddress:             "localhost",
+		AppID:                   "app1",
+		PlacementAddress:        "placement:5050",
+		HostedActorTypes:        []string{"cat", "dog"},
+		Port:                    3500,
+		ActorScanInterval:       "10s",
+		ActorIdleTimeout:        "1h",
+		DrainOngoingCallTimeout: "30s",
+		EntitiesConfig: map[string]config.EntityConfig{
+			"dog":   {ActorIdleTimeout: "5m", ActorScanInterval: "1s", ReminderCatchUpPolicy: ReminderCatchUpSkip},
+			"mouse": {ReminderCatchUpPolicy: "unknown"},
+		},
+	})
+
+	assert.Equal(t, EntityConfig{
+		ActorDeactivationScanInterval: 10 * time.Second,
+		ActorIdleTimeout:              time.Hour,
+		DrainOngoingCallTimeout:       30 * time.Second,
+		ReminderCatchUpPolicy:         ReminderCatchUpFireOnce,
+	}, c.entityConfig("cat"))
+	assert.Equal(t, EntityConfig{
+		ActorDeactivationScanInterval: time.Second,
+		ActorIdleTimeout:              5 * time.Minute,
+		DrainOngoingCallTimeout:       30 * time.Second,
+		ReminderCatchUpPolicy:         ReminderCatchUpSkip,
+	}, c.entityConfig("dog"))
+	assert.Equal(t, ReminderCatchUpFireOnce, c.entityConfig("mouse").ReminderCatchUpPolicy)
+	assert.Equal(t, []time.Duration{10 * time.Second, time.Second}, c.deactivationScanIntervals())
+}
+
+func TestPlacementLockTimeout(t *testing.T) {
+	c := NewConfig(NewConfigOpts{
+		HostAddress:             "localhost",
+		AppID:                   "app1",
+		PlacementAddress:        "placement:5050",
+		Port:                    3500,
+		DrainOngoingCallTimeout: "30s",
+		EntitiesConfig: map[string]config.EntityConfig{
+			"dog": {DrainOngoingCallTimeout: "2m"},
+		},
+	})
+	assert.Equal(t, 5*time.Second, c.placementLockTimeout())
+
+	c.DrainRebalancedActors = true
+	assert.Equal(t, 2*time.Minute+5*time.Second, c.placementLockTimeout())
+}
@@ -7,11 +7,13 @@ package actors
 
 // CreateTimerRequest is the request object to create a new timer
 type CreateTimerRequest struct {
-	Name      string
-	ActorType string
-	ActorID   string
+	Name      string      `json:"name,omitempty"`
+	ActorType string      `json:"actorType,omitempty"`
+	ActorID   string      `json:"actorID,omitempty"`
 	DueTime   string      `json:"dueTime"`
 	Period    string      `json:"period"`
 	Callback  string      `json:"callback"`
 	Data      interface{} `json:"data"`
+	// Durable timers are saved in the actor state store, so that they survive restarts and rebalancing
+	Durable bool `json:"durable,omitempty"`
 }
@@ -0,0 +1,102 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"encoding/json"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+const timersKeySuffix = "timers"
+
+// getDurableTimers returns the durable timers of the actor type
+func (a *actorsRuntime) getDurableTimers(actorType string) ([]CreateTimerRequest, error) {
+	resp, err := a.store.Get(&state.GetRequest{
+		Key: a.constructCompositeKey("actors", actorType, timersKeySuffix),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var timers []CreateTimerRequest
+	json.Unmarshal(resp.Data, &timers)
+	return timers, nil
+}
+
+// saveDurableTimer saves the timer, replacing the timer of the actor with the same name
+func (a *actorsRuntime) saveDurableTimer(timer CreateTimerRequest) error {
+	a.durableTimersLock.Lock()
+	defer a.durableTimersLock.Unlock()
+
+	timers, err := a.getDurableTimers(timer.ActorType)
+	if err != nil {
+		return err
+	}
+	return a.store.Set(&state.SetRequest{
+		Key:   a.constructCompositeKey("actors", timer.ActorType, timersKeySuffix),
+		Value: append(removeTimer(timers, timer.ActorID, timer.Name), timer),
+	})
+}
+
+// deleteDurableTimer deletes the timer of the actor with the given name if it is durable
+func (a *actorsRuntime) deleteDurableTimer(actorType, actorID, name string) error {
+	a.durableTimersLock.Lock()
+	defer a.durableTimersLock.Unlock()
+
+	timers, err := a.getDurableTimers(actorType)
+	if err != nil {
+		return err
+	}
+	kept := removeTimer(timers, actorID, name)
+	if len(kept) == len(timers) {
+		return nil
+	}
+	return a.store.Set(&state.SetRequest{
+		Key:   a.constructCompositeKey("actors", actorType, timersKeySuffix),
+		Value: kept,
+	})
+}
+
+// startDurableTimers starts the durable timers of the actors of the type hosted by this app instance.
+// Timers restart from their due time, a timer which was due while no instance hosted its actor is not fired.
+func (a *actorsRuntime) startDurableTimers(actorType string) {
+	timers, err := a.getDurableTimers(actorType)
+	if err != nil {
+		log.Debugf("error getting durable timers for actor type %s: %s", actorType, err)
+		return
+	}
+
+	for i := range timers {
+		timer := timers[i]
+		if !a.isActorHostedLocally(timer.ActorType, timer.ActorID) {
+			continue
+		}
+		timerKey := a.constructCompositeKey(timer.ActorType, timer.ActorID, timer.Name)
+		if _, exists := a.activeTimers.Load(timerKey); exists {
+			continue
+		}
+		if err := a.startTimer(&timer); err != nil {
+			log.Debugf("error starting durable timer %s: %s", timerKey, err)
+		}
+	}
+}
+
+func (a *actorsRuntime) isActorHostedLocally(actorType, actorID string) bool {
+	address, _ := a.lookupActorAddress(actorType, actorID)
+	return address != "" && a.isActorLocal(address, a.config.HostAddress, a.config.Port)
+}
+
+// removeTimer returns the timers without the timer of the actor with the given name
+func removeTimer(timers []CreateTimerRequest, actorID, name string) []CreateTimerRequest {
+	kept := make([]CreateTimerRequest, 0, len(timers))
+	for _, t := range timers {
+		if t.ActorID != actorID || t.Name != name {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,95 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/dapr/dapr/pkg/placement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newDurableTimersRuntime returns actors hosting the actors of the type on the given host
+func newDurableTimersRuntime(store state.Store, actorType, host string) *actorsRuntime {
+	appChannel := new(channelt.MockAppChannel)
+	appChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil)
+	c := NewConfig(NewConfigOpts{AppID: TestAppID, HostedActorTypes: []string{actorType}})
+	a := NewActors(store, appChannel, nil, c, nil, config.TracingSpec{}, nil).(*actorsRuntime)
+
+	hosts := placement.NewConsistentHash()
+	hosts.Add(host, TestAppID, 5000)
+	a.placementTables.Entries[actorType] = hosts
+	return a
+}
+
+func TestDurableTimers(t *testing.T) {
+	ctx := context.Background()
+	actorType, actorID := getTestActorTypeAndID()
+
+	t.Run("durable timers are restarted", func(t *testing.T) {
+		store := fakeStore()
+		a := newDurableTimersRuntime(store, actorType, "localhost")
+		fakeCallAndActivateActor(a, a.constructCompositeKey(actorType, actorID))
+
+		timer := createTimerData(actorID, actorType, "timer1", "1h", "1h", "callback", "")
+		timer.Durable = true
+		assert.NoError(t, a.CreateTimer(ctx, &timer))
+		timer2 := createTimerData(actorID, actorType, "timer2", "1h", "1h", "callback", "")
+		assert.NoError(t, a.CreateTimer(ctx, &timer2))
+
+		timers, err := a.getDurableTimers(actorType)
+		assert.NoError(t, err)
+		assert.Equal(t, []CreateTimerRequest{timer}, timers)
+
+		restarted := newDurableTimersRuntime(store, actorType, "localhost")
+		restarted.startDurableTimers(actorType)
+		_, exists := restarted.activeTimers.Load(restarted.constructCompositeKey(actorType, actorID, "timer1"))
+		assert.True(t, exists)
+		_, exists = restarted.activeTimers.Load(restarted.constructCompositeKey(actorType, actorID, "timer2"))
+		assert.False(t, exists)
+
+		assert.NoError(t, restarted.DeleteTimer(ctx, &DeleteTimerRequest{Name: "timer1", ActorID: actorID, ActorType: actorType}))
+		timers, _ = restarted.getDurableTimers(actorType)
+		assert.Empty(t, timers)
+	})
+
+	t.Run("timers of actors hosted by other instances are not started", func(t *testing.T) {
+		store := fakeStore()
+		a := newDurableTimersRuntime(store, actorType, "localhost")
+		fakeCallAndActivateActor(a, a.constructCompositeKey(actorType, actorID))
+		timer := createTimerData(actorID, actorType, "timer1", "1h", "1h", "callback", "")
+		timer.Durable = true
+		assert.NoError(t, a.CreateTimer(ctx, &timer))
+
+		other := newDurableTimersRuntime(store, actorType, "10.0.0.1")
+		other.startDurableTimers(actorType)
+		_, exists := other.activeTimers.Load(other.constructCompositeKey(actorType, actorID, "timer1"))
+		assert.False(t, exists)
+	})
+
+	t.Run("durable timers activate their actor", func(t *testing.T) {
+		store := fakeStore()
+		a := newDurableTimersRuntime(store, actorType, "localhost")
+		actorKey := a.constructCompositeKey(actorType, actorID)
+		timer := createTimerData(actorID, actorType, "timer1", "10ms", "", "callback", "")
+		timer.Durable = true
+		assert.NoError(t, a.saveDurableTimer(timer))
+
+		a.startDurableTimers(actorType)
+		time.Sleep(100 * time.Millisecond)
+		_, exists := a.actorsTable.Load(actorKey)
+		assert.True(t, exists)
+
+		assert.NoError(t, a.DeleteTimer(ctx, &DeleteTimerRequest{Name: "timer1", ActorID: actorID, ActorType: actorType}))
+	})
+}
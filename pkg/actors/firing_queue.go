@@ -0,0 +1,101 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"sync"
+)
+
+// firingQueue caps the concurrent reminder and timer firings of the app instance.
+// Firings over the cap wait in a queue per actor type, and the queues are served round robin
+// so a storm of firings of an actor type doesn't delay the firings of the other actor types.
+type firingQueue struct {
+	lock    sync.Mutex
+	limit   int
+	running int
+	// waiting holds the firings waiting for a slot, by actor type
+	waiting map[string][]chan struct{}
+	// turns holds the actor types with waiting firings, in the order they are served
+	turns []string
+}
+
+// newFiringQueue returns a queue allowing limit concurrent firings, or nil when the firings are not limited
+func newFiringQueue(limit int) *firingQueue {
+	if limit <= 0 {
+		return nil
+	}
+	return &firingQueue{
+		limit:   limit,
+		waiting: map[string][]chan struct{}{},
+	}
+}
+
+// acquire waits for a firing slot for the actor type and returns the func releasing it
+func (q *firingQueue) acquire(actorType string) func() {
+	if q == nil {
+		return func() {}
+	}
+
+	q.lock.Lock()
+	if q.running < q.limit && len(q.turns) == 0 {
+		q.running++
+		q.lock.Unlock()
+		return q.releaseFunc()
+	}
+
+	ready := make(chan struct{})
+	if _, ok := q.waiting[actorType]; !ok {
+		q.turns = append(q.turns, actorType)
+	}
+	q.waiting[actorType] = append(q.waiting[actorType], ready)
+	q.lock.Unlock()
+
+	<-ready
+	return q.releaseFunc()
+}
+
+func (q *firingQueue) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(q.release)
+	}
+}
+
+// release hands the slot over to the next waiting firing, taking the actor types in turn
+func (q *firingQueue) release() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.turns) == 0 {
+		q.running--
+		return
+	}
+
+	actorType := q.turns[0]
+	q.turns = q.turns[1:]
+	waiting := q.waiting[actorType]
+	if len(waiting) == 1 {
+		delete(q.waiting, actorType)
+	} else {
+		q.waiting[actorType] = waiting[1:]
+		q.turns = append(q.turns, actorType)
+	}
+	close(waiting[0])
+}
+
+// pending returns the number of firings waiting for a slot
+func (q *firingQueue) pending() int {
+	if q == nil {
+		return 0
+	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	count := 0
+	for _, waiting := range q.waiting {
+		count += len(waiting)
+	}
+	return count
+}
@@ -0,0 +1,86 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitPending(t *testing.T, q *firingQueue, count int) {
+	assert.Eventually(t, func() bool {
+		return q.pending() == count
+	}, time.Second, time.Millisecond)
+}
+
+func waitRunning(t *testing.T, q *firingQueue, count int) {
+	assert.Eventually(t, func() bool {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		return q.running == count
+	}, time.Second, time.Millisecond)
+}
+
+func TestFiringQueue(t *testing.T) {
+	t.Run("firings are not limited", func(t *testing.T) {
+		q := newFiringQueue(0)
+		assert.Nil(t, q)
+		release := q.acquire("cat")
+		release()
+		assert.Equal(t, 0, q.pending())
+	})
+
+	t.Run("firings over the limit wait", func(t *testing.T) {
+		q := newFiringQueue(2)
+		release1 := q.acquire("cat")
+		q.acquire("cat")
+
+		acquired := make(chan struct{})
+		go func() {
+			q.acquire("dog")
+			close(acquired)
+		}()
+		waitPending(t, q, 1)
+
+		release1()
+		release1()
+		<-acquired
+		waitRunning(t, q, 2)
+		assert.Equal(t, 0, q.pending())
+	})
+
+	t.Run("actor types take turns", func(t *testing.T) {
+		q := newFiringQueue(1)
+		release := q.acquire("cat")
+
+		served := make(chan string, 4)
+		for i, actorType := range []string{"cat", "cat", "cat", "dog"} {
+			go func(actorType string) {
+				release := q.acquire(actorType)
+				served <- actorType
+				release()
+			}(actorType)
+			waitPending(t, q, i+1)
+		}
+
+		release()
+		order := []string{}
+		for i := 0; i < 4; i++ {
+			order = append(order, <-served)
+		}
+		assert.Equal(t, []string{"cat", "dog", "cat", "cat"}, order)
+		waitRunning(t, q, 0)
+	})
+}
+
+func TestMaxConcurrentReminderFirings(t *testing.T) {
+	c := NewConfig(NewConfigOpts{AppID: TestAppID, MaxConcurrentReminderFirings: 2})
+	a := NewActors(fakeStore(), nil, nil, c, nil, config.TracingSpec{}, nil).(*actorsRuntime)
+	assert.Equal(t, 2, a.firings.limit)
+}
@@ -0,0 +1,23 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+// ListRemindersRequest is the request object to list the reminders of an actor type or of an actor
+type ListRemindersRequest struct {
+	ActorType string
+	// ActorID restricts the list to the reminders of an actor
+	ActorID string
+	// Limit is the maximum number of reminders returned, 0 returns all of them
+	Limit int
+	// ContinuationToken is the token returned with the previous page
+	ContinuationToken string
+}
+
+// ListRemindersResponse is a page of reminders, the continuation token is set when more reminders follow
+type ListRemindersResponse struct {
+	Reminders         []Reminder `json:"reminders"`
+	ContinuationToken string     `json:"continuationToken,omitempty"`
+}
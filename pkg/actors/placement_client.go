@@ -0,0 +1,204 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	dapr_credentials "github.com/dapr/dapr/pkg/credentials"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+	"github.com/dapr/dapr/pkg/runtime/security"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// placementReportTimeout is the timeout of the reports of drained actors to the placement service
+const placementReportTimeout = time.Second * 5
+
+// PlacementClient reports the actor types hosted by the app instance to a membership backend,
+// and passes the placement orders of the backend to the actor runtime.
+// The Dapr placement service is the default backend, other backends send the tables they build from their own membership.
+type PlacementClient interface {
+	// Start reports the host until the client is stopped and passes the placement orders to the handler.
+	// The host isn't reported while healthy returns false.
+	Start(host *placementv1pb.Host, healthy func() bool, handler func(*placementv1pb.PlacementOrder))
+	// ReportDrained reports the host drained the actors moved by the tables version
+	ReportDrained(host *placementv1pb.Host, version string) error
+	// Stop stops reporting the host
+	Stop()
+}
+
+// daprPlacementClient is the client of the Dapr placement service
+type daprPlacementClient struct {
+	address           string
+	heartbeatInterval time.Duration
+	certChain         *dapr_credentials.CertChain
+	connLock          *sync.Mutex
+	conn              *grpc.ClientConn
+	stream            placementv1pb.PlacementService_ReportDaprStatusClient
+	stopCh            chan struct{}
+	stopOnce          *sync.Once
+}
+
+func newDaprPlacementClient(address string, heartbeatInterval time.Duration, certChain *dapr_credentials.CertChain) *daprPlacementClient {
+	return &daprPlacementClient{
+		address:           address,
+		heartbeatInterval: heartbeatInterval,
+		certChain:         certChain,
+		connLock:          &sync.Mutex{},
+		stopCh:            make(chan struct{}),
+		stopOnce:          &sync.Once{},
+	}
+}
+
+func (c *daprPlacementClient) Start(host *placementv1pb.Host, healthy func() bool, handler func(*placementv1pb.PlacementOrder)) {
+	log.Infof("starting connection attempt to placement service at %s", c.address)
+	stream := c.connect(host.Name)
+	if stream == nil {
+		return
+	}
+	log.Infof("established connection to placement service at %s", c.address)
+
+	go func() {
+		for !c.stopped() {
+			stream := c.getStream()
+			if stream != nil {
+				if !healthy() {
+					// app is unresponsive, close the stream and disconnect from the placement service
+					err := stream.CloseSend()
+					if err != nil {
+						log.Errorf("error closing stream to placement service: %s", err)
+					}
+					continue
+				}
+
+				if err := stream.Send(host); err != nil {
+					diag.DefaultMonitoring.ActorStatusReportFailed("send", "status")
+					log.Warnf("failed to report status to placement service : %v", err)
+					c.connect(host.Name)
+				}
+			}
+			time.Sleep(c.heartbeatInterval)
+		}
+	}()
+
+	go func() {
+		for !c.stopped() {
+			resp, err := c.getStream().Recv()
+			if c.stopped() {
+				return
+			}
+			if err != nil {
+				diag.DefaultMonitoring.ActorStatusReportFailed("recv", "status")
+				log.Warnf("failed to receive the response of status report from placement service: %v", err)
+				c.connect(host.Name)
+			} else {
+				diag.DefaultMonitoring.ActorStatusReported("recv")
+			}
+			if resp != nil {
+				handler(resp)
+			}
+		}
+	}()
+}
+
+// connect connects to the placement service until it succeeds or the client is stopped
+func (c *daprPlacementClient) connect(hostAddress string) placementv1pb.PlacementService_ReportDaprStatusClient {
+	for !c.stopped() {
+		retryInterval := time.Millisecond * 250
+
+		opts, err := dapr_credentials.GetClientOptions(c.certChain, security.TLSServerName)
+		if err != nil {
+			log.Errorf("failed to establish TLS credentials for actor placement service: %s", err)
+			return nil
+		}
+
+		if diag.DefaultGRPCMonitoring.IsEnabled() {
+			opts = append(
+				opts,
+				grpc.WithUnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryClientInterceptor()))
+		}
+
+		conn, err := grpc.Dial(
+			c.address,
+			opts...,
+		)
+		if err != nil {
+			log.Warnf("error connecting to placement service: %v", err)
+			diag.DefaultMonitoring.ActorStatusReportFailed("dial", "placement")
+			time.Sleep(retryInterval)
+			continue
+		}
+
+		header := metadata.New(map[string]string{idHeader: hostAddress})
+		ctx := metadata.NewOutgoingContext(context.Background(), header)
+		client := placementv1pb.NewPlacementServiceClient(conn)
+		stream, err := client.ReportDaprStatus(ctx)
+		if err != nil {
+			log.Warnf("error establishing client to placement service: %v", err)
+			diag.DefaultMonitoring.ActorStatusReportFailed("establish", "status")
+			time.Sleep(retryInterval)
+			conn.Close()
+			continue
+		}
+
+		// the connection is also used to report drained actors
+		c.connLock.Lock()
+		c.conn = conn
+		c.stream = stream
+		c.connLock.Unlock()
+		return stream
+	}
+	return nil
+}
+
+func (c *daprPlacementClient) getStream() placementv1pb.PlacementService_ReportDaprStatusClient {
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+	return c.stream
+}
+
+func (c *daprPlacementClient) ReportDrained(host *placementv1pb.Host, version string) error {
+	c.connLock.Lock()
+	conn := c.conn
+	c.connLock.Unlock()
+	if conn == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), placementReportTimeout)
+	defer cancel()
+	_, err := placementv1pb.NewPlacementDrainClient(conn).ReportDrained(ctx, &placementv1pb.DrainReport{
+		Name:    net.JoinHostPort(host.Name, strconv.FormatInt(host.Port, 10)),
+		Version: version,
+	})
+	return err
+}
+
+func (c *daprPlacementClient) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+		c.connLock.Lock()
+		defer c.connLock.Unlock()
+		if c.conn != nil {
+			c.conn.Close()
+		}
+	})
+}
+
+func (c *daprPlacementClient) stopped() bool {
+	select {
+	case <-c.stopCh:
+		return true
+	default:
+		return false
+	}
+}
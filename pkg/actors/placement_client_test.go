@@ -0,0 +1,71 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePlacementClient struct {
+	host     *placementv1pb.Host
+	handler  func(*placementv1pb.PlacementOrder)
+	started  chan struct{}
+	versions []string
+	stopped  bool
+}
+
+func (c *fakePlacementClient) Start(host *placementv1pb.Host, healthy func() bool, handler func(*placementv1pb.PlacementOrder)) {
+	c.host = host
+	c.handler = handler
+	close(c.started)
+}
+
+func (c *fakePlacementClient) ReportDrained(host *placementv1pb.Host, version string) error {
+	c.versions = append(c.versions, version)
+	return nil
+}
+
+func (c *fakePlacementClient) Stop() {
+	c.stopped = true
+}
+
+func TestPlacementClient(t *testing.T) {
+	placementClient := &fakePlacementClient{started: make(chan struct{})}
+	c := NewConfig(NewConfigOpts{HostAddress: "10.0.0.1", AppID: TestAppID, Port: 50001})
+	a := NewActors(fakeStore(), nil, nil, c, nil, config.TracingSpec{}, placementClient).(*actorsRuntime)
+
+	assert.NoError(t, a.Init())
+	<-placementClient.started
+	assert.Equal(t, "10.0.0.1", placementClient.host.Name)
+	assert.Equal(t, int64(50001), placementClient.host.Port)
+
+	placementClient.handler(&placementv1pb.PlacementOrder{
+		Operation: updateOperation,
+		Tables: &placementv1pb.PlacementTables{
+			Version: "1",
+			Entries: map[string]*placementv1pb.PlacementTable{
+				"cat": {Hosts: map[uint64]string{}, LoadMap: map[string]*placementv1pb.Host{}},
+			},
+		},
+	})
+	assert.Equal(t, "1", a.placementTables.Version)
+	assert.Contains(t, a.placementTables.Entries, "cat")
+	assert.Equal(t, []string{"1"}, placementClient.versions)
+
+	a.Stop()
+	assert.True(t, placementClient.stopped)
+}
+
+func TestDaprPlacementClientStop(t *testing.T) {
+	placementClient := newDaprPlacementClient("localhost:50005", 0, nil)
+	placementClient.Stop()
+	placementClient.Stop()
+	assert.Nil(t, placementClient.connect("10.0.0.1"))
+}
@@ -0,0 +1,67 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package actors
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	"github.com/dapr/dapr/pkg/config"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/dapr/dapr/pkg/placement"
+	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+type fakeDrainServer struct {
+	reports chan *placementv1pb.DrainReport
+}
+
+func (s *fakeDrainServer) ReportDrained(ctx context.Context, in *placementv1pb.DrainReport) (*empty.Empty, error) {
+	s.reports <- in
+	return &empty.Empty{}, nil
+}
+
+func TestDrainRebalancedActors(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	drainServer := &fakeDrainServer{reports: make(chan *placementv1pb.DrainReport, 1)}
+	placementv1pb.RegisterPlacementDrainServer(server, drainServer)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	appChannel := new(channelt.MockAppChannel)
+	appChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil)
+	c := NewConfig(NewConfigOpts{HostAddress: "10.0.0.1", AppID: TestAppID, HostedActorTypes: []string{"cat"}, Port: 50001})
+	placementClient := newDaprPlacementClient(lis.Addr().String(), 0, nil)
+	placementClient.conn = conn
+	a := NewActors(fakeStore(), appChannel, nil, c, nil, config.TracingSpec{}, placementClient).(*actorsRuntime)
+
+	hosts := placement.NewConsistentHash()
+	hosts.Add("10.0.0.2", TestAppID, 50001)
+	a.placementTables.Entries["cat"] = hosts
+	actorKey := a.constructCompositeKey("cat", "1")
+	fakeCallAndActivateActor(a, actorKey)
+
+	a.drainRebalancedActors()
+	_, exists := a.actorsTable.Load(actorKey)
+	assert.False(t, exists)
+
+	a.reportDrained("2")
+	report := <-drainServer.reports
+	assert.Equal(t, "10.0.0.1:50001", report.Name)
+	assert.Equal(t, "2", report.Version)
+}
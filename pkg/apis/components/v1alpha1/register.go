@@ -36,6 +36,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		SchemeGroupVersion,
 		&Component{},
 		&ComponentList{},
+		&HTTPEndpoint{},
+		&HTTPEndpointList{},
+		&Subscription{},
+		&SubscriptionList{},
 	)
 	scheme.AddKnownTypes(SchemeGroupVersion)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -30,6 +30,24 @@ type Component struct {
 type ComponentSpec struct {
 	Type     string         `json:"type"`
 	Metadata []MetadataItem `json:"metadata"`
+	// Version is the version of the component implementation, such as v1
+	// +optional
+	Version string `json:"version,omitempty"`
+	// InitTimeout is the time allowed to each attempt to initialize the component, as a duration string
+	// +optional
+	InitTimeout string `json:"initTimeout,omitempty"`
+	// InitRetries is the number of times a failed initialization of the component is retried
+	// +optional
+	InitRetries int `json:"initRetries,omitempty"`
+	// InitRetryBackoff is the wait before the first retry, doubled by each following retry
+	// +optional
+	InitRetryBackoff string `json:"initRetryBackoff,omitempty"`
+	// FailOnInitError stops the runtime from starting when the component fails to initialize
+	// +optional
+	FailOnInitError bool `json:"failOnInitError,omitempty"`
+	// InitOn is when the component is initialized, at startup by default or on first use when set to lazy
+	// +optional
+	InitOn string `json:"initOn,omitempty"`
 }
 
 // MetadataItem is a name/value pair for a metadata
@@ -37,12 +55,19 @@ type MetadataItem struct {
 	Name         string       `json:"name"`
 	Value        string       `json:"value"`
 	SecretKeyRef SecretKeyRef `json:"secretKeyRef,omitempty"`
+	// EnvRef is the name of an environment variable of the runtime holding the value for the metadata item.
+	// The name must be prefixed with DAPR_COMPONENT_ENV_, and the reference is refused in Kubernetes mode.
+	// +optional
+	EnvRef string `json:"envRef,omitempty"`
 }
 
 // SecretKeyRef is a reference to a secret holding the value for the metadata item. Name is the secret name, and key is the field in the secret.
+// The secret is read from the secret store named by the reference, or else from the secret store of the component.
 type SecretKeyRef struct {
 	Name string `json:"name"`
 	Key  string `json:"key"`
+	// +optional
+	SecretStore string `json:"secretStore,omitempty"`
 }
 
 // Auth represents authentication details for the component
@@ -59,3 +84,133 @@ type ComponentList struct {
 
 	Items []Component `json:"items"`
 }
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HTTPEndpoint describes an external, non-Dapr HTTP endpoint that can be targeted by service invocation
+type HTTPEndpoint struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +optional
+	Spec HTTPEndpointSpec `json:"spec,omitempty"`
+	// +optional
+	Auth `json:"auth,omitempty"`
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// HTTPEndpointSpec is the spec for an HTTP endpoint
+type HTTPEndpointSpec struct {
+	BaseURL string `json:"baseUrl"`
+	// +optional
+	Headers []MetadataItem `json:"headers,omitempty"`
+	// +optional
+	ClientTLS *TLSSpec `json:"clientTLS,omitempty"`
+}
+
+// TLSSpec holds the PEM encoded certificates used to connect to an HTTP endpoint
+type TLSSpec struct {
+	// +optional
+	RootCA string `json:"rootCA,omitempty"`
+	// +optional
+	Certificate string `json:"certificate,omitempty"`
+	// +optional
+	PrivateKey string `json:"privateKey,omitempty"`
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HTTPEndpointList is a list of Dapr HTTP endpoints
+type HTTPEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []HTTPEndpoint `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Subscription describes a declarative pub/sub topic subscription
+type Subscription struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +optional
+	Spec SubscriptionSpec `json:"spec,omitempty"`
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// SubscriptionSpec is the spec for a subscription
+type SubscriptionSpec struct {
+	Topic string `json:"topic"`
+	// +optional
+	PubSubName string `json:"pubsubName,omitempty"`
+	// +optional
+	Route string `json:"route,omitempty"`
+	// +optional
+	DeadLetterTopic string `json:"deadLetterTopic,omitempty"`
+	// +optional
+	BulkSubscribe *BulkSubscribeSpec `json:"bulkSubscribe,omitempty"`
+	// +optional
+	Routes *RoutesSpec `json:"routes,omitempty"`
+	// +optional
+	RetryPolicy *RetryPolicySpec `json:"retryPolicy,omitempty"`
+	// +optional
+	Concurrency string `json:"concurrency,omitempty"`
+	// +optional
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// RoutesSpec holds the content-based routing rules of a subscription
+type RoutesSpec struct {
+	// +optional
+	Rules []RouteRule `json:"rules,omitempty"`
+	// +optional
+	Default string `json:"default,omitempty"`
+}
+
+// RouteRule delivers events matching the expression to the given path
+type RouteRule struct {
+	Match string `json:"match"`
+	Path  string `json:"path"`
+}
+
+// BulkSubscribeSpec configures batched delivery of messages for a subscription
+type BulkSubscribeSpec struct {
+	Enabled bool `json:"enabled"`
+	// +optional
+	MaxMessagesCount int `json:"maxMessagesCount,omitempty"`
+	// +optional
+	MaxAwaitDurationMs int `json:"maxAwaitDurationMs,omitempty"`
+}
+
+// RetryPolicySpec configures the redelivery of messages the app failed to process
+type RetryPolicySpec struct {
+	MaxRetries int `json:"maxRetries"`
+	// +optional
+	InitialIntervalMs int `json:"initialIntervalMs,omitempty"`
+	// +optional
+	MaxIntervalMs int `json:"maxIntervalMs,omitempty"`
+	// +optional
+	Jitter float64 `json:"jitter,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SubscriptionList is a list of Dapr subscriptions
+type SubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Subscription `json:"items"`
+}
@@ -30,6 +30,76 @@ type ConfigurationSpec struct {
 	TracingSpec TracingSpec `json:"tracing,omitempty"`
 	// +optional
 	MTLSSpec MTLSSpec `json:"mtls,omitempty"`
+	// +optional
+	MetadataSpec MetadataSpec `json:"metadata,omitempty"`
+	// +optional
+	InvocationSpec InvocationSpec `json:"serviceInvocation,omitempty"`
+	// +optional
+	PubSubSpec PubSubSpec `json:"pubsub,omitempty"`
+	// +optional
+	Features []FeatureSpec `json:"features,omitempty"`
+	// +optional
+	AccessControlSpec AccessControlSpec `json:"accessControl,omitempty"`
+	// +optional
+	APISpec APISpec `json:"api,omitempty"`
+	// +optional
+	AuditSpec AuditSpec `json:"audit,omitempty"`
+}
+
+// AuditSpec records the secrets API calls and the state deletes of the app to an audit sink
+type AuditSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// +optional
+	Sink string `json:"sink,omitempty"`
+	// +optional
+	BindingName string `json:"bindingName,omitempty"`
+	// +optional
+	BlockWhenFull bool `json:"blockWhenFull,omitempty"`
+}
+
+// APISpec restricts the Dapr APIs the app is allowed to use
+type APISpec struct {
+	Allowed []string `json:"allowed,omitempty"`
+	Denied  []string `json:"denied,omitempty"`
+}
+
+// AccessControlSpec isolates the apps of namespaces sharing the same infrastructure
+type AccessControlSpec struct {
+	TrustDomain         string   `json:"trustDomain,omitempty"`
+	NamespaceIsolation  bool     `json:"namespaceIsolation,omitempty"`
+	AllowedNamespaces   []string `json:"allowedNamespaces,omitempty"`
+	AllowedTrustDomains []string `json:"allowedTrustDomains,omitempty"`
+	// +optional
+	DefaultAction string `json:"defaultAction,omitempty"`
+	// +optional
+	Policies []AppPolicySpec `json:"policies,omitempty"`
+}
+
+// AppPolicySpec is the action on the invocations by the callers of a SPIFFE ID
+type AppPolicySpec struct {
+	AppID string `json:"appId"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	TrustDomain string `json:"trustDomain,omitempty"`
+	// +optional
+	DefaultAction string `json:"defaultAction,omitempty"`
+	// +optional
+	AppOperationActions []AppOperationAction `json:"operations,omitempty"`
+}
+
+// AppOperationAction is the action on the invocations of the methods of a path by a caller
+type AppOperationAction struct {
+	Name string `json:"name"`
+	// +optional
+	HTTPVerb []string `json:"httpVerb,omitempty"`
+	Action   string   `json:"action"`
+}
+
+// FeatureSpec enables or disables a preview feature
+type FeatureSpec struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
 }
 
 // PipelineSpec defines the middleware pipeline
@@ -51,6 +121,24 @@ type MTLSSpec struct {
 	AllowedClockSkew string `json:"allowedClockSkew"`
 }
 
+// InvocationSpec defines the configuration of service invocation
+type InvocationSpec struct {
+	HedgingDelay string `json:"hedgingDelay,omitempty"`
+}
+
+// PubSubSpec defines the configuration of pub/sub
+type PubSubSpec struct {
+	DefaultComponent string `json:"defaultComponent,omitempty"`
+}
+
+// MetadataSpec defines the size limits of metadata and headers for service invocation
+type MetadataSpec struct {
+	MaxTotalSize      int `json:"maxTotalSize,omitempty"`
+	MaxValueLength    int `json:"maxValueLength,omitempty"`
+	MaxAppHeaderCount int `json:"maxAppHeaderCount,omitempty"`
+	MaxAppHeaderSize  int `json:"maxAppHeaderSize,omitempty"`
+}
+
 // SelectorSpec selects target services to which the handler is to be applied
 type SelectorSpec struct {
 	Fields []SelectorField `json:"fields"`
@@ -24,6 +24,125 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlSpec) DeepCopyInto(out *AccessControlSpec) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedTrustDomains != nil {
+		in, out := &in.AllowedTrustDomains, &out.AllowedTrustDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]AppPolicySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlSpec.
+func (in *AccessControlSpec) DeepCopy() *AccessControlSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppOperationAction) DeepCopyInto(out *AppOperationAction) {
+	*out = *in
+	if in.HTTPVerb != nil {
+		in, out := &in.HTTPVerb, &out.HTTPVerb
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppOperationAction.
+func (in *AppOperationAction) DeepCopy() *AppOperationAction {
+	if in == nil {
+		return nil
+	}
+	out := new(AppOperationAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppPolicySpec) DeepCopyInto(out *AppPolicySpec) {
+	*out = *in
+	if in.AppOperationActions != nil {
+		in, out := &in.AppOperationActions, &out.AppOperationActions
+		*out = make([]AppOperationAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppPolicySpec.
+func (in *AppPolicySpec) DeepCopy() *AppPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AppPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APISpec) DeepCopyInto(out *APISpec) {
+	*out = *in
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Denied != nil {
+		in, out := &in.Denied, &out.Denied
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APISpec.
+func (in *APISpec) DeepCopy() *APISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditSpec) DeepCopyInto(out *AuditSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditSpec.
+func (in *AuditSpec) DeepCopy() *AuditSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Configuration) DeepCopyInto(out *Configuration) {
 	*out = *in
@@ -90,6 +209,17 @@ func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	in.HTTPPipelineSpec.DeepCopyInto(&out.HTTPPipelineSpec)
 	out.TracingSpec = in.TracingSpec
 	out.MTLSSpec = in.MTLSSpec
+	out.MetadataSpec = in.MetadataSpec
+	out.InvocationSpec = in.InvocationSpec
+	out.PubSubSpec = in.PubSubSpec
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]FeatureSpec, len(*in))
+		copy(*out, *in)
+	}
+	in.AccessControlSpec.DeepCopyInto(&out.AccessControlSpec)
+	in.APISpec.DeepCopyInto(&out.APISpec)
+	out.AuditSpec = in.AuditSpec
 	return
 }
 
@@ -103,6 +233,22 @@ func (in *ConfigurationSpec) DeepCopy() *ConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
+func (in *FeatureSpec) DeepCopy() *FeatureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HandlerSpec) DeepCopyInto(out *HandlerSpec) {
 	*out = *in
@@ -120,6 +266,22 @@ func (in *HandlerSpec) DeepCopy() *HandlerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvocationSpec) DeepCopyInto(out *InvocationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvocationSpec.
+func (in *InvocationSpec) DeepCopy() *InvocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InvocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
 	*out = *in
@@ -136,6 +298,22 @@ func (in *MTLSSpec) DeepCopy() *MTLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataSpec) DeepCopyInto(out *MetadataSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataSpec.
+func (in *MetadataSpec) DeepCopy() *MetadataSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 	*out = *in
@@ -159,6 +337,22 @@ func (in *PipelineSpec) DeepCopy() *PipelineSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PubSubSpec) DeepCopyInto(out *PubSubSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PubSubSpec.
+func (in *PubSubSpec) DeepCopy() *PubSubSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PubSubSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorField) DeepCopyInto(out *SelectorField) {
 	*out = *in
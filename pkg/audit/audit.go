@@ -0,0 +1,254 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+var log = logger.NewLogger("dapr.runtime.audit")
+
+// The operations recorded to the audit log
+const (
+	GetSecret             = "secrets.get"
+	BulkGetSecret         = "secrets.bulkGet"
+	DeleteState           = "state.delete"
+	BulkDeleteState       = "state.bulkDelete"
+	DeleteStateWithPrefix = "state.deleteWithPrefix"
+	// TransactionDeleteState records the deletes of a state transaction
+	TransactionDeleteState = "state.transactionDelete"
+	// EventsDropped is the operation of the event written once the queue drains, with the number of the events
+	// dropped while the queue was full
+	EventsDropped = "audit.eventsDropped"
+)
+
+// QueueSize is the number of audit events waiting to be written to the sink. The events recorded while the queue
+// is full are dropped, unless the logger blocks when the queue is full.
+const QueueSize = 1000
+
+// The results of the audited operations
+const (
+	ResultSuccess = "success"
+	ResultDenied  = "denied"
+	ResultFailure = "failure"
+)
+
+// The sinks of the audit events
+const (
+	// LogSink writes the audit events to the log of the sidecar, with the audit log type
+	LogSink = "log"
+	// BindingSink sends the audit events to an output binding of the app
+	BindingSink = "binding"
+)
+
+// Event is the audit record of an access to a secret or of a deletion of state
+type Event struct {
+	Time time.Time `json:"time"`
+	// AppID is the id of the app whose sidecar served the request
+	AppID string `json:"appId"`
+	// Caller is the SPIFFE ID of the caller when it authenticated with mTLS, the id of the app for the
+	// requests the app sent on the loopback interface, or else the address of the caller
+	Caller    string   `json:"caller,omitempty"`
+	Operation string   `json:"operation"`
+	Store     string   `json:"store"`
+	Keys      []string `json:"keys,omitempty"`
+	Result    string   `json:"result"`
+	Error     string   `json:"error,omitempty"`
+	// Dropped is the number of the dropped events, for the EventsDropped events
+	Dropped int64 `json:"dropped,omitempty"`
+}
+
+// Sink writes the audit events
+type Sink interface {
+	Write(event Event) error
+}
+
+type logSink struct {
+	log logger.Logger
+}
+
+// NewLogSink returns a sink writing the audit events to the log of the sidecar
+func NewLogSink() Sink {
+	return &logSink{log: log}
+}
+
+func (s *logSink) Write(event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.log.WithLogType(logger.LogTypeAudit).Info(string(b))
+	return nil
+}
+
+type bindingSink struct {
+	name                  string
+	sendToOutputBindingFn func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error)
+}
+
+// NewBindingSink returns a sink sending the audit events as JSON to the output binding
+func NewBindingSink(name string, sendToOutputBindingFn func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error)) Sink {
+	return &bindingSink{
+		name:                  name,
+		sendToOutputBindingFn: sendToOutputBindingFn,
+	}
+}
+
+func (s *bindingSink) Write(event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.sendToOutputBindingFn(s.name, &bindings.WriteRequest{Data: b})
+	return err
+}
+
+// NewSink returns the sink of the given type, the log sink when the type is empty
+func NewSink(sinkType, bindingName string, sendToOutputBindingFn func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error)) (Sink, error) {
+	switch sinkType {
+	case "", LogSink:
+		return NewLogSink(), nil
+	case BindingSink:
+		if bindingName == "" {
+			return nil, errors.New("the binding name of the audit binding sink is required")
+		}
+		return NewBindingSink(bindingName, sendToOutputBindingFn), nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink %s", sinkType)
+	}
+}
+
+// Logger records the audit events of an app to a sink. A nil Logger records nothing,
+// so the APIs don't need to check whether the audit log is enabled.
+type Logger struct {
+	appID         string
+	sink          Sink
+	now           func() time.Time
+	blockWhenFull bool
+
+	queue     chan Event
+	dropped   int64
+	closing   chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewLogger returns a Logger recording the events of the app to the sink. The events are written to the sink
+// in the background, in the order they are recorded. When blockWhenFull is set, the audited requests wait for
+// room in the queue instead of dropping their events.
+func NewLogger(appID string, sink Sink, blockWhenFull bool) *Logger {
+	l := &Logger{
+		appID:         appID,
+		sink:          sink,
+		now:           time.Now,
+		blockWhenFull: blockWhenFull,
+		queue:         make(chan Event, QueueSize),
+		closing:       make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// Record stamps the event with the time and the app id and queues it to be written to the sink, so the audited
+// request doesn't wait for the sink. The event is dropped when the queue is full, unless the logger blocks when
+// the queue is full, and after the logger is closed. The dropped events are counted, and their number is written
+// to the sink once the queue drains.
+func (l *Logger) Record(event Event) {
+	if l == nil {
+		return
+	}
+	event.Time = l.now().UTC()
+	event.AppID = l.appID
+	select {
+	case <-l.closing:
+		log.Errorf("dropping the audit event of %s on %s, the audit log is closed", event.Operation, event.Store)
+		diag.DefaultMonitoring.AuditEventDropped(event.Operation)
+		return
+	default:
+	}
+	if l.blockWhenFull {
+		select {
+		case l.queue <- event:
+		case <-l.closing:
+			log.Errorf("dropping the audit event of %s on %s, the audit log is closed", event.Operation, event.Store)
+			diag.DefaultMonitoring.AuditEventDropped(event.Operation)
+		}
+		return
+	}
+	select {
+	case l.queue <- event:
+	default:
+		log.Errorf("dropping the audit event of %s on %s, %d audit events are waiting to be written", event.Operation, event.Store, QueueSize)
+		atomic.AddInt64(&l.dropped, 1)
+		diag.DefaultMonitoring.AuditEventDropped(event.Operation)
+	}
+}
+
+// Close writes the queued events to the sink and stops the logger
+func (l *Logger) Close() {
+	if l == nil {
+		return
+	}
+	l.closeOnce.Do(func() { close(l.closing) })
+	<-l.done
+}
+
+// run writes the queued events to the sink until the logger is closed. The failures to write the events are
+// logged, they don't fail the audited requests.
+func (l *Logger) run() {
+	defer close(l.done)
+	for {
+		select {
+		case event := <-l.queue:
+			l.write(event)
+			if len(l.queue) == 0 {
+				l.writeDropped()
+			}
+		case <-l.closing:
+			for {
+				select {
+				case event := <-l.queue:
+					l.write(event)
+				default:
+					l.writeDropped()
+					return
+				}
+			}
+		}
+	}
+}
+
+// writeDropped writes the number of the events dropped since the last time it was written, if any
+func (l *Logger) writeDropped() {
+	dropped := atomic.SwapInt64(&l.dropped, 0)
+	if dropped == 0 {
+		return
+	}
+	l.write(Event{
+		Time:      l.now().UTC(),
+		AppID:     l.appID,
+		Operation: EventsDropped,
+		Result:    ResultFailure,
+		Dropped:   dropped,
+	})
+}
+
+func (l *Logger) write(event Event) {
+	if err := l.sink.Write(event); err != nil {
+		log.Errorf("error writing the audit event of %s on %s: %s", event.Operation, event.Store, err)
+	}
+}
@@ -0,0 +1,184 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSink struct {
+	events []Event
+	err    error
+}
+
+func (s *fakeSink) Write(event Event) error {
+	s.events = append(s.events, event)
+	return s.err
+}
+
+// blockingSink blocks the writes until it is released
+type blockingSink struct {
+	release chan struct{}
+	written int
+	events  []Event
+}
+
+func (s *blockingSink) Write(event Event) error {
+	<-s.release
+	s.written++
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+	now := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("stamps the time and the app id", func(t *testing.T) {
+		sink := &fakeSink{}
+		l := NewLogger("app1", sink, false)
+		l.now = func() time.Time { return now }
+
+		l.Record(Event{Operation: GetSecret, Store: "vault", Keys: []string{"db"}, Result: ResultSuccess})
+		l.Close()
+		assert.Equal(t, []Event{{
+			Time:      now,
+			AppID:     "app1",
+			Operation: GetSecret,
+			Store:     "vault",
+			Keys:      []string{"db"},
+			Result:    ResultSuccess,
+		}}, sink.events)
+	})
+
+	t.Run("sink errors are ignored", func(t *testing.T) {
+		sink := &fakeSink{err: errors.New("unavailable")}
+		l := NewLogger("app1", sink, false)
+
+		assert.NotPanics(t, func() { l.Record(Event{Operation: DeleteState}) })
+		l.Close()
+		assert.Len(t, sink.events, 1)
+	})
+
+	t.Run("events are written in order", func(t *testing.T) {
+		sink := &fakeSink{}
+		l := NewLogger("app1", sink, false)
+
+		l.Record(Event{Operation: GetSecret})
+		l.Record(Event{Operation: DeleteState})
+		l.Record(Event{Operation: BulkGetSecret})
+		l.Close()
+		assert.Len(t, sink.events, 3)
+		assert.Equal(t, GetSecret, sink.events[0].Operation)
+		assert.Equal(t, DeleteState, sink.events[1].Operation)
+		assert.Equal(t, BulkGetSecret, sink.events[2].Operation)
+	})
+
+	t.Run("full queue drops the events", func(t *testing.T) {
+		sink := &blockingSink{release: make(chan struct{})}
+		l := NewLogger("app1", sink, false)
+
+		for i := 0; i < QueueSize+10; i++ {
+			l.Record(Event{Operation: DeleteState})
+		}
+		close(sink.release)
+		l.Close()
+		// the first event may be taken from the queue before it's full, the last write is the dropped events
+		assert.True(t, sink.written <= QueueSize+2)
+		assert.True(t, sink.written >= QueueSize+1)
+		last := sink.events[len(sink.events)-1]
+		assert.Equal(t, EventsDropped, last.Operation)
+		assert.Equal(t, int64(QueueSize+10), int64(sink.written-1)+last.Dropped)
+	})
+
+	t.Run("blocking logger waits for room in the queue", func(t *testing.T) {
+		sink := &blockingSink{release: make(chan struct{})}
+		l := NewLogger("app1", sink, true)
+
+		recorded := make(chan struct{})
+		go func() {
+			for i := 0; i < QueueSize+10; i++ {
+				l.Record(Event{Operation: DeleteState})
+			}
+			close(recorded)
+		}()
+		select {
+		case <-recorded:
+			t.Fatal("the events were recorded while the queue was full")
+		case <-time.After(100 * time.Millisecond):
+		}
+		close(sink.release)
+		<-recorded
+		l.Close()
+		assert.Equal(t, QueueSize+10, sink.written)
+		for _, event := range sink.events {
+			assert.Equal(t, DeleteState, event.Operation)
+		}
+	})
+
+	t.Run("closed logger drops the events", func(t *testing.T) {
+		sink := &fakeSink{}
+		l := NewLogger("app1", sink, false)
+		l.Close()
+
+		assert.NotPanics(t, func() { l.Record(Event{Operation: DeleteState}) })
+		assert.NotPanics(t, l.Close)
+		assert.Empty(t, sink.events)
+	})
+
+	t.Run("nil logger", func(t *testing.T) {
+		var l *Logger
+		assert.NotPanics(t, func() { l.Record(Event{Operation: DeleteState}) })
+		assert.NotPanics(t, l.Close)
+	})
+}
+
+func TestNewSink(t *testing.T) {
+	var sent []*bindings.WriteRequest
+	var sentTo string
+	sendFn := func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) {
+		sentTo = name
+		sent = append(sent, req)
+		return nil, nil
+	}
+
+	t.Run("log sink by default", func(t *testing.T) {
+		sink, err := NewSink("", "", sendFn)
+		assert.NoError(t, err)
+		assert.IsType(t, &logSink{}, sink)
+		assert.NoError(t, sink.Write(Event{Operation: GetSecret}))
+	})
+
+	t.Run("binding sink", func(t *testing.T) {
+		sink, err := NewSink(BindingSink, "audit-queue", sendFn)
+		assert.NoError(t, err)
+
+		event := Event{AppID: "app1", Operation: BulkDeleteState, Store: "store1", Keys: []string{"a", "b"}, Result: ResultDenied}
+		assert.NoError(t, sink.Write(event))
+		assert.Equal(t, "audit-queue", sentTo)
+		assert.Len(t, sent, 1)
+
+		var written Event
+		assert.NoError(t, json.Unmarshal(sent[0].Data, &written))
+		assert.Equal(t, event, written)
+	})
+
+	t.Run("binding sink without binding name", func(t *testing.T) {
+		_, err := NewSink(BindingSink, "", sendFn)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown sink", func(t *testing.T) {
+		_, err := NewSink("syslog", "", sendFn)
+		assert.Error(t, err)
+	})
+}
@@ -7,6 +7,7 @@ package channel
 
 import (
 	"context"
+	"io"
 	"time"
 
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
@@ -25,3 +26,11 @@ type AppChannel interface {
 	GetBaseAddress() string
 	InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error)
 }
+
+// StreamingAppChannel is an AppChannel which can stream the body of the responses of user code
+type StreamingAppChannel interface {
+	AppChannel
+	// InvokeMethodStream returns the status and headers of the response, and its body as it is received.
+	// The caller must close the body.
+	InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, io.ReadCloser, error)
+}
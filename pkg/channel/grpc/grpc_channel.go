@@ -8,6 +8,8 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/config"
@@ -31,9 +33,18 @@ type Channel struct {
 
 // CreateLocalChannel creates a gRPC connection with user code
 func CreateLocalChannel(port, maxConcurrency int, conn *grpc.ClientConn, spec config.TracingSpec) *Channel {
+	return newChannel(net.JoinHostPort(channel.DefaultChannelAddress, strconv.Itoa(port)), maxConcurrency, conn, spec)
+}
+
+// CreateUnixChannel creates a gRPC AppChannel over the connection to user code listening on a Unix domain socket
+func CreateUnixChannel(socket string, maxConcurrency int, conn *grpc.ClientConn, spec config.TracingSpec) *Channel {
+	return newChannel("unix://"+socket, maxConcurrency, conn, spec)
+}
+
+func newChannel(baseAddress string, maxConcurrency int, conn *grpc.ClientConn, spec config.TracingSpec) *Channel {
 	c := &Channel{
 		client:      conn,
-		baseAddress: fmt.Sprintf("%s:%d", channel.DefaultChannelAddress, port),
+		baseAddress: baseAddress,
 		tracingSpec: spec,
 	}
 	if maxConcurrency > 0 {
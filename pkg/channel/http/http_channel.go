@@ -6,20 +6,28 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
+	"net"
+	nethttp "net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/logger"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	auth "github.com/dapr/dapr/pkg/runtime/security"
 	"github.com/valyala/fasthttp"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -28,17 +36,56 @@ const (
 	HTTPStatusCode = "http.status_code"
 )
 
+var log = logger.NewLogger("dapr.runtime.channel.http")
+
 // Channel is an HTTP implementation of an AppChannel
 type Channel struct {
-	client      *fasthttp.Client
-	baseAddress string
-	ch          chan int
-	tracingSpec config.TracingSpec
+	client *fasthttp.Client
+	// streamClient is used for the requests which stream the response body, as fasthttp buffers it
+	streamClient *nethttp.Client
+	baseAddress  string
+	ch           chan int
+	tracingSpec  config.TracingSpec
+	headers      map[string]string
+	// headerLimits are the limits of the headers forwarded from the metadata of the requests
+	headerLimits invokev1.AppHeaderLimits
+	// appToken is sent to the app in the dapr-api-token header so it can check the requests come from its sidecar
+	appToken string
+}
+
+// CreateLocalChannel creates an HTTP AppChannel, over HTTPS with the TLS config when it's not nil
+func CreateLocalChannel(port, maxConcurrency int, headerLimits invokev1.AppHeaderLimits, tlsConfig *tls.Config, spec config.TracingSpec) (channel.AppChannel, error) {
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	baseAddress := fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(channel.DefaultChannelAddress, strconv.Itoa(port)))
+	c := newLocalChannel(baseAddress, maxConcurrency, headerLimits, spec, &nethttp.Transport{})
+	if tlsConfig != nil {
+		c.client.TLSConfig = tlsConfig
+		c.streamClient.Transport.(*nethttp.Transport).TLSClientConfig = tlsConfig
+	}
+	return c, nil
+}
+
+// CreateUnixChannel creates an HTTP AppChannel to an app listening on a Unix domain socket
+func CreateUnixChannel(socket string, maxConcurrency int, headerLimits invokev1.AppHeaderLimits, spec config.TracingSpec) (channel.AppChannel, error) {
+	transport := &nethttp.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		},
+	}
+	c := newLocalChannel(fmt.Sprintf("http://%s", channel.DefaultChannelAddress), maxConcurrency, headerLimits, spec, transport)
+	c.client.Dial = func(addr string) (net.Conn, error) {
+		return net.Dial("unix", socket)
+	}
+	return c, nil
 }
 
-// CreateLocalChannel creates an HTTP AppChannel
 // nolint:gosec
-func CreateLocalChannel(port, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error) {
+func newLocalChannel(baseAddress string, maxConcurrency int, headerLimits invokev1.AppHeaderLimits, spec config.TracingSpec, streamTransport *nethttp.Transport) *Channel {
+	streamTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	c := &Channel{
 		client: &fasthttp.Client{
 			MaxConnsPerHost:           1000000,
@@ -46,13 +93,38 @@ func CreateLocalChannel(port, maxConcurrency int, spec config.TracingSpec) (chan
 			ReadTimeout:               channel.DefaultChannelRequestTimeout,
 			MaxIdemponentCallAttempts: 0,
 		},
-		baseAddress: fmt.Sprintf("http://%s:%d", channel.DefaultChannelAddress, port),
-		tracingSpec: spec,
+		streamClient: &nethttp.Client{
+			Transport: streamTransport,
+		},
+		baseAddress:  baseAddress,
+		tracingSpec:  spec,
+		headerLimits: headerLimits,
+		appToken:     auth.GetAppToken(),
 	}
 
 	if maxConcurrency > 0 {
 		c.ch = make(chan int, maxConcurrency)
 	}
+	return c
+}
+
+// CreateExternalChannel creates an HTTP AppChannel to an external, non-Dapr HTTP endpoint.
+// The given headers are added to every request sent through the channel.
+func CreateExternalChannel(baseAddress string, tlsConfig *tls.Config, headers map[string]string, spec config.TracingSpec) (channel.AppChannel, error) {
+	c := &Channel{
+		client: &fasthttp.Client{
+			MaxConnsPerHost:           1000000,
+			TLSConfig:                 tlsConfig,
+			ReadTimeout:               channel.DefaultChannelRequestTimeout,
+			MaxIdemponentCallAttempts: 0,
+		},
+		streamClient: &nethttp.Client{
+			Transport: &nethttp.Transport{TLSClientConfig: tlsConfig},
+		},
+		baseAddress: strings.TrimSuffix(baseAddress, "/"),
+		tracingSpec: spec,
+		headers:     headers,
+	}
 	return c, nil
 }
 
@@ -117,6 +189,67 @@ func (h *Channel) invokeMethodV1(ctx context.Context, req *invokev1.InvokeMethod
 	return rsp, nil
 }
 
+// InvokeMethodStream invokes user code via HTTP and returns the body of the response as it is received
+func (h *Channel) InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	httpExt := req.Message().GetHttpExtension()
+	if httpExt == nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "missing HTTP extension field")
+	}
+	if httpExt.GetVerb() == commonv1pb.HTTPExtension_NONE {
+		return nil, nil, status.Error(codes.InvalidArgument, "invalid HTTP verb")
+	}
+	if req.APIVersion() != internalv1pb.APIVersion_V1 {
+		return nil, nil, status.Error(codes.Unimplemented, fmt.Sprintf("Unsupported spec version: %d", req.APIVersion()))
+	}
+
+	channelReq := h.constructRequest(ctx, req)
+	defer fasthttp.ReleaseRequest(channelReq)
+
+	httpReq, err := nethttp.NewRequestWithContext(ctx, string(channelReq.Header.Method()), channelReq.URI().String(), bytes.NewReader(channelReq.Body()))
+	if err != nil {
+		return nil, nil, err
+	}
+	channelReq.Header.VisitAll(func(key []byte, value []byte) {
+		httpReq.Header.Add(string(key), string(value))
+	})
+
+	if h.ch != nil {
+		h.ch <- 1
+	}
+	httpResp, err := h.streamClient.Do(httpReq)
+	if err != nil {
+		if h.ch != nil {
+			<-h.ch
+		}
+		return nil, nil, err
+	}
+
+	// the body is re-framed by the receiver of the stream
+	headers := httpResp.Header.Clone()
+	headers.Del("Content-Length")
+	headers.Del("Transfer-Encoding")
+	headers.Del("Connection")
+
+	rsp := invokev1.NewInvokeMethodResponse(int32(httpResp.StatusCode), "", nil)
+	rsp.WithHeaders(metadata.MD(headers)).WithRawData(nil, httpResp.Header.Get("Content-Type"))
+	return rsp, &streamBody{ReadCloser: httpResp.Body, ch: h.ch}, nil
+}
+
+// streamBody releases the concurrency slot of the request once the response body is closed
+type streamBody struct {
+	io.ReadCloser
+	ch chan int
+}
+
+func (b *streamBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.ch != nil {
+		<-b.ch
+		b.ch = nil
+	}
+	return err
+}
+
 func (h *Channel) constructRequest(ctx context.Context, req *invokev1.InvokeMethodRequest) *fasthttp.Request {
 	var channelReq = fasthttp.AcquireRequest()
 
@@ -127,7 +260,15 @@ func (h *Channel) constructRequest(ctx context.Context, req *invokev1.InvokeMeth
 	channelReq.Header.SetMethod(req.Message().HttpExtension.Verb.String())
 
 	// Recover headers
-	invokev1.InternalMetadataToHTTPHeader(req.Metadata(), channelReq.Header.Set)
+	if trimmed := invokev1.InternalMetadataToLimitedHTTPHeader(req.Metadata(), h.headerLimits, channelReq.Header.Set); len(trimmed) > 0 {
+		log.Warnf("headers %v of the request to %s exceed the limits of %d headers and %d bytes and are trimmed", trimmed, req.Message().GetMethod(), h.headerLimits.MaxCount, h.headerLimits.MaxTotalSize)
+	}
+	for k, v := range h.headers {
+		channelReq.Header.Set(k, v)
+	}
+	if h.appToken != "" {
+		channelReq.Header.Set(auth.APITokenHeader, h.appToken)
+	}
 
 	sc := diag.FromContext(ctx)
 	diag.SpanContextToRequest(sc, channelReq)
@@ -7,10 +7,17 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -210,6 +217,82 @@ func TestInvokeWithHeaders(t *testing.T) {
 	testServer.Close()
 }
 
+func TestInvokeWithHeaderLimits(t *testing.T) {
+	ctx := context.Background()
+	testServer := httptest.NewServer(&testHandlerHeaders{})
+	defer testServer.Close()
+	c := Channel{baseAddress: testServer.URL, client: &fasthttp.Client{}, headerLimits: invokev1.NewAppHeaderLimits(1, 100)}
+
+	req := invokev1.NewInvokeMethodRequest("method")
+	req.WithMetadata(map[string][]string{
+		"H1": {"v1"},
+		"H2": {"v2"},
+	})
+	req.WithHTTPExtension(http.MethodPost, "")
+
+	// act
+	response, err := c.InvokeMethod(ctx, req)
+
+	// assert
+	assert.NoError(t, err)
+	_, body := response.RawData()
+
+	actual := map[string]string{}
+	json.Unmarshal(body, &actual)
+	assert.Equal(t, "v1", actual["H1"])
+	assert.NotContains(t, actual, "H2")
+}
+
+func TestInvokeWithAppToken(t *testing.T) {
+	os.Setenv("APP_API_TOKEN", "token1")
+	defer os.Unsetenv("APP_API_TOKEN")
+
+	ctx := context.Background()
+	testServer := httptest.NewServer(&testHandlerHeaders{})
+	defer testServer.Close()
+	c := newLocalChannel(testServer.URL, 0, invokev1.AppHeaderLimits{}, config.TracingSpec{}, &http.Transport{})
+
+	req := invokev1.NewInvokeMethodRequest("method")
+	req.WithHTTPExtension(http.MethodPost, "")
+
+	// act
+	response, err := c.InvokeMethod(ctx, req)
+
+	// assert
+	assert.NoError(t, err)
+	_, body := response.RawData()
+
+	actual := map[string]string{}
+	json.Unmarshal(body, &actual)
+	assert.Equal(t, "token1", actual["Dapr-Api-Token"])
+}
+
+func TestExternalChannelHeaders(t *testing.T) {
+	ctx := context.Background()
+	testServer := httptest.NewServer(&testHandlerHeaders{})
+	defer testServer.Close()
+
+	c, err := CreateExternalChannel(testServer.URL+"/", nil, map[string]string{"Authorization": "token"}, config.TracingSpec{})
+	assert.NoError(t, err)
+	assert.Equal(t, testServer.URL, c.GetBaseAddress())
+
+	req := invokev1.NewInvokeMethodRequest("method")
+	req.WithMetadata(map[string][]string{"H1": {"v1"}})
+	req.WithHTTPExtension(http.MethodGet, "")
+
+	// act
+	response, err := c.InvokeMethod(ctx, req)
+
+	// assert
+	assert.NoError(t, err)
+	_, body := response.RawData()
+
+	actual := map[string]string{}
+	json.Unmarshal(body, &actual)
+	assert.Equal(t, "token", actual["Authorization"])
+	assert.Equal(t, "v1", actual["H1"])
+}
+
 func TestContentType(t *testing.T) {
 	ctx := context.Background()
 	t.Run("default application/json", func(t *testing.T) {
@@ -269,3 +352,88 @@ func TestContentType(t *testing.T) {
 		testServer.Close()
 	})
 }
+
+func TestInvokeMethodStream(t *testing.T) {
+	ctx := context.Background()
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		for i := 0; i < 3; i++ {
+			io.WriteString(w, "chunk")
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer testServer.Close()
+
+	c, err := CreateLocalChannel(0, 1, invokev1.AppHeaderLimits{}, nil, config.TracingSpec{})
+	assert.NoError(t, err)
+	c.(*Channel).baseAddress = testServer.URL
+
+	req := invokev1.NewInvokeMethodRequest("method")
+	req.WithHTTPExtension(http.MethodGet, "")
+
+	// act
+	response, body, err := c.(*Channel).InvokeMethodStream(ctx, req)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, int32(200), response.Status().Code)
+	assert.Equal(t, "text/plain", response.Message().GetContentType())
+	assert.NotContains(t, response.Headers(), "Transfer-Encoding")
+	data, err := ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "chunkchunkchunk", string(data))
+	assert.NoError(t, body.Close())
+	assert.Len(t, c.(*Channel).ch, 0)
+}
+
+func TestInvokeMethodOverTLS(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "secure")
+	}))
+	defer testServer.Close()
+
+	tlsConfig := &tls.Config{RootCAs: x509.NewCertPool()}
+	tlsConfig.RootCAs.AddCert(testServer.Certificate())
+	port := testServer.Listener.Addr().(*net.TCPAddr).Port
+	c, err := CreateLocalChannel(port, 1, invokev1.AppHeaderLimits{}, tlsConfig, config.TracingSpec{})
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("https://127.0.0.1:%d", port), c.GetBaseAddress())
+
+	req := invokev1.NewInvokeMethodRequest("method")
+	req.WithHTTPExtension(http.MethodGet, "")
+
+	// act
+	response, err := c.InvokeMethod(context.Background(), req)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, int32(200), response.Status().Code)
+	_, body := response.RawData()
+	assert.Equal(t, "secure", string(body))
+}
+
+func TestInvokeMethodOverUnixDomainSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dapr-uds")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "app.socket")
+	lis, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+	server := &http.Server{Handler: &testContentTypeHandler{}}
+	go server.Serve(lis)
+	defer server.Close()
+
+	c, err := CreateUnixChannel(socket, 0, invokev1.AppHeaderLimits{}, config.TracingSpec{SamplingRate: "0"})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1", c.GetBaseAddress())
+
+	fakeReq := invokev1.NewInvokeMethodRequest("method")
+	fakeReq.WithHTTPExtension(http.MethodPost, "")
+	fakeReq.WithRawData([]byte("{}"), "application/json")
+
+	response, err := c.InvokeMethod(context.Background(), fakeReq)
+	assert.NoError(t, err)
+	_, body := response.RawData()
+	assert.Equal(t, "application/json", string(body))
+}
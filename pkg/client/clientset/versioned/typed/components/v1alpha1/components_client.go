@@ -27,6 +27,7 @@ import (
 type ComponentsV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ComponentsGetter
+	SubscriptionsGetter
 }
 
 // ComponentsV1alpha1Client is used to interact with features provided by the components.dapr.io group.
@@ -38,6 +39,10 @@ func (c *ComponentsV1alpha1Client) Components(namespace string) ComponentInterfa
 	return newComponents(c, namespace)
 }
 
+func (c *ComponentsV1alpha1Client) Subscriptions(namespace string) SubscriptionInterface {
+	return newSubscriptions(c, namespace)
+}
+
 // NewForConfig creates a new ComponentsV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*ComponentsV1alpha1Client, error) {
 	config := *c
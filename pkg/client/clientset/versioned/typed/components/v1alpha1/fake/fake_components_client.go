@@ -32,6 +32,10 @@ func (c *FakeComponentsV1alpha1) Components(namespace string) v1alpha1.Component
 	return &FakeComponents{c, namespace}
 }
 
+func (c *FakeComponentsV1alpha1) Subscriptions(namespace string) v1alpha1.SubscriptionInterface {
+	return &FakeSubscriptions{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeComponentsV1alpha1) RESTClient() rest.Interface {
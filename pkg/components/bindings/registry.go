@@ -7,6 +7,7 @@ package bindings
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/dapr/components-contrib/bindings"
 )
@@ -33,6 +34,7 @@ type (
 	}
 
 	bindingsRegistry struct {
+		lock           sync.RWMutex
 		inputBindings  map[string]func() bindings.InputBinding
 		outputBindings map[string]func() bindings.OutputBinding
 	}
@@ -64,6 +66,8 @@ func NewRegistry() Registry {
 
 // RegisterInputBindings registers one or more new input bindings.
 func (b *bindingsRegistry) RegisterInputBindings(components ...InputBinding) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
 	for _, component := range components {
 		b.inputBindings[createFullName(component.Name)] = component.FactoryMethod
 	}
@@ -71,6 +75,8 @@ func (b *bindingsRegistry) RegisterInputBindings(components ...InputBinding) {
 
 // RegisterOutputBindings registers one or more new output bindings.
 func (b *bindingsRegistry) RegisterOutputBindings(components ...OutputBinding) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
 	for _, component := range components {
 		b.outputBindings[createFullName(component.Name)] = component.FactoryMethod
 	}
@@ -78,7 +84,10 @@ func (b *bindingsRegistry) RegisterOutputBindings(components ...OutputBinding) {
 
 // Create instantiates an input binding based on `name`.
 func (b *bindingsRegistry) CreateInputBinding(name string) (bindings.InputBinding, error) {
-	if method, ok := b.inputBindings[name]; ok {
+	b.lock.RLock()
+	method, ok := b.inputBindings[name]
+	b.lock.RUnlock()
+	if ok {
 		return method(), nil
 	}
 	return nil, fmt.Errorf("couldn't find input binding %s", name)
@@ -86,7 +95,10 @@ func (b *bindingsRegistry) CreateInputBinding(name string) (bindings.InputBindin
 
 // Create instantiates an output binding based on `name`.
 func (b *bindingsRegistry) CreateOutputBinding(name string) (bindings.OutputBinding, error) {
-	if method, ok := b.outputBindings[name]; ok {
+	b.lock.RLock()
+	method, ok := b.outputBindings[name]
+	b.lock.RUnlock()
+	if ok {
 		return method(), nil
 	}
 	return nil, fmt.Errorf("couldn't find output binding %s", name)
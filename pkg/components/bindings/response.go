@@ -0,0 +1,25 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"github.com/dapr/components-contrib/bindings"
+)
+
+// InvokeResponse is the response of an output binding operation, such as the IDs generated by the
+// binding or the status code returned by the target of the binding
+type InvokeResponse struct {
+	Data     []byte
+	Metadata map[string]string
+}
+
+// InvokableOutputBinding is an output binding returning the response of its operations to the caller.
+// Output bindings only implementing Write return an empty response. The output bindings served by pluggable
+// component processes return the response of the process.
+type InvokableOutputBinding interface {
+	bindings.OutputBinding
+	Invoke(req *bindings.WriteRequest) (*InvokeResponse, error)
+}
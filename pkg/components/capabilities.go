@@ -0,0 +1,30 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package components
+
+// The features of the components supported by the APIs of the runtime, derived from the interfaces the
+// components implement and from their metadata
+const (
+	// CapabilityTransactional is reported by the state stores supporting transactions
+	CapabilityTransactional = "TRANSACTIONAL"
+	// CapabilityQueryAPI is reported by the state stores supporting the query API
+	CapabilityQueryAPI = "QUERY_API"
+	// CapabilityTTL is reported by the state stores declaring that they expire the keys saved with a TTL natively,
+	// or enabling the emulation of the expiry by the runtime
+	CapabilityTTL = "TTL"
+	// CapabilityBulkGet is reported by the state and secret stores fetching several keys at once natively
+	CapabilityBulkGet = "BULK_GET"
+	// CapabilityPrefixDelete is reported by the state stores allowing the deletion of key prefixes
+	CapabilityPrefixDelete = "PREFIX_DELETE"
+	// CapabilityBulkPublish is reported by the pub/subs publishing batches of messages natively
+	CapabilityBulkPublish = "BULK_PUBLISH"
+	// CapabilityInputBinding is reported by the bindings read by the runtime
+	CapabilityInputBinding = "INPUT_BINDING"
+	// CapabilityOutputBinding is reported by the bindings invoked by the runtime
+	CapabilityOutputBinding = "OUTPUT_BINDING"
+	// CapabilityInvokeResponse is reported by the output bindings returning the response of their operations
+	CapabilityInvokeResponse = "INVOKE_RESPONSE"
+)
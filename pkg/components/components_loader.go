@@ -11,3 +11,8 @@ import components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
 type ComponentLoader interface {
 	LoadComponents() ([]components_v1alpha1.Component, error)
 }
+
+// SubscriptionLoader is an interface for returning declarative Dapr subscriptions
+type SubscriptionLoader interface {
+	LoadSubscriptions() ([]components_v1alpha1.Subscription, error)
+}
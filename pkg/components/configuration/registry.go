@@ -0,0 +1,65 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package configuration
+
+import (
+	"fmt"
+
+	"github.com/dapr/dapr/pkg/configuration"
+)
+
+type (
+	// Configuration is a configuration store component definition.
+	Configuration struct {
+		Name          string
+		FactoryMethod func() configuration.Store
+	}
+
+	// Registry is used to get registered configuration store implementations
+	Registry interface {
+		Register(components ...Configuration)
+		Create(name string) (configuration.Store, error)
+	}
+
+	configurationStoreRegistry struct {
+		configurationStores map[string]func() configuration.Store
+	}
+)
+
+// New creates a Configuration.
+func New(name string, factoryMethod func() configuration.Store) Configuration {
+	return Configuration{
+		Name:          name,
+		FactoryMethod: factoryMethod,
+	}
+}
+
+// NewRegistry returns a new configuration store registry.
+func NewRegistry() Registry {
+	return &configurationStoreRegistry{
+		configurationStores: map[string]func() configuration.Store{},
+	}
+}
+
+// Register adds one or many new configuration stores to the registry.
+func (s *configurationStoreRegistry) Register(components ...Configuration) {
+	for _, component := range components {
+		s.configurationStores[createFullName(component.Name)] = component.FactoryMethod
+	}
+}
+
+// Create instantiates a configuration store based on `name`.
+func (s *configurationStoreRegistry) Create(name string) (configuration.Store, error) {
+	if method, ok := s.configurationStores[name]; ok {
+		return method(), nil
+	}
+
+	return nil, fmt.Errorf("couldn't find configuration store %s", name)
+}
+
+func createFullName(name string) string {
+	return fmt.Sprintf("configuration.%s", name)
+}
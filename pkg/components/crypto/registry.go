@@ -0,0 +1,65 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/dapr/dapr/pkg/crypto"
+)
+
+type (
+	// KeyVault is a key vault component definition.
+	KeyVault struct {
+		Name          string
+		FactoryMethod func() crypto.KeyVault
+	}
+
+	// Registry is used to get registered key vault implementations
+	Registry interface {
+		Register(components ...KeyVault)
+		Create(name string) (crypto.KeyVault, error)
+	}
+
+	keyVaultRegistry struct {
+		keyVaults map[string]func() crypto.KeyVault
+	}
+)
+
+// New creates a KeyVault.
+func New(name string, factoryMethod func() crypto.KeyVault) KeyVault {
+	return KeyVault{
+		Name:          name,
+		FactoryMethod: factoryMethod,
+	}
+}
+
+// NewRegistry returns a new key vault registry.
+func NewRegistry() Registry {
+	return &keyVaultRegistry{
+		keyVaults: map[string]func() crypto.KeyVault{},
+	}
+}
+
+// Register adds one or many new key vaults to the registry.
+func (s *keyVaultRegistry) Register(components ...KeyVault) {
+	for _, component := range components {
+		s.keyVaults[createFullName(component.Name)] = component.FactoryMethod
+	}
+}
+
+// Create instantiates a key vault based on `name`.
+func (s *keyVaultRegistry) Create(name string) (crypto.KeyVault, error) {
+	if method, ok := s.keyVaults[name]; ok {
+		return method(), nil
+	}
+
+	return nil, fmt.Errorf("couldn't find key vault %s", name)
+}
+
+func createFullName(name string) string {
+	return fmt.Sprintf("crypto.%s", name)
+}
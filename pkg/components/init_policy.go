@@ -0,0 +1,153 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package components
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+const (
+	// DefaultInitTimeout is the time allowed to an attempt to initialize a component without an initTimeout
+	DefaultInitTimeout = 30 * time.Second
+	// DefaultInitRetryBackoff is the wait before the first retry of a component without an initRetryBackoff
+	DefaultInitRetryBackoff = time.Second
+
+	maxInitRetryBackoff = time.Minute
+)
+
+const (
+	// InitOnStartup initializes a component when the runtime starts
+	InitOnStartup = "startup"
+	// InitOnLazy initializes a component on its first use
+	InitOnLazy = "lazy"
+)
+
+const (
+	// StatusInitialized is the status of a component which initialized successfully
+	StatusInitialized = "initialized"
+	// StatusFailed is the status of a component which failed to initialize
+	StatusFailed = "failed"
+	// StatusPending is the status of a lazy component which isn't used yet
+	StatusPending = "pending"
+)
+
+// Status is the initialization status of a component, along with the capabilities of the initialized component
+type Status struct {
+	Name            string
+	Type            string
+	Version         string
+	Status          string
+	Error           string
+	FailOnInitError bool
+	Capabilities    []string
+}
+
+// IsLazy returns whether a component is initialized on its first use
+func IsLazy(c components_v1alpha1.Component) bool {
+	return c.Spec.InitOn == InitOnLazy
+}
+
+// InitPolicy is how the runtime initializes a component
+type InitPolicy struct {
+	Timeout      time.Duration
+	Retries      int
+	RetryBackoff time.Duration
+	// FailOnInitError stops the runtime from starting when the component fails to initialize, the failures are
+	// logged and the runtime starts without the component otherwise
+	FailOnInitError bool
+}
+
+// GetInitPolicy returns the init policy declared in the spec of a component
+func GetInitPolicy(c components_v1alpha1.Component) (InitPolicy, error) {
+	policy := InitPolicy{
+		Timeout:         DefaultInitTimeout,
+		Retries:         c.Spec.InitRetries,
+		RetryBackoff:    DefaultInitRetryBackoff,
+		FailOnInitError: c.Spec.FailOnInitError,
+	}
+	if c.Spec.InitOn != "" && c.Spec.InitOn != InitOnStartup && c.Spec.InitOn != InitOnLazy {
+		return policy, fmt.Errorf("invalid initOn %q for component %s", c.Spec.InitOn, c.ObjectMeta.Name)
+	}
+	if policy.Retries < 0 {
+		return policy, fmt.Errorf("invalid initRetries %d for component %s", policy.Retries, c.ObjectMeta.Name)
+	}
+	if c.Spec.InitTimeout != "" {
+		timeout, err := time.ParseDuration(c.Spec.InitTimeout)
+		if err != nil || timeout <= 0 {
+			return policy, fmt.Errorf("invalid initTimeout %q for component %s", c.Spec.InitTimeout, c.ObjectMeta.Name)
+		}
+		policy.Timeout = timeout
+	}
+	if c.Spec.InitRetryBackoff != "" {
+		backoff, err := time.ParseDuration(c.Spec.InitRetryBackoff)
+		if err != nil || backoff < 0 {
+			return policy, fmt.Errorf("invalid initRetryBackoff %q for component %s", c.Spec.InitRetryBackoff, c.ObjectMeta.Name)
+		}
+		policy.RetryBackoff = backoff
+	}
+	return policy, nil
+}
+
+// Init initializes instance, or a new instance returned by create if instance is nil, and a new instance for each
+// retry as a failed attempt may leave its instance half initialized, until an attempt succeeds or the retries are exhausted. It waits a doubling backoff
+// between attempts and returns the initialized instance.
+// An attempt which doesn't return within the timeout fails without further retries. The initialization can't be
+// cancelled, so the instance of the attempt is closed if the attempt eventually succeeds, which releases the
+// connections it opened.
+func (p InitPolicy) Init(instance interface{}, create func() (interface{}, error), init func(instance interface{}) error) (interface{}, error) {
+	backoff := p.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 || instance == nil {
+			var err error
+			instance, err = create()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		errCh := make(chan error, 1)
+		go func(instance interface{}) {
+			errCh <- init(instance)
+		}(instance)
+
+		select {
+		case err := <-errCh:
+			if err == nil {
+				return instance, nil
+			}
+			if attempt >= p.Retries {
+				return nil, err
+			}
+			log.Warnf("component initialization failed, retrying in %s: %s", backoff, err)
+		case <-time.After(p.Timeout):
+			go func(instance interface{}) {
+				if err := <-errCh; err == nil {
+					closeInstance(instance)
+				}
+			}(instance)
+			return nil, fmt.Errorf("initialization timed out after %s", p.Timeout)
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxInitRetryBackoff {
+			backoff = maxInitRetryBackoff
+		}
+	}
+}
+
+// closeInstance closes an initialized instance of a component which isn't used
+func closeInstance(instance interface{}) {
+	if closer, ok := instance.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Warnf("error closing component instance: %s", err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package components
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInitPolicy(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		policy, err := GetInitPolicy(components_v1alpha1.Component{})
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultInitTimeout, policy.Timeout)
+		assert.Equal(t, DefaultInitRetryBackoff, policy.RetryBackoff)
+		assert.Equal(t, 0, policy.Retries)
+		assert.False(t, policy.FailOnInitError)
+	})
+
+	t.Run("from spec", func(t *testing.T) {
+		policy, err := GetInitPolicy(components_v1alpha1.Component{
+			Spec: components_v1alpha1.ComponentSpec{
+				InitTimeout:      "5s",
+				InitRetries:      3,
+				InitRetryBackoff: "100ms",
+				FailOnInitError:  true,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Second, policy.Timeout)
+		assert.Equal(t, 100*time.Millisecond, policy.RetryBackoff)
+		assert.Equal(t, 3, policy.Retries)
+		assert.True(t, policy.FailOnInitError)
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		_, err := GetInitPolicy(components_v1alpha1.Component{
+			Spec: components_v1alpha1.ComponentSpec{InitTimeout: "soon"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid initOn", func(t *testing.T) {
+		_, err := GetInitPolicy(components_v1alpha1.Component{
+			Spec: components_v1alpha1.ComponentSpec{InitOn: "sometimes"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("negative retries", func(t *testing.T) {
+		_, err := GetInitPolicy(components_v1alpha1.Component{
+			Spec: components_v1alpha1.ComponentSpec{InitRetries: -1},
+		})
+		assert.Error(t, err)
+	})
+}
+
+type fakeInstance struct {
+	id     int
+	closed chan struct{}
+}
+
+func (f *fakeInstance) Close() error {
+	close(f.closed)
+	return nil
+}
+
+func TestInitPolicyInit(t *testing.T) {
+	newCreate := func() (func() (interface{}, error), *int) {
+		created := 0
+		return func() (interface{}, error) {
+			created++
+			return &fakeInstance{id: created, closed: make(chan struct{})}, nil
+		}, &created
+	}
+
+	t.Run("retries with new instances until success", func(t *testing.T) {
+		policy := InitPolicy{Timeout: time.Second, Retries: 2, RetryBackoff: time.Millisecond}
+		create, created := newCreate()
+		initialized := []int{}
+		instance, err := policy.Init(&fakeInstance{}, create, func(instance interface{}) error {
+			initialized = append(initialized, instance.(*fakeInstance).id)
+			if len(initialized) < 3 {
+				return errors.New("unreachable")
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2}, initialized)
+		assert.Equal(t, 2, *created)
+		assert.Equal(t, 2, instance.(*fakeInstance).id)
+	})
+
+	t.Run("creates the first instance when none is given", func(t *testing.T) {
+		policy := InitPolicy{Timeout: time.Second}
+		create, created := newCreate()
+		instance, err := policy.Init(nil, create, func(instance interface{}) error {
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *created)
+		assert.Equal(t, 1, instance.(*fakeInstance).id)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		policy := InitPolicy{Timeout: time.Second, Retries: 1, RetryBackoff: time.Millisecond}
+		create, _ := newCreate()
+		calls := 0
+		_, err := policy.Init(&fakeInstance{}, create, func(instance interface{}) error {
+			calls++
+			return errors.New("unreachable")
+		})
+		assert.EqualError(t, err, "unreachable")
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("timeout closes the instance once initialized", func(t *testing.T) {
+		policy := InitPolicy{Timeout: 10 * time.Millisecond, Retries: 3, RetryBackoff: time.Millisecond}
+		create, created := newCreate()
+		done := make(chan struct{})
+		abandoned := &fakeInstance{closed: make(chan struct{})}
+		_, err := policy.Init(abandoned, create, func(instance interface{}) error {
+			<-done
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 0, *created)
+
+		close(done)
+		select {
+		case <-abandoned.closed:
+		case <-time.After(time.Second):
+			assert.Fail(t, "the abandoned instance was not closed")
+		}
+	})
+}
@@ -55,7 +55,34 @@ func (k *KubernetesComponents) LoadComponents() ([]components_v1alpha1.Component
 			log.Warnf("error deserializing component: %s", err)
 			continue
 		}
+		if component.Kind == subscriptionKind {
+			continue
+		}
 		components = append(components, component)
 	}
 	return components, nil
 }
+
+// LoadSubscriptions returns the declarative subscriptions from a given control plane address.
+// Subscriptions are sent by the operator together with the components.
+func (k *KubernetesComponents) LoadSubscriptions() ([]components_v1alpha1.Subscription, error) {
+	resp, err := k.client.GetComponents(context.Background(), &empty.Empty{}, grpc_retry.WithMax(operatorMaxRetries), grpc_retry.WithPerRetryTimeout(operatorCallTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := []components_v1alpha1.Subscription{}
+	for _, c := range resp.GetComponents() {
+		var subscription components_v1alpha1.Subscription
+		err := json.Unmarshal(c.Value, &subscription)
+		if err != nil {
+			log.Warnf("error deserializing subscription: %s", err)
+			continue
+		}
+		if subscription.Kind != subscriptionKind {
+			continue
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
@@ -33,11 +33,23 @@ func (o *mockOperator) GetComponents(ctx context.Context, in *empty.Empty) (*ope
 	}
 	b, _ := json.Marshal(&component)
 
+	subscription := v1alpha1.Subscription{}
+	subscription.Kind = "Subscription"
+	subscription.ObjectMeta.Name = "sub"
+	subscription.Spec = v1alpha1.SubscriptionSpec{
+		Topic: "topic1",
+		Route: "/orders",
+	}
+	sb, _ := json.Marshal(&subscription)
+
 	return &operatorv1pb.GetComponentResponse{
 		Components: []*any.Any{
 			{
 				Value: b,
 			},
+			{
+				Value: sb,
+			},
 		},
 	}, nil
 }
@@ -76,6 +88,13 @@ func TestLoadComponents(t *testing.T) {
 	response, err := request.LoadComponents()
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
+	assert.Len(t, response, 1)
 	assert.Equal(t, "test", response[0].Name)
 	assert.Equal(t, "testtype", response[0].Spec.Type)
+
+	subscriptions, err := request.LoadSubscriptions()
+	assert.NoError(t, err)
+	assert.Len(t, subscriptions, 1)
+	assert.Equal(t, "sub", subscriptions[0].Name)
+	assert.Equal(t, "topic1", subscriptions[0].Spec.Topic)
 }
@@ -0,0 +1,89 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"github.com/dapr/components-contrib/bindings"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	componentsv1pb "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// grpcInputBinding is an input binding served by a component process
+type grpcInputBinding struct {
+	connection
+	client componentsv1pb.InputBindingClient
+}
+
+func newGRPCInputBinding(socket string) *grpcInputBinding {
+	return &grpcInputBinding{connection: newConnection(socket)}
+}
+
+func (b *grpcInputBinding) Init(metadata bindings.Metadata) error {
+	if err := b.dial(); err != nil {
+		return err
+	}
+	b.client = componentsv1pb.NewInputBindingClient(b.conn)
+	_, err := b.client.Init(b.ctx, &componentsv1pb.InitRequest{Name: metadata.Name, Metadata: metadata.Properties})
+	return err
+}
+
+// Read opens a ReadMessages stream and delivers its messages until the stream ends
+func (b *grpcInputBinding) Read(handler func(*bindings.ReadResponse) error) error {
+	stream, err := b.client.ReadMessages(b.ctx)
+	if err != nil {
+		return err
+	}
+
+	err = receiveMessages(stream, func(msg *componentsv1pb.Message) error {
+		return handler(&bindings.ReadResponse{
+			Data:     msg.Data,
+			Metadata: msg.Metadata,
+		})
+	})
+	if b.ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// grpcOutputBinding is an output binding served by a component process
+type grpcOutputBinding struct {
+	connection
+	client componentsv1pb.OutputBindingClient
+}
+
+func newGRPCOutputBinding(socket string) *grpcOutputBinding {
+	return &grpcOutputBinding{connection: newConnection(socket)}
+}
+
+func (b *grpcOutputBinding) Init(metadata bindings.Metadata) error {
+	if err := b.dial(); err != nil {
+		return err
+	}
+	b.client = componentsv1pb.NewOutputBindingClient(b.conn)
+	_, err := b.client.Init(b.ctx, &componentsv1pb.InitRequest{Name: metadata.Name, Metadata: metadata.Properties})
+	return err
+}
+
+func (b *grpcOutputBinding) Write(req *bindings.WriteRequest) error {
+	_, err := b.Invoke(req)
+	return err
+}
+
+// Invoke returns the response of the component process to the caller of the binding
+func (b *grpcOutputBinding) Invoke(req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) {
+	resp, err := b.client.Invoke(b.ctx, &componentsv1pb.InvokeRequest{
+		Data:     req.Data,
+		Metadata: req.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &bindings_loader.InvokeResponse{
+		Data:     resp.Data,
+		Metadata: resp.Metadata,
+	}, nil
+}
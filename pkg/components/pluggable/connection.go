@@ -0,0 +1,100 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/dapr/dapr/pkg/logger"
+	componentsv1pb "github.com/dapr/dapr/pkg/proto/components/v1"
+	"google.golang.org/grpc"
+)
+
+const dialTimeout = 5 * time.Second
+
+var log = logger.NewLogger("dapr.runtime.components.pluggable")
+
+// connection is the connection of a component instance to its component process
+type connection struct {
+	socket string
+	conn   *grpc.ClientConn
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newConnection(socket string) connection {
+	ctx, cancel := context.WithCancel(context.Background())
+	return connection{
+		socket: socket,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// dial connects to the gRPC server of a component process listening on a Unix domain socket
+func dial(socket string) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+	return grpc.DialContext(ctx, socket, grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", addr)
+		}))
+}
+
+// dial connects the component instance to its component process
+func (c *connection) dial() error {
+	conn, err := dial(c.socket)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	return nil
+}
+
+// Close stops the message streams of the component instance and closes its connection
+func (c *connection) Close() error {
+	c.cancel()
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// messageStream is a PullMessages or ReadMessages stream
+type messageStream interface {
+	Send(*componentsv1pb.AckRequest) error
+	Recv() (*componentsv1pb.Message, error)
+}
+
+// receiveMessages passes the messages of a PullMessages or ReadMessages stream to the handler until the stream
+// ends, and acknowledges each message with the error returned by the handler. The messages are handled
+// concurrently, the concurrency is limited by the component process.
+func receiveMessages(stream messageStream, handler func(msg *componentsv1pb.Message) error) error {
+	var sendLock sync.Mutex
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			ack := &componentsv1pb.AckRequest{MessageId: msg.Id}
+			if err := handler(msg); err != nil {
+				ack.Error = err.Error()
+			}
+
+			sendLock.Lock()
+			defer sendLock.Unlock()
+			if err := stream.Send(ack); err != nil {
+				log.Debugf("failed to acknowledge message %s: %s", msg.Id, err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,212 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/components-contrib/state"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	pubsub_loader "github.com/dapr/dapr/pkg/components/pubsub"
+	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
+	state_loader "github.com/dapr/dapr/pkg/components/state"
+	componentsv1pb "github.com/dapr/dapr/pkg/proto/components/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultSocketsFolder is the folder where the component processes create their sockets
+	DefaultSocketsFolder = "/tmp/dapr-components-sockets"
+	// SocketsFolderEnvVar is the environment variable overriding the sockets folder
+	SocketsFolderEnvVar = "DAPR_COMPONENTS_SOCKETS_FOLDER"
+
+	stateStoreService          = "StateStore"
+	queriableStateStoreService = "QueriableStateStore"
+	pubSubService              = "PubSub"
+	inputBindingService        = "InputBinding"
+	outputBindingService       = "OutputBinding"
+	secretStoreService         = "SecretStore"
+)
+
+// service is a service a component process can serve, with the Ping method telling whether it serves it
+type service struct {
+	name string
+	ping func(ctx context.Context, conn *grpc.ClientConn) error
+}
+
+var services = []service{
+	{stateStoreService, func(ctx context.Context, conn *grpc.ClientConn) error {
+		_, err := componentsv1pb.NewStateStoreClient(conn).Ping(ctx, &empty.Empty{})
+		return err
+	}},
+	{queriableStateStoreService, func(ctx context.Context, conn *grpc.ClientConn) error {
+		_, err := componentsv1pb.NewQueriableStateStoreClient(conn).Ping(ctx, &empty.Empty{})
+		return err
+	}},
+	{pubSubService, func(ctx context.Context, conn *grpc.ClientConn) error {
+		_, err := componentsv1pb.NewPubSubClient(conn).Ping(ctx, &empty.Empty{})
+		return err
+	}},
+	{inputBindingService, func(ctx context.Context, conn *grpc.ClientConn) error {
+		_, err := componentsv1pb.NewInputBindingClient(conn).Ping(ctx, &empty.Empty{})
+		return err
+	}},
+	{outputBindingService, func(ctx context.Context, conn *grpc.ClientConn) error {
+		_, err := componentsv1pb.NewOutputBindingClient(conn).Ping(ctx, &empty.Empty{})
+		return err
+	}},
+	{secretStoreService, func(ctx context.Context, conn *grpc.ClientConn) error {
+		_, err := componentsv1pb.NewSecretStoreClient(conn).Ping(ctx, &empty.Empty{})
+		return err
+	}},
+}
+
+// Components are the components served by the component processes listening in a sockets folder
+type Components struct {
+	States         []state_loader.State
+	PubSubs        []pubsub_loader.PubSub
+	InputBindings  []bindings_loader.InputBinding
+	OutputBindings []bindings_loader.OutputBinding
+	SecretStores   []secretstores_loader.SecretStore
+}
+
+// SocketsFolder returns the folder where the component processes create their sockets
+func SocketsFolder() string {
+	if folder := os.Getenv(SocketsFolderEnvVar); folder != "" {
+		return folder
+	}
+	return DefaultSocketsFolder
+}
+
+// Discoverer discovers the components of the component processes as they create their sockets
+type Discoverer struct {
+	folder string
+	// found holds the sockets whose components were discovered already
+	found map[string]bool
+}
+
+// NewDiscoverer returns a discoverer of the components served on the sockets of the folder
+func NewDiscoverer(folder string) *Discoverer {
+	return &Discoverer{
+		folder: folder,
+		found:  map[string]bool{},
+	}
+}
+
+// Discover returns the components served on the sockets of the folder. The name of a component is the name
+// of its socket file without the extension, so a process listening on my-store.sock and serving the
+// StateStore service provides the state.my-store component type. A state store serving the QueriableStateStore
+// service too supports queries. A missing folder holds no components, and a
+// folder other users can access is refused.
+func Discover(folder string) (Components, error) {
+	return NewDiscoverer(folder).Discover()
+}
+
+// Discover returns the components served on the sockets created since the previous discovery. A socket whose
+// services couldn't be discovered is retried at the next discovery.
+func (d *Discoverer) Discover() (Components, error) {
+	folder := d.folder
+	discovered := Components{}
+	info, err := os.Stat(folder)
+	if os.IsNotExist(err) {
+		return discovered, nil
+	}
+	if err != nil {
+		return discovered, err
+	}
+	if err := checkSocketsFolder(folder, info); err != nil {
+		return discovered, err
+	}
+	files, err := ioutil.ReadDir(folder)
+	if err != nil {
+		return discovered, err
+	}
+
+	for _, f := range files {
+		if f.Mode()&os.ModeSocket == 0 {
+			continue
+		}
+		socket := filepath.Join(folder, f.Name())
+		if d.found[socket] {
+			continue
+		}
+		name := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+		served, err := servedServices(socket)
+		if err != nil {
+			log.Warnf("failed to discover the services of pluggable component %s: %s", name, err)
+			continue
+		}
+
+		queriable := false
+		for _, serviceName := range served {
+			queriable = queriable || serviceName == queriableStateStoreService
+		}
+		for _, serviceName := range served {
+			switch serviceName {
+			case stateStoreService:
+				discovered.States = append(discovered.States, state_loader.New(name, func() state.Store {
+					if queriable {
+						return newGRPCQueriableStateStore(socket)
+					}
+					return newGRPCStateStore(socket)
+				}))
+			case pubSubService:
+				discovered.PubSubs = append(discovered.PubSubs, pubsub_loader.New(name, func() pubsub.PubSub {
+					return newGRPCPubSub(socket)
+				}))
+			case inputBindingService:
+				discovered.InputBindings = append(discovered.InputBindings, bindings_loader.NewInput(name, func() bindings.InputBinding {
+					return newGRPCInputBinding(socket)
+				}))
+			case outputBindingService:
+				discovered.OutputBindings = append(discovered.OutputBindings, bindings_loader.NewOutput(name, func() bindings.OutputBinding {
+					return newGRPCOutputBinding(socket)
+				}))
+			case secretStoreService:
+				discovered.SecretStores = append(discovered.SecretStores, secretstores_loader.New(name, func() secretstores.SecretStore {
+					return newGRPCSecretStore(socket)
+				}))
+			}
+		}
+		d.found[socket] = true
+		log.Infof("found pluggable component %s serving %s", name, strings.Join(served, ", "))
+	}
+	return discovered, nil
+}
+
+// servedServices pings each service on the socket and returns the services the component process serves
+func servedServices(socket string) ([]string, error) {
+	conn, err := dial(socket)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	served := []string{}
+	for _, s := range services {
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+		err := s.ping(ctx, conn)
+		cancel()
+		if status.Code(err) == codes.Unimplemented {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		served = append(served, s.name)
+	}
+	return served, nil
+}
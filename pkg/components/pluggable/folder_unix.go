@@ -0,0 +1,30 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+//go:build !windows
+// +build !windows
+
+package pluggable
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkSocketsFolder refuses a sockets folder owned by another user or accessible by other users, as any process
+// able to create a socket in the folder could serve a component to the runtime
+func checkSocketsFolder(folder string, info os.FileInfo) error {
+	if !info.IsDir() {
+		return fmt.Errorf("sockets folder %s is not a directory", folder)
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		return fmt.Errorf("sockets folder %s must only be accessible by its owner (0700), its permissions are %#o", folder, perm)
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("sockets folder %s must be owned by the user running the runtime, it is owned by uid %d", folder, stat.Uid)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"fmt"
+	"os"
+)
+
+// checkSocketsFolder only checks that the sockets folder is a directory, its access is controlled by its ACL
+func checkSocketsFolder(folder string, info os.FileInfo) error {
+	if !info.IsDir() {
+		return fmt.Errorf("sockets folder %s is not a directory", folder)
+	}
+	return nil
+}
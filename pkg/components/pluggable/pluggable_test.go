@@ -0,0 +1,326 @@
+package pluggable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/components-contrib/state"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	componentsv1pb "github.com/dapr/dapr/pkg/proto/components/v1"
+	secrets_bulk "github.com/dapr/dapr/pkg/secretstores/bulk"
+	"github.com/dapr/dapr/pkg/state/query"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// fakeComponentServer serves the StateStore, PubSub, OutputBinding and SecretStore services from memory
+type fakeComponentServer struct {
+	lock     sync.Mutex
+	metadata map[string]string
+	items    map[string][]byte
+	acks     chan *componentsv1pb.AckRequest
+}
+
+func (f *fakeComponentServer) Init(ctx context.Context, in *componentsv1pb.InitRequest) (*empty.Empty, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.metadata = in.Metadata
+	return &empty.Empty{}, nil
+}
+
+func (f *fakeComponentServer) Ping(ctx context.Context, in *empty.Empty) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (f *fakeComponentServer) Get(ctx context.Context, in *componentsv1pb.GetStateRequest) (*componentsv1pb.GetStateResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return &componentsv1pb.GetStateResponse{Data: f.items[in.Key], Etag: "1"}, nil
+}
+
+func (f *fakeComponentServer) Set(ctx context.Context, in *componentsv1pb.SetStateRequest) (*empty.Empty, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.items[in.Key] = in.Value
+	return &empty.Empty{}, nil
+}
+
+func (f *fakeComponentServer) Delete(ctx context.Context, in *componentsv1pb.DeleteStateRequest) (*empty.Empty, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.items, in.Key)
+	return &empty.Empty{}, nil
+}
+
+func (f *fakeComponentServer) BulkSet(ctx context.Context, in *componentsv1pb.BulkSetStateRequest) (*empty.Empty, error) {
+	for _, item := range in.Items {
+		f.Set(ctx, item)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (f *fakeComponentServer) BulkDelete(ctx context.Context, in *componentsv1pb.BulkDeleteStateRequest) (*empty.Empty, error) {
+	for _, item := range in.Items {
+		f.Delete(ctx, item)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (f *fakeComponentServer) GetSecret(ctx context.Context, in *componentsv1pb.GetSecretRequest) (*componentsv1pb.GetSecretResponse, error) {
+	return &componentsv1pb.GetSecretResponse{Data: map[string]string{in.Key: "secret"}}, nil
+}
+
+func (f *fakeComponentServer) BulkGetSecret(ctx context.Context, in *componentsv1pb.BulkGetSecretRequest) (*componentsv1pb.BulkGetSecretResponse, error) {
+	return &componentsv1pb.BulkGetSecretResponse{Data: map[string]*componentsv1pb.GetSecretResponse{
+		"db":  {Data: map[string]string{"db": "secret"}},
+		"api": {Data: map[string]string{"api": "key"}},
+	}}, nil
+}
+
+// fakeQueriableServer serves the QueriableStateStore service, returning the query it received
+type fakeQueriableServer struct{}
+
+func (f *fakeQueriableServer) Ping(ctx context.Context, in *empty.Empty) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (f *fakeQueriableServer) Query(ctx context.Context, in *componentsv1pb.QueryStateRequest) (*componentsv1pb.QueryStateResponse, error) {
+	return &componentsv1pb.QueryStateResponse{
+		Results: []*componentsv1pb.QueryStateItem{{Key: in.KeyPrefix + "key1", Data: in.Query, Etag: "1"}},
+		Token:   "2",
+	}, nil
+}
+
+func (f *fakeComponentServer) Publish(ctx context.Context, in *componentsv1pb.PublishRequest) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+// PullMessages delivers two messages on the subscribed topic and reports their acks
+func (f *fakeComponentServer) PullMessages(stream componentsv1pb.PubSub_PullMessagesServer) error {
+	subscription, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	for _, id := range []string{"1", "2"} {
+		if err := stream.Send(&componentsv1pb.Message{Id: id, Topic: subscription.Topic, Data: []byte(id)}); err != nil {
+			return err
+		}
+	}
+	for {
+		ack, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		f.acks <- ack
+	}
+}
+
+func (f *fakeComponentServer) Invoke(ctx context.Context, in *componentsv1pb.InvokeRequest) (*componentsv1pb.InvokeResponse, error) {
+	return &componentsv1pb.InvokeResponse{Data: in.Data, Metadata: map[string]string{"operation": in.Metadata["operation"]}}, nil
+}
+
+func startComponentProcess(t *testing.T, folder, name string) (*fakeComponentServer, *grpc.Server) {
+	lis, err := net.Listen("unix", filepath.Join(folder, name+".sock"))
+	assert.NoError(t, err)
+
+	fake := &fakeComponentServer{items: map[string][]byte{}, acks: make(chan *componentsv1pb.AckRequest, 2)}
+	server := grpc.NewServer()
+	componentsv1pb.RegisterStateStoreServer(server, fake)
+	componentsv1pb.RegisterPubSubServer(server, fake)
+	componentsv1pb.RegisterOutputBindingServer(server, fake)
+	componentsv1pb.RegisterSecretStoreServer(server, fake)
+	go server.Serve(lis)
+	return fake, server
+}
+
+func TestDiscover(t *testing.T) {
+	folder, err := ioutil.TempDir("", "sockets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	fake, server := startComponentProcess(t, folder, "mystore")
+	defer server.Stop()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(folder, "notes.txt"), []byte("not a socket"), 0600))
+
+	discovered, err := Discover(folder)
+	assert.NoError(t, err)
+	assert.Len(t, discovered.States, 1)
+	assert.Len(t, discovered.PubSubs, 1)
+	assert.Len(t, discovered.OutputBindings, 1)
+	assert.Len(t, discovered.InputBindings, 0)
+	assert.Len(t, discovered.SecretStores, 1)
+	assert.Equal(t, "mystore", discovered.States[0].Name)
+
+	t.Run("state store", func(t *testing.T) {
+		store := discovered.States[0].FactoryMethod()
+		defer store.(*grpcStateStore).Close()
+		assert.NoError(t, store.Init(state.Metadata{Properties: map[string]string{"host": "localhost"}}))
+		assert.Equal(t, "localhost", fake.metadata["host"])
+
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "key1", Value: map[string]string{"a": "b"}}))
+		resp, err := store.Get(&state.GetRequest{Key: "key1"})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":"b"}`, string(resp.Data))
+		assert.Equal(t, "1", resp.ETag)
+
+		assert.NoError(t, store.Delete(&state.DeleteRequest{Key: "key1"}))
+		resp, err = store.Get(&state.GetRequest{Key: "key1"})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Data)
+	})
+
+	t.Run("pub/sub", func(t *testing.T) {
+		ps := discovered.PubSubs[0].FactoryMethod()
+		defer ps.(*grpcPubSub).Close()
+		assert.NoError(t, ps.Init(pubsub.Metadata{}))
+		assert.NoError(t, ps.Publish(&pubsub.PublishRequest{Topic: "topic1", Data: []byte("hello")}))
+
+		err := ps.Subscribe(pubsub.SubscribeRequest{Topic: "topic1"}, func(msg *pubsub.NewMessage) error {
+			assert.Equal(t, "topic1", msg.Topic)
+			if string(msg.Data) == "2" {
+				return errors.New("app failed")
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+
+		acks := map[string]string{}
+		for i := 0; i < 2; i++ {
+			select {
+			case ack := <-fake.acks:
+				acks[ack.MessageId] = ack.Error
+			case <-time.After(5 * time.Second):
+				t.Fatal("message not acknowledged")
+			}
+		}
+		assert.Equal(t, map[string]string{"1": "", "2": "app failed"}, acks)
+	})
+
+	t.Run("output binding", func(t *testing.T) {
+		binding := discovered.OutputBindings[0].FactoryMethod()
+		defer binding.(*grpcOutputBinding).Close()
+		assert.NoError(t, binding.Init(bindings.Metadata{Name: "mybinding"}))
+
+		resp, err := binding.(bindings_loader.InvokableOutputBinding).Invoke(&bindings.WriteRequest{
+			Data:     []byte("hello"),
+			Metadata: map[string]string{"operation": "create"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(resp.Data))
+		assert.Equal(t, "create", resp.Metadata["operation"])
+	})
+}
+
+func TestDiscoverSecretStore(t *testing.T) {
+	folder, err := ioutil.TempDir("", "sockets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	_, server := startComponentProcess(t, folder, "myvault")
+	defer server.Stop()
+
+	discovered, err := Discover(folder)
+	assert.NoError(t, err)
+	assert.Len(t, discovered.SecretStores, 1)
+
+	store := discovered.SecretStores[0].FactoryMethod()
+	defer store.(*grpcSecretStore).Close()
+	assert.NoError(t, store.Init(secretstores.Metadata{}))
+
+	resp, err := store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"db": "secret"}, resp.Data)
+
+	secrets, err := secrets_bulk.Get(store, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"db":  {"db": "secret"},
+		"api": {"api": "key"},
+	}, secrets)
+}
+
+func TestDiscoverMissingFolder(t *testing.T) {
+	discovered, err := Discover(filepath.Join(os.TempDir(), "no-such-sockets-folder"))
+	assert.NoError(t, err)
+	assert.Empty(t, discovered.States)
+}
+
+func TestDiscoverSharedFolder(t *testing.T) {
+	folder, err := ioutil.TempDir("", "sockets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(folder)
+	assert.NoError(t, os.Chmod(folder, 0777))
+
+	_, server := startComponentProcess(t, folder, "mystore")
+	defer server.Stop()
+
+	discovered, err := Discover(folder)
+	assert.Error(t, err)
+	assert.Empty(t, discovered.States)
+}
+
+func TestDiscovererFindsNewSockets(t *testing.T) {
+	folder, err := ioutil.TempDir("", "sockets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	discoverer := NewDiscoverer(folder)
+	discovered, err := discoverer.Discover()
+	assert.NoError(t, err)
+	assert.Empty(t, discovered.States)
+
+	_, server := startComponentProcess(t, folder, "mystore")
+	defer server.Stop()
+
+	discovered, err = discoverer.Discover()
+	assert.NoError(t, err)
+	assert.Len(t, discovered.States, 1)
+
+	discovered, err = discoverer.Discover()
+	assert.NoError(t, err)
+	assert.Empty(t, discovered.States)
+}
+
+func TestDiscoverQueriableStateStore(t *testing.T) {
+	folder, err := ioutil.TempDir("", "sockets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	lis, err := net.Listen("unix", filepath.Join(folder, "mystore.sock"))
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	componentsv1pb.RegisterStateStoreServer(server, &fakeComponentServer{items: map[string][]byte{}})
+	componentsv1pb.RegisterQueriableStateStoreServer(server, &fakeQueriableServer{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	discovered, err := Discover(folder)
+	assert.NoError(t, err)
+	assert.Len(t, discovered.States, 1)
+
+	store := discovered.States[0].FactoryMethod()
+	defer store.(*grpcQueriableStateStore).Close()
+	assert.NoError(t, store.Init(state.Metadata{}))
+
+	q := query.Query{Filter: &query.EQ{Key: "state", Val: "CA"}, Page: query.Pagination{Limit: 1}}
+	resp, err := store.(query.Querier).Query(&query.Request{Query: q, KeyPrefix: "app1||"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", resp.Token)
+	assert.Len(t, resp.Results, 1)
+	assert.Equal(t, "app1||key1", resp.Results[0].Key)
+	var received query.Query
+	assert.NoError(t, json.Unmarshal(resp.Results[0].Data, &received))
+	assert.Equal(t, q, received)
+}
@@ -0,0 +1,66 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"github.com/dapr/components-contrib/pubsub"
+	componentsv1pb "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// grpcPubSub is a pub/sub served by a component process
+type grpcPubSub struct {
+	connection
+	client componentsv1pb.PubSubClient
+}
+
+func newGRPCPubSub(socket string) *grpcPubSub {
+	return &grpcPubSub{connection: newConnection(socket)}
+}
+
+func (p *grpcPubSub) Init(metadata pubsub.Metadata) error {
+	if err := p.dial(); err != nil {
+		return err
+	}
+	p.client = componentsv1pb.NewPubSubClient(p.conn)
+	_, err := p.client.Init(p.ctx, &componentsv1pb.InitRequest{Metadata: metadata.Properties})
+	return err
+}
+
+func (p *grpcPubSub) Publish(req *pubsub.PublishRequest) error {
+	_, err := p.client.Publish(p.ctx, &componentsv1pb.PublishRequest{
+		Topic: req.Topic,
+		Data:  req.Data,
+	})
+	return err
+}
+
+// Subscribe opens a PullMessages stream for the topic and delivers its messages in the background
+func (p *grpcPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	stream, err := p.client.PullMessages(p.ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&componentsv1pb.AckRequest{Topic: req.Topic}); err != nil {
+		return err
+	}
+
+	go func() {
+		err := receiveMessages(stream, func(msg *componentsv1pb.Message) error {
+			topic := msg.Topic
+			if topic == "" {
+				topic = req.Topic
+			}
+			return handler(&pubsub.NewMessage{
+				Data:  msg.Data,
+				Topic: topic,
+			})
+		})
+		if p.ctx.Err() == nil {
+			log.Warnf("subscription to topic %s ended: %s", req.Topic, err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,55 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"github.com/dapr/components-contrib/secretstores"
+	componentsv1pb "github.com/dapr/dapr/pkg/proto/components/v1"
+	"github.com/dapr/dapr/pkg/secretstores/bulk"
+)
+
+// grpcSecretStore is a secret store served by a component process
+type grpcSecretStore struct {
+	connection
+	client componentsv1pb.SecretStoreClient
+}
+
+func newGRPCSecretStore(socket string) *grpcSecretStore {
+	return &grpcSecretStore{connection: newConnection(socket)}
+}
+
+func (s *grpcSecretStore) Init(metadata secretstores.Metadata) error {
+	if err := s.dial(); err != nil {
+		return err
+	}
+	s.client = componentsv1pb.NewSecretStoreClient(s.conn)
+	_, err := s.client.Init(s.ctx, &componentsv1pb.InitRequest{Metadata: metadata.Properties})
+	return err
+}
+
+func (s *grpcSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	resp, err := s.client.GetSecret(s.ctx, &componentsv1pb.GetSecretRequest{
+		Key:      req.Name,
+		Metadata: req.Metadata,
+	})
+	if err != nil {
+		return secretstores.GetSecretResponse{}, err
+	}
+	return secretstores.GetSecretResponse{Data: resp.Data}, nil
+}
+
+// BulkGetSecret returns all the secrets of the component process
+func (s *grpcSecretStore) BulkGetSecret(req bulk.Request) (bulk.Response, error) {
+	resp, err := s.client.BulkGetSecret(s.ctx, &componentsv1pb.BulkGetSecretRequest{Metadata: req.Metadata})
+	if err != nil {
+		return bulk.Response{}, err
+	}
+	secrets := make(map[string]map[string]string, len(resp.Data))
+	for name, secret := range resp.Data {
+		secrets[name] = secret.GetData()
+	}
+	return bulk.Response{Data: secrets}, nil
+}
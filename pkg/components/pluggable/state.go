@@ -0,0 +1,159 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"encoding/json"
+
+	"github.com/dapr/components-contrib/state"
+	componentsv1pb "github.com/dapr/dapr/pkg/proto/components/v1"
+	"github.com/dapr/dapr/pkg/state/query"
+)
+
+// grpcStateStore is a state store served by a component process
+type grpcStateStore struct {
+	connection
+	client componentsv1pb.StateStoreClient
+}
+
+func newGRPCStateStore(socket string) *grpcStateStore {
+	return &grpcStateStore{connection: newConnection(socket)}
+}
+
+func (s *grpcStateStore) Init(metadata state.Metadata) error {
+	if err := s.dial(); err != nil {
+		return err
+	}
+	s.client = componentsv1pb.NewStateStoreClient(s.conn)
+	_, err := s.client.Init(s.ctx, &componentsv1pb.InitRequest{Metadata: metadata.Properties})
+	return err
+}
+
+// grpcQueriableStateStore is a state store served by a component process which also serves queries
+type grpcQueriableStateStore struct {
+	*grpcStateStore
+	queryClient componentsv1pb.QueriableStateStoreClient
+}
+
+func newGRPCQueriableStateStore(socket string) *grpcQueriableStateStore {
+	return &grpcQueriableStateStore{grpcStateStore: newGRPCStateStore(socket)}
+}
+
+func (s *grpcQueriableStateStore) Init(metadata state.Metadata) error {
+	if err := s.grpcStateStore.Init(metadata); err != nil {
+		return err
+	}
+	s.queryClient = componentsv1pb.NewQueriableStateStoreClient(s.conn)
+	return nil
+}
+
+// Query passes the query to the component process in the JSON format of the state query API
+func (s *grpcQueriableStateStore) Query(req *query.Request) (*query.Response, error) {
+	q, err := json.Marshal(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.queryClient.Query(s.ctx, &componentsv1pb.QueryStateRequest{
+		Query:     q,
+		KeyPrefix: req.KeyPrefix,
+		Metadata:  req.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]query.Item, 0, len(resp.Results))
+	for _, item := range resp.Results {
+		results = append(results, query.Item{
+			Key:  item.Key,
+			Data: item.Data,
+			ETag: item.Etag,
+		})
+	}
+	return &query.Response{Results: results, Token: resp.Token}, nil
+}
+
+func (s *grpcStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	resp, err := s.client.Get(s.ctx, &componentsv1pb.GetStateRequest{
+		Key:         req.Key,
+		Metadata:    req.Metadata,
+		Consistency: req.Options.Consistency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &state.GetResponse{
+		Data:     resp.Data,
+		ETag:     resp.Etag,
+		Metadata: resp.Metadata,
+	}, nil
+}
+
+func (s *grpcStateStore) Set(req *state.SetRequest) error {
+	in, err := toSetStateRequest(req)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Set(s.ctx, in)
+	return err
+}
+
+func (s *grpcStateStore) BulkSet(req []state.SetRequest) error {
+	in := &componentsv1pb.BulkSetStateRequest{}
+	for i := range req {
+		item, err := toSetStateRequest(&req[i])
+		if err != nil {
+			return err
+		}
+		in.Items = append(in.Items, item)
+	}
+	_, err := s.client.BulkSet(s.ctx, in)
+	return err
+}
+
+func (s *grpcStateStore) Delete(req *state.DeleteRequest) error {
+	_, err := s.client.Delete(s.ctx, toDeleteStateRequest(req))
+	return err
+}
+
+func (s *grpcStateStore) BulkDelete(req []state.DeleteRequest) error {
+	in := &componentsv1pb.BulkDeleteStateRequest{}
+	for i := range req {
+		in.Items = append(in.Items, toDeleteStateRequest(&req[i]))
+	}
+	_, err := s.client.BulkDelete(s.ctx, in)
+	return err
+}
+
+// toSetStateRequest serializes the value of the request to JSON, unless the value is already serialized
+func toSetStateRequest(req *state.SetRequest) (*componentsv1pb.SetStateRequest, error) {
+	value, ok := req.Value.([]byte)
+	if !ok {
+		var err error
+		value, err = json.Marshal(req.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &componentsv1pb.SetStateRequest{
+		Key:         req.Key,
+		Value:       value,
+		Etag:        req.ETag,
+		Metadata:    req.Metadata,
+		Concurrency: req.Options.Concurrency,
+		Consistency: req.Options.Consistency,
+	}, nil
+}
+
+func toDeleteStateRequest(req *state.DeleteRequest) *componentsv1pb.DeleteStateRequest {
+	return &componentsv1pb.DeleteStateRequest{
+		Key:         req.Key,
+		Etag:        req.ETag,
+		Metadata:    req.Metadata,
+		Concurrency: req.Options.Concurrency,
+		Consistency: req.Options.Consistency,
+	}
+}
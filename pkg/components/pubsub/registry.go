@@ -7,6 +7,7 @@ package pubsub
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/dapr/components-contrib/pubsub"
 )
@@ -25,6 +26,7 @@ type (
 	}
 
 	pubSubRegistry struct {
+		lock         sync.RWMutex
 		messageBuses map[string]func() pubsub.PubSub
 	}
 )
@@ -46,6 +48,8 @@ func NewRegistry() Registry {
 
 // Register registers one or more new message buses.
 func (p *pubSubRegistry) Register(components ...PubSub) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
 	for _, component := range components {
 		p.messageBuses[createFullName(component.Name)] = component.FactoryMethod
 	}
@@ -53,7 +57,10 @@ func (p *pubSubRegistry) Register(components ...PubSub) {
 
 // Create instantiates a pub/sub based on `name`.
 func (p *pubSubRegistry) Create(name string) (pubsub.PubSub, error) {
-	if method, ok := p.messageBuses[name]; ok {
+	p.lock.RLock()
+	method, ok := p.messageBuses[name]
+	p.lock.RUnlock()
+	if ok {
 		return method(), nil
 	}
 	return nil, fmt.Errorf("couldn't find message bus %s", name)
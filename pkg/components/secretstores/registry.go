@@ -7,6 +7,7 @@ package secretstores
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/dapr/components-contrib/secretstores"
 )
@@ -25,6 +26,7 @@ type (
 	}
 
 	secretStoreRegistry struct {
+		lock         sync.RWMutex
 		secretStores map[string]func() secretstores.SecretStore
 	}
 )
@@ -46,6 +48,8 @@ func NewRegistry() Registry {
 
 // Register adds one or many new secret stores to the registry.
 func (s *secretStoreRegistry) Register(components ...SecretStore) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	for _, component := range components {
 		s.secretStores[createFullName(component.Name)] = component.FactoryMethod
 	}
@@ -53,7 +57,10 @@ func (s *secretStoreRegistry) Register(components ...SecretStore) {
 
 // Create instantiates a secret store based on `name`.
 func (s *secretStoreRegistry) Create(name string) (secretstores.SecretStore, error) {
-	if method, ok := s.secretStores[name]; ok {
+	s.lock.RLock()
+	method, ok := s.secretStores[name]
+	s.lock.RUnlock()
+	if ok {
 		return method(), nil
 	}
 
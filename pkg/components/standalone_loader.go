@@ -15,11 +15,17 @@ import (
 	"strings"
 
 	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	global_config "github.com/dapr/dapr/pkg/config"
 	config "github.com/dapr/dapr/pkg/config/modes"
 	"github.com/ghodss/yaml"
 )
 
-const yamlSeparator = "\n---"
+const (
+	yamlSeparator    = "\n---"
+	componentKind    = "Component"
+	httpEndpointKind = "HTTPEndpoint"
+	subscriptionKind = "Subscription"
+)
 
 // StandaloneComponents loads components in a standalone mode environment
 type StandaloneComponents struct {
@@ -35,29 +41,73 @@ func NewStandaloneComponents(configuration config.StandaloneConfig) *StandaloneC
 
 // LoadComponents loads dapr components from a given directory
 func (s *StandaloneComponents) LoadComponents() ([]components_v1alpha1.Component, error) {
-	dir := s.config.ComponentsPath
-	files, err := ioutil.ReadDir(dir)
+	list := []components_v1alpha1.Component{}
+	err := s.visitYamlFiles(func(filename string, b []byte) {
+		components, _ := s.decodeYaml(filename, b)
+		list = append(list, components...)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	list := []components_v1alpha1.Component{}
+	return list, nil
+}
+
+// LoadHTTPEndpoints loads dapr HTTP endpoints from the components directory
+func (s *StandaloneComponents) LoadHTTPEndpoints() ([]components_v1alpha1.HTTPEndpoint, error) {
+	list := []components_v1alpha1.HTTPEndpoint{}
+	err := s.visitYamlFiles(func(filename string, b []byte) {
+		endpoints, _ := s.decodeHTTPEndpointsYaml(filename, b)
+		list = append(list, endpoints...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// LoadSubscriptions loads declarative dapr subscriptions from the components directory
+func (s *StandaloneComponents) LoadSubscriptions() ([]components_v1alpha1.Subscription, error) {
+	list := []components_v1alpha1.Subscription{}
+	err := s.visitYamlFiles(func(filename string, b []byte) {
+		subscriptions, _ := s.decodeSubscriptionsYaml(filename, b)
+		list = append(list, subscriptions...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// visitYamlFiles reads all the yaml files in the components directory and expands their environment variable
+// templates
+func (s *StandaloneComponents) visitYamlFiles(visit func(filename string, b []byte)) error {
+	dir := s.config.ComponentsPath
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
 
 	for _, file := range files {
 		if !file.IsDir() && s.isYaml(file.Name()) {
-			b, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", dir, file.Name()))
-
+			filename := fmt.Sprintf("%s/%s", dir, file.Name())
+			b, err := ioutil.ReadFile(filename)
+			if err != nil {
+				log.Warnf("error reading file %s : %s", filename, err)
+				continue
+			}
+			b, err = global_config.ExpandEnvTemplate(b)
 			if err != nil {
-				log.Warnf("error reading file %s/%s : %s", dir, file.Name(), err)
+				log.Warnf("error expanding file %s : %s", filename, err)
 				continue
 			}
 
-			components, _ := s.decodeYaml(fmt.Sprintf("%s/%s", dir, file.Name()), b)
-			list = append(list, components...)
+			visit(filename, b)
 		}
 	}
-
-	return list, nil
+	return nil
 }
 
 // isYaml checks whether the file is yaml or not
@@ -87,12 +137,69 @@ func (s *StandaloneComponents) decodeYaml(filename string, b []byte) ([]componen
 			errors = append(errors, err)
 			continue
 		}
+		if component.Kind != "" && component.Kind != componentKind {
+			continue
+		}
 		list = append(list, component)
 	}
 
 	return list, errors
 }
 
+// decodeHTTPEndpointsYaml decodes the HTTP endpoint resources in the yaml document
+func (s *StandaloneComponents) decodeHTTPEndpointsYaml(filename string, b []byte) ([]components_v1alpha1.HTTPEndpoint, []error) {
+	list := []components_v1alpha1.HTTPEndpoint{}
+	errors := []error{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Split(s.splitYamlDoc)
+
+	for {
+		var endpoint components_v1alpha1.HTTPEndpoint
+		err := s.decode(scanner, &endpoint)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Warnf("error parsing yaml resource in %s : %s", filename, err)
+			errors = append(errors, err)
+			continue
+		}
+		if endpoint.Kind != httpEndpointKind {
+			continue
+		}
+		list = append(list, endpoint)
+	}
+
+	return list, errors
+}
+
+// decodeSubscriptionsYaml decodes the subscription resources in the yaml document
+func (s *StandaloneComponents) decodeSubscriptionsYaml(filename string, b []byte) ([]components_v1alpha1.Subscription, []error) {
+	list := []components_v1alpha1.Subscription{}
+	errors := []error{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Split(s.splitYamlDoc)
+
+	for {
+		var subscription components_v1alpha1.Subscription
+		err := s.decode(scanner, &subscription)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Warnf("error parsing yaml resource in %s : %s", filename, err)
+			errors = append(errors, err)
+			continue
+		}
+		if subscription.Kind != subscriptionKind {
+			continue
+		}
+		list = append(list, subscription)
+	}
+
+	return list, errors
+}
+
 // decode reads the YAML resource in document
 func (s *StandaloneComponents) decode(scanner *bufio.Scanner, c interface{}) error {
 	if scanner.Scan() {
@@ -1,6 +1,9 @@
 package components
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	config "github.com/dapr/dapr/pkg/config/modes"
@@ -169,3 +172,127 @@ spec:
 	assert.Equal(t, "prop3", components[1].Spec.Metadata[0].Name)
 	assert.Equal(t, "value3", components[1].Spec.Metadata[0].Value)
 }
+
+func TestStandaloneDecodeHTTPEndpoints(t *testing.T) {
+	request := &StandaloneComponents{
+		config: config.StandaloneConfig{
+			ComponentsPath: "test_component_path",
+		},
+	}
+	yaml := `
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+   name: statestore
+spec:
+   type: state.couchbase
+---
+apiVersion: dapr.io/v1alpha1
+kind: HTTPEndpoint
+metadata:
+   name: external
+spec:
+   baseUrl: https://api.example.com
+   headers:
+   - name: Authorization
+     value: token
+`
+	components, errs := request.decodeYaml("components/mixed.yaml", []byte(yaml))
+	assert.Len(t, components, 1)
+	assert.Empty(t, errs)
+	assert.Equal(t, "statestore", components[0].Name)
+
+	endpoints, errs := request.decodeHTTPEndpointsYaml("components/mixed.yaml", []byte(yaml))
+	assert.Len(t, endpoints, 1)
+	assert.Empty(t, errs)
+	assert.Equal(t, "external", endpoints[0].Name)
+	assert.Equal(t, "https://api.example.com", endpoints[0].Spec.BaseURL)
+	assert.Len(t, endpoints[0].Spec.Headers, 1)
+	assert.Equal(t, "Authorization", endpoints[0].Spec.Headers[0].Name)
+}
+
+func TestStandaloneDecodeSubscriptions(t *testing.T) {
+	request := &StandaloneComponents{
+		config: config.StandaloneConfig{
+			ComponentsPath: "test_component_path",
+		},
+	}
+	yaml := `
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+   name: pubsub
+spec:
+   type: pubsub.redis
+---
+apiVersion: dapr.io/v1alpha1
+kind: Subscription
+metadata:
+   name: orders
+spec:
+   topic: orders
+   route: /orders
+   deadLetterTopic: poison
+   bulkSubscribe:
+      enabled: true
+      maxMessagesCount: 10
+scopes:
+- app1
+`
+	components, errs := request.decodeYaml("components/mixed.yaml", []byte(yaml))
+	assert.Len(t, components, 1)
+	assert.Empty(t, errs)
+
+	subscriptions, errs := request.decodeSubscriptionsYaml("components/mixed.yaml", []byte(yaml))
+	assert.Len(t, subscriptions, 1)
+	assert.Empty(t, errs)
+	assert.Equal(t, "orders", subscriptions[0].Spec.Topic)
+	assert.Equal(t, "/orders", subscriptions[0].Spec.Route)
+	assert.Equal(t, "poison", subscriptions[0].Spec.DeadLetterTopic)
+	assert.Equal(t, 10, subscriptions[0].Spec.BulkSubscribe.MaxMessagesCount)
+	assert.Equal(t, []string{"app1"}, subscriptions[0].Scopes)
+}
+
+func TestStandaloneLoadComponentsExpandsEnvTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "components")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	os.Setenv("DAPR_COMPONENT_ENV_REDIS_HOST", "redis:6379")
+	defer os.Unsetenv("DAPR_COMPONENT_ENV_REDIS_HOST")
+
+	statestore := `
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+   name: statestore
+spec:
+   type: state.redis
+   metadata:
+   - name: redisHost
+     value: {{ env "DAPR_COMPONENT_ENV_REDIS_HOST" }}
+   - name: redisPassword
+     value: "{{ env "DAPR_COMPONENT_ENV_REDIS_PASSWORD" "" }}"
+`
+	pubsub := `
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+   name: pubsub
+spec:
+   type: pubsub.redis
+   metadata:
+   - name: redisHost
+     value: {{ env "DAPR_COMPONENT_ENV_MISSING" }}
+`
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "statestore.yaml"), []byte(statestore), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "pubsub.yaml"), []byte(pubsub), 0600))
+
+	loader := NewStandaloneComponents(config.StandaloneConfig{ComponentsPath: dir})
+	components, err := loader.LoadComponents()
+	assert.NoError(t, err)
+	// the file with a missing variable is skipped
+	assert.Len(t, components, 1)
+	assert.Equal(t, "statestore", components[0].Name)
+	assert.Equal(t, "redis:6379", components[0].Spec.Metadata[0].Value)
+	assert.Equal(t, "", components[0].Spec.Metadata[1].Value)
+}
@@ -7,6 +7,7 @@ package state
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/dapr/components-contrib/state"
 )
@@ -30,6 +31,7 @@ type Registry interface {
 }
 
 type stateStoreRegistry struct {
+	lock        sync.RWMutex
 	stateStores map[string]func() state.Store
 }
 
@@ -43,13 +45,18 @@ func NewRegistry() Registry {
 // // Register registers a new factory method that creates an instance of a StateStore.
 // // The key is the name of the state store, eg. redis.
 func (s *stateStoreRegistry) Register(components ...State) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	for _, component := range components {
 		s.stateStores[createFullName(component.Name)] = component.FactoryMethod
 	}
 }
 
 func (s *stateStoreRegistry) CreateStateStore(name string) (state.Store, error) {
-	if method, ok := s.stateStores[name]; ok {
+	s.lock.RLock()
+	method, ok := s.stateStores[name]
+	s.lock.RUnlock()
+	if ok {
 		return method(), nil
 	}
 	return nil, fmt.Errorf("couldn't find state store %s", name)
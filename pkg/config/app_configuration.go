@@ -8,11 +8,40 @@ package config
 // ApplicationConfig is an optional config supplied by user code.
 type ApplicationConfig struct {
 	Entities []string `json:"entities"`
+	// Duration. example: "1h"
+	ActorIdleTimeout string `json:"actorIdleTimeout"`
+	// Duration. example: "30s"
+	ActorScanInterval string `json:"actorScanInterval"`
+	// Duration. example: "30s"
+	DrainOngoingCallTimeout string           `json:"drainOngoingCallTimeout"`
+	DrainRebalancedActors   bool             `json:"drainRebalancedActors"`
+	Reentrancy              ReentrancyConfig `json:"reentrancy,omitempty"`
+	// Number of keys the reminders of each actor type are saved under
+	RemindersStoragePartitions int `json:"remindersStoragePartitions"`
+	// Overrides of the actor configuration, by actor type
+	EntitiesConfig map[string]EntityConfig `json:"entitiesConfig,omitempty"`
+	// Activate and deactivate actors through the actors/{type}/{id}/activate and deactivate endpoints
+	ActorLifecycleCallbacks bool `json:"actorLifecycleCallbacks"`
+	// Maximum number of reminders and timers firing at once, 0 doesn't limit them
+	MaxConcurrentReminderFirings int `json:"maxConcurrentReminderFirings,omitempty"`
+}
+
+// EntityConfig overrides the actor configuration of the app for an actor type.
+// The durations which are not set are inherited from the app configuration.
+type EntityConfig struct {
 	// Duration. example: "1h"
 	ActorIdleTimeout string `json:"actorIdleTimeout"`
 	// Duration. example: "30s"
 	ActorScanInterval string `json:"actorScanInterval"`
 	// Duration. example: "30s"
 	DrainOngoingCallTimeout string `json:"drainOngoingCallTimeout"`
-	DrainRebalancedActors   bool   `json:"drainRebalancedActors"`
+	// How overdue reminders catch up: "fireOnce" (default), "fireAll" or "skip"
+	ReminderCatchUpPolicy string `json:"reminderCatchUpPolicy,omitempty"`
+}
+
+// ReentrancyConfig allows the calls of an actor call chain to re-enter an actor of the chain
+type ReentrancyConfig struct {
+	Enabled bool `json:"enabled"`
+	// MaxStackDepth is the maximum number of nested calls of an actor within a call chain
+	MaxStackDepth *int `json:"maxStackDepth,omitempty"`
 }
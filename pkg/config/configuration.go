@@ -11,26 +11,72 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
+	"strings"
 	"time"
 
 	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"github.com/valyala/fasthttp"
 	yaml "gopkg.in/yaml.v2"
 )
 
 const (
 	operatorCallTimeout = time.Second * 5
 	operatorMaxRetries  = 100
+
+	// AllowAccess allows the app to read the secrets of a secret store which aren't denied
+	AllowAccess = "allow"
+	// DenyAccess denies the app the secrets of a secret store which aren't allowed
+	DenyAccess = "deny"
+
+	// ActorReentrancy lets the app enable the reentrancy of its actors in the app configuration
+	ActorReentrancy Feature = "Actor.Reentrancy"
+	// HotReload applies the changes of the configuration without restarting the sidecar
+	HotReload Feature = "HotReload"
+)
+
+// The Dapr APIs which are allowed or denied in the API spec
+const (
+	ActorsAPI        = "actors"
+	BindingsAPI      = "bindings"
+	ConfigurationAPI = "configuration"
+	CryptoAPI        = "crypto"
+	InvokeAPI        = "invoke"
+	JobsAPI          = "jobs"
+	MetadataAPI      = "metadata"
+	PubSubAPI        = "pubsub"
+	SecretsAPI       = "secrets"
+	ShutdownAPI      = "shutdown"
+	StateAPI         = "state"
+	WorkflowsAPI     = "workflows"
 )
 
+// Feature is a preview feature of the runtime, which is disabled unless it is enabled in the configuration
+type Feature string
+
 type Configuration struct {
 	Spec ConfigurationSpec `json:"spec" yaml:"spec"`
 }
 
 type ConfigurationSpec struct {
-	HTTPPipelineSpec PipelineSpec `json:"httpPipeline,omitempty" yaml:"httpPipeline,omitempty"`
-	TracingSpec      TracingSpec  `json:"tracing,omitempty" yaml:"tracing,omitempty"`
-	MTLSSpec         MTLSSpec     `json:"mtls,omitempty"`
+	HTTPPipelineSpec  PipelineSpec      `json:"httpPipeline,omitempty" yaml:"httpPipeline,omitempty"`
+	TracingSpec       TracingSpec       `json:"tracing,omitempty" yaml:"tracing,omitempty"`
+	MTLSSpec          MTLSSpec          `json:"mtls,omitempty"`
+	MetadataSpec      MetadataSpec      `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	InvocationSpec    InvocationSpec    `json:"serviceInvocation,omitempty" yaml:"serviceInvocation,omitempty"`
+	PubSubSpec        PubSubSpec        `json:"pubsub,omitempty" yaml:"pubsub,omitempty"`
+	Secrets           SecretsSpec       `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	Features          []FeatureSpec     `json:"features,omitempty" yaml:"features,omitempty"`
+	AccessControlSpec AccessControlSpec `json:"accessControl,omitempty" yaml:"accessControl,omitempty"`
+	APISpec           APISpec           `json:"api,omitempty" yaml:"api,omitempty"`
+	AuditSpec         AuditSpec         `json:"audit,omitempty" yaml:"audit,omitempty"`
+}
+
+// FeatureSpec enables or disables a preview feature
+type FeatureSpec struct {
+	Name    Feature `json:"name" yaml:"name"`
+	Enabled bool    `json:"enabled" yaml:"enabled"`
 }
 
 type PipelineSpec struct {
@@ -56,6 +102,254 @@ type TracingSpec struct {
 	SamplingRate string `json:"samplingRate" yaml:"samplingRate"`
 }
 
+// MetadataSpec defines the size limits of metadata and headers for service invocation
+type MetadataSpec struct {
+	// MaxTotalSize and MaxValueLength reject the requests over the limits, the headers of the responses over the
+	// limits are trimmed
+	MaxTotalSize   int `json:"maxTotalSize,omitempty" yaml:"maxTotalSize,omitempty"`
+	MaxValueLength int `json:"maxValueLength,omitempty" yaml:"maxValueLength,omitempty"`
+	// MaxAppHeaderCount and MaxAppHeaderSize limit the headers forwarded to the app, the headers over the limits
+	// are trimmed
+	MaxAppHeaderCount int `json:"maxAppHeaderCount,omitempty" yaml:"maxAppHeaderCount,omitempty"`
+	MaxAppHeaderSize  int `json:"maxAppHeaderSize,omitempty" yaml:"maxAppHeaderSize,omitempty"`
+}
+
+// InvocationSpec defines the configuration of service invocation
+type InvocationSpec struct {
+	// HedgingDelay is the delay after which a hedge request is sent to another instance of the target app for
+	// idempotent invocations.
+	// Hedging is disabled when empty.
+	HedgingDelay string `json:"hedgingDelay,omitempty" yaml:"hedgingDelay,omitempty"`
+}
+
+// PubSubSpec defines the configuration of pub/sub
+type PubSubSpec struct {
+	// DefaultComponent is the pub/sub component used by requests and subscriptions which do not name one.
+	// The first loaded pub/sub component is the default when empty.
+	DefaultComponent string `json:"defaultComponent,omitempty" yaml:"defaultComponent,omitempty"`
+}
+
+// SecretsSpec defines the secrets the app is allowed to read through the secrets API
+type SecretsSpec struct {
+	Scopes []SecretsScope `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+// SecretsScope defines the secrets of a secret store the app is allowed to read
+type SecretsScope struct {
+	StoreName string `json:"storeName" yaml:"storeName"`
+	// DefaultAccess is the access to the secrets which aren't listed, "allow" (default) or "deny"
+	DefaultAccess  string   `json:"defaultAccess,omitempty" yaml:"defaultAccess,omitempty"`
+	AllowedSecrets []string `json:"allowedSecrets,omitempty" yaml:"allowedSecrets,omitempty"`
+	DeniedSecrets  []string `json:"deniedSecrets,omitempty" yaml:"deniedSecrets,omitempty"`
+}
+
+// IsSecretAllowed returns whether the app is allowed to read the secret with the given key.
+// The denied secrets are always denied. When allowed secrets are listed only those are allowed,
+// otherwise the secrets get the default access.
+func (s SecretsScope) IsSecretAllowed(key string) bool {
+	if containsKey(s.DeniedSecrets, key) {
+		return false
+	}
+	if len(s.AllowedSecrets) != 0 {
+		return containsKey(s.AllowedSecrets, key)
+	}
+	return !strings.EqualFold(s.DefaultAccess, DenyAccess)
+}
+
+// SecretScopes returns the secret scopes by secret store name
+func (c *Configuration) SecretScopes() map[string]SecretsScope {
+	scopes := map[string]SecretsScope{}
+	for _, scope := range c.Spec.Secrets.Scopes {
+		scopes[scope.StoreName] = scope
+	}
+	return scopes
+}
+
+// AccessControlSpec isolates the apps of namespaces sharing the same infrastructure
+type AccessControlSpec struct {
+	// TrustDomain is the trust domain of the workload certificates of the app. The trust domain of the callers
+	// isn't checked when empty.
+	TrustDomain string `json:"trustDomain,omitempty" yaml:"trustDomain,omitempty"`
+	// NamespaceIsolation denies the calls from the apps of other namespaces and trust domains, and scopes the
+	// topics of the pub/sub components and the actor types to the namespace of the app
+	NamespaceIsolation bool `json:"namespaceIsolation,omitempty" yaml:"namespaceIsolation,omitempty"`
+	// AllowedNamespaces are the other namespaces whose apps are allowed to call the app
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty" yaml:"allowedNamespaces,omitempty"`
+	// AllowedTrustDomains are the other trust domains whose apps are allowed to call the app
+	AllowedTrustDomains []string `json:"allowedTrustDomains,omitempty" yaml:"allowedTrustDomains,omitempty"`
+	// DefaultAction is the action on the invocations of the app by the callers no policy matches, allow or deny.
+	// The invocations aren't checked when it's empty and there are no policies.
+	DefaultAction string `json:"defaultAction,omitempty" yaml:"defaultAction,omitempty"`
+	// Policies are the actions on the invocations of the app by the callers of the SPIFFE IDs they match
+	Policies []AppPolicySpec `json:"policies,omitempty" yaml:"policies,omitempty"`
+}
+
+// AppPolicySpec is the action on the invocations by the callers of the SPIFFE ID
+// spiffe://<trust-domain>/ns/<namespace>/<app-id>. An empty namespace or trust domain matches any.
+type AppPolicySpec struct {
+	AppID       string `json:"appId" yaml:"appId"`
+	Namespace   string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	TrustDomain string `json:"trustDomain,omitempty" yaml:"trustDomain,omitempty"`
+	// DefaultAction is allow or deny, the default action of the access control is used when it's empty
+	DefaultAction string `json:"defaultAction,omitempty" yaml:"defaultAction,omitempty"`
+	// AppOperationActions are the actions on the invocations of the operations they match, the first matching
+	// operation applies. The default action of the policy applies to the invocations no operation matches.
+	AppOperationActions []AppOperationAction `json:"operations,omitempty" yaml:"operations,omitempty"`
+}
+
+// AppOperationAction is the action on the invocations of a method path with the HTTP verbs
+type AppOperationAction struct {
+	// Name is the path of the method, a path ending with * matches all the methods starting with it,
+	// e.g. /orders/* matches /orders/1 and /orders/1/items
+	Name string `json:"name" yaml:"name"`
+	// HTTPVerb are the HTTP verbs of the invocations, * or an empty list matches all the verbs
+	HTTPVerb []string `json:"httpVerb,omitempty" yaml:"httpVerb,omitempty"`
+	// Action is allow or deny
+	Action string `json:"action" yaml:"action"`
+}
+
+// matches returns whether the policy applies to the caller of the SPIFFE ID
+func (p AppPolicySpec) matches(appID, namespace, trustDomain string) bool {
+	return p.AppID == appID &&
+		(p.Namespace == "" || p.Namespace == namespace) &&
+		(p.TrustDomain == "" || p.TrustDomain == trustDomain)
+}
+
+// operationAction returns the action of the first operation of the policy matching the invocation
+func (p AppPolicySpec) operationAction(httpVerb, method string) (string, bool) {
+	for _, o := range p.AppOperationActions {
+		if o.matches(httpVerb, method) {
+			return o.Action, true
+		}
+	}
+	return "", false
+}
+
+// matches returns whether the operation applies to the invocation of the method with the HTTP verb. The method is
+// matched on the path the app receives, so a method like /orders/../admin or /orders/%2e%2e/admin doesn't match
+// /orders/*.
+func (o AppOperationAction) matches(httpVerb, method string) bool {
+	if len(o.HTTPVerb) > 0 && !containsKey(o.HTTPVerb, "*") && !containsVerb(o.HTTPVerb, httpVerb) {
+		return false
+	}
+	method = path.Clean(appPath(method))
+	name := "/" + strings.TrimPrefix(o.Name, "/")
+	if strings.HasSuffix(name, "*") {
+		return strings.HasPrefix(method, strings.TrimSuffix(name, "*"))
+	}
+	return method == path.Clean(name)
+}
+
+// appPath returns the path of the method as the app channel sends it to the app: the method is put in the URI
+// of the request, which decodes and normalizes its path
+func appPath(method string) string {
+	var uri fasthttp.URI
+	uri.Parse(nil, []byte("http://localhost/"+method))
+	return string(uri.Path())
+}
+
+func containsVerb(verbs []string, verb string) bool {
+	for _, v := range verbs {
+		if strings.EqualFold(v, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInvocationACLEnabled returns whether the invocations of the app are checked against the access control policies
+func (s AccessControlSpec) IsInvocationACLEnabled() bool {
+	return s.DefaultAction != "" || len(s.Policies) > 0
+}
+
+// IsInvocationAllowed returns whether the caller of the SPIFFE ID made of the app id, namespace and trust domain is
+// allowed to invoke the method of the app with the HTTP verb. The first matching policy applies.
+func (s AccessControlSpec) IsInvocationAllowed(appID, namespace, trustDomain, httpVerb, method string) bool {
+	action := s.DefaultAction
+	for _, p := range s.Policies {
+		if p.matches(appID, namespace, trustDomain) {
+			if p.DefaultAction != "" {
+				action = p.DefaultAction
+			}
+			if operationAction, ok := p.operationAction(httpVerb, method); ok {
+				action = operationAction
+			}
+			break
+		}
+	}
+	return action != DenyAccess
+}
+
+// IsCallerAllowed returns whether an app of the given namespace and trust domain is allowed to call an app of the
+// namespace. All the callers are allowed unless namespaces are isolated.
+func (s AccessControlSpec) IsCallerAllowed(namespace, callerNamespace, callerTrustDomain string) bool {
+	if !s.NamespaceIsolation {
+		return true
+	}
+	if s.TrustDomain != "" && callerTrustDomain != s.TrustDomain && !containsKey(s.AllowedTrustDomains, callerTrustDomain) {
+		return false
+	}
+	return callerNamespace == namespace || containsKey(s.AllowedNamespaces, callerNamespace)
+}
+
+// APISpec restricts the Dapr APIs the app is allowed to use, the health API is always allowed
+type APISpec struct {
+	// Allowed are the APIs the app is allowed to use, all the APIs are allowed when empty
+	Allowed []string `json:"allowed,omitempty" yaml:"allowed,omitempty"`
+	// Denied are the APIs the app isn't allowed to use, even when they're allowed
+	Denied []string `json:"denied,omitempty" yaml:"denied,omitempty"`
+}
+
+// IsAPIAllowed returns whether the app is allowed to use the API
+func (s APISpec) IsAPIAllowed(api string) bool {
+	if containsKey(s.Denied, api) {
+		return false
+	}
+	return len(s.Allowed) == 0 || containsKey(s.Allowed, api)
+}
+
+// AuditSpec records the secrets API calls and the state deletes of the app to an audit sink
+type AuditSpec struct {
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Sink is where the audit events are written, log or binding. The events are logged by default.
+	Sink string `json:"sink,omitempty" yaml:"sink,omitempty"`
+	// BindingName is the output binding the events are sent to with the binding sink
+	BindingName string `json:"bindingName,omitempty" yaml:"bindingName,omitempty"`
+	// BlockWhenFull makes the audited requests wait for room in the queue of the audit events when it is full,
+	// instead of dropping their events
+	BlockWhenFull bool `json:"blockWhenFull,omitempty" yaml:"blockWhenFull,omitempty"`
+}
+
+// IsFeatureEnabled returns whether the preview feature is enabled, the features which aren't listed are disabled
+func (c *Configuration) IsFeatureEnabled(feature Feature) bool {
+	for _, f := range c.Spec.Features {
+		if f.Name == feature {
+			return f.Enabled
+		}
+	}
+	return false
+}
+
+// EnabledFeatures returns the names of the enabled preview features
+func (c *Configuration) EnabledFeatures() []string {
+	enabled := []string{}
+	for _, f := range c.Spec.Features {
+		if f.Enabled {
+			enabled = append(enabled, string(f.Name))
+		}
+	}
+	return enabled
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 type MTLSSpec struct {
 	Enabled          bool   `json:"enabled"`
 	WorkloadCertTTL  string `json:"workloadCertTTL"`
@@ -84,6 +378,10 @@ func LoadStandaloneConfiguration(config string) (*Configuration, error) {
 	if err != nil {
 		return nil, err
 	}
+	b, err = ExpandEnvTemplate(b)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding %s: %s", config, err)
+	}
 
 	var conf Configuration
 	err = yaml.Unmarshal(b, &conf)
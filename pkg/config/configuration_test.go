@@ -0,0 +1,206 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestIsSecretAllowed(t *testing.T) {
+	t.Run("all secrets are allowed by default", func(t *testing.T) {
+		scope := SecretsScope{StoreName: "store1"}
+		assert.True(t, scope.IsSecretAllowed("secret1"))
+	})
+
+	t.Run("default access deny", func(t *testing.T) {
+		scope := SecretsScope{StoreName: "store1", DefaultAccess: "Deny"}
+		assert.False(t, scope.IsSecretAllowed("secret1"))
+	})
+
+	t.Run("only the allowed secrets are allowed", func(t *testing.T) {
+		scope := SecretsScope{StoreName: "store1", AllowedSecrets: []string{"secret1"}}
+		assert.True(t, scope.IsSecretAllowed("secret1"))
+		assert.False(t, scope.IsSecretAllowed("secret2"))
+	})
+
+	t.Run("denied secrets are denied even if allowed", func(t *testing.T) {
+		scope := SecretsScope{StoreName: "store1", AllowedSecrets: []string{"secret1", "secret2"}, DeniedSecrets: []string{"secret1"}}
+		assert.False(t, scope.IsSecretAllowed("secret1"))
+		assert.True(t, scope.IsSecretAllowed("secret2"))
+	})
+
+	t.Run("denied secrets are denied", func(t *testing.T) {
+		scope := SecretsScope{StoreName: "store1", DeniedSecrets: []string{"secret1"}}
+		assert.False(t, scope.IsSecretAllowed("secret1"))
+		assert.True(t, scope.IsSecretAllowed("secret2"))
+	})
+}
+
+func TestSecretScopes(t *testing.T) {
+	c := &Configuration{Spec: ConfigurationSpec{Secrets: SecretsSpec{Scopes: []SecretsScope{
+		{StoreName: "store1", DefaultAccess: DenyAccess},
+	}}}}
+	scopes := c.SecretScopes()
+	assert.Len(t, scopes, 1)
+	assert.Equal(t, DenyAccess, scopes["store1"].DefaultAccess)
+}
+
+func TestIsFeatureEnabled(t *testing.T) {
+	var conf Configuration
+	err := yaml.Unmarshal([]byte(`spec:
+  features:
+  - name: HotReload
+    enabled: true
+  - name: Actor.Reentrancy
+    enabled: false
+`), &conf)
+	assert.NoError(t, err)
+
+	assert.True(t, conf.IsFeatureEnabled(HotReload))
+	assert.False(t, conf.IsFeatureEnabled(ActorReentrancy))
+	assert.False(t, conf.IsFeatureEnabled(Feature("Unknown")))
+	assert.Equal(t, []string{"HotReload"}, conf.EnabledFeatures())
+	assert.Empty(t, LoadDefaultConfiguration().EnabledFeatures())
+}
+
+func TestIsCallerAllowed(t *testing.T) {
+	t.Run("namespaces aren't isolated", func(t *testing.T) {
+		spec := AccessControlSpec{}
+		assert.True(t, spec.IsCallerAllowed("ns1", "ns2", "td2"))
+	})
+
+	t.Run("namespaces are isolated", func(t *testing.T) {
+		spec := AccessControlSpec{
+			TrustDomain:         "td1",
+			NamespaceIsolation:  true,
+			AllowedNamespaces:   []string{"ns2"},
+			AllowedTrustDomains: []string{"td2"},
+		}
+		assert.True(t, spec.IsCallerAllowed("ns1", "ns1", "td1"))
+		assert.True(t, spec.IsCallerAllowed("ns1", "ns2", "td2"))
+		assert.False(t, spec.IsCallerAllowed("ns1", "ns3", "td1"))
+		assert.False(t, spec.IsCallerAllowed("ns1", "ns1", "td3"))
+	})
+
+	t.Run("trust domain isn't checked", func(t *testing.T) {
+		spec := AccessControlSpec{NamespaceIsolation: true}
+		assert.True(t, spec.IsCallerAllowed("ns1", "ns1", "td3"))
+		assert.False(t, spec.IsCallerAllowed("ns1", "ns2", "td3"))
+	})
+}
+
+func TestIsInvocationAllowed(t *testing.T) {
+	t.Run("policies aren't enabled", func(t *testing.T) {
+		spec := AccessControlSpec{}
+		assert.False(t, spec.IsInvocationACLEnabled())
+		assert.True(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders"))
+	})
+
+	spec := AccessControlSpec{
+		DefaultAction: DenyAccess,
+		Policies: []AppPolicySpec{
+			{AppID: "app1", Namespace: "ns1", TrustDomain: "td1", DefaultAction: AllowAccess},
+			{AppID: "app2", DefaultAction: AllowAccess},
+			{AppID: "app3"},
+		},
+	}
+	assert.True(t, spec.IsInvocationACLEnabled())
+
+	t.Run("matching policy", func(t *testing.T) {
+		assert.True(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders"))
+		assert.True(t, spec.IsInvocationAllowed("app2", "ns2", "td2", "GET", "orders"))
+	})
+
+	t.Run("policy of another namespace or trust domain", func(t *testing.T) {
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns2", "td1", "GET", "orders"))
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td2", "GET", "orders"))
+	})
+
+	t.Run("default action", func(t *testing.T) {
+		assert.False(t, spec.IsInvocationAllowed("app3", "ns1", "td1", "GET", "orders"))
+		assert.False(t, spec.IsInvocationAllowed("app4", "ns1", "td1", "GET", "orders"))
+	})
+}
+
+func TestIsInvocationAllowedOperations(t *testing.T) {
+	spec := AccessControlSpec{
+		DefaultAction: AllowAccess,
+		Policies: []AppPolicySpec{
+			{
+				AppID:         "app1",
+				DefaultAction: DenyAccess,
+				AppOperationActions: []AppOperationAction{
+					{Name: "/orders/admin/*", Action: DenyAccess},
+					{Name: "/orders/*", HTTPVerb: []string{"GET"}, Action: AllowAccess},
+					{Name: "status", HTTPVerb: []string{"*"}, Action: AllowAccess},
+				},
+			},
+			{
+				AppID: "app2",
+				AppOperationActions: []AppOperationAction{
+					{Name: "/orders", HTTPVerb: []string{"post", "PUT"}, Action: DenyAccess},
+				},
+			},
+		},
+	}
+
+	t.Run("path prefix and verb", func(t *testing.T) {
+		assert.True(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders/1"))
+		assert.True(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "/orders/1/items"))
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "POST", "orders/1"))
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders"))
+	})
+
+	t.Run("first matching operation applies", func(t *testing.T) {
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders/admin/users"))
+	})
+
+	t.Run("method is cleaned before matching", func(t *testing.T) {
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders/../admin"))
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders/1/../admin/users"))
+	})
+
+	t.Run("encoded traversals are matched on the path the app receives", func(t *testing.T) {
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders/%2e%2e/admin"))
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders/..%2fadmin"))
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders/%2E%2E%2Fadmin"))
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders/1/%2e%2e/%2e%2e/admin"))
+		assert.True(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders%2F1"))
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "orders/%61dmin/users"))
+	})
+
+	t.Run("exact path with any verb", func(t *testing.T) {
+		assert.True(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "DELETE", "status"))
+		assert.True(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "", "/status/"))
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "status/1"))
+	})
+
+	t.Run("operations without a match use the default action of the policy", func(t *testing.T) {
+		assert.False(t, spec.IsInvocationAllowed("app1", "ns1", "td1", "GET", "invoices"))
+		assert.True(t, spec.IsInvocationAllowed("app2", "ns1", "td1", "GET", "orders"))
+	})
+
+	t.Run("verbs are case insensitive", func(t *testing.T) {
+		assert.False(t, spec.IsInvocationAllowed("app2", "ns1", "td1", "POST", "orders"))
+		assert.False(t, spec.IsInvocationAllowed("app2", "ns1", "td1", "put", "orders"))
+	})
+}
+
+func TestIsAPIAllowed(t *testing.T) {
+	assert.True(t, APISpec{}.IsAPIAllowed(StateAPI))
+
+	spec := APISpec{Denied: []string{SecretsAPI}}
+	assert.True(t, spec.IsAPIAllowed(StateAPI))
+	assert.False(t, spec.IsAPIAllowed(SecretsAPI))
+
+	spec = APISpec{Allowed: []string{StateAPI, PubSubAPI}, Denied: []string{PubSubAPI}}
+	assert.True(t, spec.IsAPIAllowed(StateAPI))
+	assert.False(t, spec.IsAPIAllowed(PubSubAPI))
+	assert.False(t, spec.IsAPIAllowed(SecretsAPI))
+}
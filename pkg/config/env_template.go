@@ -0,0 +1,119 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ComponentEnvVarPrefix prefixes the environment variables of the runtime the components and configurations can reference
+const ComponentEnvVarPrefix = "DAPR_COMPONENT_ENV_"
+
+var (
+	// envTemplate matches {{ env "NAME" }} and {{ env "NAME" "default" }}
+	envTemplate = regexp.MustCompile(`{{\s*env\s+"([^"]*)"(?:\s+"([^"]*)")?\s*}}`)
+	// envPlaceholder matches the placeholders the templates are swapped for before the file is parsed
+	envPlaceholder = regexp.MustCompile(`__dapr_env_template_(\d+)__`)
+)
+
+// ExpandEnvTemplate replaces the {{ env "NAME" }} templates of a standalone YAML file with the value of the
+// environment variable NAME, so the same file can be used across environments. Only the variables prefixed with
+// ComponentEnvVarPrefix can be referenced. The default value of {{ env "NAME" "default" }} is used when the
+// variable is not set. A variable which is not set and has no default value is an error. Other {{ }} expressions
+// are kept as is.
+//
+// The templates are expanded in the string values of the parsed documents, which are written back, so a value
+// can't change the structure of the file. The values are strings, whatever their content.
+func ExpandEnvTemplate(b []byte) ([]byte, error) {
+	if !envTemplate.Match(b) {
+		return b, nil
+	}
+
+	var templates [][][]byte
+	b = envTemplate.ReplaceAllFunc(b, func(match []byte) []byte {
+		templates = append(templates, envTemplate.FindSubmatch(match))
+		return []byte(fmt.Sprintf("__dapr_env_template_%d__", len(templates)-1))
+	})
+
+	var failed []string
+	expand := func(s string) string {
+		return envPlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+			i, _ := strconv.Atoi(envPlaceholder.FindStringSubmatch(placeholder)[1])
+			groups := templates[i]
+			name := string(groups[1])
+			if !strings.HasPrefix(name, ComponentEnvVarPrefix) {
+				failed = append(failed, fmt.Sprintf("environment variable %s can't be referenced, only the variables prefixed with %s can", name, ComponentEnvVarPrefix))
+				return placeholder
+			}
+			if value, ok := os.LookupEnv(name); ok {
+				return value
+			}
+			// the default group is nil when the template has no default value, and empty for an empty default
+			if groups[2] != nil {
+				return string(groups[2])
+			}
+			failed = append(failed, fmt.Sprintf("environment variable %s is not set and has no default value", name))
+			return placeholder
+		})
+	}
+
+	var expanded bytes.Buffer
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			continue
+		}
+		out, err := yaml.Marshal(expandStrings(doc, expand))
+		if err != nil {
+			return nil, err
+		}
+		if expanded.Len() > 0 {
+			expanded.WriteString("---\n")
+		}
+		expanded.Write(out)
+	}
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("failed to expand the environment variables: %s", strings.Join(failed, "; "))
+	}
+	return expanded.Bytes(), nil
+}
+
+// expandStrings applies expand to the string keys and values of a parsed YAML document
+func expandStrings(node interface{}, expand func(string) string) interface{} {
+	switch n := node.(type) {
+	case string:
+		return expand(n)
+	case map[interface{}]interface{}:
+		expanded := make(map[interface{}]interface{}, len(n))
+		for k, v := range n {
+			expanded[expandStrings(k, expand)] = expandStrings(v, expand)
+		}
+		return expanded
+	case []interface{}:
+		for i, v := range n {
+			n[i] = expandStrings(v, expand)
+		}
+		return n
+	default:
+		return node
+	}
+}
@@ -0,0 +1,86 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestExpandEnvTemplate(t *testing.T) {
+	os.Setenv("DAPR_COMPONENT_ENV_REDIS_HOST", "redis:6379")
+	defer os.Unsetenv("DAPR_COMPONENT_ENV_REDIS_HOST")
+
+	expand := func(t *testing.T, in string) map[string]interface{} {
+		b, err := ExpandEnvTemplate([]byte(in))
+		assert.NoError(t, err)
+		var out map[string]interface{}
+		assert.NoError(t, yaml.Unmarshal(b, &out))
+		return out
+	}
+
+	t.Run("variables are expanded", func(t *testing.T) {
+		out := expand(t, `value: {{ env "DAPR_COMPONENT_ENV_REDIS_HOST" }}
+url: "redis://{{ env "DAPR_COMPONENT_ENV_REDIS_HOST" }}/0"`)
+		assert.Equal(t, map[string]interface{}{"value": "redis:6379", "url": "redis://redis:6379/0"}, out)
+	})
+
+	t.Run("set variables take precedence over defaults", func(t *testing.T) {
+		out := expand(t, `value: {{env "DAPR_COMPONENT_ENV_REDIS_HOST" "localhost:6379"}}`)
+		assert.Equal(t, "redis:6379", out["value"])
+	})
+
+	t.Run("defaults are used for missing variables", func(t *testing.T) {
+		out := expand(t, `value: {{ env "DAPR_COMPONENT_ENV_MISSING" "localhost:6379" }}
+password: "{{ env "DAPR_COMPONENT_ENV_MISSING" "" }}"`)
+		assert.Equal(t, map[string]interface{}{"value": "localhost:6379", "password": ""}, out)
+	})
+
+	t.Run("missing variables without default fail", func(t *testing.T) {
+		_, err := ExpandEnvTemplate([]byte(`value: {{ env "DAPR_COMPONENT_ENV_MISSING" }}`))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "DAPR_COMPONENT_ENV_MISSING")
+	})
+
+	t.Run("variables without the prefix can't be referenced", func(t *testing.T) {
+		os.Setenv("DAPR_TEST_SECRET", "secret")
+		defer os.Unsetenv("DAPR_TEST_SECRET")
+
+		_, err := ExpandEnvTemplate([]byte(`value: {{ env "DAPR_TEST_SECRET" }}`))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), ComponentEnvVarPrefix)
+	})
+
+	t.Run("values can't change the structure of the file", func(t *testing.T) {
+		os.Setenv("DAPR_COMPONENT_ENV_INJECTED", "x\nadmin: true # ")
+		defer os.Unsetenv("DAPR_COMPONENT_ENV_INJECTED")
+
+		out := expand(t, `value: {{ env "DAPR_COMPONENT_ENV_INJECTED" }}
+quoted: "{{ env "DAPR_COMPONENT_ENV_INJECTED" }}"`)
+		assert.Equal(t, map[string]interface{}{
+			"value":  "x\nadmin: true # ",
+			"quoted": "x\nadmin: true # ",
+		}, out)
+	})
+
+	t.Run("documents are kept", func(t *testing.T) {
+		b, err := ExpandEnvTemplate([]byte(`value: {{ env "DAPR_COMPONENT_ENV_REDIS_HOST" }}
+---
+value: b
+`))
+		assert.NoError(t, err)
+		assert.Equal(t, "value: redis:6379\n---\nvalue: b\n", string(b))
+	})
+
+	t.Run("files without templates are kept", func(t *testing.T) {
+		b, err := ExpandEnvTemplate([]byte(`value: "{{ .Name }}"`))
+		assert.NoError(t, err)
+		assert.Equal(t, `value: "{{ .Name }}"`, string(b))
+	})
+}
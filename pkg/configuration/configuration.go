@@ -0,0 +1,57 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package configuration
+
+import (
+	"context"
+)
+
+// Store is a configuration store component, which holds configuration items such as the feature flags
+// and settings of apps
+type Store interface {
+	Init(metadata Metadata) error
+	// Get returns the items of the requested keys, or all the items of the store when no keys are requested.
+	// Keys which don't exist are left out of the response.
+	Get(ctx context.Context, req *GetRequest) (*GetResponse, error)
+	// Subscribe calls handler with the items of the subscribed keys which changed, until ctx is done
+	// or handler fails. All the items of the store are subscribed to when no keys are requested.
+	Subscribe(ctx context.Context, req *SubscribeRequest, handler func(*UpdateEvent) error) error
+}
+
+// Metadata holds the properties of a configuration store component
+type Metadata struct {
+	Properties map[string]string
+}
+
+// Item is a configuration item. Version changes every time the value changes.
+type Item struct {
+	Key      string            `json:"key"`
+	Value    string            `json:"value"`
+	Version  string            `json:"version,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// GetRequest is a request to get configuration items
+type GetRequest struct {
+	Keys     []string
+	Metadata map[string]string
+}
+
+// GetResponse holds the requested configuration items
+type GetResponse struct {
+	Items []*Item
+}
+
+// SubscribeRequest is a subscription to the changes of configuration items
+type SubscribeRequest struct {
+	Keys     []string
+	Metadata map[string]string
+}
+
+// UpdateEvent holds the configuration items which changed
+type UpdateEvent struct {
+	Items []*Item
+}
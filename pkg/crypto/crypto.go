@@ -0,0 +1,26 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package crypto
+
+import (
+	"context"
+)
+
+// KeyVault is a key management component. It holds the key encryption keys of apps, which never leave
+// the vault, and wraps the data encryption keys Dapr generates to encrypt payloads with them.
+type KeyVault interface {
+	Init(metadata Metadata) error
+	// WrapKey encrypts key with the key encryption key named keyName. The algorithm is specific to
+	// the vault, and the vault picks its default algorithm when it's empty.
+	WrapKey(ctx context.Context, key []byte, keyName, algorithm string) ([]byte, error)
+	// UnwrapKey decrypts a key wrapped by WrapKey with the same key name and algorithm
+	UnwrapKey(ctx context.Context, wrappedKey []byte, keyName, algorithm string) ([]byte, error)
+}
+
+// Metadata holds the properties of a key vault component
+type Metadata struct {
+	Properties map[string]string
+}
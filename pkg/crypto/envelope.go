@@ -0,0 +1,297 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package crypto
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Payloads are encrypted with envelope encryption: every payload is encrypted with a new random
+// AES-256 data encryption key, which is wrapped by the key vault and stored in the header of the
+// payload. The payload is split into segments sealed with AES-GCM, so that large payloads are
+// encrypted and decrypted as streams. The nonce of a segment holds its counter and whether it is
+// the last segment, so segments can't be reordered, dropped or truncated, and the header is
+// authenticated as the additional data of every segment.
+const (
+	// Cipher is the cipher of the payloads encrypted by NewEncryptWriter
+	Cipher = "A256GCM-STREAM"
+
+	magic       = "dapr.io/enc/v1\n"
+	segmentSize = 64 * 1024
+	keySize     = 32
+	prefixSize  = 7
+	tagSize     = 16
+)
+
+var (
+	// ErrInvalidPayload is returned when a payload is not encrypted by Dapr, or was altered
+	ErrInvalidPayload = errors.New("invalid encrypted payload")
+	// ErrKeyNameMismatch is returned when a payload wasn't encrypted with the expected key
+	ErrKeyNameMismatch = errors.New("payload not encrypted with the expected key")
+)
+
+type header struct {
+	Cipher      string `json:"cipher"`
+	KeyName     string `json:"keyName"`
+	Algorithm   string `json:"algorithm,omitempty"`
+	WrappedKey  []byte `json:"wrappedKey"`
+	NoncePrefix []byte `json:"noncePrefix"`
+}
+
+type segmentCipher struct {
+	aead    cipher.AEAD
+	prefix  []byte
+	header  []byte
+	counter uint32
+}
+
+func newSegmentCipher(key, prefix, header []byte) (*segmentCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &segmentCipher{aead: aead, prefix: prefix, header: header}, nil
+}
+
+func (s *segmentCipher) nonce(last bool) ([]byte, error) {
+	if s.counter == math.MaxUint32 {
+		return nil, errors.New("payload too large")
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	copy(nonce, s.prefix)
+	binary.BigEndian.PutUint32(nonce[prefixSize:], s.counter)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	s.counter++
+	return nonce, nil
+}
+
+func (s *segmentCipher) seal(dst, plaintext []byte, last bool) ([]byte, error) {
+	nonce, err := s.nonce(last)
+	if err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(dst, nonce, plaintext, s.header), nil
+}
+
+func (s *segmentCipher) open(dst, ciphertext []byte, last bool) ([]byte, error) {
+	nonce, err := s.nonce(last)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := s.aead.Open(dst, nonce, ciphertext, s.header)
+	if err != nil {
+		return nil, ErrInvalidPayload
+	}
+	return plaintext, nil
+}
+
+type encryptWriter struct {
+	dst    io.Writer
+	cipher *segmentCipher
+	buf    []byte
+	out    []byte
+	closed bool
+}
+
+// NewEncryptWriter returns a writer which encrypts the payload written to it with the key keyName of vault,
+// and writes the encrypted payload to dst. The payload is only complete once the writer is closed.
+func NewEncryptWriter(ctx context.Context, vault KeyVault, dst io.Writer, keyName, algorithm string) (io.WriteCloser, error) {
+	key := make([]byte, keySize)
+	prefix := make([]byte, prefixSize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := vault.WrapKey(ctx, key, keyName, algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping the data encryption key with key %s: %s", keyName, err)
+	}
+	h, err := json.Marshal(header{
+		Cipher:      Cipher,
+		KeyName:     keyName,
+		Algorithm:   algorithm,
+		WrappedKey:  wrappedKey,
+		NoncePrefix: prefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	h = append(h, '\n')
+
+	c, err := newSegmentCipher(key, prefix, h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(dst, magic); err != nil {
+		return nil, err
+	}
+	if _, err := dst.Write(h); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{
+		dst:    dst,
+		cipher: c,
+		buf:    make([]byte, 0, segmentSize),
+		out:    make([]byte, 0, segmentSize+tagSize),
+	}, nil
+}
+
+func (w *encryptWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("write to a closed encrypt writer")
+	}
+	n := len(p)
+	for len(p) > 0 {
+		// a full segment is only sealed once more data comes, because the last segment is sealed on Close
+		if len(w.buf) == segmentSize {
+			if err := w.flush(false); err != nil {
+				return n - len(p), err
+			}
+		}
+		c := copy(w.buf[len(w.buf):segmentSize], p)
+		w.buf = w.buf[:len(w.buf)+c]
+		p = p[c:]
+	}
+	return n, nil
+}
+
+func (w *encryptWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.flush(true)
+}
+
+func (w *encryptWriter) flush(last bool) error {
+	out, err := w.cipher.seal(w.out[:0], w.buf, last)
+	if err != nil {
+		return err
+	}
+	w.buf = w.buf[:0]
+	_, err = w.dst.Write(out)
+	return err
+}
+
+type decryptReader struct {
+	src    io.Reader
+	cipher *segmentCipher
+	in     []byte
+	carry  int
+	out    []byte
+	pos    int
+	done   bool
+	err    error
+}
+
+// NewDecryptReader returns a reader of the payload encrypted by NewEncryptWriter read from src. The key name
+// and algorithm are read from the header of the payload, and keyName, when not empty, must match it.
+// The content of a segment is only returned once it is authenticated, but the payload is only known to be
+// complete once the reader returns io.EOF.
+func NewDecryptReader(ctx context.Context, vault KeyVault, src io.Reader, keyName string) (io.Reader, error) {
+	r := bufio.NewReader(src)
+	m, err := r.ReadSlice('\n')
+	if err != nil || string(m) != magic {
+		return nil, ErrInvalidPayload
+	}
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, ErrInvalidPayload
+	}
+	h := append([]byte(nil), line...)
+
+	var hdr header
+	if err := json.Unmarshal(h, &hdr); err != nil || hdr.Cipher != Cipher || len(hdr.NoncePrefix) != prefixSize {
+		return nil, ErrInvalidPayload
+	}
+	if keyName != "" && keyName != hdr.KeyName {
+		return nil, ErrKeyNameMismatch
+	}
+
+	key, err := vault.UnwrapKey(ctx, hdr.WrappedKey, hdr.KeyName, hdr.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("error unwrapping the data encryption key with key %s: %s", hdr.KeyName, err)
+	}
+	if len(key) != keySize {
+		return nil, ErrInvalidPayload
+	}
+	c, err := newSegmentCipher(key, hdr.NoncePrefix, h)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{
+		src:    r,
+		cipher: c,
+		in:     make([]byte, segmentSize+tagSize+1),
+		out:    make([]byte, 0, segmentSize),
+	}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for d.pos == len(d.out) {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if d.done {
+			return 0, io.EOF
+		}
+		d.err = d.next()
+	}
+	n := copy(p, d.out[d.pos:])
+	d.pos += n
+	return n, nil
+}
+
+// next reads and opens the next segment. One byte more than a full segment is read to tell
+// whether the segment is the last one, and carried over to the next segment.
+func (d *decryptReader) next() error {
+	n, err := io.ReadFull(d.src, d.in[d.carry:])
+	n += d.carry
+	last := false
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		last = true
+	default:
+		return err
+	}
+
+	segment := d.in[:n]
+	if !last {
+		segment = d.in[:segmentSize+tagSize]
+	}
+	out, err := d.cipher.open(d.out[:0], segment, last)
+	if err != nil {
+		return err
+	}
+	d.out, d.pos = out, 0
+	if last {
+		d.done = true
+	} else {
+		d.in[0] = d.in[segmentSize+tagSize]
+		d.carry = 1
+	}
+	return nil
+}
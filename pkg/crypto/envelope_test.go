@@ -0,0 +1,114 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeVault wraps keys by XORing them with the key name
+type fakeVault struct{}
+
+func (fakeVault) Init(metadata Metadata) error {
+	return nil
+}
+
+func (fakeVault) WrapKey(ctx context.Context, key []byte, keyName, algorithm string) ([]byte, error) {
+	if keyName == "" {
+		return nil, errors.New("key not found")
+	}
+	wrapped := make([]byte, len(key))
+	for i := range key {
+		wrapped[i] = key[i] ^ keyName[i%len(keyName)]
+	}
+	return wrapped, nil
+}
+
+func (v fakeVault) UnwrapKey(ctx context.Context, wrappedKey []byte, keyName, algorithm string) ([]byte, error) {
+	return v.WrapKey(ctx, wrappedKey, keyName, algorithm)
+}
+
+func encrypt(t *testing.T, plaintext []byte, keyName string) []byte {
+	var buf bytes.Buffer
+	w, err := NewEncryptWriter(context.Background(), fakeVault{}, &buf, keyName, "")
+	assert.NoError(t, err)
+	// write in uneven chunks to cross the segment boundaries
+	for len(plaintext) > 0 {
+		n := 1000
+		if n > len(plaintext) {
+			n = len(plaintext)
+		}
+		_, err = w.Write(plaintext[:n])
+		assert.NoError(t, err)
+		plaintext = plaintext[n:]
+	}
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decrypt(ciphertext []byte, keyName string) ([]byte, error) {
+	r, err := NewDecryptReader(context.Background(), fakeVault{}, bytes.NewReader(ciphertext), keyName)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	for _, size := range []int{0, 1, segmentSize - 1, segmentSize, segmentSize + 1, 3*segmentSize + 17} {
+		plaintext := bytes.Repeat([]byte("0123456789"), size/10+1)[:size]
+		ciphertext := encrypt(t, plaintext, "key1")
+		assert.False(t, bytes.Contains(ciphertext, []byte("0123456789")))
+
+		decrypted, err := decrypt(ciphertext, "")
+		assert.NoError(t, err, "size %d", size)
+		assert.Equal(t, plaintext, decrypted, "size %d", size)
+	}
+}
+
+func TestDecryptInvalidPayloads(t *testing.T) {
+	plaintext := bytes.Repeat([]byte("a"), 2*segmentSize+5)
+	ciphertext := encrypt(t, plaintext, "key1")
+
+	t.Run("not encrypted", func(t *testing.T) {
+		_, err := decrypt(plaintext, "")
+		assert.Equal(t, ErrInvalidPayload, err)
+	})
+
+	t.Run("key name mismatch", func(t *testing.T) {
+		_, err := decrypt(ciphertext, "key2")
+		assert.Equal(t, ErrKeyNameMismatch, err)
+	})
+
+	t.Run("altered segment", func(t *testing.T) {
+		altered := append([]byte(nil), ciphertext...)
+		altered[len(altered)-segmentSize] ^= 1
+		_, err := decrypt(altered, "")
+		assert.Equal(t, ErrInvalidPayload, err)
+	})
+
+	t.Run("truncated at a segment boundary", func(t *testing.T) {
+		_, err := decrypt(ciphertext[:len(ciphertext)-(5+tagSize)], "")
+		assert.Equal(t, ErrInvalidPayload, err)
+	})
+
+	t.Run("altered header", func(t *testing.T) {
+		altered := bytes.Replace(ciphertext, []byte(`"cipher":"`+Cipher+`"`), []byte(`"cipher":"`+Cipher+`" `), 1)
+		_, err := decrypt(altered, "")
+		assert.Equal(t, ErrInvalidPayload, err)
+	})
+}
+
+func TestEncryptWrapError(t *testing.T) {
+	_, err := NewEncryptWriter(context.Background(), fakeVault{}, &bytes.Buffer{}, "", "")
+	assert.Error(t, err)
+}
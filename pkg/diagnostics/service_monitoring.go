@@ -2,6 +2,7 @@ package diagnostics
 
 import (
 	"context"
+	"time"
 
 	diag_utils "github.com/dapr/dapr/pkg/diagnostics/utils"
 	"go.opencensus.io/stats"
@@ -15,6 +16,8 @@ var (
 	failReasonKey = tag.MustNewKey("reason")
 	operationKey  = tag.MustNewKey("operation")
 	actorTypeKey  = tag.MustNewKey("actor_type")
+	topicKey      = tag.MustNewKey("topic")
+	bindingKey    = tag.MustNewKey("binding")
 )
 
 // serviceMetrics holds dapr runtime metric monitoring methods
@@ -23,6 +26,7 @@ type serviceMetrics struct {
 	componentLoaded        *stats.Int64Measure
 	componentInitCompleted *stats.Int64Measure
 	componentInitFailed    *stats.Int64Measure
+	componentInitLatency   *stats.Float64Measure
 
 	// mTLS metrics
 	mtlsInitCompleted             *stats.Int64Measure
@@ -39,6 +43,22 @@ type serviceMetrics struct {
 	actorActivatedFailedTotal    *stats.Int64Measure
 	actorDeactivationTotal       *stats.Int64Measure
 	actorDeactivationFailedTotal *stats.Int64Measure
+	actorActiveCount             *stats.Int64Measure
+	actorPendingCalls            *stats.Int64Measure
+	actorReminderFiringDelay     *stats.Float64Measure
+
+	// Pub/sub metrics
+	pubsubMessageDroppedTotal    *stats.Int64Measure
+	pubsubDeliveryRetriedTotal   *stats.Int64Measure
+	pubsubDeliveryExhaustedTotal *stats.Int64Measure
+	pubsubDeduplicatedTotal      *stats.Int64Measure
+
+	// Bindings metrics
+	bindingEventRetriedTotal   *stats.Int64Measure
+	bindingEventExhaustedTotal *stats.Int64Measure
+
+	// Audit metrics
+	auditEventDroppedTotal *stats.Int64Measure
 
 	appID   string
 	ctx     context.Context
@@ -61,6 +81,10 @@ func newServiceMetrics() *serviceMetrics {
 			"runtime/component/init_fail_total",
 			"The number of component initialization failures.",
 			stats.UnitDimensionless),
+		componentInitLatency: stats.Float64(
+			"runtime/component/init_latencies",
+			"The time taken to initialize the components, including the retries.",
+			stats.UnitMilliseconds),
 
 		// mTLS
 		mtlsInitCompleted: stats.Int64(
@@ -113,6 +137,51 @@ func newServiceMetrics() *serviceMetrics {
 			"runtime/actor/deactivated_failed_total",
 			"The number of the failed actor deactivation.",
 			stats.UnitDimensionless),
+		actorActiveCount: stats.Int64(
+			"runtime/actor/active_actors",
+			"The number of the active actors.",
+			stats.UnitDimensionless),
+		actorPendingCalls: stats.Int64(
+			"runtime/actor/pending_actor_calls",
+			"The number of the actor calls waiting for the turn lock of their actor.",
+			stats.UnitDimensionless),
+		actorReminderFiringDelay: stats.Float64(
+			"runtime/actor/reminder_firing_delay",
+			"The delay between the due time of the actor reminders and their firing.",
+			stats.UnitMilliseconds),
+
+		// Pub/sub
+		pubsubMessageDroppedTotal: stats.Int64(
+			"runtime/pubsub/message_dropped_total",
+			"The number of the pub/sub messages dropped at the request of the app.",
+			stats.UnitDimensionless),
+		pubsubDeliveryRetriedTotal: stats.Int64(
+			"runtime/pubsub/delivery_retried_total",
+			"The number of the retried pub/sub message deliveries to the app.",
+			stats.UnitDimensionless),
+		pubsubDeliveryExhaustedTotal: stats.Int64(
+			"runtime/pubsub/delivery_retries_exhausted_total",
+			"The number of the pub/sub messages which failed to be delivered after all retries.",
+			stats.UnitDimensionless),
+		pubsubDeduplicatedTotal: stats.Int64(
+			"runtime/pubsub/message_deduplicated_total",
+			"The number of the redelivered pub/sub messages acknowledged without invoking the app.",
+			stats.UnitDimensionless),
+		// Bindings
+		bindingEventRetriedTotal: stats.Int64(
+			"runtime/bindings/event_retried_total",
+			"The number of the retried input binding event deliveries to the app.",
+			stats.UnitDimensionless),
+		bindingEventExhaustedTotal: stats.Int64(
+			"runtime/bindings/event_retries_exhausted_total",
+			"The number of the input binding events which failed to be delivered after all retries.",
+			stats.UnitDimensionless),
+
+		// Audit metrics
+		auditEventDroppedTotal: stats.Int64(
+			"runtime/audit/event_dropped_total",
+			"The number of the audit events dropped without being written to the audit sink.",
+			stats.UnitDimensionless),
 
 		// TODO: use the correct context for each request
 		ctx:     context.Background(),
@@ -128,6 +197,7 @@ func (s *serviceMetrics) Init(appID string) error {
 		diag_utils.NewMeasureView(s.componentLoaded, []tag.Key{appIDKey}, view.Count()),
 		diag_utils.NewMeasureView(s.componentInitCompleted, []tag.Key{appIDKey, componentKey}, view.Count()),
 		diag_utils.NewMeasureView(s.componentInitFailed, []tag.Key{appIDKey, componentKey, failReasonKey}, view.Count()),
+		diag_utils.NewMeasureView(s.componentInitLatency, []tag.Key{appIDKey, componentKey}, defaultLatencyDistribution),
 
 		diag_utils.NewMeasureView(s.mtlsInitCompleted, []tag.Key{appIDKey}, view.Count()),
 		diag_utils.NewMeasureView(s.mtlsInitFailed, []tag.Key{appIDKey, failReasonKey}, view.Count()),
@@ -142,6 +212,19 @@ func (s *serviceMetrics) Init(appID string) error {
 		diag_utils.NewMeasureView(s.actorActivatedFailedTotal, []tag.Key{appIDKey, actorTypeKey}, view.Count()),
 		diag_utils.NewMeasureView(s.actorDeactivationTotal, []tag.Key{appIDKey, actorTypeKey}, view.Count()),
 		diag_utils.NewMeasureView(s.actorDeactivationFailedTotal, []tag.Key{appIDKey, actorTypeKey}, view.Count()),
+		diag_utils.NewMeasureView(s.actorActiveCount, []tag.Key{appIDKey, actorTypeKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.actorPendingCalls, []tag.Key{appIDKey, actorTypeKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.actorReminderFiringDelay, []tag.Key{appIDKey, actorTypeKey}, defaultLatencyDistribution),
+
+		diag_utils.NewMeasureView(s.pubsubMessageDroppedTotal, []tag.Key{appIDKey, topicKey}, view.Count()),
+		diag_utils.NewMeasureView(s.pubsubDeliveryRetriedTotal, []tag.Key{appIDKey, topicKey}, view.Count()),
+		diag_utils.NewMeasureView(s.pubsubDeliveryExhaustedTotal, []tag.Key{appIDKey, topicKey}, view.Count()),
+		diag_utils.NewMeasureView(s.pubsubDeduplicatedTotal, []tag.Key{appIDKey, topicKey}, view.Count()),
+
+		diag_utils.NewMeasureView(s.bindingEventRetriedTotal, []tag.Key{appIDKey, bindingKey}, view.Count()),
+		diag_utils.NewMeasureView(s.bindingEventExhaustedTotal, []tag.Key{appIDKey, bindingKey}, view.Count()),
+
+		diag_utils.NewMeasureView(s.auditEventDroppedTotal, []tag.Key{appIDKey, operationKey}, view.Count()),
 	)
 }
 
@@ -172,6 +255,16 @@ func (s *serviceMetrics) ComponentInitFailed(component string, reason string) {
 	}
 }
 
+// ComponentInitDuration records the time taken to initialize a component
+func (s *serviceMetrics) ComponentInitDuration(component string, elapsed time.Duration) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, componentKey, component),
+			s.componentInitLatency.M(float64(elapsed)/float64(time.Millisecond)))
+	}
+}
+
 // MTLSInitCompleted records metric when component is initialized
 func (s *serviceMetrics) MTLSInitCompleted() {
 	if s.enabled {
@@ -280,3 +373,103 @@ func (s *serviceMetrics) ActorDeactivationFailed(actorType, reason string) {
 			s.actorDeactivationFailedTotal.M(1))
 	}
 }
+
+// ActorActiveCount records metric of the number of the active actors of an actor type.
+func (s *serviceMetrics) ActorActiveCount(actorType string, count int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, actorTypeKey, actorType),
+			s.actorActiveCount.M(count))
+	}
+}
+
+// ActorPendingCalls records metric of the number of the calls waiting for the turn lock of the actors of an actor type.
+func (s *serviceMetrics) ActorPendingCalls(actorType string, count int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, actorTypeKey, actorType),
+			s.actorPendingCalls.M(count))
+	}
+}
+
+// ActorReminderFired records metric of the delay of a reminder firing after its due time.
+func (s *serviceMetrics) ActorReminderFired(actorType string, delay time.Duration) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, actorTypeKey, actorType),
+			s.actorReminderFiringDelay.M(float64(delay)/float64(time.Millisecond)))
+	}
+}
+
+// PubsubMessageDropped records metric when the app asks for a pub/sub message to be dropped.
+func (s *serviceMetrics) PubsubMessageDropped(topic string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, topicKey, topic),
+			s.pubsubMessageDroppedTotal.M(1))
+	}
+}
+
+// PubsubDeliveryRetried records metric when the delivery of a pub/sub message to the app is retried.
+func (s *serviceMetrics) PubsubDeliveryRetried(topic string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, topicKey, topic),
+			s.pubsubDeliveryRetriedTotal.M(1))
+	}
+}
+
+// PubsubDeliveryRetriesExhausted records metric when a pub/sub message failed to be delivered after all retries.
+func (s *serviceMetrics) PubsubDeliveryRetriesExhausted(topic string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, topicKey, topic),
+			s.pubsubDeliveryExhaustedTotal.M(1))
+	}
+}
+
+// BindingEventRetried records metric when the delivery of an input binding event to the app is retried.
+func (s *serviceMetrics) BindingEventRetried(binding string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, bindingKey, binding),
+			s.bindingEventRetriedTotal.M(1))
+	}
+}
+
+// BindingEventRetriesExhausted records metric when an input binding event failed to be delivered after all retries.
+func (s *serviceMetrics) BindingEventRetriesExhausted(binding string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, bindingKey, binding),
+			s.bindingEventExhaustedTotal.M(1))
+	}
+}
+
+// PubsubMessageDeduplicated records metric when an already processed pub/sub message is acknowledged without invoking the app.
+func (s *serviceMetrics) PubsubMessageDeduplicated(topic string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, topicKey, topic),
+			s.pubsubDeduplicatedTotal.M(1))
+	}
+}
+
+// AuditEventDropped records metric when an audit event is dropped without being written to the audit sink.
+func (s *serviceMetrics) AuditEventDropped(operation string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, operationKey, operation),
+			s.auditEventDroppedTotal.M(1))
+	}
+}
@@ -0,0 +1,50 @@
+package diagnostics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats/view"
+)
+
+func TestActorMetrics(t *testing.T) {
+	testMetrics := newServiceMetrics()
+	testMetrics.Init("fakeID")
+
+	testMetrics.ActorActiveCount("cat", 3)
+	testMetrics.ActorActiveCount("cat", 2)
+	testMetrics.ActorPendingCalls("cat", 1)
+	testMetrics.ActorReminderFired("cat", 150*time.Millisecond)
+
+	rows, err := view.RetrieveData("runtime/actor/active_actors")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, "actor_type", rows[0].Tags[0].Key.Name())
+	assert.Equal(t, "cat", rows[0].Tags[0].Value)
+	assert.Equal(t, 2.0, (rows[0].Data).(*view.LastValueData).Value)
+
+	rows, err = view.RetrieveData("runtime/actor/pending_actor_calls")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, 1.0, (rows[0].Data).(*view.LastValueData).Value)
+
+	rows, err = view.RetrieveData("runtime/actor/reminder_firing_delay")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, 150.0, (rows[0].Data).(*view.DistributionData).Min)
+}
+
+func TestComponentMetrics(t *testing.T) {
+	testMetrics := newServiceMetrics()
+	testMetrics.Init("fakeID")
+
+	testMetrics.ComponentInitDuration("state.redis", 40*time.Millisecond)
+	testMetrics.ComponentInitDuration("state.redis", 20*time.Millisecond)
+
+	rows, err := view.RetrieveData("runtime/component/init_latencies")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, int64(2), (rows[0].Data).(*view.DistributionData).Count)
+	assert.Equal(t, 20.0, (rows[0].Data).(*view.DistributionData).Min)
+}
@@ -43,7 +43,7 @@ func startTracingSpanInternal(ctx context.Context, uri, samplingRate string, spa
 	var span *trace.Span
 	name := createSpanName(uri)
 
-	rate := diag_utils.GetTraceSamplingRate(samplingRate)
+	rate := getSamplingRate(samplingRate)
 
 	// TODO : Continue using ProbabilitySampler till Go SDK starts supporting RateLimiting sampler
 	probSamplerOption := trace.WithSampler(trace.ProbabilitySampler(rate))
@@ -70,7 +70,7 @@ func GetDefaultSpanContext(spec config.TracingSpec) trace.SpanContext {
 	// Only generating TraceID. SpanID is not generated as there is no span started in the middleware.
 	spanContext.TraceID = gen.NewTraceID()
 
-	rate := diag_utils.GetTraceSamplingRate(spec.SamplingRate)
+	rate := getSamplingRate(spec.SamplingRate)
 
 	// TODO : Continue using ProbabilitySampler till Go SDK starts supporting RateLimiting sampler
 	sampler := trace.ProbabilitySampler(rate)
@@ -151,6 +151,24 @@ func UpdateSpanPairStatusesFromError(span *trace.Span, err error, method string)
 
 var tracingConfig atomic.Value // access atomically
 
+// samplingRateOverride holds the sampling rate of the configuration reloaded at runtime, which takes precedence
+// over the sampling rate of the tracing spec the servers and channels were created with
+var samplingRateOverride atomic.Value // *string, access atomically
+
+// SetSamplingRate sets the sampling rate of the spans started from now on, so a change of the tracing spec of the
+// configuration applies without restarting the servers and channels
+func SetSamplingRate(rate string) {
+	samplingRateOverride.Store(&rate)
+}
+
+// getSamplingRate returns the sampling rate set at runtime if any, or the given sampling rate of the tracing spec
+func getSamplingRate(rate string) float64 {
+	if override, ok := samplingRateOverride.Load().(*string); ok && override != nil {
+		rate = *override
+	}
+	return diag_utils.GetTraceSamplingRate(rate)
+}
+
 func init() {
 	gen := &traceIDGenerator{}
 	// initialize traceID and spanID generators.
@@ -0,0 +1,28 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSamplingRate(t *testing.T) {
+	defer samplingRateOverride.Store((*string)(nil))
+
+	spec := config.TracingSpec{SamplingRate: "0"}
+	assert.Equal(t, 0.0, getSamplingRate(spec.SamplingRate))
+	assert.False(t, GetDefaultSpanContext(spec).IsSampled())
+
+	SetSamplingRate("1")
+	assert.Equal(t, 1.0, getSamplingRate(spec.SamplingRate))
+	assert.True(t, GetDefaultSpanContext(spec).IsSampled())
+
+	SetSamplingRate("0")
+	assert.False(t, GetDefaultSpanContext(config.TracingSpec{SamplingRate: "1"}).IsSampled())
+}
@@ -8,11 +8,14 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/dapr/components-contrib/servicediscovery"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/grandcat/zeroconf"
 )
@@ -38,14 +41,60 @@ func RegisterMDNS(id string, port int) error {
 	return nil
 }
 
+// mdnsResolver is a dual-stack mDNS name resolver
+type mdnsResolver struct{}
+
+// NewMDNSResolver returns a new mDNS resolver which supports both IPv4 and IPv6 (AAAA) records
+func NewMDNSResolver() servicediscovery.Resolver {
+	return &mdnsResolver{}
+}
+
+// ResolveID resolves the address of the given app id on the local network
+func (m *mdnsResolver) ResolveID(req servicediscovery.ResolveRequest) (string, error) {
+	return LookupAddressMDNS(req.ID)
+}
+
 // LookupPortMDNS uses mdns to find the port of a given service entry on a local network
 func LookupPortMDNS(id string) (int, error) {
+	entry, err := lookupServiceEntryMDNS(id)
+	if err != nil {
+		return -1, err
+	}
+	return entry.Port, nil
+}
+
+// LookupAddressMDNS uses mdns to find the host:port address of a given service entry on a local network
+func LookupAddressMDNS(id string) (string, error) {
+	entry, err := lookupServiceEntryMDNS(id)
+	if err != nil {
+		return "", err
+	}
+	return addressFromServiceEntry(entry), nil
+}
+
+// addressFromServiceEntry formats the address of a service entry, preferring IPv4 addresses over
+// IPv6 addresses. IPv6 link-local addresses are skipped since they cannot be dialed without a zone.
+func addressFromServiceEntry(entry *zeroconf.ServiceEntry) string {
+	port := strconv.Itoa(entry.Port)
+	if len(entry.AddrIPv4) > 0 {
+		return net.JoinHostPort(entry.AddrIPv4[0].String(), port)
+	}
+	for _, ip := range entry.AddrIPv6 {
+		if ip.IsLinkLocalUnicast() {
+			continue
+		}
+		return net.JoinHostPort(ip.String(), port)
+	}
+	return net.JoinHostPort("localhost", port)
+}
+
+func lookupServiceEntryMDNS(id string) (*zeroconf.ServiceEntry, error) {
 	resolver, err := zeroconf.NewResolver(nil)
 	if err != nil {
-		log.Fatalf("Failed to initialize resolver: %v", err.Error())
+		return nil, fmt.Errorf("failed to initialize resolver: %s", err)
 	}
 
-	port := -1
+	var found *zeroconf.ServiceEntry
 	entries := make(chan *zeroconf.ServiceEntry)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
@@ -54,7 +103,7 @@ func LookupPortMDNS(id string) (int, error) {
 		for entry := range results {
 			for _, text := range entry.Text {
 				if text == id {
-					port = entry.Port
+					found = entry
 					cancel()
 					return
 				}
@@ -64,12 +113,12 @@ func LookupPortMDNS(id string) (int, error) {
 
 	err = resolver.Browse(ctx, id, "local.", entries)
 	if err != nil {
-		return -1, fmt.Errorf("failed to browse: %s", err.Error())
+		return nil, fmt.Errorf("failed to browse: %s", err.Error())
 	}
 
 	<-ctx.Done()
-	if port == -1 {
-		return port, fmt.Errorf("couldn't find service: %s", id)
+	if found == nil {
+		return nil, fmt.Errorf("couldn't find service: %s", id)
 	}
-	return port, nil
+	return found, nil
 }
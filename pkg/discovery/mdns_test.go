@@ -0,0 +1,38 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package discovery
+
+import (
+	"net"
+	"testing"
+
+	"github.com/grandcat/zeroconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressFromServiceEntry(t *testing.T) {
+	t.Run("prefers ipv4", func(t *testing.T) {
+		entry := &zeroconf.ServiceEntry{
+			Port:     50002,
+			AddrIPv4: []net.IP{net.ParseIP("10.0.0.1")},
+			AddrIPv6: []net.IP{net.ParseIP("2001:db8::1")},
+		}
+		assert.Equal(t, "10.0.0.1:50002", addressFromServiceEntry(entry))
+	})
+
+	t.Run("ipv6 only", func(t *testing.T) {
+		entry := &zeroconf.ServiceEntry{
+			Port:     50002,
+			AddrIPv6: []net.IP{net.ParseIP("fe80::1"), net.ParseIP("2001:db8::1")},
+		}
+		assert.Equal(t, "[2001:db8::1]:50002", addressFromServiceEntry(entry))
+	})
+
+	t.Run("no addresses", func(t *testing.T) {
+		entry := &zeroconf.ServiceEntry{Port: 50002}
+		assert.Equal(t, "localhost:50002", addressFromServiceEntry(entry))
+	})
+}
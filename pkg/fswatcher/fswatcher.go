@@ -3,13 +3,26 @@ package fswatcher
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 )
 
+// Watch sends an event when a file of the directory is created or written
 func Watch(ctx context.Context, dir string, eventCh chan<- struct{}) error {
+	return watch(ctx, dir, fsnotify.Create|fsnotify.Write, eventCh)
+}
+
+// WatchChanges sends an event when a file of the directory is created, written, removed or renamed
+func WatchChanges(ctx context.Context, dir string, eventCh chan<- struct{}) error {
+	return watch(ctx, dir, fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename, eventCh)
+}
+
+func watch(ctx context.Context, dir string, ops fsnotify.Op, eventCh chan<- struct{}) error {
+	// the names of the events start with the cleaned path of the directory
+	dir = filepath.Clean(dir)
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return fmt.Errorf("failed to create watcher: %s", err)
@@ -25,7 +38,7 @@ LOOP:
 		select {
 		// watch for events
 		case event := <-watcher.Events:
-			if event.Op == fsnotify.Create || event.Op == fsnotify.Write {
+			if event.Op&ops != 0 {
 				if strings.Contains(event.Name, dir) {
 					// give time for other updates to occur
 					time.Sleep(time.Second * 1)
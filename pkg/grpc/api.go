@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
@@ -16,20 +17,32 @@ import (
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/actors"
+	"github.com/dapr/dapr/pkg/audit"
 	"github.com/dapr/dapr/pkg/channel"
+	"github.com/dapr/dapr/pkg/components"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/jobs"
 	"github.com/dapr/dapr/pkg/messaging"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	daprclientv1pb "github.com/dapr/dapr/pkg/proto/daprclient/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	runtime_metadata "github.com/dapr/dapr/pkg/runtime/metadata"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	auth "github.com/dapr/dapr/pkg/runtime/security"
+	"github.com/dapr/dapr/pkg/state/consistency"
+	"github.com/dapr/dapr/pkg/state/etag"
+	"github.com/dapr/dapr/pkg/workflows"
 	"github.com/golang/protobuf/ptypes/any"
 	durpb "github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
-	jsoniter "github.com/json-iterator/go"
 	"go.opencensus.io/trace"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -42,6 +55,11 @@ const (
 	maxSeconds    = int64(10000 * 365.25 * 24 * 60 * 60)
 	minSeconds    = -maxSeconds
 	daprSeparator = "||"
+	// contentTypeMetadataKey is the publish metadata key holding the content type of the event data
+	contentTypeMetadataKey = "contenttype"
+	// errorInfoDomain and etagMismatchErrorType identify the error info of ETag mismatches
+	errorInfoDomain       = "dapr.io"
+	etagMismatchErrorType = "ETAG_MISMATCH"
 )
 
 // API is the gRPC interface for the Dapr gRPC API. It implements both the internal and external proto definitions.
@@ -50,6 +68,9 @@ type API interface {
 	CallActor(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error)
 	CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error)
 
+	// DaprActorStreaming Service methods
+	CallActorStream(in *internalv1pb.InternalInvokeRequest, stream internalv1pb.DaprActorStreaming_CallActorStreamServer) error
+
 	// Dapr Service methods
 	PublishEvent(ctx context.Context, in *daprv1pb.PublishEventEnvelope) (*empty.Empty, error)
 	InvokeService(ctx context.Context, in *daprv1pb.InvokeServiceRequest) (*commonv1pb.InvokeResponse, error)
@@ -58,40 +79,131 @@ type API interface {
 	GetSecret(ctx context.Context, in *daprv1pb.GetSecretEnvelope) (*daprv1pb.GetSecretResponseEnvelope, error)
 	SaveState(ctx context.Context, in *daprv1pb.SaveStateEnvelope) (*empty.Empty, error)
 	DeleteState(ctx context.Context, in *daprv1pb.DeleteStateEnvelope) (*empty.Empty, error)
+
+	// DaprPubSub Service methods
+	PublishBulkEvent(ctx context.Context, in *daprv1pb.BulkPublishRequestEnvelope) (*daprv1pb.BulkPublishResponseEnvelope, error)
+
+	// DaprStreaming Service methods
+	SubscribeTopicEvents(in *daprclientv1pb.TopicSubscriptionEnvelope, stream daprv1pb.DaprStreaming_SubscribeTopicEventsServer) error
+
+	// DaprState Service methods
+	ExecuteStateTransaction(ctx context.Context, in *daprv1pb.ExecuteStateTransactionEnvelope) (*empty.Empty, error)
+	QueryState(ctx context.Context, in *daprv1pb.QueryStateEnvelope) (*daprv1pb.QueryStateResponseEnvelope, error)
+	GetBulkState(ctx context.Context, in *daprv1pb.GetBulkStateEnvelope) (*daprv1pb.GetBulkStateResponseEnvelope, error)
+	SubscribeState(in *daprv1pb.SubscribeStateEnvelope, stream daprv1pb.DaprState_SubscribeStateServer) error
+	DeleteBulkState(ctx context.Context, in *daprv1pb.DeleteBulkStateEnvelope) (*empty.Empty, error)
+	DeleteStateWithPrefix(ctx context.Context, in *daprv1pb.DeleteStateWithPrefixEnvelope) (*daprv1pb.DeleteStateWithPrefixResponseEnvelope, error)
+
+	// DaprBindings Service methods
+	InvokeBindingWithResponse(ctx context.Context, in *daprv1pb.InvokeBindingEnvelope) (*daprv1pb.InvokeBindingResponseEnvelope, error)
+
+	// DaprJobs Service methods
+	ScheduleJob(ctx context.Context, in *daprv1pb.JobEnvelope) (*empty.Empty, error)
+	DeleteJob(ctx context.Context, in *daprv1pb.DeleteJobEnvelope) (*empty.Empty, error)
+	ListJobs(ctx context.Context, in *empty.Empty) (*daprv1pb.ListJobsResponseEnvelope, error)
+
+	// DaprSecrets Service methods
+	GetBulkSecret(ctx context.Context, in *daprv1pb.GetBulkSecretEnvelope) (*daprv1pb.GetBulkSecretResponseEnvelope, error)
+
+	// DaprConfiguration Service methods
+	GetConfiguration(ctx context.Context, in *daprv1pb.GetConfigurationEnvelope) (*daprv1pb.GetConfigurationResponseEnvelope, error)
+	SubscribeConfiguration(in *daprv1pb.SubscribeConfigurationEnvelope, stream daprv1pb.DaprConfiguration_SubscribeConfigurationServer) error
+
+	// DaprCrypto Service methods
+	Encrypt(stream daprv1pb.DaprCrypto_EncryptServer) error
+	Decrypt(stream daprv1pb.DaprCrypto_DecryptServer) error
+
+	// DaprWorkflows Service methods
+	StartWorkflow(ctx context.Context, in *daprv1pb.StartWorkflowEnvelope) (*daprv1pb.WorkflowInstanceEnvelope, error)
+	GetWorkflow(ctx context.Context, in *daprv1pb.WorkflowInstanceEnvelope) (*daprv1pb.GetWorkflowResponseEnvelope, error)
+	TerminateWorkflow(ctx context.Context, in *daprv1pb.WorkflowInstanceEnvelope) (*empty.Empty, error)
+	RaiseEventWorkflow(ctx context.Context, in *daprv1pb.RaiseEventWorkflowEnvelope) (*empty.Empty, error)
+
+	// DaprShutdown Service methods
+	Shutdown(ctx context.Context, in *empty.Empty) (*empty.Empty, error)
+
+	// DaprMetadata Service methods
+	GetMetadata(ctx context.Context, in *empty.Empty) (*daprv1pb.GetMetadataResponse, error)
+	SetMetadata(ctx context.Context, in *daprv1pb.SetMetadataRequest) (*empty.Empty, error)
 }
 
 type api struct {
 	actor                 actors.Actors
 	directMessaging       messaging.DirectMessaging
 	appChannel            channel.AppChannel
-	stateStores           map[string]state.Store
-	secretStores          map[string]secretstores.SecretStore
-	publishFn             func(req *pubsub.PublishRequest) error
+	compStore             *compstore.ComponentStore
+	secretScopesFn        func() map[string]config.SecretsScope
+	publishFn             func(pubsubName string, req *pubsub.PublishRequest) error
+	bulkPublishFn         func(req *runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error)
+	subscribeStreamFn     func(topic string, handler func(msg *pubsub.NewMessage) error) (func(), error)
 	id                    string
-	sendToOutputBindingFn func(name string, req *bindings.WriteRequest) error
+	sendToOutputBindingFn func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error)
+	jobs                  jobs.Scheduler
+	workflows             workflows.Engine
+	shutdownFn            func()
+	componentStatusesFn   func() []components.Status
+	subscriptionManager   runtime_pubsub.SubscriptionManager
+	app                   runtime_metadata.App
+	attributes            *runtime_metadata.Attributes
+	namespace             string
+	accessControl         config.AccessControlSpec
+	auditLog              *audit.Logger
 	tracingSpec           config.TracingSpec
+	metadataLimits        invokev1.MetadataLimits
+}
+
+// APIOpts are the dependencies and the settings of the Dapr gRPC API
+type APIOpts struct {
+	AppID                 string
+	AppChannel            channel.AppChannel
+	CompStore             *compstore.ComponentStore
+	SecretScopesFn        func() map[string]config.SecretsScope
+	PublishFn             func(pubsubName string, req *pubsub.PublishRequest) error
+	BulkPublishFn         func(*runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error)
+	SubscribeStreamFn     func(topic string, handler func(msg *pubsub.NewMessage) error) (func(), error)
+	DirectMessaging       messaging.DirectMessaging
+	Actor                 actors.Actors
+	SendToOutputBindingFn func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error)
+	JobScheduler          jobs.Scheduler
+	WorkflowEngine        workflows.Engine
+	ShutdownFn            func()
+	ComponentStatusesFn   func() []components.Status
+	SubscriptionManager   runtime_pubsub.SubscriptionManager
+	App                   runtime_metadata.App
+	Attributes            *runtime_metadata.Attributes
+	Namespace             string
+	AccessControl         config.AccessControlSpec
+	AuditLog              *audit.Logger
+	TracingSpec           config.TracingSpec
+	// MetadataLimits are the limits of the headers of the responses of the app to the invocations of other apps
+	MetadataLimits invokev1.MetadataLimits
 }
 
 // NewAPI returns a new gRPC API
-func NewAPI(
-	appID string, appChannel channel.AppChannel,
-	stateStores map[string]state.Store,
-	secretStores map[string]secretstores.SecretStore,
-	publishFn func(req *pubsub.PublishRequest) error,
-	directMessaging messaging.DirectMessaging,
-	actor actors.Actors,
-	sendToOutputBindingFn func(name string, req *bindings.WriteRequest) error,
-	tracingSpec config.TracingSpec) API {
+func NewAPI(opts APIOpts) API {
 	return &api{
-		directMessaging:       directMessaging,
-		actor:                 actor,
-		id:                    appID,
-		appChannel:            appChannel,
-		publishFn:             publishFn,
-		stateStores:           stateStores,
-		secretStores:          secretStores,
-		sendToOutputBindingFn: sendToOutputBindingFn,
-		tracingSpec:           tracingSpec,
+		directMessaging:       opts.DirectMessaging,
+		actor:                 opts.Actor,
+		id:                    opts.AppID,
+		appChannel:            opts.AppChannel,
+		publishFn:             opts.PublishFn,
+		bulkPublishFn:         opts.BulkPublishFn,
+		subscribeStreamFn:     opts.SubscribeStreamFn,
+		compStore:             opts.CompStore,
+		secretScopesFn:        opts.SecretScopesFn,
+		sendToOutputBindingFn: opts.SendToOutputBindingFn,
+		jobs:                  opts.JobScheduler,
+		workflows:             opts.WorkflowEngine,
+		shutdownFn:            opts.ShutdownFn,
+		componentStatusesFn:   opts.ComponentStatusesFn,
+		subscriptionManager:   opts.SubscriptionManager,
+		app:                   opts.App,
+		attributes:            opts.Attributes,
+		namespace:             opts.Namespace,
+		accessControl:         opts.AccessControl,
+		auditLog:              opts.AuditLog,
+		tracingSpec:           opts.TracingSpec,
+		metadataLimits:        opts.MetadataLimits,
 	}
 }
 
@@ -101,10 +213,24 @@ func (a *api) CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequ
 		return nil, status.Error(codes.Internal, "app channel is not initialized")
 	}
 
+	if err := a.checkCaller(ctx); err != nil {
+		return nil, err
+	}
+
 	req, err := invokev1.InternalInvokeRequest(in)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "parsing InternalInvokeRequest error: %s", err.Error())
 	}
+	if err := a.checkInvocationACL(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// Caller identity headers are only set from the verified mTLS certificate of the caller
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		req.WithCallerIdentity(identity.AppID, identity.Namespace, identity.TrustDomain)
+	} else {
+		req.WithCallerIdentity("", "", "")
+	}
 
 	ctx, span := diag.StartTracingServerSpanFromGRPCContext(ctx, req.Message().Method, a.tracingSpec)
 	defer span.End()
@@ -115,11 +241,60 @@ func (a *api) CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequ
 	if err != nil {
 		return nil, err
 	}
+	if removed := a.metadataLimits.Trim(resp.Headers()); len(removed) > 0 {
+		apiServerLogger.Warnf("removed the headers %v exceeding the metadata limits from the response of method %s", removed, req.Message().Method)
+	}
 	return resp.Proto(), err
 }
 
+// checkCaller denies the calls from the apps of other namespaces and trust domains which aren't allowed when
+// namespaces are isolated. The callers without a verified identity are denied, as their namespace is unknown.
+func (a *api) checkCaller(ctx context.Context) error {
+	if !a.accessControl.NamespaceIsolation {
+		return nil
+	}
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "the identity of the caller is not verified")
+	}
+	if !a.accessControl.IsCallerAllowed(a.namespace, identity.Namespace, identity.TrustDomain) {
+		return status.Errorf(codes.PermissionDenied, "app %s of namespace %s and trust domain %s is not allowed to call namespace %s", identity.AppID, identity.Namespace, identity.TrustDomain, a.namespace)
+	}
+	return nil
+}
+
+// checkInvocationACL denies the invocations the access control policies don't allow to the SPIFFE ID of the caller,
+// on the HTTP verb and the method of the request. The callers without a verified identity are denied when the
+// policies are enabled.
+func (a *api) checkInvocationACL(ctx context.Context, req *invokev1.InvokeMethodRequest) error {
+	if !a.accessControl.IsInvocationACLEnabled() {
+		return nil
+	}
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "the identity of the caller is not verified")
+	}
+	var httpVerb string
+	if ext := req.Message().GetHttpExtension(); ext != nil {
+		httpVerb = ext.GetVerb().String()
+	}
+	method := req.Message().GetMethod()
+	if !a.accessControl.IsInvocationAllowed(identity.AppID, identity.Namespace, identity.TrustDomain, httpVerb, method) {
+		operation := method
+		if httpVerb != "" {
+			operation = fmt.Sprintf("%s %s", httpVerb, method)
+		}
+		return status.Errorf(codes.PermissionDenied, "caller %s is not allowed to invoke %s on the app", identity.SPIFFEID(), operation)
+	}
+	return nil
+}
+
 // CallActor invokes a virtual actor
 func (a *api) CallActor(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error) {
+	if err := a.checkCaller(ctx); err != nil {
+		return nil, err
+	}
+
 	req, err := invokev1.InternalInvokeRequest(in)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "parsing InternalInvokeRequest error: %s", err.Error())
@@ -136,6 +311,29 @@ func (a *api) CallActor(ctx context.Context, in *internalv1pb.InternalInvokeRequ
 	return resp.Proto(), nil
 }
 
+// CallActorStream invokes a method of a local actor and streams its response to the calling Dapr runtime
+func (a *api) CallActorStream(in *internalv1pb.InternalInvokeRequest, stream internalv1pb.DaprActorStreaming_CallActorStreamServer) error {
+	if err := a.checkCaller(stream.Context()); err != nil {
+		return err
+	}
+
+	req, err := invokev1.InternalInvokeRequest(in)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "parsing InternalInvokeRequest error: %s", err.Error())
+	}
+
+	ctx, span := diag.StartTracingServerSpanFromGRPCContext(stream.Context(), req.Message().Method, a.tracingSpec)
+	defer span.End()
+	ctx = diag.NewContext(ctx, span.SpanContext())
+
+	resp, body, err := a.actor.CallStream(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return actors.SendResponseStream(stream, resp, body)
+}
+
 func (a *api) PublishEvent(ctx context.Context, in *daprv1pb.PublishEventEnvelope) (*empty.Empty, error) {
 	if a.publishFn == nil {
 		return &empty.Empty{}, errors.New("ERR_PUBSUB_NOT_FOUND")
@@ -152,10 +350,14 @@ func (a *api) PublishEvent(ctx context.Context, in *daprv1pb.PublishEventEnvelop
 	sc := diag.FromContext(ctx)
 	corID := sc.TraceID.String()
 
-	envelope := pubsub.NewCloudEventsEnvelope(uuid.New().String(), a.id, pubsub.DefaultCloudEventType, corID, body)
-	b, err := jsoniter.ConfigFastest.Marshal(envelope)
-	if err != nil {
-		return &empty.Empty{}, fmt.Errorf("ERR_PUBSUB_CLOUD_EVENTS_SER: %s", err)
+	b := body
+	md := getMetadataFromContext(ctx)
+	if !runtime_pubsub.IsRawPayload(md) {
+		var err error
+		b, err = runtime_pubsub.NewCloudEvent(uuid.New().String(), a.id, corID, md[contentTypeMetadataKey], body, md)
+		if err != nil {
+			return &empty.Empty{}, fmt.Errorf("ERR_PUBSUB_CLOUD_EVENTS_SER: %s", err)
+		}
 	}
 
 	req := pubsub.PublishRequest{
@@ -168,13 +370,54 @@ func (a *api) PublishEvent(ctx context.Context, in *daprv1pb.PublishEventEnvelop
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
 	defer span.End()
 
-	err = a.publishFn(&req)
+	err := a.publishFn(runtime_pubsub.GetPubSubName(md), &req)
+	if errors.As(err, &runtime_pubsub.NotAllowedError{}) {
+		return &empty.Empty{}, status.Errorf(codes.PermissionDenied, "ERR_PUBSUB_FORBIDDEN: %s", err)
+	}
+	if errors.As(err, &runtime_pubsub.NotFoundError{}) {
+		return &empty.Empty{}, status.Errorf(codes.InvalidArgument, "ERR_PUBSUB_NOT_FOUND: %s", err)
+	}
+	var schemaErr runtime_pubsub.SchemaValidationError
+	if errors.As(err, &schemaErr) {
+		return &empty.Empty{}, schemaValidationStatus(schemaErr)
+	}
+	if errors.Is(err, runtime_pubsub.ErrDelayedDeliveryNotConfigured) {
+		return &empty.Empty{}, status.Errorf(codes.FailedPrecondition, "ERR_PUBSUB_DELAY_NOT_CONFIGURED: %s", err)
+	}
 	if err != nil {
 		return &empty.Empty{}, fmt.Errorf("ERR_PUBSUB_PUBLISH_MESSAGE: %s", err)
 	}
 	return &empty.Empty{}, nil
 }
 
+// schemaValidationStatus converts a schema validation error to an InvalidArgument status
+// listing each schema violation of the event as a field violation
+func schemaValidationStatus(err runtime_pubsub.SchemaValidationError) error {
+	st := status.Newf(codes.InvalidArgument, "ERR_PUBSUB_EVENT_SCHEMA: event does not match the schema of topic %s", err.Topic)
+	violations := make([]*epb.BadRequest_FieldViolation, 0, len(err.Errors))
+	for _, e := range err.Errors {
+		violations = append(violations, &epb.BadRequest_FieldViolation{Field: "data", Description: e})
+	}
+	if withDetails, detailsErr := st.WithDetails(&epb.BadRequest{FieldViolations: violations}); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// getMetadataFromContext returns the metadata passed as "metadata." prefixed gRPC request headers
+func getMetadataFromContext(ctx context.Context) map[string]string {
+	const metadataPrefix string = "metadata."
+	md := map[string]string{}
+	if incomingMD, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, v := range incomingMD {
+			if strings.HasPrefix(k, metadataPrefix) && len(v) > 0 {
+				md[strings.TrimPrefix(k, metadataPrefix)] = v[0]
+			}
+		}
+	}
+	return md
+}
+
 func (a *api) InvokeService(ctx context.Context, in *daprv1pb.InvokeServiceRequest) (*commonv1pb.InvokeResponse, error) {
 	req := invokev1.FromInvokeRequestMessage(in.GetMessage())
 
@@ -206,6 +449,15 @@ func (a *api) InvokeService(ctx context.Context, in *daprv1pb.InvokeServiceReque
 }
 
 func (a *api) InvokeBinding(ctx context.Context, in *daprv1pb.InvokeBindingEnvelope) (*empty.Empty, error) {
+	if _, err := a.invokeBinding(ctx, in); err != nil {
+		return &empty.Empty{}, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// invokeBinding invokes an output binding and returns the response of the binding, which is nil
+// if the binding doesn't return the response of its operations
+func (a *api) invokeBinding(ctx context.Context, in *daprv1pb.InvokeBindingEnvelope) (*bindings_loader.InvokeResponse, error) {
 	req := &bindings.WriteRequest{
 		Metadata: in.Metadata,
 	}
@@ -217,28 +469,35 @@ func (a *api) InvokeBinding(ctx context.Context, in *daprv1pb.InvokeBindingEnvel
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, "InvokeBinding", a.tracingSpec)
 	defer span.End()
 
-	err := a.sendToOutputBindingFn(in.Name, req)
+	resp, err := a.sendToOutputBindingFn(in.Name, req)
 	if err != nil {
-		return &empty.Empty{}, fmt.Errorf("ERR_INVOKE_OUTPUT_BINDING: %s", err)
+		return nil, fmt.Errorf("ERR_INVOKE_OUTPUT_BINDING: %s", err)
 	}
-	return &empty.Empty{}, nil
+	return resp, nil
 }
 
 func (a *api) GetState(ctx context.Context, in *daprv1pb.GetStateEnvelope) (*daprv1pb.GetStateResponseEnvelope, error) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if a.compStore.StateStoresLen() == 0 {
 		return nil, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
 	}
 
 	storeName := in.StoreName
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
 		return nil, errors.New("ERR_STATE_STORE_NOT_FOUND")
 	}
 
+	metadata := getMetadataFromContext(ctx)
+	consistency, err := a.getStateConsistency(storeName, in.Consistency, metadata)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: %s", err)
+	}
 	req := state.GetRequest{
-		Key: a.getModifiedStateKey(in.Key),
+		Key:      a.getModifiedStateKey(in.StoreName, in.Key),
+		Metadata: metadata,
 		Options: state.GetStateOption{
-			Consistency: in.Consistency,
+			Consistency: consistency,
 		},
 	}
 
@@ -247,7 +506,7 @@ func (a *api) GetState(ctx context.Context, in *daprv1pb.GetStateEnvelope) (*dap
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
 	defer span.End()
 
-	getResponse, err := a.stateStores[storeName].Get(&req)
+	getResponse, err := store.Get(&req)
 	if err != nil {
 		return nil, fmt.Errorf("ERR_STATE_GET: %s", err)
 	}
@@ -261,42 +520,25 @@ func (a *api) GetState(ctx context.Context, in *daprv1pb.GetStateEnvelope) (*dap
 }
 
 func (a *api) SaveState(ctx context.Context, in *daprv1pb.SaveStateEnvelope) (*empty.Empty, error) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if a.compStore.StateStoresLen() == 0 {
 		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
 	}
 
 	storeName := in.StoreName
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
 		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_FOUND")
 	}
 
 	reqs := []state.SetRequest{}
 	for _, s := range in.Requests {
-		req := state.SetRequest{
-			Key:      a.getModifiedStateKey(s.Key),
-			Metadata: s.Metadata,
-			Value:    s.Value.Value,
-			ETag:     s.Etag,
-		}
-		if s.Options != nil {
-			req.Options = state.SetStateOption{
-				Consistency: s.Options.Consistency,
-				Concurrency: s.Options.Concurrency,
-			}
-			if s.Options.RetryPolicy != nil {
-				req.Options.RetryPolicy = state.RetryPolicy{
-					Threshold: int(s.Options.RetryPolicy.Threshold),
-					Pattern:   s.Options.RetryPolicy.Pattern,
-				}
-				if s.Options.RetryPolicy.Interval != nil {
-					dur, err := duration(s.Options.RetryPolicy.Interval)
-					if err == nil {
-						req.Options.RetryPolicy.Interval = dur
-					}
-				}
-			}
+		req := a.getSetRequest(storeName, s)
+		consistency, err := a.getStateConsistency(storeName, req.Options.Consistency, req.Metadata)
+		if err != nil {
+			return &empty.Empty{}, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: key %s: %s", s.Key, err)
 		}
+		req.Options.Consistency = consistency
 		reqs = append(reqs, req)
 	}
 
@@ -305,27 +547,81 @@ func (a *api) SaveState(ctx context.Context, in *daprv1pb.SaveStateEnvelope) (*e
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
 	defer span.End()
 
-	err := a.stateStores[storeName].BulkSet(reqs)
+	err := store.BulkSet(reqs)
+	err = etag.CheckSet(store, err, reqs)
+	if st := a.etagMismatchStatus(storeName, err); st != nil {
+		return &empty.Empty{}, st
+	}
 	if err != nil {
 		return &empty.Empty{}, fmt.Errorf("ERR_STATE_SAVE: %s", err)
 	}
 	return &empty.Empty{}, nil
 }
 
-func (a *api) DeleteState(ctx context.Context, in *daprv1pb.DeleteStateEnvelope) (*empty.Empty, error) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+// getSetRequest converts a state request of the Dapr API to a state store request
+func (a *api) getSetRequest(storeName string, s *daprv1pb.StateRequest) state.SetRequest {
+	req := state.SetRequest{
+		Key:      a.getModifiedStateKey(storeName, s.Key),
+		Metadata: s.Metadata,
+		ETag:     s.Etag,
+	}
+	if s.Value != nil {
+		req.Value = s.Value.Value
+	}
+	if s.Options != nil {
+		req.Options = state.SetStateOption{
+			Consistency: s.Options.Consistency,
+			Concurrency: s.Options.Concurrency,
+		}
+		if s.Options.RetryPolicy != nil {
+			req.Options.RetryPolicy = state.RetryPolicy{
+				Threshold: int(s.Options.RetryPolicy.Threshold),
+				Pattern:   s.Options.RetryPolicy.Pattern,
+			}
+			if s.Options.RetryPolicy.Interval != nil {
+				dur, err := duration(s.Options.RetryPolicy.Interval)
+				if err == nil {
+					req.Options.RetryPolicy.Interval = dur
+				}
+			}
+		}
+	}
+	return req
+}
+
+// getDeleteRequest converts the key, etag, options and metadata of a state request of the Dapr API
+// to a state store delete request
+func (a *api) getDeleteRequest(storeName string, s *daprv1pb.StateRequest) state.DeleteRequest {
+	setReq := a.getSetRequest(storeName, s)
+	return state.DeleteRequest{
+		Key:      setReq.Key,
+		ETag:     setReq.ETag,
+		Metadata: setReq.Metadata,
+		Options: state.DeleteStateOption{
+			Concurrency: setReq.Options.Concurrency,
+			Consistency: setReq.Options.Consistency,
+			RetryPolicy: setReq.Options.RetryPolicy,
+		},
+	}
+}
+
+func (a *api) DeleteState(ctx context.Context, in *daprv1pb.DeleteStateEnvelope) (_ *empty.Empty, err error) {
+	if a.compStore.StateStoresLen() == 0 {
 		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_CONFIGURED")
 	}
 
 	storeName := in.StoreName
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
 		return &empty.Empty{}, errors.New("ERR_STATE_STORE_NOT_FOUND")
 	}
+	defer func() { a.recordAudit(ctx, audit.DeleteState, storeName, []string{in.Key}, err) }()
 
 	req := state.DeleteRequest{
-		Key:  a.getModifiedStateKey(in.Key),
-		ETag: in.Etag,
+		Key:      a.getModifiedStateKey(in.StoreName, in.Key),
+		ETag:     in.Etag,
+		Metadata: getMetadataFromContext(ctx),
 	}
 	if in.Options != nil {
 		req.Options = state.DeleteStateOption{
@@ -347,36 +643,85 @@ func (a *api) DeleteState(ctx context.Context, in *daprv1pb.DeleteStateEnvelope)
 			req.Options.RetryPolicy = retryPolicy
 		}
 	}
+	consistency, err := a.getStateConsistency(storeName, req.Options.Consistency, req.Metadata)
+	if err != nil {
+		return &empty.Empty{}, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: %s", err)
+	}
+	req.Options.Consistency = consistency
 
 	var span *trace.Span
 	spanName := fmt.Sprintf("DeleteState: %s", storeName)
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
 	defer span.End()
 
-	err := a.stateStores[storeName].Delete(&req)
+	err = store.Delete(&req)
+	err = etag.Check(store, err, req.Key, req.ETag)
+	if st := a.etagMismatchStatus(storeName, err); st != nil {
+		return &empty.Empty{}, st
+	}
 	if err != nil {
 		return &empty.Empty{}, fmt.Errorf("ERR_STATE_DELETE: failed deleting state with key %s: %s", in.Key, err)
 	}
 	return &empty.Empty{}, nil
 }
 
-func (a *api) getModifiedStateKey(key string) string {
+// etagMismatchStatus converts an ETag mismatch to an Aborted status carrying the requested and current
+// ETags of the key as error info, it returns nil if err is not an ETag mismatch
+func (a *api) etagMismatchStatus(storeName string, err error) error {
+	var mismatch *etag.MismatchError
+	if !errors.As(err, &mismatch) {
+		return nil
+	}
+
+	key := strings.TrimPrefix(mismatch.Key, a.getModifiedStateKey(storeName, ""))
+	st := status.Newf(codes.Aborted, "ERR_STATE_ETAG_MISMATCH: etag mismatch for key %s", key)
+	info := &epb.ErrorInfo{
+		Type:   etagMismatchErrorType,
+		Domain: errorInfoDomain,
+		Metadata: map[string]string{
+			"key":         key,
+			"etag":        mismatch.ETag,
+			"currentEtag": mismatch.CurrentETag,
+		},
+	}
+	if withDetails, detailsErr := st.WithDetails(info); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// getStateConsistency resolves the consistency of a state request from its consistency option
+// or metadata, and checks that the state store supports it
+func (a *api) getStateConsistency(storeName, requested string, metadata map[string]string) (string, error) {
+	return consistency.Resolve(requested, metadata, a.compStore.GetStateConsistency(storeName))
+}
+
+func (a *api) getModifiedStateKey(storeName, key string) string {
+	if prefix, ok := a.compStore.GetStateKeyPrefix(storeName); ok {
+		return prefix.Key(key)
+	}
 	if a.id != "" {
 		return fmt.Sprintf("%s%s%s", a.id, daprSeparator, key)
 	}
 	return key
 }
 
-func (a *api) GetSecret(ctx context.Context, in *daprv1pb.GetSecretEnvelope) (*daprv1pb.GetSecretResponseEnvelope, error) {
-	if a.secretStores == nil || len(a.secretStores) == 0 {
+func (a *api) GetSecret(ctx context.Context, in *daprv1pb.GetSecretEnvelope) (_ *daprv1pb.GetSecretResponseEnvelope, err error) {
+	if a.compStore.SecretStoresLen() == 0 {
 		return nil, errors.New("ERR_SECRET_STORE_NOT_CONFIGURED")
 	}
 
 	secretStoreName := in.StoreName
 
-	if a.secretStores[secretStoreName] == nil {
+	store, ok := a.compStore.GetSecretStore(secretStoreName)
+	if !ok {
 		return nil, errors.New("ERR_SECRET_STORE_NOT_FOUND")
 	}
+	defer func() { a.recordAudit(ctx, audit.GetSecret, secretStoreName, []string{in.Key}, err) }()
+
+	if !a.isSecretAllowed(secretStoreName, in.Key) {
+		return nil, status.Errorf(codes.PermissionDenied, "ERR_PERMISSION_DENIED: access denied by policy to get %s from %s", in.Key, secretStoreName)
+	}
 
 	req := secretstores.GetSecretRequest{
 		Name:     in.Key,
@@ -388,7 +733,7 @@ func (a *api) GetSecret(ctx context.Context, in *daprv1pb.GetSecretEnvelope) (*d
 	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
 	defer span.End()
 
-	getResponse, err := a.secretStores[secretStoreName].GetSecret(req)
+	getResponse, err := store.GetSecret(req)
 
 	if err != nil {
 		return nil, fmt.Errorf("ERR_SECRET_GET: %s", err)
@@ -0,0 +1,83 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/config"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const daprServiceName = "dapr.proto.dapr.v1.Dapr"
+
+// daprServiceAPIs are the APIs of the methods of the Dapr service
+var daprServiceAPIs = map[string]string{
+	"PublishEvent":  config.PubSubAPI,
+	"InvokeService": config.InvokeAPI,
+	"InvokeBinding": config.BindingsAPI,
+	"GetState":      config.StateAPI,
+	"SaveState":     config.StateAPI,
+	"DeleteState":   config.StateAPI,
+	"GetSecret":     config.SecretsAPI,
+}
+
+// serviceAPIs are the APIs of the other services of the Dapr API
+var serviceAPIs = map[string]string{
+	bindingsServiceName:      config.BindingsAPI,
+	configurationServiceName: config.ConfigurationAPI,
+	cryptoServiceName:        config.CryptoAPI,
+	jobsServiceName:          config.JobsAPI,
+	metadataServiceName:      config.MetadataAPI,
+	pubsubServiceName:        config.PubSubAPI,
+	secretsServiceName:       config.SecretsAPI,
+	shutdownServiceName:      config.ShutdownAPI,
+	stateServiceName:         config.StateAPI,
+	streamingServiceName:     config.PubSubAPI,
+	workflowsServiceName:     config.WorkflowsAPI,
+}
+
+// apiForMethod returns the API of a full gRPC method name, /<service>/<method>
+func apiForMethod(fullMethod string) string {
+	parts := strings.SplitN(strings.TrimPrefix(fullMethod, "/"), "/", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	if parts[0] == daprServiceName {
+		return daprServiceAPIs[parts[1]]
+	}
+	return serviceAPIs[parts[0]]
+}
+
+func checkAPIAccess(spec config.APISpec, fullMethod string) error {
+	if api := apiForMethod(fullMethod); api != "" && !spec.IsAPIAllowed(api) {
+		return status.Errorf(codes.PermissionDenied, "the %s API is denied by the configuration of the app", api)
+	}
+	return nil
+}
+
+// setAPIAccessUnaryServerInterceptor denies the calls to the APIs which the configuration of the app doesn't allow
+func setAPIAccessUnaryServerInterceptor(spec config.APISpec) grpc_go.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc_go.UnaryServerInfo, handler grpc_go.UnaryHandler) (interface{}, error) {
+		if err := checkAPIAccess(spec, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// setAPIAccessStreamServerInterceptor denies the streams of the APIs which the configuration of the app doesn't allow
+func setAPIAccessStreamServerInterceptor(spec config.APISpec) grpc_go.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc_go.ServerStream, info *grpc_go.StreamServerInfo, handler grpc_go.StreamHandler) error {
+		if err := checkAPIAccess(spec, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,45 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAPIForMethod(t *testing.T) {
+	assert.Equal(t, config.StateAPI, apiForMethod("/dapr.proto.dapr.v1.Dapr/GetState"))
+	assert.Equal(t, config.SecretsAPI, apiForMethod("/dapr.proto.dapr.v1.Dapr/GetSecret"))
+	assert.Equal(t, config.SecretsAPI, apiForMethod("/dapr.proto.dapr.v1.DaprSecrets/GetBulkSecret"))
+	assert.Equal(t, config.PubSubAPI, apiForMethod("/dapr.proto.dapr.v1.DaprStreaming/SubscribeTopicEvents"))
+	assert.Equal(t, config.PubSubAPI, apiForMethod("/dapr.proto.dapr.v1.DaprPubSub/PublishBulkEvent"))
+	assert.Empty(t, apiForMethod("/grpc.health.v1.Health/Check"))
+	assert.Empty(t, apiForMethod("invalid"))
+}
+
+func TestAPIAccessUnaryServerInterceptor(t *testing.T) {
+	interceptor := setAPIAccessUnaryServerInterceptor(config.APISpec{Denied: []string{config.SecretsAPI}})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	t.Run("allowed API", func(t *testing.T) {
+		resp, err := interceptor(context.Background(), nil, &grpc_go.UnaryServerInfo{FullMethod: "/dapr.proto.dapr.v1.Dapr/GetState"}, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+	})
+
+	t.Run("denied API", func(t *testing.T) {
+		_, err := interceptor(context.Background(), nil, &grpc_go.UnaryServerInfo{FullMethod: "/dapr.proto.dapr.v1.DaprSecrets/GetBulkSecret"}, handler)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
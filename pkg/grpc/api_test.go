@@ -7,8 +7,15 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,6 +29,9 @@ import (
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/dapr/dapr/pkg/state/etag"
 	daprt "github.com/dapr/dapr/pkg/testing"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -35,6 +45,9 @@ import (
 	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
 	grpc_go "google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -287,6 +300,32 @@ func TestCallLocal(t *testing.T) {
 		_, err := client.CallLocal(context.Background(), request)
 		assert.Equal(t, codes.Unknown, status.Code(err))
 	})
+
+	t.Run("response headers exceeding the metadata limits are removed", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+
+		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+		fakeResp.WithHeaders(metadata.Pairs("small", "value", "large", strings.Repeat("a", 11)))
+		mockAppChannel := new(channelt.MockAppChannel)
+		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("*v1.InvokeMethodRequest")).Return(fakeResp, nil)
+		fakeAPI := &api{
+			id:             "fakeAPI",
+			appChannel:     mockAppChannel,
+			metadataLimits: invokev1.NewMetadataLimits(100, 10),
+		}
+		server := startInternalServer(port, fakeAPI)
+		defer server.Stop()
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := internalv1pb.NewDaprInternalClient(clientConn)
+		request := invokev1.NewInvokeMethodRequest("method").Proto()
+
+		resp, err := client.CallLocal(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Contains(t, resp.GetHeaders(), "small")
+		assert.NotContains(t, resp.GetHeaders(), "large")
+	})
 }
 
 func mustMarshalAny(msg proto.Message) *any.Any {
@@ -470,6 +509,40 @@ func TestPublishTopic(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestSchemaValidationStatus(t *testing.T) {
+	err := schemaValidationStatus(runtime_pubsub.SchemaValidationError{Topic: "orders", Errors: []string{"id is required"}})
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+	assert.Len(t, s.Details(), 1)
+	badRequest, ok := s.Details()[0].(*epb.BadRequest)
+	assert.True(t, ok)
+	assert.Equal(t, "id is required", badRequest.FieldViolations[0].Description)
+}
+
+func TestETagMismatchStatus(t *testing.T) {
+	testAPI := &api{id: "app1", compStore: compstore.New()}
+
+	t.Run("etag mismatch", func(t *testing.T) {
+		mismatch := etag.Check(fakeStateStore{}, errors.New("conflict"), "app1||key1", "1")
+		err := testAPI.etagMismatchStatus("store1", fmt.Errorf("failed saving 1 of 1 keys: %w", mismatch))
+		s, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Aborted, s.Code())
+		assert.Len(t, s.Details(), 1)
+		errInfo, ok := s.Details()[0].(*epb.ErrorInfo)
+		assert.True(t, ok)
+		assert.Equal(t, etagMismatchErrorType, errInfo.Type)
+		assert.Equal(t, "key1", errInfo.Metadata["key"])
+		assert.Equal(t, "1", errInfo.Metadata["etag"])
+		assert.Equal(t, "", errInfo.Metadata["currentEtag"])
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		assert.Nil(t, testAPI.etagMismatchStatus("store1", errors.New("conflict")))
+	})
+}
+
 func TestInvokeBinding(t *testing.T) {
 	port, _ := freeport.GetFreePort()
 
@@ -483,3 +556,107 @@ func TestInvokeBinding(t *testing.T) {
 	_, err := client.InvokeBinding(context.Background(), &daprv1pb.InvokeBindingEnvelope{})
 	assert.Nil(t, err)
 }
+
+func contextWithCaller(spiffeID string) context.Context {
+	u, _ := url.Parse(spiffeID)
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "app1"},
+		URIs:    []*url.URL{u},
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+func TestCheckCaller(t *testing.T) {
+	t.Run("namespaces aren't isolated", func(t *testing.T) {
+		fakeAPI := &api{namespace: "ns1"}
+		assert.NoError(t, fakeAPI.checkCaller(context.Background()))
+	})
+
+	fakeAPI := &api{
+		namespace: "ns1",
+		accessControl: config.AccessControlSpec{
+			TrustDomain:        "td1",
+			NamespaceIsolation: true,
+			AllowedNamespaces:  []string{"ns2"},
+		},
+	}
+
+	t.Run("caller of the namespace", func(t *testing.T) {
+		assert.NoError(t, fakeAPI.checkCaller(contextWithCaller("spiffe://td1/ns/ns1/app1")))
+	})
+
+	t.Run("caller of an allowed namespace", func(t *testing.T) {
+		assert.NoError(t, fakeAPI.checkCaller(contextWithCaller("spiffe://td1/ns/ns2/app1")))
+	})
+
+	t.Run("caller of another namespace", func(t *testing.T) {
+		err := fakeAPI.checkCaller(contextWithCaller("spiffe://td1/ns/ns3/app1"))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("caller of another trust domain", func(t *testing.T) {
+		err := fakeAPI.checkCaller(contextWithCaller("spiffe://td2/ns/ns1/app1"))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("caller without a verified identity", func(t *testing.T) {
+		err := fakeAPI.checkCaller(context.Background())
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestCheckInvocationACL(t *testing.T) {
+	req := invokev1.NewInvokeMethodRequest("orders/1").WithHTTPExtension(http.MethodGet, "")
+
+	t.Run("policies aren't enabled", func(t *testing.T) {
+		fakeAPI := &api{}
+		assert.NoError(t, fakeAPI.checkInvocationACL(context.Background(), req))
+	})
+
+	fakeAPI := &api{
+		accessControl: config.AccessControlSpec{
+			DefaultAction: config.DenyAccess,
+			Policies: []config.AppPolicySpec{
+				{AppID: "app1", Namespace: "ns1", TrustDomain: "td1", DefaultAction: config.AllowAccess},
+				{
+					AppID: "app2",
+					AppOperationActions: []config.AppOperationAction{
+						{Name: "/orders/*", HTTPVerb: []string{"GET"}, Action: config.AllowAccess},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("allowed caller", func(t *testing.T) {
+		assert.NoError(t, fakeAPI.checkInvocationACL(contextWithCaller("spiffe://td1/ns/ns1/app1"), req))
+	})
+
+	t.Run("denied caller", func(t *testing.T) {
+		err := fakeAPI.checkInvocationACL(contextWithCaller("spiffe://td1/ns/ns2/app1"), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Contains(t, err.Error(), "spiffe://td1/ns/ns2/app1")
+	})
+
+	t.Run("allowed operation", func(t *testing.T) {
+		assert.NoError(t, fakeAPI.checkInvocationACL(contextWithCaller("spiffe://td1/ns/ns1/app2"), req))
+	})
+
+	t.Run("denied operation", func(t *testing.T) {
+		post := invokev1.NewInvokeMethodRequest("orders/1").WithHTTPExtension(http.MethodPost, "")
+		err := fakeAPI.checkInvocationACL(contextWithCaller("spiffe://td1/ns/ns1/app2"), post)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Contains(t, err.Error(), "POST orders/1")
+
+		grpcReq := invokev1.NewInvokeMethodRequest("orders/1")
+		err = fakeAPI.checkInvocationACL(contextWithCaller("spiffe://td1/ns/ns1/app2"), grpcReq)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("caller without a verified identity", func(t *testing.T) {
+		err := fakeAPI.checkInvocationACL(context.Background(), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
@@ -0,0 +1,92 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"net"
+
+	auth "github.com/dapr/dapr/pkg/runtime/security"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// checkAPIToken checks the API token of the metadata of a call, and returns the context of the call without the
+// token so it isn't forwarded to the apps
+func checkAPIToken(ctx context.Context, verifier *auth.APITokenVerifier) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	if values := md.Get(auth.APITokenHeader); len(values) > 0 {
+		token = values[0]
+	}
+	err := verifier.Verify(tokenClient(ctx), token)
+	if err == auth.ErrAPITokenLockedOut {
+		return nil, status.Error(codes.ResourceExhausted, "too many calls with an invalid API token, try again later")
+	} else if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "the call must have a valid %s metadata", auth.APITokenHeader)
+	}
+
+	md = md.Copy()
+	delete(md, auth.APITokenHeader)
+	return metadata.NewIncomingContext(ctx, md), nil
+}
+
+// tokenClient returns the host of the peer of a call, the invalid API tokens are counted per host
+func tokenClient(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+// setAPITokenAuthUnaryServerInterceptor rejects the calls without the API token
+func setAPITokenAuthUnaryServerInterceptor(token string) grpc_go.UnaryServerInterceptor {
+	verifier := auth.SharedAPITokenVerifier(token)
+	return func(ctx context.Context, req interface{}, info *grpc_go.UnaryServerInfo, handler grpc_go.UnaryHandler) (interface{}, error) {
+		ctx, err := checkAPIToken(ctx, verifier)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// setAPITokenAuthStreamServerInterceptor rejects the streams without the API token
+func setAPITokenAuthStreamServerInterceptor(token string) grpc_go.StreamServerInterceptor {
+	verifier := auth.SharedAPITokenVerifier(token)
+	return func(srv interface{}, ss grpc_go.ServerStream, info *grpc_go.StreamServerInfo, handler grpc_go.StreamHandler) error {
+		ctx, err := checkAPIToken(ss.Context(), verifier)
+		if err != nil {
+			return err
+		}
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
+
+// appTokenCredentials sends the app token in the metadata of the calls to the app, so the app can check the calls
+// come from its sidecar
+type appTokenCredentials struct {
+	token string
+}
+
+func (c appTokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{auth.APITokenHeader: c.token}, nil
+}
+
+// RequireTransportSecurity allows the token over the insecure connection to the app, which is local
+func (c appTokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,103 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	auth "github.com/dapr/dapr/pkg/runtime/security"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServerStream is a server stream with the given context
+type fakeServerStream struct {
+	grpc_go.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestAPITokenAuthUnaryServerInterceptor(t *testing.T) {
+	interceptor := setAPITokenAuthUnaryServerInterceptor("token1")
+	info := &grpc_go.UnaryServerInfo{FullMethod: "/dapr.proto.dapr.v1.Dapr/GetState"}
+	var handlerMD metadata.MD
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerMD, _ = metadata.FromIncomingContext(ctx)
+		return "ok", nil
+	}
+
+	t.Run("missing metadata", func(t *testing.T) {
+		_, err := interceptor(context.Background(), nil, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.APITokenHeader, "token2"))
+		_, err := interceptor(ctx, nil, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("valid token is not forwarded", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.APITokenHeader, "token1", "key1", "value1"))
+		resp, err := interceptor(ctx, nil, info, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+		assert.Empty(t, handlerMD.Get(auth.APITokenHeader))
+		assert.Equal(t, []string{"value1"}, handlerMD.Get("key1"))
+	})
+
+	t.Run("client locked out after repeated invalid tokens", func(t *testing.T) {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+		invalid := metadata.NewIncomingContext(ctx, metadata.Pairs(auth.APITokenHeader, "token2"))
+		for i := 0; i < 5; i++ {
+			_, err := interceptor(invalid, nil, info, handler)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		}
+
+		_, err := interceptor(invalid, nil, info, handler)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		// the valid tokens of a locked out client are rejected too
+		valid := metadata.NewIncomingContext(ctx, metadata.Pairs(auth.APITokenHeader, "token1"))
+		_, err = interceptor(valid, nil, info, handler)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		// the streams share the lockout of the unary calls
+		stream := setAPITokenAuthStreamServerInterceptor("token1")
+		err = stream(nil, &fakeServerStream{ctx: invalid}, &grpc_go.StreamServerInfo{}, func(srv interface{}, stream grpc_go.ServerStream) error {
+			return nil
+		})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		// the lockout is per host, whichever the port of the connection
+		other := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 5000}})
+		_, err = interceptor(metadata.NewIncomingContext(other, metadata.Pairs(auth.APITokenHeader, "token2")), nil, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestAppTokenCredentials(t *testing.T) {
+	os.Setenv(auth.AppAPITokenEnvVar, "token1")
+	defer os.Unsetenv(auth.AppAPITokenEnvVar)
+
+	assert.Len(t, appDialOptions(), 1)
+
+	creds := appTokenCredentials{token: auth.GetAppToken()}
+	md, err := creds.GetRequestMetadata(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{auth.APITokenHeader: "token1"}, md)
+	assert.False(t, creds.RequireTransportSecurity())
+}
@@ -0,0 +1,61 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/dapr/dapr/pkg/audit"
+	auth "github.com/dapr/dapr/pkg/runtime/security"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// recordAudit records the call on the keys of the store to the audit log, with the result of the error of the call
+func (a *api) recordAudit(ctx context.Context, operation, storeName string, keys []string, err error) {
+	if a.auditLog == nil {
+		return
+	}
+
+	event := audit.Event{
+		Caller:    a.auditCaller(ctx),
+		Operation: operation,
+		Store:     storeName,
+		Keys:      keys,
+		Result:    audit.ResultSuccess,
+	}
+	if err != nil {
+		event.Result = audit.ResultFailure
+		if status.Code(err) == codes.PermissionDenied {
+			event.Result = audit.ResultDenied
+		}
+		event.Error = err.Error()
+	}
+	a.auditLog.Record(event)
+}
+
+// auditCaller returns the SPIFFE ID of the caller when it authenticated with mTLS, the id of the app for the calls
+// sent on the loopback interface or on a Unix domain socket, which only the app reaches, or else the address of the caller
+func (a *api) auditCaller(ctx context.Context) string {
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		return identity.SPIFFEID()
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	switch addr := p.Addr.(type) {
+	case *net.UnixAddr:
+		return a.id
+	case *net.TCPAddr:
+		if addr.IP.IsLoopback() {
+			return a.id
+		}
+	}
+	return p.Addr.String()
+}
@@ -0,0 +1,134 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/audit"
+	"github.com/dapr/dapr/pkg/config"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+)
+
+type fakeAuditSink struct {
+	events []audit.Event
+}
+
+func (s *fakeAuditSink) Write(event audit.Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestRecordAudit(t *testing.T) {
+	testAPI := &api{
+		id: "app1",
+		compStore: newTestCompStore(map[string]state.Store{
+			"store1": fakeStateStore{},
+			"store2": &fakeTransactionalStore{},
+		}, map[string]secretstores.SecretStore{
+			"store1": fakeSecretStore{secrets: map[string]map[string]string{
+				"db":    {"password": "1"},
+				"queue": {"key": "2"},
+				"token": {"token": "3"},
+			}},
+		}),
+		secretScopesFn: func() map[string]config.SecretsScope {
+			return map[string]config.SecretsScope{
+				"store1": {StoreName: "store1", DeniedSecrets: []string{"token"}},
+			}
+		},
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	// audited calls the API and returns the audit events written once the call returns
+	audited := func(call func() error) ([]audit.Event, error) {
+		sink := &fakeAuditSink{}
+		testAPI.auditLog = audit.NewLogger("app1", sink, false)
+		err := call()
+		testAPI.auditLog.Close()
+		return sink.events, err
+	}
+
+	t.Run("denied secret", func(t *testing.T) {
+		events, err := audited(func() error {
+			_, err := testAPI.GetSecret(ctx, &daprv1pb.GetSecretEnvelope{StoreName: "store1", Key: "token"})
+			return err
+		})
+		assert.Error(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, "app1", events[0].AppID)
+		assert.Equal(t, "10.0.0.1:5000", events[0].Caller)
+		assert.Equal(t, audit.GetSecret, events[0].Operation)
+		assert.Equal(t, []string{"token"}, events[0].Keys)
+		assert.Equal(t, audit.ResultDenied, events[0].Result)
+		assert.Contains(t, events[0].Error, "ERR_PERMISSION_DENIED")
+	})
+
+	t.Run("bulk secrets record the returned secrets", func(t *testing.T) {
+		events, err := audited(func() error {
+			_, err := testAPI.GetBulkSecret(ctx, &daprv1pb.GetBulkSecretEnvelope{StoreName: "store1", Keys: []string{"queue", "db", "token"}})
+			return err
+		})
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, audit.BulkGetSecret, events[0].Operation)
+		assert.Equal(t, []string{"db", "queue"}, events[0].Keys)
+		assert.Equal(t, audit.ResultSuccess, events[0].Result)
+	})
+
+	t.Run("prefix delete not allowed", func(t *testing.T) {
+		events, err := audited(func() error {
+			_, err := testAPI.DeleteStateWithPrefix(ctx, &daprv1pb.DeleteStateWithPrefixEnvelope{StoreName: "store1", Prefix: "order"})
+			return err
+		})
+		assert.Error(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, audit.DeleteStateWithPrefix, events[0].Operation)
+		assert.Equal(t, []string{"order"}, events[0].Keys)
+		assert.Equal(t, audit.ResultDenied, events[0].Result)
+	})
+
+	t.Run("transaction deletes", func(t *testing.T) {
+		events, err := audited(func() error {
+			_, err := testAPI.ExecuteStateTransaction(ctx, &daprv1pb.ExecuteStateTransactionEnvelope{
+				StoreName: "store2",
+				Operations: []*daprv1pb.TransactionalStateOperation{
+					{OperationType: string(state.Upsert), Request: &daprv1pb.StateRequest{Key: "a"}},
+					{OperationType: string(state.Delete), Request: &daprv1pb.StateRequest{Key: "b"}},
+				},
+			})
+			return err
+		})
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, audit.TransactionDeleteState, events[0].Operation)
+		assert.Equal(t, "store2", events[0].Store)
+		assert.Equal(t, []string{"b"}, events[0].Keys)
+		assert.Equal(t, audit.ResultSuccess, events[0].Result)
+	})
+
+	t.Run("calls of the app record the app id", func(t *testing.T) {
+		for _, addr := range []net.Addr{
+			&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5000},
+			&net.TCPAddr{IP: net.IPv6loopback, Port: 5000},
+			&net.UnixAddr{Name: "/tmp/dapr-app1-grpc.socket", Net: "unix"},
+		} {
+			appCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+			events, _ := audited(func() error {
+				_, err := testAPI.DeleteState(appCtx, &daprv1pb.DeleteStateEnvelope{StoreName: "store1", Key: "a"})
+				return err
+			})
+			assert.Len(t, events, 1)
+			assert.Equal(t, "app1", events[0].Caller, addr.String())
+		}
+	})
+}
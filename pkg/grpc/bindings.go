@@ -0,0 +1,30 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+)
+
+// bindingsServiceName is the name of the DaprBindings service in the gRPC method names
+const bindingsServiceName = "dapr.proto.dapr.v1.DaprBindings"
+
+// InvokeBindingWithResponse invokes an output binding and returns the response data and metadata of the binding.
+// The response is empty for bindings that don't return the response of their operations.
+func (a *api) InvokeBindingWithResponse(ctx context.Context, in *daprv1pb.InvokeBindingEnvelope) (*daprv1pb.InvokeBindingResponseEnvelope, error) {
+	resp, err := a.invokeBinding(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	out := &daprv1pb.InvokeBindingResponseEnvelope{}
+	if resp != nil {
+		out.Data = resp.Data
+		out.Metadata = resp.Metadata
+	}
+	return out, nil
+}
@@ -0,0 +1,91 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+)
+
+func startBindingsServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprBindingsServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+func TestInvokeBindingWithResponse(t *testing.T) {
+	var invoked *bindings.WriteRequest
+	port, _ := freeport.GetFreePort()
+	server := startBindingsServer(port, &api{
+		sendToOutputBindingFn: func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) {
+			invoked = req
+			switch name {
+			case "http":
+				return &bindings_loader.InvokeResponse{
+					Data:     []byte(`{"id":"1"}`),
+					Metadata: map[string]string{"statusCode": "201"},
+				}, nil
+			case "queue":
+				return nil, nil
+			default:
+				return nil, errors.New("couldn't find output binding")
+			}
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("binding response", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprBindingsClient(clientConn).InvokeBindingWithResponse(context.Background(), &daprv1pb.InvokeBindingEnvelope{
+			Name:     "http",
+			Data:     &any.Any{Value: []byte("data")},
+			Metadata: map[string]string{"method": "POST"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"1"}`, string(resp.Data))
+		assert.Equal(t, map[string]string{"statusCode": "201"}, resp.Metadata)
+		assert.Equal(t, []byte("data"), invoked.Data)
+		assert.Equal(t, map[string]string{"method": "POST"}, invoked.Metadata)
+	})
+
+	t.Run("binding without response", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprBindingsClient(clientConn).InvokeBindingWithResponse(context.Background(), &daprv1pb.InvokeBindingEnvelope{Name: "queue"})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Data)
+		assert.Empty(t, resp.Metadata)
+	})
+
+	t.Run("binding error", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprBindingsClient(clientConn).InvokeBindingWithResponse(context.Background(), &daprv1pb.InvokeBindingEnvelope{Name: "unknown"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ERR_INVOKE_OUTPUT_BINDING")
+	})
+}
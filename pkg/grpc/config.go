@@ -5,18 +5,33 @@
 
 package grpc
 
+import "crypto/tls"
+
 // ServerConfig is the config object for a grpc server
 type ServerConfig struct {
 	AppID       string
 	HostAddress string
 	Port        int
+	// UnixDomainSocket is the path of the Unix domain socket the server listens on instead of its port
+	UnixDomainSocket string
+	// MaxRecvMsgSize and MaxSendMsgSize are the maximum sizes in MB of the messages received and sent by the
+	// server, the defaults of gRPC are used when they are zero
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	// TLSConfig serves the API with TLS on the port of the server when it is set, the Unix domain socket is only
+	// reachable on the host and is served without TLS
+	TLSConfig *tls.Config
 }
 
 // NewServerConfig returns a new grpc server config
-func NewServerConfig(appID string, hostAddress string, port int) ServerConfig {
+func NewServerConfig(appID string, hostAddress string, port int, unixDomainSocket string, maxRecvMsgSize, maxSendMsgSize int, tlsConfig *tls.Config) ServerConfig {
 	return ServerConfig{
-		AppID:       appID,
-		HostAddress: hostAddress,
-		Port:        port,
+		AppID:            appID,
+		HostAddress:      hostAddress,
+		Port:             port,
+		UnixDomainSocket: unixDomainSocket,
+		MaxRecvMsgSize:   maxRecvMsgSize,
+		MaxSendMsgSize:   maxSendMsgSize,
+		TLSConfig:        tlsConfig,
 	}
 }
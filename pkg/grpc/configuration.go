@@ -0,0 +1,87 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dapr/dapr/pkg/configuration"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// configurationServiceName is the name of the DaprConfiguration service in the gRPC method names
+const configurationServiceName = "dapr.proto.dapr.v1.DaprConfiguration"
+
+// GetConfiguration returns the configuration items of the keys, or all the items of the store when no keys are given
+func (a *api) GetConfiguration(ctx context.Context, in *daprv1pb.GetConfigurationEnvelope) (*daprv1pb.GetConfigurationResponseEnvelope, error) {
+	store, err := a.getConfigurationStore(in.StoreName)
+	if err != nil {
+		return nil, err
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("GetConfiguration: %s", in.StoreName)
+	ctx, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	resp, err := store.Get(ctx, &configuration.GetRequest{Keys: in.Keys, Metadata: in.Metadata})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "ERR_CONFIGURATION_GET: %s", err)
+	}
+
+	out := &daprv1pb.GetConfigurationResponseEnvelope{}
+	if resp != nil {
+		out.Items = configurationItemEnvelopes(resp.Items)
+	}
+	return out, nil
+}
+
+// SubscribeConfiguration sends the subscribed configuration items which change over the stream,
+// until the client disconnects
+func (a *api) SubscribeConfiguration(in *daprv1pb.SubscribeConfigurationEnvelope, stream daprv1pb.DaprConfiguration_SubscribeConfigurationServer) error {
+	store, err := a.getConfigurationStore(in.StoreName)
+	if err != nil {
+		return err
+	}
+
+	req := configuration.SubscribeRequest{Keys: in.Keys, Metadata: in.Metadata}
+	err = store.Subscribe(stream.Context(), &req, func(e *configuration.UpdateEvent) error {
+		return stream.Send(&daprv1pb.ConfigurationUpdateEnvelope{Items: configurationItemEnvelopes(e.Items)})
+	})
+	if err != nil && stream.Context().Err() == nil {
+		return status.Errorf(codes.Internal, "ERR_CONFIGURATION_SUBSCRIBE: %s", err)
+	}
+	return nil
+}
+
+func (a *api) getConfigurationStore(storeName string) (configuration.Store, error) {
+	if a.compStore.ConfigurationStoresLen() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_CONFIGURATION_STORE_NOT_CONFIGURED")
+	}
+	store, ok := a.compStore.GetConfigurationStore(storeName)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_CONFIGURATION_STORE_NOT_FOUND: configuration store name: %s", storeName)
+	}
+	return store, nil
+}
+
+func configurationItemEnvelopes(items []*configuration.Item) []*daprv1pb.ConfigurationItemEnvelope {
+	envelopes := make([]*daprv1pb.ConfigurationItemEnvelope, 0, len(items))
+	for _, item := range items {
+		envelopes = append(envelopes, &daprv1pb.ConfigurationItemEnvelope{
+			Key:      item.Key,
+			Value:    item.Value,
+			Version:  item.Version,
+			Metadata: item.Metadata,
+		})
+	}
+	return envelopes
+}
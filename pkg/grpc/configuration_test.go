@@ -0,0 +1,165 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/configuration"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeConfigurationStore struct {
+	items map[string]*configuration.Item
+}
+
+func (f *fakeConfigurationStore) Init(metadata configuration.Metadata) error {
+	return nil
+}
+
+func (f *fakeConfigurationStore) Get(ctx context.Context, req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	if req.Metadata["fail"] == "true" {
+		return nil, errors.New("get failed")
+	}
+	resp := &configuration.GetResponse{}
+	for _, key := range req.Keys {
+		if item, ok := f.items[key]; ok {
+			resp.Items = append(resp.Items, item)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeConfigurationStore) Subscribe(ctx context.Context, req *configuration.SubscribeRequest, handler func(*configuration.UpdateEvent) error) error {
+	var items []*configuration.Item
+	for _, key := range req.Keys {
+		items = append(items, &configuration.Item{Key: key, Value: "updated", Version: "2"})
+	}
+	if err := handler(&configuration.UpdateEvent{Items: items}); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func startConfigurationServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprConfigurationServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+func TestGetConfiguration(t *testing.T) {
+	store := &fakeConfigurationStore{items: map[string]*configuration.Item{
+		"key1": {Key: "key1", Value: "value1", Version: "1"},
+	}}
+	compStore := compstore.New()
+	compStore.AddConfigurationStore("store1", store)
+	port, _ := freeport.GetFreePort()
+	server := startConfigurationServer(port, &api{
+		id:        "app1",
+		compStore: compStore,
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("existing keys are returned", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprConfigurationClient(clientConn).GetConfiguration(context.Background(), &daprv1pb.GetConfigurationEnvelope{
+			StoreName: "store1",
+			Keys:      []string{"key1", "key2"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items, 1)
+		assert.Equal(t, "key1", resp.Items[0].Key)
+		assert.Equal(t, "value1", resp.Items[0].Value)
+		assert.Equal(t, "1", resp.Items[0].Version)
+	})
+
+	t.Run("store not found", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprConfigurationClient(clientConn).GetConfiguration(context.Background(), &daprv1pb.GetConfigurationEnvelope{StoreName: "store2"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprConfigurationClient(clientConn).GetConfiguration(context.Background(), &daprv1pb.GetConfigurationEnvelope{
+			StoreName: "store1",
+			Metadata:  map[string]string{"fail": "true"},
+		})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGetConfigurationNotConfigured(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	server := startConfigurationServer(port, &api{id: "app1", compStore: compstore.New()})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	_, err := daprv1pb.NewDaprConfigurationClient(clientConn).GetConfiguration(context.Background(), &daprv1pb.GetConfigurationEnvelope{StoreName: "store1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestSubscribeConfiguration(t *testing.T) {
+	compStore := compstore.New()
+	compStore.AddConfigurationStore("store1", &fakeConfigurationStore{})
+	port, _ := freeport.GetFreePort()
+	server := startConfigurationServer(port, &api{
+		id:        "app1",
+		compStore: compStore,
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("updates are streamed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := daprv1pb.NewDaprConfigurationClient(clientConn).SubscribeConfiguration(ctx, &daprv1pb.SubscribeConfigurationEnvelope{
+			StoreName: "store1",
+			Keys:      []string{"key1"},
+		})
+		assert.NoError(t, err)
+
+		update, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Len(t, update.Items, 1)
+		assert.Equal(t, "key1", update.Items[0].Key)
+		assert.Equal(t, "updated", update.Items[0].Value)
+	})
+
+	t.Run("store not found", func(t *testing.T) {
+		stream, err := daprv1pb.NewDaprConfigurationClient(clientConn).SubscribeConfiguration(context.Background(), &daprv1pb.SubscribeConfigurationEnvelope{StoreName: "store2"})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
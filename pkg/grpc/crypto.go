@@ -0,0 +1,148 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dapr/dapr/pkg/crypto"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// cryptoServiceName is the name of the DaprCrypto service in the gRPC method names
+	cryptoServiceName = "dapr.proto.dapr.v1.DaprCrypto"
+	// cryptoChunkSize is the size of the chunks of the decrypted payloads sent over the Decrypt stream
+	cryptoChunkSize = 64 * 1024
+)
+
+// cryptoStreamWriter sends the bytes written to it over the stream
+type cryptoStreamWriter struct {
+	stream daprv1pb.DaprCrypto_EncryptServer
+}
+
+func (w cryptoStreamWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&daprv1pb.CryptoResponseEnvelope{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Encrypt encrypts the payload received over the stream and sends the encrypted payload back as it goes
+func (a *api) Encrypt(stream daprv1pb.DaprCrypto_EncryptServer) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	vault, err := a.getKeyVault(in.VaultName)
+	if err != nil {
+		return err
+	}
+	if in.KeyName == "" {
+		return status.Error(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: key name is required")
+	}
+
+	spanName := fmt.Sprintf("Encrypt: %s", in.VaultName)
+	ctx, span := diag.StartTracingClientSpanFromGRPCContext(stream.Context(), spanName, a.tracingSpec)
+	defer span.End()
+
+	w, err := crypto.NewEncryptWriter(ctx, vault, cryptoStreamWriter{stream}, in.KeyName, in.Algorithm)
+	if err != nil {
+		return status.Errorf(codes.Internal, "ERR_CRYPTO_ENCRYPT: %s", err)
+	}
+	for {
+		if _, err := w.Write(in.Data); err != nil {
+			return status.Errorf(codes.Internal, "ERR_CRYPTO_ENCRYPT: %s", err)
+		}
+		if in, err = stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return status.Errorf(codes.Internal, "ERR_CRYPTO_ENCRYPT: %s", err)
+	}
+	return nil
+}
+
+// cryptoStreamReader reads the payload received over the stream, starting with the data of the first message
+type cryptoStreamReader struct {
+	stream daprv1pb.DaprCrypto_DecryptServer
+	data   []byte
+}
+
+func (r *cryptoStreamReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		in, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.data = in.Data
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// Decrypt decrypts the payload received over the stream and sends the decrypted payload back as it goes.
+// The content of a chunk is only sent once it is authenticated, but the payload is only known to be
+// complete once the stream ends without an error.
+func (a *api) Decrypt(stream daprv1pb.DaprCrypto_DecryptServer) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	vault, err := a.getKeyVault(in.VaultName)
+	if err != nil {
+		return err
+	}
+
+	spanName := fmt.Sprintf("Decrypt: %s", in.VaultName)
+	ctx, span := diag.StartTracingClientSpanFromGRPCContext(stream.Context(), spanName, a.tracingSpec)
+	defer span.End()
+
+	r, err := crypto.NewDecryptReader(ctx, vault, &cryptoStreamReader{stream: stream, data: in.Data}, in.KeyName)
+	if err != nil {
+		return decryptError(err)
+	}
+	buf := make([]byte, cryptoChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := stream.Send(&daprv1pb.CryptoResponseEnvelope{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		} else if err != nil {
+			return decryptError(err)
+		}
+	}
+}
+
+func decryptError(err error) error {
+	if err == crypto.ErrInvalidPayload || err == crypto.ErrKeyNameMismatch {
+		return status.Errorf(codes.InvalidArgument, "ERR_CRYPTO_DECRYPT: %s", err)
+	}
+	return status.Errorf(codes.Internal, "ERR_CRYPTO_DECRYPT: %s", err)
+}
+
+func (a *api) getKeyVault(vaultName string) (crypto.KeyVault, error) {
+	if a.compStore.KeyVaultsLen() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_CRYPTO_VAULT_NOT_CONFIGURED")
+	}
+	vault, ok := a.compStore.GetKeyVault(vaultName)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_CRYPTO_VAULT_NOT_FOUND: key vault name: %s", vaultName)
+	}
+	return vault, nil
+}
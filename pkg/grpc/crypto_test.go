@@ -0,0 +1,181 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/crypto"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeKeyVault wraps keys by XORing them with the key name
+type fakeKeyVault struct{}
+
+func (fakeKeyVault) Init(metadata crypto.Metadata) error {
+	return nil
+}
+
+func (fakeKeyVault) WrapKey(ctx context.Context, key []byte, keyName, algorithm string) ([]byte, error) {
+	if keyName == "missing" {
+		return nil, errors.New("key not found")
+	}
+	wrapped := make([]byte, len(key))
+	for i := range key {
+		wrapped[i] = key[i] ^ keyName[i%len(keyName)]
+	}
+	return wrapped, nil
+}
+
+func (v fakeKeyVault) UnwrapKey(ctx context.Context, wrappedKey []byte, keyName, algorithm string) ([]byte, error) {
+	return v.WrapKey(ctx, wrappedKey, keyName, algorithm)
+}
+
+func startCryptoServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprCryptoServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+// cryptoClientStream is the client side of the Encrypt and Decrypt streams
+type cryptoClientStream interface {
+	Recv() (*daprv1pb.CryptoResponseEnvelope, error)
+	grpc_go.ClientStream
+}
+
+// sendChunks sends the messages over the stream and returns the received payload
+func sendChunks(stream cryptoClientStream, msgs ...interface{}) ([]byte, error) {
+	for _, msg := range msgs {
+		if err := stream.SendMsg(msg); err != nil {
+			return nil, err
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	var out []byte
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, err
+		}
+		out = append(out, resp.Data...)
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	compStore := compstore.New()
+	compStore.AddKeyVault("vault1", fakeKeyVault{})
+	port, _ := freeport.GetFreePort()
+	server := startCryptoServer(port, &api{
+		id:        "app1",
+		compStore: compStore,
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	plaintext := bytes.Repeat([]byte("0123456789"), 20000)
+	var ciphertext []byte
+
+	t.Run("encrypt in chunks", func(t *testing.T) {
+		stream, err := daprv1pb.NewDaprCryptoClient(clientConn).Encrypt(context.Background())
+		assert.NoError(t, err)
+		ciphertext, err = sendChunks(stream,
+			&daprv1pb.EncryptRequestEnvelope{VaultName: "vault1", KeyName: "key1", Data: plaintext[:150000]},
+			&daprv1pb.EncryptRequestEnvelope{Data: plaintext[150000:]})
+		assert.NoError(t, err)
+		assert.False(t, bytes.Contains(ciphertext, []byte("0123456789")))
+	})
+
+	t.Run("decrypt in chunks", func(t *testing.T) {
+		stream, err := daprv1pb.NewDaprCryptoClient(clientConn).Decrypt(context.Background())
+		assert.NoError(t, err)
+		decrypted, err := sendChunks(stream,
+			&daprv1pb.DecryptRequestEnvelope{VaultName: "vault1", KeyName: "key1", Data: ciphertext[:100]},
+			&daprv1pb.DecryptRequestEnvelope{Data: ciphertext[100:]})
+		assert.NoError(t, err)
+		assert.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("decrypt an altered payload", func(t *testing.T) {
+		altered := append([]byte(nil), ciphertext...)
+		altered[len(altered)-1] ^= 1
+		stream, err := daprv1pb.NewDaprCryptoClient(clientConn).Decrypt(context.Background())
+		assert.NoError(t, err)
+		_, err = sendChunks(stream, &daprv1pb.DecryptRequestEnvelope{VaultName: "vault1", Data: altered})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("key name mismatch", func(t *testing.T) {
+		stream, err := daprv1pb.NewDaprCryptoClient(clientConn).Decrypt(context.Background())
+		assert.NoError(t, err)
+		_, err = sendChunks(stream, &daprv1pb.DecryptRequestEnvelope{VaultName: "vault1", KeyName: "key2", Data: ciphertext})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("key name is required", func(t *testing.T) {
+		stream, err := daprv1pb.NewDaprCryptoClient(clientConn).Encrypt(context.Background())
+		assert.NoError(t, err)
+		_, err = sendChunks(stream, &daprv1pb.EncryptRequestEnvelope{VaultName: "vault1", Data: plaintext})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("key wrap error", func(t *testing.T) {
+		stream, err := daprv1pb.NewDaprCryptoClient(clientConn).Encrypt(context.Background())
+		assert.NoError(t, err)
+		_, err = sendChunks(stream, &daprv1pb.EncryptRequestEnvelope{VaultName: "vault1", KeyName: "missing", Data: plaintext})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("vault not found", func(t *testing.T) {
+		stream, err := daprv1pb.NewDaprCryptoClient(clientConn).Encrypt(context.Background())
+		assert.NoError(t, err)
+		_, err = sendChunks(stream, &daprv1pb.EncryptRequestEnvelope{VaultName: "vault2", KeyName: "key1"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestEncryptNotConfigured(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	server := startCryptoServer(port, &api{id: "app1", compStore: compstore.New()})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	stream, err := daprv1pb.NewDaprCryptoClient(clientConn).Encrypt(context.Background())
+	assert.NoError(t, err)
+	_, err = sendChunks(stream, &daprv1pb.EncryptRequestEnvelope{VaultName: "vault1", KeyName: "key1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
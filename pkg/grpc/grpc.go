@@ -6,8 +6,11 @@
 package grpc
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
+	"strconv"
 	"sync"
 
 	"github.com/dapr/dapr/pkg/channel"
@@ -31,7 +34,11 @@ type Manager struct {
 	lock           *sync.Mutex
 	connectionPool map[string]*grpc.ClientConn
 	auth           security.Authenticator
+	workloadCerts  *security.WorkloadCertificates
+	appTLSConfig   *tls.Config
 	mode           modes.DaprMode
+	maxRecvMsgSize int
+	maxSendMsgSize int
 }
 
 // NewGRPCManager returns a new grpc manager
@@ -46,11 +53,39 @@ func NewGRPCManager(mode modes.DaprMode) *Manager {
 // SetAuthenticator sets the gRPC manager a tls authenticator context
 func (g *Manager) SetAuthenticator(auth security.Authenticator) {
 	g.auth = auth
+	g.workloadCerts = security.NewWorkloadCertificates(auth)
+}
+
+// SetAppTLSConfig sets the TLS config of the connection to the app, the connection is insecure when it's nil
+func (g *Manager) SetAppTLSConfig(tlsConfig *tls.Config) {
+	g.appTLSConfig = tlsConfig
+}
+
+// SetMaxMessageSize sets the maximum sizes in MB of the messages received and sent over the connections of the
+// gRPC manager, the defaults of gRPC are used when they are zero
+func (g *Manager) SetMaxMessageSize(maxRecvMsgSize, maxSendMsgSize int) {
+	g.maxRecvMsgSize = maxRecvMsgSize
+	g.maxSendMsgSize = maxSendMsgSize
+}
+
+// callOptions returns the dial options setting the maximum message sizes of the calls
+func (g *Manager) callOptions() []grpc.DialOption {
+	callOpts := []grpc.CallOption{}
+	if g.maxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(g.maxRecvMsgSize<<20))
+	}
+	if g.maxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(g.maxSendMsgSize<<20))
+	}
+	if len(callOpts) == 0 {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithDefaultCallOptions(callOpts...)}
 }
 
 // CreateLocalChannel creates a new gRPC AppChannel
 func (g *Manager) CreateLocalChannel(port, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error) {
-	conn, err := g.GetGRPCConnection(fmt.Sprintf("127.0.0.1:%v", port), "", true, false)
+	conn, err := g.getGRPCConnection(net.JoinHostPort(channel.DefaultChannelAddress, strconv.Itoa(port)), "", true, false, g.appTransportCredentials(), appDialOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("error establishing connection to app grpc on port %v: %s", port, err)
 	}
@@ -60,8 +95,58 @@ func (g *Manager) CreateLocalChannel(port, maxConcurrency int, spec config.Traci
 	return ch, nil
 }
 
+// CreateUnixChannel creates a new gRPC AppChannel to an app listening on a Unix domain socket
+func (g *Manager) CreateUnixChannel(socket string, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error) {
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", addr)
+		}),
+	}
+	if diag.DefaultGRPCMonitoring.IsEnabled() {
+		opts = append(opts, grpc.WithUnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryClientInterceptor()))
+	}
+	opts = append(opts, g.callOptions()...)
+	opts = append(opts, appDialOptions()...)
+
+	conn, err := grpc.Dial(socket, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error establishing connection to app grpc on socket %s: %s", socket, err)
+	}
+
+	g.AppClient = conn
+	return grpc_channel.CreateUnixChannel(socket, maxConcurrency, conn, spec), nil
+}
+
+// appTransportCredentials returns the TLS credentials of the connection to the app, or nil when the connection is
+// insecure
+func (g *Manager) appTransportCredentials() credentials.TransportCredentials {
+	if g.appTLSConfig == nil {
+		return nil
+	}
+	return credentials.NewTLS(g.appTLSConfig)
+}
+
+// appDialOptions returns the dial options of the connection to the app, which send the app token on every call
+// when APP_API_TOKEN is set
+func appDialOptions() []grpc.DialOption {
+	token := security.GetAppToken()
+	if token == "" {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithPerRPCCredentials(appTokenCredentials{token: token})}
+}
+
 // GetGRPCConnection returns a new grpc connection for a given address and inits one if doesn't exist
 func (g *Manager) GetGRPCConnection(address, id string, skipTLS, recreateIfExists bool) (*grpc.ClientConn, error) {
+	return g.getGRPCConnection(address, id, skipTLS, recreateIfExists, nil)
+}
+
+// getGRPCConnection returns a connection secured with mTLS unless the TLS is skipped, the connection is then secured
+// with the transport credentials when they are not nil
+func (g *Manager) getGRPCConnection(address, id string, skipTLS, recreateIfExists bool, transportCreds credentials.TransportCredentials, extraOpts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	if val, ok := g.connectionPool[address]; ok && !recreateIfExists {
 		return val, nil
 	}
@@ -82,21 +167,21 @@ func (g *Manager) GetGRPCConnection(address, id string, skipTLS, recreateIfExist
 	}
 
 	if !skipTLS && g.auth != nil {
+		// the reconnections of the pooled connections present the rotated workload certificate
 		signedCert := g.auth.GetCurrentSignedCert()
-		cert, err := tls.X509KeyPair(signedCert.WorkloadCert, signedCert.PrivateKeyPem)
-		if err != nil {
-			return nil, fmt.Errorf("error generating x509 Key Pair: %s", err)
-		}
-
 		ta := credentials.NewTLS(&tls.Config{
-			ServerName:   id,
-			Certificates: []tls.Certificate{cert},
-			RootCAs:      signedCert.TrustChain,
+			ServerName:           id,
+			RootCAs:              signedCert.TrustChain,
+			GetClientCertificate: g.workloadCerts.GetClientCertificate,
 		})
 		opts = append(opts, grpc.WithTransportCredentials(ta))
+	} else if transportCreds != nil {
+		opts = append(opts, grpc.WithTransportCredentials(transportCreds))
 	} else {
 		opts = append(opts, grpc.WithInsecure())
 	}
+	opts = append(opts, g.callOptions()...)
+	opts = append(opts, extraOpts...)
 
 	dialPrefix := GetDialAddressPrefix(g.mode)
 	conn, err := grpc.Dial(dialPrefix+address, opts...)
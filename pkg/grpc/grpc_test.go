@@ -0,0 +1,174 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/modes"
+	auth "github.com/dapr/dapr/pkg/runtime/security"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuthenticator signs the workload certificates with a test root certificate instead of sentry
+type fakeAuthenticator struct {
+	lock       sync.Mutex
+	rootCert   *x509.Certificate
+	rootKey    *ecdsa.PrivateKey
+	trustChain *x509.CertPool
+	current    *auth.SignedCertificate
+	serial     int64
+}
+
+func newFakeAuthenticator(t *testing.T) *fakeAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "root"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDER)
+	assert.NoError(t, err)
+	trustChain := x509.NewCertPool()
+	trustChain.AddCert(cert)
+	return &fakeAuthenticator{rootCert: cert, rootKey: key, trustChain: trustChain, serial: 1}
+}
+
+func (f *fakeAuthenticator) GetTrustAnchors() *x509.CertPool {
+	return f.trustChain
+}
+
+func (f *fakeAuthenticator) GetCurrentSignedCert() *auth.SignedCertificate {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.current
+}
+
+func (f *fakeAuthenticator) CreateSignedWorkloadCert(id string) (*auth.SignedCertificate, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	f.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(f.serial),
+		Subject:      pkix.Name{CommonName: id},
+		DNSNames:     []string{id},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, f.rootCert, &key.PublicKey, f.rootKey)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	f.current = &auth.SignedCertificate{
+		WorkloadCert:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		PrivateKeyPem: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		Expiry:        template.NotAfter,
+		TrustChain:    f.trustChain,
+	}
+	return f.current, nil
+}
+
+func TestCallOptions(t *testing.T) {
+	t.Run("default message sizes", func(t *testing.T) {
+		m := NewGRPCManager(modes.StandaloneMode)
+		assert.Empty(t, m.callOptions())
+	})
+
+	t.Run("maximum message sizes", func(t *testing.T) {
+		m := NewGRPCManager(modes.StandaloneMode)
+		m.SetMaxMessageSize(16, 8)
+		assert.Equal(t, 16, m.maxRecvMsgSize)
+		assert.Equal(t, 8, m.maxSendMsgSize)
+		assert.Len(t, m.callOptions(), 1)
+	})
+}
+
+func TestWorkloadCertRotation(t *testing.T) {
+	authenticator := newFakeAuthenticator(t)
+	s := &server{
+		config:        NewServerConfig("app1", "localhost", 0, "", 0, 0, nil),
+		kind:          internalServer,
+		authenticator: authenticator,
+		renewMutex:    &sync.Mutex{},
+		logger:        logger.NewLogger("dapr.runtime.grpc.test"),
+	}
+	srv, err := s.getGRPCServer()
+	assert.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	m := NewGRPCManager(modes.StandaloneMode)
+	m.SetAuthenticator(authenticator)
+
+	// the server and the client handshake with the current certificate
+	invoke := func(recreate bool) {
+		conn, err := m.GetGRPCConnection(lis.Addr().String(), "app1", false, recreate)
+		assert.NoError(t, err)
+		err = conn.Invoke(context.Background(), "/test.Test/Test", &empty.Empty{}, &empty.Empty{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	}
+	servedSerial := func() *big.Int {
+		conn, err := tls.Dial("tcp", lis.Addr().String(), &tls.Config{
+			ServerName:           "app1",
+			RootCAs:              authenticator.trustChain,
+			GetClientCertificate: auth.NewWorkloadCertificates(authenticator).GetClientCertificate,
+			NextProtos:           []string{"h2"},
+		})
+		assert.NoError(t, err)
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].SerialNumber
+	}
+
+	invoke(false)
+	oldConn, _ := m.GetGRPCConnection(lis.Addr().String(), "app1", false, false)
+	assert.Equal(t, int64(2), servedSerial().Int64())
+
+	_, err = authenticator.CreateSignedWorkloadCert("app1")
+	assert.NoError(t, err)
+
+	// the new connections use the rotated certificate without restarting the server
+	invoke(true)
+	assert.Equal(t, int64(3), servedSerial().Int64())
+
+	// the connection of the previous certificate keeps working
+	err = oldConn.Invoke(context.Background(), "/test.Test/Test", &empty.Empty{}, &empty.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,76 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dapr/dapr/pkg/jobs"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// jobsServiceName is the name of the DaprJobs service in the gRPC method names
+const jobsServiceName = "dapr.proto.dapr.v1.DaprJobs"
+
+// ScheduleJob saves a job of the app, replacing the job with the same name
+func (a *api) ScheduleJob(ctx context.Context, in *daprv1pb.JobEnvelope) (*empty.Empty, error) {
+	if a.jobs == nil {
+		return &empty.Empty{}, status.Error(codes.FailedPrecondition, "ERR_JOBS_NOT_CONFIGURED")
+	}
+
+	err := a.jobs.ScheduleJob(jobs.Job{
+		Name:     in.Name,
+		Schedule: in.Schedule,
+		DueTime:  in.DueTime,
+		Data:     in.Data,
+	})
+	if errors.As(err, &jobs.InvalidJobError{}) {
+		return &empty.Empty{}, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: %s", err)
+	} else if err != nil {
+		return &empty.Empty{}, status.Errorf(codes.Internal, "ERR_JOB_SCHEDULE: %s", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// DeleteJob deletes a job of the app
+func (a *api) DeleteJob(ctx context.Context, in *daprv1pb.DeleteJobEnvelope) (*empty.Empty, error) {
+	if a.jobs == nil {
+		return &empty.Empty{}, status.Error(codes.FailedPrecondition, "ERR_JOBS_NOT_CONFIGURED")
+	}
+
+	if err := a.jobs.DeleteJob(in.Name); err != nil {
+		return &empty.Empty{}, status.Errorf(codes.Internal, "ERR_JOB_DELETE: %s", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// ListJobs returns the jobs of the app
+func (a *api) ListJobs(ctx context.Context, in *empty.Empty) (*daprv1pb.ListJobsResponseEnvelope, error) {
+	if a.jobs == nil {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_JOBS_NOT_CONFIGURED")
+	}
+
+	list, err := a.jobs.ListJobs()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "ERR_JOB_LIST: %s", err)
+	}
+	resp := &daprv1pb.ListJobsResponseEnvelope{}
+	for _, j := range list {
+		resp.Jobs = append(resp.Jobs, &daprv1pb.JobEnvelope{
+			Name:     j.Name,
+			Schedule: j.Schedule,
+			DueTime:  j.DueTime,
+			Data:     j.Data,
+			NextRun:  j.NextRun.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
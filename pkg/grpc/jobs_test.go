@@ -0,0 +1,113 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/jobs"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeScheduler struct {
+	jobs map[string]jobs.Job
+}
+
+func (f *fakeScheduler) ScheduleJob(job jobs.Job) error {
+	if job.Schedule == "" && job.DueTime == "" {
+		return jobs.InvalidJobError{Name: job.Name, Reason: "job schedule or due time is required"}
+	}
+	job.NextRun = time.Date(2020, 6, 10, 0, 0, 0, 0, time.UTC)
+	f.jobs[job.Name] = job
+	return nil
+}
+
+func (f *fakeScheduler) DeleteJob(name string) error {
+	delete(f.jobs, name)
+	return nil
+}
+
+func (f *fakeScheduler) ListJobs() ([]jobs.Job, error) {
+	list := []jobs.Job{}
+	for _, j := range f.jobs {
+		list = append(list, j)
+	}
+	return list, nil
+}
+
+func (f *fakeScheduler) Start(interval time.Duration) {}
+
+func (f *fakeScheduler) Stop() {}
+
+func startJobsServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprJobsServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+func TestJobs(t *testing.T) {
+	scheduler := &fakeScheduler{jobs: map[string]jobs.Job{}}
+	testAPI := &api{jobs: scheduler}
+	port, _ := freeport.GetFreePort()
+	server := startJobsServer(port, testAPI)
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("schedule job", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprJobsClient(clientConn).ScheduleJob(context.Background(), &daprv1pb.JobEnvelope{Name: "report", Schedule: "@daily", Data: []byte("sales")})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("sales"), scheduler.jobs["report"].Data)
+	})
+
+	t.Run("schedule invalid job", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprJobsClient(clientConn).ScheduleJob(context.Background(), &daprv1pb.JobEnvelope{Name: "invalid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("list jobs", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprJobsClient(clientConn).ListJobs(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Jobs, 1)
+		assert.Equal(t, "report", resp.Jobs[0].Name)
+		assert.Equal(t, "@daily", resp.Jobs[0].Schedule)
+		assert.Equal(t, "2020-06-10T00:00:00Z", resp.Jobs[0].NextRun)
+	})
+
+	t.Run("delete job", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprJobsClient(clientConn).DeleteJob(context.Background(), &daprv1pb.DeleteJobEnvelope{Name: "report"})
+		assert.NoError(t, err)
+		assert.Empty(t, scheduler.jobs)
+	})
+
+	t.Run("jobs not configured", func(t *testing.T) {
+		testAPI.jobs = nil
+		_, err := daprv1pb.NewDaprJobsClient(clientConn).ListJobs(context.Background(), &empty.Empty{})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,77 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// metadataServiceName is the name of the DaprMetadata service in the gRPC method names
+const metadataServiceName = "dapr.proto.dapr.v1.DaprMetadata"
+
+// GetMetadata returns the id of the app, how the sidecar reaches the app, the actor types hosted by the app, the
+// loaded components, the topic subscriptions and the custom attributes of the sidecar
+func (a *api) GetMetadata(ctx context.Context, in *empty.Empty) (*daprv1pb.GetMetadataResponse, error) {
+	resp := &daprv1pb.GetMetadataResponse{
+		Id:               a.id,
+		ExtendedMetadata: map[string]string{},
+	}
+	if a.app.Port > 0 {
+		resp.AppConnectionProperties = &daprv1pb.AppConnectionProperties{
+			Port:     int32(a.app.Port),
+			Protocol: a.app.Protocol,
+		}
+	}
+	if a.actor != nil {
+		for _, c := range a.actor.GetActiveActorsCount(ctx) {
+			resp.ActiveActorsCount = append(resp.ActiveActorsCount, &daprv1pb.ActiveActorsCount{Type: c.Type, Count: int32(c.Count)})
+		}
+	}
+	if a.componentStatusesFn != nil {
+		for _, s := range a.componentStatusesFn() {
+			resp.RegisteredComponents = append(resp.RegisteredComponents, &daprv1pb.RegisteredComponent{
+				Name:         s.Name,
+				Type:         s.Type,
+				Version:      s.Version,
+				Status:       s.Status,
+				Capabilities: s.Capabilities,
+			})
+		}
+	}
+	if a.subscriptionManager != nil {
+		for _, s := range a.subscriptionManager.Subscriptions() {
+			resp.Subscriptions = append(resp.Subscriptions, &daprv1pb.PubsubSubscription{
+				PubsubName: s.PubSubName,
+				Topic:      s.Topic,
+				Route:      s.Route,
+				Paused:     s.Paused,
+			})
+		}
+	}
+	if a.attributes != nil {
+		for _, attr := range a.attributes.List() {
+			resp.ExtendedMetadata[attr.Key] = attr.Value
+		}
+	}
+	return resp, nil
+}
+
+// SetMetadata sets a custom attribute of the sidecar, which is returned by the metadata APIs
+func (a *api) SetMetadata(ctx context.Context, in *daprv1pb.SetMetadataRequest) (*empty.Empty, error) {
+	if in.Key == "" {
+		return &empty.Empty{}, status.Error(codes.InvalidArgument, "ERR_METADATA_KEY_EMPTY")
+	}
+	if a.attributes == nil {
+		return &empty.Empty{}, status.Error(codes.Unimplemented, "ERR_METADATA_NOT_SUPPORTED")
+	}
+	a.attributes.Set(in.Key, in.Value)
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,77 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/components"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	runtime_metadata "github.com/dapr/dapr/pkg/runtime/metadata"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func startMetadataServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprMetadataServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+func TestMetadata(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	server := startMetadataServer(port, &api{
+		id:         "fakeAPI",
+		app:        runtime_metadata.App{Port: 3000, Protocol: "grpc"},
+		attributes: runtime_metadata.NewAttributes(),
+		componentStatusesFn: func() []components.Status {
+			return []components.Status{{Name: "statestore", Type: "state.redis", Version: "v1", Status: "Ready"}}
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("set and get metadata", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprMetadataClient(clientConn).SetMetadata(context.Background(), &daprv1pb.SetMetadataRequest{Key: "team", Value: "orders"})
+		assert.NoError(t, err)
+
+		resp, err := daprv1pb.NewDaprMetadataClient(clientConn).GetMetadata(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+		assert.Equal(t, "fakeAPI", resp.Id)
+		assert.Equal(t, map[string]string{"team": "orders"}, resp.ExtendedMetadata)
+		assert.Equal(t, int32(3000), resp.AppConnectionProperties.Port)
+		assert.Equal(t, "grpc", resp.AppConnectionProperties.Protocol)
+		assert.Len(t, resp.RegisteredComponents, 1)
+		assert.Equal(t, "statestore", resp.RegisteredComponents[0].Name)
+		assert.Equal(t, "v1", resp.RegisteredComponents[0].Version)
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprMetadataClient(clientConn).SetMetadata(context.Background(), &daprv1pb.SetMetadataRequest{Value: "orders"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
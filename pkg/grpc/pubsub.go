@@ -0,0 +1,83 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/google/uuid"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pubsubServiceName is the name of the DaprPubSub service in the gRPC method names
+const pubsubServiceName = "dapr.proto.dapr.v1.DaprPubSub"
+
+// PublishBulkEvent publishes the entries of the request to the topic of the pub/sub component of the pubsubName
+// metadata, and returns the publish status of each entry. A call some entries of which failed to be published
+// succeeds, the failed entries are the ones with the FAILED status.
+func (a *api) PublishBulkEvent(ctx context.Context, in *daprv1pb.BulkPublishRequestEnvelope) (*daprv1pb.BulkPublishResponseEnvelope, error) {
+	if a.bulkPublishFn == nil {
+		return &daprv1pb.BulkPublishResponseEnvelope{}, status.Error(codes.InvalidArgument, "ERR_PUBSUB_NOT_FOUND")
+	}
+
+	sc := diag.FromContext(ctx)
+	corID := sc.TraceID.String()
+
+	req := runtime_pubsub.BulkPublishRequest{
+		PubSubName: runtime_pubsub.GetPubSubName(getMetadataFromContext(ctx)),
+		Topic:      in.Topic,
+		Entries:    make([]runtime_pubsub.BulkPublishRequestEntry, 0, len(in.Entries)),
+	}
+	for _, e := range in.Entries {
+		if e.EntryId == "" {
+			return &daprv1pb.BulkPublishResponseEnvelope{}, status.Error(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: entry_id is required for every entry")
+		}
+
+		b, err := runtime_pubsub.NewCloudEvent(uuid.New().String(), a.id, corID, e.ContentType, e.Event, e.Metadata)
+		if err != nil {
+			return &daprv1pb.BulkPublishResponseEnvelope{}, fmt.Errorf("ERR_PUBSUB_CLOUD_EVENTS_SER: %s", err)
+		}
+		req.Entries = append(req.Entries, runtime_pubsub.BulkPublishRequestEntry{
+			EntryID: e.EntryId,
+			Data:    b,
+		})
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("PublishBulkEvent: %s", in.Topic)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	statuses, err := a.bulkPublishFn(&req)
+	if errors.As(err, &runtime_pubsub.NotAllowedError{}) {
+		return &daprv1pb.BulkPublishResponseEnvelope{}, status.Errorf(codes.PermissionDenied, "ERR_PUBSUB_FORBIDDEN: %s", err)
+	}
+	if errors.As(err, &runtime_pubsub.NotFoundError{}) {
+		return &daprv1pb.BulkPublishResponseEnvelope{}, status.Errorf(codes.InvalidArgument, "ERR_PUBSUB_NOT_FOUND: %s", err)
+	}
+	if err != nil {
+		return &daprv1pb.BulkPublishResponseEnvelope{}, fmt.Errorf("ERR_PUBSUB_PUBLISH_MESSAGE: %s", err)
+	}
+
+	resp := &daprv1pb.BulkPublishResponseEnvelope{
+		Statuses: make([]*daprv1pb.BulkPublishResponseEntry, 0, len(statuses)),
+	}
+	for _, s := range statuses {
+		resp.Statuses = append(resp.Statuses, &daprv1pb.BulkPublishResponseEntry{
+			EntryId: s.EntryID,
+			Status:  s.Status,
+			Error:   s.Error,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,117 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func startPubSubServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprPubSubServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+func TestPublishBulkEvent(t *testing.T) {
+	entries := []*daprv1pb.BulkPublishRequestEntry{
+		{EntryId: "1", Event: []byte(`{"a":1}`), ContentType: "application/json"},
+		{EntryId: "2", Event: []byte("hello")},
+	}
+
+	t.Run("statuses of the entries", func(t *testing.T) {
+		var received *runtime_pubsub.BulkPublishRequest
+		port, _ := freeport.GetFreePort()
+		server := startPubSubServer(port, &api{id: "app1", bulkPublishFn: func(req *runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error) {
+			received = req
+			return []runtime_pubsub.BulkPublishResponseEntry{
+				{EntryID: "1", Status: runtime_pubsub.BulkPublishSucceeded},
+				{EntryID: "2", Status: runtime_pubsub.BulkPublishFailed, Error: "failed"},
+			}, nil
+		}})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "metadata.pubsubName", "pubsub2")
+		resp, err := daprv1pb.NewDaprPubSubClient(clientConn).PublishBulkEvent(ctx, &daprv1pb.BulkPublishRequestEnvelope{Topic: "topic1", Entries: entries})
+		assert.NoError(t, err)
+		assert.Equal(t, "pubsub2", received.PubSubName)
+		assert.Equal(t, "topic1", received.Topic)
+		assert.Len(t, received.Entries, 2)
+		assert.Contains(t, string(received.Entries[0].Data), `"data":{"a":1}`)
+		assert.Len(t, resp.Statuses, 2)
+		assert.Equal(t, runtime_pubsub.BulkPublishSucceeded, resp.Statuses[0].Status)
+		assert.Equal(t, "failed", resp.Statuses[1].Error)
+	})
+
+	t.Run("entry without id", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startPubSubServer(port, &api{bulkPublishFn: func(req *runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error) {
+			return nil, nil
+		}})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		_, err := daprv1pb.NewDaprPubSubClient(clientConn).PublishBulkEvent(context.Background(), &daprv1pb.BulkPublishRequestEnvelope{
+			Topic:   "topic1",
+			Entries: []*daprv1pb.BulkPublishRequestEntry{{Event: []byte("hello")}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("topic not allowed", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startPubSubServer(port, &api{bulkPublishFn: func(req *runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error) {
+			return nil, runtime_pubsub.NotAllowedError{Topic: req.Topic, AppID: "app1"}
+		}})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		_, err := daprv1pb.NewDaprPubSubClient(clientConn).PublishBulkEvent(context.Background(), &daprv1pb.BulkPublishRequestEnvelope{Topic: "topic1", Entries: entries})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("no pub/sub", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startPubSubServer(port, &api{})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		_, err := daprv1pb.NewDaprPubSubClient(clientConn).PublishBulkEvent(context.Background(), &daprv1pb.BulkPublishRequestEnvelope{Topic: "topic1", Entries: entries})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,77 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dapr/dapr/pkg/audit"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	secrets_bulk "github.com/dapr/dapr/pkg/secretstores/bulk"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// secretsServiceName is the name of the DaprSecrets service in the gRPC method names
+const secretsServiceName = "dapr.proto.dapr.v1.DaprSecrets"
+
+// GetBulkSecret returns the secrets with the given keys, or all the secrets of the store when no keys are given.
+// The secrets denied to the app are omitted.
+func (a *api) GetBulkSecret(ctx context.Context, in *daprv1pb.GetBulkSecretEnvelope) (_ *daprv1pb.GetBulkSecretResponseEnvelope, err error) {
+	if a.compStore.SecretStoresLen() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_SECRET_STORE_NOT_CONFIGURED")
+	}
+
+	secretStoreName := in.StoreName
+
+	store, ok := a.compStore.GetSecretStore(secretStoreName)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_SECRET_STORE_NOT_FOUND: secret store name: %s", secretStoreName)
+	}
+	// the audit event records the secrets returned to the app, or the requested ones when the call fails
+	accessed := in.Keys
+	defer func() { a.recordAudit(ctx, audit.BulkGetSecret, secretStoreName, accessed, err) }()
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("BulkGetSecret: %s", secretStoreName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	secrets, err := secrets_bulk.Get(store, in.Keys, in.Metadata)
+	if errors.Is(err, secrets_bulk.ErrBulkGetNotSupported) {
+		return nil, status.Errorf(codes.Unimplemented, "ERR_SECRET_STORE_NOT_SUPPORTED: secret store %s: %s", secretStoreName, err)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "ERR_SECRET_GET: %s", err)
+	}
+
+	resp := &daprv1pb.GetBulkSecretResponseEnvelope{Data: map[string]*daprv1pb.SecretEnvelope{}}
+	accessed = make([]string, 0, len(secrets))
+	for name, secret := range secrets {
+		if a.isSecretAllowed(secretStoreName, name) {
+			resp.Data[name] = &daprv1pb.SecretEnvelope{Secrets: secret}
+			accessed = append(accessed, name)
+		}
+	}
+	sort.Strings(accessed)
+	return resp, nil
+}
+
+// isSecretAllowed returns whether the secret scope of the store allows the app to read the secret,
+// all the secrets of a store without a scope are allowed
+func (a *api) isSecretAllowed(storeName, key string) bool {
+	if a.secretScopesFn == nil {
+		return true
+	}
+	if scope, ok := a.secretScopesFn()[storeName]; ok {
+		return scope.IsSecretAllowed(key)
+	}
+	return true
+}
@@ -0,0 +1,138 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/config"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeSecretStore struct {
+	secrets map[string]map[string]string
+}
+
+func (f fakeSecretStore) Init(metadata secretstores.Metadata) error {
+	return nil
+}
+
+func (f fakeSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	return secretstores.GetSecretResponse{Data: f.secrets[req.Name]}, nil
+}
+
+func startSecretsServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprSecretsServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+func TestGetBulkSecret(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	server := startSecretsServer(port, &api{
+		compStore: newTestCompStore(nil, map[string]secretstores.SecretStore{
+			"store1": fakeSecretStore{secrets: map[string]map[string]string{
+				"db":    {"password": "1"},
+				"queue": {"key": "2"},
+				"token": {"token": "3"},
+			}},
+		}),
+		secretScopesFn: func() map[string]config.SecretsScope {
+			return map[string]config.SecretsScope{
+				"store1": {StoreName: "store1", DeniedSecrets: []string{"token"}},
+			}
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("secrets by name", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprSecretsClient(clientConn).GetBulkSecret(context.Background(), &daprv1pb.GetBulkSecretEnvelope{
+			StoreName: "store1",
+			Keys:      []string{"db", "queue", "unknown"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 2)
+		assert.Equal(t, map[string]string{"password": "1"}, resp.Data["db"].Secrets)
+		assert.Equal(t, map[string]string{"key": "2"}, resp.Data["queue"].Secrets)
+	})
+
+	t.Run("denied secrets are omitted", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprSecretsClient(clientConn).GetBulkSecret(context.Background(), &daprv1pb.GetBulkSecretEnvelope{
+			StoreName: "store1",
+			Keys:      []string{"db", "token"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 1)
+		assert.Equal(t, map[string]string{"password": "1"}, resp.Data["db"].Secrets)
+	})
+
+	t.Run("all secrets of a store which can't list them", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprSecretsClient(clientConn).GetBulkSecret(context.Background(), &daprv1pb.GetBulkSecretEnvelope{StoreName: "store1"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("secret store not found", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprSecretsClient(clientConn).GetBulkSecret(context.Background(), &daprv1pb.GetBulkSecretEnvelope{StoreName: "store2", Keys: []string{"db"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGetSecretWithScopes(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	server := startDaprAPIServer(port, &api{
+		compStore: newTestCompStore(nil, map[string]secretstores.SecretStore{
+			"store1": fakeSecretStore{secrets: map[string]map[string]string{
+				"db": {"password": "1"},
+			}},
+		}),
+		secretScopesFn: func() map[string]config.SecretsScope {
+			return map[string]config.SecretsScope{
+				"store1": {StoreName: "store1", AllowedSecrets: []string{"db"}},
+			}
+		},
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	client := daprv1pb.NewDaprClient(clientConn)
+
+	t.Run("allowed secret", func(t *testing.T) {
+		resp, err := client.GetSecret(context.Background(), &daprv1pb.GetSecretEnvelope{StoreName: "store1", Key: "db"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"password": "1"}, resp.Data)
+	})
+
+	t.Run("denied secret", func(t *testing.T) {
+		_, err := client.GetSecret(context.Background(), &daprv1pb.GetSecretEnvelope{StoreName: "store1", Key: "queue"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"os"
 	"sync"
 	"time"
 
@@ -31,23 +32,26 @@ const (
 	apiServer                      = "apiServer"
 	internalServer                 = "internalServer"
 	defaultMaxConnectionAgeSeconds = 30
+	// unixDomainSocketMode lets the app connect to the Unix domain socket of the server when it runs as another user
+	unixDomainSocketMode = 0666
 )
 
 // Server is an interface for the dapr gRPC server
 type Server interface {
 	StartNonBlocking() error
+	Shutdown(timeout time.Duration)
 }
 
 type server struct {
 	api                API
 	config             ServerConfig
+	apiSpec            config.APISpec
 	tracingSpec        config.TracingSpec
 	authenticator      auth.Authenticator
 	listener           net.Listener
 	srv                *grpc_go.Server
 	renewMutex         *sync.Mutex
 	signedCert         *auth.SignedCertificate
-	tlsCert            tls.Certificate
 	signedCertDuration time.Duration
 	kind               string
 	logger             logger.Logger
@@ -58,10 +62,11 @@ var apiServerLogger = logger.NewLogger("dapr.runtime.grpc.api")
 var internalServerLogger = logger.NewLogger("dapr.runtime.grpc.internal")
 
 // NewAPIServer returns a new user facing gRPC API server
-func NewAPIServer(api API, config ServerConfig, tracingSpec config.TracingSpec) Server {
+func NewAPIServer(api API, config ServerConfig, apiSpec config.APISpec, tracingSpec config.TracingSpec) Server {
 	return &server{
 		api:         api,
 		config:      config,
+		apiSpec:     apiSpec,
 		tracingSpec: tracingSpec,
 		kind:        apiServer,
 		logger:      apiServerLogger,
@@ -89,7 +94,7 @@ func getDefaultMaxAgeDuration() *time.Duration {
 
 // StartNonBlocking starts a new server in a goroutine
 func (s *server) StartNonBlocking() error {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%v", s.config.Port))
+	lis, err := s.listen()
 	if err != nil {
 		return err
 	}
@@ -103,8 +108,20 @@ func (s *server) StartNonBlocking() error {
 
 	if s.kind == internalServer {
 		internalv1pb.RegisterDaprInternalServer(server, s.api)
+		internalv1pb.RegisterDaprActorStreamingServer(server, s.api)
 	} else if s.kind == apiServer {
 		daprv1pb.RegisterDaprServer(server, s.api)
+		daprv1pb.RegisterDaprPubSubServer(server, s.api)
+		daprv1pb.RegisterDaprStreamingServer(server, s.api)
+		daprv1pb.RegisterDaprStateServer(server, s.api)
+		daprv1pb.RegisterDaprBindingsServer(server, s.api)
+		daprv1pb.RegisterDaprJobsServer(server, s.api)
+		daprv1pb.RegisterDaprSecretsServer(server, s.api)
+		daprv1pb.RegisterDaprConfigurationServer(server, s.api)
+		daprv1pb.RegisterDaprCryptoServer(server, s.api)
+		daprv1pb.RegisterDaprWorkflowsServer(server, s.api)
+		daprv1pb.RegisterDaprShutdownServer(server, s.api)
+		daprv1pb.RegisterDaprMetadataServer(server, s.api)
 	}
 	go func() {
 		if err := server.Serve(lis); err != nil {
@@ -114,6 +131,46 @@ func (s *server) StartNonBlocking() error {
 	return nil
 }
 
+// listen listens on the Unix domain socket of the server if it has one, or on its port
+func (s *server) listen() (net.Listener, error) {
+	if s.config.UnixDomainSocket == "" {
+		return net.Listen("tcp", fmt.Sprintf(":%v", s.config.Port))
+	}
+
+	if err := os.Remove(s.config.UnixDomainSocket); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error removing the stale socket %s: %s", s.config.UnixDomainSocket, err)
+	}
+	lis, err := net.Listen("unix", s.config.UnixDomainSocket)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(s.config.UnixDomainSocket, unixDomainSocketMode); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	return lis, nil
+}
+
+// Shutdown stops accepting calls and waits for the calls and streams in progress until the timeout, then closes
+// the remaining connections
+func (s *server) Shutdown(timeout time.Duration) {
+	if s.srv == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		s.logger.Warnf("timed out after %s waiting for the calls in progress, closing the connections", timeout)
+		s.srv.Stop()
+	}
+}
+
 func (s *server) generateWorkloadCert() error {
 	s.logger.Info("sending workload csr request to sentry")
 	signedCert, err := s.authenticator.CreateSignedWorkloadCert(s.config.AppID)
@@ -122,13 +179,11 @@ func (s *server) generateWorkloadCert() error {
 	}
 	s.logger.Info("certificate signed successfully")
 
-	tlsCert, err := tls.X509KeyPair(signedCert.WorkloadCert, signedCert.PrivateKeyPem)
-	if err != nil {
+	if _, err := tls.X509KeyPair(signedCert.WorkloadCert, signedCert.PrivateKeyPem); err != nil {
 		return fmt.Errorf("error creating x509 Key Pair: %s", err)
 	}
 
 	s.signedCert = signedCert
-	s.tlsCert = tlsCert
 	s.signedCertDuration = signedCert.Expiry.Sub(time.Now().UTC())
 	return nil
 }
@@ -146,9 +201,35 @@ func (s *server) getMiddlewareOptions() []grpc_go.ServerOption {
 		)
 	}
 
+	streamServerInterceptor := diag.SetTracingSpanContextGRPCMiddlewareStream(s.tracingSpec)
+	if s.kind == apiServer && (len(s.apiSpec.Allowed) > 0 || len(s.apiSpec.Denied) > 0) {
+		unaryServerInterceptor = grpc_middleware.ChainUnaryServer(
+			setAPIAccessUnaryServerInterceptor(s.apiSpec),
+			unaryServerInterceptor,
+		)
+		streamServerInterceptor = grpc_middleware.ChainStreamServer(
+			setAPIAccessStreamServerInterceptor(s.apiSpec),
+			streamServerInterceptor,
+		)
+	}
+
+	if s.kind == apiServer {
+		if token := auth.GetAPIToken(); token != "" {
+			s.logger.Info("enabled token authentication on gRPC server")
+			unaryServerInterceptor = grpc_middleware.ChainUnaryServer(
+				setAPITokenAuthUnaryServerInterceptor(token),
+				unaryServerInterceptor,
+			)
+			streamServerInterceptor = grpc_middleware.ChainStreamServer(
+				setAPITokenAuthStreamServerInterceptor(token),
+				streamServerInterceptor,
+			)
+		}
+	}
+
 	opts = append(
 		opts,
-		grpc_go.StreamInterceptor(diag.SetTracingSpanContextGRPCMiddlewareStream(s.tracingSpec)),
+		grpc_go.StreamInterceptor(streamServerInterceptor),
 		grpc_go.UnaryInterceptor(unaryServerInterceptor))
 
 	return opts
@@ -159,6 +240,12 @@ func (s *server) getGRPCServer() (*grpc_go.Server, error) {
 	if s.maxConnectionAge != nil {
 		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{MaxConnectionAge: *s.maxConnectionAge}))
 	}
+	if s.config.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc_go.MaxRecvMsgSize(s.config.MaxRecvMsgSize<<20))
+	}
+	if s.config.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc_go.MaxSendMsgSize(s.config.MaxSendMsgSize<<20))
+	}
 
 	if s.authenticator != nil {
 		err := s.generateWorkloadCert()
@@ -166,17 +253,18 @@ func (s *server) getGRPCServer() (*grpc_go.Server, error) {
 			return nil, err
 		}
 
+		// the handshakes get the rotated certificate of the authenticator, so the rotation doesn't restart the server
 		tlsConfig := tls.Config{
-			ClientCAs:  s.signedCert.TrustChain,
-			ClientAuth: tls.RequireAndVerifyClientCert,
-			GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
-				return &s.tlsCert, nil
-			},
+			ClientCAs:      s.signedCert.TrustChain,
+			ClientAuth:     tls.RequireAndVerifyClientCert,
+			GetCertificate: auth.NewWorkloadCertificates(s.authenticator).GetCertificate,
 		}
 		ta := credentials.NewTLS(&tlsConfig)
 
 		opts = append(opts, grpc_go.Creds(ta))
 		go s.startWorkloadCertRotation()
+	} else if s.kind == apiServer && s.config.TLSConfig != nil && s.config.UnixDomainSocket == "" {
+		opts = append(opts, grpc_go.Creds(credentials.NewTLS(s.config.TLSConfig)))
 	}
 
 	return grpc_go.NewServer(opts...), nil
@@ -191,13 +279,15 @@ func (s *server) startWorkloadCertRotation() {
 		s.renewMutex.Lock()
 		renew := shouldRenewCert(s.signedCert.Expiry, s.signedCertDuration)
 		if renew {
-			s.logger.Info("renewing certificate: requesting new cert and restarting gRPC server")
+			s.logger.Info("renewing certificate: requesting new cert, the connections in progress keep the current cert")
 
 			err := s.generateWorkloadCert()
 			if err != nil {
-				s.logger.Errorf("error starting server: %s", err)
+				s.logger.Errorf("error renewing certificate, retrying: %s", err)
+			} else {
+				s.logger.Infof("certificate renewed. new cert expires on: %s", s.signedCert.Expiry.String())
+				diag.DefaultMonitoring.MTLSWorkLoadCertRotationCompleted()
 			}
-			diag.DefaultMonitoring.MTLSWorkLoadCertRotationCompleted()
 		}
 		s.renewMutex.Unlock()
 	}
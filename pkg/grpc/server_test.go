@@ -1,13 +1,31 @@
 package grpc
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/dapr/dapr/pkg/config"
 	"github.com/dapr/dapr/pkg/logger"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 func TestCertRenewal(t *testing.T) {
@@ -60,3 +78,76 @@ func TestGetMiddlewareOptions(t *testing.T) {
 		assert.Equal(t, 2, len(serverOption))
 	})
 }
+
+func TestListenOnUnixDomainSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dapr-uds")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "dapr-grpc.socket")
+	// a stale socket left by a previous run is replaced
+	assert.NoError(t, ioutil.WriteFile(socket, nil, 0600))
+
+	s := &server{
+		config: ServerConfig{UnixDomainSocket: socket},
+	}
+	lis, err := s.listen()
+	assert.NoError(t, err)
+	defer lis.Close()
+	assert.Equal(t, "unix", lis.Addr().Network())
+
+	info, err := os.Stat(socket)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(unixDomainSocketMode), info.Mode().Perm())
+}
+
+func TestGetGRPCServerMaxMessageSize(t *testing.T) {
+	s := &server{
+		config: NewServerConfig("app", "localhost", 0, "", 16, 8, nil),
+		logger: logger.NewLogger("dapr.runtime.grpc.test"),
+	}
+	assert.Equal(t, 16, s.config.MaxRecvMsgSize)
+	assert.Equal(t, 8, s.config.MaxSendMsgSize)
+
+	srv, err := s.getGRPCServer()
+	assert.NoError(t, err)
+	assert.NotNil(t, srv)
+}
+
+func TestGetGRPCServerWithTLS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	s := &server{
+		config: NewServerConfig("app", "localhost", 0, "", 0, 0, &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{certDER}, PrivateKey: key}},
+		}),
+		kind:   apiServer,
+		logger: logger.NewLogger("dapr.runtime.grpc.test"),
+	}
+	srv, err := s.getGRPCServer()
+	assert.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// nolint:gosec
+	conn, err := grpc_go.DialContext(ctx, lis.Addr().String(), grpc_go.WithBlock(), grpc_go.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	// the call reaches the server over TLS, which has no service registered
+	_, err = daprv1pb.NewDaprMetadataClient(conn).GetMetadata(ctx, &empty.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,27 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// shutdownServiceName is the name of the DaprShutdown service in the gRPC method names
+const shutdownServiceName = "dapr.proto.dapr.v1.DaprShutdown"
+
+// Shutdown starts the graceful shutdown of the sidecar, so job-style apps can stop it once they are done.
+// The shutdown continues after the call returns.
+func (a *api) Shutdown(ctx context.Context, in *empty.Empty) (*empty.Empty, error) {
+	if a.shutdownFn == nil {
+		return &empty.Empty{}, status.Error(codes.Unimplemented, "ERR_SHUTDOWN_NOT_SUPPORTED")
+	}
+	a.shutdownFn()
+	return &empty.Empty{}, nil
+}
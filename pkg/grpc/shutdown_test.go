@@ -0,0 +1,67 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func startShutdownServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprShutdownServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+func TestShutdown(t *testing.T) {
+	t.Run("shutdown requested", func(t *testing.T) {
+		requested := 0
+		port, _ := freeport.GetFreePort()
+		server := startShutdownServer(port, &api{shutdownFn: func() { requested++ }})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		_, err := daprv1pb.NewDaprShutdownClient(clientConn).Shutdown(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, requested)
+	})
+
+	t.Run("shutdown not supported", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startShutdownServer(port, &api{})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		_, err := daprv1pb.NewDaprShutdownClient(clientConn).Shutdown(context.Background(), &empty.Empty{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
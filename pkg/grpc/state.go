@@ -0,0 +1,331 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/audit"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/dapr/dapr/pkg/state/bulk"
+	"github.com/dapr/dapr/pkg/state/etag"
+	"github.com/dapr/dapr/pkg/state/query"
+	"github.com/dapr/dapr/pkg/state/watch"
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stateServiceName is the name of the DaprState service in the gRPC method names
+const stateServiceName = "dapr.proto.dapr.v1.DaprState"
+
+// ExecuteStateTransaction applies the upsert and delete operations atomically on a transactional state store
+func (a *api) ExecuteStateTransaction(ctx context.Context, in *daprv1pb.ExecuteStateTransactionEnvelope) (_ *empty.Empty, err error) {
+	if a.compStore.StateStoresLen() == 0 {
+		return &empty.Empty{}, status.Error(codes.FailedPrecondition, "ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		return &empty.Empty{}, status.Errorf(codes.InvalidArgument, "ERR_STATE_STORE_NOT_FOUND: state store name: %s", storeName)
+	}
+
+	transactionalStore, ok := store.(state.TransactionalStore)
+	if !ok {
+		return &empty.Empty{}, status.Errorf(codes.Unimplemented, "ERR_STATE_STORE_NOT_SUPPORTED: state store %s doesn't support transactions", storeName)
+	}
+
+	operations := []state.TransactionalRequest{}
+	for _, o := range in.Operations {
+		if o.Request == nil {
+			return &empty.Empty{}, status.Error(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: request is required for every operation")
+		}
+
+		switch state.OperationType(o.OperationType) {
+		case state.Upsert:
+			operations = append(operations, state.TransactionalRequest{
+				Operation: state.Upsert,
+				Request:   a.getSetRequest(storeName, o.Request),
+			})
+		case state.Delete:
+			operations = append(operations, state.TransactionalRequest{
+				Operation: state.Delete,
+				Request:   a.getDeleteRequest(storeName, o.Request),
+			})
+		default:
+			return &empty.Empty{}, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: operation type %s not supported", o.OperationType)
+		}
+	}
+
+	if deleted := transactionDeletedKeys(in.Operations); len(deleted) > 0 {
+		defer func() { a.recordAudit(ctx, audit.TransactionDeleteState, storeName, deleted, err) }()
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("StateTransaction: %s", storeName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	if err = transactionalStore.Multi(operations); err != nil {
+		err = etag.CheckTransaction(store, err, operations)
+		if st := a.etagMismatchStatus(storeName, err); st != nil {
+			return &empty.Empty{}, st
+		}
+		return &empty.Empty{}, fmt.Errorf("ERR_STATE_TRANSACTION: %s", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// transactionDeletedKeys returns the keys deleted by the operations of a state transaction, as sent by the app
+func transactionDeletedKeys(operations []*daprv1pb.TransactionalStateOperation) []string {
+	var keys []string
+	for _, o := range operations {
+		if state.OperationType(o.OperationType) == state.Delete {
+			keys = append(keys, o.Request.Key)
+		}
+	}
+	return keys
+}
+
+// QueryState returns a page of the state entries of the app matching the query, on state stores supporting queries
+func (a *api) QueryState(ctx context.Context, in *daprv1pb.QueryStateEnvelope) (*daprv1pb.QueryStateResponseEnvelope, error) {
+	if a.compStore.StateStoresLen() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_STATE_STORE_NOT_FOUND: state store name: %s", storeName)
+	}
+
+	querier, ok := store.(query.Querier)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "ERR_STATE_STORE_NOT_SUPPORTED: state store %s doesn't support queries", storeName)
+	}
+
+	req := query.Request{
+		KeyPrefix: a.getModifiedStateKey(storeName, ""),
+		Metadata:  in.Metadata,
+	}
+	if err := json.Unmarshal([]byte(in.Query), &req.Query); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: %s", err)
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("QueryState: %s", storeName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	resp, err := querier.Query(&req)
+	if err != nil {
+		return nil, fmt.Errorf("ERR_STATE_QUERY: %s", err)
+	}
+
+	out := &daprv1pb.QueryStateResponseEnvelope{
+		Results: make([]*daprv1pb.QueryStateItem, 0, len(resp.Results)),
+		Token:   resp.Token,
+	}
+	for _, item := range resp.Results {
+		out.Results = append(out.Results, &daprv1pb.QueryStateItem{
+			Key:  strings.TrimPrefix(item.Key, req.KeyPrefix),
+			Data: item.Data,
+			Etag: item.ETag,
+		})
+	}
+	return out, nil
+}
+
+// GetBulkState gets the state of the keys with the requested parallelism. Keys which fail to be fetched
+// have an error in their item instead of failing the whole request.
+func (a *api) GetBulkState(ctx context.Context, in *daprv1pb.GetBulkStateEnvelope) (*daprv1pb.GetBulkStateResponseEnvelope, error) {
+	if a.compStore.StateStoresLen() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_STATE_STORE_NOT_FOUND: state store name: %s", storeName)
+	}
+
+	parallelism, err := bulk.GetParallelism(in.Metadata)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: %s", err)
+	}
+	if in.Parallelism > 0 {
+		parallelism = int(in.Parallelism)
+	}
+
+	consistency, err := a.getStateConsistency(storeName, "", in.Metadata)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: %s", err)
+	}
+
+	reqs := make([]state.GetRequest, 0, len(in.Keys))
+	for _, k := range in.Keys {
+		reqs = append(reqs, state.GetRequest{
+			Key:      a.getModifiedStateKey(storeName, k),
+			Metadata: in.Metadata,
+			Options: state.GetStateOption{
+				Consistency: consistency,
+			},
+		})
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("GetBulkState: %s", storeName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	responses := bulk.Get(store, reqs, parallelism)
+	out := &daprv1pb.GetBulkStateResponseEnvelope{Items: make([]*daprv1pb.BulkStateItem, 0, len(responses))}
+	for i, r := range responses {
+		out.Items = append(out.Items, &daprv1pb.BulkStateItem{
+			Key:   in.Keys[i],
+			Data:  r.Data,
+			Etag:  r.ETag,
+			Error: r.Error,
+		})
+	}
+	return out, nil
+}
+
+// SubscribeState sends the changes of the subscribed keys over the stream until the client disconnects.
+// State stores with a change feed are watched, other stores are polled.
+func (a *api) SubscribeState(in *daprv1pb.SubscribeStateEnvelope, stream daprv1pb.DaprState_SubscribeStateServer) error {
+	if a.compStore.StateStoresLen() == 0 {
+		return status.Error(codes.FailedPrecondition, "ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "ERR_STATE_STORE_NOT_FOUND: state store name: %s", storeName)
+	}
+
+	req := watch.Request{Metadata: in.Metadata}
+	for _, k := range in.Keys {
+		req.Keys = append(req.Keys, a.getModifiedStateKey(storeName, k))
+	}
+	for _, p := range in.Prefixes {
+		req.Prefixes = append(req.Prefixes, a.getModifiedStateKey(storeName, p))
+	}
+	if err := watch.Validate(store, &req); errors.Is(err, watch.ErrPrefixNotSupported) {
+		return status.Errorf(codes.Unimplemented, "ERR_STATE_STORE_NOT_SUPPORTED: state store %s: %s", storeName, err)
+	} else if err != nil {
+		return status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: %s", err)
+	}
+
+	keyPrefix := a.getModifiedStateKey(storeName, "")
+	err := watch.Subscribe(stream.Context(), store, &req, func(c watch.Change) error {
+		return stream.Send(&daprv1pb.StateChangeEnvelope{
+			Key:     strings.TrimPrefix(c.Key, keyPrefix),
+			Data:    c.Data,
+			Etag:    c.ETag,
+			Deleted: c.Deleted,
+		})
+	})
+	if err != nil && stream.Context().Err() == nil {
+		return status.Errorf(codes.Internal, "ERR_STATE_SUBSCRIBE: %s", err)
+	}
+	return nil
+}
+
+// DeleteBulkState deletes the keys with a single bulk delete of the state store
+func (a *api) DeleteBulkState(ctx context.Context, in *daprv1pb.DeleteBulkStateEnvelope) (_ *empty.Empty, err error) {
+	if a.compStore.StateStoresLen() == 0 {
+		return &empty.Empty{}, status.Error(codes.FailedPrecondition, "ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		return &empty.Empty{}, status.Errorf(codes.InvalidArgument, "ERR_STATE_STORE_NOT_FOUND: state store name: %s", storeName)
+	}
+
+	keys := make([]string, 0, len(in.Requests))
+	for _, r := range in.Requests {
+		keys = append(keys, r.Key)
+	}
+	defer func() { a.recordAudit(ctx, audit.BulkDeleteState, storeName, keys, err) }()
+
+	reqs := make([]state.DeleteRequest, 0, len(in.Requests))
+	for _, r := range in.Requests {
+		req := a.getDeleteRequest(storeName, r)
+		consistency, err := a.getStateConsistency(storeName, req.Options.Consistency, req.Metadata)
+		if err != nil {
+			return &empty.Empty{}, status.Errorf(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: key %s: %s", r.Key, err)
+		}
+		req.Options.Consistency = consistency
+		reqs = append(reqs, req)
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("DeleteBulkState: %s", storeName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	err = store.BulkDelete(reqs)
+	err = etag.CheckDelete(store, err, reqs)
+	if st := a.etagMismatchStatus(storeName, err); st != nil {
+		return &empty.Empty{}, st
+	}
+	if err != nil {
+		return &empty.Empty{}, status.Errorf(codes.Internal, "ERR_STATE_DELETE: %s", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// DeleteStateWithPrefix deletes the keys of the app starting with the prefix,
+// on state stores which allow prefix deletes in their component metadata
+func (a *api) DeleteStateWithPrefix(ctx context.Context, in *daprv1pb.DeleteStateWithPrefixEnvelope) (_ *daprv1pb.DeleteStateWithPrefixResponseEnvelope, err error) {
+	if a.compStore.StateStoresLen() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_STATE_STORE_NOT_CONFIGURED")
+	}
+
+	storeName := in.StoreName
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "ERR_STATE_STORE_NOT_FOUND: state store name: %s", storeName)
+	}
+	defer func() { a.recordAudit(ctx, audit.DeleteStateWithPrefix, storeName, []string{in.Prefix}, err) }()
+
+	if !a.compStore.IsStatePrefixDeleteAllowed(storeName) {
+		return nil, status.Errorf(codes.PermissionDenied, "ERR_STATE_PREFIX_DELETE_NOT_ALLOWED: state store %s does not allow prefix deletes, set %s to true in its metadata", storeName, bulk.AllowPrefixDeleteMetadataKey)
+	}
+	if in.Prefix == "" {
+		return nil, status.Error(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: prefix is required")
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("DeleteStateWithPrefix: %s", storeName)
+	_, span = diag.StartTracingClientSpanFromGRPCContext(ctx, spanName, a.tracingSpec)
+	defer span.End()
+
+	deleted, err := bulk.DeleteWithPrefix(store, a.getModifiedStateKey(storeName, in.Prefix))
+	if errors.Is(err, bulk.ErrPrefixDeleteNotSupported) {
+		return nil, status.Errorf(codes.Unimplemented, "ERR_STATE_STORE_NOT_SUPPORTED: state store %s: %s", storeName, err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "ERR_STATE_DELETE: %s", err)
+	}
+	return &daprv1pb.DeleteStateWithPrefixResponseEnvelope{Deleted: int32(deleted)}, nil
+}
@@ -0,0 +1,372 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/components-contrib/state"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	"github.com/dapr/dapr/pkg/state/query"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStateStore struct{}
+
+func (fakeStateStore) Init(metadata state.Metadata) error                    { return nil }
+func (fakeStateStore) Delete(req *state.DeleteRequest) error                 { return nil }
+func (fakeStateStore) BulkDelete(req []state.DeleteRequest) error            { return nil }
+func (fakeStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) { return nil, nil }
+func (fakeStateStore) Set(req *state.SetRequest) error                       { return nil }
+func (fakeStateStore) BulkSet(req []state.SetRequest) error                  { return nil }
+
+type fakeTransactionalStore struct {
+	fakeStateStore
+	operations []state.TransactionalRequest
+}
+
+func (f *fakeTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	f.operations = reqs
+	return nil
+}
+
+type fakeQuerierStore struct {
+	fakeStateStore
+	req *query.Request
+}
+
+func (f *fakeQuerierStore) Query(req *query.Request) (*query.Response, error) {
+	f.req = req
+	return &query.Response{
+		Results: []query.Item{{Key: "app1||key1", Data: []byte(`{"state":"CA"}`), ETag: "1"}},
+		Token:   "2",
+	}, nil
+}
+
+func startStateServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprStateServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+// newTestCompStore returns a component store holding the state stores and the secret stores
+func newTestCompStore(stateStores map[string]state.Store, secretStores map[string]secretstores.SecretStore) *compstore.ComponentStore {
+	s := compstore.New()
+	for name, store := range stateStores {
+		s.AddStateStore(name, store)
+	}
+	for name, store := range secretStores {
+		s.AddSecretStore(name, store)
+	}
+	return s
+}
+
+func TestExecuteStateTransaction(t *testing.T) {
+	transactionalStore := &fakeTransactionalStore{}
+	port, _ := freeport.GetFreePort()
+	server := startStateServer(port, &api{
+		compStore: newTestCompStore(map[string]state.Store{
+			"store1": transactionalStore,
+			"store2": fakeStateStore{},
+		}, nil),
+		id: "app1",
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("operations are applied in a transaction", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).ExecuteStateTransaction(context.Background(), &daprv1pb.ExecuteStateTransactionEnvelope{
+			StoreName: "store1",
+			Operations: []*daprv1pb.TransactionalStateOperation{
+				{OperationType: "upsert", Request: &daprv1pb.StateRequest{Key: "key1", Value: &any.Any{Value: []byte("value1")}}},
+				{OperationType: "delete", Request: &daprv1pb.StateRequest{Key: "key2", Etag: "1"}},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, transactionalStore.operations, 2)
+		assert.Equal(t, state.SetRequest{Key: "app1||key1", Value: []byte("value1")}, transactionalStore.operations[0].Request)
+		assert.Equal(t, state.DeleteRequest{Key: "app1||key2", ETag: "1"}, transactionalStore.operations[1].Request)
+	})
+
+	t.Run("store without transactions", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).ExecuteStateTransaction(context.Background(), &daprv1pb.ExecuteStateTransactionEnvelope{StoreName: "store2"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("unsupported operation", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).ExecuteStateTransaction(context.Background(), &daprv1pb.ExecuteStateTransactionEnvelope{
+			StoreName:  "store1",
+			Operations: []*daprv1pb.TransactionalStateOperation{{OperationType: "merge", Request: &daprv1pb.StateRequest{Key: "key1"}}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("store not found", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).ExecuteStateTransaction(context.Background(), &daprv1pb.ExecuteStateTransactionEnvelope{StoreName: "store3"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestQueryState(t *testing.T) {
+	querier := &fakeQuerierStore{}
+	port, _ := freeport.GetFreePort()
+	server := startStateServer(port, &api{
+		compStore: newTestCompStore(map[string]state.Store{
+			"store1": querier,
+			"store2": fakeStateStore{},
+		}, nil),
+		id: "app1",
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("query results", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprStateClient(clientConn).QueryState(context.Background(), &daprv1pb.QueryStateEnvelope{
+			StoreName: "store1",
+			Query:     `{"filter": {"EQ": {"state": "CA"}}, "sort": [{"key": "state"}]}`,
+			Metadata:  map[string]string{"partitionKey": "p1"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "2", resp.Token)
+		assert.Len(t, resp.Results, 1)
+		assert.Equal(t, "key1", resp.Results[0].Key)
+		assert.Equal(t, `{"state":"CA"}`, string(resp.Results[0].Data))
+		assert.Equal(t, "1", resp.Results[0].Etag)
+
+		assert.Equal(t, "app1||", querier.req.KeyPrefix)
+		assert.Equal(t, &query.EQ{Key: "state", Val: "CA"}, querier.req.Query.Filter)
+		assert.Equal(t, map[string]string{"partitionKey": "p1"}, querier.req.Metadata)
+	})
+
+	t.Run("malformed query", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).QueryState(context.Background(), &daprv1pb.QueryStateEnvelope{StoreName: "store1", Query: `{"filter": []}`})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("store without queries", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).QueryState(context.Background(), &daprv1pb.QueryStateEnvelope{StoreName: "store2", Query: `{}`})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+type fakeBulkStore struct {
+	fakeStateStore
+}
+
+func (fakeBulkStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	if req.Key == "app1||failing" {
+		return nil, errors.New("get failed")
+	}
+	return &state.GetResponse{Data: []byte(req.Key), ETag: "1"}, nil
+}
+
+func TestGetBulkState(t *testing.T) {
+	compStore := newTestCompStore(map[string]state.Store{"store1": fakeBulkStore{}}, nil)
+	compStore.SetStateConsistency("store1", []string{"eventual"})
+	port, _ := freeport.GetFreePort()
+	server := startStateServer(port, &api{
+		id:        "app1",
+		compStore: compStore,
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("keys are fetched in order", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprStateClient(clientConn).GetBulkState(context.Background(), &daprv1pb.GetBulkStateEnvelope{
+			StoreName:   "store1",
+			Keys:        []string{"key1", "failing", "key2"},
+			Parallelism: 2,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items, 3)
+		assert.Equal(t, "key1", resp.Items[0].Key)
+		assert.Equal(t, "app1||key1", string(resp.Items[0].Data))
+		assert.Equal(t, "failing", resp.Items[1].Key)
+		assert.Equal(t, "get failed", resp.Items[1].Error)
+		assert.Equal(t, "app1||key2", string(resp.Items[2].Data))
+	})
+
+	t.Run("unsupported consistency", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).GetBulkState(context.Background(), &daprv1pb.GetBulkStateEnvelope{
+			StoreName: "store1",
+			Keys:      []string{"key1"},
+			Metadata:  map[string]string{"consistency": "strong"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid parallelism", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).GetBulkState(context.Background(), &daprv1pb.GetBulkStateEnvelope{
+			StoreName: "store1",
+			Keys:      []string{"key1"},
+			Metadata:  map[string]string{"parallelism": "-1"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+type fakeChangingStore struct {
+	fakeStateStore
+	lock  sync.Mutex
+	value []byte
+	etag  int
+}
+
+func (f *fakeChangingStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if req.Key != "app1||key1" || f.value == nil {
+		return nil, nil
+	}
+	return &state.GetResponse{Data: f.value, ETag: strconv.Itoa(f.etag)}, nil
+}
+
+func (f *fakeChangingStore) Set(req *state.SetRequest) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.value = req.Value.([]byte)
+	f.etag++
+	return nil
+}
+
+func TestSubscribeState(t *testing.T) {
+	store := &fakeChangingStore{}
+	port, _ := freeport.GetFreePort()
+	server := startStateServer(port, &api{
+		compStore: newTestCompStore(map[string]state.Store{"store1": store}, nil),
+		id:        "app1",
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("changes are streamed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := daprv1pb.NewDaprStateClient(clientConn).SubscribeState(ctx, &daprv1pb.SubscribeStateEnvelope{
+			StoreName: "store1",
+			Keys:      []string{"key1"},
+			Metadata:  map[string]string{"pollInterval": "10ms"},
+		})
+		assert.NoError(t, err)
+
+		time.Sleep(50 * time.Millisecond)
+		store.Set(&state.SetRequest{Key: "app1||key1", Value: []byte("value1")})
+		change, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, "key1", change.Key)
+		assert.Equal(t, "value1", string(change.Data))
+		assert.Equal(t, "1", change.Etag)
+		assert.False(t, change.Deleted)
+	})
+
+	t.Run("prefixes are not supported", func(t *testing.T) {
+		stream, err := daprv1pb.NewDaprStateClient(clientConn).SubscribeState(context.Background(), &daprv1pb.SubscribeStateEnvelope{
+			StoreName: "store1",
+			Prefixes:  []string{"key"},
+		})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		stream, err := daprv1pb.NewDaprStateClient(clientConn).SubscribeState(context.Background(), &daprv1pb.SubscribeStateEnvelope{StoreName: "store1"})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestDeleteBulkState(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	server := startStateServer(port, &api{
+		compStore: newTestCompStore(map[string]state.Store{"store1": fakeStateStore{}}, nil),
+		id:        "app1",
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	_, err := daprv1pb.NewDaprStateClient(clientConn).DeleteBulkState(context.Background(), &daprv1pb.DeleteBulkStateEnvelope{
+		StoreName: "store1",
+		Requests:  []*daprv1pb.StateRequest{{Key: "key1"}, {Key: "key2"}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestDeleteStateWithPrefix(t *testing.T) {
+	compStore := newTestCompStore(map[string]state.Store{
+		"store1": &fakeQuerierStore{},
+		"store2": fakeStateStore{},
+		"store3": fakeStateStore{},
+	}, nil)
+	compStore.SetStatePrefixDelete("store1", true)
+	compStore.SetStatePrefixDelete("store2", true)
+	port, _ := freeport.GetFreePort()
+	server := startStateServer(port, &api{
+		id:        "app1",
+		compStore: compStore,
+	})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("keys are deleted", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprStateClient(clientConn).DeleteStateWithPrefix(context.Background(), &daprv1pb.DeleteStateWithPrefixEnvelope{StoreName: "store1", Prefix: "key"})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), resp.Deleted)
+	})
+
+	t.Run("store does not support prefix deletes", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).DeleteStateWithPrefix(context.Background(), &daprv1pb.DeleteStateWithPrefixEnvelope{StoreName: "store2", Prefix: "key"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("prefix deletes are not allowed", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).DeleteStateWithPrefix(context.Background(), &daprv1pb.DeleteStateWithPrefixEnvelope{StoreName: "store3", Prefix: "key"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("prefix is required", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprStateClient(clientConn).DeleteStateWithPrefix(context.Background(), &daprv1pb.DeleteStateWithPrefixEnvelope{StoreName: "store1"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
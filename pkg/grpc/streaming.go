@@ -0,0 +1,72 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/dapr/components-contrib/pubsub"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	daprclientv1pb "github.com/dapr/dapr/pkg/proto/daprclient/v1"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamingServiceName is the name of the DaprStreaming service in the gRPC method names
+const streamingServiceName = "dapr.proto.dapr.v1.DaprStreaming"
+
+// SubscribeTopicEvents sends the events of the topic over the stream until the client disconnects.
+// An event is acknowledged to the pub/sub component once it was sent over the stream.
+func (a *api) SubscribeTopicEvents(in *daprclientv1pb.TopicSubscriptionEnvelope, stream daprv1pb.DaprStreaming_SubscribeTopicEventsServer) error {
+	if a.subscribeStreamFn == nil {
+		return status.Error(codes.FailedPrecondition, "ERR_PUBSUB_NOT_FOUND")
+	}
+	if in.GetTopic() == "" {
+		return status.Error(codes.InvalidArgument, "ERR_TOPIC_EMPTY")
+	}
+
+	rawPayload := runtime_pubsub.IsRawPayload(in.GetMetadata())
+	var sendLock sync.Mutex
+	unsubscribe, err := a.subscribeStreamFn(in.GetTopic(), func(msg *pubsub.NewMessage) error {
+		envelope, err := topicEventEnvelope(msg, rawPayload)
+		if err != nil {
+			return err
+		}
+
+		// Messages of a topic may be delivered concurrently, while a stream must not be written concurrently
+		sendLock.Lock()
+		defer sendLock.Unlock()
+		return stream.Send(envelope)
+	})
+	if errors.As(err, &runtime_pubsub.NotAllowedError{}) {
+		return status.Errorf(codes.PermissionDenied, "ERR_PUBSUB_FORBIDDEN: %s", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "ERR_PUBSUB_SUBSCRIBE: %s", err)
+	}
+	defer unsubscribe()
+
+	<-stream.Context().Done()
+	return nil
+}
+
+func topicEventEnvelope(msg *pubsub.NewMessage, rawPayload bool) (*daprclientv1pb.CloudEventEnvelope, error) {
+	if rawPayload {
+		return &daprclientv1pb.CloudEventEnvelope{
+			Id:              uuid.New().String(),
+			DataContentType: runtime_pubsub.RawPayloadContentType(msg.Data),
+			Topic:           msg.Topic,
+			Data:            &any.Any{Value: msg.Data},
+		}, nil
+	}
+
+	envelope, _, err := runtime_pubsub.NewTopicEventEnvelope(msg.Topic, msg.Data)
+	return envelope, err
+}
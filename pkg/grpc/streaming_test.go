@@ -0,0 +1,120 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	daprclientv1pb "github.com/dapr/dapr/pkg/proto/daprclient/v1"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func startStreamingServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprStreamingServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+func TestSubscribeTopicEvents(t *testing.T) {
+	t.Run("events are sent over the stream", func(t *testing.T) {
+		handlers := make(chan func(msg *pubsub.NewMessage) error, 1)
+		unsubscribed := make(chan struct{})
+		port, _ := freeport.GetFreePort()
+		server := startStreamingServer(port, &api{
+			subscribeStreamFn: func(topic string, handler func(msg *pubsub.NewMessage) error) (func(), error) {
+				assert.Equal(t, "topic1", topic)
+				handlers <- handler
+				return func() { close(unsubscribed) }, nil
+			},
+		})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := daprv1pb.NewDaprStreamingClient(clientConn).SubscribeTopicEvents(ctx, &daprclientv1pb.TopicSubscriptionEnvelope{Topic: "topic1"})
+		assert.NoError(t, err)
+
+		handler := <-handlers
+		go func() {
+			assert.NoError(t, handler(&pubsub.NewMessage{
+				Topic: "topic1",
+				Data:  []byte(`{"specversion":"1.0","id":"event1","source":"app1","type":"com.dapr.event.sent","datacontenttype":"text/plain","data":"hello"}`),
+			}))
+		}()
+
+		envelope, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, "event1", envelope.Id)
+		assert.Equal(t, "topic1", envelope.Topic)
+
+		cancel()
+		select {
+		case <-unsubscribed:
+		case <-time.After(5 * time.Second):
+			t.Error("stream was not unsubscribed after the client disconnected")
+		}
+	})
+
+	t.Run("forbidden topic", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startStreamingServer(port, &api{
+			subscribeStreamFn: func(topic string, handler func(msg *pubsub.NewMessage) error) (func(), error) {
+				return nil, runtime_pubsub.NotAllowedError{Topic: topic, AppID: "app1"}
+			},
+		})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		stream, err := daprv1pb.NewDaprStreamingClient(clientConn).SubscribeTopicEvents(context.Background(), &daprclientv1pb.TopicSubscriptionEnvelope{Topic: "topic1"})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("empty topic", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startStreamingServer(port, &api{
+			subscribeStreamFn: func(topic string, handler func(msg *pubsub.NewMessage) error) (func(), error) {
+				return func() {}, nil
+			},
+		})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		stream, err := daprv1pb.NewDaprStreamingClient(clientConn).SubscribeTopicEvents(context.Background(), &daprclientv1pb.TopicSubscriptionEnvelope{})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
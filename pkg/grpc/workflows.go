@@ -0,0 +1,97 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/dapr/dapr/pkg/workflows"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workflowsServiceName is the name of the DaprWorkflows service in the gRPC method names
+const workflowsServiceName = "dapr.proto.dapr.v1.DaprWorkflows"
+
+// StartWorkflow starts an instance of a workflow of the app
+func (a *api) StartWorkflow(ctx context.Context, in *daprv1pb.StartWorkflowEnvelope) (*daprv1pb.WorkflowInstanceEnvelope, error) {
+	if a.workflows == nil {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_WORKFLOWS_NOT_CONFIGURED")
+	}
+	if len(in.Input) > 0 && !json.Valid(in.Input) {
+		return nil, status.Error(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: the input of workflows must be JSON")
+	}
+
+	if err := a.workflows.Start(ctx, in.WorkflowName, in.InstanceId, in.Input); err != nil {
+		return nil, workflowError("ERR_START_WORKFLOW", err)
+	}
+	return &daprv1pb.WorkflowInstanceEnvelope{WorkflowName: in.WorkflowName, InstanceId: in.InstanceId}, nil
+}
+
+// GetWorkflow returns an instance of a workflow of the app
+func (a *api) GetWorkflow(ctx context.Context, in *daprv1pb.WorkflowInstanceEnvelope) (*daprv1pb.GetWorkflowResponseEnvelope, error) {
+	if a.workflows == nil {
+		return nil, status.Error(codes.FailedPrecondition, "ERR_WORKFLOWS_NOT_CONFIGURED")
+	}
+
+	instance, err := a.workflows.Get(ctx, in.WorkflowName, in.InstanceId)
+	if err != nil {
+		return nil, workflowError("ERR_GET_WORKFLOW", err)
+	}
+	return &daprv1pb.GetWorkflowResponseEnvelope{
+		InstanceId:    instance.ID,
+		WorkflowName:  instance.WorkflowName,
+		RuntimeStatus: instance.Status,
+		Input:         instance.Input,
+		Output:        instance.Output,
+		Error:         instance.Error,
+		CustomStatus:  instance.CustomStatus,
+		CreatedAt:     instance.CreatedAt.Format(time.RFC3339),
+		LastUpdatedAt: instance.LastUpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// TerminateWorkflow terminates an instance of a workflow of the app
+func (a *api) TerminateWorkflow(ctx context.Context, in *daprv1pb.WorkflowInstanceEnvelope) (*empty.Empty, error) {
+	if a.workflows == nil {
+		return &empty.Empty{}, status.Error(codes.FailedPrecondition, "ERR_WORKFLOWS_NOT_CONFIGURED")
+	}
+
+	if err := a.workflows.Terminate(ctx, in.WorkflowName, in.InstanceId); err != nil {
+		return &empty.Empty{}, workflowError("ERR_TERMINATE_WORKFLOW", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// RaiseEventWorkflow raises an event of an instance of a workflow of the app
+func (a *api) RaiseEventWorkflow(ctx context.Context, in *daprv1pb.RaiseEventWorkflowEnvelope) (*empty.Empty, error) {
+	if a.workflows == nil {
+		return &empty.Empty{}, status.Error(codes.FailedPrecondition, "ERR_WORKFLOWS_NOT_CONFIGURED")
+	}
+	if len(in.EventData) > 0 && !json.Valid(in.EventData) {
+		return &empty.Empty{}, status.Error(codes.InvalidArgument, "ERR_MALFORMED_REQUEST: the data of workflow events must be JSON")
+	}
+
+	if err := a.workflows.RaiseEvent(ctx, in.WorkflowName, in.InstanceId, in.EventName, in.EventData); err != nil {
+		return &empty.Empty{}, workflowError("ERR_RAISE_EVENT_WORKFLOW", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func workflowError(errorCode string, err error) error {
+	switch err {
+	case workflows.ErrInstanceNotFound:
+		return status.Errorf(codes.NotFound, "ERR_WORKFLOW_INSTANCE_NOT_FOUND: %s", err)
+	case workflows.ErrInstanceExists:
+		return status.Errorf(codes.AlreadyExists, "ERR_WORKFLOW_INSTANCE_EXISTS: %s", err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %s", errorCode, err)
+	}
+}
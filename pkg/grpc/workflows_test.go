@@ -0,0 +1,160 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	daprv1pb "github.com/dapr/dapr/pkg/proto/dapr/v1"
+	"github.com/dapr/dapr/pkg/workflows"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/assert"
+	grpc_go "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeWorkflowEngine struct {
+	instances map[string]*workflows.Instance
+	events    []string
+}
+
+func (f *fakeWorkflowEngine) Start(ctx context.Context, workflowName, instanceID string, input json.RawMessage) error {
+	if workflowName == "broken" {
+		return errors.New("app unavailable")
+	}
+	if _, ok := f.instances[instanceID]; ok {
+		return workflows.ErrInstanceExists
+	}
+	now := time.Date(2020, 6, 10, 0, 0, 0, 0, time.UTC)
+	f.instances[instanceID] = &workflows.Instance{
+		ID:            instanceID,
+		WorkflowName:  workflowName,
+		Status:        workflows.StatusRunning,
+		Input:         input,
+		CreatedAt:     now,
+		LastUpdatedAt: now,
+	}
+	return nil
+}
+
+func (f *fakeWorkflowEngine) Get(ctx context.Context, workflowName, instanceID string) (*workflows.Instance, error) {
+	instance, ok := f.instances[instanceID]
+	if !ok {
+		return nil, workflows.ErrInstanceNotFound
+	}
+	return instance, nil
+}
+
+func (f *fakeWorkflowEngine) Terminate(ctx context.Context, workflowName, instanceID string) error {
+	instance, ok := f.instances[instanceID]
+	if !ok {
+		return workflows.ErrInstanceNotFound
+	}
+	instance.Status = workflows.StatusTerminated
+	return nil
+}
+
+func (f *fakeWorkflowEngine) RaiseEvent(ctx context.Context, workflowName, instanceID, eventName string, data json.RawMessage) error {
+	if _, ok := f.instances[instanceID]; !ok {
+		return workflows.ErrInstanceNotFound
+	}
+	f.events = append(f.events, eventName)
+	return nil
+}
+
+func startWorkflowsServer(port int, testAPIServer *api) *grpc_go.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc_go.NewServer()
+	go func() {
+		daprv1pb.RegisterDaprWorkflowsServer(server, testAPIServer)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
+func TestWorkflows(t *testing.T) {
+	engine := &fakeWorkflowEngine{instances: map[string]*workflows.Instance{}}
+	port, _ := freeport.GetFreePort()
+	server := startWorkflowsServer(port, &api{workflows: engine})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	t.Run("start workflow", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprWorkflowsClient(clientConn).StartWorkflow(context.Background(), &daprv1pb.StartWorkflowEnvelope{WorkflowName: "order", InstanceId: "o1", Input: []byte(`{"item":"book"}`)})
+		assert.NoError(t, err)
+		assert.Equal(t, "o1", resp.InstanceId)
+		assert.Equal(t, "order", resp.WorkflowName)
+	})
+
+	t.Run("start existing instance", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprWorkflowsClient(clientConn).StartWorkflow(context.Background(), &daprv1pb.StartWorkflowEnvelope{WorkflowName: "order", InstanceId: "o1"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("start with malformed input", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprWorkflowsClient(clientConn).StartWorkflow(context.Background(), &daprv1pb.StartWorkflowEnvelope{WorkflowName: "order", InstanceId: "o2", Input: []byte("not json")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("start with engine error", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprWorkflowsClient(clientConn).StartWorkflow(context.Background(), &daprv1pb.StartWorkflowEnvelope{WorkflowName: "broken", InstanceId: "b1"})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Contains(t, err.Error(), "ERR_START_WORKFLOW")
+	})
+
+	t.Run("get workflow", func(t *testing.T) {
+		resp, err := daprv1pb.NewDaprWorkflowsClient(clientConn).GetWorkflow(context.Background(), &daprv1pb.WorkflowInstanceEnvelope{WorkflowName: "order", InstanceId: "o1"})
+		assert.NoError(t, err)
+		assert.Equal(t, workflows.StatusRunning, resp.RuntimeStatus)
+		assert.Equal(t, `{"item":"book"}`, string(resp.Input))
+		assert.Equal(t, "2020-06-10T00:00:00Z", resp.CreatedAt)
+	})
+
+	t.Run("get missing workflow", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprWorkflowsClient(clientConn).GetWorkflow(context.Background(), &daprv1pb.WorkflowInstanceEnvelope{WorkflowName: "order", InstanceId: "missing"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("raise event", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprWorkflowsClient(clientConn).RaiseEventWorkflow(context.Background(), &daprv1pb.RaiseEventWorkflowEnvelope{WorkflowName: "order", InstanceId: "o1", EventName: "approved", EventData: []byte("true")})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"approved"}, engine.events)
+	})
+
+	t.Run("terminate workflow", func(t *testing.T) {
+		_, err := daprv1pb.NewDaprWorkflowsClient(clientConn).TerminateWorkflow(context.Background(), &daprv1pb.WorkflowInstanceEnvelope{WorkflowName: "order", InstanceId: "o1"})
+		assert.NoError(t, err)
+		assert.Equal(t, workflows.StatusTerminated, engine.instances["o1"].Status)
+	})
+}
+
+func TestWorkflowsNotConfigured(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+	server := startWorkflowsServer(port, &api{})
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	_, err := daprv1pb.NewDaprWorkflowsClient(clientConn).StartWorkflow(context.Background(), &daprv1pb.StartWorkflowEnvelope{WorkflowName: "order", InstanceId: "o1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -6,9 +6,15 @@
 package health
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
 	"net"
 	"net/http"
 	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 const (
@@ -28,36 +34,63 @@ type healthCheckOptions struct {
 	failureThreshold  int
 	interval          time.Duration
 	successStatusCode int
+	tlsConfig         *tls.Config
 }
 
 // StartEndpointHealthCheck starts a health check on the specified address with the given options.
 // It returns a channel that will emit true if the endpoint is healthy and false if the failure conditions
 // Have been met.
 func StartEndpointHealthCheck(endpointAddress string, opts ...Option) chan bool {
-	options := &healthCheckOptions{}
-	applyDefaults(options)
-
-	for _, o := range opts {
-		o(options)
+	options := newOptions(opts)
+	client := &http.Client{
+		Timeout: options.requestTimeout,
+		Transport: &http.Transport{
+			Dial: (&net.Dialer{
+				Timeout: options.requestTimeout,
+			}).Dial,
+			TLSClientConfig: options.tlsConfig,
+		},
 	}
+	return startHealthCheck(func(ctx context.Context) bool {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointAddress, nil)
+		if err != nil {
+			return false
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == options.successStatusCode
+	}, options)
+}
+
+// StartGRPCHealthCheck starts a health check of the server of the connection with the gRPC health checking
+// protocol. Like StartEndpointHealthCheck, it returns a channel that will emit true if the server is serving and
+// false if the failure conditions have been met. The success status code option is ignored.
+func StartGRPCHealthCheck(conn *grpc.ClientConn, opts ...Option) chan bool {
+	options := newOptions(opts)
+	client := grpc_health_v1.NewHealthClient(conn)
+	return startHealthCheck(func(ctx context.Context) bool {
+		resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		return err == nil && resp.Status == grpc_health_v1.HealthCheckResponse_SERVING
+	}, options)
+}
+
+// startHealthCheck runs the probe at the interval of the options once the initial delay is over
+func startHealthCheck(probe func(ctx context.Context) bool, options *healthCheckOptions) chan bool {
 	signalChan := make(chan bool, 1)
 
-	go func(ch chan<- bool, endpointAddress string, options *healthCheckOptions) {
+	go func(ch chan<- bool, options *healthCheckOptions) {
 		ticker := time.NewTicker(options.interval)
 		failureCount := 0
 		time.Sleep(options.initialDelay)
 
 		for range ticker.C {
-			client := &http.Client{
-				Timeout: options.requestTimeout,
-				Transport: &http.Transport{
-					Dial: (&net.Dialer{
-						Timeout: options.requestTimeout,
-					}).Dial,
-				},
-			}
-			resp, err := client.Get(endpointAddress)
-			if err != nil || resp.StatusCode != options.successStatusCode {
+			ctx, cancel := context.WithTimeout(context.Background(), options.requestTimeout)
+			healthy := probe(ctx)
+			cancel()
+			if !healthy {
 				failureCount++
 				if failureCount == options.failureThreshold {
 					ch <- false
@@ -66,14 +99,49 @@ func StartEndpointHealthCheck(endpointAddress string, opts ...Option) chan bool
 				ch <- true
 				failureCount = 0
 			}
-			if resp != nil && resp.Body != nil {
-				resp.Body.Close()
-			}
 		}
-	}(signalChan, endpointAddress, options)
+	}(signalChan, options)
 	return signalChan
 }
 
+// WaitForEndpoint probes the specified address until it responds with the success status code of the options, or
+// until the timeout expires. A zero timeout waits indefinitely. The initial delay and the failure threshold of the
+// options are ignored.
+func WaitForEndpoint(endpointAddress string, timeout time.Duration, opts ...Option) error {
+	options := newOptions(opts)
+	client := &http.Client{
+		Timeout: options.requestTimeout,
+	}
+	if options.tlsConfig != nil {
+		client.Transport = &http.Transport{TLSClientConfig: options.tlsConfig}
+	}
+	start := time.Now()
+	for {
+		resp, err := client.Get(endpointAddress)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == options.successStatusCode {
+				return nil
+			}
+			err = fmt.Errorf("status code %d", resp.StatusCode)
+		}
+		if timeout > 0 && time.Since(start) >= timeout {
+			return fmt.Errorf("%s not ready after %s: %s", endpointAddress, timeout, err)
+		}
+		time.Sleep(options.interval)
+	}
+}
+
+func newOptions(opts []Option) *healthCheckOptions {
+	options := &healthCheckOptions{}
+	applyDefaults(options)
+
+	for _, o := range opts {
+		o(options)
+	}
+	return options
+}
+
 func applyDefaults(o *healthCheckOptions) {
 	o.failureThreshold = failureThreshold
 	o.initialDelay = initialDelay
@@ -116,3 +184,10 @@ func WithInterval(interval time.Duration) Option {
 		o.interval = interval
 	}
 }
+
+// WithTLSConfig sets the TLS config of the requests to an HTTPS endpoint
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *healthCheckOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
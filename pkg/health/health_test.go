@@ -6,12 +6,18 @@
 package health
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	grpc_health "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func TestHealthCheck(t *testing.T) {
@@ -165,3 +171,79 @@ func TestResponses(t *testing.T) {
 		}
 	})
 }
+
+func TestGRPCHealthCheck(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	healthServer := grpc_health.NewServer()
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	ch := StartGRPCHealthCheck(conn, WithInitialDelay(0), WithInterval(time.Millisecond*10), WithFailureThreshold(1))
+	assert.True(t, <-ch)
+
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	for healthy := range ch {
+		if !healthy {
+			break
+		}
+	}
+
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	assert.True(t, <-ch)
+}
+
+func TestWaitForEndpoint(t *testing.T) {
+	t.Run("ready endpoint", func(t *testing.T) {
+		server := httptest.NewServer(&testServer{
+			statusCode: 200,
+		})
+		defer server.Close()
+
+		assert.NoError(t, WaitForEndpoint(server.URL, time.Second, WithInterval(time.Millisecond*10)))
+	})
+
+	t.Run("endpoint ready after a while", func(t *testing.T) {
+		ts := &testServer{
+			statusCode: 503,
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ts.numberOfCalls == 2 {
+				ts.statusCode = 200
+			}
+			ts.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		assert.NoError(t, WaitForEndpoint(server.URL, 0, WithInterval(time.Millisecond*10)))
+		assert.Equal(t, 3, ts.numberOfCalls)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server := httptest.NewServer(&testServer{
+			statusCode: 500,
+		})
+		defer server.Close()
+
+		err := WaitForEndpoint(server.URL, time.Millisecond*50, WithInterval(time.Millisecond*10))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "status code 500")
+	})
+
+	t.Run("TLS endpoint", func(t *testing.T) {
+		server := httptest.NewTLSServer(&testServer{
+			statusCode: 200,
+		})
+		defer server.Close()
+
+		tlsConfig := &tls.Config{RootCAs: x509.NewCertPool()}
+		tlsConfig.RootCAs.AddCert(server.Certificate())
+		assert.NoError(t, WaitForEndpoint(server.URL, time.Second, WithInterval(time.Millisecond*10), WithTLSConfig(tlsConfig)))
+	})
+}
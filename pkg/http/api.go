@@ -6,8 +6,16 @@
 package http
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,18 +26,40 @@ import (
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/actors"
+	"github.com/dapr/dapr/pkg/audit"
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/channel/http"
+	"github.com/dapr/dapr/pkg/components"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
 	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/configuration"
+	"github.com/dapr/dapr/pkg/crypto"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/jobs"
+	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/messaging"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/dapr/dapr/pkg/outbox"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	runtime_metadata "github.com/dapr/dapr/pkg/runtime/metadata"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	auth "github.com/dapr/dapr/pkg/runtime/security"
+	secrets_bulk "github.com/dapr/dapr/pkg/secretstores/bulk"
+	secrets_cache "github.com/dapr/dapr/pkg/secretstores/cache"
+	"github.com/dapr/dapr/pkg/state/bulk"
+	"github.com/dapr/dapr/pkg/state/consistency"
+	"github.com/dapr/dapr/pkg/state/etag"
+	"github.com/dapr/dapr/pkg/state/query"
+	"github.com/dapr/dapr/pkg/state/watch"
+	"github.com/dapr/dapr/pkg/workflows"
 	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/mitchellh/mapstructure"
 	"github.com/valyala/fasthttp"
 	fhttp "github.com/valyala/fasthttp"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // API returns a list of HTTP endpoints for Dapr
@@ -42,26 +72,88 @@ type api struct {
 	endpoints             []Endpoint
 	directMessaging       messaging.DirectMessaging
 	appChannel            channel.AppChannel
-	stateStores           map[string]state.Store
-	secretStores          map[string]secretstores.SecretStore
+	compStore             *compstore.ComponentStore
+	secretScopesFn        func() map[string]config.SecretsScope
 	json                  jsoniter.API
 	actor                 actors.Actors
-	publishFn             func(req *pubsub.PublishRequest) error
-	sendToOutputBindingFn func(name string, req *bindings.WriteRequest) error
+	publishFn             func(pubsubName string, req *pubsub.PublishRequest) error
+	bulkPublishFn         func(req *runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error)
+	outbox                outbox.Outbox
+	subscriptionManager   runtime_pubsub.SubscriptionManager
+	sendToOutputBindingFn func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error)
+	jobs                  jobs.Scheduler
+	workflows             workflows.Engine
+	componentStatusesFn   func() []components.Status
+	shutdownFn            func()
+	appHealthFn           func() string
+	adminToken            string
 	id                    string
-	extendedMetadata      sync.Map
+	app                   runtime_metadata.App
+	attributes            *runtime_metadata.Attributes
+	apiSpec               config.APISpec
+	auditLog              *audit.Logger
 	readyStatus           bool
 	tracingSpec           config.TracingSpec
 }
 
 type metadata struct {
-	ID                string                      `json:"id"`
-	ActiveActorsCount []actors.ActiveActorsCount  `json:"actors"`
-	Extended          map[interface{}]interface{} `json:"extended"`
+	ID                string                     `json:"id"`
+	ActiveActorsCount []actors.ActiveActorsCount `json:"actors"`
+	Extended          extendedMetadata           `json:"extended"`
+	Subscriptions     []subscriptionMetadata     `json:"subscriptions,omitempty"`
+	StateStores       []stateStoreMetadata       `json:"stateStores,omitempty"`
+	Components        []componentMetadata        `json:"components,omitempty"`
+	App               *appMetadata               `json:"app,omitempty"`
+}
+
+// extendedMetadata holds the custom attributes of the sidecar, serialized as a JSON object
+type extendedMetadata []runtime_metadata.Attribute
+
+func (m extendedMetadata) MarshalJSON() ([]byte, error) {
+	values := make(map[string]string, len(m))
+	for _, attr := range m {
+		values[attr.Key] = attr.Value
+	}
+	return json.Marshal(values)
+}
+
+// appMetadata describes how the sidecar reaches the app
+type appMetadata struct {
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// componentMetadata describes the initialization status of a component and the features it supports,
+// so apps can detect them instead of trying the operations
+type componentMetadata struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Version      string   `json:"version,omitempty"`
+	Status       string   `json:"status"`
+	Error        string   `json:"error,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// stateStoreMetadata describes how the keys of the app are saved in a state store,
+// which is needed to migrate the state when the key prefix strategy changes,
+// and the consistency modes supported by the store
+type stateStoreMetadata struct {
+	Name        string   `json:"name"`
+	KeyPrefix   string   `json:"keyPrefix"`
+	KeyFormat   string   `json:"keyFormat"`
+	Consistency []string `json:"consistency,omitempty"`
+}
+
+type subscriptionMetadata struct {
+	PubSubName string `json:"pubsubName"`
+	Topic      string `json:"topic"`
+	Route      string `json:"route,omitempty"`
+	Paused     bool   `json:"paused,omitempty"`
 }
 
 const (
 	apiVersionV1         = "v1.0"
+	apiVersionV1alpha1   = "v1.0-alpha1"
 	idParam              = "id"
 	methodParam          = "method"
 	topicParam           = "topic"
@@ -71,41 +163,132 @@ const (
 	stateKeyParam        = "key"
 	secretStoreNameParam = "secretStoreName"
 	secretNameParam      = "key"
+	secretNamesParam     = "keys"
 	nameParam            = "name"
 	consistencyParam     = "consistency"
 	retryIntervalParam   = "retryInterval"
 	retryPatternParam    = "retryPattern"
 	retryThresholdParam  = "retryThreshold"
 	concurrencyParam     = "concurrency"
+	stateKeysParam       = "keys"
+	statePrefixesParam   = "prefixes"
+	statePrefixParam     = "prefix"
+	limitParam           = "limit"
+	continuationParam    = "continuationToken"
 	daprSeparator        = "||"
+	// metadataPrefix is the prefix of the query parameters and headers holding metadata
+	metadataPrefix = "metadata."
+	// configurationKeysParam is the query parameter holding the comma separated configuration keys
+	configurationKeysParam = "keys"
+	// vaultNameParam, keyNameParam and algorithmParam name the key vault, key and key wrap algorithm of the crypto API
+	vaultNameParam = "vaultName"
+	keyNameParam   = "keyName"
+	algorithmParam = "algorithm"
+	// workflowNameParam, instanceIDParam and eventNameParam name the workflow, instance and event of the workflow API
+	workflowNameParam = "workflowName"
+	instanceIDParam   = "instanceID"
+	eventNameParam    = "eventName"
+	// eventStreamKeepAlive is the interval of the keep alive comments of server-sent events streams
+	eventStreamKeepAlive = 15 * time.Second
+	// adminTokenHeader is the header holding the token authenticating the requests to the admin endpoints
+	adminTokenHeader = "dapr-admin-token"
 )
 
+// AdminTokenEnvVar is the environment variable holding the token of the admin endpoints, such as the logging
+// endpoint. The admin endpoints are disabled when it isn't set.
+const AdminTokenEnvVar = "DAPR_ADMIN_TOKEN"
+
+// APIOpts are the dependencies and the settings of the Dapr HTTP API
+type APIOpts struct {
+	AppID                 string
+	AppChannel            channel.AppChannel
+	DirectMessaging       messaging.DirectMessaging
+	CompStore             *compstore.ComponentStore
+	SecretScopesFn        func() map[string]config.SecretsScope
+	PublishFn             func(pubsubName string, req *pubsub.PublishRequest) error
+	BulkPublishFn         func(*runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error)
+	StateOutbox           outbox.Outbox
+	SubscriptionManager   runtime_pubsub.SubscriptionManager
+	Actor                 actors.Actors
+	SendToOutputBindingFn func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error)
+	JobScheduler          jobs.Scheduler
+	WorkflowEngine        workflows.Engine
+	ComponentStatusesFn   func() []components.Status
+	ShutdownFn            func()
+	AppHealthFn           func() string
+	App                   runtime_metadata.App
+	Attributes            *runtime_metadata.Attributes
+	APISpec               config.APISpec
+	AuditLog              *audit.Logger
+	TracingSpec           config.TracingSpec
+}
+
 // NewAPI returns a new API
-func NewAPI(appID string, appChannel channel.AppChannel, directMessaging messaging.DirectMessaging, stateStores map[string]state.Store, secretStores map[string]secretstores.SecretStore, publishFn func(*pubsub.PublishRequest) error, actor actors.Actors, sendToOutputBindingFn func(name string, req *bindings.WriteRequest) error, tracingSpec config.TracingSpec) API {
+func NewAPI(opts APIOpts) API {
+	if opts.Attributes == nil {
+		opts.Attributes = runtime_metadata.NewAttributes()
+	}
 	api := &api{
-		appChannel:            appChannel,
-		directMessaging:       directMessaging,
-		stateStores:           stateStores,
-		secretStores:          secretStores,
+		appChannel:            opts.AppChannel,
+		directMessaging:       opts.DirectMessaging,
+		compStore:             opts.CompStore,
+		secretScopesFn:        opts.SecretScopesFn,
 		json:                  jsoniter.ConfigFastest,
-		actor:                 actor,
-		publishFn:             publishFn,
-		sendToOutputBindingFn: sendToOutputBindingFn,
-		id:                    appID,
-		tracingSpec:           tracingSpec,
-	}
-	api.endpoints = append(api.endpoints, api.constructStateEndpoints()...)
-	api.endpoints = append(api.endpoints, api.constructSecretEndpoints()...)
-	api.endpoints = append(api.endpoints, api.constructPubSubEndpoints()...)
-	api.endpoints = append(api.endpoints, api.constructActorEndpoints()...)
-	api.endpoints = append(api.endpoints, api.constructDirectMessagingEndpoints()...)
-	api.endpoints = append(api.endpoints, api.constructMetadataEndpoints()...)
-	api.endpoints = append(api.endpoints, api.constructBindingsEndpoints()...)
+		actor:                 opts.Actor,
+		publishFn:             opts.PublishFn,
+		bulkPublishFn:         opts.BulkPublishFn,
+		outbox:                opts.StateOutbox,
+		subscriptionManager:   opts.SubscriptionManager,
+		sendToOutputBindingFn: opts.SendToOutputBindingFn,
+		jobs:                  opts.JobScheduler,
+		workflows:             opts.WorkflowEngine,
+		componentStatusesFn:   opts.ComponentStatusesFn,
+		shutdownFn:            opts.ShutdownFn,
+		appHealthFn:           opts.AppHealthFn,
+		app:                   opts.App,
+		attributes:            opts.Attributes,
+		apiSpec:               opts.APISpec,
+		auditLog:              opts.AuditLog,
+		adminToken:            os.Getenv(AdminTokenEnvVar),
+		id:                    opts.AppID,
+		tracingSpec:           opts.TracingSpec,
+	}
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.StateAPI, api.constructStateEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.SecretsAPI, api.constructSecretEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.ConfigurationAPI, api.constructConfigurationEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.CryptoAPI, api.constructCryptoEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.PubSubAPI, api.constructPubSubEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.ActorsAPI, api.constructActorEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.InvokeAPI, api.constructDirectMessagingEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.MetadataAPI, api.constructMetadataEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.BindingsAPI, api.constructBindingsEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.JobsAPI, api.constructJobsEndpoints())...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.WorkflowsAPI, api.constructWorkflowEndpoints())...)
 	api.endpoints = append(api.endpoints, api.constructHealthzEndpoints()...)
+	api.endpoints = append(api.endpoints, api.allowedEndpoints(config.ShutdownAPI, api.constructShutdownEndpoints())...)
 
 	return api
 }
 
+// allowedEndpoints returns the endpoints of an API, which deny the requests when the configuration of the app
+// doesn't allow the API
+func (a *api) allowedEndpoints(name string, endpoints []Endpoint) []Endpoint {
+	if a.apiSpec.IsAPIAllowed(name) {
+		return endpoints
+	}
+	for i := range endpoints {
+		endpoints[i].Handler = onAPIDenied(name)
+	}
+	return endpoints
+}
+
+func onAPIDenied(name string) fasthttp.RequestHandler {
+	return func(reqCtx *fasthttp.RequestCtx) {
+		msg := NewErrorResponse("ERR_API_DENIED", fmt.Sprintf("the %s API is denied by the configuration of the app", name))
+		respondWithError(reqCtx, 403, msg)
+	}
+}
+
 // APIEndpoints returns the list of registered endpoints
 func (a *api) APIEndpoints() []Endpoint {
 	return a.endpoints
@@ -130,12 +313,54 @@ func (a *api) constructStateEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onPostState,
 		},
+		{
+			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
+			Route:   "state/{storeName}/bulk",
+			Version: apiVersionV1,
+			Handler: a.onBulkGetState,
+		},
 		{
 			Methods: []string{fhttp.MethodDelete},
 			Route:   "state/{storeName}/{key}",
 			Version: apiVersionV1,
 			Handler: a.onDeleteState,
 		},
+		{
+			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
+			Route:   "state/{storeName}/bulk/delete",
+			Version: apiVersionV1,
+			Handler: a.onBulkDeleteState,
+		},
+		{
+			Methods: []string{fhttp.MethodDelete},
+			Route:   "state/{storeName}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onDeleteStateWithPrefix,
+		},
+		{
+			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
+			Route:   "state/{storeName}/transaction",
+			Version: apiVersionV1,
+			Handler: a.onPostStateTransaction,
+		},
+		{
+			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
+			Route:   "state/{storeName}/transaction",
+			Version: apiVersionV1alpha1,
+			Handler: a.onPostStateTransaction,
+		},
+		{
+			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
+			Route:   "state/{storeName}/query",
+			Version: apiVersionV1alpha1,
+			Handler: a.onQueryState,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "state/{storeName}/subscribe",
+			Version: apiVersionV1alpha1,
+			Handler: a.onSubscribeState,
+		},
 	}
 }
 
@@ -147,6 +372,12 @@ func (a *api) constructSecretEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onGetSecret,
 		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "secrets/{secretStoreName}",
+			Version: apiVersionV1,
+			Handler: a.onBulkGetSecret,
+		},
 	}
 }
 
@@ -158,6 +389,24 @@ func (a *api) constructPubSubEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onPublish,
 		},
+		{
+			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
+			Route:   "publish/bulk/{topic:*}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onBulkPublish,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "subscriptions/{topic}/pause",
+			Version: apiVersionV1alpha1,
+			Handler: a.onPauseSubscription,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "subscriptions/{topic}/resume",
+			Version: apiVersionV1alpha1,
+			Handler: a.onResumeSubscription,
+		},
 	}
 }
 
@@ -172,6 +421,58 @@ func (a *api) constructBindingsEndpoints() []Endpoint {
 	}
 }
 
+func (a *api) constructJobsEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
+			Route:   "jobs/{name}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onScheduleJob,
+		},
+		{
+			Methods: []string{fhttp.MethodDelete},
+			Route:   "jobs/{name}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onDeleteJob,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "jobs",
+			Version: apiVersionV1alpha1,
+			Handler: a.onListJobs,
+		},
+	}
+}
+
+func (a *api) constructWorkflowEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "workflows/{workflowName}/{instanceID}/start",
+			Version: apiVersionV1alpha1,
+			Handler: a.onStartWorkflow,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "workflows/{workflowName}/{instanceID}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onGetWorkflow,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "workflows/{workflowName}/{instanceID}/terminate",
+			Version: apiVersionV1alpha1,
+			Handler: a.onTerminateWorkflow,
+		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "workflows/{workflowName}/{instanceID}/raiseEvent/{eventName}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onRaiseWorkflowEvent,
+		},
+	}
+}
+
 func (a *api) constructDirectMessagingEndpoints() []Endpoint {
 	return []Endpoint{
 		{
@@ -197,6 +498,12 @@ func (a *api) constructActorEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onDirectActorMessage,
 		},
+		{
+			Methods: []string{fhttp.MethodGet, fhttp.MethodPost, fhttp.MethodDelete, fhttp.MethodPut},
+			Route:   "actors/{actorType}/{actorId}/stream/{method}",
+			Version: apiVersionV1,
+			Handler: a.onDirectActorStreamMessage,
+		},
 		{
 			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
 			Route:   "actors/{actorType}/{actorId}/state/{key}",
@@ -245,6 +552,52 @@ func (a *api) constructActorEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onGetActorReminder,
 		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "actors/{actorType}/{actorId}/reminders",
+			Version: apiVersionV1,
+			Handler: a.onListActorReminders,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "reminders/{actorType}",
+			Version: apiVersionV1,
+			Handler: a.onListActorReminders,
+		},
+	}
+}
+
+func (a *api) constructConfigurationEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "configuration/{storeName}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onGetConfiguration,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "configuration/{storeName}/subscribe",
+			Version: apiVersionV1alpha1,
+			Handler: a.onSubscribeConfiguration,
+		},
+	}
+}
+
+func (a *api) constructCryptoEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
+			Route:   "crypto/{vaultName}/encrypt",
+			Version: apiVersionV1alpha1,
+			Handler: a.onEncrypt,
+		},
+		{
+			Methods: []string{fhttp.MethodPost, fhttp.MethodPut},
+			Route:   "crypto/{vaultName}/decrypt",
+			Version: apiVersionV1alpha1,
+			Handler: a.onDecrypt,
+		},
 	}
 }
 
@@ -262,6 +615,24 @@ func (a *api) constructMetadataEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onPutMetadata,
 		},
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "metadata/secrets/flush",
+			Version: apiVersionV1,
+			Handler: a.onFlushSecretCache,
+		},
+		{
+			Methods: []string{fhttp.MethodGet},
+			Route:   "logging",
+			Version: apiVersionV1,
+			Handler: a.onGetLogging,
+		},
+		{
+			Methods: []string{fhttp.MethodPut},
+			Route:   "logging",
+			Version: apiVersionV1,
+			Handler: a.onPutLogging,
+		},
 	}
 }
 
@@ -276,6 +647,29 @@ func (a *api) constructHealthzEndpoints() []Endpoint {
 	}
 }
 
+func (a *api) constructShutdownEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fhttp.MethodPost},
+			Route:   "shutdown",
+			Version: apiVersionV1,
+			Handler: a.onShutdown,
+		},
+	}
+}
+
+// onShutdown starts the graceful shutdown of the sidecar, so job-style apps can stop it once they are done.
+// The shutdown continues after the response is sent.
+func (a *api) onShutdown(reqCtx *fasthttp.RequestCtx) {
+	if a.shutdownFn == nil {
+		msg := NewErrorResponse("ERR_SHUTDOWN_NOT_SUPPORTED", "")
+		respondWithError(reqCtx, 501, msg)
+		return
+	}
+	a.shutdownFn()
+	respondEmpty(reqCtx, 204)
+}
+
 func (a *api) onOutputBindingMessage(reqCtx *fasthttp.RequestCtx) {
 	name := reqCtx.UserValue(nameParam).(string)
 	body := reqCtx.PostBody()
@@ -303,7 +697,7 @@ func (a *api) onOutputBindingMessage(reqCtx *fasthttp.RequestCtx) {
 	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
 	defer span.End()
 
-	err = a.sendToOutputBindingFn(name, &bindings.WriteRequest{
+	resp, err := a.sendToOutputBindingFn(name, &bindings.WriteRequest{
 		Metadata: req.Metadata,
 		Data:     b,
 	})
@@ -313,11 +707,196 @@ func (a *api) onOutputBindingMessage(reqCtx *fasthttp.RequestCtx) {
 		respondWithError(reqCtx, 500, msg)
 		return
 	}
-	respondEmpty(reqCtx, 200)
+	if resp == nil {
+		respondEmpty(reqCtx, 200)
+		return
+	}
+	// the metadata of the binding response is returned as metadata. prefixed headers
+	for k, v := range resp.Metadata {
+		reqCtx.Response.Header.Set(metadataPrefix+k, v)
+	}
+	respond(reqCtx, 200, resp.Data)
+}
+
+// onScheduleJob saves a job of the app, replacing the job with the same name
+func (a *api) onScheduleJob(reqCtx *fasthttp.RequestCtx) {
+	if a.jobs == nil {
+		msg := NewErrorResponse("ERR_JOBS_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	var req scheduleJobRequest
+	if err := a.json.Unmarshal(reqCtx.PostBody(), &req); err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("can't deserialize request: %s", err))
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	err := a.jobs.ScheduleJob(jobs.Job{
+		Name:     reqCtx.UserValue(nameParam).(string),
+		Schedule: req.Schedule,
+		DueTime:  req.DueTime,
+		Data:     req.Data,
+	})
+	if errors.As(err, &jobs.InvalidJobError{}) {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	} else if err != nil {
+		msg := NewErrorResponse("ERR_JOB_SCHEDULE", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	respondEmpty(reqCtx, 204)
+}
+
+// workflowsConfigured responds with an error when the workflow engine isn't running
+func (a *api) workflowsConfigured(reqCtx *fasthttp.RequestCtx) bool {
+	if a.workflows == nil {
+		msg := NewErrorResponse("ERR_WORKFLOWS_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return false
+	}
+	return true
+}
+
+// getWorkflowBody returns the JSON body of a workflow request, or responds with an error
+func getWorkflowBody(reqCtx *fasthttp.RequestCtx) (json.RawMessage, bool) {
+	body := reqCtx.PostBody()
+	if len(body) == 0 {
+		return nil, true
+	}
+	if !json.Valid(body) {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", "the body of workflow requests must be JSON")
+		respondWithError(reqCtx, 400, msg)
+		return nil, false
+	}
+	return append(json.RawMessage(nil), body...), true
+}
+
+// respondWithWorkflowError responds with the error of a workflow request
+func respondWithWorkflowError(reqCtx *fasthttp.RequestCtx, errorCode string, err error) {
+	switch err {
+	case workflows.ErrInstanceNotFound:
+		respondWithError(reqCtx, 404, NewErrorResponse("ERR_WORKFLOW_INSTANCE_NOT_FOUND", err.Error()))
+	case workflows.ErrInstanceExists:
+		respondWithError(reqCtx, 409, NewErrorResponse("ERR_WORKFLOW_INSTANCE_EXISTS", err.Error()))
+	default:
+		respondWithError(reqCtx, 500, NewErrorResponse(errorCode, err.Error()))
+	}
+}
+
+// onStartWorkflow starts an instance of the workflow with the request body as its input
+func (a *api) onStartWorkflow(reqCtx *fasthttp.RequestCtx) {
+	if !a.workflowsConfigured(reqCtx) {
+		return
+	}
+	input, ok := getWorkflowBody(reqCtx)
+	if !ok {
+		return
+	}
+
+	instanceID := reqCtx.UserValue(instanceIDParam).(string)
+	err := a.workflows.Start(reqCtx, reqCtx.UserValue(workflowNameParam).(string), instanceID, input)
+	if err != nil {
+		respondWithWorkflowError(reqCtx, "ERR_START_WORKFLOW", err)
+		return
+	}
+	b, _ := a.json.Marshal(&startWorkflowResponse{InstanceID: instanceID})
+	respondWithJSON(reqCtx, 202, b)
+}
+
+func (a *api) onGetWorkflow(reqCtx *fasthttp.RequestCtx) {
+	if !a.workflowsConfigured(reqCtx) {
+		return
+	}
+
+	instance, err := a.workflows.Get(reqCtx, reqCtx.UserValue(workflowNameParam).(string), reqCtx.UserValue(instanceIDParam).(string))
+	if err != nil {
+		respondWithWorkflowError(reqCtx, "ERR_GET_WORKFLOW", err)
+		return
+	}
+	b, _ := a.json.Marshal(instance)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onTerminateWorkflow(reqCtx *fasthttp.RequestCtx) {
+	if !a.workflowsConfigured(reqCtx) {
+		return
+	}
+
+	err := a.workflows.Terminate(reqCtx, reqCtx.UserValue(workflowNameParam).(string), reqCtx.UserValue(instanceIDParam).(string))
+	if err != nil {
+		respondWithWorkflowError(reqCtx, "ERR_TERMINATE_WORKFLOW", err)
+		return
+	}
+	respondEmpty(reqCtx, 202)
+}
+
+// onRaiseWorkflowEvent raises an event with the request body as its data
+func (a *api) onRaiseWorkflowEvent(reqCtx *fasthttp.RequestCtx) {
+	if !a.workflowsConfigured(reqCtx) {
+		return
+	}
+	data, ok := getWorkflowBody(reqCtx)
+	if !ok {
+		return
+	}
+
+	err := a.workflows.RaiseEvent(reqCtx, reqCtx.UserValue(workflowNameParam).(string), reqCtx.UserValue(instanceIDParam).(string),
+		reqCtx.UserValue(eventNameParam).(string), data)
+	if err != nil {
+		respondWithWorkflowError(reqCtx, "ERR_RAISE_EVENT_WORKFLOW", err)
+		return
+	}
+	respondEmpty(reqCtx, 202)
+}
+
+func (a *api) onDeleteJob(reqCtx *fasthttp.RequestCtx) {
+	if a.jobs == nil {
+		msg := NewErrorResponse("ERR_JOBS_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	if err := a.jobs.DeleteJob(reqCtx.UserValue(nameParam).(string)); err != nil {
+		msg := NewErrorResponse("ERR_JOB_DELETE", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	respondEmpty(reqCtx, 204)
+}
+
+func (a *api) onListJobs(reqCtx *fasthttp.RequestCtx) {
+	if a.jobs == nil {
+		msg := NewErrorResponse("ERR_JOBS_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	list, err := a.jobs.ListJobs()
+	if err != nil {
+		msg := NewErrorResponse("ERR_JOB_LIST", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	resp := make([]jobResponse, 0, len(list))
+	for _, j := range list {
+		resp = append(resp, jobResponse{
+			Name:     j.Name,
+			Schedule: j.Schedule,
+			DueTime:  j.DueTime,
+			Data:     j.Data,
+			NextRun:  j.NextRun,
+		})
+	}
+	b, _ := a.json.Marshal(resp)
+	respondWithJSON(reqCtx, 200, b)
 }
 
 func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if a.compStore.StateStoresLen() == 0 {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_CONFIGURED", "")
 		respondWithError(reqCtx, 400, msg)
 		return
@@ -325,7 +904,8 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 
 	storeName := reqCtx.UserValue(storeNameParam).(string)
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
 		respondWithError(reqCtx, 401, msg)
 		return
@@ -340,15 +920,22 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 	defer span.End()
 
 	key := reqCtx.UserValue(stateKeyParam).(string)
-	consistency := string(reqCtx.QueryArgs().Peek(consistencyParam))
+	metadata := getMetadataFromRequest(reqCtx)
+	consistency, err := a.getStateConsistency(storeName, string(reqCtx.QueryArgs().Peek(consistencyParam)), metadata)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
 	req := state.GetRequest{
-		Key: a.getModifiedStateKey(key),
+		Key:      a.getModifiedStateKey(storeName, key),
+		Metadata: metadata,
 		Options: state.GetStateOption{
 			Consistency: consistency,
 		},
 	}
 
-	resp, err := a.stateStores[storeName].Get(&req)
+	resp, err := store.Get(&req)
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_GET", err.Error())
 		respondWithError(reqCtx, 500, msg)
@@ -361,30 +948,120 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 	respondWithETaggedJSON(reqCtx, 200, resp.Data, resp.ETag)
 }
 
-func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
-		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
+func (a *api) onBulkGetState(reqCtx *fasthttp.RequestCtx) {
+	if a.compStore.StateStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_CONFIGURED", "")
 		respondWithError(reqCtx, 400, msg)
 		return
 	}
 
 	storeName := reqCtx.UserValue(storeNameParam).(string)
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
 		respondWithError(reqCtx, 401, msg)
 		return
 	}
 
-	key := reqCtx.UserValue(stateKeyParam).(string)
-	etag := string(reqCtx.Request.Header.Peek("If-Match"))
+	var req bulkGetRequest
+	err := a.json.Unmarshal(reqCtx.PostBody(), &req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
 
-	concurrency := string(reqCtx.QueryArgs().Peek(concurrencyParam))
-	consistency := string(reqCtx.QueryArgs().Peek(consistencyParam))
-	retryInterval := string(reqCtx.QueryArgs().Peek(retryIntervalParam))
-	retryPattern := string(reqCtx.QueryArgs().Peek(retryPatternParam))
-	retryThredhold := string(reqCtx.QueryArgs().Peek(retryThresholdParam))
-	iRetryInterval := 0
+	metadata := getMetadataFromRequest(reqCtx)
+	parallelism, err := bulk.GetParallelism(metadata)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	if req.Parallelism > 0 {
+		parallelism = req.Parallelism
+	}
+
+	consistency, err := a.getStateConsistency(storeName, "", metadata)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	reqs := make([]state.GetRequest, 0, len(req.Keys))
+	for _, k := range req.Keys {
+		reqs = append(reqs, state.GetRequest{
+			Key:      a.getModifiedStateKey(storeName, k),
+			Metadata: metadata,
+			Options: state.GetStateOption{
+				Consistency: consistency,
+			},
+		})
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("GetBulkState: %s", storeName)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	responses := bulk.Get(store, reqs, parallelism)
+	items := make([]bulkGetResponseItem, 0, len(responses))
+	for i, r := range responses {
+		item := bulkGetResponseItem{
+			Key:   req.Keys[i],
+			ETag:  r.ETag,
+			Error: r.Error,
+		}
+		if len(r.Data) > 0 {
+			if jsoniter.Valid(r.Data) {
+				item.Data = r.Data
+			} else {
+				item.Data, _ = a.json.Marshal(string(r.Data))
+			}
+		}
+		items = append(items, item)
+	}
+
+	b, _ := a.json.Marshal(items)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
+	if a.compStore.StateStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 401, msg)
+		return
+	}
+
+	key := reqCtx.UserValue(stateKeyParam).(string)
+	defer a.recordAudit(reqCtx, audit.DeleteState, storeName, []string{key})
+
+	metadata := getMetadataFromRequest(reqCtx)
+	concurrency := string(reqCtx.QueryArgs().Peek(concurrencyParam))
+	consistency, err := a.getStateConsistency(storeName, string(reqCtx.QueryArgs().Peek(consistencyParam)), metadata)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	retryInterval := string(reqCtx.QueryArgs().Peek(retryIntervalParam))
+	retryPattern := string(reqCtx.QueryArgs().Peek(retryPatternParam))
+	retryThredhold := string(reqCtx.QueryArgs().Peek(retryThresholdParam))
+	iRetryInterval := 0
 	iRetryThreshold := 0
 
 	if retryInterval != "" {
@@ -395,8 +1072,9 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	req := state.DeleteRequest{
-		Key:  a.getModifiedStateKey(key),
-		ETag: etag,
+		Key:      a.getModifiedStateKey(storeName, key),
+		ETag:     string(reqCtx.Request.Header.Peek("If-Match")),
+		Metadata: metadata,
 		Options: state.DeleteStateOption{
 			Concurrency: concurrency,
 			Consistency: consistency,
@@ -416,7 +1094,11 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
 	defer span.End()
 
-	err := a.stateStores[storeName].Delete(&req)
+	err = store.Delete(&req)
+	err = etag.Check(store, err, req.Key, req.ETag)
+	if a.respondWithETagMismatch(reqCtx, storeName, err) {
+		return
+	}
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_DELETE", fmt.Sprintf("failed deleting state with key %s: %s", key, err))
 		respondWithError(reqCtx, 500, msg)
@@ -425,8 +1107,124 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 	respondEmpty(reqCtx, 200)
 }
 
+func (a *api) onBulkDeleteState(reqCtx *fasthttp.RequestCtx) {
+	if a.compStore.StateStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 401, msg)
+		return
+	}
+
+	var keys []string
+	defer func() { a.recordAudit(reqCtx, audit.BulkDeleteState, storeName, keys) }()
+
+	reqs := []state.DeleteRequest{}
+	err := a.json.Unmarshal(reqCtx.PostBody(), &reqs)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	for i, r := range reqs {
+		keys = append(keys, r.Key)
+		reqs[i].Key = a.getModifiedStateKey(storeName, r.Key)
+		reqs[i].Options.Consistency, err = a.getStateConsistency(storeName, r.Options.Consistency, r.Metadata)
+		if err != nil {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("key %s: %s", r.Key, err))
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("DeleteBulkState: %s", storeName)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	err = store.BulkDelete(reqs)
+	err = etag.CheckDelete(store, err, reqs)
+	if a.respondWithETagMismatch(reqCtx, storeName, err) {
+		return
+	}
+	if err != nil {
+		msg := NewErrorResponse("ERR_STATE_DELETE", fmt.Sprintf("failed deleting state: %s", err))
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	respondEmpty(reqCtx, 200)
+}
+
+// onDeleteStateWithPrefix deletes the keys of the app starting with the prefix query parameter,
+// on state stores which allow prefix deletes in their component metadata
+func (a *api) onDeleteStateWithPrefix(reqCtx *fasthttp.RequestCtx) {
+	if a.compStore.StateStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 401, msg)
+		return
+	}
+
+	prefix := string(reqCtx.QueryArgs().Peek(statePrefixParam))
+	defer a.recordAudit(reqCtx, audit.DeleteStateWithPrefix, storeName, []string{prefix})
+
+	if !a.compStore.IsStatePrefixDeleteAllowed(storeName) {
+		msg := NewErrorResponse("ERR_STATE_PREFIX_DELETE_NOT_ALLOWED", fmt.Sprintf("state store %s does not allow prefix deletes, set %s to true in its metadata", storeName, bulk.AllowPrefixDeleteMetadataKey))
+		respondWithError(reqCtx, 403, msg)
+		return
+	}
+
+	if prefix == "" {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", "prefix is required")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("DeleteStateWithPrefix: %s", storeName)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	deleted, err := bulk.DeleteWithPrefix(store, a.getModifiedStateKey(storeName, prefix))
+	if errors.Is(err, bulk.ErrPrefixDeleteNotSupported) {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_SUPPORTED", fmt.Sprintf("state store %s: %s", storeName, err))
+		respondWithError(reqCtx, 501, msg)
+		return
+	}
+	if err != nil {
+		msg := NewErrorResponse("ERR_STATE_DELETE", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+
+	b, _ := a.json.Marshal(prefixDeleteResponse{Deleted: deleted})
+	respondWithJSON(reqCtx, 200, b)
+}
+
 func (a *api) onGetSecret(reqCtx *fasthttp.RequestCtx) {
-	if a.secretStores == nil || len(a.secretStores) == 0 {
+	if a.compStore.SecretStoresLen() == 0 {
 		msg := NewErrorResponse("ERR_SECRET_STORE_NOT_CONFIGURED", "")
 		respondWithError(reqCtx, 400, msg)
 		return
@@ -434,23 +1232,22 @@ func (a *api) onGetSecret(reqCtx *fasthttp.RequestCtx) {
 
 	secretStoreName := reqCtx.UserValue(secretStoreNameParam).(string)
 
-	if a.secretStores[secretStoreName] == nil {
+	store, ok := a.compStore.GetSecretStore(secretStoreName)
+	if !ok {
 		msg := NewErrorResponse("ERR_SECRET_STORE_NOT_FOUND", fmt.Sprintf("secret store name: %s", secretStoreName))
 		respondWithError(reqCtx, 401, msg)
 		return
 	}
 
-	metadata := map[string]string{}
-	const metadataPrefix string = "metadata."
-	reqCtx.QueryArgs().VisitAll(func(key []byte, value []byte) {
-		queryKey := string(key)
-		if strings.HasPrefix(queryKey, metadataPrefix) {
-			k := strings.TrimPrefix(queryKey, metadataPrefix)
-			metadata[k] = string(value)
-		}
-	})
-
 	key := reqCtx.UserValue(secretNameParam).(string)
+	defer a.recordAudit(reqCtx, audit.GetSecret, secretStoreName, []string{key})
+	if !a.isSecretAllowed(secretStoreName, key) {
+		msg := NewErrorResponse("ERR_PERMISSION_DENIED", fmt.Sprintf("access denied by policy to get %s from %s", key, secretStoreName))
+		respondWithError(reqCtx, 403, msg)
+		return
+	}
+
+	metadata := getMetadataFromRequest(reqCtx)
 	req := secretstores.GetSecretRequest{
 		Name:     key,
 		Metadata: metadata,
@@ -464,7 +1261,7 @@ func (a *api) onGetSecret(reqCtx *fasthttp.RequestCtx) {
 	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
 	defer span.End()
 
-	resp, err := a.secretStores[secretStoreName].GetSecret(req)
+	resp, err := store.GetSecret(req)
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_GET", err.Error())
 		respondWithError(reqCtx, 500, msg)
@@ -480,8 +1277,118 @@ func (a *api) onGetSecret(reqCtx *fasthttp.RequestCtx) {
 	respondWithJSON(reqCtx, 200, respBytes)
 }
 
+// onBulkGetSecret returns the secrets named by the comma separated keys query parameter,
+// or all the secrets of the store when no keys are given. The secrets denied to the app are omitted.
+func (a *api) onBulkGetSecret(reqCtx *fasthttp.RequestCtx) {
+	if a.compStore.SecretStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_SECRET_STORE_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	secretStoreName := reqCtx.UserValue(secretStoreNameParam).(string)
+
+	store, ok := a.compStore.GetSecretStore(secretStoreName)
+	if !ok {
+		msg := NewErrorResponse("ERR_SECRET_STORE_NOT_FOUND", fmt.Sprintf("secret store name: %s", secretStoreName))
+		respondWithError(reqCtx, 401, msg)
+		return
+	}
+
+	metadata := getMetadataFromRequest(reqCtx)
+	names := splitQueryArg(reqCtx, secretNamesParam)
+	// the audit event records the secrets returned to the app, or the requested ones when the request fails
+	accessed := names
+	defer func() { a.recordAudit(reqCtx, audit.BulkGetSecret, secretStoreName, accessed) }()
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("BulkGetSecret: %s", secretStoreName)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	secrets, err := secrets_bulk.Get(store, names, metadata)
+	if errors.Is(err, secrets_bulk.ErrBulkGetNotSupported) {
+		msg := NewErrorResponse("ERR_SECRET_STORE_NOT_SUPPORTED", fmt.Sprintf("secret store %s: %s", secretStoreName, err))
+		respondWithError(reqCtx, 501, msg)
+		return
+	} else if err != nil {
+		msg := NewErrorResponse("ERR_SECRET_GET", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	accessed = make([]string, 0, len(secrets))
+	for name := range secrets {
+		if !a.isSecretAllowed(secretStoreName, name) {
+			delete(secrets, name)
+		} else {
+			accessed = append(accessed, name)
+		}
+	}
+	sort.Strings(accessed)
+
+	respBytes, _ := a.json.Marshal(secrets)
+	respondWithJSON(reqCtx, 200, respBytes)
+}
+
+// recordAudit records the request on the keys of the store to the audit log, with the result of the response
+func (a *api) recordAudit(reqCtx *fasthttp.RequestCtx, operation, storeName string, keys []string) {
+	if a.auditLog == nil {
+		return
+	}
+
+	event := audit.Event{
+		Caller:    a.auditCaller(reqCtx),
+		Operation: operation,
+		Store:     storeName,
+		Keys:      keys,
+		Result:    audit.ResultSuccess,
+	}
+	if code := reqCtx.Response.StatusCode(); code >= 300 {
+		event.Result = audit.ResultFailure
+		if code == 403 {
+			event.Result = audit.ResultDenied
+		}
+		var errResp ErrorResponse
+		if err := a.json.Unmarshal(reqCtx.Response.Body(), &errResp); err == nil {
+			event.Error = errResp.ErrorCode
+			if errResp.Message != "" {
+				event.Error = fmt.Sprintf("%s: %s", errResp.ErrorCode, errResp.Message)
+			}
+		}
+	}
+	a.auditLog.Record(event)
+}
+
+// auditCaller returns the id of the app for the requests sent on the loopback interface or on a Unix domain socket,
+// which only the app reaches, or else the address of the caller
+func (a *api) auditCaller(reqCtx *fasthttp.RequestCtx) string {
+	if _, ok := reqCtx.RemoteAddr().(*net.UnixAddr); ok {
+		return a.id
+	}
+	ip := reqCtx.RemoteIP()
+	if ip.IsLoopback() {
+		return a.id
+	}
+	return ip.String()
+}
+
+// isSecretAllowed returns whether the secret scope of the store allows the app to read the secret,
+// all the secrets of a store without a scope are allowed
+func (a *api) isSecretAllowed(storeName, key string) bool {
+	if a.secretScopesFn == nil {
+		return true
+	}
+	if scope, ok := a.secretScopesFn()[storeName]; ok {
+		return scope.IsSecretAllowed(key)
+	}
+	return true
+}
+
 func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if a.compStore.StateStoresLen() == 0 {
 		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
 		respondWithError(reqCtx, 400, msg)
 		return
@@ -489,7 +1396,8 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 
 	storeName := reqCtx.UserValue(storeNameParam).(string)
 
-	if a.stateStores[storeName] == nil {
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
 		respondWithError(reqCtx, 401, msg)
 		return
@@ -504,7 +1412,13 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	for i, r := range reqs {
-		reqs[i].Key = a.getModifiedStateKey(r.Key)
+		reqs[i].Key = a.getModifiedStateKey(storeName, r.Key)
+		reqs[i].Options.Consistency, err = a.getStateConsistency(storeName, r.Options.Consistency, r.Metadata)
+		if err != nil {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("key %s: %s", r.Key, err))
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
 	}
 
 	var span *trace.Span
@@ -515,7 +1429,11 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
 	defer span.End()
 
-	err = a.stateStores[storeName].BulkSet(reqs)
+	err = store.BulkSet(reqs)
+	err = etag.CheckSet(store, err, reqs)
+	if a.respondWithETagMismatch(reqCtx, storeName, err) {
+		return
+	}
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_SAVE", err.Error())
 		respondWithError(reqCtx, 500, msg)
@@ -525,7 +1443,358 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 	respondEmpty(reqCtx, 201)
 }
 
-func (a *api) getModifiedStateKey(key string) string {
+func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
+	if a.compStore.StateStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 401, msg)
+		return
+	}
+
+	transactionalStore, ok := store.(state.TransactionalStore)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_SUPPORTED", fmt.Sprintf("state store %s doesn't support transactions", storeName))
+		respondWithError(reqCtx, 501, msg)
+		return
+	}
+
+	var req stateTransactionRequest
+	err := a.json.Unmarshal(reqCtx.PostBody(), &req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	operations, err := a.getStateTransactionOperations(storeName, req.Operations)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	if deleted := transactionDeletedKeys(req.Operations); len(deleted) > 0 {
+		defer a.recordAudit(reqCtx, audit.TransactionDeleteState, storeName, deleted)
+	}
+
+	if len(req.Outbox) > 0 && (a.publishFn == nil || a.outbox == nil) {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	corID := sc.TraceID.String()
+
+	events := make([]outbox.Event, 0, len(req.Outbox))
+	for _, e := range req.Outbox {
+		if e.Topic == "" {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", "topic is required for every outbox event")
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+
+		b := []byte(e.Data)
+		if !runtime_pubsub.IsRawPayload(e.Metadata) {
+			b, err = runtime_pubsub.NewCloudEvent(uuid.New().String(), a.id, corID, e.ContentType, e.Data, e.Metadata)
+			if err != nil {
+				msg := NewErrorResponse("ERR_PUBSUB_CLOUD_EVENTS_SER", err.Error())
+				respondWithError(reqCtx, 500, msg)
+				return
+			}
+		}
+		events = append(events, outbox.Event{
+			Topic: e.Topic,
+			Data:  b,
+		})
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("StateTransaction: %s", storeName)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	if len(events) > 0 {
+		err = a.outbox.Transact(storeName, operations, events)
+	} else {
+		err = transactionalStore.Multi(operations)
+	}
+	err = etag.CheckTransaction(store, err, operations)
+	if a.respondWithETagMismatch(reqCtx, storeName, err) {
+		return
+	}
+	if err != nil {
+		msg := NewErrorResponse("ERR_STATE_TRANSACTION", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+
+	respondEmpty(reqCtx, 201)
+}
+
+func (a *api) onQueryState(reqCtx *fasthttp.RequestCtx) {
+	if a.compStore.StateStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 401, msg)
+		return
+	}
+
+	querier, ok := store.(query.Querier)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_SUPPORTED", fmt.Sprintf("state store %s doesn't support queries", storeName))
+		respondWithError(reqCtx, 501, msg)
+		return
+	}
+
+	req := query.Request{
+		KeyPrefix: a.getModifiedStateKey(storeName, ""),
+		Metadata:  getMetadataFromRequest(reqCtx),
+	}
+	err := a.json.Unmarshal(reqCtx.PostBody(), &req.Query)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("QueryState: %s", storeName)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	resp, err := querier.Query(&req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_STATE_QUERY", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+
+	qresp := stateQueryResponse{
+		Results: make([]stateQueryItem, 0, len(resp.Results)),
+		Token:   resp.Token,
+	}
+	for _, item := range resp.Results {
+		qitem := stateQueryItem{
+			Key:  strings.TrimPrefix(item.Key, req.KeyPrefix),
+			ETag: item.ETag,
+		}
+		if jsoniter.Valid(item.Data) {
+			qitem.Data = item.Data
+		} else {
+			qitem.Data, _ = a.json.Marshal(string(item.Data))
+		}
+		qresp.Results = append(qresp.Results, qitem)
+	}
+
+	b, _ := a.json.Marshal(qresp)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+// getStateTransactionOperations converts the operations of a state transaction request to state store requests
+func (a *api) getStateTransactionOperations(storeName string, ops []stateTransactionOperation) ([]state.TransactionalRequest, error) {
+	requests := []state.TransactionalRequest{}
+	for _, o := range ops {
+		switch o.Operation {
+		case state.Upsert:
+			var upsert state.SetRequest
+			if err := mapstructure.Decode(o.Request, &upsert); err != nil {
+				return nil, err
+			}
+			upsert.Key = a.getModifiedStateKey(storeName, upsert.Key)
+			requests = append(requests, state.TransactionalRequest{
+				Operation: state.Upsert,
+				Request:   upsert,
+			})
+		case state.Delete:
+			var delete state.DeleteRequest
+			if err := mapstructure.Decode(o.Request, &delete); err != nil {
+				return nil, err
+			}
+			delete.Key = a.getModifiedStateKey(storeName, delete.Key)
+			requests = append(requests, state.TransactionalRequest{
+				Operation: state.Delete,
+				Request:   delete,
+			})
+		default:
+			return nil, fmt.Errorf("operation type %s not supported", o.Operation)
+		}
+	}
+	return requests, nil
+}
+
+// transactionDeletedKeys returns the keys deleted by the operations of a state transaction, as sent by the app
+func transactionDeletedKeys(ops []stateTransactionOperation) []string {
+	var keys []string
+	for _, o := range ops {
+		if o.Operation != state.Delete {
+			continue
+		}
+		var delete state.DeleteRequest
+		if err := mapstructure.Decode(o.Request, &delete); err == nil {
+			keys = append(keys, delete.Key)
+		}
+	}
+	return keys
+}
+
+// onSubscribeState streams the changes of the keys and key prefixes listed by the keys and prefixes
+// query parameters as server-sent events, until the client disconnects
+func (a *api) onSubscribeState(reqCtx *fasthttp.RequestCtx) {
+	if a.compStore.StateStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf("state store name: %s", storeName))
+		respondWithError(reqCtx, 401, msg)
+		return
+	}
+
+	req := watch.Request{Metadata: getMetadataFromRequest(reqCtx)}
+	for _, k := range splitQueryArg(reqCtx, stateKeysParam) {
+		req.Keys = append(req.Keys, a.getModifiedStateKey(storeName, k))
+	}
+	for _, p := range splitQueryArg(reqCtx, statePrefixesParam) {
+		req.Prefixes = append(req.Prefixes, a.getModifiedStateKey(storeName, p))
+	}
+	if err := watch.Validate(store, &req); errors.Is(err, watch.ErrPrefixNotSupported) {
+		msg := NewErrorResponse("ERR_STATE_STORE_NOT_SUPPORTED", fmt.Sprintf("state store %s: %s", storeName, err))
+		respondWithError(reqCtx, 501, msg)
+		return
+	} else if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	keyPrefix := a.getModifiedStateKey(storeName, "")
+	streamEvents(reqCtx, func(ctx context.Context, send func(event string, data []byte) error) {
+		err := watch.Subscribe(ctx, store, &req, func(c watch.Change) error {
+			event := stateChangeEvent{
+				Key:     strings.TrimPrefix(c.Key, keyPrefix),
+				ETag:    c.ETag,
+				Deleted: c.Deleted,
+			}
+			if len(c.Data) > 0 {
+				if jsoniter.Valid(c.Data) {
+					event.Data = c.Data
+				} else {
+					event.Data, _ = a.json.Marshal(string(c.Data))
+				}
+			}
+			b, _ := a.json.Marshal(event)
+			return send("change", b)
+		})
+		if err != nil && ctx.Err() == nil {
+			b, _ := a.json.Marshal(NewErrorResponse("ERR_STATE_SUBSCRIBE", err.Error()))
+			send("error", b)
+		}
+	})
+}
+
+// streamEvents responds with a stream of server-sent events, which subscribe sends until the client disconnects
+// or it returns. Keep alive comments are sent between the events, so that the disconnection of the client is
+// detected, which cancels the context of subscribe.
+func streamEvents(reqCtx *fasthttp.RequestCtx, subscribe func(ctx context.Context, send func(event string, data []byte) error)) {
+	reqCtx.Response.Header.SetContentType(eventStreamContentType)
+	reqCtx.Response.Header.Set("Cache-Control", "no-cache")
+	reqCtx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var lock sync.Mutex
+		write := func(event string) error {
+			lock.Lock()
+			defer lock.Unlock()
+			if _, err := w.WriteString(event); err != nil {
+				return err
+			}
+			return w.Flush()
+		}
+
+		go func() {
+			ticker := time.NewTicker(eventStreamKeepAlive)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := write(": keep-alive\n\n"); err != nil {
+						cancel()
+						return
+					}
+				}
+			}
+		}()
+
+		subscribe(ctx, func(event string, data []byte) error {
+			return write(fmt.Sprintf("event: %s\ndata: %s\n\n", event, data))
+		})
+	})
+}
+
+// splitQueryArg returns the comma separated values of a query parameter
+func splitQueryArg(reqCtx *fasthttp.RequestCtx, name string) []string {
+	var values []string
+	for _, v := range strings.Split(string(reqCtx.QueryArgs().Peek(name)), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// getStateConsistency resolves the consistency of a state request from its consistency option
+// or metadata, and checks that the state store supports it
+func (a *api) getStateConsistency(storeName, requested string, metadata map[string]string) (string, error) {
+	return consistency.Resolve(requested, metadata, a.compStore.GetStateConsistency(storeName))
+}
+
+// respondWithETagMismatch responds with a 409 and the current ETag of the key if err is an ETag mismatch
+func (a *api) respondWithETagMismatch(reqCtx *fasthttp.RequestCtx, storeName string, err error) bool {
+	var mismatch *etag.MismatchError
+	if !errors.As(err, &mismatch) {
+		return false
+	}
+
+	key := strings.TrimPrefix(mismatch.Key, a.getModifiedStateKey(storeName, ""))
+	msg := NewErrorResponse("ERR_STATE_ETAG_MISMATCH", fmt.Sprintf("etag mismatch for key %s", key))
+	respondWithError(reqCtx, 409, msg)
+	reqCtx.Response.Header.Set(etagHeader, mismatch.CurrentETag)
+	return true
+}
+
+func (a *api) getModifiedStateKey(storeName, key string) string {
+	if prefix, ok := a.compStore.GetStateKeyPrefix(storeName); ok {
+		return prefix.Key(key)
+	}
 	if a.id != "" {
 		return fmt.Sprintf("%s%s%s", a.id, daprSeparator, key)
 	}
@@ -560,8 +1829,12 @@ func (a *api) onDirectMessage(reqCtx *fasthttp.RequestCtx) {
 	resp, err := a.directMessaging.Invoke(ctx, targetID, req)
 	// err does not represent user application response
 	if err != nil {
+		statusCode := fhttp.StatusInternalServerError
+		if status.Code(err) == codes.ResourceExhausted {
+			statusCode = invokev1.HTTPStatusFromCode(codes.ResourceExhausted)
+		}
 		msg := NewErrorResponse("ERR_DIRECT_INVOKE", err.Error())
-		respondWithError(reqCtx, fhttp.StatusInternalServerError, msg)
+		respondWithError(reqCtx, statusCode, msg)
 		return
 	}
 
@@ -757,6 +2030,49 @@ func (a *api) onGetActorReminder(reqCtx *fasthttp.RequestCtx) {
 	}
 }
 
+// onListActorReminders lists the reminders of an actor, or of an actor type when the route has no actor ID
+func (a *api) onListActorReminders(reqCtx *fasthttp.RequestCtx) {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	req := actors.ListRemindersRequest{
+		ActorType:         reqCtx.UserValue(actorTypeParam).(string),
+		ContinuationToken: string(reqCtx.QueryArgs().Peek(continuationParam)),
+	}
+	if actorID, ok := reqCtx.UserValue(actorIDParam).(string); ok {
+		req.ActorID = actorID
+	}
+	if limit := string(reqCtx.QueryArgs().Peek(limitParam)); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 0 {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("invalid limit: %s", limit))
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+		req.Limit = l
+	}
+
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+
+	resp, err := a.actor.ListReminders(ctx, &req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_REMINDER_LIST", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	b, err := a.json.Marshal(resp)
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_REMINDER_LIST", err.Error())
+		respondWithError(reqCtx, 500, msg)
+	} else {
+		respondWithJSON(reqCtx, 200, b)
+	}
+}
+
 func (a *api) onDeleteActorTimer(reqCtx *fasthttp.RequestCtx) {
 	if a.actor == nil {
 		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
@@ -777,22 +2093,72 @@ func (a *api) onDeleteActorTimer(reqCtx *fasthttp.RequestCtx) {
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
 	ctx := diag.NewContext((context.Context)(reqCtx), sc)
 
-	err := a.actor.DeleteTimer(ctx, &req)
-	if err != nil {
-		msg := NewErrorResponse("ERR_ACTOR_TIMER_DELETE", err.Error())
-		respondWithError(reqCtx, 500, msg)
-	} else {
-		respondEmpty(reqCtx, 200)
+	err := a.actor.DeleteTimer(ctx, &req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_TIMER_DELETE", err.Error())
+		respondWithError(reqCtx, 500, msg)
+	} else {
+		respondEmpty(reqCtx, 200)
+	}
+}
+
+func (a *api) onDirectActorMessage(reqCtx *fasthttp.RequestCtx) {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
+		respondWithError(reqCtx, fhttp.StatusBadRequest, msg)
+		return
+	}
+
+	ctx, req := a.directActorRequest(reqCtx)
+	resp, err := a.actor.Call(ctx, req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_INVOKE_METHOD", err.Error())
+		respondWithError(reqCtx, fhttp.StatusInternalServerError, msg)
+		return
+	}
+
+	// TODO: add trace parent and state
+	invokev1.InternalMetadataToHTTPHeader(resp.Headers(), reqCtx.Response.Header.Set)
+	contentType, body := resp.RawData()
+	reqCtx.Response.Header.SetContentType(contentType)
+
+	// Construct response
+	statusCode := int(resp.Status().Code)
+	if !resp.IsHTTPResponse() {
+		statusCode = invokev1.HTTPStatusFromCode(codes.Code(statusCode))
 	}
+	respond(reqCtx, statusCode, body)
 }
 
-func (a *api) onDirectActorMessage(reqCtx *fasthttp.RequestCtx) {
+// onDirectActorStreamMessage invokes an actor method and streams its response body with chunked encoding
+func (a *api) onDirectActorStreamMessage(reqCtx *fasthttp.RequestCtx) {
 	if a.actor == nil {
 		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", "")
 		respondWithError(reqCtx, fhttp.StatusBadRequest, msg)
 		return
 	}
 
+	ctx, req := a.directActorRequest(reqCtx)
+	resp, body, err := a.actor.CallStream(ctx, req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_INVOKE_METHOD", err.Error())
+		respondWithError(reqCtx, fhttp.StatusInternalServerError, msg)
+		return
+	}
+
+	invokev1.InternalMetadataToHTTPHeader(resp.Headers(), reqCtx.Response.Header.Set)
+	reqCtx.Response.Header.SetContentType(resp.Message().GetContentType())
+	statusCode := int(resp.Status().Code)
+	if !resp.IsHTTPResponse() {
+		statusCode = invokev1.HTTPStatusFromCode(codes.Code(statusCode))
+	}
+	reqCtx.Response.SetStatusCode(statusCode)
+	// the body is closed, ending the actor turn, once it has been written
+	reqCtx.Response.SetBodyStream(body, -1)
+}
+
+// directActorRequest returns the actor method invocation of the request along with its tracing context
+func (a *api) directActorRequest(reqCtx *fasthttp.RequestCtx) (context.Context, *invokev1.InvokeMethodRequest) {
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	verb := strings.ToUpper(string(reqCtx.Method()))
@@ -812,26 +2178,7 @@ func (a *api) onDirectActorMessage(reqCtx *fasthttp.RequestCtx) {
 	req.WithMetadata(metadata)
 
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
-	ctx := diag.NewContext((context.Context)(reqCtx), sc)
-
-	resp, err := a.actor.Call(ctx, req)
-	if err != nil {
-		msg := NewErrorResponse("ERR_ACTOR_INVOKE_METHOD", err.Error())
-		respondWithError(reqCtx, fhttp.StatusInternalServerError, msg)
-		return
-	}
-
-	// TODO: add trace parent and state
-	invokev1.InternalMetadataToHTTPHeader(resp.Headers(), reqCtx.Response.Header.Set)
-	contentType, body := resp.RawData()
-	reqCtx.Response.Header.SetContentType(contentType)
-
-	// Construct response
-	statusCode := int(resp.Status().Code)
-	if !resp.IsHTTPResponse() {
-		statusCode = invokev1.HTTPStatusFromCode(codes.Code(statusCode))
-	}
-	respond(reqCtx, statusCode, body)
+	return diag.NewContext((context.Context)(reqCtx), sc), req
 }
 
 func (a *api) onSaveActorState(reqCtx *fasthttp.RequestCtx) {
@@ -956,13 +2303,10 @@ func (a *api) onDeleteActorState(reqCtx *fasthttp.RequestCtx) {
 }
 
 func (a *api) onGetMetadata(reqCtx *fasthttp.RequestCtx) {
-	temp := make(map[interface{}]interface{})
-
-	// Copy synchronously so it can be serialized to JSON.
-	a.extendedMetadata.Range(func(key, value interface{}) bool {
-		temp[key] = value
-		return true
-	})
+	temp := extendedMetadata{}
+	if a.attributes != nil {
+		temp = a.attributes.List()
+	}
 
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
 	ctx := diag.NewContext((context.Context)(reqCtx), sc)
@@ -972,6 +2316,49 @@ func (a *api) onGetMetadata(reqCtx *fasthttp.RequestCtx) {
 		ActiveActorsCount: a.actor.GetActiveActorsCount(ctx),
 		Extended:          temp,
 	}
+	if a.app.Port > 0 {
+		mtd.App = &appMetadata{
+			Port:     a.app.Port,
+			Protocol: a.app.Protocol,
+		}
+	}
+	if a.subscriptionManager != nil {
+		for _, s := range a.subscriptionManager.Subscriptions() {
+			mtd.Subscriptions = append(mtd.Subscriptions, subscriptionMetadata{
+				PubSubName: s.PubSubName,
+				Topic:      s.Topic,
+				Route:      s.Route,
+				Paused:     s.Paused,
+			})
+		}
+	}
+
+	for name := range a.compStore.ListStateStores() {
+		prefix, ok := a.compStore.GetStateKeyPrefix(name)
+		if !ok {
+			continue
+		}
+		mtd.StateStores = append(mtd.StateStores, stateStoreMetadata{
+			Name:        name,
+			KeyPrefix:   prefix.Strategy,
+			KeyFormat:   prefix.Format(),
+			Consistency: a.compStore.GetStateConsistency(name),
+		})
+	}
+	sort.Slice(mtd.StateStores, func(i, j int) bool { return mtd.StateStores[i].Name < mtd.StateStores[j].Name })
+
+	if a.componentStatusesFn != nil {
+		for _, s := range a.componentStatusesFn() {
+			mtd.Components = append(mtd.Components, componentMetadata{
+				Name:         s.Name,
+				Type:         s.Type,
+				Version:      s.Version,
+				Status:       s.Status,
+				Error:        s.Error,
+				Capabilities: s.Capabilities,
+			})
+		}
+	}
 
 	mtdBytes, err := a.json.Marshal(mtd)
 	if err != nil {
@@ -985,10 +2372,262 @@ func (a *api) onGetMetadata(reqCtx *fasthttp.RequestCtx) {
 func (a *api) onPutMetadata(reqCtx *fasthttp.RequestCtx) {
 	key := fmt.Sprintf("%v", reqCtx.UserValue("key"))
 	body := reqCtx.PostBody()
-	a.extendedMetadata.Store(key, string(body))
+	a.attributes.Set(key, string(body))
 	respondEmpty(reqCtx, 200)
 }
 
+// authenticateAdmin checks the admin token of the request, or responds with an error
+func (a *api) authenticateAdmin(reqCtx *fasthttp.RequestCtx) bool {
+	if a.adminToken == "" {
+		msg := NewErrorResponse("ERR_ADMIN_API_DISABLED", fmt.Sprintf("the admin endpoints require the %s environment variable", AdminTokenEnvVar))
+		respondWithError(reqCtx, 403, msg)
+		return false
+	}
+
+	token := string(reqCtx.Request.Header.Peek(adminTokenHeader))
+	err := auth.SharedAPITokenVerifier(a.adminToken).Verify(reqCtx.RemoteIP().String(), token)
+	if err == auth.ErrAPITokenLockedOut {
+		msg := NewErrorResponse("ERR_ADMIN_LOCKED_OUT", fmt.Sprintf("too many requests with an invalid %s header, try again later", adminTokenHeader))
+		respondWithError(reqCtx, 429, msg)
+		return false
+	} else if err != nil {
+		msg := NewErrorResponse("ERR_ADMIN_UNAUTHORIZED", fmt.Sprintf("invalid %s header", adminTokenHeader))
+		respondWithError(reqCtx, 401, msg)
+		return false
+	}
+	return true
+}
+
+// onGetLogging returns the log level of each logger of the sidecar
+func (a *api) onGetLogging(reqCtx *fasthttp.RequestCtx) {
+	if !a.authenticateAdmin(reqCtx) {
+		return
+	}
+
+	b, _ := a.json.Marshal(newLoggingResponse())
+	respondWithJSON(reqCtx, 200, b)
+}
+
+// onPutLogging changes the log level of the sidecar, or of the loggers in the given scopes only
+// such as dapr.runtime.actor to debug the actors building block
+func (a *api) onPutLogging(reqCtx *fasthttp.RequestCtx) {
+	if !a.authenticateAdmin(reqCtx) {
+		return
+	}
+
+	var req loggingRequest
+	if err := a.json.Unmarshal(reqCtx.PostBody(), &req); err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	updated, err := logger.SetLoggersOutputLevel(req.Level, req.Scopes...)
+	if err != nil {
+		msg := NewErrorResponse("ERR_LOG_LEVEL_SET", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	log.Infof("log level set to %s for %s", req.Level, strings.Join(updated, ", "))
+
+	b, _ := a.json.Marshal(newLoggingResponse())
+	respondWithJSON(reqCtx, 200, b)
+}
+
+// getConfigurationStore returns the configuration store named by the request, or responds with an error
+func (a *api) getConfigurationStore(reqCtx *fasthttp.RequestCtx) (configuration.Store, string, bool) {
+	if a.compStore.ConfigurationStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_CONFIGURATION_STORE_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return nil, "", false
+	}
+
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+	store, ok := a.compStore.GetConfigurationStore(storeName)
+	if !ok {
+		msg := NewErrorResponse("ERR_CONFIGURATION_STORE_NOT_FOUND", fmt.Sprintf("configuration store name: %s", storeName))
+		respondWithError(reqCtx, 401, msg)
+		return nil, "", false
+	}
+	return store, storeName, true
+}
+
+// onGetConfiguration returns the configuration items of the comma separated keys query parameter,
+// or all the items of the store when no keys are given
+func (a *api) onGetConfiguration(reqCtx *fasthttp.RequestCtx) {
+	store, storeName, ok := a.getConfigurationStore(reqCtx)
+	if !ok {
+		return
+	}
+
+	req := configuration.GetRequest{
+		Keys:     splitQueryArg(reqCtx, configurationKeysParam),
+		Metadata: getMetadataFromRequest(reqCtx),
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("GetConfiguration: %s", storeName)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	ctx, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	resp, err := store.Get(ctx, &req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_CONFIGURATION_GET", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+
+	items := []*configuration.Item{}
+	if resp != nil && resp.Items != nil {
+		items = resp.Items
+	}
+	b, _ := a.json.Marshal(items)
+	respondWithJSON(reqCtx, 200, b)
+}
+
+// onSubscribeConfiguration streams the configuration items of the comma separated keys query parameter which
+// change as server-sent events, until the client disconnects. All the items of the store are subscribed to
+// when no keys are given.
+func (a *api) onSubscribeConfiguration(reqCtx *fasthttp.RequestCtx) {
+	store, _, ok := a.getConfigurationStore(reqCtx)
+	if !ok {
+		return
+	}
+
+	req := configuration.SubscribeRequest{
+		Keys:     splitQueryArg(reqCtx, configurationKeysParam),
+		Metadata: getMetadataFromRequest(reqCtx),
+	}
+	streamEvents(reqCtx, func(ctx context.Context, send func(event string, data []byte) error) {
+		err := store.Subscribe(ctx, &req, func(e *configuration.UpdateEvent) error {
+			b, _ := a.json.Marshal(e.Items)
+			return send("update", b)
+		})
+		if err != nil && ctx.Err() == nil {
+			b, _ := a.json.Marshal(NewErrorResponse("ERR_CONFIGURATION_SUBSCRIBE", err.Error()))
+			send("error", b)
+		}
+	})
+}
+
+// getKeyVault returns the key vault named by the request, or responds with an error
+func (a *api) getKeyVault(reqCtx *fasthttp.RequestCtx) (crypto.KeyVault, string, bool) {
+	if a.compStore.KeyVaultsLen() == 0 {
+		msg := NewErrorResponse("ERR_CRYPTO_VAULT_NOT_CONFIGURED", "")
+		respondWithError(reqCtx, 400, msg)
+		return nil, "", false
+	}
+
+	vaultName := reqCtx.UserValue(vaultNameParam).(string)
+	vault, ok := a.compStore.GetKeyVault(vaultName)
+	if !ok {
+		msg := NewErrorResponse("ERR_CRYPTO_VAULT_NOT_FOUND", fmt.Sprintf("key vault name: %s", vaultName))
+		respondWithError(reqCtx, 401, msg)
+		return nil, "", false
+	}
+	return vault, vaultName, true
+}
+
+// onEncrypt encrypts the request body with the key of the keyName query parameter. The optional algorithm
+// query parameter picks the algorithm the key vault wraps the data encryption key with.
+func (a *api) onEncrypt(reqCtx *fasthttp.RequestCtx) {
+	vault, vaultName, ok := a.getKeyVault(reqCtx)
+	if !ok {
+		return
+	}
+	keyName := string(reqCtx.QueryArgs().Peek(keyNameParam))
+	if keyName == "" {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", "key name is required")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	algorithm := string(reqCtx.QueryArgs().Peek(algorithmParam))
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("Encrypt: %s", vaultName)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	ctx, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	var buf bytes.Buffer
+	w, err := crypto.NewEncryptWriter(ctx, vault, &buf, keyName, algorithm)
+	if err == nil {
+		_, err = w.Write(reqCtx.PostBody())
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		msg := NewErrorResponse("ERR_CRYPTO_ENCRYPT", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+	reqCtx.Response.Header.SetContentType(binaryContentType)
+	respond(reqCtx, 200, buf.Bytes())
+}
+
+// onDecrypt decrypts the request body encrypted by onEncrypt. The optional keyName query parameter
+// checks that the payload was encrypted with the expected key.
+func (a *api) onDecrypt(reqCtx *fasthttp.RequestCtx) {
+	vault, vaultName, ok := a.getKeyVault(reqCtx)
+	if !ok {
+		return
+	}
+	keyName := string(reqCtx.QueryArgs().Peek(keyNameParam))
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("Decrypt: %s", vaultName)
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	ctx, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	// the whole payload is decrypted before responding, so that altered or truncated payloads are rejected
+	r, err := crypto.NewDecryptReader(ctx, vault, bytes.NewReader(reqCtx.PostBody()), keyName)
+	var b []byte
+	if err == nil {
+		b, err = ioutil.ReadAll(r)
+	}
+	if err != nil {
+		code := 500
+		if err == crypto.ErrInvalidPayload || err == crypto.ErrKeyNameMismatch {
+			code = 400
+		}
+		msg := NewErrorResponse("ERR_CRYPTO_DECRYPT", err.Error())
+		respondWithError(reqCtx, code, msg)
+		return
+	}
+	reqCtx.Response.Header.SetContentType(binaryContentType)
+	respond(reqCtx, 200, b)
+}
+
+// onFlushSecretCache drops the cached secrets of the secret store named by the storeName query parameter,
+// or of all the secret stores when none is named
+func (a *api) onFlushSecretCache(reqCtx *fasthttp.RequestCtx) {
+	storeName := string(reqCtx.QueryArgs().Peek(storeNameParam))
+	if _, ok := a.compStore.GetSecretStore(storeName); storeName != "" && !ok {
+		msg := NewErrorResponse("ERR_SECRET_STORE_NOT_FOUND", fmt.Sprintf("secret store name: %s", storeName))
+		respondWithError(reqCtx, 401, msg)
+		return
+	}
+
+	for name, store := range a.compStore.ListSecretStores() {
+		if storeName != "" && name != storeName {
+			continue
+		}
+		if flusher, ok := store.(secrets_cache.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+	respondEmpty(reqCtx, 204)
+}
+
 func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 	if a.publishFn == nil {
 		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", "")
@@ -998,17 +2637,23 @@ func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 
 	topic := reqCtx.UserValue(topicParam).(string)
 	body := reqCtx.PostBody()
+	metadata := getMetadataFromRequest(reqCtx)
 
 	// TODO : Remove passing corID in NewCloudEventsEnvelope through arguments as it can be passed through context
 	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
 	corID := sc.TraceID.String()
-	envelope := pubsub.NewCloudEventsEnvelope(uuid.New().String(), a.id, pubsub.DefaultCloudEventType, corID, body)
 
-	b, err := a.json.Marshal(envelope)
-	if err != nil {
-		msg := NewErrorResponse("ERR_PUBSUB_CLOUD_EVENTS_SER", err.Error())
-		respondWithError(reqCtx, 500, msg)
-		return
+	b := body
+	if !runtime_pubsub.IsRawPayload(metadata) {
+		contentType := string(reqCtx.Request.Header.ContentType())
+
+		var err error
+		b, err = runtime_pubsub.NewCloudEvent(uuid.New().String(), a.id, corID, contentType, body, metadata)
+		if err != nil {
+			msg := NewErrorResponse("ERR_PUBSUB_CLOUD_EVENTS_SER", err.Error())
+			respondWithError(reqCtx, 500, msg)
+			return
+		}
 	}
 
 	req := pubsub.PublishRequest{
@@ -1023,8 +2668,22 @@ func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
 	defer span.End()
 
-	err = a.publishFn(&req)
-	if err != nil {
+	err := a.publishFn(runtime_pubsub.GetPubSubName(metadata), &req)
+	var schemaErr runtime_pubsub.SchemaValidationError
+	if errors.As(err, &runtime_pubsub.NotAllowedError{}) {
+		msg := NewErrorResponse("ERR_PUBSUB_FORBIDDEN", err.Error())
+		respondWithError(reqCtx, 403, msg)
+	} else if errors.As(err, &schemaErr) {
+		msg := NewErrorResponse("ERR_PUBSUB_EVENT_SCHEMA", fmt.Sprintf("event does not match the schema of topic %s", schemaErr.Topic))
+		msg.Details = schemaErr.Errors
+		respondWithError(reqCtx, 400, msg)
+	} else if errors.As(err, &runtime_pubsub.NotFoundError{}) {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", err.Error())
+		respondWithError(reqCtx, 400, msg)
+	} else if errors.Is(err, runtime_pubsub.ErrDelayedDeliveryNotConfigured) {
+		msg := NewErrorResponse("ERR_PUBSUB_DELAY_NOT_CONFIGURED", err.Error())
+		respondWithError(reqCtx, 400, msg)
+	} else if err != nil {
 		msg := NewErrorResponse("ERR_PUBSUB_PUBLISH_MESSAGE", err.Error())
 		respondWithError(reqCtx, 500, msg)
 	} else {
@@ -1032,6 +2691,131 @@ func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 	}
 }
 
+func (a *api) onBulkPublish(reqCtx *fasthttp.RequestCtx) {
+	if a.bulkPublishFn == nil {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	topic := reqCtx.UserValue(topicParam).(string)
+
+	var entries []bulkPublishEntry
+	err := a.json.Unmarshal(reqCtx.PostBody(), &entries)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	sc := diag.GetSpanContextFromRequestContext(reqCtx, a.tracingSpec)
+	corID := sc.TraceID.String()
+
+	req := runtime_pubsub.BulkPublishRequest{
+		PubSubName: runtime_pubsub.GetPubSubName(getMetadataFromRequest(reqCtx)),
+		Topic:      topic,
+		Entries:    make([]runtime_pubsub.BulkPublishRequestEntry, 0, len(entries)),
+	}
+	for _, e := range entries {
+		if e.EntryID == "" {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", "entryId is required for every entry")
+			respondWithError(reqCtx, 400, msg)
+			return
+		}
+
+		b, err := runtime_pubsub.NewCloudEvent(uuid.New().String(), a.id, corID, e.ContentType, e.Event, e.Metadata)
+		if err != nil {
+			msg := NewErrorResponse("ERR_PUBSUB_CLOUD_EVENTS_SER", err.Error())
+			respondWithError(reqCtx, 500, msg)
+			return
+		}
+		req.Entries = append(req.Entries, runtime_pubsub.BulkPublishRequestEntry{
+			EntryID: e.EntryID,
+			Data:    b,
+		})
+	}
+
+	var span *trace.Span
+	spanName := fmt.Sprintf("PublishBulkEvent: %s", topic)
+	ctx := diag.NewContext((context.Context)(reqCtx), sc)
+	_, span = diag.StartTracingClientSpanFromHTTPContext(ctx, &reqCtx.Request, spanName, a.tracingSpec)
+	diag.SpanContextToRequest(span.SpanContext(), &reqCtx.Request)
+	defer span.End()
+
+	statuses, err := a.bulkPublishFn(&req)
+	if errors.As(err, &runtime_pubsub.NotAllowedError{}) {
+		msg := NewErrorResponse("ERR_PUBSUB_FORBIDDEN", err.Error())
+		respondWithError(reqCtx, 403, msg)
+		return
+	}
+	if errors.As(err, &runtime_pubsub.NotFoundError{}) {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", err.Error())
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+	if err != nil {
+		msg := NewErrorResponse("ERR_PUBSUB_PUBLISH_MESSAGE", err.Error())
+		respondWithError(reqCtx, 500, msg)
+		return
+	}
+
+	code := 200
+	for _, s := range statuses {
+		if s.Status != runtime_pubsub.BulkPublishSucceeded {
+			code = 500
+			break
+		}
+	}
+	b, _ := a.json.Marshal(statuses)
+	respondWithJSON(reqCtx, code, b)
+}
+
+func (a *api) onPauseSubscription(reqCtx *fasthttp.RequestCtx) {
+	a.setSubscriptionPaused(reqCtx, true)
+}
+
+func (a *api) onResumeSubscription(reqCtx *fasthttp.RequestCtx) {
+	a.setSubscriptionPaused(reqCtx, false)
+}
+
+func (a *api) setSubscriptionPaused(reqCtx *fasthttp.RequestCtx, paused bool) {
+	if a.subscriptionManager == nil {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", "")
+		respondWithError(reqCtx, 400, msg)
+		return
+	}
+
+	topic := reqCtx.UserValue(topicParam).(string)
+	var err error
+	if paused {
+		err = a.subscriptionManager.PauseSubscription(topic)
+	} else {
+		err = a.subscriptionManager.ResumeSubscription(topic)
+	}
+	if errors.As(err, &runtime_pubsub.SubscriptionNotFoundError{}) {
+		msg := NewErrorResponse("ERR_SUBSCRIPTION_NOT_FOUND", err.Error())
+		respondWithError(reqCtx, 404, msg)
+	} else if err != nil {
+		msg := NewErrorResponse("ERR_SUBSCRIPTION_UPDATE", err.Error())
+		respondWithError(reqCtx, 500, msg)
+	} else {
+		respondEmpty(reqCtx, 204)
+	}
+}
+
+// getMetadataFromRequest returns the metadata passed as "metadata." prefixed query parameters
+func getMetadataFromRequest(reqCtx *fasthttp.RequestCtx) map[string]string {
+	metadata := map[string]string{}
+	reqCtx.QueryArgs().VisitAll(func(key []byte, value []byte) {
+		queryKey := string(key)
+		if strings.HasPrefix(queryKey, metadataPrefix) {
+			k := strings.TrimPrefix(queryKey, metadataPrefix)
+			metadata[k] = string(value)
+		}
+	})
+	return metadata
+}
+
 // GetStatusCodeFromMetadata extracts the http status code from the metadata if it exists
 func GetStatusCodeFromMetadata(metadata map[string]string) int {
 	code := metadata[http.HTTPStatusCode]
@@ -1044,11 +2828,23 @@ func GetStatusCodeFromMetadata(metadata map[string]string) int {
 	return 200
 }
 
+// onGetHealthz reports the health of the sidecar. When the health of the app is checked, it is reported in the
+// body of the response without failing the health of the sidecar.
 func (a *api) onGetHealthz(reqCtx *fasthttp.RequestCtx) {
 	if !a.readyStatus {
 		msg := NewErrorResponse("ERR_HEALTH_NOT_READY", "dapr is not ready")
 		respondWithError(reqCtx, 500, msg)
-	} else {
+		return
+	}
+
+	var appHealth string
+	if a.appHealthFn != nil {
+		appHealth = a.appHealthFn()
+	}
+	if appHealth == "" {
 		respondEmpty(reqCtx, 200)
+		return
 	}
+	b, _ := a.json.Marshal(healthzResponse{AppHealth: appHealth})
+	respondWithJSON(reqCtx, 200, b)
 }
@@ -18,22 +18,35 @@ import (
 	gohttp "net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/components-contrib/exporters"
 	"github.com/dapr/components-contrib/exporters/stringexporter"
 	"github.com/dapr/components-contrib/middleware"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/actors"
+	"github.com/dapr/dapr/pkg/audit"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
 	http_middleware_loader "github.com/dapr/dapr/pkg/components/middleware/http"
 	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/crypto"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/jobs"
 	"github.com/dapr/dapr/pkg/logger"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	v1 "github.com/dapr/dapr/pkg/messaging/v1"
 	http_middleware "github.com/dapr/dapr/pkg/middleware/http"
+	"github.com/dapr/dapr/pkg/outbox"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	runtime_metadata "github.com/dapr/dapr/pkg/runtime/metadata"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/dapr/dapr/pkg/state/keyprefix"
+	"github.com/dapr/dapr/pkg/state/query"
 	daprt "github.com/dapr/dapr/pkg/testing"
+	"github.com/dapr/dapr/pkg/workflows"
 	routing "github.com/fasthttp/router"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -47,7 +60,7 @@ var retryCounter = 0
 func TestV1OutputBindingsEndpoints(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
 	testAPI := &api{
-		sendToOutputBindingFn: func(name string, req *bindings.WriteRequest) error { return nil },
+		sendToOutputBindingFn: func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) { return nil, nil },
 		json:                  jsoniter.ConfigFastest,
 	}
 	fakeServer.StartServer(testAPI.constructBindingsEndpoints())
@@ -67,6 +80,29 @@ func TestV1OutputBindingsEndpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Invoke output bindings - response data", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/bindings/testbinding", apiVersionV1)
+		req := OutputBindingRequest{
+			Data: "fake output",
+		}
+		b, _ := json.Marshal(&req)
+
+		testAPI.sendToOutputBindingFn = func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) {
+			return &bindings_loader.InvokeResponse{
+				Data:     []byte(`{"id":"1"}`),
+				Metadata: map[string]string{"statusCode": "201"},
+			}, nil
+		}
+
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, b, nil)
+
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, []byte(`{"id":"1"}`), resp.RawBody)
+		assert.Equal(t, "201", resp.RawHeader.Get("metadata.statusCode"))
+	})
+
 	t.Run("Invoke output bindings - 500 InternalError", func(t *testing.T) {
 		apiPath := fmt.Sprintf("%s/bindings/notfound", apiVersionV1)
 		req := OutputBindingRequest{
@@ -74,8 +110,8 @@ func TestV1OutputBindingsEndpoints(t *testing.T) {
 		}
 		b, _ := json.Marshal(&req)
 
-		testAPI.sendToOutputBindingFn = func(name string, req *bindings.WriteRequest) error {
-			return errors.New("missing binding name")
+		testAPI.sendToOutputBindingFn = func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) {
+			return nil, errors.New("missing binding name")
 		}
 
 		testMethods := []string{"POST", "PUT"}
@@ -92,6 +128,285 @@ func TestV1OutputBindingsEndpoints(t *testing.T) {
 	fakeServer.Shutdown()
 }
 
+func TestV1PublishRawPayload(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	var published *pubsub.PublishRequest
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+		publishFn: func(pubsubName string, req *pubsub.PublishRequest) error {
+			published = req
+			return nil
+		},
+	}
+	fakeServer.StartServer(testAPI.constructPubSubEndpoints())
+	apiPath := fmt.Sprintf("%s/publish/topic1", apiVersionV1)
+
+	t.Run("raw payload is not wrapped", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte("raw data"), map[string]string{"metadata.rawPayload": "true"})
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, []byte("raw data"), published.Data)
+	})
+
+	t.Run("payload is wrapped in a cloud event by default", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte("raw data"), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.NotEqual(t, []byte("raw data"), published.Data)
+		assert.Contains(t, string(published.Data), "specversion")
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1PublishPubSubName(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	var pubsubNames []string
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+		publishFn: func(pubsubName string, req *pubsub.PublishRequest) error {
+			if pubsubName == "unknown" {
+				return runtime_pubsub.NotFoundError{PubSubName: pubsubName}
+			}
+			pubsubNames = append(pubsubNames, pubsubName)
+			return nil
+		},
+	}
+	fakeServer.StartServer(testAPI.constructPubSubEndpoints())
+	apiPath := fmt.Sprintf("%s/publish/topic1", apiVersionV1)
+
+	t.Run("default pub sub", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte("data"), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, []string{""}, pubsubNames)
+	})
+
+	t.Run("named pub sub", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte("data"), map[string]string{"metadata.pubsubName": "kafka"})
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, []string{"", "kafka"}, pubsubNames)
+	})
+
+	t.Run("unknown pub sub", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte("data"), map[string]string{"metadata.pubsubName": "unknown"})
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_PUBSUB_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1PublishSchemaValidation(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+		publishFn: func(pubsubName string, req *pubsub.PublishRequest) error {
+			return runtime_pubsub.SchemaValidationError{Topic: req.Topic, Errors: []string{"id: Invalid type."}}
+		},
+	}
+	fakeServer.StartServer(testAPI.constructPubSubEndpoints())
+
+	apiPath := fmt.Sprintf("%s/publish/orders", apiVersionV1)
+	resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"id": "a"}`), nil)
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, "ERR_PUBSUB_EVENT_SCHEMA", resp.ErrorBody["errorCode"])
+
+	var body ErrorResponse
+	assert.NoError(t, json.Unmarshal(resp.RawBody, &body))
+	assert.Equal(t, []string{"id: Invalid type."}, body.Details)
+
+	fakeServer.Shutdown()
+}
+
+type fakeSubscriptionManager struct {
+	paused map[string]bool
+}
+
+func (f *fakeSubscriptionManager) Subscriptions() []runtime_pubsub.SubscriptionStatus {
+	return []runtime_pubsub.SubscriptionStatus{{PubSubName: "pubsub1", Topic: "topic1", Paused: f.paused["topic1"]}}
+}
+
+func (f *fakeSubscriptionManager) PauseSubscription(topic string) error {
+	return f.setPaused(topic, true)
+}
+
+func (f *fakeSubscriptionManager) ResumeSubscription(topic string) error {
+	return f.setPaused(topic, false)
+}
+
+func (f *fakeSubscriptionManager) setPaused(topic string, paused bool) error {
+	if topic != "topic1" {
+		return runtime_pubsub.SubscriptionNotFoundError{Topic: topic}
+	}
+	f.paused[topic] = paused
+	return nil
+}
+
+func TestV1SubscriptionPauseEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	manager := &fakeSubscriptionManager{paused: map[string]bool{}}
+	testAPI := &api{
+		json:                jsoniter.ConfigFastest,
+		subscriptionManager: manager,
+	}
+	fakeServer.StartServer(testAPI.constructPubSubEndpoints())
+
+	t.Run("pause", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/subscriptions/topic1/pause", apiVersionV1alpha1), nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.True(t, manager.paused["topic1"])
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/subscriptions/topic1/resume", apiVersionV1alpha1), nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.False(t, manager.paused["topic1"])
+	})
+
+	t.Run("topic not subscribed", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/subscriptions/topic2/pause", apiVersionV1alpha1), nil, nil)
+		assert.Equal(t, 404, resp.StatusCode)
+		assert.Equal(t, "ERR_SUBSCRIPTION_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+type fakeScheduler struct {
+	jobs map[string]jobs.Job
+}
+
+func (f *fakeScheduler) ScheduleJob(job jobs.Job) error {
+	if job.Schedule == "" && job.DueTime == "" {
+		return jobs.InvalidJobError{Name: job.Name, Reason: "job schedule or due time is required"}
+	}
+	f.jobs[job.Name] = job
+	return nil
+}
+
+func (f *fakeScheduler) DeleteJob(name string) error {
+	delete(f.jobs, name)
+	return nil
+}
+
+func (f *fakeScheduler) ListJobs() ([]jobs.Job, error) {
+	list := []jobs.Job{}
+	for _, j := range f.jobs {
+		list = append(list, j)
+	}
+	return list, nil
+}
+
+func (f *fakeScheduler) Start(interval time.Duration) {}
+
+func (f *fakeScheduler) Stop() {}
+
+func TestV1JobsEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	scheduler := &fakeScheduler{jobs: map[string]jobs.Job{}}
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+		jobs: scheduler,
+	}
+	fakeServer.StartServer(testAPI.constructJobsEndpoints())
+
+	t.Run("schedule job", func(t *testing.T) {
+		body := []byte(`{"schedule":"@daily","data":{"report":"sales"}}`)
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/jobs/report", apiVersionV1alpha1), body, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.Equal(t, "@daily", scheduler.jobs["report"].Schedule)
+		assert.Equal(t, `{"report":"sales"}`, string(scheduler.jobs["report"].Data))
+	})
+
+	t.Run("schedule invalid job", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/jobs/invalid", apiVersionV1alpha1), []byte(`{}`), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("list jobs", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/jobs", apiVersionV1alpha1), nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		var list []jobResponse
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &list))
+		assert.Len(t, list, 1)
+		assert.Equal(t, "report", list[0].Name)
+		assert.Equal(t, `{"report":"sales"}`, string(list[0].Data))
+	})
+
+	t.Run("delete job", func(t *testing.T) {
+		resp := fakeServer.DoRequest("DELETE", fmt.Sprintf("%s/jobs/report", apiVersionV1alpha1), nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.Empty(t, scheduler.jobs)
+	})
+
+	t.Run("jobs not configured", func(t *testing.T) {
+		testAPI.jobs = nil
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/jobs", apiVersionV1alpha1), nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_JOBS_NOT_CONFIGURED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1BulkPublishEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructPubSubEndpoints())
+	apiPath := fmt.Sprintf("%s/publish/bulk/topic1", apiVersionV1alpha1)
+	body := []byte(`[{"entryId": "1", "event": "first"}, {"entryId": "2", "event": "second"}]`)
+
+	t.Run("Bulk publish - 200 OK", func(t *testing.T) {
+		var received *runtime_pubsub.BulkPublishRequest
+		testAPI.bulkPublishFn = func(req *runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error) {
+			received = req
+			return []runtime_pubsub.BulkPublishResponseEntry{
+				{EntryID: "1", Status: runtime_pubsub.BulkPublishSucceeded},
+				{EntryID: "2", Status: runtime_pubsub.BulkPublishSucceeded},
+			}, nil
+		}
+
+		resp := fakeServer.DoRequest("POST", apiPath, body, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "topic1", received.Topic)
+		assert.Len(t, received.Entries, 2)
+	})
+
+	t.Run("Bulk publish - partial failure returns statuses", func(t *testing.T) {
+		testAPI.bulkPublishFn = func(req *runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error) {
+			return []runtime_pubsub.BulkPublishResponseEntry{
+				{EntryID: "1", Status: runtime_pubsub.BulkPublishSucceeded},
+				{EntryID: "2", Status: runtime_pubsub.BulkPublishFailed, Error: "failed"},
+			}, nil
+		}
+
+		resp := fakeServer.DoRequest("POST", apiPath, body, nil)
+		assert.Equal(t, 500, resp.StatusCode)
+		var statuses []runtime_pubsub.BulkPublishResponseEntry
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &statuses))
+		assert.Equal(t, runtime_pubsub.BulkPublishFailed, statuses[1].Status)
+	})
+
+	t.Run("Bulk publish - missing entry id", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`[{"event": "text"}]`), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Bulk publish - topic not allowed", func(t *testing.T) {
+		testAPI.bulkPublishFn = func(req *runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error) {
+			return nil, runtime_pubsub.NotAllowedError{Topic: req.Topic, AppID: "app1"}
+		}
+
+		resp := fakeServer.DoRequest("POST", apiPath, body, nil)
+		assert.Equal(t, 403, resp.StatusCode)
+		assert.Equal(t, "ERR_PUBSUB_FORBIDDEN", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
 func TestV1OutputBindingsEndpointsWithTracer(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
 	buffer := ""
@@ -106,7 +421,7 @@ func TestV1OutputBindingsEndpointsWithTracer(t *testing.T) {
 	createExporters(meta)
 
 	testAPI := &api{
-		sendToOutputBindingFn: func(name string, req *bindings.WriteRequest) error { return nil },
+		sendToOutputBindingFn: func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) { return nil, nil },
 		json:                  jsoniter.ConfigFastest,
 		tracingSpec:           spec,
 	}
@@ -137,8 +452,8 @@ func TestV1OutputBindingsEndpointsWithTracer(t *testing.T) {
 		}
 		b, _ := json.Marshal(&req)
 
-		testAPI.sendToOutputBindingFn = func(name string, req *bindings.WriteRequest) error {
-			return errors.New("missing binding name")
+		testAPI.sendToOutputBindingFn = func(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) {
+			return nil, errors.New("missing binding name")
 		}
 
 		testMethods := []string{"POST", "PUT"}
@@ -596,6 +911,82 @@ func TestV1ActorEndpoints(t *testing.T) {
 		mockActors.AssertNumberOfCalls(t, "TransactionalStateOperation", 1)
 	})
 
+	t.Run("List actor reminders - 200 OK", func(t *testing.T) {
+		apiPath := "v1.0/actors/fakeActorType/fakeActorID/reminders"
+		mockActors := new(daprt.MockActors)
+		mockActors.On("ListReminders", &actors.ListRemindersRequest{
+			ActorType: "fakeActorType",
+			ActorID:   "fakeActorID",
+		}).Return(&actors.ListRemindersResponse{
+			Reminders: []actors.Reminder{{ActorType: "fakeActorType", ActorID: "fakeActorID", Name: "reminder1", Period: "1h"}},
+		}, nil)
+
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		var list actors.ListRemindersResponse
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &list))
+		assert.Len(t, list.Reminders, 1)
+		assert.Equal(t, "reminder1", list.Reminders[0].Name)
+		mockActors.AssertNumberOfCalls(t, "ListReminders", 1)
+	})
+
+	t.Run("List actor type reminders by page - 200 OK", func(t *testing.T) {
+		apiPath := "v1.0/reminders/fakeActorType?limit=10&continuationToken=token1"
+		mockActors := new(daprt.MockActors)
+		mockActors.On("ListReminders", &actors.ListRemindersRequest{
+			ActorType:         "fakeActorType",
+			Limit:             10,
+			ContinuationToken: "token1",
+		}).Return(&actors.ListRemindersResponse{Reminders: []actors.Reminder{}, ContinuationToken: "token2"}, nil)
+
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "token2", resp.JSONBody.(map[string]interface{})["continuationToken"])
+		mockActors.AssertNumberOfCalls(t, "ListReminders", 1)
+	})
+
+	t.Run("List actor type reminders with invalid limit - 400", func(t *testing.T) {
+		apiPath := "v1.0/reminders/fakeActorType?limit=-1"
+		testAPI.actor = new(daprt.MockActors)
+
+		// act
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+		// assert
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Direct actor stream message - 200 OK", func(t *testing.T) {
+		apiPath := "v1.0/actors/fakeActorType/fakeActorID/stream/report"
+		fakeResponse := invokev1.NewInvokeMethodResponse(200, "OK", nil).WithRawData(nil, "text/plain")
+		mockActors := new(daprt.MockActors)
+		mockActors.On("CallStream", mock.MatchedBy(func(req *invokev1.InvokeMethodRequest) bool {
+			return req.Actor().GetActorType() == "fakeActorType" && req.Message().Method == "report"
+		})).Return(fakeResponse, ioutil.NopCloser(strings.NewReader("chunk1chunk2")), nil)
+
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, fakeData, nil)
+
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "text/plain", resp.ContentType)
+		assert.Equal(t, "chunk1chunk2", string(resp.RawBody))
+		mockActors.AssertNumberOfCalls(t, "CallStream", 1)
+	})
+
 	fakeServer.Shutdown()
 }
 
@@ -603,8 +994,9 @@ func TestV1MetadataEndpoint(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
 
 	testAPI := &api{
-		actor: nil,
-		json:  jsoniter.ConfigFastest,
+		actor:     nil,
+		compStore: newTestCompStore(nil, nil),
+		json:      jsoniter.ConfigFastest,
 	}
 
 	fakeServer.StartServer(testAPI.constructMetadataEndpoints())
@@ -632,6 +1024,29 @@ func TestV1MetadataEndpoint(t *testing.T) {
 		mockActors.AssertNumberOfCalls(t, "GetActiveActorsCount", 1)
 	})
 
+	t.Run("Metadata - attributes and app", func(t *testing.T) {
+		mockActors := new(daprt.MockActors)
+		mockActors.On("GetActiveActorsCount")
+
+		testAPI.actor = mockActors
+		testAPI.app = runtime_metadata.App{Port: 3000, Protocol: "http"}
+		testAPI.attributes = runtime_metadata.NewAttributes()
+
+		resp := fakeServer.DoRequest("PUT", "v1.0/metadata/team", []byte("orders"), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		resp = fakeServer.DoRequest("GET", "v1.0/metadata", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var body struct {
+			Extended map[string]string `json:"extended"`
+			App      appMetadata       `json:"app"`
+		}
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &body))
+		assert.Equal(t, map[string]string{"team": "orders"}, body.Extended)
+		assert.Equal(t, appMetadata{Port: 3000, Protocol: "http"}, body.App)
+	})
+
 	fakeServer.Shutdown()
 }
 
@@ -1303,8 +1718,8 @@ func TestV1StateEndpoints(t *testing.T) {
 		"store1": fakeStore,
 	}
 	testAPI := &api{
-		stateStores: fakeStores,
-		json:        jsoniter.ConfigFastest,
+		compStore: newTestCompStore(fakeStores, nil),
+		json:      jsoniter.ConfigFastest,
 	}
 	fakeServer.StartServer(testAPI.constructStateEndpoints())
 	storeName := "store1"
@@ -1364,7 +1779,9 @@ func TestV1StateEndpoints(t *testing.T) {
 		// act
 		resp := fakeServer.DoRequest("POST", apiPath, b, nil)
 		// assert
-		assert.Equal(t, 500, resp.StatusCode, "updating existing key with wrong etag should fail")
+		assert.Equal(t, 409, resp.StatusCode, "updating existing key with wrong etag should fail")
+		assert.Equal(t, etag, resp.RawHeader.Get("ETag"), "failed to return the current etag")
+		assert.Equal(t, "ERR_STATE_ETAG_MISMATCH", resp.ErrorBody["errorCode"])
 	})
 	t.Run("Delete state - No ETag", func(t *testing.T) {
 		apiPath := fmt.Sprintf("v1.0/state/%s/good-key", storeName)
@@ -1385,7 +1802,8 @@ func TestV1StateEndpoints(t *testing.T) {
 		// act
 		resp := fakeServer.DoRequest("DELETE", apiPath, nil, nil, "BAD ETAG")
 		// assert
-		assert.Equal(t, 500, resp.StatusCode, "updating existing key with wrong etag should fail")
+		assert.Equal(t, 409, resp.StatusCode, "updating existing key with wrong etag should fail")
+		assert.Equal(t, etag, resp.RawHeader.Get("ETag"), "failed to return the current etag")
 	})
 	t.Run("Delete state - With Retries", func(t *testing.T) {
 		apiPath := fmt.Sprintf("v1.0/state/%s/failed-key", storeName)
@@ -1422,59 +1840,415 @@ func TestV1StateEndpoints(t *testing.T) {
 	})
 }
 
-type fakeStateStore struct {
-	counter int
-}
-
-func (c fakeStateStore) BulkDelete(req []state.DeleteRequest) error {
-	for _, r := range req {
-		err := c.Delete(&r)
-		if err != nil {
-			return err
-		}
+func TestV1StateTransactionEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	fakeOutbox := &fakeOutbox{}
+	transactionalStore := &fakeTransactionalStore{}
+	testAPI := &api{
+		compStore: newTestCompStore(map[string]state.Store{"store1": transactionalStore, "store2": fakeStateStore{}}, nil),
+		id:        "app1",
+		outbox:    fakeOutbox,
+		publishFn: func(pubsubName string, req *pubsub.PublishRequest) error { return nil },
+		json:      jsoniter.ConfigFastest,
 	}
+	fakeServer.StartServer(testAPI.constructStateEndpoints())
+	apiPath := fmt.Sprintf("%s/state/store1/transaction", apiVersionV1alpha1)
+
+	t.Run("Transaction with outbox events - 201", func(t *testing.T) {
+		body := []byte(`{
+			"operations": [
+				{"operation": "upsert", "request": {"key": "key1", "value": "value1"}},
+				{"operation": "delete", "request": {"key": "key2"}}
+			],
+			"outbox": [{"topic": "orders", "data": {"id": 1}}]
+		}`)
+		resp := fakeServer.DoRequest("POST", apiPath, body, nil)
+		assert.Equal(t, 201, resp.StatusCode)
 
-	return nil
-}
-func (c fakeStateStore) BulkSet(req []state.SetRequest) error {
-	for _, s := range req {
-		err := c.Set(&s)
-		if err != nil {
-			return err
-		}
-	}
+		assert.Len(t, fakeOutbox.operations, 2)
+		assert.Equal(t, "app1||key1", fakeOutbox.operations[0].Request.(state.SetRequest).Key)
+		assert.Equal(t, "app1||key2", fakeOutbox.operations[1].Request.(state.DeleteRequest).Key)
+		assert.Len(t, fakeOutbox.events, 1)
+		assert.Equal(t, "orders", fakeOutbox.events[0].Topic)
+		assert.Contains(t, string(fakeOutbox.events[0].Data), `"specversion":"1.0"`)
+	})
 
-	return nil
+	t.Run("Unsupported operation - 400", func(t *testing.T) {
+		body := []byte(`{"operations": [{"operation": "merge", "request": {"key": "key1"}}]}`)
+		resp := fakeServer.DoRequest("POST", apiPath, body, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Transaction without outbox events - 201", func(t *testing.T) {
+		body := []byte(`{
+			"operations": [
+				{"operation": "upsert", "request": {"key": "key1", "value": "value1"}},
+				{"operation": "delete", "request": {"key": "key2", "etag": "1"}}
+			]
+		}`)
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/state/store1/transaction", apiVersionV1), body, nil)
+		assert.Equal(t, 201, resp.StatusCode)
+
+		assert.Len(t, transactionalStore.operations, 2)
+		assert.Equal(t, "app1||key1", transactionalStore.operations[0].Request.(state.SetRequest).Key)
+		assert.Equal(t, "1", transactionalStore.operations[1].Request.(state.DeleteRequest).ETag)
+	})
+
+	t.Run("Store without transactions - 501", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/state/store2/transaction", apiVersionV1), []byte(`{}`), nil)
+		assert.Equal(t, 501, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_STORE_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Store not found - 401", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/state/store3/transaction", apiVersionV1alpha1), []byte(`{}`), nil)
+		assert.Equal(t, 401, resp.StatusCode)
+	})
+
+	fakeServer.Shutdown()
 }
-func (c fakeStateStore) Delete(req *state.DeleteRequest) error {
-	if req.Key == "good-key" {
-		if req.ETag != "" && req.ETag != "`~!@#$%^&*()_+-={}[]|\\:\";'<>?,./'" {
-			return errors.New("ETag mismatch")
-		}
-		return nil
-	} else if req.Key == "failed-key" {
-		return state.DeleteWithRetries(func(req *state.DeleteRequest) error {
-			retryCounter++
-			if retryCounter < 3 {
-				return errors.New("Simulated failure")
-			}
-			return nil
-		}, req)
+
+// newTestCompStore returns a component store holding the state stores and the secret stores
+func newTestCompStore(stateStores map[string]state.Store, secretStores map[string]secretstores.SecretStore) *compstore.ComponentStore {
+	s := compstore.New()
+	for name, store := range stateStores {
+		s.AddStateStore(name, store)
 	}
-	return errors.New("NOT FOUND")
-}
-func (c fakeStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
-	if req.Key == "good-key" {
-		return &state.GetResponse{
-			Data: []byte("life is good"),
-			ETag: "`~!@#$%^&*()_+-={}[]|\\:\";'<>?,./'",
-		}, nil
+	for name, store := range secretStores {
+		s.AddSecretStore(name, store)
 	}
-	return nil, nil
+	return s
 }
-func (c fakeStateStore) Init(metadata state.Metadata) error {
-	c.counter = 0
-	return nil
+
+func TestV1StateKeyPrefix(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	nonePrefix, _ := keyprefix.New(map[string]string{keyprefix.MetadataKey: keyprefix.None}, "app1", "store1", "")
+	namePrefix, _ := keyprefix.New(map[string]string{keyprefix.MetadataKey: keyprefix.Name}, "app1", "store2", "")
+	transactionalStore := &fakeTransactionalStore{}
+	testAPI := &api{
+		id:        "app1",
+		compStore: newTestCompStore(map[string]state.Store{"store1": fakeStateStore{}, "store2": transactionalStore, "store3": fakeStateStore{}}, nil),
+		json:      jsoniter.ConfigFastest,
+	}
+	testAPI.compStore.SetStateKeyPrefix("store1", nonePrefix)
+	testAPI.compStore.SetStateKeyPrefix("store2", namePrefix)
+	fakeServer.StartServer(testAPI.constructStateEndpoints())
+
+	t.Run("Keys are not prefixed", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/state/store1/good-key", apiVersionV1), nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+
+	t.Run("Keys are prefixed with the store name", func(t *testing.T) {
+		body := []byte(`{"operations": [{"operation": "upsert", "request": {"key": "key1", "value": "value1"}}]}`)
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/state/store2/transaction", apiVersionV1), body, nil)
+		assert.Equal(t, 201, resp.StatusCode)
+		assert.Equal(t, "store2||key1", transactionalStore.operations[0].Request.(state.SetRequest).Key)
+	})
+
+	t.Run("Keys are prefixed with the app id by default", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/state/store3/good-key", apiVersionV1), nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1StateConsistency(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		compStore: newTestCompStore(map[string]state.Store{"store1": fakeStateStore{}}, nil),
+		json:      jsoniter.ConfigFastest,
+	}
+	testAPI.compStore.SetStateConsistency("store1", []string{"eventual"})
+	fakeServer.StartServer(testAPI.constructStateEndpoints())
+
+	t.Run("Get state - supported consistency", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/state/store1/good-key?metadata.consistency=eventual&metadata.readPreference=nearest", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+
+	t.Run("Get state - unsupported consistency", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/state/store1/good-key?consistency=strong", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Save state - unsupported consistency", func(t *testing.T) {
+		b, _ := json.Marshal([]state.SetRequest{{
+			Key:      "good-key",
+			Metadata: map[string]string{"consistency": "strong"},
+		}})
+		resp := fakeServer.DoRequest("POST", "v1.0/state/store1", b, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("Delete state - invalid read preference", func(t *testing.T) {
+		resp := fakeServer.DoRequest("DELETE", "v1.0/state/store1/good-key?metadata.readPreference=anywhere", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1StateBulkDeleteEndpoints(t *testing.T) {
+	etag := "`~!@#$%^&*()_+-={}[]|\\:\";'<>?,./'"
+	fakeServer := newFakeHTTPServer()
+	querierStore := &fakeQuerierStore{items: []query.Item{{Key: "order1"}, {Key: "order2"}}}
+	testAPI := &api{
+		compStore: newTestCompStore(map[string]state.Store{
+			"store1": fakeStateStore{},
+			"store2": querierStore,
+			"store3": querierStore,
+		}, nil),
+		json: jsoniter.ConfigFastest,
+	}
+	testAPI.compStore.SetStatePrefixDelete("store2", true)
+	fakeServer.StartServer(testAPI.constructStateEndpoints())
+
+	t.Run("Bulk delete state - 200", func(t *testing.T) {
+		b, _ := json.Marshal([]state.DeleteRequest{{Key: "good-key", ETag: etag}})
+		resp := fakeServer.DoRequest("POST", "v1.0/state/store1/bulk/delete", b, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+
+	t.Run("Bulk delete state - 409 etag mismatch", func(t *testing.T) {
+		b, _ := json.Marshal([]state.DeleteRequest{{Key: "good-key", ETag: "BAD ETAG"}})
+		resp := fakeServer.DoRequest("POST", "v1.0/state/store1/bulk/delete", b, nil)
+		assert.Equal(t, 409, resp.StatusCode)
+		assert.Equal(t, etag, resp.RawHeader.Get("ETag"))
+	})
+
+	t.Run("Delete state with prefix - 200", func(t *testing.T) {
+		resp := fakeServer.DoRequest("DELETE", "v1.0-alpha1/state/store2?prefix=order", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.JSONEq(t, `{"deleted": 2}`, string(resp.RawBody))
+		assert.Equal(t, "order", querierStore.req.KeyPrefix)
+		assert.Equal(t, []string{"order1", "order2"}, querierStore.deleted)
+	})
+
+	t.Run("Delete state with prefix - 400 no prefix", func(t *testing.T) {
+		resp := fakeServer.DoRequest("DELETE", "v1.0-alpha1/state/store2", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("Delete state with prefix - 403 not allowed", func(t *testing.T) {
+		resp := fakeServer.DoRequest("DELETE", "v1.0-alpha1/state/store3?prefix=order", nil, nil)
+		assert.Equal(t, 403, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_PREFIX_DELETE_NOT_ALLOWED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1StateSubscribeEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		compStore: newTestCompStore(map[string]state.Store{"store1": fakeStateStore{}}, nil),
+		id:        "app1",
+		json:      jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructStateEndpoints())
+
+	t.Run("Subscribe to state - 401 ERR_STATE_STORE_NOT_FOUND", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/state/notexistStore/subscribe?keys=key1", apiVersionV1alpha1), nil, nil)
+		assert.Equal(t, 401, resp.StatusCode)
+	})
+
+	t.Run("Subscribe to state - 400 no keys", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/state/store1/subscribe", apiVersionV1alpha1), nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Subscribe to state - 501 prefixes not supported", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/state/store1/subscribe?prefixes=order", apiVersionV1alpha1), nil, nil)
+		assert.Equal(t, 501, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_STORE_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1BulkGetStateEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		compStore: newTestCompStore(map[string]state.Store{"store1": fakeStateStore{}}, nil),
+		json:      jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructStateEndpoints())
+	apiPath := fmt.Sprintf("%s/state/store1/bulk", apiVersionV1)
+
+	t.Run("Bulk get - 200", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"keys": ["good-key", "missing-key"], "parallelism": 2}`), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		var items []bulkGetResponseItem
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &items))
+		assert.Len(t, items, 2)
+		assert.Equal(t, "good-key", items[0].Key)
+		assert.Equal(t, `"life is good"`, string(items[0].Data))
+		assert.NotEmpty(t, items[0].ETag)
+		assert.Equal(t, bulkGetResponseItem{Key: "missing-key"}, items[1])
+	})
+
+	t.Run("Invalid parallelism - 400", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", apiPath+"?metadata.parallelism=none", []byte(`{"keys": ["good-key"]}`), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1StateQueryEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	querier := &fakeQuerierStore{items: []query.Item{
+		{Key: "app1||key1", Data: []byte(`{"state":"CA"}`), ETag: "1"},
+		{Key: "app1||key2", Data: []byte(`{"state":"WA"}`)},
+	}}
+	testAPI := &api{
+		compStore: newTestCompStore(map[string]state.Store{"store1": querier, "store2": fakeStateStore{}}, nil),
+		id:        "app1",
+		json:      jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructStateEndpoints())
+
+	t.Run("Query - 200", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/state/store1/query", apiVersionV1alpha1)
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"filter": {"EQ": {"state": "CA"}}, "page": {"limit": 1}}`), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.JSONEq(t, `{"results": [{"key": "key1", "data": {"state": "CA"}, "etag": "1"}]}`, string(resp.RawBody))
+		assert.Equal(t, "app1||", querier.req.KeyPrefix)
+		assert.Equal(t, 1, querier.req.Query.Page.Limit)
+	})
+
+	t.Run("Malformed query - 400", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/state/store1/query", apiVersionV1alpha1)
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"filter": {"LIKE": {"state": "C%"}}}`), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Store without queries - 501", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/state/store2/query", apiVersionV1alpha1)
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{}`), nil)
+		assert.Equal(t, 501, resp.StatusCode)
+		assert.Equal(t, "ERR_STATE_STORE_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+// fakeQuerierStore evaluates queries on its items, in order
+type fakeQuerierStore struct {
+	fakeStateStore
+	items   []query.Item
+	req     *query.Request
+	deleted []string
+}
+
+func (f *fakeQuerierStore) BulkDelete(req []state.DeleteRequest) error {
+	for _, r := range req {
+		f.deleted = append(f.deleted, r.Key)
+	}
+	return nil
+}
+
+func (f *fakeQuerierStore) Query(req *query.Request) (*query.Response, error) {
+	f.req = req
+	resp := &query.Response{}
+	for _, item := range f.items {
+		var value interface{}
+		json.Unmarshal(item.Data, &value)
+		if req.Query.Filter == nil || req.Query.Filter.Match(value) {
+			resp.Results = append(resp.Results, item)
+		}
+		if req.Query.Page.Limit > 0 && len(resp.Results) == req.Query.Page.Limit {
+			break
+		}
+	}
+	return resp, nil
+}
+
+type fakeOutbox struct {
+	operations []state.TransactionalRequest
+	events     []outbox.Event
+}
+
+func (f *fakeOutbox) Transact(storeName string, operations []state.TransactionalRequest, events []outbox.Event) error {
+	f.operations = operations
+	f.events = events
+	return nil
+}
+
+func (f *fakeOutbox) Start(interval time.Duration) {}
+
+func (f *fakeOutbox) Stop() {}
+
+type fakeStateStore struct {
+	counter int
+}
+
+type fakeTransactionalStore struct {
+	fakeStateStore
+	operations []state.TransactionalRequest
+}
+
+func (f *fakeTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	f.operations = reqs
+	return nil
+}
+
+func (c fakeStateStore) BulkDelete(req []state.DeleteRequest) error {
+	for _, r := range req {
+		err := c.Delete(&r)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+func (c fakeStateStore) BulkSet(req []state.SetRequest) error {
+	for _, s := range req {
+		err := c.Set(&s)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+func (c fakeStateStore) Delete(req *state.DeleteRequest) error {
+	if req.Key == "good-key" {
+		if req.ETag != "" && req.ETag != "`~!@#$%^&*()_+-={}[]|\\:\";'<>?,./'" {
+			return errors.New("ETag mismatch")
+		}
+		return nil
+	} else if req.Key == "failed-key" {
+		return state.DeleteWithRetries(func(req *state.DeleteRequest) error {
+			retryCounter++
+			if retryCounter < 3 {
+				return errors.New("Simulated failure")
+			}
+			return nil
+		}, req)
+	}
+	return errors.New("NOT FOUND")
+}
+func (c fakeStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	if req.Key == "good-key" {
+		return &state.GetResponse{
+			Data: []byte("life is good"),
+			ETag: "`~!@#$%^&*()_+-={}[]|\\:\";'<>?,./'",
+		}, nil
+	}
+	return nil, nil
+}
+func (c fakeStateStore) Init(metadata state.Metadata) error {
+	c.counter = 0
+	return nil
 }
 func (c fakeStateStore) Set(req *state.SetRequest) error {
 	if req.Key == "good-key" {
@@ -1501,8 +2275,8 @@ func TestV1SecretEndpoints(t *testing.T) {
 		"store1": fakeStore,
 	}
 	testAPI := &api{
-		secretStores: fakeStores,
-		json:         jsoniter.ConfigFastest,
+		compStore: newTestCompStore(nil, fakeStores),
+		json:      jsoniter.ConfigFastest,
 	}
 	fakeServer.StartServer(testAPI.constructSecretEndpoints())
 	storeName := "store1"
@@ -1529,6 +2303,322 @@ func TestV1SecretEndpoints(t *testing.T) {
 	})
 }
 
+func TestV1BulkSecretEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		compStore: newTestCompStore(nil, map[string]secretstores.SecretStore{
+			"store1": fakeSecretStore{},
+		}),
+		json: jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructSecretEndpoints())
+
+	t.Run("secret store not found", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/secrets/notexistStore", nil, nil)
+		assert.Equal(t, 401, resp.StatusCode)
+		assert.Equal(t, "ERR_SECRET_STORE_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("all secrets of a store which can't list them", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/secrets/store1", nil, nil)
+		assert.Equal(t, 501, resp.StatusCode)
+		assert.Equal(t, "ERR_SECRET_STORE_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1SecretEndpointsWithScopes(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		compStore: newTestCompStore(nil, map[string]secretstores.SecretStore{
+			"store1": fakeSecretStore{},
+		}),
+		secretScopesFn: func() map[string]config.SecretsScope {
+			return map[string]config.SecretsScope{
+				"store1": {StoreName: "store1", DefaultAccess: config.DenyAccess, AllowedSecrets: []string{"bad-key"}},
+			}
+		},
+		json: jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructSecretEndpoints())
+
+	t.Run("denied secret", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/secrets/store1/good-key", nil, nil)
+		assert.Equal(t, 403, resp.StatusCode)
+		assert.Equal(t, "ERR_PERMISSION_DENIED", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("allowed secret", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/secrets/store1/bad-key", nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+	})
+
+	fakeServer.Shutdown()
+}
+
+type fakeAuditSink struct {
+	events []audit.Event
+}
+
+func (s *fakeAuditSink) Write(event audit.Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestV1AuditLog(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		compStore: newTestCompStore(map[string]state.Store{
+			"store1": fakeStateStore{},
+			"store2": &fakeTransactionalStore{},
+		}, map[string]secretstores.SecretStore{
+			"store1": fakeSecretStore{},
+		}),
+		secretScopesFn: func() map[string]config.SecretsScope {
+			return map[string]config.SecretsScope{
+				"store1": {StoreName: "store1", DefaultAccess: config.DenyAccess, AllowedSecrets: []string{"bad-key"}},
+			}
+		},
+		json: jsoniter.ConfigFastest,
+	}
+	endpoints := append(testAPI.constructSecretEndpoints(), testAPI.constructStateEndpoints()...)
+	fakeServer.StartServer(endpoints)
+
+	// doAuditedRequest sends the request and returns the audit events written once the request is served
+	doAuditedRequest := func(method, path string, body []byte) (fakeHTTPResponse, []audit.Event) {
+		sink := &fakeAuditSink{}
+		testAPI.auditLog = audit.NewLogger("app1", sink, false)
+		resp := fakeServer.DoRequest(method, path, body, nil)
+		testAPI.auditLog.Close()
+		return resp, sink.events
+	}
+
+	t.Run("allowed secret", func(t *testing.T) {
+		resp, events := doAuditedRequest("GET", "v1.0/secrets/store1/bad-key", nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.Len(t, events, 1)
+		assert.Equal(t, "app1", events[0].AppID)
+		assert.Equal(t, audit.GetSecret, events[0].Operation)
+		assert.Equal(t, "store1", events[0].Store)
+		assert.Equal(t, []string{"bad-key"}, events[0].Keys)
+		assert.Equal(t, audit.ResultSuccess, events[0].Result)
+		assert.NotEmpty(t, events[0].Caller)
+	})
+
+	t.Run("denied secret", func(t *testing.T) {
+		resp, events := doAuditedRequest("GET", "v1.0/secrets/store1/good-key", nil)
+		assert.Equal(t, 403, resp.StatusCode)
+		assert.Len(t, events, 1)
+		assert.Equal(t, audit.ResultDenied, events[0].Result)
+		assert.Contains(t, events[0].Error, "ERR_PERMISSION_DENIED")
+	})
+
+	t.Run("deleted state", func(t *testing.T) {
+		resp, events := doAuditedRequest("DELETE", "v1.0/state/store1/good-key", nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Len(t, events, 1)
+		assert.Equal(t, audit.DeleteState, events[0].Operation)
+		assert.Equal(t, []string{"good-key"}, events[0].Keys)
+		assert.Equal(t, audit.ResultSuccess, events[0].Result)
+	})
+
+	t.Run("failed state delete", func(t *testing.T) {
+		resp, events := doAuditedRequest("DELETE", "v1.0/state/store1/missing-key", nil)
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Len(t, events, 1)
+		assert.Equal(t, audit.ResultFailure, events[0].Result)
+		assert.Contains(t, events[0].Error, "ERR_STATE_DELETE")
+	})
+
+	t.Run("transaction deletes", func(t *testing.T) {
+		body := []byte(`{"operations":[{"operation":"upsert","request":{"key":"a","value":"1"}},{"operation":"delete","request":{"key":"b"}},{"operation":"delete","request":{"key":"c"}}]}`)
+		resp, events := doAuditedRequest("POST", "v1.0/state/store2/transaction", body)
+		assert.Equal(t, 201, resp.StatusCode)
+		assert.Len(t, events, 1)
+		assert.Equal(t, audit.TransactionDeleteState, events[0].Operation)
+		assert.Equal(t, "store2", events[0].Store)
+		assert.Equal(t, []string{"b", "c"}, events[0].Keys)
+		assert.Equal(t, audit.ResultSuccess, events[0].Result)
+	})
+
+	t.Run("transactions without deletes aren't audited", func(t *testing.T) {
+		body := []byte(`{"operations":[{"operation":"upsert","request":{"key":"a","value":"1"}}]}`)
+		resp, events := doAuditedRequest("POST", "v1.0/state/store2/transaction", body)
+		assert.Equal(t, 201, resp.StatusCode)
+		assert.Empty(t, events)
+	})
+
+	t.Run("reads aren't audited", func(t *testing.T) {
+		_, events := doAuditedRequest("GET", "v1.0/state/store1/good-key", nil)
+		assert.Empty(t, events)
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1DeniedAPIEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		compStore: newTestCompStore(nil, map[string]secretstores.SecretStore{
+			"store1": fakeSecretStore{},
+		}),
+		json:    jsoniter.ConfigFastest,
+		apiSpec: config.APISpec{Denied: []string{config.SecretsAPI}},
+	}
+	fakeServer.StartServer(testAPI.allowedEndpoints(config.SecretsAPI, testAPI.constructSecretEndpoints()))
+
+	t.Run("denied API", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/secrets/store1/good-key", nil, nil)
+		assert.Equal(t, 403, resp.StatusCode)
+		assert.Equal(t, "ERR_API_DENIED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+
+	testAPI.apiSpec = config.APISpec{Allowed: []string{config.StateAPI, config.SecretsAPI}}
+	fakeServer.StartServer(testAPI.allowedEndpoints(config.SecretsAPI, testAPI.constructSecretEndpoints()))
+
+	t.Run("allowed API", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0/secrets/store1/bad-key", nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+	})
+
+	fakeServer.Shutdown()
+}
+
+type fakeCachedSecretStore struct {
+	fakeSecretStore
+	flushed bool
+}
+
+func (c *fakeCachedSecretStore) Flush() {
+	c.flushed = true
+}
+
+func TestV1FlushSecretCache(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	store1, store2 := &fakeCachedSecretStore{}, &fakeCachedSecretStore{}
+	testAPI := &api{
+		compStore: newTestCompStore(nil, map[string]secretstores.SecretStore{
+			"store1": store1,
+			"store2": store2,
+			"store3": fakeSecretStore{},
+		}),
+		json: jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructMetadataEndpoints())
+
+	t.Run("flush the cache of a store", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/metadata/secrets/flush?storeName=store1", nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.True(t, store1.flushed)
+		assert.False(t, store2.flushed)
+	})
+
+	t.Run("flush the caches of all the stores", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/metadata/secrets/flush", nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.True(t, store2.flushed)
+	})
+
+	t.Run("secret store not found", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/metadata/secrets/flush?storeName=notexistStore", nil, nil)
+		assert.Equal(t, 401, resp.StatusCode)
+		assert.Equal(t, "ERR_SECRET_STORE_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1LoggingEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		adminToken: "logging-token",
+		json:       jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructMetadataEndpoints())
+	defer logger.NewLogger("dapr.runtime.http").SetOutputLevel(logger.InfoLevel)
+
+	doRequest := func(method, token string, body []byte) fakeHTTPResponse {
+		r, _ := gohttp.NewRequest(method, "http://localhost/v1.0/logging", bytes.NewBuffer(body))
+		if token != "" {
+			r.Header.Set(adminTokenHeader, token)
+		}
+		res, err := fakeServer.client.Do(r)
+		assert.NoError(t, err)
+		defer res.Body.Close()
+		bodyBytes, _ := ioutil.ReadAll(res.Body)
+		return fakeHTTPResponse{StatusCode: res.StatusCode, RawBody: bodyBytes}
+	}
+
+	t.Run("unauthenticated request", func(t *testing.T) {
+		assert.Equal(t, 401, doRequest("GET", "", nil).StatusCode)
+		assert.Equal(t, 401, doRequest("PUT", "wrong", []byte(`{"level":"debug"}`)).StatusCode)
+	})
+
+	t.Run("set the level of a scope", func(t *testing.T) {
+		res := doRequest("PUT", "logging-token", []byte(`{"level":"debug","scopes":["dapr.runtime.http"]}`))
+		assert.Equal(t, 200, res.StatusCode)
+		assert.Equal(t, logger.DebugLevel, logger.NewLogger("dapr.runtime.http").GetOutputLevel())
+	})
+
+	t.Run("get the levels", func(t *testing.T) {
+		res := doRequest("GET", "logging-token", nil)
+		assert.Equal(t, 200, res.StatusCode)
+		assert.Contains(t, string(res.RawBody), `{"name":"dapr.runtime.http","level":"debug"}`)
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		assert.Equal(t, 400, doRequest("PUT", "logging-token", []byte(`{"level":"verbose"}`)).StatusCode)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		assert.Equal(t, 400, doRequest("PUT", "logging-token", []byte(`{"level":"info","scopes":["dapr.unknown"]}`)).StatusCode)
+	})
+
+	t.Run("client locked out after repeated invalid tokens", func(t *testing.T) {
+		// the invalid tokens of the unauthenticated request count toward the lockout, the valid tokens don't reset them
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, 401, doRequest("GET", "wrong", nil).StatusCode)
+		}
+		assert.Equal(t, 429, doRequest("GET", "wrong", nil).StatusCode)
+		assert.Equal(t, 429, doRequest("GET", "logging-token", nil).StatusCode)
+	})
+
+	t.Run("admin endpoints disabled without token", func(t *testing.T) {
+		testAPI.adminToken = ""
+		assert.Equal(t, 403, doRequest("GET", "logging-token", nil).StatusCode)
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1ShutdownEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	requested := 0
+	testAPI := &api{
+		shutdownFn: func() { requested++ },
+		json:       jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructShutdownEndpoints())
+
+	t.Run("shutdown requested", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/shutdown", nil, nil)
+		assert.Equal(t, 204, resp.StatusCode)
+		assert.Equal(t, 1, requested)
+	})
+
+	t.Run("shutdown not supported", func(t *testing.T) {
+		testAPI.shutdownFn = nil
+		resp := fakeServer.DoRequest("POST", "v1.0/shutdown", nil, nil)
+		assert.Equal(t, 501, resp.StatusCode)
+		assert.Equal(t, "ERR_SHUTDOWN_NOT_SUPPORTED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
 type fakeSecretStore struct {
 }
 
@@ -1567,6 +2657,215 @@ func TestV1HealthzEndpoint(t *testing.T) {
 		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
 
 		assert.Equal(t, 200, resp.StatusCode)
+		assert.Empty(t, resp.RawBody)
+	})
+
+	t.Run("Healthz - 200 OK with unhealthy app", func(t *testing.T) {
+		apiPath := "v1.0/healthz"
+		testAPI.appHealthFn = func() string { return "unhealthy" }
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"appHealth":"unhealthy"}`, string(resp.RawBody))
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestV1ConfigurationEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		compStore: compstore.New(),
+		json:      jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructConfigurationEndpoints())
+
+	t.Run("configuration store not configured", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0-alpha1/configuration/store1?keys=key1", nil, nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_CONFIGURATION_STORE_NOT_CONFIGURED", resp.ErrorBody["errorCode"])
+	})
+
+	testAPI.compStore.AddConfigurationStore("store1", nil)
+
+	t.Run("configuration store not found", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0-alpha1/configuration/store2?keys=key1", nil, nil)
+		assert.Equal(t, 401, resp.StatusCode)
+		assert.Equal(t, "ERR_CONFIGURATION_STORE_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("subscribe to a store not found", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0-alpha1/configuration/store2/subscribe?keys=key1", nil, nil)
+		assert.Equal(t, 401, resp.StatusCode)
+		assert.Equal(t, "ERR_CONFIGURATION_STORE_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+// fakeKeyVault wraps keys by XORing them with the key name
+type fakeKeyVault struct{}
+
+func (fakeKeyVault) Init(metadata crypto.Metadata) error {
+	return nil
+}
+
+func (fakeKeyVault) WrapKey(ctx context.Context, key []byte, keyName, algorithm string) ([]byte, error) {
+	wrapped := make([]byte, len(key))
+	for i := range key {
+		wrapped[i] = key[i] ^ keyName[i%len(keyName)]
+	}
+	return wrapped, nil
+}
+
+func (v fakeKeyVault) UnwrapKey(ctx context.Context, wrappedKey []byte, keyName, algorithm string) ([]byte, error) {
+	return v.WrapKey(ctx, wrappedKey, keyName, algorithm)
+}
+
+func TestV1CryptoEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		compStore: compstore.New(),
+		json:      jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructCryptoEndpoints())
+
+	t.Run("key vault not configured", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/crypto/vault1/encrypt?keyName=key1", []byte("secret"), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_CRYPTO_VAULT_NOT_CONFIGURED", resp.ErrorBody["errorCode"])
+	})
+
+	testAPI.compStore.AddKeyVault("vault1", fakeKeyVault{})
+
+	t.Run("key vault not found", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/crypto/vault2/encrypt?keyName=key1", []byte("secret"), nil)
+		assert.Equal(t, 401, resp.StatusCode)
+		assert.Equal(t, "ERR_CRYPTO_VAULT_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("key name is required", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/crypto/vault1/encrypt", []byte("secret"), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/crypto/vault1/encrypt?keyName=key1", []byte("secret"), nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "application/octet-stream", resp.ContentType)
+		assert.NotContains(t, string(resp.RawBody), "secret")
+
+		resp = fakeServer.DoRequest("POST", "v1.0-alpha1/crypto/vault1/decrypt?keyName=key1", resp.RawBody, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "secret", string(resp.RawBody))
+	})
+
+	t.Run("decrypt an invalid payload", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/crypto/vault1/decrypt", []byte("secret"), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_CRYPTO_DECRYPT", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+type fakeWorkflowEngine struct {
+	instances map[string]*workflows.Instance
+}
+
+func (f *fakeWorkflowEngine) Start(ctx context.Context, workflowName, instanceID string, input json.RawMessage) error {
+	if workflowName == "broken" {
+		return errors.New("app unavailable")
+	}
+	if _, ok := f.instances[instanceID]; ok {
+		return workflows.ErrInstanceExists
+	}
+	// the route values of fasthttp are only valid during the request
+	instanceID = string([]byte(instanceID))
+	f.instances[instanceID] = &workflows.Instance{ID: instanceID, WorkflowName: workflowName, Status: workflows.StatusRunning, Input: input}
+	return nil
+}
+
+func (f *fakeWorkflowEngine) Get(ctx context.Context, workflowName, instanceID string) (*workflows.Instance, error) {
+	instance, ok := f.instances[instanceID]
+	if !ok {
+		return nil, workflows.ErrInstanceNotFound
+	}
+	return instance, nil
+}
+
+func (f *fakeWorkflowEngine) Terminate(ctx context.Context, workflowName, instanceID string) error {
+	instance, ok := f.instances[instanceID]
+	if !ok {
+		return workflows.ErrInstanceNotFound
+	}
+	instance.Status = workflows.StatusTerminated
+	return nil
+}
+
+func (f *fakeWorkflowEngine) RaiseEvent(ctx context.Context, workflowName, instanceID, eventName string, data json.RawMessage) error {
+	if _, ok := f.instances[instanceID]; !ok {
+		return workflows.ErrInstanceNotFound
+	}
+	return nil
+}
+
+func TestV1WorkflowEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		json: jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructWorkflowEndpoints())
+
+	t.Run("workflows not configured", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/workflows/order/o1/start", []byte("{}"), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_WORKFLOWS_NOT_CONFIGURED", resp.ErrorBody["errorCode"])
+	})
+
+	engine := &fakeWorkflowEngine{instances: map[string]*workflows.Instance{}}
+	testAPI.workflows = engine
+
+	t.Run("start workflow", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/workflows/order/o1/start", []byte(`{"item":"book"}`), nil)
+		assert.Equal(t, 202, resp.StatusCode)
+		assert.Equal(t, `{"item":"book"}`, string(engine.instances["o1"].Input))
+	})
+
+	t.Run("start with malformed input", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/workflows/order/o2/start", []byte("not json"), nil)
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("start existing instance", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/workflows/order/o1/start", []byte("{}"), nil)
+		assert.Equal(t, 409, resp.StatusCode)
+		assert.Equal(t, "ERR_WORKFLOW_INSTANCE_EXISTS", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("start with engine error", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/workflows/broken/b1/start", []byte("{}"), nil)
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_START_WORKFLOW", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("get missing workflow", func(t *testing.T) {
+		resp := fakeServer.DoRequest("GET", "v1.0-alpha1/workflows/order/missing", nil, nil)
+		assert.Equal(t, 404, resp.StatusCode)
+		assert.Equal(t, "ERR_WORKFLOW_INSTANCE_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("raise event", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/workflows/order/o1/raiseEvent/approved", []byte("true"), nil)
+		assert.Equal(t, 202, resp.StatusCode)
+	})
+
+	t.Run("terminate workflow", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0-alpha1/workflows/order/o1/terminate", nil, nil)
+		assert.Equal(t, 202, resp.StatusCode)
+		assert.Equal(t, workflows.StatusTerminated, engine.instances["o1"].Status)
 	})
 
 	fakeServer.Shutdown()
@@ -5,6 +5,8 @@
 
 package http
 
+import "crypto/tls"
+
 // ServerConfig holds config values for an HTTP server
 type ServerConfig struct {
 	AllowedOrigins  string
@@ -13,16 +15,27 @@ type ServerConfig struct {
 	Port            int
 	ProfilePort     int
 	EnableProfiling bool
+	// UnixDomainSocket is the path of the Unix domain socket the server listens on instead of its port
+	UnixDomainSocket string
+	// MaxRequestBodySize is the maximum size in MB of the body of the requests, the default of the server is
+	// used when it is zero
+	MaxRequestBodySize int
+	// TLSConfig serves the API over HTTPS on the port of the server when it is set, the Unix domain socket is
+	// only reachable on the host and is served without TLS
+	TLSConfig *tls.Config
 }
 
 // NewServerConfig returns a new HTTP server config
-func NewServerConfig(appID string, hostAddress string, port int, profilePort int, allowedOrigins string, enableProfiling bool) ServerConfig {
+func NewServerConfig(appID string, hostAddress string, port int, profilePort int, allowedOrigins string, enableProfiling bool, unixDomainSocket string, maxRequestBodySize int, tlsConfig *tls.Config) ServerConfig {
 	return ServerConfig{
-		AllowedOrigins:  allowedOrigins,
-		AppID:           appID,
-		HostAddress:     hostAddress,
-		Port:            port,
-		ProfilePort:     profilePort,
-		EnableProfiling: enableProfiling,
+		AllowedOrigins:     allowedOrigins,
+		AppID:              appID,
+		HostAddress:        hostAddress,
+		Port:               port,
+		ProfilePort:        profilePort,
+		EnableProfiling:    enableProfiling,
+		UnixDomainSocket:   unixDomainSocket,
+		MaxRequestBodySize: maxRequestBodySize,
+		TLSConfig:          tlsConfig,
 	}
 }
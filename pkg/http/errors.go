@@ -7,8 +7,9 @@ package http
 
 // ErrorResponse is an HTTP response message sent back to calling clients by the Dapr Runtime HTTP API
 type ErrorResponse struct {
-	ErrorCode string `json:"errorCode"`
-	Message   string `json:"message"`
+	ErrorCode string   `json:"errorCode"`
+	Message   string   `json:"message"`
+	Details   []string `json:"details,omitempty"`
 }
 
 // NewErrorResponse returns a new ErrorResponse
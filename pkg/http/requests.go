@@ -5,8 +5,60 @@
 
 package http
 
+import (
+	"github.com/dapr/components-contrib/state"
+	jsoniter "github.com/json-iterator/go"
+)
+
 // OutputBindingRequest is the request object to invoke an output binding
 type OutputBindingRequest struct {
 	Metadata map[string]string `json:"metadata"`
 	Data     interface{}       `json:"data"`
 }
+
+// bulkPublishEntry is a single event of a bulk publish request
+type bulkPublishEntry struct {
+	EntryID     string              `json:"entryId"`
+	Event       jsoniter.RawMessage `json:"event"`
+	ContentType string              `json:"contentType,omitempty"`
+	Metadata    map[string]string   `json:"metadata,omitempty"`
+}
+
+// bulkGetRequest is a request to get the state of several keys
+type bulkGetRequest struct {
+	Keys        []string `json:"keys"`
+	Parallelism int      `json:"parallelism,omitempty"`
+}
+
+// stateTransactionRequest is a state transaction along with the events to publish once it is committed
+type stateTransactionRequest struct {
+	Operations []stateTransactionOperation `json:"operations"`
+	Outbox     []outboxEvent               `json:"outbox,omitempty"`
+}
+
+// stateTransactionOperation is a single upsert or delete operation of a state transaction
+type stateTransactionOperation struct {
+	Operation state.OperationType `json:"operation"`
+	Request   interface{}         `json:"request"`
+}
+
+// outboxEvent is an event published once the state transaction it is part of is committed
+type outboxEvent struct {
+	Topic       string              `json:"topic"`
+	Data        jsoniter.RawMessage `json:"data"`
+	ContentType string              `json:"contentType,omitempty"`
+	Metadata    map[string]string   `json:"metadata,omitempty"`
+}
+
+// loggingRequest sets the log level of the loggers in the given scopes, or of all the loggers without scopes
+type loggingRequest struct {
+	Level  string   `json:"level"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// scheduleJobRequest is the schedule, due time and data of a job
+type scheduleJobRequest struct {
+	Schedule string              `json:"schedule,omitempty"`
+	DueTime  string              `json:"dueTime,omitempty"`
+	Data     jsoniter.RawMessage `json:"data,omitempty"`
+}
@@ -7,15 +7,98 @@ package http
 
 import (
 	"encoding/json"
+	"sort"
+	"time"
 
+	"github.com/dapr/dapr/pkg/logger"
 	"github.com/valyala/fasthttp"
 )
 
 const (
 	jsonContentTypeHeader = "application/json"
 	etagHeader            = "ETag"
+	// eventStreamContentType is the content type of server-sent events streams
+	eventStreamContentType = "text/event-stream"
+	// binaryContentType is the content type of the encrypted and decrypted payloads of the crypto API
+	binaryContentType = "application/octet-stream"
 )
 
+// bulkGetResponseItem is the state of a key of a bulk get request
+type bulkGetResponseItem struct {
+	Key   string          `json:"key"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	ETag  string          `json:"etag,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// stateQueryResponse is a page of the results of a state query
+type stateQueryResponse struct {
+	Results []stateQueryItem `json:"results"`
+	Token   string           `json:"token,omitempty"`
+}
+
+// stateQueryItem is a state entry matching a state query
+type stateQueryItem struct {
+	Key  string          `json:"key"`
+	Data json.RawMessage `json:"data"`
+	ETag string          `json:"etag,omitempty"`
+}
+
+// stateChangeEvent is the data of a server-sent event notifying the change of a subscribed key
+type stateChangeEvent struct {
+	Key     string          `json:"key"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	ETag    string          `json:"etag,omitempty"`
+	Deleted bool            `json:"deleted,omitempty"`
+}
+
+// loggingResponse is the log level of each logger of the sidecar
+type loggingResponse struct {
+	Loggers []loggerLevel `json:"loggers"`
+}
+
+// loggerLevel is the log level of a logger
+type loggerLevel struct {
+	Name  string          `json:"name"`
+	Level logger.LogLevel `json:"level"`
+}
+
+// newLoggingResponse returns the log level of each logger, sorted by logger name
+func newLoggingResponse() loggingResponse {
+	resp := loggingResponse{Loggers: []loggerLevel{}}
+	for name, level := range logger.GetLoggersOutputLevel() {
+		resp.Loggers = append(resp.Loggers, loggerLevel{Name: name, Level: level})
+	}
+	sort.Slice(resp.Loggers, func(i, j int) bool {
+		return resp.Loggers[i].Name < resp.Loggers[j].Name
+	})
+	return resp
+}
+
+// healthzResponse is the health of the app reported by the app health check of the sidecar
+type healthzResponse struct {
+	AppHealth string `json:"appHealth"`
+}
+
+// prefixDeleteResponse is the number of keys deleted by a prefix delete
+type prefixDeleteResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// jobResponse is a job of the app and its next run
+// startWorkflowResponse is the response of a started workflow instance
+type startWorkflowResponse struct {
+	InstanceID string `json:"instanceID"`
+}
+
+type jobResponse struct {
+	Name     string          `json:"name"`
+	Schedule string          `json:"schedule,omitempty"`
+	DueTime  string          `json:"dueTime,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	NextRun  time.Time       `json:"nextRun"`
+}
+
 // respondWithJSON overrides the content-type with application/json
 func respondWithJSON(ctx *fasthttp.RequestCtx, code int, obj []byte) {
 	respond(ctx, code, obj)
@@ -6,8 +6,12 @@
 package http
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net"
 	"strings"
+	"sync"
+	"time"
 
 	cors "github.com/AdhityaRamadhanus/fasthttpcors"
 	"github.com/dapr/dapr/pkg/config"
@@ -15,6 +19,7 @@ import (
 
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	http_middleware "github.com/dapr/dapr/pkg/middleware/http"
+	auth "github.com/dapr/dapr/pkg/runtime/security"
 	routing "github.com/fasthttp/router"
 	"github.com/valyala/fasthttp"
 	"github.com/valyala/fasthttp/pprofhandler"
@@ -22,9 +27,13 @@ import (
 
 var log = logger.NewLogger("dapr.runtime.http")
 
+// unixDomainSocketMode lets the app connect to the Unix domain socket of the server when it runs as another user
+const unixDomainSocketMode = 0666
+
 // Server is an interface for the Dapr HTTP server
 type Server interface {
 	StartNonBlocking()
+	Shutdown(timeout time.Duration) error
 }
 
 type server struct {
@@ -32,6 +41,10 @@ type server struct {
 	tracingSpec config.TracingSpec
 	pipeline    http_middleware.Pipeline
 	api         API
+	srv         *fasthttp.Server
+
+	shutdownLock sync.Mutex
+	shuttingDown bool
 }
 
 // NewServer returns a new HTTP server
@@ -52,11 +65,29 @@ func (s *server) StartNonBlocking() {
 				s.useComponents(
 					s.useRouter())))
 
+	handler = s.useAPIAuthentication(handler)
 	handler = s.useMetrics(handler)
 	handler = s.useTracing(handler)
 
+	s.srv = &fasthttp.Server{
+		Handler:            handler,
+		MaxRequestBodySize: s.config.MaxRequestBodySize << 20,
+		ErrorHandler:       s.onServeError,
+	}
 	go func() {
-		log.Fatal(fasthttp.ListenAndServe(fmt.Sprintf(":%v", s.config.Port), handler))
+		var err error
+		switch {
+		case s.config.UnixDomainSocket != "":
+			err = s.srv.ListenAndServeUNIX(s.config.UnixDomainSocket, unixDomainSocketMode)
+		case s.config.TLSConfig != nil:
+			err = s.listenAndServeTLS()
+		default:
+			err = s.srv.ListenAndServe(fmt.Sprintf(":%v", s.config.Port))
+		}
+		// the server returns when it's shut down, which mustn't exit the process while the runtime stops
+		if err != nil && !s.isShuttingDown() {
+			log.Fatal(err)
+		}
 	}()
 
 	if s.config.EnableProfiling {
@@ -67,6 +98,61 @@ func (s *server) StartNonBlocking() {
 	}
 }
 
+// listenAndServeTLS serves HTTPS on the port of the server with its TLS config, which may rotate the certificate
+func (s *server) listenAndServeTLS() error {
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%v", s.config.Port))
+	if err != nil {
+		return err
+	}
+	return s.srv.Serve(tls.NewListener(ln, s.config.TLSConfig))
+}
+
+// Shutdown stops accepting requests and waits for the requests in progress until the timeout
+func (s *server) Shutdown(timeout time.Duration) error {
+	if s.srv == nil {
+		return nil
+	}
+	s.shutdownLock.Lock()
+	s.shuttingDown = true
+	s.shutdownLock.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.srv.Shutdown()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s waiting for the requests in progress", timeout)
+	}
+}
+
+func (s *server) isShuttingDown() bool {
+	s.shutdownLock.Lock()
+	defer s.shutdownLock.Unlock()
+	return s.shuttingDown
+}
+
+// onServeError responds to the requests the server fails to read, so clients sending a body larger than the
+// maximum request body size get an explicit error
+func (s *server) onServeError(ctx *fasthttp.RequestCtx, err error) {
+	if err == fasthttp.ErrBodyTooLarge {
+		msg := NewErrorResponse("ERR_REQUEST_TOO_LARGE", fmt.Sprintf("the request body exceeds the maximum request size of %vMB", s.maxRequestBodySize()))
+		respondWithError(ctx, fasthttp.StatusRequestEntityTooLarge, msg)
+		return
+	}
+	ctx.Error("Error when parsing request", fasthttp.StatusBadRequest)
+}
+
+// maxRequestBodySize returns the maximum size in MB of the body of the requests
+func (s *server) maxRequestBodySize() int {
+	if s.config.MaxRequestBodySize > 0 {
+		return s.config.MaxRequestBodySize
+	}
+	return fasthttp.DefaultMaxRequestBodySize >> 20
+}
+
 func (s *server) useTracing(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 	log.Infof("enabled tracing http middleware")
 	return diag.SetTracingSpanContextFromHTTPContext(next, s.tracingSpec)
@@ -79,6 +165,34 @@ func (s *server) useMetrics(next fasthttp.RequestHandler) fasthttp.RequestHandle
 	return next
 }
 
+// useAPIAuthentication rejects the requests without the API token when DAPR_API_TOKEN is set, except the health
+// checks. The token is removed from the requests so it isn't forwarded to the apps.
+func (s *server) useAPIAuthentication(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	token := auth.GetAPIToken()
+	if token == "" {
+		return next
+	}
+	log.Info("enabled token authentication on http server")
+	healthzPath := fmt.Sprintf("/%s/healthz", apiVersionV1)
+	verifier := auth.SharedAPITokenVerifier(token)
+	return func(ctx *fasthttp.RequestCtx) {
+		if string(ctx.Path()) != healthzPath {
+			err := verifier.Verify(ctx.RemoteIP().String(), string(ctx.Request.Header.Peek(auth.APITokenHeader)))
+			if err == auth.ErrAPITokenLockedOut {
+				msg := NewErrorResponse("ERR_API_TOKEN_LOCKED_OUT", "too many requests with an invalid API token, try again later")
+				respondWithError(ctx, fasthttp.StatusTooManyRequests, msg)
+				return
+			} else if err != nil {
+				msg := NewErrorResponse("ERR_API_TOKEN_INVALID", fmt.Sprintf("the request must have a valid %s header", auth.APITokenHeader))
+				respondWithError(ctx, fasthttp.StatusUnauthorized, msg)
+				return
+			}
+		}
+		ctx.Request.Header.Del(auth.APITokenHeader)
+		next(ctx)
+	}
+}
+
 func (s *server) useRouter() fasthttp.RequestHandler {
 	endpoints := s.api.APIEndpoints()
 	router := s.getRouter(endpoints)
@@ -1,9 +1,17 @@
 package http
 
 import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	auth "github.com/dapr/dapr/pkg/runtime/security"
 	"github.com/stretchr/testify/assert"
 	"github.com/valyala/fasthttp"
 )
@@ -35,6 +43,117 @@ func TestUseProxy(t *testing.T) {
 	h(&fasthttp.RequestCtx{})
 }
 
+func TestOnServeError(t *testing.T) {
+	t.Run("request body too large", func(t *testing.T) {
+		s := &server{config: ServerConfig{MaxRequestBodySize: 8}}
+		ctx := &fasthttp.RequestCtx{}
+		s.onServeError(ctx, fasthttp.ErrBodyTooLarge)
+
+		assert.Equal(t, fasthttp.StatusRequestEntityTooLarge, ctx.Response.StatusCode())
+		var resp ErrorResponse
+		assert.NoError(t, json.Unmarshal(ctx.Response.Body(), &resp))
+		assert.Equal(t, "ERR_REQUEST_TOO_LARGE", resp.ErrorCode)
+		assert.Contains(t, resp.Message, "8MB")
+	})
+
+	t.Run("default maximum request body size", func(t *testing.T) {
+		s := &server{}
+		ctx := &fasthttp.RequestCtx{}
+		s.onServeError(ctx, fasthttp.ErrBodyTooLarge)
+
+		assert.Equal(t, fasthttp.StatusRequestEntityTooLarge, ctx.Response.StatusCode())
+		assert.Contains(t, string(ctx.Response.Body()), "4MB")
+	})
+
+	t.Run("malformed request", func(t *testing.T) {
+		s := &server{}
+		ctx := &fasthttp.RequestCtx{}
+		s.onServeError(ctx, io.ErrUnexpectedEOF)
+
+		assert.Equal(t, fasthttp.StatusBadRequest, ctx.Response.StatusCode())
+	})
+}
+
+func TestUseAPIAuthentication(t *testing.T) {
+	os.Setenv(auth.APITokenEnvVar, "token1")
+	defer os.Unsetenv(auth.APITokenEnvVar)
+
+	var forwardedToken string
+	s := NewTestServer()
+	h := s.useAPIAuthentication(func(ctx *fasthttp.RequestCtx) {
+		forwardedToken = string(ctx.Request.Header.Peek(auth.APITokenHeader))
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	})
+
+	// the client address isn't shared with the other tests, as the lockout of the API tokens is shared
+	serve := func(path, token string) *fasthttp.RequestCtx {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Init(&fasthttp.Request{}, &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}, nil)
+		ctx.Request.SetRequestURI(path)
+		if token != "" {
+			ctx.Request.Header.Set(auth.APITokenHeader, token)
+		}
+		h(ctx)
+		return ctx
+	}
+
+	t.Run("missing token", func(t *testing.T) {
+		ctx := serve("/v1.0/state/store1", "")
+		assert.Equal(t, fasthttp.StatusUnauthorized, ctx.Response.StatusCode())
+		assert.Contains(t, string(ctx.Response.Body()), "ERR_API_TOKEN_INVALID")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := serve("/v1.0/state/store1", "token2")
+		assert.Equal(t, fasthttp.StatusUnauthorized, ctx.Response.StatusCode())
+	})
+
+	t.Run("valid token is not forwarded", func(t *testing.T) {
+		ctx := serve("/v1.0/state/store1", "token1")
+		assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+		assert.Empty(t, forwardedToken)
+	})
+
+	t.Run("health checks are not authenticated", func(t *testing.T) {
+		ctx := serve("/v1.0/healthz", "")
+		assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+	})
+
+	t.Run("client locked out after repeated invalid tokens", func(t *testing.T) {
+		// the missing and invalid tokens of the cases above count toward the lockout, the valid tokens don't reset them
+		for i := 0; i < 3; i++ {
+			ctx := serve("/v1.0/state/store1", "token2")
+			assert.Equal(t, fasthttp.StatusUnauthorized, ctx.Response.StatusCode())
+		}
+
+		ctx := serve("/v1.0/state/store1", "token2")
+		assert.Equal(t, fasthttp.StatusTooManyRequests, ctx.Response.StatusCode())
+		assert.Contains(t, string(ctx.Response.Body()), "ERR_API_TOKEN_LOCKED_OUT")
+
+		// the valid tokens of a locked out client are rejected too
+		ctx = serve("/v1.0/state/store1", "token1")
+		assert.Equal(t, fasthttp.StatusTooManyRequests, ctx.Response.StatusCode())
+	})
+}
+
 func NewTestServer() *server { //nolint:golint
 	return &server{}
 }
+
+func TestShutdown(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dapr-http")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := &server{
+		api:    &api{},
+		config: ServerConfig{UnixDomainSocket: filepath.Join(dir, "dapr-http.socket")},
+	}
+	s.StartNonBlocking()
+	time.Sleep(50 * time.Millisecond)
+
+	// the server returning once it's shut down doesn't exit the process
+	assert.NoError(t, s.Shutdown(time.Second))
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, s.isShuttingDown())
+}
@@ -14,6 +14,7 @@ import (
 	scheme "github.com/dapr/dapr/pkg/client/clientset/versioned"
 	"github.com/dapr/dapr/pkg/credentials"
 	"github.com/dapr/dapr/pkg/runtime"
+	auth "github.com/dapr/dapr/pkg/runtime/security"
 	"github.com/dapr/dapr/pkg/sentry/certs"
 	"k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +45,9 @@ const (
 	daprReadinessProbeTimeoutKey      = "dapr.io/sidecar-readiness-probe-timeout-seconds"
 	daprReadinessProbePeriodKey       = "dapr.io/sidecar-readiness-probe-period-seconds"
 	daprReadinessProbeThresholdKey    = "dapr.io/sidecar-readiness-probe-threshold"
+	daprAPITokenSecretKey             = "dapr.io/api-token-secret"
+	daprAppTokenSecretKey             = "dapr.io/app-token-secret"
+	apiTokenSecretKey                 = "token"
 	sidecarHTTPPort                   = 3500
 	sidecarAPIGRPCPort                = 50001
 	sidecarInternalGRPCPort           = 50002
@@ -217,6 +221,29 @@ func logAsJSONEnabled(annotations map[string]string) bool {
 	return getBoolAnnotationOrDefault(annotations, daprLogAsJSON, defaultLogAsJSON)
 }
 
+func getAPITokenSecret(annotations map[string]string) string {
+	return getStringAnnotation(annotations, daprAPITokenSecretKey)
+}
+
+func getAppTokenSecret(annotations map[string]string) string {
+	return getStringAnnotation(annotations, daprAppTokenSecretKey)
+}
+
+// tokenSecretEnvVar returns the environment variable reading a token from the token key of a secret
+func tokenSecretEnvVar(name, secret string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				Key: apiTokenSecretKey,
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secret,
+				},
+			},
+		},
+	}
+}
+
 func profilingEnabled(annotations map[string]string) bool {
 	return getBoolAnnotationOrDefault(annotations, daprProfilingKey, false)
 }
@@ -436,6 +463,14 @@ func getSidecarContainer(annotations map[string]string, id, daprSidecarImage, na
 		c.Args = append(c.Args, "--enable-profiling")
 	}
 
+	// the token the Dapr APIs require and the token sent to the app are read from the token key of their secrets
+	if secret := getAPITokenSecret(annotations); secret != "" {
+		c.Env = append(c.Env, tokenSecretEnvVar(auth.APITokenEnvVar, secret))
+	}
+	if secret := getAppTokenSecret(annotations); secret != "" {
+		c.Env = append(c.Env, tokenSecretEnvVar(auth.AppAPITokenEnvVar, secret))
+	}
+
 	if mtlsEnabled && trustAnchors != "" {
 		c.Args = append(c.Args, "--enable-mtls")
 		c.Env = append(c.Env, corev1.EnvVar{
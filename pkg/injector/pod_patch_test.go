@@ -63,3 +63,19 @@ func TestGetSideCarContainer(t *testing.T) {
 
 	assert.EqualValues(t, expectedArgs, container.Args)
 }
+
+func TestGetSideCarContainerWithTokenSecrets(t *testing.T) {
+	annotations := map[string]string{
+		daprAPITokenSecretKey: "dapr-api-token",
+		daprAppTokenSecretKey: "app-api-token",
+	}
+
+	container, _ := getSidecarContainer(annotations, "app_id", "darpio/dapr", "dapr-system", "controlplane:9000", "placement:50000", nil, "", "", "", "sentry:50000", false, "pod_identity")
+
+	env := container.Env[len(container.Env)-2:]
+	assert.Equal(t, "DAPR_API_TOKEN", env[0].Name)
+	assert.Equal(t, "dapr-api-token", env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "token", env[0].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, "APP_API_TOKEN", env[1].Name)
+	assert.Equal(t, "app-api-token", env[1].ValueFrom.SecretKeyRef.Name)
+}
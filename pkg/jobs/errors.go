@@ -0,0 +1,18 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package jobs
+
+import "fmt"
+
+// InvalidJobError is returned when a job is scheduled without a name or with an invalid schedule or due time
+type InvalidJobError struct {
+	Name   string
+	Reason string
+}
+
+func (e InvalidJobError) Error() string {
+	return fmt.Sprintf("invalid job %s: %s", e.Name, e.Reason)
+}
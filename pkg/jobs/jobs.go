@@ -0,0 +1,296 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/state/partitions"
+)
+
+const (
+	// DefaultPollInterval is the default longest interval between two reads of the jobs. The scheduler also
+	// wakes up when the next job is due and when a job is scheduled through this replica.
+	DefaultPollInterval = time.Second * 30
+	// runClaim is how long a due one-shot job is claimed by the replica running it. The job is deleted once
+	// it ran successfully, otherwise it is run again when the claim expires.
+	runClaim = time.Minute
+
+	jobsKeyPart = "jobs"
+)
+
+var log = logger.NewLogger("dapr.runtime.jobs")
+
+// Scheduler persists the jobs of the app in a state store and invokes the app when they are due
+type Scheduler interface {
+	ScheduleJob(job Job) error
+	DeleteJob(name string) error
+	ListJobs() ([]Job, error)
+	Start(interval time.Duration)
+	Stop()
+}
+
+// Job is a job of the app. A job with a schedule runs repeatedly, a job with only a due time runs once.
+// A job with both first runs at the due time and then on the schedule.
+type Job struct {
+	Name string `json:"name"`
+	// Schedule is a cron expression, a cron descriptor such as @daily or an interval such as @every 1h
+	Schedule string `json:"schedule,omitempty"`
+	// DueTime is an RFC3339 time or a duration from the time the job is scheduled
+	DueTime string `json:"dueTime,omitempty"`
+	Data    []byte `json:"data,omitempty"`
+	// NextRun is persisted so that the runs missed while the runtime is down are run once it restarts
+	NextRun time.Time `json:"nextRun"`
+}
+
+type scheduler struct {
+	partitions *partitions.Partitions
+	invokeFn   func(*Job) error
+
+	// wake wakes the scheduler up when a job is scheduled
+	wake     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScheduler returns a Scheduler which stores each job of the app as a record of the jobs partitions of
+// the state store. The partitions are updated with optimistic concurrency, so a due job is only run by the
+// replica of the app which first records its next run.
+func NewScheduler(appID string, store state.Store, invokeFn func(*Job) error) Scheduler {
+	return &scheduler{
+		partitions: partitions.New(store, partitions.DefaultCount, jobsKeyPart, appID),
+		invokeFn:   invokeFn,
+		wake:       make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+}
+
+// ScheduleJob saves the job, replacing the job with the same name.
+// An InvalidJobError is returned if the job has no name or an invalid schedule or due time.
+func (s *scheduler) ScheduleJob(job Job) error {
+	if job.Name == "" {
+		return InvalidJobError{Reason: "job name is required"}
+	}
+	nextRun, err := firstRun(&job, time.Now())
+	if err != nil {
+		return InvalidJobError{Name: job.Name, Reason: err.Error()}
+	}
+	job.NextRun = nextRun
+
+	if err := s.partitions.Put(job.Name, job); err != nil {
+		return fmt.Errorf("error saving job %s: %s", job.Name, err)
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// DeleteJob deletes the job, deleting a job which doesn't exist is not an error
+func (s *scheduler) DeleteJob(name string) error {
+	if err := s.partitions.Remove(s.partitions.Of(name), name); err != nil {
+		return fmt.Errorf("error deleting job %s: %s", name, err)
+	}
+	return nil
+}
+
+// ListJobs returns the jobs of the app sorted by name
+func (s *scheduler) ListJobs() ([]Job, error) {
+	jobs := []Job{}
+	for partition := 0; partition < s.partitions.Count(); partition++ {
+		records, _, err := s.partitions.Get(partition)
+		if err != nil {
+			return nil, fmt.Errorf("error getting jobs: %s", err)
+		}
+		for name, b := range records {
+			var j Job
+			if err := json.Unmarshal(b, &j); err != nil {
+				return nil, fmt.Errorf("error deserializing job %s: %s", name, err)
+			}
+			jobs = append(jobs, j)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+	return jobs, nil
+}
+
+// Start creates the partitions of the jobs, and runs the due jobs when the next job is due, when a job is
+// scheduled, and at most after the given interval, as the jobs may be scheduled through other replicas
+func (s *scheduler) Start(interval time.Duration) {
+	if err := s.partitions.Create(); err != nil {
+		log.Warnf("error creating the jobs partitions: %s", err)
+	}
+	timer := time.NewTimer(0)
+	go func() {
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C:
+			case <-s.wake:
+				if !timer.Stop() {
+					<-timer.C
+				}
+			case <-s.done:
+				return
+			}
+
+			now := time.Now()
+			wait := interval
+			if next := s.runDue(now); !next.IsZero() && next.Sub(now) < wait {
+				wait = next.Sub(now)
+			}
+			timer.Reset(wait)
+		}
+	}()
+}
+
+// Stop stops running the due jobs, which stay saved
+func (s *scheduler) Stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// runDue runs the due jobs of every partition and returns the next run of the remaining jobs
+func (s *scheduler) runDue(now time.Time) time.Time {
+	var next time.Time
+	for partition := 0; partition < s.partitions.Count(); partition++ {
+		if n := s.runDuePartition(partition, now); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
+
+// runDuePartition records the next runs of the due jobs of the partition and invokes the app for each of them,
+// and returns the next run of the remaining jobs. When another replica of the app updated the partition
+// meanwhile, the record is attempted again on the jobs read again, so the jobs it ran aren't due anymore.
+// A one-shot job is claimed rather than deleted, and deleted once it ran successfully.
+func (s *scheduler) runDuePartition(partition int, now time.Time) time.Time {
+	claimedUntil := now.Add(runClaim)
+	var due, remaining []Job
+	err := s.partitions.Update(partition, func(records partitions.Records) (bool, error) {
+		due, remaining = nil, nil
+		for name, b := range records {
+			var j Job
+			if err := json.Unmarshal(b, &j); err != nil {
+				log.Warnf("dropping job %s which can't be deserialized: %s", name, err)
+				delete(records, name)
+				continue
+			}
+			if j.NextRun.After(now) {
+				remaining = append(remaining, j)
+				continue
+			}
+			due = append(due, j)
+			if j.Schedule == "" {
+				j.NextRun = claimedUntil
+			} else if sched, err := parseSchedule(j.Schedule); err != nil {
+				log.Warnf("dropping job %s: %s", j.Name, err)
+				j.NextRun = time.Time{}
+			} else {
+				j.NextRun = sched.next(now)
+			}
+			if j.NextRun.IsZero() {
+				delete(records, name)
+				continue
+			}
+			remaining = append(remaining, j)
+			b, err := json.Marshal(j)
+			if err != nil {
+				return false, err
+			}
+			records[name] = b
+		}
+		return len(due) > 0, nil
+	})
+	next := nextRun(remaining)
+	if err != nil {
+		log.Debugf("failed to record the next runs of the due jobs, they may be run by another replica: %s", err)
+		return next
+	}
+
+	for i := range due {
+		if err := s.invokeFn(&due[i]); err != nil {
+			log.Warnf("error running job %s: %s", due[i].Name, err)
+			continue
+		}
+		if due[i].Schedule == "" {
+			s.deleteRanJob(partition, due[i].Name, claimedUntil)
+		}
+	}
+	return next
+}
+
+// deleteRanJob deletes a one-shot job which ran successfully, unless it was scheduled again meanwhile
+func (s *scheduler) deleteRanJob(partition int, name string, claimedUntil time.Time) {
+	err := s.partitions.Update(partition, func(records partitions.Records) (bool, error) {
+		b, ok := records[name]
+		if !ok {
+			return false, nil
+		}
+		var j Job
+		if err := json.Unmarshal(b, &j); err != nil || !j.NextRun.Equal(claimedUntil) {
+			return false, nil
+		}
+		delete(records, name)
+		return true, nil
+	})
+	if err != nil {
+		log.Warnf("failed to delete job %s which ran, it runs again once its claim expires: %s", name, err)
+	}
+}
+
+// nextRun returns the earliest next run of the jobs, or zero if there are no jobs
+func nextRun(jobs []Job) time.Time {
+	var next time.Time
+	for _, j := range jobs {
+		if next.IsZero() || j.NextRun.Before(next) {
+			next = j.NextRun
+		}
+	}
+	return next
+}
+
+// firstRun validates the schedule and due time of the job and returns its first run
+func firstRun(job *Job, now time.Time) (time.Time, error) {
+	if job.Schedule == "" && job.DueTime == "" {
+		return time.Time{}, errors.New("job schedule or due time is required")
+	}
+
+	var sched schedule
+	if job.Schedule != "" {
+		var err error
+		if sched, err = parseSchedule(job.Schedule); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if job.DueTime == "" {
+		next := sched.next(now)
+		if next.IsZero() {
+			return time.Time{}, fmt.Errorf("schedule %s never runs", job.Schedule)
+		}
+		return next, nil
+	}
+
+	if d, err := time.ParseDuration(job.DueTime); err == nil {
+		return now.Add(d), nil
+	}
+	t, err := time.Parse(time.RFC3339, job.DueTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due time %s, must be an RFC3339 time or a duration", job.DueTime)
+	}
+	return t, nil
+}
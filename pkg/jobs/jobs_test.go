@@ -0,0 +1,259 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore is a state store with etags, which are the versions of the keys. As the state stores of
+// components-contrib, it saves the keys unconditionally when the ETag is empty.
+type fakeStore struct {
+	items    map[string][]byte
+	versions map[string]int
+	lock     sync.Mutex
+	// beforeSet is called before the keys are saved
+	beforeSet func()
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{items: map[string][]byte{}, versions: map[string]int{}}
+}
+
+func (f *fakeStore) Init(metadata state.Metadata) error {
+	return nil
+}
+
+func (f *fakeStore) Delete(req *state.DeleteRequest) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if req.ETag != "" && req.ETag != strconv.Itoa(f.versions[req.Key]) {
+		return errors.New("etag mismatch")
+	}
+	delete(f.items, req.Key)
+	delete(f.versions, req.Key)
+	return nil
+}
+
+func (f *fakeStore) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, ok := f.items[req.Key]; !ok {
+		return &state.GetResponse{}, nil
+	}
+	return &state.GetResponse{Data: f.items[req.Key], ETag: strconv.Itoa(f.versions[req.Key])}, nil
+}
+
+func (f *fakeStore) Set(req *state.SetRequest) error {
+	if f.beforeSet != nil {
+		f.beforeSet()
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if req.ETag != "" && req.ETag != strconv.Itoa(f.versions[req.Key]) {
+		return errors.New("etag mismatch")
+	}
+	b, _ := json.Marshal(req.Value)
+	f.items[req.Key] = b
+	f.versions[req.Key]++
+	return nil
+}
+
+func (f *fakeStore) BulkSet(req []state.SetRequest) error {
+	return nil
+}
+
+func TestScheduleJob(t *testing.T) {
+	s := NewScheduler("app1", newFakeStore(), nil)
+
+	t.Run("jobs are saved", func(t *testing.T) {
+		assert.NoError(t, s.ScheduleJob(Job{Name: "b", Schedule: "@hourly", Data: []byte("1")}))
+		assert.NoError(t, s.ScheduleJob(Job{Name: "a", DueTime: "1h"}))
+
+		jobs, err := s.ListJobs()
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 2)
+		assert.Equal(t, "a", jobs[0].Name)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), jobs[0].NextRun, time.Minute)
+		assert.Equal(t, "b", jobs[1].Name)
+		assert.Equal(t, []byte("1"), jobs[1].Data)
+		assert.Equal(t, 0, jobs[1].NextRun.Minute())
+	})
+
+	t.Run("job with the same name is replaced", func(t *testing.T) {
+		assert.NoError(t, s.ScheduleJob(Job{Name: "a", DueTime: "2020-06-10T10:00:00Z"}))
+
+		jobs, err := s.ListJobs()
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 2)
+		assert.Equal(t, time.Date(2020, 6, 10, 10, 0, 0, 0, time.UTC), jobs[0].NextRun.UTC())
+	})
+
+	t.Run("invalid jobs", func(t *testing.T) {
+		err := s.ScheduleJob(Job{Schedule: "@hourly"})
+		assert.True(t, errors.As(err, &InvalidJobError{}))
+		assert.Error(t, s.ScheduleJob(Job{Name: "c"}))
+		assert.Error(t, s.ScheduleJob(Job{Name: "c", Schedule: "* *"}))
+		assert.Error(t, s.ScheduleJob(Job{Name: "c", DueTime: "tomorrow"}))
+	})
+
+	t.Run("jobs are deleted", func(t *testing.T) {
+		assert.NoError(t, s.DeleteJob("a"))
+		assert.NoError(t, s.DeleteJob("unknown"))
+
+		jobs, err := s.ListJobs()
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 1)
+		assert.Equal(t, "b", jobs[0].Name)
+	})
+}
+
+func TestRunDueJobs(t *testing.T) {
+	store := newFakeStore()
+	var invoked []string
+	invokeFn := func(job *Job) error {
+		invoked = append(invoked, job.Name)
+		return nil
+	}
+	s := NewScheduler("app1", store, invokeFn).(*scheduler)
+
+	now := time.Now()
+	assert.NoError(t, s.ScheduleJob(Job{Name: "once", DueTime: "1s"}))
+	assert.NoError(t, s.ScheduleJob(Job{Name: "repeated", DueTime: "1s", Schedule: "@every 1h"}))
+	assert.NoError(t, s.ScheduleJob(Job{Name: "later", DueTime: "1h"}))
+
+	t.Run("jobs not due yet", func(t *testing.T) {
+		s.runDue(now)
+		assert.Empty(t, invoked)
+	})
+
+	t.Run("due jobs are run", func(t *testing.T) {
+		s.runDue(now.Add(2 * time.Second))
+		assert.ElementsMatch(t, []string{"once", "repeated"}, invoked)
+
+		jobs, err := s.ListJobs()
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 2)
+		assert.Equal(t, "repeated", jobs[1].Name)
+		assert.WithinDuration(t, now.Add(time.Hour+2*time.Second), jobs[1].NextRun, time.Second)
+	})
+
+	t.Run("jobs are run by a single replica", func(t *testing.T) {
+		invoked = nil
+		replica := NewScheduler("app1", store, invokeFn).(*scheduler)
+		s.runDue(now.Add(2 * time.Hour))
+		replica.runDue(now.Add(2 * time.Hour))
+		assert.ElementsMatch(t, []string{"repeated", "later"}, invoked)
+	})
+
+	t.Run("the next run is returned", func(t *testing.T) {
+		jobs, err := s.ListJobs()
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 1)
+		assert.Equal(t, jobs[0].NextRun, s.runDue(now.Add(2*time.Hour)))
+	})
+}
+
+func TestFailedJobRunsAgain(t *testing.T) {
+	fail := true
+	runs := 0
+	invokeFn := func(job *Job) error {
+		runs++
+		if fail {
+			return errors.New("app unavailable")
+		}
+		return nil
+	}
+	s := NewScheduler("app1", newFakeStore(), invokeFn).(*scheduler)
+	now := time.Now()
+	assert.NoError(t, s.ScheduleJob(Job{Name: "once", DueTime: "1s"}))
+
+	s.runDue(now.Add(2 * time.Second))
+	assert.Equal(t, 1, runs)
+	jobs, err := s.ListJobs()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+
+	// the job is claimed by the failed run
+	s.runDue(now.Add(3 * time.Second))
+	assert.Equal(t, 1, runs)
+
+	fail = false
+	s.runDue(now.Add(2*time.Second + runClaim))
+	assert.Equal(t, 2, runs)
+	jobs, err = s.ListJobs()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestConcurrentScheduleIsAttemptedAgain(t *testing.T) {
+	store := newFakeStore()
+	s := NewScheduler("app1", store, nil).(*scheduler)
+	replica := NewScheduler("app1", store, nil).(*scheduler)
+	assert.NoError(t, s.partitions.Create())
+	// "a" and the job of the replica are saved in the same partition
+	name := ""
+	for i := 0; name == ""; i++ {
+		if n := "b" + strconv.Itoa(i); s.partitions.Of(n) == s.partitions.Of("a") {
+			name = n
+		}
+	}
+	store.beforeSet = func() {
+		store.beforeSet = nil
+		assert.NoError(t, replica.ScheduleJob(Job{Name: name, DueTime: "1h"}))
+	}
+
+	assert.NoError(t, s.ScheduleJob(Job{Name: "a", DueTime: "1h"}))
+	jobs, err := s.ListJobs()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+}
+
+func TestJobsAreSpreadOverPartitions(t *testing.T) {
+	store := newFakeStore()
+	s := NewScheduler("app1", store, nil)
+	for i := 0; i < 50; i++ {
+		assert.NoError(t, s.ScheduleJob(Job{Name: "job" + strconv.Itoa(i), DueTime: "1h"}))
+	}
+
+	jobs, err := s.ListJobs()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 50)
+	assert.True(t, len(store.items) > 1)
+}
+
+func TestAppKeysAreNotRead(t *testing.T) {
+	store := newFakeStore()
+	assert.NoError(t, store.Set(&state.SetRequest{Key: "app1||jobs", Value: []Job{{Name: "a"}}}))
+	s := NewScheduler("app1", store, nil)
+	s.Start(time.Hour)
+	defer s.Stop()
+
+	jobs, err := s.ListJobs()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+	_, ok := store.items["app1||jobs"]
+	assert.True(t, ok)
+}
+
+func TestStop(t *testing.T) {
+	s := NewScheduler("app1", newFakeStore(), nil)
+	s.Start(time.Millisecond)
+	s.Stop()
+	s.Stop()
+}
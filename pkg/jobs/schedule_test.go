@@ -0,0 +1,54 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// a wednesday
+	now := time.Date(2020, 6, 10, 10, 30, 15, 0, time.UTC)
+
+	testCases := []struct {
+		schedule string
+		next     time.Time
+	}{
+		{"* * * * *", time.Date(2020, 6, 10, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2020, 6, 10, 10, 45, 0, 0, time.UTC)},
+		{"0 9-17 * * 1-5", time.Date(2020, 6, 10, 11, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2020, 6, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, 6, 14, 0, 0, 0, 0, time.UTC)},
+		{"30 8 1,15 * *", time.Date(2020, 6, 15, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 * 5", time.Date(2020, 6, 12, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2020, 6, 11, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", time.Date(2020, 6, 10, 10, 31, 45, 0, time.UTC)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.schedule, func(t *testing.T) {
+			s, err := parseSchedule(tc.schedule)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.next, s.next(now))
+		})
+	}
+
+	t.Run("never runs", func(t *testing.T) {
+		s, err := parseSchedule("0 0 30 2 *")
+		assert.NoError(t, err)
+		assert.True(t, s.next(now).IsZero())
+	})
+
+	t.Run("invalid schedules", func(t *testing.T) {
+		for _, schedule := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *", "@every 1ms", "@every x"} {
+			_, err := parseSchedule(schedule)
+			assert.Error(t, err, schedule)
+		}
+	})
+}
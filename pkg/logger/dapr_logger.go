@@ -84,6 +84,16 @@ func (l *daprLogger) SetOutputLevel(outputLevel LogLevel) {
 	l.logger.Logger.SetLevel(toLogrusLevel(outputLevel))
 }
 
+// GetOutputLevel returns log output level
+func (l *daprLogger) GetOutputLevel() LogLevel {
+	level := l.logger.Logger.GetLevel()
+	if level == logrus.WarnLevel {
+		// logrus names the level warning
+		return WarnLevel
+	}
+	return toLogLevel(level.String())
+}
+
 // WithLogType specify the log_type field in log. Default value is LogTypeLog
 func (l *daprLogger) WithLogType(logType string) Logger {
 	return &daprLogger{
@@ -6,6 +6,8 @@
 package logger
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -15,6 +17,8 @@ const (
 	LogTypeLog = "log"
 	// LogTypeRequest is Request log type
 	LogTypeRequest = "request"
+	// LogTypeAudit is the type of the audit log of the access to secrets and sensitive APIs
+	LogTypeAudit = "audit"
 
 	// Field names that defines Dapr log schema
 	logFieldTimeStamp = "time"
@@ -60,6 +64,8 @@ type Logger interface {
 	SetAppID(id string)
 	// SetOutputLevel sets log output level
 	SetOutputLevel(outputLevel LogLevel)
+	// GetOutputLevel returns log output level
+	GetOutputLevel() LogLevel
 
 	// WithLogType specify the log_type field in log. Default value is LogTypeLog
 	WithLogType(logType string) Logger
@@ -130,3 +136,48 @@ func getLoggers() map[string]Logger {
 
 	return l
 }
+
+// GetLoggersOutputLevel returns the output level of each registered logger
+func GetLoggersOutputLevel() map[string]LogLevel {
+	levels := map[string]LogLevel{}
+	for name, l := range getLoggers() {
+		levels[name] = l.GetOutputLevel()
+	}
+	return levels
+}
+
+// SetLoggersOutputLevel sets the output level of the registered loggers in the given scopes at runtime, or of all
+// the loggers when no scope is given. A scope is the name of a logger and includes the loggers named after it, such
+// as dapr.runtime.actor for the actors building block. It returns the names of the updated loggers, sorted.
+func SetLoggersOutputLevel(outputLevel string, scopes ...string) ([]string, error) {
+	level := toLogLevel(outputLevel)
+	if level == UndefinedLevel {
+		return nil, fmt.Errorf("undefined Log Output Level:%s", outputLevel)
+	}
+
+	loggers := getLoggers()
+	matches := map[string]bool{}
+	for _, scope := range scopes {
+		found := false
+		for name := range loggers {
+			if name == scope || strings.HasPrefix(name, scope+".") {
+				matches[name] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no logger in scope %s", scope)
+		}
+	}
+
+	updated := []string{}
+	for name, l := range loggers {
+		if len(scopes) > 0 && !matches[name] {
+			continue
+		}
+		l.SetOutputLevel(level)
+		updated = append(updated, name)
+	}
+	sort.Strings(updated)
+	return updated, nil
+}
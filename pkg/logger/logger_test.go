@@ -61,3 +61,45 @@ func TestToLogLevel(t *testing.T) {
 		assert.Equal(t, UndefinedLevel, toLogLevel("undefined"))
 	})
 }
+
+func TestSetLoggersOutputLevel(t *testing.T) {
+	clearLoggers()
+	NewLogger("dapr.runtime").SetOutputLevel(InfoLevel)
+	NewLogger("dapr.runtime.actor").SetOutputLevel(InfoLevel)
+	NewLogger("dapr.runtime.actors").SetOutputLevel(InfoLevel)
+
+	t.Run("set the level of a scope", func(t *testing.T) {
+		updated, err := SetLoggersOutputLevel("debug", "dapr.runtime.actor")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"dapr.runtime.actor"}, updated)
+		assert.Equal(t, map[string]LogLevel{
+			"dapr.runtime":        InfoLevel,
+			"dapr.runtime.actor":  DebugLevel,
+			"dapr.runtime.actors": InfoLevel,
+		}, GetLoggersOutputLevel())
+	})
+
+	t.Run("set the level of all the loggers", func(t *testing.T) {
+		updated, err := SetLoggersOutputLevel("warn")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"dapr.runtime", "dapr.runtime.actor", "dapr.runtime.actors"}, updated)
+		assert.Equal(t, WarnLevel, NewLogger("dapr.runtime").GetOutputLevel())
+	})
+
+	t.Run("scopes include the loggers named after them", func(t *testing.T) {
+		updated, err := SetLoggersOutputLevel("error", "dapr.runtime")
+		assert.NoError(t, err)
+		assert.Len(t, updated, 3)
+	})
+
+	t.Run("undefined level", func(t *testing.T) {
+		_, err := SetLoggersOutputLevel("verbose")
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := SetLoggersOutputLevel("debug", "dapr.runtime.unknown")
+		assert.Error(t, err)
+		assert.Equal(t, ErrorLevel, NewLogger("dapr.runtime").GetOutputLevel())
+	})
+}
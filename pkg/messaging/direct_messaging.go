@@ -9,11 +9,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/dapr/components-contrib/servicediscovery"
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/modes"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc"
@@ -21,11 +24,16 @@ import (
 	"google.golang.org/grpc/status"
 
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
 )
 
+var log = logger.NewLogger("dapr.runtime.direct_messaging")
+
 const (
 	invokeRemoteRetryCount = 3
+	// hedgeResolveAttempts is how many times the target app is resolved to find another instance for a hedge request
+	hedgeResolveAttempts = 3
 )
 
 // messageClientConnection is the function type to connect to the other
@@ -44,8 +52,23 @@ type directMessaging struct {
 	mode                modes.DaprMode
 	grpcPort            int
 	namespace           string
+	namespaceIsolated   bool
+	trustDomain         string
 	resolver            servicediscovery.Resolver
 	tracingSpec         config.TracingSpec
+	metadataLimits      invokev1.MetadataLimits
+	externalChannels    map[string]channel.AppChannel
+	hedgingDelay        time.Duration
+}
+
+type remoteInvokeFn func(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error)
+
+// hedgedInvokeFn sends a request to an instance of the target app
+type hedgedInvokeFn func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error)
+
+type invokeResult struct {
+	resp *invokev1.InvokeMethodResponse
+	err  error
 }
 
 // NewDirectMessaging returns a new direct messaging api
@@ -55,7 +78,12 @@ func NewDirectMessaging(
 	appChannel channel.AppChannel,
 	clientConnFn messageClientConnection,
 	resolver servicediscovery.Resolver,
-	tracingSpec config.TracingSpec) DirectMessaging {
+	tracingSpec config.TracingSpec,
+	metadataLimits invokev1.MetadataLimits,
+	externalChannels map[string]channel.AppChannel,
+	hedgingDelay time.Duration,
+	trustDomain string,
+	namespaceIsolated bool) DirectMessaging {
 	return &directMessaging{
 		appChannel:          appChannel,
 		connectionCreatorFn: clientConnFn,
@@ -65,15 +93,43 @@ func NewDirectMessaging(
 		namespace:           namespace,
 		resolver:            resolver,
 		tracingSpec:         tracingSpec,
+		metadataLimits:      metadataLimits,
+		externalChannels:    externalChannels,
+		hedgingDelay:        hedgingDelay,
+		trustDomain:         trustDomain,
+		namespaceIsolated:   namespaceIsolated,
 	}
 }
 
-// Invoke takes a message requests and invokes an app, either local or remote
+// Invoke takes a message requests and invokes an app, either local, remote or an external HTTP endpoint.
+// The apps of other namespaces are targeted as <app-id>.<namespace> when namespaces are isolated.
 func (d *directMessaging) Invoke(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
-	if targetAppID == d.appID {
-		return d.invokeLocal(ctx, req)
+	if err := d.metadataLimits.Check(req.Metadata()); err != nil {
+		return nil, err
+	}
+
+	var resp *invokev1.InvokeMethodResponse
+	var err error
+	if externalChannel, ok := d.externalChannels[targetAppID]; ok {
+		resp, err = externalChannel.InvokeMethod(ctx, req)
+	} else if id, namespace := d.parseTarget(targetAppID); id == d.appID && namespace == d.namespace {
+		resp, err = d.invokeLocal(ctx, req)
+	} else {
+		// the headers of the responses of other apps are trimmed by their sidecar
+		fn := d.invokeRemote
+		if d.hedgingDelay > 0 && isIdempotentRequest(req) {
+			fn = d.invokeRemoteHedged
+		}
+		return d.invokeWithRetry(ctx, invokeRemoteRetryCount, targetAppID, fn, req)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return d.invokeWithRetry(ctx, invokeRemoteRetryCount, targetAppID, d.invokeRemote, req)
+
+	if removed := d.metadataLimits.Trim(resp.Headers()); len(removed) > 0 {
+		log.Warnf("removed the headers %v exceeding the metadata limits from the response of method %s", removed, req.Message().Method)
+	}
+	return resp, nil
 }
 
 // invokeWithRetry will call a remote endpoint for the specified number of retries and will only retry in the case of transient failures
@@ -83,7 +139,7 @@ func (d *directMessaging) invokeWithRetry(
 	ctx context.Context,
 	numRetries int,
 	targetID string,
-	fn func(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error),
+	fn remoteInvokeFn,
 	req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
 	for i := 0; i < numRetries; i++ {
 		resp, err := fn(ctx, targetID, req)
@@ -108,11 +164,93 @@ func (d *directMessaging) invokeWithRetry(
 	return nil, fmt.Errorf("failed to invoke target %s after %v retries", targetID, numRetries)
 }
 
+// invokeRemoteHedged sends the hedge request to another instance of the target app than the first request.
+// Requests are not hedged when the resolver doesn't return another address, as the hedge would only repeat the
+// call to the same slow instance.
+func (d *directMessaging) invokeRemoteHedged(ctx context.Context, targetID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	address, err := d.getAddressFromMessageRequest(targetID)
+	if err != nil {
+		return nil, err
+	}
+	first := func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+		return d.invokeRemoteAt(ctx, address, targetID, req)
+	}
+	hedgeAddress, ok := d.getHedgeAddress(targetID, address)
+	if !ok {
+		return first(ctx, req)
+	}
+	hedge := func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+		return d.invokeRemoteAt(ctx, hedgeAddress, targetID, req)
+	}
+	return invokeHedged(ctx, d.hedgingDelay, first, hedge, req)
+}
+
+// getHedgeAddress resolves the target app until the resolver returns another address than the address of the
+// first request, it returns false if it doesn't
+func (d *directMessaging) getHedgeAddress(targetID, address string) (string, bool) {
+	for i := 0; i < hedgeResolveAttempts; i++ {
+		hedgeAddress, err := d.getAddressFromMessageRequest(targetID)
+		if err == nil && hedgeAddress != address {
+			return hedgeAddress, true
+		}
+	}
+	return "", false
+}
+
+// invokeHedged sends the request and, if no response has arrived after delay, sends a copy of the request as a
+// hedge request. The first successful response is returned and the outstanding request is cancelled.
+func invokeHedged(ctx context.Context, delay time.Duration, first, hedge hedgedInvokeFn, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan invokeResult, 2)
+	call := func(fn hedgedInvokeFn, req *invokev1.InvokeMethodRequest) {
+		resp, err := fn(ctx, req)
+		results <- invokeResult{resp: resp, err: err}
+	}
+	// the requests are changed while they are sent, so each request has its own copy
+	hedgeReq := req.Clone()
+	go call(first, req)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case r := <-results:
+		return r.resp, r.err
+	case <-timer.C:
+		go call(hedge, hedgeReq)
+	}
+
+	r := <-results
+	if r.err == nil {
+		return r.resp, nil
+	}
+	r = <-results
+	return r.resp, r.err
+}
+
+// isIdempotentRequest returns true for GET-like requests which are safe to hedge
+func isIdempotentRequest(req *invokev1.InvokeMethodRequest) bool {
+	httpExt := req.Message().GetHttpExtension()
+	if httpExt == nil {
+		return false
+	}
+	switch httpExt.GetVerb() {
+	case commonv1pb.HTTPExtension_GET, commonv1pb.HTTPExtension_HEAD, commonv1pb.HTTPExtension_OPTIONS:
+		return true
+	}
+	return false
+}
+
 func (d *directMessaging) invokeLocal(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
 	if d.appChannel == nil {
 		return nil, errors.New("cannot invoke local endpoint: app channel not initialized")
 	}
 
+	// The app is calling itself, so the caller identity is known without a certificate
+	req.WithCallerIdentity(d.appID, d.namespace, d.trustDomain)
+
 	return d.appChannel.InvokeMethod(ctx, req)
 }
 
@@ -121,7 +259,11 @@ func (d *directMessaging) invokeRemote(ctx context.Context, targetID string, req
 	if err != nil {
 		return nil, err
 	}
+	return d.invokeRemoteAt(ctx, address, targetID, req)
+}
 
+// invokeRemoteAt sends the request to the instance of the target app at the address
+func (d *directMessaging) invokeRemoteAt(ctx context.Context, address, targetID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
 	conn, err := d.connectionCreatorFn(address, targetID, false, false)
 	if err != nil {
 		return nil, err
@@ -147,7 +289,20 @@ func (d *directMessaging) invokeRemote(ctx context.Context, targetID string, req
 	return invokev1.InternalInvokeResponse(resp)
 }
 
-func (d *directMessaging) getAddressFromMessageRequest(appID string) (string, error) {
-	request := servicediscovery.ResolveRequest{ID: appID, Namespace: d.namespace, Port: d.grpcPort}
+func (d *directMessaging) getAddressFromMessageRequest(target string) (string, error) {
+	appID, namespace := d.parseTarget(target)
+	request := servicediscovery.ResolveRequest{ID: appID, Namespace: namespace, Port: d.grpcPort}
 	return d.resolver.ResolveID(request)
 }
+
+// parseTarget returns the id and the namespace of the target app. When namespaces are isolated, the target of an
+// app of another namespace is <app-id>.<namespace>, the ids of the apps can hold dots otherwise.
+func (d *directMessaging) parseTarget(target string) (string, string) {
+	if !d.namespaceIsolated {
+		return target, d.namespace
+	}
+	if i := strings.LastIndex(target, "."); i > 0 {
+		return target[:i], target[i+1:]
+	}
+	return target, d.namespace
+}
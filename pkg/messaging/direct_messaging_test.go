@@ -0,0 +1,140 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/servicediscovery"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsIdempotentRequest(t *testing.T) {
+	assert.True(t, isIdempotentRequest(invokev1.NewInvokeMethodRequest("method").WithHTTPExtension("GET", "")))
+	assert.True(t, isIdempotentRequest(invokev1.NewInvokeMethodRequest("method").WithHTTPExtension("HEAD", "")))
+	assert.False(t, isIdempotentRequest(invokev1.NewInvokeMethodRequest("method").WithHTTPExtension("POST", "")))
+	assert.False(t, isIdempotentRequest(invokev1.NewInvokeMethodRequest("method")))
+}
+
+func TestInvokeHedged(t *testing.T) {
+	req := invokev1.NewInvokeMethodRequest("method").WithHTTPExtension("GET", "")
+
+	t.Run("fast response does not send a hedge request", func(t *testing.T) {
+		var calls int32
+		fn := func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+			atomic.AddInt32(&calls, 1)
+			return invokev1.NewInvokeMethodResponse(200, "", nil), nil
+		}
+
+		resp, err := invokeHedged(context.Background(), time.Second, fn, fn, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(200), resp.Status().Code)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("slow response is hedged and cancelled", func(t *testing.T) {
+		cancelled := make(chan struct{})
+		first := func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+			<-ctx.Done()
+			close(cancelled)
+			return nil, ctx.Err()
+		}
+		hedge := func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+			return invokev1.NewInvokeMethodResponse(201, "", nil), nil
+		}
+
+		resp, err := invokeHedged(context.Background(), time.Millisecond, first, hedge, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(201), resp.Status().Code)
+		<-cancelled
+	})
+
+	t.Run("both requests fail", func(t *testing.T) {
+		first := func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+			time.Sleep(10 * time.Millisecond)
+			return nil, errors.New("failed")
+		}
+		hedge := func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+			return nil, errors.New("failed")
+		}
+
+		_, err := invokeHedged(context.Background(), time.Millisecond, first, hedge, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("hedge request is a copy of the request", func(t *testing.T) {
+		requests := make(chan *invokev1.InvokeMethodRequest, 2)
+		first := func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+			requests <- req
+			req.WithCallerIdentity("app1", "", "")
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		hedge := func(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+			requests <- req
+			req.WithCallerIdentity("app1", "", "")
+			return invokev1.NewInvokeMethodResponse(200, "", nil), nil
+		}
+
+		_, err := invokeHedged(context.Background(), time.Millisecond, first, hedge, req)
+		assert.NoError(t, err)
+		assert.True(t, <-requests != <-requests)
+	})
+}
+
+func TestGetHedgeAddress(t *testing.T) {
+	t.Run("another instance", func(t *testing.T) {
+		d := &directMessaging{resolver: &fakeResolver{addresses: []string{"10.0.0.1:50002", "10.0.0.2:50002"}}}
+		address, ok := d.getHedgeAddress("app1", "10.0.0.1:50002")
+		assert.True(t, ok)
+		assert.Equal(t, "10.0.0.2:50002", address)
+	})
+
+	t.Run("single instance", func(t *testing.T) {
+		d := &directMessaging{resolver: &fakeResolver{addresses: []string{"10.0.0.1:50002"}}}
+		_, ok := d.getHedgeAddress("app1", "10.0.0.1:50002")
+		assert.False(t, ok)
+	})
+}
+
+// fakeResolver returns its addresses in turn
+type fakeResolver struct {
+	addresses []string
+	next      int
+}
+
+func (f *fakeResolver) ResolveID(req servicediscovery.ResolveRequest) (string, error) {
+	address := f.addresses[f.next%len(f.addresses)]
+	f.next++
+	return address, nil
+}
+
+func TestParseTarget(t *testing.T) {
+	t.Run("isolated namespaces", func(t *testing.T) {
+		d := &directMessaging{namespace: "ns1", namespaceIsolated: true}
+
+		id, namespace := d.parseTarget("app1")
+		assert.Equal(t, "app1", id)
+		assert.Equal(t, "ns1", namespace)
+
+		id, namespace = d.parseTarget("app1.ns2")
+		assert.Equal(t, "app1", id)
+		assert.Equal(t, "ns2", namespace)
+	})
+
+	t.Run("ids with dots without isolation", func(t *testing.T) {
+		d := &directMessaging{namespace: "ns1"}
+
+		id, namespace := d.parseTarget("app1.v2")
+		assert.Equal(t, "app1.v2", id)
+		assert.Equal(t, "ns1", namespace)
+	})
+}
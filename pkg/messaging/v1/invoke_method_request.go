@@ -12,7 +12,9 @@ import (
 
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	internalv1pb "github.com/dapr/dapr/pkg/proto/daprinternal/v1"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 )
 
 const (
@@ -58,6 +60,11 @@ func InternalInvokeRequest(pb *internalv1pb.InternalInvokeRequest) (*InvokeMetho
 	return req, nil
 }
 
+// Clone returns a deep copy of the request, which can be changed without changing the request
+func (imr *InvokeMethodRequest) Clone() *InvokeMethodRequest {
+	return &InvokeMethodRequest{r: proto.Clone(imr.r).(*internalv1pb.InternalInvokeRequest)}
+}
+
 // WithActor sets actor type and id
 func (imr *InvokeMethodRequest) WithActor(actorType, actorID string) *InvokeMethodRequest {
 	imr.r.Actor = &internalv1pb.Actor{ActorType: actorType, ActorId: actorID}
@@ -166,3 +173,34 @@ func (imr *InvokeMethodRequest) RawData() (string, []byte) {
 
 	return contentType, dataValue
 }
+
+// WithCallerIdentity removes any caller supplied identity headers from the metadata
+// and sets the verified identity of the caller. Empty values are not set.
+func (imr *InvokeMethodRequest) WithCallerIdentity(appID, namespace, trustDomain string) *InvokeMethodRequest {
+	if imr.r.Metadata == nil {
+		imr.r.Metadata = DaprInternalMetadata{}
+	}
+
+	for k := range imr.r.Metadata {
+		if isCallerIdentityHeaderKey(k) {
+			delete(imr.r.Metadata, k)
+		}
+	}
+
+	identity := map[string]string{
+		CallerAppIDHeader:       appID,
+		CallerNamespaceHeader:   namespace,
+		CallerTrustDomainHeader: trustDomain,
+	}
+	for k, v := range identity {
+		if v == "" {
+			continue
+		}
+		imr.r.Metadata[k] = &structpb.ListValue{
+			Values: []*structpb.Value{
+				{Kind: &structpb.Value_StringValue{StringValue: v}},
+			},
+		}
+	}
+	return imr
+}
@@ -132,3 +132,43 @@ func TestProto(t *testing.T) {
 	assert.Equal(t, "application/json", req2.GetMessage().ContentType)
 	assert.Equal(t, []byte("test"), req2.GetMessage().Data.Value)
 }
+
+func TestClone(t *testing.T) {
+	req := NewInvokeMethodRequest("test_method")
+	req.WithMetadata(map[string][]string{"custom": {"value"}})
+	clone := req.Clone()
+	clone.WithCallerIdentity("app1", "", "")
+	clone.Message().Method = "other_method"
+
+	assert.Len(t, req.Metadata(), 1)
+	assert.Equal(t, "test_method", req.Message().GetMethod())
+	assert.Len(t, clone.Metadata(), 2)
+}
+
+func TestWithCallerIdentity(t *testing.T) {
+	t.Run("caller supplied identity headers are removed", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method")
+		req.WithMetadata(map[string][]string{
+			"Dapr-Caller-App-Id": {"spoofed"},
+			"custom":             {"value"},
+		})
+		req.WithCallerIdentity("", "", "")
+
+		md := req.Metadata()
+		assert.Len(t, md, 1)
+		assert.Equal(t, "value", md["custom"].GetValues()[0].GetStringValue())
+	})
+
+	t.Run("verified identity is set", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test_method")
+		req.WithMetadata(map[string][]string{
+			"dapr-caller-app-id": {"spoofed"},
+		})
+		req.WithCallerIdentity("app1", "default", "public")
+
+		md := req.Metadata()
+		assert.Equal(t, "app1", md[CallerAppIDHeader].GetValues()[0].GetStringValue())
+		assert.Equal(t, "default", md[CallerNamespaceHeader].GetValues()[0].GetStringValue())
+		assert.Equal(t, "public", md[CallerTrustDomainHeader].GetValues()[0].GetStringValue())
+	})
+}
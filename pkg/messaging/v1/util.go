@@ -7,6 +7,7 @@ package v1
 
 import (
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -34,6 +35,13 @@ const (
 	// gRPCBinaryMetadata is the suffix of grpc metadata binary value
 	gRPCBinaryMetadataSuffix = "-bin"
 
+	// CallerAppIDHeader is the header key of the verified app id of the caller
+	CallerAppIDHeader = "dapr-caller-app-id"
+	// CallerNamespaceHeader is the header key of the verified namespace of the caller
+	CallerNamespaceHeader = "dapr-caller-namespace"
+	// CallerTrustDomainHeader is the header key of the verified trust domain of the caller
+	CallerTrustDomainHeader = "dapr-caller-trust-domain"
+
 	// W3C trace correlation headers
 	traceparentHeader = "traceparent"
 	tracestateHeader  = "tracestate"
@@ -47,8 +55,114 @@ const (
 	errorInfoDomain            = "dapr.io"
 	errorInfoHTTPCodeMetadata  = "http.code"
 	errorInfoHTTPErrorMetadata = "http.error_message"
+
+	// DefaultMaxMetadataSize is the default limit in bytes for the total size of all metadata keys and values
+	DefaultMaxMetadataSize = 64 * 1024
+	// DefaultMaxMetadataValueLength is the default limit in bytes for a single metadata value
+	DefaultMaxMetadataValueLength = 16 * 1024
+	// DefaultMaxAppHeaderCount is the default limit for the number of headers forwarded to the app
+	DefaultMaxAppHeaderCount = 100
+	// DefaultMaxAppHeaderSize is the default limit in bytes for the total size of the headers forwarded to the app
+	DefaultMaxAppHeaderSize = 8 * 1024
 )
 
+// MetadataLimits holds the size guardrails applied to metadata
+// converted between HTTP headers, gRPC metadata and internal metadata.
+type MetadataLimits struct {
+	// MaxTotalSize is the maximum total size of all metadata keys and values in bytes
+	MaxTotalSize int
+	// MaxValueLength is the maximum length of a single metadata value in bytes
+	MaxValueLength int
+}
+
+// NewMetadataLimits returns MetadataLimits, using the defaults for values that are not positive
+func NewMetadataLimits(maxTotalSize, maxValueLength int) MetadataLimits {
+	if maxTotalSize <= 0 {
+		maxTotalSize = DefaultMaxMetadataSize
+	}
+	if maxValueLength <= 0 {
+		maxValueLength = DefaultMaxMetadataValueLength
+	}
+	return MetadataLimits{
+		MaxTotalSize:   maxTotalSize,
+		MaxValueLength: maxValueLength,
+	}
+}
+
+// Check validates internal metadata against the limits and returns
+// a ResourceExhausted status error if any limit is exceeded.
+func (l MetadataLimits) Check(internalMD DaprInternalMetadata) error {
+	total := 0
+	for k, listVal := range internalMD {
+		total += len(k)
+		for _, v := range listVal.GetValues() {
+			valLen := len(v.GetStringValue())
+			if l.MaxValueLength > 0 && valLen > l.MaxValueLength {
+				return grpc_status.Errorf(codes.ResourceExhausted, "metadata value of %s is %d bytes, exceeding the limit of %d bytes", k, valLen, l.MaxValueLength)
+			}
+			total += valLen
+		}
+	}
+	if l.MaxTotalSize > 0 && total > l.MaxTotalSize {
+		return grpc_status.Errorf(codes.ResourceExhausted, "metadata size is %d bytes, exceeding the limit of %d bytes", total, l.MaxTotalSize)
+	}
+	return nil
+}
+
+// Trim removes the metadata exceeding the limits, in the order of the keys, and returns the keys it removes.
+// Responses are trimmed instead of being rejected, as the call they answer has already run.
+func (l MetadataLimits) Trim(internalMD DaprInternalMetadata) []string {
+	keys := make([]string, 0, len(internalMD))
+	for k := range internalMD {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	removed := []string{}
+	total := 0
+	for _, k := range keys {
+		size := len(k)
+		exceeded := false
+		for _, v := range internalMD[k].GetValues() {
+			valLen := len(v.GetStringValue())
+			if l.MaxValueLength > 0 && valLen > l.MaxValueLength {
+				exceeded = true
+			}
+			size += valLen
+		}
+		if exceeded || (l.MaxTotalSize > 0 && total+size > l.MaxTotalSize) {
+			delete(internalMD, k)
+			removed = append(removed, k)
+			continue
+		}
+		total += size
+	}
+	return removed
+}
+
+// AppHeaderLimits holds the limits of the headers forwarded from internal metadata to the app,
+// so apps with small header buffers don't reset the connection.
+type AppHeaderLimits struct {
+	// MaxCount is the maximum number of headers
+	MaxCount int
+	// MaxTotalSize is the maximum total size of all header keys and values in bytes
+	MaxTotalSize int
+}
+
+// NewAppHeaderLimits returns AppHeaderLimits, using the defaults for values that are not positive
+func NewAppHeaderLimits(maxCount, maxTotalSize int) AppHeaderLimits {
+	if maxCount <= 0 {
+		maxCount = DefaultMaxAppHeaderCount
+	}
+	if maxTotalSize <= 0 {
+		maxTotalSize = DefaultMaxAppHeaderSize
+	}
+	return AppHeaderLimits{
+		MaxCount:     maxCount,
+		MaxTotalSize: maxTotalSize,
+	}
+}
+
 // DaprInternalMetadata is the metadata type to transfer HTTP header and gRPC metadata
 // from user app to Dapr.
 type DaprInternalMetadata map[string]*structpb.ListValue
@@ -114,6 +228,11 @@ func isTraceCorrleationHeaderKey(key string) bool {
 	return k == tracestateHeader || k == traceparentHeader || k == tracebinMetadata
 }
 
+func isCallerIdentityHeaderKey(key string) bool {
+	k := strings.ToLower(key)
+	return k == CallerAppIDHeader || k == CallerNamespaceHeader || k == CallerTrustDomainHeader
+}
+
 // InternalMetadataToGrpcMetadata converts internal metadata map to gRPC metadata
 func InternalMetadataToGrpcMetadata(internalMD DaprInternalMetadata, httpHeaderConversion bool) metadata.MD {
 	var md = metadata.MD{}
@@ -165,6 +284,35 @@ func InternalMetadataToHTTPHeader(internalMD DaprInternalMetadata, setHeader fun
 	}
 }
 
+// InternalMetadataToLimitedHTTPHeader converts internal metadata pb to HTTP headers like InternalMetadataToHTTPHeader.
+// The headers are set in key order until the limits are reached, and the keys of the headers which are trimmed are
+// returned. Zero limits aren't enforced.
+func InternalMetadataToLimitedHTTPHeader(internalMD DaprInternalMetadata, limits AppHeaderLimits, setHeader func(string, string)) []string {
+	headers := map[string]string{}
+	InternalMetadataToHTTPHeader(internalMD, func(k, v string) {
+		headers[k] = v
+	})
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	trimmed := []string{}
+	count, total := 0, 0
+	for _, k := range keys {
+		size := len(k) + len(headers[k])
+		if (limits.MaxCount > 0 && count+1 > limits.MaxCount) || (limits.MaxTotalSize > 0 && total+size > limits.MaxTotalSize) {
+			trimmed = append(trimmed, k)
+			continue
+		}
+		count++
+		total += size
+		setHeader(k, headers[k])
+	}
+	return trimmed
+}
+
 // HTTPStatusFromCode converts a gRPC error code into the corresponding HTTP response status.
 // https://github.com/grpc-ecosystem/grpc-gateway/blob/master/runtime/errors.go#L15
 // See: https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
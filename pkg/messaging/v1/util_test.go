@@ -263,3 +263,84 @@ func TestErrorFromInternalStatus(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, expected, actual)
 }
+
+func TestMetadataLimits(t *testing.T) {
+	t.Run("defaults are applied", func(t *testing.T) {
+		limits := NewMetadataLimits(0, -1)
+		assert.Equal(t, DefaultMaxMetadataSize, limits.MaxTotalSize)
+		assert.Equal(t, DefaultMaxMetadataValueLength, limits.MaxValueLength)
+	})
+
+	t.Run("metadata within limits", func(t *testing.T) {
+		limits := NewMetadataLimits(100, 10)
+		md := GrpcMetadataToInternalMetadata(metadata.Pairs("key", "value"))
+		assert.NoError(t, limits.Check(md))
+	})
+
+	t.Run("value exceeds limit", func(t *testing.T) {
+		limits := NewMetadataLimits(100, 10)
+		md := GrpcMetadataToInternalMetadata(metadata.Pairs("key", strings.Repeat("a", 11)))
+		err := limits.Check(md)
+		assert.Error(t, err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("total size exceeds limit", func(t *testing.T) {
+		limits := NewMetadataLimits(20, 10)
+		md := GrpcMetadataToInternalMetadata(metadata.Pairs("key1", "value1", "key2", "value2", "key3", "value3"))
+		err := limits.Check(md)
+		assert.Error(t, err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("metadata exceeding the limits is trimmed", func(t *testing.T) {
+		limits := NewMetadataLimits(20, 10)
+		md := GrpcMetadataToInternalMetadata(metadata.Pairs("key1", "value1", "key2", strings.Repeat("a", 11), "key3", "value3", "key4", "value4"))
+		removed := limits.Trim(md)
+		assert.Equal(t, []string{"key2", "key4"}, removed)
+		assert.Len(t, md, 2)
+		assert.NoError(t, limits.Check(md))
+	})
+}
+
+func TestInternalMetadataToLimitedHTTPHeader(t *testing.T) {
+	md := GrpcMetadataToInternalMetadata(metadata.Pairs("key1", "value1", "key2", "value2", "key3", strings.Repeat("a", 20)))
+
+	t.Run("defaults are applied", func(t *testing.T) {
+		limits := NewAppHeaderLimits(0, -1)
+		assert.Equal(t, DefaultMaxAppHeaderCount, limits.MaxCount)
+		assert.Equal(t, DefaultMaxAppHeaderSize, limits.MaxTotalSize)
+	})
+
+	t.Run("headers within limits", func(t *testing.T) {
+		headers := map[string]string{}
+		trimmed := InternalMetadataToLimitedHTTPHeader(md, NewAppHeaderLimits(10, 100), func(k, v string) {
+			headers[k] = v
+		})
+		assert.Empty(t, trimmed)
+		assert.Len(t, headers, 3)
+	})
+
+	t.Run("headers over the count are trimmed", func(t *testing.T) {
+		headers := map[string]string{}
+		trimmed := InternalMetadataToLimitedHTTPHeader(md, NewAppHeaderLimits(2, 100), func(k, v string) {
+			headers[k] = v
+		})
+		assert.Equal(t, []string{"key3"}, trimmed)
+		assert.Equal(t, map[string]string{"key1": "value1", "key2": "value2"}, headers)
+	})
+
+	t.Run("headers over the size are trimmed", func(t *testing.T) {
+		headers := map[string]string{}
+		trimmed := InternalMetadataToLimitedHTTPHeader(md, NewAppHeaderLimits(10, 20), func(k, v string) {
+			headers[k] = v
+		})
+		assert.Equal(t, []string{"key3"}, trimmed)
+		assert.Len(t, headers, 2)
+	})
+
+	t.Run("zero limits aren't enforced", func(t *testing.T) {
+		trimmed := InternalMetadataToLimitedHTTPHeader(md, AppHeaderLimits{}, func(k, v string) {})
+		assert.Empty(t, trimmed)
+	})
+}
@@ -22,7 +22,10 @@ import (
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const serverPort = 6500
+const (
+	serverPort       = 6500
+	subscriptionKind = "Subscription"
+)
 
 var log = logger.NewLogger("dapr.operator.api")
 
@@ -105,10 +108,29 @@ func (a *apiServer) GetComponents(ctx context.Context, in *empty.Empty) (*operat
 			Value: b,
 		})
 	}
+
+	// Subscriptions are sent alongside components and told apart by their kind
+	subscriptions, err := a.Client.ComponentsV1alpha1().Subscriptions(meta_v1.NamespaceAll).List(meta_v1.ListOptions{})
+	if err != nil {
+		log.Warnf("error getting subscriptions: %s", err)
+		return resp, nil
+	}
+	for _, s := range subscriptions.Items {
+		s.Kind = subscriptionKind
+		b, err := json.Marshal(&s)
+		if err != nil {
+			log.Warnf("error marshalling subscription: %s", err)
+			continue
+		}
+		resp.Components = append(resp.Components, &any.Any{
+			Value: b,
+		})
+	}
 	return resp, nil
 }
 
-// ComponentUpdate updates Dapr sidecars whenever a component in the cluster is modified
+// ComponentUpdate updates Dapr sidecars whenever a component in the cluster is modified or deleted.
+// The deleted components are sent with their deletion time set.
 func (a *apiServer) ComponentUpdate(in *empty.Empty, srv operatorv1pb.Operator_ComponentUpdateServer) error {
 	log.Info("sidecar connected for component updates")
 
@@ -77,6 +77,7 @@ func NewOperator(kubeAPI *k8s.API, config *Config) Operator {
 		UpdateFunc: func(_, newObj interface{}) {
 			o.syncComponent(newObj)
 		},
+		DeleteFunc: o.syncDeletedComponent,
 	})
 
 	return o
@@ -89,6 +90,24 @@ func (o *operator) syncComponent(obj interface{}) {
 	}
 }
 
+// syncDeletedComponent sends the deleted component to the sidecars with its deletion time set,
+// which tells them to close the component
+func (o *operator) syncDeletedComponent(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	c, ok := obj.(*v1alpha1.Component)
+	if !ok {
+		return
+	}
+	if c.DeletionTimestamp == nil {
+		c = c.DeepCopy()
+		now := meta_v1.Now()
+		c.DeletionTimestamp = &now
+	}
+	o.apiServer.OnComponentUpdated(c)
+}
+
 func (o *operator) syncDeployment(obj interface{}) {
 	o.daprHandler.ObjectCreated(obj)
 }
@@ -0,0 +1,267 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+	"github.com/dapr/dapr/pkg/state/etag"
+	"github.com/dapr/dapr/pkg/state/partitions"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultSweepInterval is the default interval at which pending events left over by failed publishes are published
+	DefaultSweepInterval = 10 * time.Second
+
+	// transactAttempts is the number of times a transaction is attempted when the partition of its events is
+	// updated concurrently by another replica of the app
+	transactAttempts = 3
+
+	outboxKeyPart          = "outbox"
+	incompatibleStateStore = "state store does not support transactions"
+)
+
+var log = logger.NewLogger("dapr.runtime.outbox")
+
+// Outbox persists events along with state transactions and publishes them once the transaction is committed
+type Outbox interface {
+	Transact(storeName string, operations []state.TransactionalRequest, events []Event) error
+	Start(interval time.Duration)
+	Stop()
+}
+
+// Event is an event to publish once the state transaction it is part of is committed
+type Event struct {
+	ID    string `json:"id"`
+	Topic string `json:"topic"`
+	Data  []byte `json:"data"`
+}
+
+// pendingEvent is an event saved in the outbox until it is published
+type pendingEvent struct {
+	Event
+	SavedAt time.Time `json:"savedAt"`
+}
+
+// transactionalStore is a state store supporting transactions
+type transactionalStore interface {
+	state.Store
+	state.TransactionalStore
+}
+
+type outbox struct {
+	appID     string
+	compStore *compstore.ComponentStore
+	publishFn func(*pubsub.PublishRequest) error
+	now       func() time.Time
+
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOutbox returns an Outbox which stores pending events in the state stores of the app.
+// Each pending event is a record of the outbox partitions of the state store, the events of a transaction are
+// saved in the same partition. The replica committing a transaction publishes its events and removes them,
+// the sweep only publishes the events left over for longer than its interval.
+func NewOutbox(appID string, compStore *compstore.ComponentStore, publishFn func(*pubsub.PublishRequest) error) Outbox {
+	return &outbox{
+		appID:     appID,
+		compStore: compStore,
+		publishFn: publishFn,
+		now:       time.Now,
+		done:      make(chan struct{}),
+	}
+}
+
+// Transact executes the state operations and persists the events in a single transaction.
+// The events are published right after the transaction is committed. Events which fail to be
+// published stay pending and are published again by the sweep, which gives at-least-once delivery.
+func (o *outbox) Transact(storeName string, operations []state.TransactionalRequest, events []Event) error {
+	store, err := o.transactionalStore(storeName)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return store.Multi(operations)
+	}
+
+	savedAt := o.now().UTC()
+	saved := partitions.Records{}
+	for i := range events {
+		if events[i].ID == "" {
+			events[i].ID = uuid.New().String()
+		}
+		b, err := json.Marshal(pendingEvent{Event: events[i], SavedAt: savedAt})
+		if err != nil {
+			return fmt.Errorf("error serializing outbox event: %s", err)
+		}
+		saved[events[i].ID] = b
+	}
+
+	p := o.partitions(store)
+	partition := p.Of(events[0].ID)
+	// The transaction is atomic, so it is attempted again when it fails as another replica updated the
+	// partition of the events meanwhile
+	for attempt := 0; attempt < transactAttempts; attempt++ {
+		var records partitions.Records
+		var tag string
+		records, tag, err = p.GetForUpdate(partition)
+		if err != nil {
+			break
+		}
+		for id, b := range saved {
+			records[id] = b
+		}
+		reqs := append(append([]state.TransactionalRequest{}, operations...), state.TransactionalRequest{
+			Operation: state.Upsert,
+			Request:   p.SetRequest(partition, records, tag),
+		})
+		err = store.Multi(reqs)
+		if err == nil {
+			break
+		}
+		if err = etag.CheckTransaction(store, err, reqs); !p.IsConflict(partition, err) {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	go o.publishCommitted(storeName, p, partition, events)
+	return nil
+}
+
+// Start creates the outbox partitions of the transactional state stores, and publishes the events left pending in
+// them at the given interval, until the outbox is stopped
+func (o *outbox) Start(interval time.Duration) {
+	for name, s := range o.compStore.ListStateStores() {
+		if _, ok := s.(state.TransactionalStore); ok {
+			if err := o.partitions(s).Create(); err != nil {
+				log.Warnf("error creating the outbox partitions in state store %s: %s", name, err)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				savedBefore := o.now().Add(-interval)
+				for name, s := range o.compStore.ListStateStores() {
+					if _, ok := s.(state.TransactionalStore); ok {
+						o.publishPending(name, savedBefore)
+					}
+				}
+			case <-o.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the sweep, the pending events stay saved
+func (o *outbox) Stop() {
+	o.stopOnce.Do(func() { close(o.done) })
+}
+
+// publishCommitted publishes the events of a committed transaction and removes the events published
+func (o *outbox) publishCommitted(storeName string, p *partitions.Partitions, partition int, events []Event) {
+	if o.publishFn == nil {
+		return
+	}
+	o.removePublished(storeName, p, partition, o.publish(events))
+}
+
+// publishPending publishes the pending events of the state store saved before the given time, which were left
+// over by a failed publish or by a replica which stopped before publishing them
+func (o *outbox) publishPending(storeName string, savedBefore time.Time) {
+	store, err := o.transactionalStore(storeName)
+	if err != nil || o.publishFn == nil {
+		return
+	}
+
+	p := o.partitions(store)
+	for partition := 0; partition < p.Count(); partition++ {
+		records, _, err := p.Get(partition)
+		if err != nil {
+			log.Warnf("failed to get pending outbox events of state store %s: %s", storeName, err)
+			continue
+		}
+		pending := []Event{}
+		dropped := []string{}
+		for id, b := range records {
+			var e pendingEvent
+			if err := json.Unmarshal(b, &e); err != nil {
+				log.Warnf("dropping outbox event %s which can't be deserialized: %s", id, err)
+				dropped = append(dropped, id)
+			} else if e.SavedAt.Before(savedBefore) {
+				pending = append(pending, e.Event)
+			}
+		}
+		o.removePublished(storeName, p, partition, append(dropped, o.publish(pending)...))
+	}
+}
+
+// removePublished removes the events published or dropped from the partition
+func (o *outbox) removePublished(storeName string, p *partitions.Partitions, partition int, done []string) {
+	if len(done) == 0 {
+		return
+	}
+	if err := p.Remove(partition, done...); err != nil {
+		// The events will be published again by the next sweep
+		log.Warnf("failed to remove published outbox events of state store %s: %s", storeName, err)
+	}
+}
+
+// publish publishes the events and returns the ids of the events published or dropped
+func (o *outbox) publish(events []Event) []string {
+	done := []string{}
+	for _, e := range events {
+		err := o.publishFn(&pubsub.PublishRequest{
+			Topic: e.Topic,
+			Data:  e.Data,
+		})
+		if errors.As(err, &runtime_pubsub.NotAllowedError{}) {
+			log.Warnf("dropping outbox event %s: %s", e.ID, err)
+			done = append(done, e.ID)
+		} else if err != nil {
+			log.Debugf("failed to publish outbox event %s, will retry: %s", e.ID, err)
+		} else {
+			done = append(done, e.ID)
+		}
+	}
+	return done
+}
+
+func (o *outbox) transactionalStore(storeName string) (transactionalStore, error) {
+	s, ok := o.compStore.GetStateStore(storeName)
+	if !ok {
+		return nil, fmt.Errorf("state store %s not found", storeName)
+	}
+	store, ok := s.(transactionalStore)
+	if !ok {
+		return nil, errors.New(incompatibleStateStore)
+	}
+	return store, nil
+}
+
+// partitions returns the partitions of the pending events of the app in the state store
+func (o *outbox) partitions(store state.Store) *partitions.Partitions {
+	return partitions.New(store, partitions.DefaultCount, outboxKeyPart, o.appID)
+}
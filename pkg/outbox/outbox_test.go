@@ -0,0 +1,285 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	"github.com/dapr/dapr/pkg/state/keyprefix"
+	"github.com/dapr/dapr/pkg/state/partitions"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTransactionalStore is a state store with etags, which are the versions of the keys
+type fakeTransactionalStore struct {
+	items    map[string][]byte
+	versions map[string]int
+	// beforeMulti is called before the transactions are executed
+	beforeMulti func()
+	lock        sync.Mutex
+}
+
+func newFakeTransactionalStore() *fakeTransactionalStore {
+	return &fakeTransactionalStore{items: map[string][]byte{}, versions: map[string]int{}}
+}
+
+func (f *fakeTransactionalStore) Init(metadata state.Metadata) error {
+	return nil
+}
+
+func (f *fakeTransactionalStore) Delete(req *state.DeleteRequest) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.checkETag(req.Key, req.ETag); err != nil {
+		return err
+	}
+	delete(f.items, req.Key)
+	return nil
+}
+
+func (f *fakeTransactionalStore) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeTransactionalStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, ok := f.items[req.Key]; !ok {
+		return &state.GetResponse{}, nil
+	}
+	return &state.GetResponse{Data: f.items[req.Key], ETag: strconv.Itoa(f.versions[req.Key])}, nil
+}
+
+func (f *fakeTransactionalStore) Set(req *state.SetRequest) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.checkETag(req.Key, req.ETag); err != nil {
+		return err
+	}
+	f.set(req)
+	return nil
+}
+
+func (f *fakeTransactionalStore) set(req *state.SetRequest) {
+	b, _ := json.Marshal(req.Value)
+	f.items[req.Key] = b
+	f.versions[req.Key]++
+}
+
+// checkETag fails if the ETag isn't the current one, the keys are saved unconditionally when the ETag is empty
+func (f *fakeTransactionalStore) checkETag(key, etag string) error {
+	if etag != "" && etag != strconv.Itoa(f.versions[key]) {
+		return errors.New("etag mismatch")
+	}
+	return nil
+}
+
+func (f *fakeTransactionalStore) BulkSet(req []state.SetRequest) error {
+	return nil
+}
+
+func (f *fakeTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	if f.beforeMulti != nil {
+		f.beforeMulti()
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, r := range reqs {
+		var err error
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			err = f.checkETag(req.Key, req.ETag)
+		case state.DeleteRequest:
+			err = f.checkETag(req.Key, req.ETag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range reqs {
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			f.set(&req)
+		case state.DeleteRequest:
+			delete(f.items, req.Key)
+		}
+	}
+	return nil
+}
+
+// pending returns the pending events of all the outbox partitions of app1
+func (f *fakeTransactionalStore) pending(t *testing.T) []Event {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var events []Event
+	for p := 0; p < partitions.DefaultCount; p++ {
+		b := f.items[keyprefix.InternalKey("outbox", "app1", strconv.Itoa(p))]
+		if len(b) == 0 {
+			continue
+		}
+		var records map[string]pendingEvent
+		assert.NoError(t, json.Unmarshal(b, &records))
+		for _, e := range records {
+			events = append(events, e.Event)
+		}
+	}
+	return events
+}
+
+func newTestCompStore(store state.Store) *compstore.ComponentStore {
+	s := compstore.New()
+	s.AddStateStore("store1", store)
+	return s
+}
+
+func TestTransact(t *testing.T) {
+	upsert := []state.TransactionalRequest{
+		{
+			Operation: state.Upsert,
+			Request:   state.SetRequest{Key: "app1||key1", Value: "value1"},
+		},
+	}
+
+	t.Run("publishes events after commit", func(t *testing.T) {
+		store := newFakeTransactionalStore()
+		published := make(chan *pubsub.PublishRequest, 1)
+		o := NewOutbox("app1", newTestCompStore(store), func(req *pubsub.PublishRequest) error {
+			published <- req
+			return nil
+		})
+
+		err := o.Transact("store1", upsert, []Event{{Topic: "orders", Data: []byte("order1")}})
+		assert.NoError(t, err)
+		resp, _ := store.Get(&state.GetRequest{Key: "app1||key1"})
+		assert.Equal(t, `"value1"`, string(resp.Data))
+
+		select {
+		case req := <-published:
+			assert.Equal(t, "orders", req.Topic)
+			assert.Equal(t, []byte("order1"), req.Data)
+		case <-time.After(time.Second):
+			assert.Fail(t, "event was not published")
+		}
+		assert.Eventually(t, func() bool { return len(store.pending(t)) == 0 }, time.Second, time.Millisecond*10)
+	})
+
+	t.Run("keeps events which fail to be published", func(t *testing.T) {
+		store := newFakeTransactionalStore()
+		o := NewOutbox("app1", newTestCompStore(store), func(req *pubsub.PublishRequest) error {
+			return errors.New("broker down")
+		}).(*outbox)
+
+		err := o.Transact("store1", upsert, []Event{{Topic: "orders", Data: []byte("order1")}})
+		assert.NoError(t, err)
+		o.publishPending("store1", time.Now().Add(time.Minute))
+
+		pending := store.pending(t)
+		assert.Len(t, pending, 1)
+		assert.NotEmpty(t, pending[0].ID)
+	})
+
+	t.Run("events of concurrent replicas are kept", func(t *testing.T) {
+		store := newFakeTransactionalStore()
+		failing := func(req *pubsub.PublishRequest) error {
+			return errors.New("broker down")
+		}
+		o := NewOutbox("app1", newTestCompStore(store), failing)
+		replica := NewOutbox("app1", newTestCompStore(store), failing)
+		store.beforeMulti = func() {
+			store.beforeMulti = nil
+			assert.NoError(t, replica.Transact("store1", nil, []Event{{Topic: "orders", Data: []byte("order2")}}))
+		}
+
+		err := o.Transact("store1", upsert, []Event{{Topic: "orders", Data: []byte("order1")}})
+		assert.NoError(t, err)
+		assert.Len(t, store.pending(t), 2)
+	})
+
+	t.Run("transactions failing on the etags of the app keys aren't attempted again", func(t *testing.T) {
+		store := newFakeTransactionalStore()
+		o := NewOutbox("app1", newTestCompStore(store), nil)
+		attempts := 0
+		store.beforeMulti = func() { attempts++ }
+
+		err := o.Transact("store1", []state.TransactionalRequest{
+			{
+				Operation: state.Upsert,
+				Request:   state.SetRequest{Key: "app1||key1", Value: "value1", ETag: "5"},
+			},
+		}, []Event{{Topic: "orders", Data: []byte("order1")}})
+		assert.Error(t, err)
+		assert.Equal(t, 1, attempts)
+		assert.Empty(t, store.pending(t))
+	})
+
+	t.Run("state store not found", func(t *testing.T) {
+		o := NewOutbox("app1", compstore.New(), nil)
+		err := o.Transact("store1", upsert, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestPublishPending(t *testing.T) {
+	store := newFakeTransactionalStore()
+	fail := true
+	failed := make(chan struct{}, 2)
+	published := 0
+	o := NewOutbox("app1", newTestCompStore(store), func(req *pubsub.PublishRequest) error {
+		if fail {
+			failed <- struct{}{}
+			return errors.New("broker down")
+		}
+		published++
+		return nil
+	}).(*outbox)
+	now := time.Now()
+	o.now = func() time.Time { return now }
+
+	assert.NoError(t, o.Transact("store1", nil, []Event{{Topic: "orders"}, {Topic: "orders"}}))
+	<-failed
+	<-failed
+	fail = false
+	assert.Len(t, store.pending(t), 2)
+
+	// the events which may still be published by the replica which saved them are left
+	o.publishPending("store1", now)
+	assert.Zero(t, published)
+
+	o.publishPending("store1", now.Add(time.Second))
+	assert.Equal(t, 2, published)
+	assert.Empty(t, store.pending(t))
+}
+
+func TestStop(t *testing.T) {
+	o := NewOutbox("app1", newTestCompStore(newFakeTransactionalStore()), nil)
+	o.Start(time.Millisecond)
+	o.Stop()
+	o.Stop()
+}
+
+func TestAppKeysAreNotRead(t *testing.T) {
+	store := newFakeTransactionalStore()
+	assert.NoError(t, store.Set(&state.SetRequest{Key: "app1||outbox", Value: []Event{}}))
+	published := 0
+	o := NewOutbox("app1", newTestCompStore(store), func(req *pubsub.PublishRequest) error {
+		published++
+		return nil
+	})
+
+	o.Start(time.Hour)
+	defer o.Stop()
+	assert.Zero(t, published)
+	_, ok := store.items["app1||outbox"]
+	assert.True(t, ok)
+}
@@ -23,7 +23,12 @@ import (
 	blake2b "github.com/minio/blake2b-simd"
 )
 
-const replicationFactor = 10
+const (
+	// DefaultReplicationFactor is the default number of virtual nodes of each host on the ring
+	DefaultReplicationFactor = 10
+	// DefaultLoadFactor is the default maximum load of a host, relative to the average load of the hosts
+	DefaultLoadFactor = 1.25
+)
 
 // ErrNoHosts is an error for no hosts
 var ErrNoHosts = errors.New("no hosts added")
@@ -49,6 +54,9 @@ type Consistent struct {
 	loadMap   map[string]*Host
 	totalLoad int64
 
+	replicationFactor int
+	loadFactor        float64
+
 	sync.RWMutex
 }
 
@@ -72,19 +80,29 @@ func NewHost(name, id string, load int64, port int64) *Host {
 
 // NewConsistentHash returns a new consistent hash
 func NewConsistentHash() *Consistent {
+	return NewConsistentHashWithOptions(DefaultReplicationFactor, DefaultLoadFactor)
+}
+
+// NewConsistentHashWithOptions returns a new consistent hash with the given number of virtual nodes per host
+// and maximum load of a host, relative to the average load of the hosts
+func NewConsistentHashWithOptions(replicationFactor int, loadFactor float64) *Consistent {
 	return &Consistent{
-		hosts:     map[uint64]string{},
-		sortedSet: []uint64{},
-		loadMap:   map[string]*Host{},
+		hosts:             map[uint64]string{},
+		sortedSet:         []uint64{},
+		loadMap:           map[string]*Host{},
+		replicationFactor: replicationFactor,
+		loadFactor:        loadFactor,
 	}
 }
 
 // NewFromExisting creates a new consistent hash from existing values
 func NewFromExisting(hosts map[uint64]string, sortedSet []uint64, loadMap map[string]*Host) *Consistent {
 	return &Consistent{
-		hosts:     hosts,
-		sortedSet: sortedSet,
-		loadMap:   loadMap,
+		hosts:             hosts,
+		sortedSet:         sortedSet,
+		loadMap:           loadMap,
+		replicationFactor: DefaultReplicationFactor,
+		loadFactor:        DefaultLoadFactor,
 	}
 }
 
@@ -106,7 +124,7 @@ func (c *Consistent) Add(host, id string, port int64) bool {
 	}
 
 	c.loadMap[host] = &Host{Name: host, AppID: id, Load: 0, Port: port}
-	for i := 0; i < replicationFactor; i++ {
+	for i := 0; i < c.replicationFactor; i++ {
 		h := c.hash(fmt.Sprintf("%s%d", host, i))
 		c.hosts[h] = host
 		c.sortedSet = append(c.sortedSet, h)
@@ -233,7 +251,7 @@ func (c *Consistent) Remove(host string) bool {
 	c.Lock()
 	defer c.Unlock()
 
-	for i := 0; i < replicationFactor; i++ {
+	for i := 0; i < c.replicationFactor; i++ {
 		h := c.hash(fmt.Sprintf("%s%d", host, i))
 		delete(c.hosts, h)
 		c.delSlice(h)
@@ -264,7 +282,7 @@ func (c *Consistent) GetLoads() map[string]int64 {
 
 // MaxLoad returns the maximum load of the single host
 // which is:
-// (total_load/number_of_hosts)*1.25
+// (total_load/number_of_hosts)*load_factor
 // total_load = is the total number of active requests served by hosts
 // for more info:
 // https://research.googleblog.com/2017/04/consistent-hashing-with-bounded-loads.html
@@ -277,7 +295,7 @@ func (c *Consistent) MaxLoad() int64 {
 	if avgLoadPerNode == 0 {
 		avgLoadPerNode = 1
 	}
-	avgLoadPerNode = math.Ceil(avgLoadPerNode * 1.25)
+	avgLoadPerNode = math.Ceil(avgLoadPerNode * c.loadFactor)
 	return int64(avgLoadPerNode)
 }
 
@@ -292,7 +310,7 @@ func (c *Consistent) loadOK(host string) bool {
 	if avgLoadPerNode == 0 {
 		avgLoadPerNode = 1
 	}
-	avgLoadPerNode = math.Ceil(avgLoadPerNode * 1.25)
+	avgLoadPerNode = math.Ceil(avgLoadPerNode * c.loadFactor)
 
 	bhost, ok := c.loadMap[host]
 	if !ok {
@@ -0,0 +1,44 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package placement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicationFactor(t *testing.T) {
+	c := NewConsistentHashWithOptions(100, DefaultLoadFactor)
+	c.Add("host1", "app1", 50001)
+	c.Add("host2", "app1", 50001)
+	hosts, sortedSet, _, _ := c.GetInternals()
+	assert.Len(t, hosts, 200)
+	assert.Len(t, sortedSet, 200)
+
+	c.Remove("host1")
+	hosts, sortedSet, _, _ = c.GetInternals()
+	assert.Len(t, hosts, 100)
+	assert.Len(t, sortedSet, 100)
+
+	assert.Equal(t, DefaultReplicationFactor, NewConsistentHash().replicationFactor)
+}
+
+func TestLoadFactor(t *testing.T) {
+	c := NewConsistentHashWithOptions(DefaultReplicationFactor, 2)
+	c.Add("host1", "app1", 50001)
+	c.Add("host2", "app1", 50001)
+	c.UpdateLoad("host1", 10)
+	c.UpdateLoad("host2", 10)
+	assert.Equal(t, int64(20), c.MaxLoad())
+
+	c = NewConsistentHash()
+	c.Add("host1", "app1", 50001)
+	c.Add("host2", "app1", 50001)
+	c.UpdateLoad("host1", 10)
+	c.UpdateLoad("host2", 10)
+	assert.Equal(t, int64(13), c.MaxLoad())
+}
@@ -0,0 +1,91 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package placement
+
+import (
+	"context"
+	"time"
+
+	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// DefaultDrainTimeout is the default time the hosts are left locked to drain their rebalanced actors after a
+// tables update, the calls of the actors which take longer to drain may run on both their old and new hosts
+const DefaultDrainTimeout = time.Second * 10
+
+// ReportDrained records that a host drained its rebalanced actors
+func (p *Service) ReportDrained(ctx context.Context, in *placementv1pb.DrainReport) (*empty.Empty, error) {
+	p.drainLock.Lock()
+	defer p.drainLock.Unlock()
+
+	if in.Version == p.drainVersion {
+		p.drainedHosts[in.Name] = true
+		if len(p.drainedHosts) >= p.drainExpected {
+			p.signalDrained()
+		}
+	}
+	return &empty.Empty{}, nil
+}
+
+// startDrain starts waiting for the given number of hosts to drain the tables version. The wait for the
+// previous version ends, as the new version supersedes it.
+func (p *Service) startDrain(version string, expected int) <-chan struct{} {
+	p.drainLock.Lock()
+	defer p.drainLock.Unlock()
+
+	if p.drainedCh != nil {
+		p.signalDrained()
+	}
+	p.drainVersion = version
+	p.drainExpected = expected
+	p.drainedHosts = map[string]bool{}
+	p.drainedCh = make(chan struct{})
+	if expected == 0 {
+		p.signalDrained()
+	}
+	return p.drainedCh
+}
+
+// skipDrain stops waiting for a host which didn't receive the tables update
+func (p *Service) skipDrain() {
+	p.drainLock.Lock()
+	defer p.drainLock.Unlock()
+
+	p.drainExpected--
+	if len(p.drainedHosts) >= p.drainExpected {
+		p.signalDrained()
+	}
+}
+
+// waitDrained waits until the hosts drained the tables version or the drain timeout elapses
+func (p *Service) waitDrained(drained <-chan struct{}) {
+	if p.drainTimeout <= 0 {
+		return
+	}
+	select {
+	case <-drained:
+	case <-time.After(p.drainTimeout):
+		p.drainLock.Lock()
+		log.Warnf("%d of %d hosts drained tables version %s before the drain timeout", len(p.drainedHosts), p.drainExpected, p.drainVersion)
+		p.drainLock.Unlock()
+	}
+}
+
+// isCurrentDrain returns whether the drain wasn't superseded by the drain of a newer tables version
+func (p *Service) isCurrentDrain(drained <-chan struct{}) bool {
+	p.drainLock.Lock()
+	defer p.drainLock.Unlock()
+	return p.drainedCh == drained
+}
+
+func (p *Service) signalDrained() {
+	select {
+	case <-p.drainedCh:
+	default:
+		close(p.drainedCh)
+	}
+}
@@ -0,0 +1,101 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package placement
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// fakeHostStream records the operations of the placement orders sent to a host
+type fakeHostStream struct {
+	placementv1pb.PlacementService_ReportDaprStatusServer
+	lock       sync.Mutex
+	operations []string
+}
+
+func (f *fakeHostStream) Send(o *placementv1pb.PlacementOrder) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.operations = append(f.operations, o.Operation)
+	return nil
+}
+
+func (f *fakeHostStream) sent() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]string{}, f.operations...)
+}
+
+func TestDrain(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("drained once all hosts report the version", func(t *testing.T) {
+		p := NewPlacementService(DefaultReplicationFactor, DefaultLoadFactor, time.Second)
+		drained := p.startDrain("2", 2)
+
+		p.ReportDrained(ctx, &placementv1pb.DrainReport{Name: "host1:50001", Version: "1"})
+		p.ReportDrained(ctx, &placementv1pb.DrainReport{Name: "host1:50001", Version: "2"})
+		p.ReportDrained(ctx, &placementv1pb.DrainReport{Name: "host1:50001", Version: "2"})
+		assert.False(t, isClosed(drained))
+
+		p.ReportDrained(ctx, &placementv1pb.DrainReport{Name: "host2:50001", Version: "2"})
+		assert.True(t, isClosed(drained))
+		p.ReportDrained(ctx, &placementv1pb.DrainReport{Name: "host3:50001", Version: "2"})
+	})
+
+	t.Run("hosts which didn't receive the update are not waited for", func(t *testing.T) {
+		p := NewPlacementService(DefaultReplicationFactor, DefaultLoadFactor, time.Second)
+		drained := p.startDrain("2", 2)
+		p.ReportDrained(ctx, &placementv1pb.DrainReport{Name: "host1:50001", Version: "2"})
+		p.skipDrain()
+		assert.True(t, isClosed(drained))
+
+		assert.True(t, isClosed(p.startDrain("3", 0)))
+	})
+
+	t.Run("wait ends with the drain timeout", func(t *testing.T) {
+		p := NewPlacementService(DefaultReplicationFactor, DefaultLoadFactor, 10*time.Millisecond)
+		start := time.Now()
+		p.waitDrained(p.startDrain("2", 1))
+		assert.True(t, time.Since(start) >= 10*time.Millisecond)
+	})
+
+	t.Run("tables update doesn't wait for the drain", func(t *testing.T) {
+		p := NewPlacementService(DefaultReplicationFactor, DefaultLoadFactor, time.Minute)
+		host1, host2 := &fakeHostStream{}, &fakeHostStream{}
+		hosts := []placementv1pb.PlacementService_ReportDaprStatusServer{host1, host2}
+
+		p.PerformTablesUpdate(hosts, placementOptions{incrementGeneration: true})
+		assert.Equal(t, []string{"lock", "update"}, host1.sent())
+
+		// the newer update supersedes the drain of the first one
+		p.PerformTablesUpdate(hosts, placementOptions{incrementGeneration: true})
+		assert.Equal(t, []string{"lock", "update", "lock", "update"}, host1.sent())
+
+		p.ReportDrained(ctx, &placementv1pb.DrainReport{Name: "host1:50001", Version: "2"})
+		p.ReportDrained(ctx, &placementv1pb.DrainReport{Name: "host2:50001", Version: "2"})
+		assert.Eventually(t, func() bool {
+			return len(host2.sent()) == 5
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{"lock", "update", "lock", "update", "unlock"}, host1.sent())
+		assert.Equal(t, []string{"lock", "update", "lock", "update", "unlock"}, host2.sent())
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	dapr_credentials "github.com/dapr/dapr/pkg/credentials"
 	"github.com/dapr/dapr/pkg/logger"
@@ -31,14 +32,28 @@ type Service struct {
 	hostsEntities     map[string][]string
 	hostsLock         *sync.Mutex
 	updateLock        *sync.Mutex
+	replicationFactor int
+	loadFactor        float64
+
+	// drainTimeout is how long a tables update waits for the hosts to drain their rebalanced actors
+	drainTimeout  time.Duration
+	drainLock     *sync.Mutex
+	drainVersion  string
+	drainExpected int
+	drainedHosts  map[string]bool
+	drainedCh     chan struct{}
+	// lockedHosts are the hosts waiting for the unlock of the tables they received
+	lockedHosts []placementv1pb.PlacementService_ReportDaprStatusServer
 }
 
 type placementOptions struct {
 	incrementGeneration bool
 }
 
-// NewPlacementService returns a new placement service
-func NewPlacementService() *Service {
+// NewPlacementService returns a new placement service building the hash tables of the actor types
+// with the given number of virtual nodes per host and load factor. Tables updates wait up to the
+// drain timeout for the hosts to drain their rebalanced actors, 0 doesn't wait.
+func NewPlacementService(replicationFactor int, loadFactor float64, drainTimeout time.Duration) *Service {
 	return &Service{
 		entriesLock:       &sync.RWMutex{},
 		entries:           make(map[string]*Consistent),
@@ -46,6 +61,10 @@ func NewPlacementService() *Service {
 		hostsEntities:     make(map[string][]string),
 		hostsLock:         &sync.Mutex{},
 		updateLock:        &sync.Mutex{},
+		replicationFactor: replicationFactor,
+		loadFactor:        loadFactor,
+		drainTimeout:      drainTimeout,
+		drainLock:         &sync.Mutex{},
 	}
 }
 
@@ -101,7 +120,9 @@ func (p *Service) RemoveHost(srv placementv1pb.PlacementService_ReportDaprStatus
 }
 
 // PerformTablesUpdate updates the connected dapr runtimes using a 3 stage commit. first it locks so no further dapr can be taken
-// it then proceeds to update and then unlock once all runtimes have been updated
+// it then proceeds to update and then unlock once all runtimes have drained the actors they lost, so that an actor
+// isn't activated by its new host while its previous host still runs it. The drain is awaited in the background
+// so that the heartbeats and the next tables updates aren't delayed by it.
 func (p *Service) PerformTablesUpdate(hosts []placementv1pb.PlacementService_ReportDaprStatusServer,
 	options placementOptions) {
 	p.updateLock.Lock()
@@ -121,6 +142,7 @@ func (p *Service) PerformTablesUpdate(hosts []placementv1pb.PlacementService_Rep
 			log.Errorf("error updating host on lock operation: %s", err)
 			continue
 		}
+		p.addLockedHost(host)
 	}
 
 	v := fmt.Sprintf("%v", p.generation)
@@ -152,24 +174,50 @@ func (p *Service) PerformTablesUpdate(hosts []placementv1pb.PlacementService_Rep
 		o.Tables.Entries[k] = &table
 	}
 
+	// hosts may report that they drained as soon as they receive the update
+	drained := p.startDrain(v, len(hosts))
 	for _, host := range hosts {
 		err := host.Send(&o)
 		if err != nil {
 			log.Errorf("error updating host on update operation: %s", err)
+			p.skipDrain()
 			continue
 		}
 	}
+	go p.unlockWhenDrained(drained)
+}
 
-	o.Tables = nil
-	o.Operation = "unlock"
+// unlockWhenDrained unlocks the locked hosts once they drained the tables version or the drain timeout elapses,
+// unless a newer tables update superseded the version, in which case the newer update unlocks them
+func (p *Service) unlockWhenDrained(drained <-chan struct{}) {
+	p.waitDrained(drained)
 
-	for _, host := range hosts {
+	p.updateLock.Lock()
+	defer p.updateLock.Unlock()
+	if !p.isCurrentDrain(drained) {
+		return
+	}
+
+	o := placementv1pb.PlacementOrder{
+		Operation: "unlock",
+	}
+	for _, host := range p.lockedHosts {
 		err := host.Send(&o)
 		if err != nil {
 			log.Errorf("error updating host on unlock operation: %s", err)
 			continue
 		}
 	}
+	p.lockedHosts = nil
+}
+
+func (p *Service) addLockedHost(host placementv1pb.PlacementService_ReportDaprStatusServer) {
+	for _, h := range p.lockedHosts {
+		if h == host {
+			return
+		}
+	}
+	p.lockedHosts = append(p.lockedHosts, host)
 }
 
 // ProcessRemovedHost removes a host from the hash table
@@ -202,7 +250,7 @@ func (p *Service) ProcessHost(host *placementv1pb.Host) {
 	for _, e := range host.Entities {
 		p.entriesLock.Lock()
 		if _, ok := p.entries[e]; !ok {
-			p.entries[e] = NewConsistentHash()
+			p.entries[e] = NewConsistentHashWithOptions(p.replicationFactor, p.loadFactor)
 		}
 
 		exists := p.entries[e].Add(host.Name, host.Id, host.Port)
@@ -238,6 +286,7 @@ func (p *Service) Run(port string, certChain *dapr_credentials.CertChain) {
 	}
 	s := grpc.NewServer(opts...)
 	placementv1pb.RegisterPlacementServiceServer(s, p)
+	placementv1pb.RegisterPlacementDrainServer(s, p)
 
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
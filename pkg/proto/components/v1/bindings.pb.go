@@ -0,0 +1,488 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/components/v1/bindings.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type InvokeRequest struct {
+	Data                 []byte            `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *InvokeRequest) Reset()         { *m = InvokeRequest{} }
+func (m *InvokeRequest) String() string { return proto.CompactTextString(m) }
+func (*InvokeRequest) ProtoMessage()    {}
+func (*InvokeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{0}
+}
+
+func (m *InvokeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeRequest.Unmarshal(m, b)
+}
+func (m *InvokeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeRequest.Marshal(b, m, deterministic)
+}
+func (m *InvokeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeRequest.Merge(m, src)
+}
+func (m *InvokeRequest) XXX_Size() int {
+	return xxx_messageInfo_InvokeRequest.Size(m)
+}
+func (m *InvokeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeRequest proto.InternalMessageInfo
+
+func (m *InvokeRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *InvokeRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type InvokeResponse struct {
+	Data                 []byte            `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *InvokeResponse) Reset()         { *m = InvokeResponse{} }
+func (m *InvokeResponse) String() string { return proto.CompactTextString(m) }
+func (*InvokeResponse) ProtoMessage()    {}
+func (*InvokeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{1}
+}
+
+func (m *InvokeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeResponse.Unmarshal(m, b)
+}
+func (m *InvokeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeResponse.Marshal(b, m, deterministic)
+}
+func (m *InvokeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeResponse.Merge(m, src)
+}
+func (m *InvokeResponse) XXX_Size() int {
+	return xxx_messageInfo_InvokeResponse.Size(m)
+}
+func (m *InvokeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeResponse proto.InternalMessageInfo
+
+func (m *InvokeResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *InvokeResponse) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*InvokeRequest)(nil), "dapr.proto.components.v1.InvokeRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.InvokeRequest.MetadataEntry")
+	proto.RegisterType((*InvokeResponse)(nil), "dapr.proto.components.v1.InvokeResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.InvokeResponse.MetadataEntry")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/components/v1/bindings.proto", fileDescriptor_41e4fa278c4a6074)
+}
+
+var fileDescriptor_41e4fa278c4a6074 = []byte{
+	// 371 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x93, 0x41, 0x4f, 0xfa, 0x30,
+	0x18, 0xc6, 0x53, 0xe0, 0x4f, 0xfe, 0x56, 0x30, 0xa6, 0x31, 0x86, 0xcc, 0x0b, 0x12, 0x09, 0x3b,
+	0x98, 0x4e, 0x30, 0x12, 0xa3, 0x27, 0x49, 0x38, 0x70, 0x20, 0xea, 0x8e, 0x7a, 0xda, 0xd8, 0xeb,
+	0x5c, 0xc6, 0xda, 0x4a, 0xbb, 0x25, 0x7c, 0x2e, 0xe3, 0x97, 0xd2, 0x2f, 0x61, 0xd6, 0x82, 0x86,
+	0xc8, 0x94, 0x8b, 0x89, 0x97, 0xe5, 0xdd, 0xfb, 0x3e, 0x7d, 0xfa, 0xdb, 0x9e, 0x16, 0x77, 0x02,
+	0x4f, 0xcc, 0x1c, 0x31, 0xe3, 0x8a, 0x3b, 0x13, 0x9e, 0x08, 0xce, 0x80, 0x29, 0xe9, 0x64, 0x5d,
+	0xc7, 0x8f, 0x58, 0x10, 0xb1, 0x50, 0x52, 0x3d, 0x24, 0x8d, 0x5c, 0x68, 0x6a, 0xfa, 0x29, 0xa4,
+	0x59, 0xd7, 0x3a, 0x08, 0x39, 0x0f, 0xa7, 0x60, 0x4c, 0xfc, 0xf4, 0xc1, 0x81, 0x44, 0xa8, 0xb9,
+	0x91, 0x5a, 0xed, 0x42, 0xff, 0x09, 0x4f, 0x12, 0xce, 0x8c, 0xac, 0xf5, 0x8c, 0x70, 0x7d, 0xc4,
+	0x32, 0x1e, 0x83, 0x0b, 0x4f, 0x29, 0x48, 0x45, 0x08, 0xae, 0x04, 0x9e, 0xf2, 0x1a, 0xa8, 0x89,
+	0xec, 0x9a, 0xab, 0x6b, 0x72, 0x8b, 0xff, 0x27, 0xa0, 0x3c, 0xdd, 0x2f, 0x35, 0xcb, 0xf6, 0x76,
+	0xef, 0x8c, 0x16, 0x61, 0xd1, 0x15, 0x3b, 0x3a, 0x5e, 0xac, 0x1b, 0x32, 0x35, 0x9b, 0xbb, 0x1f,
+	0x36, 0xd6, 0x25, 0xae, 0xaf, 0x8c, 0xc8, 0x2e, 0x2e, 0xc7, 0x30, 0xd7, 0xdb, 0x6e, 0xb9, 0x79,
+	0x49, 0xf6, 0xf0, 0xbf, 0xcc, 0x9b, 0xa6, 0xd0, 0x28, 0xe9, 0x9e, 0x79, 0xb9, 0x28, 0x9d, 0xa3,
+	0xd6, 0x0b, 0xc2, 0x3b, 0xcb, 0x6d, 0xa4, 0xe0, 0x4c, 0xc2, 0x5a, 0x6c, 0xf7, 0x0b, 0x76, 0xff,
+	0x67, 0x6c, 0xe3, 0xf7, 0x2b, 0xdc, 0xbd, 0x57, 0x84, 0x6b, 0x23, 0x26, 0x52, 0x35, 0x30, 0x19,
+	0x93, 0x21, 0xae, 0x8c, 0x58, 0xa4, 0x48, 0xfb, 0x3b, 0xae, 0x48, 0x2d, 0x7e, 0xa6, 0xb5, 0x4f,
+	0x4d, 0xe4, 0x74, 0x19, 0x39, 0x1d, 0xe6, 0x91, 0x93, 0x3e, 0xae, 0xdc, 0xe4, 0x76, 0x05, 0xf3,
+	0xc2, 0x75, 0xf7, 0xb8, 0xe6, 0x82, 0x17, 0x8c, 0x41, 0x4a, 0x2f, 0x04, 0x49, 0x8e, 0x8a, 0x31,
+	0xae, 0x26, 0xf1, 0x92, 0xe2, 0xb0, 0x58, 0xb5, 0x70, 0xb2, 0xd1, 0x09, 0xea, 0xbd, 0x21, 0x5c,
+	0xbf, 0x4e, 0xd5, 0x5f, 0xfa, 0xda, 0xaa, 0x09, 0x99, 0x74, 0x36, 0x3c, 0xbd, 0x96, 0xbd, 0xe9,
+	0x79, 0x19, 0xd0, 0xbb, 0xe3, 0x30, 0x52, 0x8f, 0xa9, 0x9f, 0xcb, 0x1c, 0x7d, 0xf9, 0xf4, 0x43,
+	0xc4, 0xe1, 0xba, 0x5b, 0xe8, 0x57, 0x75, 0xf3, 0xf4, 0x7d, 0x00, 0x90, 0x4f, 0xb2, 0x58, 0x08,
+	0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// InputBindingClient is the client API for InputBinding service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type InputBindingClient interface {
+	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	ReadMessages(ctx context.Context, opts ...grpc.CallOption) (InputBinding_ReadMessagesClient, error)
+}
+
+type inputBindingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewInputBindingClient(cc *grpc.ClientConn) InputBindingClient {
+	return &inputBindingClient{cc}
+}
+
+func (c *inputBindingClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.InputBinding/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputBindingClient) Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.InputBinding/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputBindingClient) ReadMessages(ctx context.Context, opts ...grpc.CallOption) (InputBinding_ReadMessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_InputBinding_serviceDesc.Streams[0], "/dapr.proto.components.v1.InputBinding/ReadMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &inputBindingReadMessagesClient{stream}
+	return x, nil
+}
+
+type InputBinding_ReadMessagesClient interface {
+	Send(*AckRequest) error
+	Recv() (*Message, error)
+	grpc.ClientStream
+}
+
+type inputBindingReadMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *inputBindingReadMessagesClient) Send(m *AckRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *inputBindingReadMessagesClient) Recv() (*Message, error) {
+	m := new(Message)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// InputBindingServer is the server API for InputBinding service.
+type InputBindingServer interface {
+	Init(context.Context, *InitRequest) (*empty.Empty, error)
+	Ping(context.Context, *empty.Empty) (*empty.Empty, error)
+	ReadMessages(InputBinding_ReadMessagesServer) error
+}
+
+// UnimplementedInputBindingServer can be embedded to have forward compatible implementations.
+type UnimplementedInputBindingServer struct {
+}
+
+func (*UnimplementedInputBindingServer) Init(ctx context.Context, req *InitRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (*UnimplementedInputBindingServer) Ping(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedInputBindingServer) ReadMessages(srv InputBinding_ReadMessagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadMessages not implemented")
+}
+
+func RegisterInputBindingServer(s *grpc.Server, srv InputBindingServer) {
+	s.RegisterService(&_InputBinding_serviceDesc, srv)
+}
+
+func _InputBinding_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputBindingServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.InputBinding/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputBindingServer).Init(ctx, req.(*InitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputBinding_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputBindingServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.InputBinding/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputBindingServer).Ping(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputBinding_ReadMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(InputBindingServer).ReadMessages(&inputBindingReadMessagesServer{stream})
+}
+
+type InputBinding_ReadMessagesServer interface {
+	Send(*Message) error
+	Recv() (*AckRequest, error)
+	grpc.ServerStream
+}
+
+type inputBindingReadMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *inputBindingReadMessagesServer) Send(m *Message) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *inputBindingReadMessagesServer) Recv() (*AckRequest, error) {
+	m := new(AckRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _InputBinding_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.InputBinding",
+	HandlerType: (*InputBindingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _InputBinding_Init_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _InputBinding_Ping_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadMessages",
+			Handler:       _InputBinding_ReadMessages_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/components/v1/bindings.proto",
+}
+
+// OutputBindingClient is the client API for OutputBinding service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OutputBindingClient interface {
+	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error)
+}
+
+type outputBindingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOutputBindingClient(cc *grpc.ClientConn) OutputBindingClient {
+	return &outputBindingClient{cc}
+}
+
+func (c *outputBindingClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputBindingClient) Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputBindingClient) Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error) {
+	out := new(InvokeResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/Invoke", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutputBindingServer is the server API for OutputBinding service.
+type OutputBindingServer interface {
+	Init(context.Context, *InitRequest) (*empty.Empty, error)
+	Ping(context.Context, *empty.Empty) (*empty.Empty, error)
+	Invoke(context.Context, *InvokeRequest) (*InvokeResponse, error)
+}
+
+// UnimplementedOutputBindingServer can be embedded to have forward compatible implementations.
+type UnimplementedOutputBindingServer struct {
+}
+
+func (*UnimplementedOutputBindingServer) Init(ctx context.Context, req *InitRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (*UnimplementedOutputBindingServer) Ping(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedOutputBindingServer) Invoke(ctx context.Context, req *InvokeRequest) (*InvokeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Invoke not implemented")
+}
+
+func RegisterOutputBindingServer(s *grpc.Server, srv OutputBindingServer) {
+	s.RegisterService(&_OutputBinding_serviceDesc, srv)
+}
+
+func _OutputBinding_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).Init(ctx, req.(*InitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputBinding_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).Ping(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputBinding_Invoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).Invoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/Invoke",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).Invoke(ctx, req.(*InvokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OutputBinding_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.OutputBinding",
+	HandlerType: (*OutputBindingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _OutputBinding_Init_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _OutputBinding_Ping_Handler,
+		},
+		{
+			MethodName: "Invoke",
+			Handler:    _OutputBinding_Invoke_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/components/v1/bindings.proto",
+}
@@ -0,0 +1,229 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/components/v1/common.proto
+
+package v1
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// InitRequest passes the name and the metadata of the component to the
+// component process.
+type InitRequest struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *InitRequest) Reset()         { *m = InitRequest{} }
+func (m *InitRequest) String() string { return proto.CompactTextString(m) }
+func (*InitRequest) ProtoMessage()    {}
+func (*InitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c195287edb1eea95, []int{0}
+}
+
+func (m *InitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InitRequest.Unmarshal(m, b)
+}
+func (m *InitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InitRequest.Marshal(b, m, deterministic)
+}
+func (m *InitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InitRequest.Merge(m, src)
+}
+func (m *InitRequest) XXX_Size() int {
+	return xxx_messageInfo_InitRequest.Size(m)
+}
+func (m *InitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InitRequest proto.InternalMessageInfo
+
+func (m *InitRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *InitRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// AckRequest is sent by the runtime on the PullMessages and ReadMessages
+// streams. The first request of a PullMessages stream names the topic to
+// subscribe to, the following ones acknowledge the delivered messages, with
+// an error when the app failed to process the message.
+type AckRequest struct {
+	Topic                string   `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	MessageId            string   `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AckRequest) Reset()         { *m = AckRequest{} }
+func (m *AckRequest) String() string { return proto.CompactTextString(m) }
+func (*AckRequest) ProtoMessage()    {}
+func (*AckRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c195287edb1eea95, []int{1}
+}
+
+func (m *AckRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AckRequest.Unmarshal(m, b)
+}
+func (m *AckRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AckRequest.Marshal(b, m, deterministic)
+}
+func (m *AckRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AckRequest.Merge(m, src)
+}
+func (m *AckRequest) XXX_Size() int {
+	return xxx_messageInfo_AckRequest.Size(m)
+}
+func (m *AckRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AckRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AckRequest proto.InternalMessageInfo
+
+func (m *AckRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *AckRequest) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *AckRequest) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// Message is a message delivered by the component process on the
+// PullMessages and ReadMessages streams.
+type Message struct {
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic                string            `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Data                 []byte            `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c195287edb1eea95, []int{2}
+}
+
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Message.Unmarshal(m, b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+}
+func (m *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(m, src)
+}
+func (m *Message) XXX_Size() int {
+	return xxx_messageInfo_Message.Size(m)
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Message proto.InternalMessageInfo
+
+func (m *Message) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Message) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *Message) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Message) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*InitRequest)(nil), "dapr.proto.components.v1.InitRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.InitRequest.MetadataEntry")
+	proto.RegisterType((*AckRequest)(nil), "dapr.proto.components.v1.AckRequest")
+	proto.RegisterType((*Message)(nil), "dapr.proto.components.v1.Message")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.Message.MetadataEntry")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/components/v1/common.proto", fileDescriptor_c195287edb1eea95)
+}
+
+var fileDescriptor_c195287edb1eea95 = []byte{
+	// 302 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x52, 0x41, 0x4b, 0xc3, 0x30,
+	0x18, 0xa5, 0x59, 0xa7, 0xee, 0x9b, 0x8a, 0x84, 0x1d, 0x8a, 0x20, 0x8c, 0x82, 0xb0, 0x83, 0xa4,
+	0xcc, 0x5d, 0x44, 0x4f, 0x0a, 0x1e, 0x86, 0x0c, 0xa1, 0x17, 0xc1, 0x8b, 0x64, 0x4d, 0xa8, 0xa1,
+	0x36, 0xa9, 0x69, 0x5a, 0xd8, 0xaf, 0xf2, 0x97, 0xf8, 0x9f, 0x24, 0x69, 0x74, 0x15, 0xdc, 0xd1,
+	0x4b, 0x79, 0xef, 0xcb, 0xf7, 0xde, 0x2b, 0x8f, 0x0f, 0xce, 0x19, 0xad, 0x74, 0x52, 0x69, 0x65,
+	0x54, 0x92, 0xa9, 0xb2, 0x52, 0x92, 0x4b, 0x53, 0x27, 0xed, 0xdc, 0xb2, 0x52, 0x49, 0xe2, 0x9e,
+	0x70, 0x64, 0xd7, 0x3a, 0x4c, 0xb6, 0x6b, 0xa4, 0x9d, 0xc7, 0x1f, 0x01, 0x8c, 0x97, 0x52, 0x98,
+	0x94, 0xbf, 0x37, 0xbc, 0x36, 0x18, 0x43, 0x28, 0x69, 0xc9, 0xa3, 0x60, 0x1a, 0xcc, 0x46, 0xa9,
+	0xc3, 0xf8, 0x11, 0x0e, 0x4a, 0x6e, 0x28, 0xa3, 0x86, 0x46, 0x68, 0x3a, 0x98, 0x8d, 0x2f, 0x17,
+	0x64, 0x97, 0x21, 0xe9, 0x99, 0x91, 0x95, 0x57, 0xdd, 0x4b, 0xa3, 0x37, 0xe9, 0x8f, 0xc9, 0xe9,
+	0x0d, 0x1c, 0xfd, 0x7a, 0xc2, 0x27, 0x30, 0x28, 0xf8, 0xc6, 0x87, 0x5a, 0x88, 0x27, 0x30, 0x6c,
+	0xe9, 0x5b, 0xc3, 0x23, 0xe4, 0x66, 0x1d, 0xb9, 0x46, 0x57, 0x41, 0xfc, 0x04, 0x70, 0x9b, 0x15,
+	0xdf, 0xff, 0x3b, 0x81, 0xa1, 0x51, 0x95, 0xc8, 0xbc, 0xb6, 0x23, 0xf8, 0x0c, 0xa0, 0xe4, 0x75,
+	0x4d, 0x73, 0xfe, 0x22, 0x98, 0xb7, 0x18, 0xf9, 0xc9, 0x92, 0x59, 0x11, 0xd7, 0x5a, 0xe9, 0x68,
+	0xd0, 0x89, 0x1c, 0x89, 0x3f, 0x03, 0xd8, 0x5f, 0x75, 0x3b, 0xf8, 0x18, 0x90, 0x60, 0xde, 0x13,
+	0x09, 0xb6, 0x8d, 0x41, 0xfd, 0x18, 0x0c, 0xa1, 0x2b, 0xc5, 0xda, 0x1c, 0xa6, 0x0e, 0xe3, 0x87,
+	0x5e, 0x59, 0xa1, 0x2b, 0x2b, 0xd9, 0x5d, 0x96, 0x8f, 0xfb, 0x97, 0xa2, 0xee, 0xc8, 0xf3, 0x45,
+	0x2e, 0xcc, 0x6b, 0xb3, 0xb6, 0xa1, 0x89, 0x3b, 0x14, 0xf7, 0xa9, 0x8a, 0xfc, 0xaf, 0x8b, 0x59,
+	0xef, 0xb9, 0xe1, 0xe2, 0x6b, 0x00, 0xc2, 0xac, 0x56, 0x74, 0x54, 0x02, 0x00, 0x00,
+}
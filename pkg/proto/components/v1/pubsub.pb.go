@@ -0,0 +1,324 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/components/v1/pubsub.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type PublishRequest struct {
+	Topic                string   `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishRequest) Reset()         { *m = PublishRequest{} }
+func (m *PublishRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishRequest) ProtoMessage()    {}
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79bd61ce30be5363, []int{0}
+}
+
+func (m *PublishRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishRequest.Unmarshal(m, b)
+}
+func (m *PublishRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishRequest.Merge(m, src)
+}
+func (m *PublishRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishRequest.Size(m)
+}
+func (m *PublishRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishRequest proto.InternalMessageInfo
+
+func (m *PublishRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *PublishRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*PublishRequest)(nil), "dapr.proto.components.v1.PublishRequest")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/components/v1/pubsub.proto", fileDescriptor_79bd61ce30be5363)
+}
+
+var fileDescriptor_79bd61ce30be5363 = []byte{
+	// 277 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x41, 0x4b, 0xc3, 0x30,
+	0x14, 0xc7, 0xc9, 0x98, 0x13, 0xc3, 0xf0, 0xf0, 0x10, 0x29, 0xf5, 0x32, 0xc5, 0x41, 0x0f, 0x92,
+	0x38, 0x05, 0x0f, 0xde, 0x14, 0x76, 0x10, 0x11, 0x4a, 0xbd, 0xe9, 0xa9, 0xe9, 0x62, 0x16, 0xd6,
+	0x26, 0x71, 0x49, 0x06, 0x7e, 0x2c, 0xbf, 0xa1, 0x34, 0xe9, 0x10, 0xc1, 0x5c, 0xca, 0x6b, 0xde,
+	0x2f, 0xbf, 0xfc, 0x1f, 0x0f, 0xcf, 0x57, 0xb5, 0xd9, 0x52, 0xb3, 0xd5, 0x4e, 0xd3, 0x46, 0x77,
+	0x46, 0x2b, 0xae, 0x9c, 0xa5, 0xbb, 0x05, 0x35, 0x9e, 0x59, 0xcf, 0x48, 0x68, 0x41, 0xd6, 0x63,
+	0xb1, 0x26, 0xbf, 0x18, 0xd9, 0x2d, 0xf2, 0x33, 0xa1, 0xb5, 0x68, 0x79, 0x54, 0x30, 0xff, 0x41,
+	0x79, 0x67, 0xdc, 0x57, 0x44, 0xf3, 0xb4, 0xbd, 0xd1, 0x5d, 0xa7, 0x55, 0xc4, 0x2e, 0xee, 0xf1,
+	0x71, 0xe9, 0x59, 0x2b, 0xed, 0xba, 0xe2, 0x9f, 0x9e, 0x5b, 0x07, 0x27, 0xf8, 0xc0, 0x69, 0x23,
+	0x9b, 0x0c, 0xcd, 0x50, 0x71, 0x54, 0xc5, 0x1f, 0x00, 0x3c, 0x5e, 0xd5, 0xae, 0xce, 0x46, 0x33,
+	0x54, 0x4c, 0xab, 0x50, 0xdf, 0x7c, 0x8f, 0xf0, 0xa4, 0xf4, 0xec, 0xd5, 0x33, 0x58, 0xe2, 0xf1,
+	0x93, 0x92, 0x0e, 0xe6, 0x24, 0x95, 0x96, 0xf4, 0xfd, 0xe1, 0x8d, 0xfc, 0x94, 0xc4, 0xe8, 0x64,
+	0x1f, 0x9d, 0x2c, 0xfb, 0xe8, 0x70, 0x87, 0xc7, 0xa5, 0x54, 0x02, 0x12, 0xfd, 0xe4, 0xbd, 0x67,
+	0x7c, 0x38, 0x4c, 0x01, 0x45, 0x3a, 0xc1, 0xdf, 0x41, 0x93, 0xb2, 0x77, 0x3c, 0x2d, 0x7d, 0xdb,
+	0xbe, 0x70, 0x6b, 0x6b, 0xc1, 0x2d, 0x5c, 0xa6, 0x8d, 0x0f, 0xcd, 0x66, 0x6f, 0x3b, 0x4f, 0x53,
+	0x83, 0xa9, 0x40, 0xd7, 0xe8, 0x91, 0xbc, 0x5d, 0x09, 0xe9, 0xd6, 0x9e, 0xf5, 0x0c, 0x0d, 0x3b,
+	0x0a, 0x1f, 0xb3, 0x11, 0xff, 0x2d, 0x8b, 0x4d, 0xc2, 0xe1, 0xed, 0xcf, 0x00, 0x56, 0x8d, 0xd7,
+	0x83, 0x2d, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PubSubClient is the client API for PubSub service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PubSubClient interface {
+	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	PullMessages(ctx context.Context, opts ...grpc.CallOption) (PubSub_PullMessagesClient, error)
+}
+
+type pubSubClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPubSubClient(cc *grpc.ClientConn) PubSubClient {
+	return &pubSubClient{cc}
+}
+
+func (c *pubSubClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pubSubClient) Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pubSubClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Publish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pubSubClient) PullMessages(ctx context.Context, opts ...grpc.CallOption) (PubSub_PullMessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PubSub_serviceDesc.Streams[0], "/dapr.proto.components.v1.PubSub/PullMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pubSubPullMessagesClient{stream}
+	return x, nil
+}
+
+type PubSub_PullMessagesClient interface {
+	Send(*AckRequest) error
+	Recv() (*Message, error)
+	grpc.ClientStream
+}
+
+type pubSubPullMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *pubSubPullMessagesClient) Send(m *AckRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pubSubPullMessagesClient) Recv() (*Message, error) {
+	m := new(Message)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PubSubServer is the server API for PubSub service.
+type PubSubServer interface {
+	Init(context.Context, *InitRequest) (*empty.Empty, error)
+	Ping(context.Context, *empty.Empty) (*empty.Empty, error)
+	Publish(context.Context, *PublishRequest) (*empty.Empty, error)
+	PullMessages(PubSub_PullMessagesServer) error
+}
+
+// UnimplementedPubSubServer can be embedded to have forward compatible implementations.
+type UnimplementedPubSubServer struct {
+}
+
+func (*UnimplementedPubSubServer) Init(ctx context.Context, req *InitRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (*UnimplementedPubSubServer) Ping(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedPubSubServer) Publish(ctx context.Context, req *PublishRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (*UnimplementedPubSubServer) PullMessages(srv PubSub_PullMessagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method PullMessages not implemented")
+}
+
+func RegisterPubSubServer(s *grpc.Server, srv PubSubServer) {
+	s.RegisterService(&_PubSub_serviceDesc, srv)
+}
+
+func _PubSub_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Init(ctx, req.(*InitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PubSub_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Ping(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PubSub_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Publish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PubSub_PullMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PubSubServer).PullMessages(&pubSubPullMessagesServer{stream})
+}
+
+type PubSub_PullMessagesServer interface {
+	Send(*Message) error
+	Recv() (*AckRequest, error)
+	grpc.ServerStream
+}
+
+type pubSubPullMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *pubSubPullMessagesServer) Send(m *Message) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pubSubPullMessagesServer) Recv() (*AckRequest, error) {
+	m := new(AckRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _PubSub_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.PubSub",
+	HandlerType: (*PubSubServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _PubSub_Init_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _PubSub_Ping_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _PubSub_Publish_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PullMessages",
+			Handler:       _PubSub_PullMessages_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/components/v1/pubsub.proto",
+}
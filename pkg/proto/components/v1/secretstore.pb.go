@@ -0,0 +1,427 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/components/v1/secretstore.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetSecretRequest struct {
+	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetSecretRequest) Reset()         { *m = GetSecretRequest{} }
+func (m *GetSecretRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSecretRequest) ProtoMessage()    {}
+func (*GetSecretRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_449764f753f3d28a, []int{0}
+}
+
+func (m *GetSecretRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSecretRequest.Unmarshal(m, b)
+}
+func (m *GetSecretRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSecretRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSecretRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSecretRequest.Merge(m, src)
+}
+func (m *GetSecretRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSecretRequest.Size(m)
+}
+func (m *GetSecretRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSecretRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSecretRequest proto.InternalMessageInfo
+
+func (m *GetSecretRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetSecretRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// GetSecretResponse holds the values of a secret by name.
+type GetSecretResponse struct {
+	Data                 map[string]string `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetSecretResponse) Reset()         { *m = GetSecretResponse{} }
+func (m *GetSecretResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSecretResponse) ProtoMessage()    {}
+func (*GetSecretResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_449764f753f3d28a, []int{1}
+}
+
+func (m *GetSecretResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSecretResponse.Unmarshal(m, b)
+}
+func (m *GetSecretResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSecretResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSecretResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSecretResponse.Merge(m, src)
+}
+func (m *GetSecretResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSecretResponse.Size(m)
+}
+func (m *GetSecretResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSecretResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSecretResponse proto.InternalMessageInfo
+
+func (m *GetSecretResponse) GetData() map[string]string {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type BulkGetSecretRequest struct {
+	Metadata             map[string]string `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BulkGetSecretRequest) Reset()         { *m = BulkGetSecretRequest{} }
+func (m *BulkGetSecretRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkGetSecretRequest) ProtoMessage()    {}
+func (*BulkGetSecretRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_449764f753f3d28a, []int{2}
+}
+
+func (m *BulkGetSecretRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkGetSecretRequest.Unmarshal(m, b)
+}
+func (m *BulkGetSecretRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkGetSecretRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkGetSecretRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkGetSecretRequest.Merge(m, src)
+}
+func (m *BulkGetSecretRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkGetSecretRequest.Size(m)
+}
+func (m *BulkGetSecretRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkGetSecretRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkGetSecretRequest proto.InternalMessageInfo
+
+func (m *BulkGetSecretRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// BulkGetSecretResponse holds the secrets of the store by name.
+type BulkGetSecretResponse struct {
+	Data                 map[string]*GetSecretResponse `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *BulkGetSecretResponse) Reset()         { *m = BulkGetSecretResponse{} }
+func (m *BulkGetSecretResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkGetSecretResponse) ProtoMessage()    {}
+func (*BulkGetSecretResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_449764f753f3d28a, []int{3}
+}
+
+func (m *BulkGetSecretResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkGetSecretResponse.Unmarshal(m, b)
+}
+func (m *BulkGetSecretResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkGetSecretResponse.Marshal(b, m, deterministic)
+}
+func (m *BulkGetSecretResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkGetSecretResponse.Merge(m, src)
+}
+func (m *BulkGetSecretResponse) XXX_Size() int {
+	return xxx_messageInfo_BulkGetSecretResponse.Size(m)
+}
+func (m *BulkGetSecretResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkGetSecretResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkGetSecretResponse proto.InternalMessageInfo
+
+func (m *BulkGetSecretResponse) GetData() map[string]*GetSecretResponse {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetSecretRequest)(nil), "dapr.proto.components.v1.GetSecretRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.GetSecretRequest.MetadataEntry")
+	proto.RegisterType((*GetSecretResponse)(nil), "dapr.proto.components.v1.GetSecretResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.GetSecretResponse.DataEntry")
+	proto.RegisterType((*BulkGetSecretRequest)(nil), "dapr.proto.components.v1.BulkGetSecretRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.BulkGetSecretRequest.MetadataEntry")
+	proto.RegisterType((*BulkGetSecretResponse)(nil), "dapr.proto.components.v1.BulkGetSecretResponse")
+	proto.RegisterMapType((map[string]*GetSecretResponse)(nil), "dapr.proto.components.v1.BulkGetSecretResponse.DataEntry")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/components/v1/secretstore.proto", fileDescriptor_449764f753f3d28a)
+}
+
+var fileDescriptor_449764f753f3d28a = []byte{
+	// 414 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xdf, 0x4a, 0x02, 0x41,
+	0x14, 0xc6, 0x99, 0xd5, 0x22, 0x8f, 0x08, 0x36, 0x58, 0x2c, 0xdb, 0x8d, 0x08, 0x82, 0x58, 0xcc,
+	0xa2, 0x51, 0xd9, 0x9f, 0x9b, 0x44, 0x09, 0x2f, 0x84, 0xd0, 0x2e, 0xa2, 0xbb, 0xd5, 0x9d, 0x36,
+	0xd1, 0xdd, 0xd9, 0x76, 0x67, 0x05, 0x9f, 0xa4, 0xa7, 0xe8, 0xba, 0x97, 0xe8, 0x31, 0x7a, 0x90,
+	0xd8, 0x19, 0xb3, 0xd5, 0xdc, 0x70, 0x83, 0x6e, 0x64, 0x9c, 0x73, 0xe6, 0x3b, 0xbf, 0xf9, 0xce,
+	0x9e, 0x81, 0xaa, 0x69, 0xb8, 0x9e, 0xee, 0x7a, 0x8c, 0x33, 0x7d, 0xc8, 0x6c, 0x97, 0x39, 0xd4,
+	0xe1, 0xbe, 0x3e, 0xad, 0xe9, 0x3e, 0x1d, 0x7a, 0x94, 0xfb, 0x9c, 0x79, 0x94, 0x88, 0x38, 0x56,
+	0xc3, 0x5c, 0xb9, 0x26, 0xdf, 0xb9, 0x64, 0x5a, 0xd3, 0x0e, 0x2c, 0xc6, 0xac, 0x09, 0x95, 0x3a,
+	0x83, 0xe0, 0x51, 0xa7, 0xb6, 0xcb, 0x67, 0x32, 0x55, 0x2b, 0xc7, 0x96, 0x18, 0x32, 0xdb, 0x66,
+	0x8e, 0x4c, 0x2b, 0xbd, 0x21, 0xc8, 0xdf, 0x50, 0xde, 0x17, 0x65, 0x7b, 0xf4, 0x39, 0xa0, 0x3e,
+	0xc7, 0x79, 0x48, 0x8d, 0xe9, 0x4c, 0x45, 0x45, 0x54, 0xc9, 0xf4, 0xc2, 0x25, 0xbe, 0x83, 0x1d,
+	0x9b, 0x72, 0xc3, 0x34, 0xb8, 0xa1, 0x2a, 0xc5, 0x54, 0x25, 0x5b, 0x6f, 0x90, 0x38, 0x2e, 0xb2,
+	0xaa, 0x47, 0xba, 0xf3, 0xa3, 0x6d, 0x87, 0x7b, 0xb3, 0xde, 0x42, 0x49, 0xbb, 0x84, 0xdc, 0x52,
+	0x68, 0x4d, 0xe1, 0x02, 0x6c, 0x4d, 0x8d, 0x49, 0x40, 0x55, 0x45, 0xec, 0xc9, 0x3f, 0x17, 0x4a,
+	0x03, 0x95, 0x5e, 0x10, 0xec, 0x46, 0x2a, 0xf9, 0x2e, 0x73, 0x7c, 0x8a, 0x3b, 0x90, 0x16, 0x90,
+	0x48, 0x40, 0x9e, 0x6c, 0x04, 0x29, 0x8f, 0x92, 0xd6, 0x82, 0x50, 0x48, 0x68, 0x67, 0x90, 0x69,
+	0xfd, 0x89, 0xec, 0x15, 0x41, 0xa1, 0x19, 0x4c, 0xc6, 0x3f, 0x7c, 0xbd, 0x8f, 0xb8, 0x28, 0x01,
+	0xaf, 0xe2, 0x01, 0xd7, 0x29, 0xfc, 0x8f, 0x93, 0xef, 0x08, 0xf6, 0x56, 0xaa, 0xcd, 0xdd, 0xec,
+	0x2e, 0xb9, 0x79, 0xbe, 0x31, 0x6c, 0x8c, 0xa3, 0xe6, 0xef, 0x8e, 0x5e, 0x47, 0x09, 0xb3, 0xf5,
+	0xc3, 0x04, 0xcd, 0x8b, 0x5c, 0xa7, 0xfe, 0xa1, 0x40, 0x56, 0x46, 0xfb, 0x9c, 0x79, 0x14, 0xb7,
+	0x21, 0xdd, 0x71, 0x46, 0x1c, 0x97, 0xe3, 0xf5, 0xc2, 0xf8, 0xdc, 0x62, 0x6d, 0x9f, 0xc8, 0xb1,
+	0x22, 0x5f, 0x63, 0x45, 0xda, 0xe1, 0x58, 0xe1, 0x53, 0x48, 0xdf, 0x8e, 0x1c, 0x0b, 0xc7, 0xc4,
+	0x63, 0xcf, 0x99, 0x90, 0x59, 0xe0, 0xe2, 0xea, 0xe6, 0x53, 0xa3, 0x25, 0xb9, 0x3f, 0x76, 0x21,
+	0xb7, 0xd4, 0x03, 0x4c, 0x92, 0x7d, 0x59, 0x9a, 0x9e, 0xb0, 0xb9, 0x4d, 0xf2, 0x70, 0x64, 0x8d,
+	0xf8, 0x53, 0x30, 0x08, 0xb3, 0x75, 0xf1, 0xda, 0x88, 0x1f, 0x77, 0x6c, 0xad, 0x7b, 0x76, 0x06,
+	0xdb, 0x62, 0xf3, 0xf8, 0x73, 0x00, 0x9c, 0x70, 0x47, 0xc3, 0xfc, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SecretStoreClient is the client API for SecretStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SecretStoreClient interface {
+	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
+	// BulkGetSecret returns all the secrets of the store.
+	BulkGetSecret(ctx context.Context, in *BulkGetSecretRequest, opts ...grpc.CallOption) (*BulkGetSecretResponse, error)
+}
+
+type secretStoreClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSecretStoreClient(cc *grpc.ClientConn) SecretStoreClient {
+	return &secretStoreClient{cc}
+}
+
+func (c *secretStoreClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.SecretStore/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretStoreClient) Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.SecretStore/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretStoreClient) GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error) {
+	out := new(GetSecretResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.SecretStore/GetSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretStoreClient) BulkGetSecret(ctx context.Context, in *BulkGetSecretRequest, opts ...grpc.CallOption) (*BulkGetSecretResponse, error) {
+	out := new(BulkGetSecretResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.SecretStore/BulkGetSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SecretStoreServer is the server API for SecretStore service.
+type SecretStoreServer interface {
+	Init(context.Context, *InitRequest) (*empty.Empty, error)
+	Ping(context.Context, *empty.Empty) (*empty.Empty, error)
+	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
+	// BulkGetSecret returns all the secrets of the store.
+	BulkGetSecret(context.Context, *BulkGetSecretRequest) (*BulkGetSecretResponse, error)
+}
+
+// UnimplementedSecretStoreServer can be embedded to have forward compatible implementations.
+type UnimplementedSecretStoreServer struct {
+}
+
+func (*UnimplementedSecretStoreServer) Init(ctx context.Context, req *InitRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (*UnimplementedSecretStoreServer) Ping(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedSecretStoreServer) GetSecret(ctx context.Context, req *GetSecretRequest) (*GetSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecret not implemented")
+}
+func (*UnimplementedSecretStoreServer) BulkGetSecret(ctx context.Context, req *BulkGetSecretRequest) (*BulkGetSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkGetSecret not implemented")
+}
+
+func RegisterSecretStoreServer(s *grpc.Server, srv SecretStoreServer) {
+	s.RegisterService(&_SecretStore_serviceDesc, srv)
+}
+
+func _SecretStore_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretStoreServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.SecretStore/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretStoreServer).Init(ctx, req.(*InitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretStore_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretStoreServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.SecretStore/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretStoreServer).Ping(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretStore_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretStoreServer).GetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.SecretStore/GetSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretStoreServer).GetSecret(ctx, req.(*GetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretStore_BulkGetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkGetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretStoreServer).BulkGetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.SecretStore/BulkGetSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretStoreServer).BulkGetSecret(ctx, req.(*BulkGetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SecretStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.SecretStore",
+	HandlerType: (*SecretStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _SecretStore_Init_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _SecretStore_Ping_Handler,
+		},
+		{
+			MethodName: "GetSecret",
+			Handler:    _SecretStore_GetSecret_Handler,
+		},
+		{
+			MethodName: "BulkGetSecret",
+			Handler:    _SecretStore_BulkGetSecret_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/components/v1/secretstore.proto",
+}
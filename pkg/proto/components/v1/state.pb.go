@@ -0,0 +1,998 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/components/v1/state.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetStateRequest struct {
+	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Consistency          string            `protobuf:"bytes,3,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetStateRequest) Reset()         { *m = GetStateRequest{} }
+func (m *GetStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateRequest) ProtoMessage()    {}
+func (*GetStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{0}
+}
+
+func (m *GetStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateRequest.Unmarshal(m, b)
+}
+func (m *GetStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateRequest.Merge(m, src)
+}
+func (m *GetStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateRequest.Size(m)
+}
+func (m *GetStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateRequest proto.InternalMessageInfo
+
+func (m *GetStateRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetStateRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *GetStateRequest) GetConsistency() string {
+	if m != nil {
+		return m.Consistency
+	}
+	return ""
+}
+
+// GetStateResponse has empty data when the key isn't found.
+type GetStateResponse struct {
+	Data                 []byte            `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Etag                 string            `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetStateResponse) Reset()         { *m = GetStateResponse{} }
+func (m *GetStateResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateResponse) ProtoMessage()    {}
+func (*GetStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{1}
+}
+
+func (m *GetStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateResponse.Unmarshal(m, b)
+}
+func (m *GetStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateResponse.Merge(m, src)
+}
+func (m *GetStateResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateResponse.Size(m)
+}
+func (m *GetStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateResponse proto.InternalMessageInfo
+
+func (m *GetStateResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *GetStateResponse) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *GetStateResponse) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type SetStateRequest struct {
+	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte            `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Etag                 string            `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Concurrency          string            `protobuf:"bytes,5,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	Consistency          string            `protobuf:"bytes,6,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetStateRequest) Reset()         { *m = SetStateRequest{} }
+func (m *SetStateRequest) String() string { return proto.CompactTextString(m) }
+func (*SetStateRequest) ProtoMessage()    {}
+func (*SetStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{2}
+}
+
+func (m *SetStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetStateRequest.Unmarshal(m, b)
+}
+func (m *SetStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetStateRequest.Marshal(b, m, deterministic)
+}
+func (m *SetStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetStateRequest.Merge(m, src)
+}
+func (m *SetStateRequest) XXX_Size() int {
+	return xxx_messageInfo_SetStateRequest.Size(m)
+}
+func (m *SetStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetStateRequest proto.InternalMessageInfo
+
+func (m *SetStateRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetStateRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *SetStateRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *SetStateRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *SetStateRequest) GetConcurrency() string {
+	if m != nil {
+		return m.Concurrency
+	}
+	return ""
+}
+
+func (m *SetStateRequest) GetConsistency() string {
+	if m != nil {
+		return m.Consistency
+	}
+	return ""
+}
+
+type DeleteStateRequest struct {
+	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Etag                 string            `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Concurrency          string            `protobuf:"bytes,4,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	Consistency          string            `protobuf:"bytes,5,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DeleteStateRequest) Reset()         { *m = DeleteStateRequest{} }
+func (m *DeleteStateRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteStateRequest) ProtoMessage()    {}
+func (*DeleteStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{3}
+}
+
+func (m *DeleteStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteStateRequest.Unmarshal(m, b)
+}
+func (m *DeleteStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteStateRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteStateRequest.Merge(m, src)
+}
+func (m *DeleteStateRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteStateRequest.Size(m)
+}
+func (m *DeleteStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteStateRequest proto.InternalMessageInfo
+
+func (m *DeleteStateRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *DeleteStateRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *DeleteStateRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *DeleteStateRequest) GetConcurrency() string {
+	if m != nil {
+		return m.Concurrency
+	}
+	return ""
+}
+
+func (m *DeleteStateRequest) GetConsistency() string {
+	if m != nil {
+		return m.Consistency
+	}
+	return ""
+}
+
+type BulkSetStateRequest struct {
+	Items                []*SetStateRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *BulkSetStateRequest) Reset()         { *m = BulkSetStateRequest{} }
+func (m *BulkSetStateRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkSetStateRequest) ProtoMessage()    {}
+func (*BulkSetStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{4}
+}
+
+func (m *BulkSetStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkSetStateRequest.Unmarshal(m, b)
+}
+func (m *BulkSetStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkSetStateRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkSetStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkSetStateRequest.Merge(m, src)
+}
+func (m *BulkSetStateRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkSetStateRequest.Size(m)
+}
+func (m *BulkSetStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkSetStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkSetStateRequest proto.InternalMessageInfo
+
+func (m *BulkSetStateRequest) GetItems() []*SetStateRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type BulkDeleteStateRequest struct {
+	Items                []*DeleteStateRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *BulkDeleteStateRequest) Reset()         { *m = BulkDeleteStateRequest{} }
+func (m *BulkDeleteStateRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkDeleteStateRequest) ProtoMessage()    {}
+func (*BulkDeleteStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{5}
+}
+
+func (m *BulkDeleteStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkDeleteStateRequest.Unmarshal(m, b)
+}
+func (m *BulkDeleteStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkDeleteStateRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkDeleteStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkDeleteStateRequest.Merge(m, src)
+}
+func (m *BulkDeleteStateRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkDeleteStateRequest.Size(m)
+}
+func (m *BulkDeleteStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkDeleteStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkDeleteStateRequest proto.InternalMessageInfo
+
+func (m *BulkDeleteStateRequest) GetItems() []*DeleteStateRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// QueryStateRequest holds the query in the JSON format of the state query
+// API, with the filter, the sort keys and the page of the results. Only the
+// keys starting with the key prefix are queried.
+type QueryStateRequest struct {
+	Query                []byte            `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	KeyPrefix            string            `protobuf:"bytes,2,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *QueryStateRequest) Reset()         { *m = QueryStateRequest{} }
+func (m *QueryStateRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryStateRequest) ProtoMessage()    {}
+func (*QueryStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{6}
+}
+
+func (m *QueryStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateRequest.Unmarshal(m, b)
+}
+func (m *QueryStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateRequest.Marshal(b, m, deterministic)
+}
+func (m *QueryStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateRequest.Merge(m, src)
+}
+func (m *QueryStateRequest) XXX_Size() int {
+	return xxx_messageInfo_QueryStateRequest.Size(m)
+}
+func (m *QueryStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateRequest proto.InternalMessageInfo
+
+func (m *QueryStateRequest) GetQuery() []byte {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+func (m *QueryStateRequest) GetKeyPrefix() string {
+	if m != nil {
+		return m.KeyPrefix
+	}
+	return ""
+}
+
+func (m *QueryStateRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type QueryStateItem struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryStateItem) Reset()         { *m = QueryStateItem{} }
+func (m *QueryStateItem) String() string { return proto.CompactTextString(m) }
+func (*QueryStateItem) ProtoMessage()    {}
+func (*QueryStateItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{7}
+}
+
+func (m *QueryStateItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateItem.Unmarshal(m, b)
+}
+func (m *QueryStateItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateItem.Marshal(b, m, deterministic)
+}
+func (m *QueryStateItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateItem.Merge(m, src)
+}
+func (m *QueryStateItem) XXX_Size() int {
+	return xxx_messageInfo_QueryStateItem.Size(m)
+}
+func (m *QueryStateItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateItem proto.InternalMessageInfo
+
+func (m *QueryStateItem) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *QueryStateItem) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *QueryStateItem) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+// QueryStateResponse holds a page of results and the token of the next page,
+// which is empty on the last page.
+type QueryStateResponse struct {
+	Results              []*QueryStateItem `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Token                string            `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *QueryStateResponse) Reset()         { *m = QueryStateResponse{} }
+func (m *QueryStateResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryStateResponse) ProtoMessage()    {}
+func (*QueryStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{8}
+}
+
+func (m *QueryStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateResponse.Unmarshal(m, b)
+}
+func (m *QueryStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateResponse.Marshal(b, m, deterministic)
+}
+func (m *QueryStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateResponse.Merge(m, src)
+}
+func (m *QueryStateResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryStateResponse.Size(m)
+}
+func (m *QueryStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateResponse proto.InternalMessageInfo
+
+func (m *QueryStateResponse) GetResults() []*QueryStateItem {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *QueryStateResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*GetStateRequest)(nil), "dapr.proto.components.v1.GetStateRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.GetStateRequest.MetadataEntry")
+	proto.RegisterType((*GetStateResponse)(nil), "dapr.proto.components.v1.GetStateResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.GetStateResponse.MetadataEntry")
+	proto.RegisterType((*SetStateRequest)(nil), "dapr.proto.components.v1.SetStateRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.SetStateRequest.MetadataEntry")
+	proto.RegisterType((*DeleteStateRequest)(nil), "dapr.proto.components.v1.DeleteStateRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.DeleteStateRequest.MetadataEntry")
+	proto.RegisterType((*BulkSetStateRequest)(nil), "dapr.proto.components.v1.BulkSetStateRequest")
+	proto.RegisterType((*BulkDeleteStateRequest)(nil), "dapr.proto.components.v1.BulkDeleteStateRequest")
+	proto.RegisterType((*QueryStateRequest)(nil), "dapr.proto.components.v1.QueryStateRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.QueryStateRequest.MetadataEntry")
+	proto.RegisterType((*QueryStateItem)(nil), "dapr.proto.components.v1.QueryStateItem")
+	proto.RegisterType((*QueryStateResponse)(nil), "dapr.proto.components.v1.QueryStateResponse")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/components/v1/state.proto", fileDescriptor_2179950bcc2e9039)
+}
+
+var fileDescriptor_2179950bcc2e9039 = []byte{
+	// 671 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5f, 0x4f, 0xd4, 0x40,
+	0x10, 0x4f, 0xaf, 0x77, 0x20, 0x03, 0x0a, 0x2e, 0x84, 0x5c, 0xce, 0x98, 0x90, 0x8b, 0x24, 0xa0,
+	0xd8, 0x13, 0x4c, 0x14, 0xf1, 0xc1, 0xe4, 0x22, 0x41, 0x4c, 0x34, 0xd8, 0x2a, 0x0f, 0x86, 0xc4,
+	0xf4, 0x8e, 0xa1, 0x36, 0xfd, 0xb3, 0xa5, 0xdd, 0x12, 0xfb, 0x39, 0xfc, 0x08, 0x3e, 0xfb, 0xe0,
+	0x07, 0xf1, 0xd9, 0x17, 0x3f, 0x8c, 0xd9, 0x6d, 0xcb, 0xf5, 0xee, 0xba, 0x5c, 0x4f, 0xf1, 0xa5,
+	0xd9, 0x9d, 0x9d, 0xf9, 0xcd, 0x9f, 0xdf, 0xcc, 0x6e, 0xe1, 0xde, 0xa9, 0x19, 0x84, 0x9d, 0x20,
+	0xa4, 0x8c, 0x76, 0xfa, 0xd4, 0x0b, 0xa8, 0x8f, 0x3e, 0x8b, 0x3a, 0x17, 0xdb, 0x9d, 0x88, 0x99,
+	0x0c, 0x35, 0x71, 0x42, 0x9a, 0x5c, 0x2b, 0x5d, 0x6b, 0x03, 0x2d, 0xed, 0x62, 0xbb, 0x75, 0xc7,
+	0xa2, 0xd4, 0x72, 0x31, 0x45, 0xe8, 0xc5, 0x67, 0x1d, 0xf4, 0x02, 0x96, 0xa4, 0xaa, 0xad, 0x75,
+	0x29, 0x78, 0x9f, 0x7a, 0x1e, 0xf5, 0x53, 0xb5, 0xf6, 0x2f, 0x05, 0x16, 0x0f, 0x90, 0x19, 0xdc,
+	0xa1, 0x8e, 0xe7, 0x31, 0x46, 0x8c, 0x2c, 0x81, 0xea, 0x60, 0xd2, 0x54, 0xd6, 0x94, 0x8d, 0x39,
+	0x9d, 0x2f, 0x89, 0x01, 0x37, 0x3c, 0x64, 0xe6, 0xa9, 0xc9, 0xcc, 0x66, 0x6d, 0x4d, 0xdd, 0x98,
+	0xdf, 0x79, 0xaa, 0xc9, 0xc2, 0xd2, 0x46, 0xe0, 0xb4, 0x37, 0x99, 0xe5, 0xbe, 0xcf, 0xc2, 0x44,
+	0xbf, 0x04, 0x22, 0x6b, 0x30, 0xdf, 0xa7, 0x7e, 0x64, 0x47, 0x0c, 0xfd, 0x7e, 0xd2, 0x54, 0x85,
+	0xbb, 0xa2, 0xa8, 0xf5, 0x1c, 0x6e, 0x0e, 0x19, 0x97, 0x44, 0xb6, 0x02, 0x8d, 0x0b, 0xd3, 0x8d,
+	0xb1, 0x59, 0x13, 0xb2, 0x74, 0xb3, 0x57, 0xdb, 0x55, 0xda, 0x3f, 0x15, 0x58, 0x1a, 0x84, 0x12,
+	0x05, 0xd4, 0x8f, 0x90, 0x10, 0xa8, 0x8b, 0x24, 0x38, 0xc2, 0x82, 0x2e, 0xd6, 0x5c, 0x86, 0xcc,
+	0xb4, 0x32, 0x04, 0xb1, 0x26, 0xef, 0x0b, 0x09, 0xab, 0x22, 0xe1, 0xdd, 0x2a, 0x09, 0xa7, 0x5e,
+	0x64, 0x19, 0xff, 0x5b, 0x3e, 0xdf, 0x6a, 0xb0, 0x68, 0x4c, 0x64, 0x6a, 0xc8, 0x7e, 0x21, 0xb3,
+	0xbf, 0x4c, 0x51, 0x2d, 0xa4, 0x58, 0xe4, 0xb4, 0x3e, 0x89, 0x53, 0x63, 0x2a, 0x4e, 0xfb, 0x71,
+	0x18, 0x0a, 0x4e, 0x1b, 0x97, 0x9c, 0xe6, 0xa2, 0x51, 0xd6, 0x67, 0xae, 0x99, 0xf5, 0xaf, 0x35,
+	0x20, 0x2f, 0xd1, 0x45, 0x86, 0x13, 0x0a, 0x55, 0xc6, 0xfa, 0xf1, 0x18, 0xeb, 0x7b, 0xf2, 0x92,
+	0x8c, 0x7b, 0xa9, 0x5a, 0x95, 0xfa, 0xc4, 0xaa, 0x34, 0xae, 0xb9, 0x2a, 0xc7, 0xb0, 0xdc, 0x8d,
+	0x5d, 0x67, 0xb4, 0x7d, 0x5e, 0x40, 0xc3, 0x66, 0xe8, 0x45, 0x4d, 0x45, 0x24, 0xbb, 0x59, 0x99,
+	0x7f, 0x3d, 0xb5, 0x6b, 0x9f, 0xc0, 0x2a, 0xc7, 0x2d, 0x29, 0x78, 0x77, 0x18, 0x7a, 0x6b, 0x9a,
+	0x3a, 0xe6, 0xe8, 0xbf, 0x15, 0xb8, 0xfd, 0x2e, 0xc6, 0x30, 0x19, 0x42, 0x5e, 0x81, 0xc6, 0x39,
+	0x17, 0x66, 0x33, 0x9c, 0x6e, 0xc8, 0x5d, 0x00, 0x07, 0x93, 0x4f, 0x41, 0x88, 0x67, 0xf6, 0x97,
+	0xac, 0x00, 0x73, 0x0e, 0x26, 0x47, 0x42, 0x40, 0x3e, 0x8c, 0x31, 0xfb, 0x4c, 0x1e, 0xd1, 0x98,
+	0xcf, 0xff, 0x33, 0xd0, 0xaf, 0xe1, 0xd6, 0xc0, 0xd3, 0x21, 0x43, 0xaf, 0xbc, 0x4b, 0xb3, 0x4b,
+	0x77, 0xfc, 0xbe, 0x2a, 0x0c, 0x73, 0xdb, 0x07, 0x52, 0x8c, 0x3a, 0xbb, 0xed, 0xba, 0x30, 0x1b,
+	0x62, 0x14, 0xbb, 0x2c, 0xa7, 0x61, 0xa3, 0x4a, 0xd2, 0x3c, 0x14, 0x3d, 0x37, 0xe4, 0xf1, 0x33,
+	0xea, 0xa0, 0x9f, 0xc7, 0x2f, 0x36, 0x3b, 0xdf, 0xeb, 0x00, 0x42, 0xd9, 0x60, 0x34, 0x44, 0xb2,
+	0x0f, 0xf5, 0x43, 0xdf, 0x66, 0x64, 0x5d, 0x8e, 0xcf, 0xcf, 0xb3, 0x72, 0xb6, 0x56, 0xb5, 0xf4,
+	0xe5, 0xd2, 0xf2, 0x97, 0x4b, 0xdb, 0xe7, 0x2f, 0x17, 0x79, 0x02, 0xf5, 0x23, 0xdb, 0xb7, 0x88,
+	0xe4, 0x5c, 0x6a, 0x77, 0x02, 0xea, 0x01, 0x32, 0xb2, 0x59, 0xf9, 0x4d, 0x6a, 0xdd, 0xaf, 0x7e,
+	0x9b, 0x93, 0x57, 0xa0, 0x1a, 0x57, 0xa3, 0x8f, 0x4c, 0x87, 0x34, 0xce, 0xb7, 0x30, 0x93, 0x76,
+	0x3b, 0x99, 0x6a, 0x1e, 0xa4, 0x78, 0x47, 0x30, 0x9b, 0x8d, 0x35, 0x79, 0x28, 0x07, 0x2c, 0x99,
+	0x7c, 0x29, 0xe2, 0x31, 0xc0, 0x60, 0xa0, 0xc9, 0xa3, 0xab, 0x41, 0xab, 0x47, 0xba, 0xf3, 0x43,
+	0x81, 0x65, 0xde, 0x61, 0xb6, 0xd9, 0x73, 0xb1, 0xd0, 0x38, 0x7f, 0xcb, 0x78, 0x0f, 0x1a, 0xa2,
+	0x61, 0xc9, 0x83, 0x29, 0xc6, 0xb8, 0xb5, 0x55, 0x4d, 0x39, 0xe5, 0xbd, 0xab, 0x7d, 0xdc, 0xb2,
+	0x6c, 0xf6, 0x39, 0xee, 0x71, 0xd5, 0x8e, 0xf8, 0x9d, 0x12, 0x9f, 0xc0, 0xb1, 0xca, 0xfe, 0xab,
+	0x7a, 0x33, 0x42, 0xf8, 0xf8, 0xcf, 0x00, 0x7f, 0x80, 0x61, 0x14, 0xd7, 0x09, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// StateStoreClient is the client API for StateStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StateStoreClient interface {
+	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	Get(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error)
+	Set(ctx context.Context, in *SetStateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Delete(ctx context.Context, in *DeleteStateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	BulkSet(ctx context.Context, in *BulkSetStateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	BulkDelete(ctx context.Context, in *BulkDeleteStateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type stateStoreClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStateStoreClient(cc *grpc.ClientConn) StateStoreClient {
+	return &stateStoreClient{cc}
+}
+
+func (c *stateStoreClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Get(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error) {
+	out := new(GetStateResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Set(ctx context.Context, in *SetStateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Set", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Delete(ctx context.Context, in *DeleteStateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) BulkSet(ctx context.Context, in *BulkSetStateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/BulkSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) BulkDelete(ctx context.Context, in *BulkDeleteStateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/BulkDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StateStoreServer is the server API for StateStore service.
+type StateStoreServer interface {
+	Init(context.Context, *InitRequest) (*empty.Empty, error)
+	Ping(context.Context, *empty.Empty) (*empty.Empty, error)
+	Get(context.Context, *GetStateRequest) (*GetStateResponse, error)
+	Set(context.Context, *SetStateRequest) (*empty.Empty, error)
+	Delete(context.Context, *DeleteStateRequest) (*empty.Empty, error)
+	BulkSet(context.Context, *BulkSetStateRequest) (*empty.Empty, error)
+	BulkDelete(context.Context, *BulkDeleteStateRequest) (*empty.Empty, error)
+}
+
+// UnimplementedStateStoreServer can be embedded to have forward compatible implementations.
+type UnimplementedStateStoreServer struct {
+}
+
+func (*UnimplementedStateStoreServer) Init(ctx context.Context, req *InitRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (*UnimplementedStateStoreServer) Ping(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedStateStoreServer) Get(ctx context.Context, req *GetStateRequest) (*GetStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedStateStoreServer) Set(ctx context.Context, req *SetStateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (*UnimplementedStateStoreServer) Delete(ctx context.Context, req *DeleteStateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedStateStoreServer) BulkSet(ctx context.Context, req *BulkSetStateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkSet not implemented")
+}
+func (*UnimplementedStateStoreServer) BulkDelete(ctx context.Context, req *BulkDeleteStateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDelete not implemented")
+}
+
+func RegisterStateStoreServer(s *grpc.Server, srv StateStoreServer) {
+	s.RegisterService(&_StateStore_serviceDesc, srv)
+}
+
+func _StateStore_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Init(ctx, req.(*InitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Ping(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Get(ctx, req.(*GetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Set",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Set(ctx, req.(*SetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Delete(ctx, req.(*DeleteStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_BulkSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkSetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).BulkSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/BulkSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).BulkSet(ctx, req.(*BulkSetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_BulkDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).BulkDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/BulkDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).BulkDelete(ctx, req.(*BulkDeleteStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _StateStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.StateStore",
+	HandlerType: (*StateStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _StateStore_Init_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _StateStore_Ping_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _StateStore_Get_Handler,
+		},
+		{
+			MethodName: "Set",
+			Handler:    _StateStore_Set_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _StateStore_Delete_Handler,
+		},
+		{
+			MethodName: "BulkSet",
+			Handler:    _StateStore_BulkSet_Handler,
+		},
+		{
+			MethodName: "BulkDelete",
+			Handler:    _StateStore_BulkDelete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/components/v1/state.proto",
+}
+
+// QueriableStateStoreClient is the client API for QueriableStateStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type QueriableStateStoreClient interface {
+	Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	Query(ctx context.Context, in *QueryStateRequest, opts ...grpc.CallOption) (*QueryStateResponse, error)
+}
+
+type queriableStateStoreClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewQueriableStateStoreClient(cc *grpc.ClientConn) QueriableStateStoreClient {
+	return &queriableStateStoreClient{cc}
+}
+
+func (c *queriableStateStoreClient) Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.QueriableStateStore/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queriableStateStoreClient) Query(ctx context.Context, in *QueryStateRequest, opts ...grpc.CallOption) (*QueryStateResponse, error) {
+	out := new(QueryStateResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.QueriableStateStore/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QueriableStateStoreServer is the server API for QueriableStateStore service.
+type QueriableStateStoreServer interface {
+	Ping(context.Context, *empty.Empty) (*empty.Empty, error)
+	Query(context.Context, *QueryStateRequest) (*QueryStateResponse, error)
+}
+
+// UnimplementedQueriableStateStoreServer can be embedded to have forward compatible implementations.
+type UnimplementedQueriableStateStoreServer struct {
+}
+
+func (*UnimplementedQueriableStateStoreServer) Ping(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedQueriableStateStoreServer) Query(ctx context.Context, req *QueryStateRequest) (*QueryStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
+
+func RegisterQueriableStateStoreServer(s *grpc.Server, srv QueriableStateStoreServer) {
+	s.RegisterService(&_QueriableStateStore_serviceDesc, srv)
+}
+
+func _QueriableStateStore_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueriableStateStoreServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.QueriableStateStore/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueriableStateStoreServer).Ping(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueriableStateStore_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueriableStateStoreServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.QueriableStateStore/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueriableStateStoreServer).Query(ctx, req.(*QueryStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _QueriableStateStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.QueriableStateStore",
+	HandlerType: (*QueriableStateStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _QueriableStateStore_Ping_Handler,
+		},
+		{
+			MethodName: "Query",
+			Handler:    _QueriableStateStore_Query_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/components/v1/state.proto",
+}
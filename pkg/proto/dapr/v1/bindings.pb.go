@@ -0,0 +1,185 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/bindings.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// InvokeBindingResponseEnvelope holds the response data and metadata of an output binding operation
+type InvokeBindingResponseEnvelope struct {
+	Data                 []byte            `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *InvokeBindingResponseEnvelope) Reset()         { *m = InvokeBindingResponseEnvelope{} }
+func (m *InvokeBindingResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*InvokeBindingResponseEnvelope) ProtoMessage()    {}
+func (*InvokeBindingResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a23db5fa4f279076, []int{0}
+}
+
+func (m *InvokeBindingResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeBindingResponseEnvelope.Unmarshal(m, b)
+}
+func (m *InvokeBindingResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeBindingResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *InvokeBindingResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeBindingResponseEnvelope.Merge(m, src)
+}
+func (m *InvokeBindingResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_InvokeBindingResponseEnvelope.Size(m)
+}
+func (m *InvokeBindingResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeBindingResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeBindingResponseEnvelope proto.InternalMessageInfo
+
+func (m *InvokeBindingResponseEnvelope) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *InvokeBindingResponseEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*InvokeBindingResponseEnvelope)(nil), "dapr.proto.dapr.v1.InvokeBindingResponseEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.InvokeBindingResponseEnvelope.MetadataEntry")
+}
+
+func init() { proto.RegisterFile("dapr/proto/dapr/v1/bindings.proto", fileDescriptor_a23db5fa4f279076) }
+
+var fileDescriptor_a23db5fa4f279076 = []byte{
+	// 293 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x4c, 0x49, 0x2c, 0x28,
+	0xd2, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0xd7, 0x07, 0x33, 0xcb, 0x0c, 0xf5, 0x93, 0x32, 0xf3, 0x52,
+	0x32, 0xf3, 0xd2, 0x8b, 0xf5, 0xc0, 0xc2, 0x42, 0x42, 0x20, 0x71, 0x08, 0x5b, 0x0f, 0xcc, 0x2c,
+	0x33, 0x94, 0x92, 0xc5, 0xa2, 0x0d, 0xa1, 0x4c, 0xe9, 0x2c, 0x23, 0x97, 0xac, 0x67, 0x5e, 0x59,
+	0x7e, 0x76, 0xaa, 0x13, 0xc4, 0xac, 0xa0, 0xd4, 0xe2, 0x82, 0xfc, 0xbc, 0xe2, 0x54, 0xd7, 0xbc,
+	0xb2, 0xd4, 0x9c, 0xfc, 0x82, 0x54, 0x21, 0x21, 0x2e, 0x96, 0x94, 0xc4, 0x92, 0x44, 0x09, 0x46,
+	0x05, 0x46, 0x0d, 0x9e, 0x20, 0x30, 0x5b, 0x28, 0x9a, 0x8b, 0x23, 0x37, 0xb5, 0x24, 0x11, 0x2c,
+	0xce, 0xa4, 0xc0, 0xac, 0xc1, 0x6d, 0x64, 0xaf, 0x87, 0x69, 0xb7, 0x1e, 0x5e, 0x83, 0xf5, 0x7c,
+	0xa1, 0x26, 0xb8, 0xe6, 0x95, 0x14, 0x55, 0x06, 0xc1, 0x0d, 0x94, 0xb2, 0xe6, 0xe2, 0x45, 0x91,
+	0x12, 0x12, 0xe0, 0x62, 0xce, 0x4e, 0xad, 0x04, 0x3b, 0x80, 0x33, 0x08, 0xc4, 0x14, 0x12, 0xe1,
+	0x62, 0x2d, 0x4b, 0xcc, 0x29, 0x4d, 0x95, 0x60, 0x02, 0x8b, 0x41, 0x38, 0x56, 0x4c, 0x16, 0x8c,
+	0x46, 0x9d, 0x8c, 0x5c, 0x3c, 0x2e, 0x89, 0x05, 0x45, 0x50, 0x4b, 0x8b, 0x85, 0x2a, 0xb9, 0x24,
+	0x51, 0x9c, 0x11, 0x9e, 0x59, 0x92, 0x01, 0x73, 0x8a, 0x90, 0x26, 0x41, 0x57, 0xc3, 0x5c, 0x2b,
+	0x65, 0x48, 0xb2, 0x07, 0x9d, 0xd2, 0xb8, 0xb8, 0x32, 0xe1, 0x6a, 0x9d, 0x84, 0x90, 0x9d, 0x15,
+	0x00, 0x32, 0xa6, 0x38, 0x4a, 0x2d, 0x3d, 0xb3, 0x24, 0xa3, 0x34, 0x49, 0x2f, 0x39, 0x3f, 0x17,
+	0x12, 0x39, 0x60, 0xa2, 0x20, 0x3b, 0x1d, 0x35, 0xc2, 0x56, 0x31, 0x49, 0x83, 0x34, 0xeb, 0x39,
+	0xe7, 0x64, 0xa6, 0xe6, 0x95, 0xe8, 0x39, 0x96, 0x96, 0xe4, 0xa7, 0xa7, 0xe6, 0xe9, 0xb9, 0x17,
+	0x15, 0x24, 0xeb, 0x95, 0x19, 0x26, 0xb1, 0x81, 0x15, 0x1b, 0x03, 0x06, 0x00, 0xcc, 0xde, 0xba,
+	0x9f, 0x22, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprBindingsClient is the client API for DaprBindings service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprBindingsClient interface {
+	// Invokes an output binding and returns the response of the binding.
+	InvokeBindingWithResponse(ctx context.Context, in *InvokeBindingEnvelope, opts ...grpc.CallOption) (*InvokeBindingResponseEnvelope, error)
+}
+
+type daprBindingsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprBindingsClient(cc *grpc.ClientConn) DaprBindingsClient {
+	return &daprBindingsClient{cc}
+}
+
+func (c *daprBindingsClient) InvokeBindingWithResponse(ctx context.Context, in *InvokeBindingEnvelope, opts ...grpc.CallOption) (*InvokeBindingResponseEnvelope, error) {
+	out := new(InvokeBindingResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprBindings/InvokeBindingWithResponse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaprBindingsServer is the server API for DaprBindings service.
+type DaprBindingsServer interface {
+	// Invokes an output binding and returns the response of the binding.
+	InvokeBindingWithResponse(context.Context, *InvokeBindingEnvelope) (*InvokeBindingResponseEnvelope, error)
+}
+
+// UnimplementedDaprBindingsServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprBindingsServer struct {
+}
+
+func (*UnimplementedDaprBindingsServer) InvokeBindingWithResponse(ctx context.Context, req *InvokeBindingEnvelope) (*InvokeBindingResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeBindingWithResponse not implemented")
+}
+
+func RegisterDaprBindingsServer(s *grpc.Server, srv DaprBindingsServer) {
+	s.RegisterService(&_DaprBindings_serviceDesc, srv)
+}
+
+func _DaprBindings_InvokeBindingWithResponse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeBindingEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprBindingsServer).InvokeBindingWithResponse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprBindings/InvokeBindingWithResponse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprBindingsServer).InvokeBindingWithResponse(ctx, req.(*InvokeBindingEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DaprBindings_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprBindings",
+	HandlerType: (*DaprBindingsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InvokeBindingWithResponse",
+			Handler:    _DaprBindings_InvokeBindingWithResponse_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/dapr/v1/bindings.proto",
+}
@@ -0,0 +1,474 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/configuration.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// GetConfigurationEnvelope is the request of GetConfiguration. All the items of the store are returned
+// when no keys are given.
+type GetConfigurationEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Keys                 []string          `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetConfigurationEnvelope) Reset()         { *m = GetConfigurationEnvelope{} }
+func (m *GetConfigurationEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigurationEnvelope) ProtoMessage()    {}
+func (*GetConfigurationEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39fb4cb666ed333d, []int{0}
+}
+
+func (m *GetConfigurationEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigurationEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigurationEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigurationEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigurationEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigurationEnvelope.Merge(m, src)
+}
+func (m *GetConfigurationEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigurationEnvelope.Size(m)
+}
+func (m *GetConfigurationEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigurationEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigurationEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigurationEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *GetConfigurationEnvelope) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetConfigurationEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// ConfigurationItemEnvelope is a configuration item
+type ConfigurationItemEnvelope struct {
+	Key                  string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string            `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Version              string            `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConfigurationItemEnvelope) Reset()         { *m = ConfigurationItemEnvelope{} }
+func (m *ConfigurationItemEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ConfigurationItemEnvelope) ProtoMessage()    {}
+func (*ConfigurationItemEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39fb4cb666ed333d, []int{1}
+}
+
+func (m *ConfigurationItemEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigurationItemEnvelope.Unmarshal(m, b)
+}
+func (m *ConfigurationItemEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigurationItemEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ConfigurationItemEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigurationItemEnvelope.Merge(m, src)
+}
+func (m *ConfigurationItemEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ConfigurationItemEnvelope.Size(m)
+}
+func (m *ConfigurationItemEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigurationItemEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigurationItemEnvelope proto.InternalMessageInfo
+
+func (m *ConfigurationItemEnvelope) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ConfigurationItemEnvelope) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *ConfigurationItemEnvelope) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ConfigurationItemEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// GetConfigurationResponseEnvelope holds the configuration items of the keys which exist
+type GetConfigurationResponseEnvelope struct {
+	Items                []*ConfigurationItemEnvelope `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *GetConfigurationResponseEnvelope) Reset()         { *m = GetConfigurationResponseEnvelope{} }
+func (m *GetConfigurationResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigurationResponseEnvelope) ProtoMessage()    {}
+func (*GetConfigurationResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39fb4cb666ed333d, []int{2}
+}
+
+func (m *GetConfigurationResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigurationResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigurationResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigurationResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigurationResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigurationResponseEnvelope.Merge(m, src)
+}
+func (m *GetConfigurationResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigurationResponseEnvelope.Size(m)
+}
+func (m *GetConfigurationResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigurationResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigurationResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigurationResponseEnvelope) GetItems() []*ConfigurationItemEnvelope {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// SubscribeConfigurationEnvelope is the request of SubscribeConfiguration. The changes of all the items
+// of the store are sent over the stream when no keys are given.
+type SubscribeConfigurationEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Keys                 []string          `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SubscribeConfigurationEnvelope) Reset()         { *m = SubscribeConfigurationEnvelope{} }
+func (m *SubscribeConfigurationEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SubscribeConfigurationEnvelope) ProtoMessage()    {}
+func (*SubscribeConfigurationEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39fb4cb666ed333d, []int{3}
+}
+
+func (m *SubscribeConfigurationEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeConfigurationEnvelope.Unmarshal(m, b)
+}
+func (m *SubscribeConfigurationEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeConfigurationEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SubscribeConfigurationEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeConfigurationEnvelope.Merge(m, src)
+}
+func (m *SubscribeConfigurationEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SubscribeConfigurationEnvelope.Size(m)
+}
+func (m *SubscribeConfigurationEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeConfigurationEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeConfigurationEnvelope proto.InternalMessageInfo
+
+func (m *SubscribeConfigurationEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *SubscribeConfigurationEnvelope) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *SubscribeConfigurationEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// ConfigurationUpdateEnvelope holds the subscribed configuration items which changed
+type ConfigurationUpdateEnvelope struct {
+	Items                []*ConfigurationItemEnvelope `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ConfigurationUpdateEnvelope) Reset()         { *m = ConfigurationUpdateEnvelope{} }
+func (m *ConfigurationUpdateEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ConfigurationUpdateEnvelope) ProtoMessage()    {}
+func (*ConfigurationUpdateEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39fb4cb666ed333d, []int{4}
+}
+
+func (m *ConfigurationUpdateEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigurationUpdateEnvelope.Unmarshal(m, b)
+}
+func (m *ConfigurationUpdateEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigurationUpdateEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ConfigurationUpdateEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigurationUpdateEnvelope.Merge(m, src)
+}
+func (m *ConfigurationUpdateEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ConfigurationUpdateEnvelope.Size(m)
+}
+func (m *ConfigurationUpdateEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigurationUpdateEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigurationUpdateEnvelope proto.InternalMessageInfo
+
+func (m *ConfigurationUpdateEnvelope) GetItems() []*ConfigurationItemEnvelope {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetConfigurationEnvelope)(nil), "dapr.proto.dapr.v1.GetConfigurationEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetConfigurationEnvelope.MetadataEntry")
+	proto.RegisterType((*ConfigurationItemEnvelope)(nil), "dapr.proto.dapr.v1.ConfigurationItemEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.ConfigurationItemEnvelope.MetadataEntry")
+	proto.RegisterType((*GetConfigurationResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetConfigurationResponseEnvelope")
+	proto.RegisterType((*SubscribeConfigurationEnvelope)(nil), "dapr.proto.dapr.v1.SubscribeConfigurationEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.SubscribeConfigurationEnvelope.MetadataEntry")
+	proto.RegisterType((*ConfigurationUpdateEnvelope)(nil), "dapr.proto.dapr.v1.ConfigurationUpdateEnvelope")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/dapr/v1/configuration.proto", fileDescriptor_39fb4cb666ed333d)
+}
+
+var fileDescriptor_39fb4cb666ed333d = []byte{
+	// 441 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x4f, 0x8b, 0x13, 0x31,
+	0x14, 0x27, 0x33, 0xbb, 0x6a, 0x9f, 0x08, 0x6b, 0x10, 0x8d, 0x5d, 0x94, 0xd2, 0xc3, 0xd2, 0x83,
+	0xa6, 0xb6, 0x7a, 0x90, 0xdd, 0x8b, 0x6e, 0x5d, 0x16, 0x0f, 0x8a, 0x8c, 0xa8, 0x20, 0x82, 0x64,
+	0xda, 0xe7, 0x18, 0xda, 0x49, 0x42, 0x92, 0x19, 0xe8, 0xc9, 0x2f, 0xe0, 0x27, 0xf1, 0x63, 0xf9,
+	0x01, 0xbc, 0xf8, 0x05, 0xa4, 0xe9, 0x3a, 0xec, 0xec, 0x74, 0x75, 0x0b, 0xdb, 0xcb, 0xf0, 0x92,
+	0xf7, 0xf2, 0x7e, 0x7f, 0xf2, 0x26, 0xb0, 0x37, 0x11, 0xc6, 0xf6, 0x8d, 0xd5, 0x5e, 0xf7, 0x43,
+	0x58, 0x0e, 0xfa, 0x63, 0xad, 0xbe, 0xc8, 0xac, 0xb0, 0xc2, 0x4b, 0xad, 0x78, 0xc8, 0x51, 0xba,
+	0x48, 0x2e, 0x63, 0x1e, 0xc2, 0x72, 0xd0, 0xfd, 0x49, 0x80, 0x1d, 0xa3, 0x1f, 0x9d, 0x2e, 0x3f,
+	0x52, 0x25, 0xce, 0xb4, 0x41, 0x7a, 0x0f, 0xc0, 0x79, 0x6d, 0xf1, 0xb3, 0x12, 0x39, 0x32, 0xd2,
+	0x21, 0xbd, 0x56, 0xd2, 0x0a, 0x3b, 0xaf, 0x45, 0x8e, 0x94, 0xc2, 0xd6, 0x14, 0xe7, 0x8e, 0x45,
+	0x9d, 0xb8, 0xd7, 0x4a, 0x42, 0x4c, 0xdf, 0xc3, 0xb5, 0x1c, 0xbd, 0x98, 0x08, 0x2f, 0x58, 0xdc,
+	0x89, 0x7b, 0xd7, 0x87, 0xfb, 0xbc, 0x09, 0xcb, 0xcf, 0x83, 0xe4, 0xaf, 0x4e, 0x0e, 0x1f, 0x29,
+	0x6f, 0xe7, 0x49, 0xd5, 0xab, 0x7d, 0x00, 0x37, 0x6a, 0x29, 0xba, 0x03, 0xf1, 0x14, 0xe7, 0x27,
+	0xa4, 0x16, 0x21, 0xbd, 0x05, 0xdb, 0xa5, 0x98, 0x15, 0xc8, 0xa2, 0xb0, 0xb7, 0x5c, 0xec, 0x47,
+	0x4f, 0x49, 0xf7, 0x37, 0x81, 0xbb, 0x35, 0xb8, 0x97, 0x1e, 0xf3, 0x4a, 0xe5, 0x05, 0x3b, 0x51,
+	0x06, 0x57, 0x4b, 0xb4, 0x4e, 0x6a, 0xc5, 0xe2, 0xb0, 0xff, 0x77, 0x49, 0x3f, 0x9c, 0x12, 0xbd,
+	0x15, 0x44, 0x1f, 0xac, 0x12, 0x7d, 0x2e, 0x85, 0xcd, 0xa8, 0xce, 0xa0, 0x73, 0xd6, 0xe6, 0x04,
+	0x9d, 0xd1, 0xca, 0x61, 0xa5, 0x7d, 0x04, 0xdb, 0xd2, 0x63, 0xee, 0x18, 0x09, 0xb4, 0x1f, 0xae,
+	0x45, 0x3b, 0x59, 0x9e, 0xed, 0xfe, 0x22, 0x70, 0xff, 0x6d, 0x91, 0xba, 0xb1, 0x95, 0x29, 0x5e,
+	0xda, 0x24, 0x7d, 0x6a, 0x4c, 0xd2, 0xb3, 0x55, 0xec, 0xfe, 0x0d, 0xbc, 0x19, 0x67, 0x53, 0xd8,
+	0xad, 0xa1, 0xbd, 0x33, 0x13, 0xe1, 0x2f, 0xd7, 0xd4, 0xe1, 0xf7, 0x08, 0x6e, 0xbe, 0x10, 0xc6,
+	0xd6, 0x0a, 0x69, 0x09, 0x3b, 0x67, 0xef, 0x94, 0x3e, 0x58, 0xe7, 0x07, 0x6b, 0x3f, 0xb9, 0x48,
+	0x75, 0x63, 0x4e, 0xbe, 0xc1, 0xed, 0xd5, 0x46, 0xd3, 0xe1, 0xfa, 0x97, 0xd2, 0xee, 0xff, 0xd7,
+	0x91, 0xba, 0xa3, 0x8f, 0xc8, 0xe1, 0x14, 0x40, 0x56, 0xb5, 0x87, 0x77, 0x1a, 0xce, 0xbc, 0x59,
+	0xf4, 0x72, 0x1f, 0xf7, 0x32, 0xe9, 0xbf, 0x16, 0x29, 0x1f, 0xeb, 0x7c, 0xf9, 0x14, 0x86, 0x8f,
+	0x99, 0x66, 0xf5, 0xe7, 0xf1, 0x47, 0xb4, 0xbb, 0xe8, 0xc0, 0x47, 0x33, 0x89, 0xca, 0xf3, 0xe7,
+	0x85, 0xd7, 0x19, 0x2a, 0x7e, 0x6c, 0xcd, 0x98, 0x97, 0x83, 0xf4, 0x4a, 0x28, 0x7e, 0xfc, 0x67,
+	0x00, 0xe5, 0x6c, 0x90, 0xf5, 0x59, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprConfigurationClient is the client API for DaprConfiguration service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprConfigurationClient interface {
+	GetConfiguration(ctx context.Context, in *GetConfigurationEnvelope, opts ...grpc.CallOption) (*GetConfigurationResponseEnvelope, error)
+	SubscribeConfiguration(ctx context.Context, in *SubscribeConfigurationEnvelope, opts ...grpc.CallOption) (DaprConfiguration_SubscribeConfigurationClient, error)
+}
+
+type daprConfigurationClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprConfigurationClient(cc *grpc.ClientConn) DaprConfigurationClient {
+	return &daprConfigurationClient{cc}
+}
+
+func (c *daprConfigurationClient) GetConfiguration(ctx context.Context, in *GetConfigurationEnvelope, opts ...grpc.CallOption) (*GetConfigurationResponseEnvelope, error) {
+	out := new(GetConfigurationResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprConfiguration/GetConfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprConfigurationClient) SubscribeConfiguration(ctx context.Context, in *SubscribeConfigurationEnvelope, opts ...grpc.CallOption) (DaprConfiguration_SubscribeConfigurationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DaprConfiguration_serviceDesc.Streams[0], "/dapr.proto.dapr.v1.DaprConfiguration/SubscribeConfiguration", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprConfigurationSubscribeConfigurationClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DaprConfiguration_SubscribeConfigurationClient interface {
+	Recv() (*ConfigurationUpdateEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprConfigurationSubscribeConfigurationClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprConfigurationSubscribeConfigurationClient) Recv() (*ConfigurationUpdateEnvelope, error) {
+	m := new(ConfigurationUpdateEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DaprConfigurationServer is the server API for DaprConfiguration service.
+type DaprConfigurationServer interface {
+	GetConfiguration(context.Context, *GetConfigurationEnvelope) (*GetConfigurationResponseEnvelope, error)
+	SubscribeConfiguration(*SubscribeConfigurationEnvelope, DaprConfiguration_SubscribeConfigurationServer) error
+}
+
+// UnimplementedDaprConfigurationServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprConfigurationServer struct {
+}
+
+func (*UnimplementedDaprConfigurationServer) GetConfiguration(ctx context.Context, req *GetConfigurationEnvelope) (*GetConfigurationResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfiguration not implemented")
+}
+func (*UnimplementedDaprConfigurationServer) SubscribeConfiguration(req *SubscribeConfigurationEnvelope, srv DaprConfiguration_SubscribeConfigurationServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeConfiguration not implemented")
+}
+
+func RegisterDaprConfigurationServer(s *grpc.Server, srv DaprConfigurationServer) {
+	s.RegisterService(&_DaprConfiguration_serviceDesc, srv)
+}
+
+func _DaprConfiguration_GetConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigurationEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprConfigurationServer).GetConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprConfiguration/GetConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprConfigurationServer).GetConfiguration(ctx, req.(*GetConfigurationEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprConfiguration_SubscribeConfiguration_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeConfigurationEnvelope)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaprConfigurationServer).SubscribeConfiguration(m, &daprConfigurationSubscribeConfigurationServer{stream})
+}
+
+type DaprConfiguration_SubscribeConfigurationServer interface {
+	Send(*ConfigurationUpdateEnvelope) error
+	grpc.ServerStream
+}
+
+type daprConfigurationSubscribeConfigurationServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprConfigurationSubscribeConfigurationServer) Send(m *ConfigurationUpdateEnvelope) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _DaprConfiguration_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprConfiguration",
+	HandlerType: (*DaprConfigurationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetConfiguration",
+			Handler:    _DaprConfiguration_GetConfiguration_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeConfiguration",
+			Handler:       _DaprConfiguration_SubscribeConfiguration_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/dapr/v1/configuration.proto",
+}
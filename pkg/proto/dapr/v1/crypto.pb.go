@@ -0,0 +1,399 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/crypto.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// EncryptRequestEnvelope is a chunk of the payload of an Encrypt stream. The vault name, key name and
+// algorithm are only read from the first message of the stream.
+type EncryptRequestEnvelope struct {
+	VaultName            string   `protobuf:"bytes,1,opt,name=vault_name,json=vaultName,proto3" json:"vault_name,omitempty"`
+	KeyName              string   `protobuf:"bytes,2,opt,name=key_name,json=keyName,proto3" json:"key_name,omitempty"`
+	Algorithm            string   `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncryptRequestEnvelope) Reset()         { *m = EncryptRequestEnvelope{} }
+func (m *EncryptRequestEnvelope) String() string { return proto.CompactTextString(m) }
+func (*EncryptRequestEnvelope) ProtoMessage()    {}
+func (*EncryptRequestEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86d6449d863b88e4, []int{0}
+}
+
+func (m *EncryptRequestEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptRequestEnvelope.Unmarshal(m, b)
+}
+func (m *EncryptRequestEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptRequestEnvelope.Marshal(b, m, deterministic)
+}
+func (m *EncryptRequestEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptRequestEnvelope.Merge(m, src)
+}
+func (m *EncryptRequestEnvelope) XXX_Size() int {
+	return xxx_messageInfo_EncryptRequestEnvelope.Size(m)
+}
+func (m *EncryptRequestEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptRequestEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptRequestEnvelope proto.InternalMessageInfo
+
+func (m *EncryptRequestEnvelope) GetVaultName() string {
+	if m != nil {
+		return m.VaultName
+	}
+	return ""
+}
+
+func (m *EncryptRequestEnvelope) GetKeyName() string {
+	if m != nil {
+		return m.KeyName
+	}
+	return ""
+}
+
+func (m *EncryptRequestEnvelope) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *EncryptRequestEnvelope) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// DecryptRequestEnvelope is a chunk of the payload of a Decrypt stream. The vault name and key name are
+// only read from the first message of the stream, and the key name is optional.
+type DecryptRequestEnvelope struct {
+	VaultName            string   `protobuf:"bytes,1,opt,name=vault_name,json=vaultName,proto3" json:"vault_name,omitempty"`
+	KeyName              string   `protobuf:"bytes,2,opt,name=key_name,json=keyName,proto3" json:"key_name,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DecryptRequestEnvelope) Reset()         { *m = DecryptRequestEnvelope{} }
+func (m *DecryptRequestEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DecryptRequestEnvelope) ProtoMessage()    {}
+func (*DecryptRequestEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86d6449d863b88e4, []int{1}
+}
+
+func (m *DecryptRequestEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecryptRequestEnvelope.Unmarshal(m, b)
+}
+func (m *DecryptRequestEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecryptRequestEnvelope.Marshal(b, m, deterministic)
+}
+func (m *DecryptRequestEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecryptRequestEnvelope.Merge(m, src)
+}
+func (m *DecryptRequestEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DecryptRequestEnvelope.Size(m)
+}
+func (m *DecryptRequestEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecryptRequestEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecryptRequestEnvelope proto.InternalMessageInfo
+
+func (m *DecryptRequestEnvelope) GetVaultName() string {
+	if m != nil {
+		return m.VaultName
+	}
+	return ""
+}
+
+func (m *DecryptRequestEnvelope) GetKeyName() string {
+	if m != nil {
+		return m.KeyName
+	}
+	return ""
+}
+
+func (m *DecryptRequestEnvelope) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// CryptoResponseEnvelope is a chunk of the encrypted or decrypted payload
+type CryptoResponseEnvelope struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CryptoResponseEnvelope) Reset()         { *m = CryptoResponseEnvelope{} }
+func (m *CryptoResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*CryptoResponseEnvelope) ProtoMessage()    {}
+func (*CryptoResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86d6449d863b88e4, []int{2}
+}
+
+func (m *CryptoResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CryptoResponseEnvelope.Unmarshal(m, b)
+}
+func (m *CryptoResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CryptoResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *CryptoResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CryptoResponseEnvelope.Merge(m, src)
+}
+func (m *CryptoResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_CryptoResponseEnvelope.Size(m)
+}
+func (m *CryptoResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_CryptoResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CryptoResponseEnvelope proto.InternalMessageInfo
+
+func (m *CryptoResponseEnvelope) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*EncryptRequestEnvelope)(nil), "dapr.proto.dapr.v1.EncryptRequestEnvelope")
+	proto.RegisterType((*DecryptRequestEnvelope)(nil), "dapr.proto.dapr.v1.DecryptRequestEnvelope")
+	proto.RegisterType((*CryptoResponseEnvelope)(nil), "dapr.proto.dapr.v1.CryptoResponseEnvelope")
+}
+
+func init() { proto.RegisterFile("dapr/proto/dapr/v1/crypto.proto", fileDescriptor_86d6449d863b88e4) }
+
+var fileDescriptor_86d6449d863b88e4 = []byte{
+	// 308 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x52, 0xcd, 0x4a, 0xf3, 0x40,
+	0x14, 0x65, 0xda, 0xf2, 0xf5, 0xeb, 0xc5, 0x85, 0xcc, 0xa2, 0xd4, 0x3f, 0x2c, 0x5d, 0x48, 0x11,
+	0x99, 0x18, 0x7d, 0x02, 0xdb, 0x14, 0x77, 0x22, 0x59, 0xba, 0x91, 0x69, 0x72, 0x4d, 0x43, 0x92,
+	0x99, 0x71, 0x32, 0x09, 0x64, 0xef, 0xd3, 0xf8, 0x48, 0x3e, 0x8d, 0x64, 0x62, 0x53, 0xc5, 0xe0,
+	0xaa, 0x9b, 0x70, 0x38, 0xf7, 0x9c, 0x9c, 0x3b, 0x87, 0x0b, 0xe7, 0x21, 0x57, 0xda, 0x51, 0x5a,
+	0x1a, 0xe9, 0x58, 0x58, 0xba, 0x4e, 0xa0, 0x2b, 0x65, 0x24, 0xb3, 0x24, 0xa5, 0x35, 0xdb, 0x60,
+	0x66, 0x61, 0xe9, 0xce, 0xde, 0x08, 0x8c, 0x57, 0xc2, 0xca, 0x7c, 0x7c, 0x2d, 0x30, 0x37, 0x2b,
+	0x51, 0x62, 0x2a, 0x15, 0xd2, 0x33, 0x80, 0x92, 0x17, 0xa9, 0x79, 0x16, 0x3c, 0xc3, 0x09, 0x99,
+	0x92, 0xf9, 0xc8, 0x1f, 0x59, 0xe6, 0x81, 0x67, 0x48, 0x8f, 0xe0, 0x7f, 0x82, 0x55, 0x33, 0xec,
+	0xd9, 0xe1, 0x30, 0xc1, 0xca, 0x8e, 0x4e, 0x61, 0xc4, 0xd3, 0x48, 0xea, 0xd8, 0x6c, 0xb2, 0x49,
+	0xbf, 0x31, 0xb6, 0x04, 0xa5, 0x30, 0x08, 0xb9, 0xe1, 0x93, 0xc1, 0x94, 0xcc, 0x0f, 0x7c, 0x8b,
+	0x67, 0x2f, 0x30, 0xf6, 0x70, 0xcf, 0x5b, 0x6c, 0x73, 0xfa, 0xdf, 0x72, 0xae, 0x60, 0xbc, 0xb4,
+	0x95, 0xf8, 0x98, 0x2b, 0x29, 0x72, 0x6c, 0x73, 0xb6, 0x6a, 0xb2, 0x53, 0xdf, 0x7c, 0x10, 0x00,
+	0x8f, 0x2b, 0xdd, 0x58, 0x28, 0xc2, 0xf0, 0xab, 0x2a, 0x7a, 0xc9, 0x7e, 0x77, 0xc9, 0xba, 0x7b,
+	0x3c, 0xee, 0xd4, 0x76, 0x6f, 0x31, 0x27, 0xd7, 0xa4, 0x8e, 0xf1, 0xf0, 0x8f, 0x18, 0x0f, 0xf7,
+	0x11, 0xb3, 0x08, 0x01, 0xe2, 0x56, 0xb8, 0x38, 0xdc, 0xbd, 0xf3, 0xb1, 0xfe, 0x43, 0xfe, 0x74,
+	0x11, 0xc5, 0x66, 0x53, 0xac, 0x59, 0x20, 0xb3, 0xe6, 0x9c, 0xec, 0x47, 0x25, 0xd1, 0xcf, 0x13,
+	0x7b, 0xef, 0x9d, 0xd4, 0x56, 0xb6, 0x4c, 0x63, 0x14, 0x86, 0xdd, 0x15, 0x46, 0x46, 0x28, 0xd8,
+	0xbd, 0x56, 0x01, 0x2b, 0xdd, 0xf5, 0x3f, 0x2b, 0xbe, 0xfd, 0x1c, 0x00, 0xc6, 0x98, 0x09, 0x0c,
+	0x9d, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprCryptoClient is the client API for DaprCrypto service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprCryptoClient interface {
+	Encrypt(ctx context.Context, opts ...grpc.CallOption) (DaprCrypto_EncryptClient, error)
+	Decrypt(ctx context.Context, opts ...grpc.CallOption) (DaprCrypto_DecryptClient, error)
+}
+
+type daprCryptoClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprCryptoClient(cc *grpc.ClientConn) DaprCryptoClient {
+	return &daprCryptoClient{cc}
+}
+
+func (c *daprCryptoClient) Encrypt(ctx context.Context, opts ...grpc.CallOption) (DaprCrypto_EncryptClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DaprCrypto_serviceDesc.Streams[0], "/dapr.proto.dapr.v1.DaprCrypto/Encrypt", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprCryptoEncryptClient{stream}
+	return x, nil
+}
+
+type DaprCrypto_EncryptClient interface {
+	Send(*EncryptRequestEnvelope) error
+	Recv() (*CryptoResponseEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprCryptoEncryptClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprCryptoEncryptClient) Send(m *EncryptRequestEnvelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *daprCryptoEncryptClient) Recv() (*CryptoResponseEnvelope, error) {
+	m := new(CryptoResponseEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daprCryptoClient) Decrypt(ctx context.Context, opts ...grpc.CallOption) (DaprCrypto_DecryptClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DaprCrypto_serviceDesc.Streams[1], "/dapr.proto.dapr.v1.DaprCrypto/Decrypt", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprCryptoDecryptClient{stream}
+	return x, nil
+}
+
+type DaprCrypto_DecryptClient interface {
+	Send(*DecryptRequestEnvelope) error
+	Recv() (*CryptoResponseEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprCryptoDecryptClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprCryptoDecryptClient) Send(m *DecryptRequestEnvelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *daprCryptoDecryptClient) Recv() (*CryptoResponseEnvelope, error) {
+	m := new(CryptoResponseEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DaprCryptoServer is the server API for DaprCrypto service.
+type DaprCryptoServer interface {
+	Encrypt(DaprCrypto_EncryptServer) error
+	Decrypt(DaprCrypto_DecryptServer) error
+}
+
+// UnimplementedDaprCryptoServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprCryptoServer struct {
+}
+
+func (*UnimplementedDaprCryptoServer) Encrypt(srv DaprCrypto_EncryptServer) error {
+	return status.Errorf(codes.Unimplemented, "method Encrypt not implemented")
+}
+func (*UnimplementedDaprCryptoServer) Decrypt(srv DaprCrypto_DecryptServer) error {
+	return status.Errorf(codes.Unimplemented, "method Decrypt not implemented")
+}
+
+func RegisterDaprCryptoServer(s *grpc.Server, srv DaprCryptoServer) {
+	s.RegisterService(&_DaprCrypto_serviceDesc, srv)
+}
+
+func _DaprCrypto_Encrypt_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DaprCryptoServer).Encrypt(&daprCryptoEncryptServer{stream})
+}
+
+type DaprCrypto_EncryptServer interface {
+	Send(*CryptoResponseEnvelope) error
+	Recv() (*EncryptRequestEnvelope, error)
+	grpc.ServerStream
+}
+
+type daprCryptoEncryptServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprCryptoEncryptServer) Send(m *CryptoResponseEnvelope) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *daprCryptoEncryptServer) Recv() (*EncryptRequestEnvelope, error) {
+	m := new(EncryptRequestEnvelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _DaprCrypto_Decrypt_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DaprCryptoServer).Decrypt(&daprCryptoDecryptServer{stream})
+}
+
+type DaprCrypto_DecryptServer interface {
+	Send(*CryptoResponseEnvelope) error
+	Recv() (*DecryptRequestEnvelope, error)
+	grpc.ServerStream
+}
+
+type daprCryptoDecryptServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprCryptoDecryptServer) Send(m *CryptoResponseEnvelope) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *daprCryptoDecryptServer) Recv() (*DecryptRequestEnvelope, error) {
+	m := new(DecryptRequestEnvelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _DaprCrypto_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprCrypto",
+	HandlerType: (*DaprCryptoServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Encrypt",
+			Handler:       _DaprCrypto_Encrypt_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Decrypt",
+			Handler:       _DaprCrypto_Decrypt_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/dapr/v1/crypto.proto",
+}
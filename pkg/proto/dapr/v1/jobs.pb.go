@@ -0,0 +1,366 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/jobs.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// JobEnvelope is a job of the app. The next run is ignored when the job is scheduled.
+type JobEnvelope struct {
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Schedule string `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	DueTime  string `protobuf:"bytes,3,opt,name=due_time,json=dueTime,proto3" json:"due_time,omitempty"`
+	Data     []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// next_run is an RFC3339 time
+	NextRun              string   `protobuf:"bytes,5,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobEnvelope) Reset()         { *m = JobEnvelope{} }
+func (m *JobEnvelope) String() string { return proto.CompactTextString(m) }
+func (*JobEnvelope) ProtoMessage()    {}
+func (*JobEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1bb4c6ef45323469, []int{0}
+}
+
+func (m *JobEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobEnvelope.Unmarshal(m, b)
+}
+func (m *JobEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobEnvelope.Marshal(b, m, deterministic)
+}
+func (m *JobEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobEnvelope.Merge(m, src)
+}
+func (m *JobEnvelope) XXX_Size() int {
+	return xxx_messageInfo_JobEnvelope.Size(m)
+}
+func (m *JobEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobEnvelope proto.InternalMessageInfo
+
+func (m *JobEnvelope) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *JobEnvelope) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
+
+func (m *JobEnvelope) GetDueTime() string {
+	if m != nil {
+		return m.DueTime
+	}
+	return ""
+}
+
+func (m *JobEnvelope) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *JobEnvelope) GetNextRun() string {
+	if m != nil {
+		return m.NextRun
+	}
+	return ""
+}
+
+// DeleteJobEnvelope is the request of DeleteJob
+type DeleteJobEnvelope struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJobEnvelope) Reset()         { *m = DeleteJobEnvelope{} }
+func (m *DeleteJobEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobEnvelope) ProtoMessage()    {}
+func (*DeleteJobEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1bb4c6ef45323469, []int{1}
+}
+
+func (m *DeleteJobEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobEnvelope.Unmarshal(m, b)
+}
+func (m *DeleteJobEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobEnvelope.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobEnvelope.Merge(m, src)
+}
+func (m *DeleteJobEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobEnvelope.Size(m)
+}
+func (m *DeleteJobEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobEnvelope proto.InternalMessageInfo
+
+func (m *DeleteJobEnvelope) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// ListJobsResponseEnvelope holds the jobs of the app sorted by name
+type ListJobsResponseEnvelope struct {
+	Jobs                 []*JobEnvelope `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListJobsResponseEnvelope) Reset()         { *m = ListJobsResponseEnvelope{} }
+func (m *ListJobsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ListJobsResponseEnvelope) ProtoMessage()    {}
+func (*ListJobsResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1bb4c6ef45323469, []int{2}
+}
+
+func (m *ListJobsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsResponseEnvelope.Unmarshal(m, b)
+}
+func (m *ListJobsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ListJobsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsResponseEnvelope.Merge(m, src)
+}
+func (m *ListJobsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ListJobsResponseEnvelope.Size(m)
+}
+func (m *ListJobsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsResponseEnvelope proto.InternalMessageInfo
+
+func (m *ListJobsResponseEnvelope) GetJobs() []*JobEnvelope {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*JobEnvelope)(nil), "dapr.proto.dapr.v1.JobEnvelope")
+	proto.RegisterType((*DeleteJobEnvelope)(nil), "dapr.proto.dapr.v1.DeleteJobEnvelope")
+	proto.RegisterType((*ListJobsResponseEnvelope)(nil), "dapr.proto.dapr.v1.ListJobsResponseEnvelope")
+}
+
+func init() { proto.RegisterFile("dapr/proto/dapr/v1/jobs.proto", fileDescriptor_1bb4c6ef45323469) }
+
+var fileDescriptor_1bb4c6ef45323469 = []byte{
+	// 364 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xd1, 0x4a, 0xe3, 0x40,
+	0x18, 0x85, 0x49, 0xdb, 0xdd, 0x4d, 0xa7, 0xcb, 0xc2, 0xce, 0x85, 0xc4, 0x14, 0xb1, 0x14, 0xd4,
+	0x5e, 0xc8, 0x84, 0xb6, 0x4f, 0x60, 0x6d, 0x15, 0x82, 0x60, 0x89, 0x5e, 0x79, 0x53, 0x32, 0xcd,
+	0x6f, 0x1a, 0x4d, 0x66, 0x86, 0x64, 0x26, 0xe8, 0x13, 0xf8, 0x2e, 0x3e, 0x9d, 0x8f, 0x20, 0x33,
+	0xb1, 0x41, 0x69, 0x4b, 0x6f, 0xc2, 0x99, 0x3f, 0xdf, 0x7f, 0x92, 0x73, 0x18, 0x74, 0x14, 0x85,
+	0x22, 0xf7, 0x44, 0xce, 0x25, 0xf7, 0x8c, 0x2c, 0x87, 0xde, 0x13, 0xa7, 0x05, 0x31, 0x23, 0x8c,
+	0xf5, 0xac, 0xd2, 0xc4, 0xc8, 0x72, 0xe8, 0x76, 0x63, 0xce, 0xe3, 0x14, 0xaa, 0x25, 0xaa, 0x1e,
+	0x3d, 0xc8, 0x84, 0x7c, 0xad, 0xa0, 0xfe, 0x9b, 0x85, 0x3a, 0x3e, 0xa7, 0x33, 0x56, 0x42, 0xca,
+	0x05, 0x60, 0x8c, 0x5a, 0x2c, 0xcc, 0xc0, 0xb1, 0x7a, 0xd6, 0xa0, 0x1d, 0x18, 0x8d, 0x5d, 0x64,
+	0x17, 0xcb, 0x15, 0x44, 0x2a, 0x05, 0xa7, 0x61, 0xe6, 0xf5, 0x19, 0x1f, 0x22, 0x3b, 0x52, 0xb0,
+	0x90, 0x49, 0x06, 0x4e, 0xd3, 0xbc, 0xfb, 0x13, 0x29, 0xb8, 0x4f, 0x32, 0x63, 0x15, 0x85, 0x32,
+	0x74, 0x5a, 0x3d, 0x6b, 0xf0, 0x37, 0x30, 0x5a, 0xe3, 0x0c, 0x5e, 0xe4, 0x22, 0x57, 0xcc, 0xf9,
+	0x55, 0xe1, 0xfa, 0x1c, 0x28, 0xd6, 0x3f, 0x43, 0xff, 0xa7, 0x90, 0x82, 0x84, 0x3d, 0xbf, 0xd3,
+	0xbf, 0x45, 0xce, 0x4d, 0x52, 0x48, 0x9f, 0xd3, 0x22, 0x80, 0x42, 0x70, 0x56, 0x40, 0xcd, 0x8f,
+	0x51, 0x4b, 0xb7, 0xe1, 0x58, 0xbd, 0xe6, 0xa0, 0x33, 0x3a, 0x26, 0x9b, 0x75, 0x90, 0x6f, 0xf6,
+	0x81, 0x81, 0x47, 0x1f, 0x16, 0xb2, 0xa7, 0xa1, 0xc8, 0xb5, 0x23, 0xbe, 0x42, 0x9d, 0xbb, 0xaf,
+	0x70, 0x3e, 0xa7, 0x78, 0x9f, 0x85, 0x7b, 0x40, 0xaa, 0x7a, 0xc9, 0xba, 0x5e, 0x32, 0xd3, 0xf5,
+	0x62, 0x1f, 0xb5, 0xeb, 0x38, 0xf8, 0x64, 0x9b, 0xcb, 0x46, 0xda, 0x9d, 0x5e, 0x73, 0x64, 0xaf,
+	0x13, 0xe3, 0x1d, 0x8c, 0x7b, 0xbe, 0xed, 0x13, 0xbb, 0x7a, 0x9a, 0x50, 0x84, 0x92, 0x1a, 0x9b,
+	0xfc, 0x5b, 0xa7, 0x9f, 0xeb, 0xed, 0xe2, 0xe1, 0x34, 0x4e, 0xe4, 0x4a, 0x51, 0xb2, 0xe4, 0x59,
+	0x75, 0xc9, 0xcc, 0x43, 0x3c, 0xc7, 0x3f, 0x2f, 0xde, 0x7b, 0xa3, 0xab, 0x17, 0xc9, 0x65, 0x9a,
+	0x00, 0x93, 0xe4, 0x42, 0x49, 0x1e, 0x03, 0x23, 0xd7, 0xb9, 0x58, 0x92, 0x72, 0x48, 0x7f, 0x1b,
+	0x78, 0xfc, 0x39, 0x00, 0xfa, 0x3c, 0xd5, 0x32, 0xb3, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprJobsClient is the client API for DaprJobs service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprJobsClient interface {
+	ScheduleJob(ctx context.Context, in *JobEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteJob(ctx context.Context, in *DeleteJobEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListJobs(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ListJobsResponseEnvelope, error)
+}
+
+type daprJobsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprJobsClient(cc *grpc.ClientConn) DaprJobsClient {
+	return &daprJobsClient{cc}
+}
+
+func (c *daprJobsClient) ScheduleJob(ctx context.Context, in *JobEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprJobs/ScheduleJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprJobsClient) DeleteJob(ctx context.Context, in *DeleteJobEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprJobs/DeleteJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprJobsClient) ListJobs(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ListJobsResponseEnvelope, error) {
+	out := new(ListJobsResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprJobs/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaprJobsServer is the server API for DaprJobs service.
+type DaprJobsServer interface {
+	ScheduleJob(context.Context, *JobEnvelope) (*empty.Empty, error)
+	DeleteJob(context.Context, *DeleteJobEnvelope) (*empty.Empty, error)
+	ListJobs(context.Context, *empty.Empty) (*ListJobsResponseEnvelope, error)
+}
+
+// UnimplementedDaprJobsServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprJobsServer struct {
+}
+
+func (*UnimplementedDaprJobsServer) ScheduleJob(ctx context.Context, req *JobEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleJob not implemented")
+}
+func (*UnimplementedDaprJobsServer) DeleteJob(ctx context.Context, req *DeleteJobEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJob not implemented")
+}
+func (*UnimplementedDaprJobsServer) ListJobs(ctx context.Context, req *empty.Empty) (*ListJobsResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+
+func RegisterDaprJobsServer(s *grpc.Server, srv DaprJobsServer) {
+	s.RegisterService(&_DaprJobs_serviceDesc, srv)
+}
+
+func _DaprJobs_ScheduleJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprJobsServer).ScheduleJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprJobs/ScheduleJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprJobsServer).ScheduleJob(ctx, req.(*JobEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprJobs_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprJobsServer).DeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprJobs/DeleteJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprJobsServer).DeleteJob(ctx, req.(*DeleteJobEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprJobs_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprJobsServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprJobs/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprJobsServer).ListJobs(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DaprJobs_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprJobs",
+	HandlerType: (*DaprJobsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ScheduleJob",
+			Handler:    _DaprJobs_ScheduleJob_Handler,
+		},
+		{
+			MethodName: "DeleteJob",
+			Handler:    _DaprJobs_DeleteJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _DaprJobs_ListJobs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/dapr/v1/jobs.proto",
+}
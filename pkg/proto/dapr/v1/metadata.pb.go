@@ -0,0 +1,558 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/metadata.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ActiveActorsCount is the number of active actors of an actor type hosted by the app
+type ActiveActorsCount struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActiveActorsCount) Reset()         { *m = ActiveActorsCount{} }
+func (m *ActiveActorsCount) String() string { return proto.CompactTextString(m) }
+func (*ActiveActorsCount) ProtoMessage()    {}
+func (*ActiveActorsCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d0acbce2850be80c, []int{0}
+}
+
+func (m *ActiveActorsCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActiveActorsCount.Unmarshal(m, b)
+}
+func (m *ActiveActorsCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActiveActorsCount.Marshal(b, m, deterministic)
+}
+func (m *ActiveActorsCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActiveActorsCount.Merge(m, src)
+}
+func (m *ActiveActorsCount) XXX_Size() int {
+	return xxx_messageInfo_ActiveActorsCount.Size(m)
+}
+func (m *ActiveActorsCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActiveActorsCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActiveActorsCount proto.InternalMessageInfo
+
+func (m *ActiveActorsCount) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ActiveActorsCount) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// RegisteredComponent describes a loaded component, its status and the features it supports
+type RegisteredComponent struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Version              string   `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Capabilities         []string `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisteredComponent) Reset()         { *m = RegisteredComponent{} }
+func (m *RegisteredComponent) String() string { return proto.CompactTextString(m) }
+func (*RegisteredComponent) ProtoMessage()    {}
+func (*RegisteredComponent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d0acbce2850be80c, []int{1}
+}
+
+func (m *RegisteredComponent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisteredComponent.Unmarshal(m, b)
+}
+func (m *RegisteredComponent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisteredComponent.Marshal(b, m, deterministic)
+}
+func (m *RegisteredComponent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisteredComponent.Merge(m, src)
+}
+func (m *RegisteredComponent) XXX_Size() int {
+	return xxx_messageInfo_RegisteredComponent.Size(m)
+}
+func (m *RegisteredComponent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisteredComponent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisteredComponent proto.InternalMessageInfo
+
+func (m *RegisteredComponent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RegisteredComponent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *RegisteredComponent) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *RegisteredComponent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *RegisteredComponent) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+// PubsubSubscription describes a topic subscription of the app
+type PubsubSubscription struct {
+	PubsubName           string   `protobuf:"bytes,1,opt,name=pubsub_name,json=pubsubName,proto3" json:"pubsub_name,omitempty"`
+	Topic                string   `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Route                string   `protobuf:"bytes,3,opt,name=route,proto3" json:"route,omitempty"`
+	Paused               bool     `protobuf:"varint,4,opt,name=paused,proto3" json:"paused,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PubsubSubscription) Reset()         { *m = PubsubSubscription{} }
+func (m *PubsubSubscription) String() string { return proto.CompactTextString(m) }
+func (*PubsubSubscription) ProtoMessage()    {}
+func (*PubsubSubscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d0acbce2850be80c, []int{2}
+}
+
+func (m *PubsubSubscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PubsubSubscription.Unmarshal(m, b)
+}
+func (m *PubsubSubscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PubsubSubscription.Marshal(b, m, deterministic)
+}
+func (m *PubsubSubscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubsubSubscription.Merge(m, src)
+}
+func (m *PubsubSubscription) XXX_Size() int {
+	return xxx_messageInfo_PubsubSubscription.Size(m)
+}
+func (m *PubsubSubscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubsubSubscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubsubSubscription proto.InternalMessageInfo
+
+func (m *PubsubSubscription) GetPubsubName() string {
+	if m != nil {
+		return m.PubsubName
+	}
+	return ""
+}
+
+func (m *PubsubSubscription) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *PubsubSubscription) GetRoute() string {
+	if m != nil {
+		return m.Route
+	}
+	return ""
+}
+
+func (m *PubsubSubscription) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+// AppConnectionProperties describes how the sidecar reaches the app
+type AppConnectionProperties struct {
+	Port                 int32    `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	Protocol             string   `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppConnectionProperties) Reset()         { *m = AppConnectionProperties{} }
+func (m *AppConnectionProperties) String() string { return proto.CompactTextString(m) }
+func (*AppConnectionProperties) ProtoMessage()    {}
+func (*AppConnectionProperties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d0acbce2850be80c, []int{3}
+}
+
+func (m *AppConnectionProperties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppConnectionProperties.Unmarshal(m, b)
+}
+func (m *AppConnectionProperties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppConnectionProperties.Marshal(b, m, deterministic)
+}
+func (m *AppConnectionProperties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppConnectionProperties.Merge(m, src)
+}
+func (m *AppConnectionProperties) XXX_Size() int {
+	return xxx_messageInfo_AppConnectionProperties.Size(m)
+}
+func (m *AppConnectionProperties) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppConnectionProperties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppConnectionProperties proto.InternalMessageInfo
+
+func (m *AppConnectionProperties) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *AppConnectionProperties) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+// GetMetadataResponse is the response of GetMetadata
+type GetMetadataResponse struct {
+	Id                      string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActiveActorsCount       []*ActiveActorsCount     `protobuf:"bytes,2,rep,name=active_actors_count,json=activeActorsCount,proto3" json:"active_actors_count,omitempty"`
+	ExtendedMetadata        map[string]string        `protobuf:"bytes,3,rep,name=extended_metadata,json=extendedMetadata,proto3" json:"extended_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	RegisteredComponents    []*RegisteredComponent   `protobuf:"bytes,4,rep,name=registered_components,json=registeredComponents,proto3" json:"registered_components,omitempty"`
+	Subscriptions           []*PubsubSubscription    `protobuf:"bytes,5,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	AppConnectionProperties *AppConnectionProperties `protobuf:"bytes,6,opt,name=app_connection_properties,json=appConnectionProperties,proto3" json:"app_connection_properties,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}                 `json:"-"`
+	XXX_unrecognized        []byte                   `json:"-"`
+	XXX_sizecache           int32                    `json:"-"`
+}
+
+func (m *GetMetadataResponse) Reset()         { *m = GetMetadataResponse{} }
+func (m *GetMetadataResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMetadataResponse) ProtoMessage()    {}
+func (*GetMetadataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d0acbce2850be80c, []int{4}
+}
+
+func (m *GetMetadataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMetadataResponse.Unmarshal(m, b)
+}
+func (m *GetMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMetadataResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMetadataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMetadataResponse.Merge(m, src)
+}
+func (m *GetMetadataResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMetadataResponse.Size(m)
+}
+func (m *GetMetadataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMetadataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMetadataResponse proto.InternalMessageInfo
+
+func (m *GetMetadataResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetMetadataResponse) GetActiveActorsCount() []*ActiveActorsCount {
+	if m != nil {
+		return m.ActiveActorsCount
+	}
+	return nil
+}
+
+func (m *GetMetadataResponse) GetExtendedMetadata() map[string]string {
+	if m != nil {
+		return m.ExtendedMetadata
+	}
+	return nil
+}
+
+func (m *GetMetadataResponse) GetRegisteredComponents() []*RegisteredComponent {
+	if m != nil {
+		return m.RegisteredComponents
+	}
+	return nil
+}
+
+func (m *GetMetadataResponse) GetSubscriptions() []*PubsubSubscription {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+func (m *GetMetadataResponse) GetAppConnectionProperties() *AppConnectionProperties {
+	if m != nil {
+		return m.AppConnectionProperties
+	}
+	return nil
+}
+
+// SetMetadataRequest sets a custom attribute of the sidecar
+type SetMetadataRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetMetadataRequest) Reset()         { *m = SetMetadataRequest{} }
+func (m *SetMetadataRequest) String() string { return proto.CompactTextString(m) }
+func (*SetMetadataRequest) ProtoMessage()    {}
+func (*SetMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d0acbce2850be80c, []int{5}
+}
+
+func (m *SetMetadataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMetadataRequest.Unmarshal(m, b)
+}
+func (m *SetMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMetadataRequest.Marshal(b, m, deterministic)
+}
+func (m *SetMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMetadataRequest.Merge(m, src)
+}
+func (m *SetMetadataRequest) XXX_Size() int {
+	return xxx_messageInfo_SetMetadataRequest.Size(m)
+}
+func (m *SetMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMetadataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMetadataRequest proto.InternalMessageInfo
+
+func (m *SetMetadataRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetMetadataRequest) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ActiveActorsCount)(nil), "dapr.proto.dapr.v1.ActiveActorsCount")
+	proto.RegisterType((*RegisteredComponent)(nil), "dapr.proto.dapr.v1.RegisteredComponent")
+	proto.RegisterType((*PubsubSubscription)(nil), "dapr.proto.dapr.v1.PubsubSubscription")
+	proto.RegisterType((*AppConnectionProperties)(nil), "dapr.proto.dapr.v1.AppConnectionProperties")
+	proto.RegisterType((*GetMetadataResponse)(nil), "dapr.proto.dapr.v1.GetMetadataResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetMetadataResponse.ExtendedMetadataEntry")
+	proto.RegisterType((*SetMetadataRequest)(nil), "dapr.proto.dapr.v1.SetMetadataRequest")
+}
+
+func init() { proto.RegisterFile("dapr/proto/dapr/v1/metadata.proto", fileDescriptor_d0acbce2850be80c) }
+
+var fileDescriptor_d0acbce2850be80c = []byte{
+	// 637 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5d, 0x6b, 0xdb, 0x30,
+	0x14, 0xc5, 0x49, 0xd3, 0xb5, 0x37, 0xdd, 0x68, 0xd5, 0x2f, 0x2f, 0x7d, 0x58, 0x66, 0x58, 0x1b,
+	0x18, 0x38, 0x34, 0x7b, 0x19, 0x63, 0x7d, 0x48, 0xb3, 0x52, 0x06, 0x6b, 0x29, 0x2e, 0x7b, 0x19,
+	0x03, 0x23, 0xdb, 0xb7, 0x9e, 0xd7, 0xc4, 0xd2, 0x24, 0x39, 0x2c, 0xbf, 0x63, 0xff, 0x62, 0xb0,
+	0x3f, 0xb1, 0x5f, 0x36, 0x24, 0x7f, 0xe0, 0x34, 0x1e, 0xf4, 0xa5, 0xdc, 0x7b, 0x24, 0x9d, 0x7b,
+	0xea, 0x73, 0x72, 0xe1, 0x65, 0x44, 0xb9, 0x18, 0x72, 0xc1, 0x14, 0x1b, 0x9a, 0x72, 0x7e, 0x3a,
+	0x9c, 0xa1, 0xa2, 0x11, 0x55, 0xd4, 0x35, 0x30, 0x21, 0x1a, 0xcf, 0x6b, 0xd7, 0x94, 0xf3, 0xd3,
+	0xde, 0x51, 0xcc, 0x58, 0x3c, 0xc5, 0xfc, 0x61, 0x90, 0xdd, 0x0d, 0x71, 0xc6, 0xd5, 0x22, 0xbf,
+	0xe4, 0x9c, 0xc1, 0xce, 0x38, 0x54, 0xc9, 0x1c, 0xc7, 0xa1, 0x62, 0x42, 0x4e, 0x58, 0x96, 0x2a,
+	0x42, 0x60, 0x4d, 0x2d, 0x38, 0xda, 0x56, 0xdf, 0x1a, 0x6c, 0x7a, 0xa6, 0x26, 0x7b, 0xd0, 0x09,
+	0xf5, 0xa1, 0xdd, 0xea, 0x5b, 0x83, 0x8e, 0x97, 0x37, 0xce, 0x2f, 0x0b, 0x76, 0x3d, 0x8c, 0x13,
+	0xa9, 0x50, 0x60, 0x34, 0x61, 0x33, 0xce, 0x52, 0xcc, 0x19, 0x52, 0x3a, 0xab, 0x18, 0x74, 0x5d,
+	0xb1, 0xb6, 0x6a, 0xac, 0x36, 0x3c, 0x99, 0xa3, 0x90, 0x09, 0x4b, 0xed, 0xb6, 0x81, 0xcb, 0x96,
+	0x1c, 0xc0, 0xba, 0x54, 0x54, 0x65, 0xd2, 0x5e, 0x33, 0x07, 0x45, 0x47, 0x1c, 0xd8, 0x0a, 0x29,
+	0xa7, 0x41, 0x32, 0x4d, 0x54, 0x82, 0xd2, 0xee, 0xf4, 0xdb, 0x83, 0x4d, 0x6f, 0x09, 0x73, 0x16,
+	0x40, 0x6e, 0xb2, 0x40, 0x66, 0xc1, 0x6d, 0x16, 0xc8, 0x50, 0x24, 0x5c, 0x69, 0xc6, 0x17, 0xd0,
+	0xe5, 0x06, 0xf5, 0x6b, 0xd2, 0x20, 0x87, 0xae, 0xb5, 0xc0, 0x3d, 0xe8, 0x28, 0xc6, 0x93, 0xb0,
+	0x50, 0x98, 0x37, 0x1a, 0x15, 0x2c, 0x53, 0x58, 0x08, 0xcc, 0x1b, 0x2d, 0x8f, 0xd3, 0x4c, 0x62,
+	0x64, 0xe4, 0x6d, 0x78, 0x45, 0xe7, 0x7c, 0x84, 0xc3, 0x31, 0xe7, 0x13, 0x96, 0xa6, 0x18, 0xea,
+	0xa9, 0x37, 0x82, 0x71, 0x14, 0x5a, 0x95, 0xfe, 0xff, 0x39, 0x13, 0xca, 0x0c, 0xee, 0x78, 0xa6,
+	0x26, 0x3d, 0xd8, 0x30, 0x3e, 0x84, 0x6c, 0x5a, 0x4c, 0xad, 0x7a, 0xe7, 0xef, 0x1a, 0xec, 0x5e,
+	0xa2, 0xba, 0x2a, 0x1c, 0xf6, 0x50, 0x72, 0x96, 0x4a, 0x24, 0xcf, 0xa0, 0x95, 0x44, 0x85, 0xfc,
+	0x56, 0x12, 0x91, 0xcf, 0xb0, 0x4b, 0x8d, 0x85, 0x3e, 0x35, 0x1e, 0xfa, 0xa5, 0x4f, 0xed, 0x41,
+	0x77, 0xf4, 0xca, 0x5d, 0x4d, 0x84, 0xbb, 0xe2, 0xb8, 0xb7, 0x43, 0x1f, 0x42, 0xe4, 0x3b, 0xec,
+	0xe0, 0x4f, 0x85, 0x69, 0x84, 0x91, 0x5f, 0xa6, 0xcc, 0x6e, 0x1b, 0xd2, 0xb3, 0x26, 0xd2, 0x06,
+	0xa9, 0xee, 0x45, 0x41, 0x50, 0x1e, 0x5c, 0xa4, 0x4a, 0x2c, 0xbc, 0x6d, 0x7c, 0x00, 0x93, 0xaf,
+	0xb0, 0x2f, 0xaa, 0x14, 0xf9, 0x61, 0x19, 0x23, 0xed, 0xbd, 0x9e, 0x77, 0xd2, 0x34, 0xaf, 0x21,
+	0x76, 0xde, 0x9e, 0x58, 0x05, 0x25, 0xf9, 0x04, 0x4f, 0x65, 0x2d, 0x08, 0x79, 0x66, 0xba, 0xa3,
+	0xe3, 0x26, 0xd6, 0xd5, 0xdc, 0x78, 0xcb, 0x8f, 0x49, 0x0c, 0xcf, 0x29, 0xe7, 0x7e, 0x58, 0x59,
+	0xec, 0xf3, 0xca, 0x63, 0x7b, 0xbd, 0x6f, 0x0d, 0xba, 0xa3, 0xd7, 0x8d, 0x1f, 0xbd, 0x39, 0x16,
+	0xde, 0x21, 0x6d, 0x3e, 0xe8, 0x4d, 0x60, 0xbf, 0xf1, 0xfb, 0x91, 0x6d, 0x68, 0xdf, 0xe3, 0xa2,
+	0x48, 0x80, 0x2e, 0x75, 0x46, 0xe7, 0x74, 0x9a, 0x95, 0xbf, 0xad, 0xbc, 0x79, 0xd7, 0x7a, 0x6b,
+	0x39, 0xef, 0x81, 0xdc, 0xd6, 0x8d, 0xf9, 0x91, 0xa1, 0x54, 0x8f, 0x65, 0x18, 0xfd, 0xb1, 0x60,
+	0xeb, 0x03, 0xe5, 0xa2, 0x32, 0xea, 0x1a, 0xba, 0x35, 0x9f, 0xc9, 0x81, 0x9b, 0xef, 0x16, 0xb7,
+	0xdc, 0x2d, 0xee, 0x85, 0xde, 0x2d, 0xbd, 0x93, 0x47, 0x06, 0x84, 0x5c, 0x41, 0xb7, 0x26, 0x8f,
+	0x34, 0x5a, 0xb2, 0xaa, 0xbf, 0xf7, 0x9f, 0xb9, 0xe7, 0x77, 0x00, 0x49, 0xf5, 0xf0, 0x9c, 0xd4,
+	0xa5, 0xdf, 0xe8, 0x9b, 0xf2, 0xcb, 0x71, 0x9c, 0xa8, 0x6f, 0x59, 0xe0, 0x86, 0x6c, 0x96, 0xef,
+	0x50, 0xf3, 0x87, 0xdf, 0xc7, 0xcb, 0x7b, 0xf5, 0x77, 0xeb, 0x48, 0x3f, 0x76, 0x27, 0xd3, 0x04,
+	0x53, 0xe5, 0x8e, 0x33, 0xc5, 0x62, 0x4c, 0xdd, 0x4b, 0xc1, 0x43, 0x77, 0x7e, 0x1a, 0xac, 0x9b,
+	0xcb, 0x6f, 0xfe, 0x0d, 0x00, 0xd3, 0xce, 0x99, 0xc0, 0x92, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprMetadataClient is the client API for DaprMetadata service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprMetadataClient interface {
+	GetMetadata(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetMetadataResponse, error)
+	SetMetadata(ctx context.Context, in *SetMetadataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type daprMetadataClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprMetadataClient(cc *grpc.ClientConn) DaprMetadataClient {
+	return &daprMetadataClient{cc}
+}
+
+func (c *daprMetadataClient) GetMetadata(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetMetadataResponse, error) {
+	out := new(GetMetadataResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprMetadata/GetMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprMetadataClient) SetMetadata(ctx context.Context, in *SetMetadataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprMetadata/SetMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaprMetadataServer is the server API for DaprMetadata service.
+type DaprMetadataServer interface {
+	GetMetadata(context.Context, *empty.Empty) (*GetMetadataResponse, error)
+	SetMetadata(context.Context, *SetMetadataRequest) (*empty.Empty, error)
+}
+
+// UnimplementedDaprMetadataServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprMetadataServer struct {
+}
+
+func (*UnimplementedDaprMetadataServer) GetMetadata(ctx context.Context, req *empty.Empty) (*GetMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetadata not implemented")
+}
+func (*UnimplementedDaprMetadataServer) SetMetadata(ctx context.Context, req *SetMetadataRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMetadata not implemented")
+}
+
+func RegisterDaprMetadataServer(s *grpc.Server, srv DaprMetadataServer) {
+	s.RegisterService(&_DaprMetadata_serviceDesc, srv)
+}
+
+func _DaprMetadata_GetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprMetadataServer).GetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprMetadata/GetMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprMetadataServer).GetMetadata(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprMetadata_SetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprMetadataServer).SetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprMetadata/SetMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprMetadataServer).SetMetadata(ctx, req.(*SetMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DaprMetadata_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprMetadata",
+	HandlerType: (*DaprMetadataServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMetadata",
+			Handler:    _DaprMetadata_GetMetadata_Handler,
+		},
+		{
+			MethodName: "SetMetadata",
+			Handler:    _DaprMetadata_SetMetadata_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/dapr/v1/metadata.proto",
+}
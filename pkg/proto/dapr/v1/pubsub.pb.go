@@ -0,0 +1,356 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/pubsub.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// BulkPublishRequestEnvelope is the request of PublishBulkEvent. The pub/sub component is the one of the
+// pubsubName metadata of the call, as for PublishEvent.
+type BulkPublishRequestEnvelope struct {
+	Topic                string                     `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Entries              []*BulkPublishRequestEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *BulkPublishRequestEnvelope) Reset()         { *m = BulkPublishRequestEnvelope{} }
+func (m *BulkPublishRequestEnvelope) String() string { return proto.CompactTextString(m) }
+func (*BulkPublishRequestEnvelope) ProtoMessage()    {}
+func (*BulkPublishRequestEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7b90b188d60b001, []int{0}
+}
+
+func (m *BulkPublishRequestEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkPublishRequestEnvelope.Unmarshal(m, b)
+}
+func (m *BulkPublishRequestEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkPublishRequestEnvelope.Marshal(b, m, deterministic)
+}
+func (m *BulkPublishRequestEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkPublishRequestEnvelope.Merge(m, src)
+}
+func (m *BulkPublishRequestEnvelope) XXX_Size() int {
+	return xxx_messageInfo_BulkPublishRequestEnvelope.Size(m)
+}
+func (m *BulkPublishRequestEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkPublishRequestEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkPublishRequestEnvelope proto.InternalMessageInfo
+
+func (m *BulkPublishRequestEnvelope) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *BulkPublishRequestEnvelope) GetEntries() []*BulkPublishRequestEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// BulkPublishRequestEntry is an event of a bulk publish request. The entry id identifies the event in the
+// statuses of the response.
+type BulkPublishRequestEntry struct {
+	EntryId              string            `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Event                []byte            `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	ContentType          string            `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BulkPublishRequestEntry) Reset()         { *m = BulkPublishRequestEntry{} }
+func (m *BulkPublishRequestEntry) String() string { return proto.CompactTextString(m) }
+func (*BulkPublishRequestEntry) ProtoMessage()    {}
+func (*BulkPublishRequestEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7b90b188d60b001, []int{1}
+}
+
+func (m *BulkPublishRequestEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkPublishRequestEntry.Unmarshal(m, b)
+}
+func (m *BulkPublishRequestEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkPublishRequestEntry.Marshal(b, m, deterministic)
+}
+func (m *BulkPublishRequestEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkPublishRequestEntry.Merge(m, src)
+}
+func (m *BulkPublishRequestEntry) XXX_Size() int {
+	return xxx_messageInfo_BulkPublishRequestEntry.Size(m)
+}
+func (m *BulkPublishRequestEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkPublishRequestEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkPublishRequestEntry proto.InternalMessageInfo
+
+func (m *BulkPublishRequestEntry) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
+}
+
+func (m *BulkPublishRequestEntry) GetEvent() []byte {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *BulkPublishRequestEntry) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *BulkPublishRequestEntry) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// BulkPublishResponseEnvelope holds the publish statuses of the entries of the request
+type BulkPublishResponseEnvelope struct {
+	Statuses             []*BulkPublishResponseEntry `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *BulkPublishResponseEnvelope) Reset()         { *m = BulkPublishResponseEnvelope{} }
+func (m *BulkPublishResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*BulkPublishResponseEnvelope) ProtoMessage()    {}
+func (*BulkPublishResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7b90b188d60b001, []int{2}
+}
+
+func (m *BulkPublishResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkPublishResponseEnvelope.Unmarshal(m, b)
+}
+func (m *BulkPublishResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkPublishResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *BulkPublishResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkPublishResponseEnvelope.Merge(m, src)
+}
+func (m *BulkPublishResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_BulkPublishResponseEnvelope.Size(m)
+}
+func (m *BulkPublishResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkPublishResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkPublishResponseEnvelope proto.InternalMessageInfo
+
+func (m *BulkPublishResponseEnvelope) GetStatuses() []*BulkPublishResponseEntry {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+// BulkPublishResponseEntry is the publish status of an entry, SUCCESS or FAILED
+type BulkPublishResponseEntry struct {
+	EntryId              string   `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkPublishResponseEntry) Reset()         { *m = BulkPublishResponseEntry{} }
+func (m *BulkPublishResponseEntry) String() string { return proto.CompactTextString(m) }
+func (*BulkPublishResponseEntry) ProtoMessage()    {}
+func (*BulkPublishResponseEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7b90b188d60b001, []int{3}
+}
+
+func (m *BulkPublishResponseEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkPublishResponseEntry.Unmarshal(m, b)
+}
+func (m *BulkPublishResponseEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkPublishResponseEntry.Marshal(b, m, deterministic)
+}
+func (m *BulkPublishResponseEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkPublishResponseEntry.Merge(m, src)
+}
+func (m *BulkPublishResponseEntry) XXX_Size() int {
+	return xxx_messageInfo_BulkPublishResponseEntry.Size(m)
+}
+func (m *BulkPublishResponseEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkPublishResponseEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkPublishResponseEntry proto.InternalMessageInfo
+
+func (m *BulkPublishResponseEntry) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
+}
+
+func (m *BulkPublishResponseEntry) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *BulkPublishResponseEntry) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*BulkPublishRequestEnvelope)(nil), "dapr.proto.dapr.v1.BulkPublishRequestEnvelope")
+	proto.RegisterType((*BulkPublishRequestEntry)(nil), "dapr.proto.dapr.v1.BulkPublishRequestEntry")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.BulkPublishRequestEntry.MetadataEntry")
+	proto.RegisterType((*BulkPublishResponseEnvelope)(nil), "dapr.proto.dapr.v1.BulkPublishResponseEnvelope")
+	proto.RegisterType((*BulkPublishResponseEntry)(nil), "dapr.proto.dapr.v1.BulkPublishResponseEntry")
+}
+
+func init() { proto.RegisterFile("dapr/proto/dapr/v1/pubsub.proto", fileDescriptor_d7b90b188d60b001) }
+
+var fileDescriptor_d7b90b188d60b001 = []byte{
+	// 419 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x4b, 0x8b, 0x13, 0x41,
+	0x10, 0xc7, 0x99, 0x89, 0xae, 0xd9, 0xda, 0x15, 0x42, 0x23, 0x3a, 0x66, 0x0f, 0xc6, 0x1c, 0x24,
+	0xa0, 0x74, 0xc8, 0x7a, 0xf1, 0x71, 0x32, 0x1a, 0xd4, 0x83, 0x10, 0x46, 0xbd, 0x78, 0x59, 0xe6,
+	0x51, 0xcc, 0x0e, 0x99, 0x9d, 0x6e, 0xbb, 0xab, 0x07, 0xe6, 0xb8, 0x5f, 0xc7, 0x4f, 0x29, 0xfd,
+	0x48, 0x24, 0xe8, 0x6a, 0xbc, 0x0c, 0x55, 0x35, 0xf5, 0xff, 0xd7, 0xaf, 0xab, 0x69, 0x78, 0x54,
+	0x66, 0x52, 0xcd, 0xa5, 0x12, 0x24, 0xe6, 0x2e, 0xec, 0x16, 0x73, 0x69, 0x72, 0x6d, 0x72, 0xee,
+	0x8a, 0x8c, 0xd9, 0xaa, 0x8f, 0xb9, 0x0b, 0xbb, 0xc5, 0xb4, 0x87, 0xf1, 0xd2, 0x34, 0x9b, 0xb5,
+	0xc9, 0x9b, 0x5a, 0x5f, 0xa6, 0xf8, 0xdd, 0xa0, 0xa6, 0x55, 0xdb, 0x61, 0x23, 0x24, 0xb2, 0x7b,
+	0x70, 0x9b, 0x84, 0xac, 0x8b, 0x24, 0x9a, 0x44, 0xb3, 0xe3, 0xd4, 0x27, 0x6c, 0x05, 0x77, 0xb0,
+	0x25, 0x55, 0xa3, 0x4e, 0xe2, 0xc9, 0x60, 0x76, 0x72, 0xfe, 0x94, 0xff, 0xee, 0xcc, 0xff, 0x64,
+	0x4b, 0xaa, 0x4f, 0xb7, 0xda, 0xe9, 0x75, 0x0c, 0x0f, 0x6e, 0x68, 0x62, 0x0f, 0x61, 0x68, 0xdb,
+	0xfa, 0x8b, 0xba, 0x0c, 0xb3, 0x9d, 0xac, 0xff, 0x58, 0x5a, 0x26, 0xec, 0xb0, 0xa5, 0x24, 0x9e,
+	0x44, 0xb3, 0xd3, 0xd4, 0x27, 0xec, 0x31, 0x9c, 0x16, 0xa2, 0x25, 0x6c, 0xe9, 0x82, 0x7a, 0x89,
+	0xc9, 0xc0, 0x89, 0x4e, 0x42, 0xed, 0x4b, 0x2f, 0x91, 0x7d, 0x85, 0xe1, 0x15, 0x52, 0x56, 0x66,
+	0x94, 0x25, 0xb7, 0x1c, 0xf7, 0xcb, 0xff, 0xe0, 0xe6, 0x9f, 0x82, 0xd6, 0x9f, 0x62, 0x67, 0x35,
+	0x7e, 0x0d, 0x77, 0xf7, 0x7e, 0xb1, 0x11, 0x0c, 0x36, 0xd8, 0x07, 0x6c, 0x1b, 0x5a, 0xe4, 0x2e,
+	0x6b, 0x0c, 0x3a, 0xe4, 0xe3, 0xd4, 0x27, 0xaf, 0xe2, 0x17, 0xd1, 0xb4, 0x82, 0xb3, 0xbd, 0x79,
+	0x5a, 0x8a, 0x56, 0xe3, 0x6e, 0xff, 0x1f, 0x60, 0xa8, 0x29, 0x23, 0xa3, 0x51, 0x27, 0x91, 0x43,
+	0x7e, 0xf6, 0x4f, 0xe4, 0xad, 0x85, 0xa3, 0xdc, 0xaa, 0xa7, 0x05, 0x24, 0x37, 0x75, 0xfd, 0x6d,
+	0xd9, 0xf7, 0xe1, 0xc8, 0x5b, 0x04, 0xf4, 0x90, 0xb9, 0x4b, 0x50, 0x4a, 0xa8, 0xb0, 0x67, 0x9f,
+	0x9c, 0x5f, 0x47, 0x00, 0xef, 0x32, 0xa9, 0xd6, 0x26, 0xff, 0x6c, 0x72, 0xa6, 0x61, 0x14, 0xe6,
+	0xd9, 0xd1, 0x2b, 0x77, 0x4f, 0xfc, 0xd0, 0x95, 0xfb, 0x0d, 0x8c, 0xe7, 0x07, 0x9f, 0xd7, 0x0b,
+	0x96, 0x25, 0x40, 0xbd, 0xeb, 0x5c, 0x8e, 0x7e, 0xe1, 0xac, 0xad, 0x85, 0xfe, 0xf6, 0xa4, 0xaa,
+	0xe9, 0xd2, 0xe4, 0xbc, 0x10, 0x57, 0xfe, 0x95, 0xb8, 0x8f, 0xdc, 0x54, 0xfb, 0x2f, 0xe7, 0x47,
+	0x7c, 0x66, 0xa5, 0xfc, 0x6d, 0x53, 0x63, 0x4b, 0xfc, 0x8d, 0x21, 0x51, 0x61, 0xcb, 0xdf, 0x2b,
+	0x59, 0xf0, 0x6e, 0x91, 0x1f, 0xb9, 0xe6, 0xe7, 0x3f, 0x07, 0x00, 0x34, 0x8d, 0x3b, 0x4d, 0x74,
+	0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprPubSubClient is the client API for DaprPubSub service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprPubSubClient interface {
+	PublishBulkEvent(ctx context.Context, in *BulkPublishRequestEnvelope, opts ...grpc.CallOption) (*BulkPublishResponseEnvelope, error)
+}
+
+type daprPubSubClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprPubSubClient(cc *grpc.ClientConn) DaprPubSubClient {
+	return &daprPubSubClient{cc}
+}
+
+func (c *daprPubSubClient) PublishBulkEvent(ctx context.Context, in *BulkPublishRequestEnvelope, opts ...grpc.CallOption) (*BulkPublishResponseEnvelope, error) {
+	out := new(BulkPublishResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprPubSub/PublishBulkEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaprPubSubServer is the server API for DaprPubSub service.
+type DaprPubSubServer interface {
+	PublishBulkEvent(context.Context, *BulkPublishRequestEnvelope) (*BulkPublishResponseEnvelope, error)
+}
+
+// UnimplementedDaprPubSubServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprPubSubServer struct {
+}
+
+func (*UnimplementedDaprPubSubServer) PublishBulkEvent(ctx context.Context, req *BulkPublishRequestEnvelope) (*BulkPublishResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishBulkEvent not implemented")
+}
+
+func RegisterDaprPubSubServer(s *grpc.Server, srv DaprPubSubServer) {
+	s.RegisterService(&_DaprPubSub_serviceDesc, srv)
+}
+
+func _DaprPubSub_PublishBulkEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkPublishRequestEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprPubSubServer).PublishBulkEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprPubSub/PublishBulkEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprPubSubServer).PublishBulkEvent(ctx, req.(*BulkPublishRequestEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DaprPubSub_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprPubSub",
+	HandlerType: (*DaprPubSubServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PublishBulkEvent",
+			Handler:    _DaprPubSub_PublishBulkEvent_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/dapr/v1/pubsub.proto",
+}
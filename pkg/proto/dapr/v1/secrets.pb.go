@@ -0,0 +1,281 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/secrets.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// GetBulkSecretEnvelope is the request of GetBulkSecret. All the secrets of the store are returned when no keys are given.
+type GetBulkSecretEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Keys                 []string          `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBulkSecretEnvelope) Reset()         { *m = GetBulkSecretEnvelope{} }
+func (m *GetBulkSecretEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetBulkSecretEnvelope) ProtoMessage()    {}
+func (*GetBulkSecretEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0017bb4a8f9cd607, []int{0}
+}
+
+func (m *GetBulkSecretEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBulkSecretEnvelope.Unmarshal(m, b)
+}
+func (m *GetBulkSecretEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBulkSecretEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetBulkSecretEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBulkSecretEnvelope.Merge(m, src)
+}
+func (m *GetBulkSecretEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetBulkSecretEnvelope.Size(m)
+}
+func (m *GetBulkSecretEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBulkSecretEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBulkSecretEnvelope proto.InternalMessageInfo
+
+func (m *GetBulkSecretEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *GetBulkSecretEnvelope) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetBulkSecretEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// SecretEnvelope holds the values of a secret
+type SecretEnvelope struct {
+	Secrets              map[string]string `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SecretEnvelope) Reset()         { *m = SecretEnvelope{} }
+func (m *SecretEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SecretEnvelope) ProtoMessage()    {}
+func (*SecretEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0017bb4a8f9cd607, []int{1}
+}
+
+func (m *SecretEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SecretEnvelope.Unmarshal(m, b)
+}
+func (m *SecretEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SecretEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SecretEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecretEnvelope.Merge(m, src)
+}
+func (m *SecretEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SecretEnvelope.Size(m)
+}
+func (m *SecretEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecretEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecretEnvelope proto.InternalMessageInfo
+
+func (m *SecretEnvelope) GetSecrets() map[string]string {
+	if m != nil {
+		return m.Secrets
+	}
+	return nil
+}
+
+// GetBulkSecretResponseEnvelope holds the secrets by name
+type GetBulkSecretResponseEnvelope struct {
+	Data                 map[string]*SecretEnvelope `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *GetBulkSecretResponseEnvelope) Reset()         { *m = GetBulkSecretResponseEnvelope{} }
+func (m *GetBulkSecretResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetBulkSecretResponseEnvelope) ProtoMessage()    {}
+func (*GetBulkSecretResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0017bb4a8f9cd607, []int{2}
+}
+
+func (m *GetBulkSecretResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBulkSecretResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetBulkSecretResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBulkSecretResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetBulkSecretResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBulkSecretResponseEnvelope.Merge(m, src)
+}
+func (m *GetBulkSecretResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetBulkSecretResponseEnvelope.Size(m)
+}
+func (m *GetBulkSecretResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBulkSecretResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBulkSecretResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetBulkSecretResponseEnvelope) GetData() map[string]*SecretEnvelope {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetBulkSecretEnvelope)(nil), "dapr.proto.dapr.v1.GetBulkSecretEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetBulkSecretEnvelope.MetadataEntry")
+	proto.RegisterType((*SecretEnvelope)(nil), "dapr.proto.dapr.v1.SecretEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.SecretEnvelope.SecretsEntry")
+	proto.RegisterType((*GetBulkSecretResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetBulkSecretResponseEnvelope")
+	proto.RegisterMapType((map[string]*SecretEnvelope)(nil), "dapr.proto.dapr.v1.GetBulkSecretResponseEnvelope.DataEntry")
+}
+
+func init() { proto.RegisterFile("dapr/proto/dapr/v1/secrets.proto", fileDescriptor_0017bb4a8f9cd607) }
+
+var fileDescriptor_0017bb4a8f9cd607 = []byte{
+	// 386 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xcd, 0x6a, 0xdb, 0x40,
+	0x10, 0xc7, 0x91, 0xe4, 0x7e, 0x68, 0x5c, 0x97, 0x76, 0x69, 0x41, 0xa8, 0x18, 0x84, 0x0f, 0xc5,
+	0xbd, 0xac, 0x90, 0x7b, 0xa8, 0xb1, 0x4f, 0x75, 0x6d, 0x4c, 0x0f, 0x6d, 0x82, 0x7c, 0x4b, 0x0e,
+	0x61, 0x6d, 0x0f, 0x8e, 0xd1, 0x27, 0xda, 0x95, 0xc0, 0x90, 0xf7, 0xc8, 0x3b, 0xe4, 0x5d, 0xf2,
+	0x06, 0x79, 0x98, 0xa0, 0x95, 0x22, 0x24, 0xc7, 0xc4, 0xf1, 0x45, 0xfc, 0x77, 0x76, 0x66, 0xf6,
+	0x37, 0x7f, 0x34, 0x60, 0xad, 0x59, 0x9c, 0xd8, 0x71, 0x12, 0x89, 0xc8, 0x96, 0x32, 0x73, 0x6c,
+	0x8e, 0xab, 0x04, 0x05, 0xa7, 0x32, 0x4a, 0x48, 0x1e, 0x2e, 0x34, 0x95, 0x32, 0x73, 0x7a, 0x0f,
+	0x0a, 0x7c, 0x9d, 0xa3, 0x98, 0xa4, 0xbe, 0xb7, 0x90, 0xc9, 0xb3, 0x30, 0x43, 0x3f, 0x8a, 0x91,
+	0x74, 0x01, 0xb8, 0x88, 0x12, 0xbc, 0x0a, 0x59, 0x80, 0x86, 0x62, 0x29, 0x7d, 0xdd, 0xd5, 0x65,
+	0xe4, 0x3f, 0x0b, 0x90, 0x10, 0x68, 0x79, 0xb8, 0xe3, 0x86, 0x6a, 0x69, 0x7d, 0xdd, 0x95, 0x9a,
+	0x2c, 0xe0, 0x7d, 0x80, 0x82, 0xad, 0x99, 0x60, 0x86, 0x66, 0x69, 0xfd, 0xf6, 0xe0, 0x17, 0x7d,
+	0xfe, 0x26, 0x3d, 0xf8, 0x1e, 0xfd, 0x57, 0x56, 0xce, 0x42, 0x91, 0xec, 0xdc, 0xaa, 0x91, 0x39,
+	0x86, 0x4e, 0xe3, 0x8a, 0x7c, 0x02, 0xcd, 0xc3, 0x5d, 0x49, 0x94, 0x4b, 0xf2, 0x05, 0xde, 0x64,
+	0xcc, 0x4f, 0xd1, 0x50, 0x65, 0xac, 0x38, 0x8c, 0xd4, 0xa1, 0xd2, 0xbb, 0x55, 0xe0, 0xe3, 0xde,
+	0x5c, 0x7f, 0xe1, 0x5d, 0x69, 0x8b, 0xa1, 0x48, 0x46, 0xfb, 0x10, 0xe3, 0x1e, 0x5c, 0x71, 0xe4,
+	0x05, 0xdb, 0x53, 0xbd, 0x39, 0x82, 0x0f, 0xf5, 0x8b, 0x93, 0xc8, 0xee, 0x15, 0xe8, 0x36, 0x8c,
+	0x70, 0x91, 0xc7, 0x51, 0xc8, 0xb1, 0x02, 0x3d, 0x83, 0x96, 0x74, 0xb2, 0xa0, 0x1c, 0x1f, 0x75,
+	0x72, 0xbf, 0x01, 0x9d, 0x56, 0x6e, 0xca, 0x46, 0xe6, 0x25, 0xe8, 0xd3, 0x17, 0x5c, 0x1c, 0xd6,
+	0x59, 0xdb, 0x83, 0xde, 0x71, 0x5b, 0x6a, 0xf3, 0x0c, 0x6e, 0xa0, 0x3d, 0x65, 0x71, 0x52, 0xfa,
+	0x41, 0x02, 0xe8, 0x34, 0xe0, 0xc8, 0x8f, 0x57, 0xff, 0x09, 0xa6, 0x73, 0xf2, 0xa8, 0x13, 0x04,
+	0xd8, 0x56, 0xb9, 0x93, 0xcf, 0x35, 0x92, 0xf3, 0xbc, 0x0b, 0xbf, 0xf8, 0xbe, 0xd9, 0x8a, 0xeb,
+	0x74, 0x49, 0x57, 0x51, 0x50, 0x6c, 0x87, 0xfc, 0xc4, 0xde, 0xa6, 0xb9, 0x31, 0x77, 0xea, 0xb7,
+	0xbc, 0x96, 0xfe, 0xf1, 0xb7, 0x18, 0x0a, 0xfa, 0x3b, 0x15, 0xd1, 0x06, 0x43, 0x3a, 0x4f, 0xe2,
+	0x15, 0xcd, 0x9c, 0xe5, 0x5b, 0x99, 0xfc, 0xf3, 0x71, 0x00, 0x1f, 0x16, 0x53, 0x41, 0x6c, 0x03,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprSecretsClient is the client API for DaprSecrets service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprSecretsClient interface {
+	GetBulkSecret(ctx context.Context, in *GetBulkSecretEnvelope, opts ...grpc.CallOption) (*GetBulkSecretResponseEnvelope, error)
+}
+
+type daprSecretsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprSecretsClient(cc *grpc.ClientConn) DaprSecretsClient {
+	return &daprSecretsClient{cc}
+}
+
+func (c *daprSecretsClient) GetBulkSecret(ctx context.Context, in *GetBulkSecretEnvelope, opts ...grpc.CallOption) (*GetBulkSecretResponseEnvelope, error) {
+	out := new(GetBulkSecretResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprSecrets/GetBulkSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaprSecretsServer is the server API for DaprSecrets service.
+type DaprSecretsServer interface {
+	GetBulkSecret(context.Context, *GetBulkSecretEnvelope) (*GetBulkSecretResponseEnvelope, error)
+}
+
+// UnimplementedDaprSecretsServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprSecretsServer struct {
+}
+
+func (*UnimplementedDaprSecretsServer) GetBulkSecret(ctx context.Context, req *GetBulkSecretEnvelope) (*GetBulkSecretResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBulkSecret not implemented")
+}
+
+func RegisterDaprSecretsServer(s *grpc.Server, srv DaprSecretsServer) {
+	s.RegisterService(&_DaprSecrets_serviceDesc, srv)
+}
+
+func _DaprSecrets_GetBulkSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBulkSecretEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprSecretsServer).GetBulkSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprSecrets/GetBulkSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprSecretsServer).GetBulkSecret(ctx, req.(*GetBulkSecretEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DaprSecrets_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprSecrets",
+	HandlerType: (*DaprSecretsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBulkSecret",
+			Handler:    _DaprSecrets_GetBulkSecret_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/dapr/v1/secrets.proto",
+}
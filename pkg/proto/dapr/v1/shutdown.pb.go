@@ -0,0 +1,126 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/shutdown.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() { proto.RegisterFile("dapr/proto/dapr/v1/shutdown.proto", fileDescriptor_743d7ac2c3e16a69) }
+
+var fileDescriptor_743d7ac2c3e16a69 = []byte{
+	// 182 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x4c, 0x49, 0x2c, 0x28,
+	0xd2, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0xd7, 0x07, 0x33, 0xcb, 0x0c, 0xf5, 0x8b, 0x33, 0x4a, 0x4b,
+	0x52, 0xf2, 0xcb, 0xf3, 0xf4, 0xc0, 0xc2, 0x42, 0x42, 0x20, 0x71, 0x08, 0x5b, 0x0f, 0xcc, 0x2c,
+	0x33, 0x94, 0x92, 0x4e, 0xcf, 0xcf, 0x4f, 0xcf, 0x49, 0x85, 0x68, 0x4c, 0x2a, 0x4d, 0xd3, 0x4f,
+	0xcd, 0x2d, 0x28, 0xa9, 0x84, 0x28, 0x32, 0xf2, 0xe2, 0xe2, 0x71, 0x49, 0x2c, 0x28, 0x0a, 0x86,
+	0x1a, 0x23, 0x64, 0xc5, 0xc5, 0x01, 0x67, 0x8b, 0xe9, 0x41, 0x74, 0xea, 0xc1, 0x74, 0xea, 0xb9,
+	0x82, 0x74, 0x4a, 0xe1, 0x10, 0x77, 0x4a, 0xe3, 0xe2, 0xca, 0x84, 0x5b, 0xeb, 0x24, 0x84, 0x6c,
+	0x6e, 0x00, 0x48, 0x65, 0x71, 0x94, 0x5a, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e,
+	0x2e, 0xc4, 0x07, 0x60, 0xa2, 0x20, 0x3b, 0x1d, 0xd5, 0x57, 0xab, 0x98, 0xa4, 0x41, 0x9a, 0xf5,
+	0x9c, 0x73, 0x32, 0x53, 0xf3, 0x4a, 0xf4, 0x1c, 0x4b, 0x4b, 0xf2, 0xd3, 0x53, 0xf3, 0xf4, 0xdc,
+	0x8b, 0x0a, 0x92, 0xf5, 0xca, 0x0c, 0x93, 0xd8, 0xc0, 0x8a, 0x8d, 0x01, 0x03, 0x00, 0x32, 0xe1,
+	0xf8, 0xaf, 0x10, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprShutdownClient is the client API for DaprShutdown service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprShutdownClient interface {
+	// Starts the graceful shutdown of the sidecar.
+	Shutdown(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type daprShutdownClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprShutdownClient(cc *grpc.ClientConn) DaprShutdownClient {
+	return &daprShutdownClient{cc}
+}
+
+func (c *daprShutdownClient) Shutdown(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprShutdown/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaprShutdownServer is the server API for DaprShutdown service.
+type DaprShutdownServer interface {
+	// Starts the graceful shutdown of the sidecar.
+	Shutdown(context.Context, *empty.Empty) (*empty.Empty, error)
+}
+
+// UnimplementedDaprShutdownServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprShutdownServer struct {
+}
+
+func (*UnimplementedDaprShutdownServer) Shutdown(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+
+func RegisterDaprShutdownServer(s *grpc.Server, srv DaprShutdownServer) {
+	s.RegisterService(&_DaprShutdown_serviceDesc, srv)
+}
+
+func _DaprShutdown_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprShutdownServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprShutdown/Shutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprShutdownServer).Shutdown(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DaprShutdown_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprShutdown",
+	HandlerType: (*DaprShutdownServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Shutdown",
+			Handler:    _DaprShutdown_Shutdown_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/dapr/v1/shutdown.proto",
+}
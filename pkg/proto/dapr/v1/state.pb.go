@@ -0,0 +1,1082 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/state.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// TransactionalStateOperation is an upsert or delete operation of a state transaction.
+// Delete operations only use the key, etag and options of the request.
+type TransactionalStateOperation struct {
+	OperationType        string        `protobuf:"bytes,1,opt,name=operation_type,json=operationType,proto3" json:"operation_type,omitempty"`
+	Request              *StateRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *TransactionalStateOperation) Reset()         { *m = TransactionalStateOperation{} }
+func (m *TransactionalStateOperation) String() string { return proto.CompactTextString(m) }
+func (*TransactionalStateOperation) ProtoMessage()    {}
+func (*TransactionalStateOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{0}
+}
+
+func (m *TransactionalStateOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionalStateOperation.Unmarshal(m, b)
+}
+func (m *TransactionalStateOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionalStateOperation.Marshal(b, m, deterministic)
+}
+func (m *TransactionalStateOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionalStateOperation.Merge(m, src)
+}
+func (m *TransactionalStateOperation) XXX_Size() int {
+	return xxx_messageInfo_TransactionalStateOperation.Size(m)
+}
+func (m *TransactionalStateOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionalStateOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionalStateOperation proto.InternalMessageInfo
+
+func (m *TransactionalStateOperation) GetOperationType() string {
+	if m != nil {
+		return m.OperationType
+	}
+	return ""
+}
+
+func (m *TransactionalStateOperation) GetRequest() *StateRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+// ExecuteStateTransactionEnvelope is the request of ExecuteStateTransaction
+type ExecuteStateTransactionEnvelope struct {
+	StoreName            string                         `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Operations           []*TransactionalStateOperation `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ExecuteStateTransactionEnvelope) Reset()         { *m = ExecuteStateTransactionEnvelope{} }
+func (m *ExecuteStateTransactionEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ExecuteStateTransactionEnvelope) ProtoMessage()    {}
+func (*ExecuteStateTransactionEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{1}
+}
+
+func (m *ExecuteStateTransactionEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteStateTransactionEnvelope.Unmarshal(m, b)
+}
+func (m *ExecuteStateTransactionEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteStateTransactionEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ExecuteStateTransactionEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteStateTransactionEnvelope.Merge(m, src)
+}
+func (m *ExecuteStateTransactionEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ExecuteStateTransactionEnvelope.Size(m)
+}
+func (m *ExecuteStateTransactionEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteStateTransactionEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteStateTransactionEnvelope proto.InternalMessageInfo
+
+func (m *ExecuteStateTransactionEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *ExecuteStateTransactionEnvelope) GetOperations() []*TransactionalStateOperation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+// QueryStateEnvelope is the request of QueryState. The query is a JSON document holding
+// the filter, sort keys and pagination of the query.
+type QueryStateEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Query                string            `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *QueryStateEnvelope) Reset()         { *m = QueryStateEnvelope{} }
+func (m *QueryStateEnvelope) String() string { return proto.CompactTextString(m) }
+func (*QueryStateEnvelope) ProtoMessage()    {}
+func (*QueryStateEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{2}
+}
+
+func (m *QueryStateEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateEnvelope.Unmarshal(m, b)
+}
+func (m *QueryStateEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateEnvelope.Marshal(b, m, deterministic)
+}
+func (m *QueryStateEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateEnvelope.Merge(m, src)
+}
+func (m *QueryStateEnvelope) XXX_Size() int {
+	return xxx_messageInfo_QueryStateEnvelope.Size(m)
+}
+func (m *QueryStateEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateEnvelope proto.InternalMessageInfo
+
+func (m *QueryStateEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *QueryStateEnvelope) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *QueryStateEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// QueryStateItem is a state entry matching a query
+type QueryStateItem struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryStateItem) Reset()         { *m = QueryStateItem{} }
+func (m *QueryStateItem) String() string { return proto.CompactTextString(m) }
+func (*QueryStateItem) ProtoMessage()    {}
+func (*QueryStateItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{3}
+}
+
+func (m *QueryStateItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateItem.Unmarshal(m, b)
+}
+func (m *QueryStateItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateItem.Marshal(b, m, deterministic)
+}
+func (m *QueryStateItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateItem.Merge(m, src)
+}
+func (m *QueryStateItem) XXX_Size() int {
+	return xxx_messageInfo_QueryStateItem.Size(m)
+}
+func (m *QueryStateItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateItem proto.InternalMessageInfo
+
+func (m *QueryStateItem) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *QueryStateItem) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *QueryStateItem) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+// QueryStateResponseEnvelope holds a page of query results and the token of the next page
+type QueryStateResponseEnvelope struct {
+	Results              []*QueryStateItem `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Token                string            `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *QueryStateResponseEnvelope) Reset()         { *m = QueryStateResponseEnvelope{} }
+func (m *QueryStateResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*QueryStateResponseEnvelope) ProtoMessage()    {}
+func (*QueryStateResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{4}
+}
+
+func (m *QueryStateResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateResponseEnvelope.Unmarshal(m, b)
+}
+func (m *QueryStateResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *QueryStateResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateResponseEnvelope.Merge(m, src)
+}
+func (m *QueryStateResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_QueryStateResponseEnvelope.Size(m)
+}
+func (m *QueryStateResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateResponseEnvelope proto.InternalMessageInfo
+
+func (m *QueryStateResponseEnvelope) GetResults() []*QueryStateItem {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *QueryStateResponseEnvelope) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+// GetBulkStateEnvelope is the request of GetBulkState
+type GetBulkStateEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Keys                 []string          `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Parallelism          int32             `protobuf:"varint,3,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBulkStateEnvelope) Reset()         { *m = GetBulkStateEnvelope{} }
+func (m *GetBulkStateEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetBulkStateEnvelope) ProtoMessage()    {}
+func (*GetBulkStateEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{5}
+}
+
+func (m *GetBulkStateEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBulkStateEnvelope.Unmarshal(m, b)
+}
+func (m *GetBulkStateEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBulkStateEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetBulkStateEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBulkStateEnvelope.Merge(m, src)
+}
+func (m *GetBulkStateEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetBulkStateEnvelope.Size(m)
+}
+func (m *GetBulkStateEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBulkStateEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBulkStateEnvelope proto.InternalMessageInfo
+
+func (m *GetBulkStateEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *GetBulkStateEnvelope) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetBulkStateEnvelope) GetParallelism() int32 {
+	if m != nil {
+		return m.Parallelism
+	}
+	return 0
+}
+
+func (m *GetBulkStateEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// BulkStateItem is the state of a key of a bulk get. The error is set if the key could not be fetched.
+type BulkStateItem struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkStateItem) Reset()         { *m = BulkStateItem{} }
+func (m *BulkStateItem) String() string { return proto.CompactTextString(m) }
+func (*BulkStateItem) ProtoMessage()    {}
+func (*BulkStateItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{6}
+}
+
+func (m *BulkStateItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkStateItem.Unmarshal(m, b)
+}
+func (m *BulkStateItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkStateItem.Marshal(b, m, deterministic)
+}
+func (m *BulkStateItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkStateItem.Merge(m, src)
+}
+func (m *BulkStateItem) XXX_Size() int {
+	return xxx_messageInfo_BulkStateItem.Size(m)
+}
+func (m *BulkStateItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkStateItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkStateItem proto.InternalMessageInfo
+
+func (m *BulkStateItem) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *BulkStateItem) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *BulkStateItem) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *BulkStateItem) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// GetBulkStateResponseEnvelope holds the states of the keys of a bulk get, in the order of the keys
+type GetBulkStateResponseEnvelope struct {
+	Items                []*BulkStateItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetBulkStateResponseEnvelope) Reset()         { *m = GetBulkStateResponseEnvelope{} }
+func (m *GetBulkStateResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetBulkStateResponseEnvelope) ProtoMessage()    {}
+func (*GetBulkStateResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{7}
+}
+
+func (m *GetBulkStateResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBulkStateResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetBulkStateResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBulkStateResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetBulkStateResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBulkStateResponseEnvelope.Merge(m, src)
+}
+func (m *GetBulkStateResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetBulkStateResponseEnvelope.Size(m)
+}
+func (m *GetBulkStateResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBulkStateResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBulkStateResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetBulkStateResponseEnvelope) GetItems() []*BulkStateItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// SubscribeStateEnvelope is the request of SubscribeState. The changes of the keys and
+// of the keys starting with the prefixes are sent over the stream.
+type SubscribeStateEnvelope struct {
+	StoreName            string            `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Keys                 []string          `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Prefixes             []string          `protobuf:"bytes,3,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SubscribeStateEnvelope) Reset()         { *m = SubscribeStateEnvelope{} }
+func (m *SubscribeStateEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SubscribeStateEnvelope) ProtoMessage()    {}
+func (*SubscribeStateEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{8}
+}
+
+func (m *SubscribeStateEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeStateEnvelope.Unmarshal(m, b)
+}
+func (m *SubscribeStateEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeStateEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SubscribeStateEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeStateEnvelope.Merge(m, src)
+}
+func (m *SubscribeStateEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SubscribeStateEnvelope.Size(m)
+}
+func (m *SubscribeStateEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeStateEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeStateEnvelope proto.InternalMessageInfo
+
+func (m *SubscribeStateEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *SubscribeStateEnvelope) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *SubscribeStateEnvelope) GetPrefixes() []string {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+func (m *SubscribeStateEnvelope) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// StateChangeEnvelope is the new state of a subscribed key. Data and etag are empty if the key was deleted.
+type StateChangeEnvelope struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	Deleted              bool     `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateChangeEnvelope) Reset()         { *m = StateChangeEnvelope{} }
+func (m *StateChangeEnvelope) String() string { return proto.CompactTextString(m) }
+func (*StateChangeEnvelope) ProtoMessage()    {}
+func (*StateChangeEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{9}
+}
+
+func (m *StateChangeEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateChangeEnvelope.Unmarshal(m, b)
+}
+func (m *StateChangeEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateChangeEnvelope.Marshal(b, m, deterministic)
+}
+func (m *StateChangeEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateChangeEnvelope.Merge(m, src)
+}
+func (m *StateChangeEnvelope) XXX_Size() int {
+	return xxx_messageInfo_StateChangeEnvelope.Size(m)
+}
+func (m *StateChangeEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateChangeEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateChangeEnvelope proto.InternalMessageInfo
+
+func (m *StateChangeEnvelope) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StateChangeEnvelope) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *StateChangeEnvelope) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *StateChangeEnvelope) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+// DeleteBulkStateEnvelope is the request of DeleteBulkState.
+// The deletes only use the key, etag, options and metadata of the requests.
+type DeleteBulkStateEnvelope struct {
+	StoreName            string          `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Requests             []*StateRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *DeleteBulkStateEnvelope) Reset()         { *m = DeleteBulkStateEnvelope{} }
+func (m *DeleteBulkStateEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DeleteBulkStateEnvelope) ProtoMessage()    {}
+func (*DeleteBulkStateEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{10}
+}
+
+func (m *DeleteBulkStateEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBulkStateEnvelope.Unmarshal(m, b)
+}
+func (m *DeleteBulkStateEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBulkStateEnvelope.Marshal(b, m, deterministic)
+}
+func (m *DeleteBulkStateEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBulkStateEnvelope.Merge(m, src)
+}
+func (m *DeleteBulkStateEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DeleteBulkStateEnvelope.Size(m)
+}
+func (m *DeleteBulkStateEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBulkStateEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteBulkStateEnvelope proto.InternalMessageInfo
+
+func (m *DeleteBulkStateEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *DeleteBulkStateEnvelope) GetRequests() []*StateRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+// DeleteStateWithPrefixEnvelope is the request of DeleteStateWithPrefix
+type DeleteStateWithPrefixEnvelope struct {
+	StoreName            string   `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Prefix               string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteStateWithPrefixEnvelope) Reset()         { *m = DeleteStateWithPrefixEnvelope{} }
+func (m *DeleteStateWithPrefixEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DeleteStateWithPrefixEnvelope) ProtoMessage()    {}
+func (*DeleteStateWithPrefixEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{11}
+}
+
+func (m *DeleteStateWithPrefixEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteStateWithPrefixEnvelope.Unmarshal(m, b)
+}
+func (m *DeleteStateWithPrefixEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteStateWithPrefixEnvelope.Marshal(b, m, deterministic)
+}
+func (m *DeleteStateWithPrefixEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteStateWithPrefixEnvelope.Merge(m, src)
+}
+func (m *DeleteStateWithPrefixEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DeleteStateWithPrefixEnvelope.Size(m)
+}
+func (m *DeleteStateWithPrefixEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteStateWithPrefixEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteStateWithPrefixEnvelope proto.InternalMessageInfo
+
+func (m *DeleteStateWithPrefixEnvelope) GetStoreName() string {
+	if m != nil {
+		return m.StoreName
+	}
+	return ""
+}
+
+func (m *DeleteStateWithPrefixEnvelope) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+// DeleteStateWithPrefixResponseEnvelope holds the number of keys deleted by DeleteStateWithPrefix
+type DeleteStateWithPrefixResponseEnvelope struct {
+	Deleted              int32    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteStateWithPrefixResponseEnvelope) Reset()         { *m = DeleteStateWithPrefixResponseEnvelope{} }
+func (m *DeleteStateWithPrefixResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DeleteStateWithPrefixResponseEnvelope) ProtoMessage()    {}
+func (*DeleteStateWithPrefixResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c8433f1a1033023, []int{12}
+}
+
+func (m *DeleteStateWithPrefixResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteStateWithPrefixResponseEnvelope.Unmarshal(m, b)
+}
+func (m *DeleteStateWithPrefixResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteStateWithPrefixResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *DeleteStateWithPrefixResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteStateWithPrefixResponseEnvelope.Merge(m, src)
+}
+func (m *DeleteStateWithPrefixResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DeleteStateWithPrefixResponseEnvelope.Size(m)
+}
+func (m *DeleteStateWithPrefixResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteStateWithPrefixResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteStateWithPrefixResponseEnvelope proto.InternalMessageInfo
+
+func (m *DeleteStateWithPrefixResponseEnvelope) GetDeleted() int32 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*TransactionalStateOperation)(nil), "dapr.proto.dapr.v1.TransactionalStateOperation")
+	proto.RegisterType((*ExecuteStateTransactionEnvelope)(nil), "dapr.proto.dapr.v1.ExecuteStateTransactionEnvelope")
+	proto.RegisterType((*QueryStateEnvelope)(nil), "dapr.proto.dapr.v1.QueryStateEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.QueryStateEnvelope.MetadataEntry")
+	proto.RegisterType((*QueryStateItem)(nil), "dapr.proto.dapr.v1.QueryStateItem")
+	proto.RegisterType((*QueryStateResponseEnvelope)(nil), "dapr.proto.dapr.v1.QueryStateResponseEnvelope")
+	proto.RegisterType((*GetBulkStateEnvelope)(nil), "dapr.proto.dapr.v1.GetBulkStateEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.GetBulkStateEnvelope.MetadataEntry")
+	proto.RegisterType((*BulkStateItem)(nil), "dapr.proto.dapr.v1.BulkStateItem")
+	proto.RegisterType((*GetBulkStateResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetBulkStateResponseEnvelope")
+	proto.RegisterType((*SubscribeStateEnvelope)(nil), "dapr.proto.dapr.v1.SubscribeStateEnvelope")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.dapr.v1.SubscribeStateEnvelope.MetadataEntry")
+	proto.RegisterType((*StateChangeEnvelope)(nil), "dapr.proto.dapr.v1.StateChangeEnvelope")
+	proto.RegisterType((*DeleteBulkStateEnvelope)(nil), "dapr.proto.dapr.v1.DeleteBulkStateEnvelope")
+	proto.RegisterType((*DeleteStateWithPrefixEnvelope)(nil), "dapr.proto.dapr.v1.DeleteStateWithPrefixEnvelope")
+	proto.RegisterType((*DeleteStateWithPrefixResponseEnvelope)(nil), "dapr.proto.dapr.v1.DeleteStateWithPrefixResponseEnvelope")
+}
+
+func init() { proto.RegisterFile("dapr/proto/dapr/v1/state.proto", fileDescriptor_8c8433f1a1033023) }
+
+var fileDescriptor_8c8433f1a1033023 = []byte{
+	// 810 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5b, 0x4f, 0xdb, 0x58,
+	0x10, 0x96, 0x73, 0x81, 0x64, 0xb8, 0xad, 0xce, 0xb2, 0x60, 0x99, 0x65, 0x37, 0x6b, 0x09, 0x36,
+	0x6a, 0x25, 0x87, 0x40, 0xd5, 0x52, 0xca, 0x0b, 0x97, 0x08, 0xb5, 0x52, 0x0b, 0x35, 0x08, 0xa4,
+	0xbe, 0xa0, 0x93, 0x64, 0x30, 0x6e, 0x7c, 0xe3, 0xf8, 0x38, 0x22, 0x6f, 0x7d, 0xe9, 0x6b, 0xa5,
+	0xfe, 0x8d, 0xfe, 0xa2, 0xfe, 0x96, 0xaa, 0x0f, 0x95, 0x8f, 0x63, 0x27, 0x21, 0x0e, 0x84, 0x22,
+	0x5e, 0xa2, 0x99, 0x93, 0xb9, 0x7c, 0x73, 0xbe, 0x99, 0x39, 0x86, 0x7f, 0x9a, 0xd4, 0x63, 0x15,
+	0x8f, 0xb9, 0xdc, 0xad, 0x08, 0xb1, 0x5d, 0xad, 0xf8, 0x9c, 0x72, 0xd4, 0xc4, 0x19, 0x21, 0xe1,
+	0x61, 0x24, 0x6b, 0x42, 0x6c, 0x57, 0x95, 0x25, 0xc3, 0x75, 0x0d, 0x0b, 0x23, 0xaf, 0x7a, 0x70,
+	0x51, 0x41, 0xdb, 0xe3, 0x9d, 0xc8, 0x48, 0x59, 0x4e, 0x09, 0xd8, 0x8b, 0xa1, 0x7e, 0x92, 0x60,
+	0xe9, 0x84, 0x51, 0xc7, 0xa7, 0x0d, 0x6e, 0xba, 0x0e, 0xb5, 0x8e, 0xc3, 0x64, 0x87, 0x1e, 0x32,
+	0x1a, 0xea, 0x64, 0x05, 0x66, 0xdd, 0x58, 0x39, 0xe7, 0x1d, 0x0f, 0x65, 0xa9, 0x24, 0x95, 0x8b,
+	0xfa, 0x4c, 0x72, 0x7a, 0xd2, 0xf1, 0x90, 0x6c, 0xc1, 0x24, 0xc3, 0xab, 0x00, 0x7d, 0x2e, 0x67,
+	0x4a, 0x52, 0x79, 0x6a, 0xbd, 0xa4, 0x0d, 0x03, 0xd5, 0x44, 0x6c, 0x3d, 0xb2, 0xd3, 0x63, 0x07,
+	0xf5, 0xab, 0x04, 0xff, 0xd6, 0xae, 0xb1, 0x11, 0x70, 0x14, 0x06, 0x7d, 0x70, 0x6a, 0x4e, 0x1b,
+	0x2d, 0xd7, 0x43, 0xb2, 0x0c, 0xe0, 0x73, 0x97, 0xe1, 0xb9, 0x43, 0xed, 0x18, 0x42, 0x51, 0x9c,
+	0xbc, 0xa3, 0x36, 0x92, 0x43, 0x80, 0x04, 0x8f, 0x2f, 0x67, 0x4a, 0xd9, 0xf2, 0xd4, 0x7a, 0x25,
+	0x0d, 0xc1, 0x2d, 0xa5, 0xea, 0x7d, 0x21, 0xd4, 0xef, 0x12, 0x90, 0xf7, 0x01, 0xb2, 0x8e, 0xb0,
+	0x19, 0x17, 0xc6, 0x3c, 0xe4, 0xaf, 0x42, 0x27, 0x71, 0x07, 0x45, 0x3d, 0x52, 0xc8, 0x11, 0x14,
+	0x6c, 0xe4, 0xb4, 0x49, 0x39, 0x95, 0xb3, 0x02, 0xda, 0xb3, 0x34, 0x68, 0xc3, 0xe9, 0xb4, 0xb7,
+	0x5d, 0xb7, 0x9a, 0xc3, 0x59, 0x47, 0x4f, 0xa2, 0x28, 0xaf, 0x60, 0x66, 0xe0, 0x2f, 0xf2, 0x07,
+	0x64, 0x5b, 0xd8, 0xe9, 0x02, 0x0a, 0xc5, 0x10, 0x4a, 0x9b, 0x5a, 0x01, 0xc6, 0x50, 0x84, 0xb2,
+	0x95, 0xd9, 0x94, 0xd4, 0x37, 0x30, 0xdb, 0x4b, 0xf5, 0x9a, 0xa3, 0x9d, 0xe2, 0x4d, 0x20, 0x27,
+	0xe0, 0x86, 0xce, 0xd3, 0xba, 0x90, 0xc3, 0x33, 0xe4, 0xd4, 0x90, 0xb3, 0xc2, 0x4c, 0xc8, 0xaa,
+	0x07, 0x4a, 0x2f, 0x96, 0x8e, 0xbe, 0xe7, 0x3a, 0x7e, 0xef, 0xb6, 0xb6, 0xc3, 0xa6, 0xf0, 0x03,
+	0x8b, 0xfb, 0xb2, 0x24, 0xea, 0x56, 0x6f, 0xaf, 0x3b, 0x04, 0xa3, 0xc7, 0x2e, 0x61, 0x05, 0xdc,
+	0x6d, 0xa1, 0x13, 0x57, 0x20, 0x14, 0xf5, 0xa7, 0x04, 0xf3, 0x07, 0xc8, 0x77, 0x03, 0xab, 0x75,
+	0x2f, 0x6a, 0x08, 0xe4, 0x5a, 0xd8, 0x89, 0x7a, 0xa3, 0xa8, 0x0b, 0x99, 0x94, 0x60, 0xca, 0xa3,
+	0x8c, 0x5a, 0x16, 0x5a, 0xa6, 0x6f, 0x8b, 0xc2, 0xf2, 0x7a, 0xff, 0x11, 0xd1, 0xfb, 0xa8, 0xcb,
+	0x89, 0x12, 0x9e, 0xa7, 0x95, 0x90, 0x06, 0xe8, 0x71, 0xc8, 0x3b, 0x87, 0x99, 0x24, 0xd3, 0xc3,
+	0xb8, 0x0b, 0x93, 0x20, 0x63, 0x2e, 0x93, 0x73, 0x51, 0x12, 0xa1, 0xa8, 0x67, 0xf0, 0x77, 0x7f,
+	0x35, 0x43, 0x9c, 0xbe, 0x80, 0xbc, 0xc9, 0xd1, 0x8e, 0x19, 0xfd, 0x2f, 0xed, 0x3a, 0x06, 0x10,
+	0xea, 0x91, 0xbd, 0xfa, 0x43, 0x82, 0x85, 0xe3, 0xa0, 0xee, 0x37, 0x98, 0x59, 0xc7, 0x07, 0x53,
+	0xa7, 0x40, 0xc1, 0x63, 0x78, 0x61, 0x5e, 0xa3, 0x2f, 0x66, 0xaa, 0xa8, 0x27, 0x3a, 0x39, 0x19,
+	0x22, 0x6d, 0x33, 0x75, 0x19, 0xa5, 0x82, 0x79, 0x1c, 0xda, 0x4c, 0xf8, 0x53, 0x64, 0xd9, 0xbb,
+	0xa4, 0x8e, 0xd1, 0x2b, 0xfc, 0xf7, 0xc9, 0x93, 0x61, 0xb2, 0x89, 0x16, 0x72, 0x6c, 0x0a, 0xfa,
+	0x0a, 0x7a, 0xac, 0xaa, 0x6d, 0x58, 0xdc, 0x17, 0xe2, 0xbd, 0x47, 0x64, 0x1b, 0x0a, 0xdd, 0x95,
+	0x1c, 0xaf, 0xd0, 0xbb, 0x97, 0x78, 0xe2, 0xa1, 0x9e, 0xc2, 0x72, 0x94, 0x57, 0xfc, 0x7f, 0x66,
+	0xf2, 0xcb, 0x23, 0x41, 0xc8, 0xb8, 0xd9, 0x17, 0x60, 0x22, 0x62, 0xb0, 0x7b, 0x7b, 0x5d, 0x4d,
+	0xdd, 0x81, 0x95, 0xd4, 0xb8, 0x43, 0x9d, 0xd9, 0x77, 0x25, 0x92, 0x98, 0xe4, 0x58, 0x5d, 0xff,
+	0x92, 0x87, 0xe2, 0x3e, 0xf5, 0x98, 0x88, 0x40, 0x2e, 0x60, 0x71, 0xc4, 0x6b, 0x43, 0x36, 0xd2,
+	0xea, 0xbd, 0xe3, 0x69, 0x52, 0x16, 0xb4, 0xe8, 0xf9, 0xd5, 0xe2, 0xe7, 0x57, 0xab, 0x85, 0xcf,
+	0x2f, 0x69, 0x02, 0xf4, 0x56, 0x1b, 0x59, 0x1d, 0x6f, 0xe5, 0x2b, 0xda, 0xed, 0x76, 0x43, 0x55,
+	0x7f, 0x84, 0xe9, 0xfe, 0x79, 0x25, 0xe5, 0x71, 0xf7, 0x93, 0xb2, 0x76, 0x97, 0xe5, 0x50, 0x2e,
+	0x03, 0x66, 0x07, 0x87, 0x86, 0x3c, 0x19, 0x7f, 0xb0, 0x94, 0xff, 0x47, 0x36, 0xd3, 0xe0, 0x54,
+	0xac, 0x49, 0xe4, 0x14, 0xe6, 0x6e, 0xf4, 0x30, 0x79, 0x9a, 0xe6, 0x3d, 0xa2, 0xd1, 0x47, 0x52,
+	0xf2, 0x59, 0x82, 0xbf, 0x52, 0x9b, 0x89, 0x54, 0x47, 0x87, 0x1f, 0xd1, 0xcf, 0xca, 0xcb, 0xb1,
+	0x5d, 0x6e, 0x5e, 0xe4, 0x6e, 0x03, 0xc0, 0x4c, 0x7c, 0x76, 0xe7, 0x92, 0xde, 0x3c, 0x0a, 0x63,
+	0xf9, 0x1f, 0x56, 0x0d, 0x93, 0x5f, 0x06, 0x75, 0xad, 0xe1, 0xda, 0xd1, 0x27, 0x9b, 0xf8, 0xf1,
+	0x5a, 0xc6, 0xe0, 0x67, 0xdc, 0xb7, 0xcc, 0x52, 0xe8, 0xa9, 0xed, 0x59, 0x26, 0x3a, 0x5c, 0xdb,
+	0x09, 0xb8, 0x6b, 0xa0, 0xa3, 0x1d, 0x30, 0xaf, 0xa1, 0xb5, 0xab, 0xf5, 0x09, 0x61, 0xbc, 0xf1,
+	0x6b, 0x00, 0x6c, 0x96, 0xc6, 0x26, 0x52, 0x0a, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprStateClient is the client API for DaprState service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprStateClient interface {
+	ExecuteStateTransaction(ctx context.Context, in *ExecuteStateTransactionEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	QueryState(ctx context.Context, in *QueryStateEnvelope, opts ...grpc.CallOption) (*QueryStateResponseEnvelope, error)
+	GetBulkState(ctx context.Context, in *GetBulkStateEnvelope, opts ...grpc.CallOption) (*GetBulkStateResponseEnvelope, error)
+	SubscribeState(ctx context.Context, in *SubscribeStateEnvelope, opts ...grpc.CallOption) (DaprState_SubscribeStateClient, error)
+	DeleteBulkState(ctx context.Context, in *DeleteBulkStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteStateWithPrefix(ctx context.Context, in *DeleteStateWithPrefixEnvelope, opts ...grpc.CallOption) (*DeleteStateWithPrefixResponseEnvelope, error)
+}
+
+type daprStateClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprStateClient(cc *grpc.ClientConn) DaprStateClient {
+	return &daprStateClient{cc}
+}
+
+func (c *daprStateClient) ExecuteStateTransaction(ctx context.Context, in *ExecuteStateTransactionEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprState/ExecuteStateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprStateClient) QueryState(ctx context.Context, in *QueryStateEnvelope, opts ...grpc.CallOption) (*QueryStateResponseEnvelope, error) {
+	out := new(QueryStateResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprState/QueryState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprStateClient) GetBulkState(ctx context.Context, in *GetBulkStateEnvelope, opts ...grpc.CallOption) (*GetBulkStateResponseEnvelope, error) {
+	out := new(GetBulkStateResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprState/GetBulkState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprStateClient) SubscribeState(ctx context.Context, in *SubscribeStateEnvelope, opts ...grpc.CallOption) (DaprState_SubscribeStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DaprState_serviceDesc.Streams[0], "/dapr.proto.dapr.v1.DaprState/SubscribeState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprStateSubscribeStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DaprState_SubscribeStateClient interface {
+	Recv() (*StateChangeEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprStateSubscribeStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprStateSubscribeStateClient) Recv() (*StateChangeEnvelope, error) {
+	m := new(StateChangeEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daprStateClient) DeleteBulkState(ctx context.Context, in *DeleteBulkStateEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprState/DeleteBulkState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprStateClient) DeleteStateWithPrefix(ctx context.Context, in *DeleteStateWithPrefixEnvelope, opts ...grpc.CallOption) (*DeleteStateWithPrefixResponseEnvelope, error) {
+	out := new(DeleteStateWithPrefixResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprState/DeleteStateWithPrefix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaprStateServer is the server API for DaprState service.
+type DaprStateServer interface {
+	ExecuteStateTransaction(context.Context, *ExecuteStateTransactionEnvelope) (*empty.Empty, error)
+	QueryState(context.Context, *QueryStateEnvelope) (*QueryStateResponseEnvelope, error)
+	GetBulkState(context.Context, *GetBulkStateEnvelope) (*GetBulkStateResponseEnvelope, error)
+	SubscribeState(*SubscribeStateEnvelope, DaprState_SubscribeStateServer) error
+	DeleteBulkState(context.Context, *DeleteBulkStateEnvelope) (*empty.Empty, error)
+	DeleteStateWithPrefix(context.Context, *DeleteStateWithPrefixEnvelope) (*DeleteStateWithPrefixResponseEnvelope, error)
+}
+
+// UnimplementedDaprStateServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprStateServer struct {
+}
+
+func (*UnimplementedDaprStateServer) ExecuteStateTransaction(ctx context.Context, req *ExecuteStateTransactionEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteStateTransaction not implemented")
+}
+func (*UnimplementedDaprStateServer) QueryState(ctx context.Context, req *QueryStateEnvelope) (*QueryStateResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryState not implemented")
+}
+func (*UnimplementedDaprStateServer) GetBulkState(ctx context.Context, req *GetBulkStateEnvelope) (*GetBulkStateResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBulkState not implemented")
+}
+func (*UnimplementedDaprStateServer) SubscribeState(req *SubscribeStateEnvelope, srv DaprState_SubscribeStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeState not implemented")
+}
+func (*UnimplementedDaprStateServer) DeleteBulkState(ctx context.Context, req *DeleteBulkStateEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBulkState not implemented")
+}
+func (*UnimplementedDaprStateServer) DeleteStateWithPrefix(ctx context.Context, req *DeleteStateWithPrefixEnvelope) (*DeleteStateWithPrefixResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStateWithPrefix not implemented")
+}
+
+func RegisterDaprStateServer(s *grpc.Server, srv DaprStateServer) {
+	s.RegisterService(&_DaprState_serviceDesc, srv)
+}
+
+func _DaprState_ExecuteStateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteStateTransactionEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprStateServer).ExecuteStateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprState/ExecuteStateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprStateServer).ExecuteStateTransaction(ctx, req.(*ExecuteStateTransactionEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprState_QueryState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStateEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprStateServer).QueryState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprState/QueryState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprStateServer).QueryState(ctx, req.(*QueryStateEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprState_GetBulkState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBulkStateEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprStateServer).GetBulkState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprState/GetBulkState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprStateServer).GetBulkState(ctx, req.(*GetBulkStateEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprState_SubscribeState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeStateEnvelope)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaprStateServer).SubscribeState(m, &daprStateSubscribeStateServer{stream})
+}
+
+type DaprState_SubscribeStateServer interface {
+	Send(*StateChangeEnvelope) error
+	grpc.ServerStream
+}
+
+type daprStateSubscribeStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprStateSubscribeStateServer) Send(m *StateChangeEnvelope) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DaprState_DeleteBulkState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBulkStateEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprStateServer).DeleteBulkState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprState/DeleteBulkState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprStateServer).DeleteBulkState(ctx, req.(*DeleteBulkStateEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprState_DeleteStateWithPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStateWithPrefixEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprStateServer).DeleteStateWithPrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprState/DeleteStateWithPrefix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprStateServer).DeleteStateWithPrefix(ctx, req.(*DeleteStateWithPrefixEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DaprState_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprState",
+	HandlerType: (*DaprStateServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ExecuteStateTransaction",
+			Handler:    _DaprState_ExecuteStateTransaction_Handler,
+		},
+		{
+			MethodName: "QueryState",
+			Handler:    _DaprState_QueryState_Handler,
+		},
+		{
+			MethodName: "GetBulkState",
+			Handler:    _DaprState_GetBulkState_Handler,
+		},
+		{
+			MethodName: "DeleteBulkState",
+			Handler:    _DaprState_DeleteBulkState_Handler,
+		},
+		{
+			MethodName: "DeleteStateWithPrefix",
+			Handler:    _DaprState_DeleteStateWithPrefix_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeState",
+			Handler:       _DaprState_SubscribeState_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/dapr/v1/state.proto",
+}
@@ -0,0 +1,157 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/streaming.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	v1 "github.com/dapr/dapr/pkg/proto/daprclient/v1"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("dapr/proto/dapr/v1/streaming.proto", fileDescriptor_51842f160c0f6237)
+}
+
+var fileDescriptor_51842f160c0f6237 = []byte{
+	// 222 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x4a, 0x49, 0x2c, 0x28,
+	0xd2, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0xd7, 0x07, 0x33, 0xcb, 0x0c, 0xf5, 0x8b, 0x4b, 0x8a, 0x52,
+	0x13, 0x73, 0x33, 0xf3, 0xd2, 0xf5, 0xc0, 0xe2, 0x42, 0x42, 0x20, 0x09, 0x08, 0x5b, 0x0f, 0xcc,
+	0x2c, 0x33, 0x94, 0xd2, 0x44, 0xd3, 0x97, 0x9c, 0x93, 0x99, 0x9a, 0x57, 0x02, 0xd2, 0x8d, 0xe0,
+	0x41, 0xb4, 0x18, 0xf5, 0x30, 0x72, 0xf1, 0xba, 0x24, 0x16, 0x14, 0x05, 0xc3, 0x8c, 0x15, 0xaa,
+	0xe6, 0x12, 0x09, 0x2e, 0x4d, 0x2a, 0x4e, 0x2e, 0xca, 0x4c, 0x4a, 0x0d, 0xc9, 0x2f, 0xc8, 0x4c,
+	0x76, 0x2d, 0x4b, 0xcd, 0x2b, 0x29, 0x16, 0x32, 0xd6, 0x43, 0xb3, 0x09, 0x6a, 0x4e, 0x99, 0xa1,
+	0x1e, 0x58, 0x19, 0x54, 0x53, 0x41, 0x49, 0x66, 0x7e, 0x9e, 0x6b, 0x5e, 0x59, 0x6a, 0x4e, 0x7e,
+	0x41, 0xaa, 0x94, 0x0e, 0x6e, 0x4d, 0xce, 0x39, 0xf9, 0xa5, 0x29, 0x60, 0xb3, 0x61, 0xaa, 0x0d,
+	0x18, 0x9d, 0xd2, 0xb9, 0xb8, 0x32, 0xe1, 0xfe, 0x70, 0x12, 0x46, 0x71, 0x59, 0x00, 0xc8, 0x90,
+	0xe2, 0x28, 0xb5, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24, 0xbd, 0xe4, 0xfc, 0x5c, 0x48, 0xa0, 0x80,
+	0x89, 0x82, 0xec, 0x74, 0xd4, 0x80, 0x5a, 0xc5, 0x24, 0x0d, 0xd2, 0xad, 0xe7, 0x0c, 0xb1, 0xd0,
+	0xb1, 0xb4, 0x24, 0x3f, 0x3d, 0x35, 0x4f, 0xcf, 0xbd, 0xa8, 0x20, 0x59, 0xaf, 0xcc, 0x30, 0x89,
+	0x0d, 0xac, 0xd8, 0x18, 0x30, 0x00, 0xf4, 0xf1, 0xa5, 0x80, 0x63, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprStreamingClient is the client API for DaprStreaming service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprStreamingClient interface {
+	// Subscribes to a topic and streams its events until the client disconnects.
+	SubscribeTopicEvents(ctx context.Context, in *v1.TopicSubscriptionEnvelope, opts ...grpc.CallOption) (DaprStreaming_SubscribeTopicEventsClient, error)
+}
+
+type daprStreamingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprStreamingClient(cc *grpc.ClientConn) DaprStreamingClient {
+	return &daprStreamingClient{cc}
+}
+
+func (c *daprStreamingClient) SubscribeTopicEvents(ctx context.Context, in *v1.TopicSubscriptionEnvelope, opts ...grpc.CallOption) (DaprStreaming_SubscribeTopicEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DaprStreaming_serviceDesc.Streams[0], "/dapr.proto.dapr.v1.DaprStreaming/SubscribeTopicEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprStreamingSubscribeTopicEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DaprStreaming_SubscribeTopicEventsClient interface {
+	Recv() (*v1.CloudEventEnvelope, error)
+	grpc.ClientStream
+}
+
+type daprStreamingSubscribeTopicEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprStreamingSubscribeTopicEventsClient) Recv() (*v1.CloudEventEnvelope, error) {
+	m := new(v1.CloudEventEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DaprStreamingServer is the server API for DaprStreaming service.
+type DaprStreamingServer interface {
+	// Subscribes to a topic and streams its events until the client disconnects.
+	SubscribeTopicEvents(*v1.TopicSubscriptionEnvelope, DaprStreaming_SubscribeTopicEventsServer) error
+}
+
+// UnimplementedDaprStreamingServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprStreamingServer struct {
+}
+
+func (*UnimplementedDaprStreamingServer) SubscribeTopicEvents(req *v1.TopicSubscriptionEnvelope, srv DaprStreaming_SubscribeTopicEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTopicEvents not implemented")
+}
+
+func RegisterDaprStreamingServer(s *grpc.Server, srv DaprStreamingServer) {
+	s.RegisterService(&_DaprStreaming_serviceDesc, srv)
+}
+
+func _DaprStreaming_SubscribeTopicEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(v1.TopicSubscriptionEnvelope)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaprStreamingServer).SubscribeTopicEvents(m, &daprStreamingSubscribeTopicEventsServer{stream})
+}
+
+type DaprStreaming_SubscribeTopicEventsServer interface {
+	Send(*v1.CloudEventEnvelope) error
+	grpc.ServerStream
+}
+
+type daprStreamingSubscribeTopicEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprStreamingSubscribeTopicEventsServer) Send(m *v1.CloudEventEnvelope) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _DaprStreaming_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprStreaming",
+	HandlerType: (*DaprStreamingServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTopicEvents",
+			Handler:       _DaprStreaming_SubscribeTopicEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/dapr/v1/streaming.proto",
+}
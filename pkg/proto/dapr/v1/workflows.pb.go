@@ -0,0 +1,534 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/dapr/v1/workflows.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// StartWorkflowEnvelope is the request of StartWorkflow. The input must be JSON.
+type StartWorkflowEnvelope struct {
+	WorkflowName         string   `protobuf:"bytes,1,opt,name=workflow_name,json=workflowName,proto3" json:"workflow_name,omitempty"`
+	InstanceId           string   `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Input                []byte   `protobuf:"bytes,3,opt,name=input,proto3" json:"input,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartWorkflowEnvelope) Reset()         { *m = StartWorkflowEnvelope{} }
+func (m *StartWorkflowEnvelope) String() string { return proto.CompactTextString(m) }
+func (*StartWorkflowEnvelope) ProtoMessage()    {}
+func (*StartWorkflowEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ebcfd66d1e78018, []int{0}
+}
+
+func (m *StartWorkflowEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartWorkflowEnvelope.Unmarshal(m, b)
+}
+func (m *StartWorkflowEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartWorkflowEnvelope.Marshal(b, m, deterministic)
+}
+func (m *StartWorkflowEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartWorkflowEnvelope.Merge(m, src)
+}
+func (m *StartWorkflowEnvelope) XXX_Size() int {
+	return xxx_messageInfo_StartWorkflowEnvelope.Size(m)
+}
+func (m *StartWorkflowEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartWorkflowEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartWorkflowEnvelope proto.InternalMessageInfo
+
+func (m *StartWorkflowEnvelope) GetWorkflowName() string {
+	if m != nil {
+		return m.WorkflowName
+	}
+	return ""
+}
+
+func (m *StartWorkflowEnvelope) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *StartWorkflowEnvelope) GetInput() []byte {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+// WorkflowInstanceEnvelope names a workflow instance
+type WorkflowInstanceEnvelope struct {
+	WorkflowName         string   `protobuf:"bytes,1,opt,name=workflow_name,json=workflowName,proto3" json:"workflow_name,omitempty"`
+	InstanceId           string   `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkflowInstanceEnvelope) Reset()         { *m = WorkflowInstanceEnvelope{} }
+func (m *WorkflowInstanceEnvelope) String() string { return proto.CompactTextString(m) }
+func (*WorkflowInstanceEnvelope) ProtoMessage()    {}
+func (*WorkflowInstanceEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ebcfd66d1e78018, []int{1}
+}
+
+func (m *WorkflowInstanceEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkflowInstanceEnvelope.Unmarshal(m, b)
+}
+func (m *WorkflowInstanceEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkflowInstanceEnvelope.Marshal(b, m, deterministic)
+}
+func (m *WorkflowInstanceEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowInstanceEnvelope.Merge(m, src)
+}
+func (m *WorkflowInstanceEnvelope) XXX_Size() int {
+	return xxx_messageInfo_WorkflowInstanceEnvelope.Size(m)
+}
+func (m *WorkflowInstanceEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowInstanceEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowInstanceEnvelope proto.InternalMessageInfo
+
+func (m *WorkflowInstanceEnvelope) GetWorkflowName() string {
+	if m != nil {
+		return m.WorkflowName
+	}
+	return ""
+}
+
+func (m *WorkflowInstanceEnvelope) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+// GetWorkflowResponseEnvelope is a workflow instance
+type GetWorkflowResponseEnvelope struct {
+	InstanceId    string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	WorkflowName  string `protobuf:"bytes,2,opt,name=workflow_name,json=workflowName,proto3" json:"workflow_name,omitempty"`
+	RuntimeStatus string `protobuf:"bytes,3,opt,name=runtime_status,json=runtimeStatus,proto3" json:"runtime_status,omitempty"`
+	Input         []byte `protobuf:"bytes,4,opt,name=input,proto3" json:"input,omitempty"`
+	Output        []byte `protobuf:"bytes,5,opt,name=output,proto3" json:"output,omitempty"`
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	CustomStatus  []byte `protobuf:"bytes,7,opt,name=custom_status,json=customStatus,proto3" json:"custom_status,omitempty"`
+	// created_at and last_updated_at are RFC3339 times
+	CreatedAt            string   `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUpdatedAt        string   `protobuf:"bytes,9,opt,name=last_updated_at,json=lastUpdatedAt,proto3" json:"last_updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWorkflowResponseEnvelope) Reset()         { *m = GetWorkflowResponseEnvelope{} }
+func (m *GetWorkflowResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetWorkflowResponseEnvelope) ProtoMessage()    {}
+func (*GetWorkflowResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ebcfd66d1e78018, []int{2}
+}
+
+func (m *GetWorkflowResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWorkflowResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetWorkflowResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWorkflowResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetWorkflowResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWorkflowResponseEnvelope.Merge(m, src)
+}
+func (m *GetWorkflowResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetWorkflowResponseEnvelope.Size(m)
+}
+func (m *GetWorkflowResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWorkflowResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWorkflowResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetWorkflowResponseEnvelope) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *GetWorkflowResponseEnvelope) GetWorkflowName() string {
+	if m != nil {
+		return m.WorkflowName
+	}
+	return ""
+}
+
+func (m *GetWorkflowResponseEnvelope) GetRuntimeStatus() string {
+	if m != nil {
+		return m.RuntimeStatus
+	}
+	return ""
+}
+
+func (m *GetWorkflowResponseEnvelope) GetInput() []byte {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (m *GetWorkflowResponseEnvelope) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *GetWorkflowResponseEnvelope) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *GetWorkflowResponseEnvelope) GetCustomStatus() []byte {
+	if m != nil {
+		return m.CustomStatus
+	}
+	return nil
+}
+
+func (m *GetWorkflowResponseEnvelope) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *GetWorkflowResponseEnvelope) GetLastUpdatedAt() string {
+	if m != nil {
+		return m.LastUpdatedAt
+	}
+	return ""
+}
+
+// RaiseEventWorkflowEnvelope is the request of RaiseEventWorkflow. The event data must be JSON.
+type RaiseEventWorkflowEnvelope struct {
+	WorkflowName         string   `protobuf:"bytes,1,opt,name=workflow_name,json=workflowName,proto3" json:"workflow_name,omitempty"`
+	InstanceId           string   `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	EventName            string   `protobuf:"bytes,3,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	EventData            []byte   `protobuf:"bytes,4,opt,name=event_data,json=eventData,proto3" json:"event_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaiseEventWorkflowEnvelope) Reset()         { *m = RaiseEventWorkflowEnvelope{} }
+func (m *RaiseEventWorkflowEnvelope) String() string { return proto.CompactTextString(m) }
+func (*RaiseEventWorkflowEnvelope) ProtoMessage()    {}
+func (*RaiseEventWorkflowEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ebcfd66d1e78018, []int{3}
+}
+
+func (m *RaiseEventWorkflowEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaiseEventWorkflowEnvelope.Unmarshal(m, b)
+}
+func (m *RaiseEventWorkflowEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaiseEventWorkflowEnvelope.Marshal(b, m, deterministic)
+}
+func (m *RaiseEventWorkflowEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaiseEventWorkflowEnvelope.Merge(m, src)
+}
+func (m *RaiseEventWorkflowEnvelope) XXX_Size() int {
+	return xxx_messageInfo_RaiseEventWorkflowEnvelope.Size(m)
+}
+func (m *RaiseEventWorkflowEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaiseEventWorkflowEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaiseEventWorkflowEnvelope proto.InternalMessageInfo
+
+func (m *RaiseEventWorkflowEnvelope) GetWorkflowName() string {
+	if m != nil {
+		return m.WorkflowName
+	}
+	return ""
+}
+
+func (m *RaiseEventWorkflowEnvelope) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *RaiseEventWorkflowEnvelope) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *RaiseEventWorkflowEnvelope) GetEventData() []byte {
+	if m != nil {
+		return m.EventData
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StartWorkflowEnvelope)(nil), "dapr.proto.dapr.v1.StartWorkflowEnvelope")
+	proto.RegisterType((*WorkflowInstanceEnvelope)(nil), "dapr.proto.dapr.v1.WorkflowInstanceEnvelope")
+	proto.RegisterType((*GetWorkflowResponseEnvelope)(nil), "dapr.proto.dapr.v1.GetWorkflowResponseEnvelope")
+	proto.RegisterType((*RaiseEventWorkflowEnvelope)(nil), "dapr.proto.dapr.v1.RaiseEventWorkflowEnvelope")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/dapr/v1/workflows.proto", fileDescriptor_9ebcfd66d1e78018)
+}
+
+var fileDescriptor_9ebcfd66d1e78018 = []byte{
+	// 507 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0xe1, 0x6a, 0x13, 0x41,
+	0x10, 0x26, 0xa9, 0x8d, 0x66, 0x9a, 0x28, 0xae, 0x5a, 0x8e, 0x84, 0x62, 0x89, 0x58, 0x2a, 0xc8,
+	0x86, 0xe8, 0x13, 0xa4, 0x36, 0x94, 0xfe, 0x11, 0x49, 0x15, 0x51, 0x84, 0xb8, 0xc9, 0x4d, 0xaf,
+	0x47, 0xef, 0x76, 0x97, 0xdd, 0xb9, 0x2b, 0xbe, 0x4d, 0x7f, 0xfb, 0x08, 0x3e, 0x9d, 0xdc, 0xee,
+	0x5d, 0xda, 0x98, 0x8b, 0xa0, 0xe8, 0x9f, 0x63, 0xf7, 0xdb, 0x6f, 0xbe, 0x99, 0x9d, 0xfd, 0x6e,
+	0x60, 0x10, 0x0a, 0x6d, 0x86, 0xda, 0x28, 0x52, 0x43, 0xb7, 0xcc, 0x47, 0xc3, 0x2b, 0x65, 0x2e,
+	0xcf, 0x13, 0x75, 0x65, 0xb9, 0xc3, 0x19, 0x2b, 0x0e, 0xfc, 0x9a, 0xbb, 0x65, 0x3e, 0xea, 0xf5,
+	0x23, 0xa5, 0xa2, 0x04, 0x7d, 0xe4, 0x3c, 0x3b, 0x1f, 0x62, 0xaa, 0xe9, 0x9b, 0x27, 0x0d, 0x2c,
+	0x3c, 0x39, 0x23, 0x61, 0xe8, 0x63, 0x29, 0x34, 0x91, 0x39, 0x26, 0x4a, 0x23, 0x7b, 0x06, 0xdd,
+	0x4a, 0x7c, 0x26, 0x45, 0x8a, 0x41, 0x63, 0xbf, 0x71, 0xd8, 0x9e, 0x76, 0x2a, 0xf0, 0xad, 0x48,
+	0x91, 0x3d, 0x85, 0x9d, 0x58, 0x5a, 0x12, 0x72, 0x81, 0xb3, 0x38, 0x0c, 0x9a, 0x8e, 0x02, 0x15,
+	0x74, 0x1a, 0xb2, 0xc7, 0xb0, 0x1d, 0x4b, 0x9d, 0x51, 0xb0, 0xb5, 0xdf, 0x38, 0xec, 0x4c, 0xfd,
+	0x66, 0xf0, 0x15, 0x82, 0x2a, 0xdf, 0x69, 0xc9, 0xfd, 0xb7, 0x79, 0x07, 0x3f, 0x9a, 0xd0, 0x3f,
+	0xc1, 0xe5, 0xad, 0xa6, 0x68, 0xb5, 0x92, 0xf6, 0x26, 0xcb, 0x2f, 0x02, 0x8d, 0xb5, 0xc2, 0xd7,
+	0xca, 0x68, 0xd6, 0x94, 0xf1, 0x1c, 0xee, 0x9b, 0x4c, 0x52, 0x9c, 0xe2, 0xcc, 0x92, 0xa0, 0xcc,
+	0xba, 0x6b, 0xb6, 0xa7, 0xdd, 0x12, 0x3d, 0x73, 0xe0, 0x4d, 0x13, 0xee, 0xdc, 0x6a, 0x02, 0xdb,
+	0x85, 0x96, 0xca, 0xa8, 0x80, 0xb7, 0x1d, 0x5c, 0xee, 0x0a, 0x36, 0x1a, 0xa3, 0x4c, 0xd0, 0x72,
+	0x5a, 0x7e, 0x53, 0xd4, 0xb3, 0xc8, 0x2c, 0xa9, 0xb4, 0xca, 0x74, 0xd7, 0x05, 0x75, 0x3c, 0x58,
+	0x26, 0xda, 0x03, 0x58, 0x18, 0x14, 0x84, 0xe1, 0x4c, 0x50, 0x70, 0xcf, 0xc5, 0xb7, 0x4b, 0x64,
+	0x4c, 0xec, 0x00, 0x1e, 0x24, 0xc2, 0xd2, 0x2c, 0xd3, 0x61, 0xc5, 0x69, 0xfb, 0x7a, 0x0b, 0xf8,
+	0x83, 0x47, 0xc7, 0x34, 0xb8, 0x6e, 0x40, 0x6f, 0x2a, 0x62, 0x8b, 0x93, 0x1c, 0xe5, 0xff, 0x72,
+	0xc6, 0x1e, 0x00, 0x16, 0xf2, 0x5e, 0xc2, 0xf7, 0xad, 0xed, 0x10, 0x17, 0xbf, 0x3c, 0x0e, 0x05,
+	0x89, 0xb2, 0x71, 0xfe, 0xf8, 0x58, 0x90, 0x78, 0x75, 0xbd, 0x05, 0xdd, 0x63, 0xa1, 0x4d, 0x55,
+	0x9c, 0x65, 0x17, 0xd0, 0x5d, 0x31, 0x32, 0x7b, 0xc1, 0xd7, 0xff, 0x05, 0x5e, 0xeb, 0xf5, 0xde,
+	0xcb, 0x3a, 0xea, 0x46, 0x87, 0x26, 0xb0, 0x73, 0xcb, 0x5a, 0xec, 0x8f, 0x82, 0x7b, 0xc3, 0x3a,
+	0xf6, 0xef, 0x9c, 0xfa, 0x09, 0x1e, 0xbe, 0x47, 0x93, 0xc6, 0x52, 0x10, 0xfe, 0x65, 0xce, 0x5d,
+	0xee, 0x27, 0x00, 0xaf, 0x26, 0x00, 0x9f, 0x14, 0x13, 0x80, 0x7d, 0x01, 0xb6, 0xfe, 0xcc, 0x8c,
+	0xd7, 0x69, 0x6f, 0xb6, 0xc3, 0x26, 0xf5, 0xa3, 0x08, 0x20, 0x5e, 0x0a, 0x1c, 0x3d, 0x5a, 0x79,
+	0xad, 0x77, 0x05, 0xd5, 0x7e, 0x3e, 0x88, 0x62, 0xba, 0xc8, 0xe6, 0x7c, 0xa1, 0x52, 0x3f, 0xd1,
+	0xdc, 0x47, 0x5f, 0x46, 0xab, 0x53, 0xee, 0x7b, 0xb3, 0x5f, 0x44, 0xf3, 0x37, 0x49, 0x8c, 0x92,
+	0xf8, 0x38, 0x23, 0x15, 0xa1, 0xe4, 0x27, 0x46, 0x2f, 0x78, 0x3e, 0x9a, 0xb7, 0x1c, 0xf9, 0xf5,
+	0xcf, 0x01, 0x00, 0x57, 0x7c, 0x9c, 0x2b, 0x20, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprWorkflowsClient is the client API for DaprWorkflows service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprWorkflowsClient interface {
+	StartWorkflow(ctx context.Context, in *StartWorkflowEnvelope, opts ...grpc.CallOption) (*WorkflowInstanceEnvelope, error)
+	GetWorkflow(ctx context.Context, in *WorkflowInstanceEnvelope, opts ...grpc.CallOption) (*GetWorkflowResponseEnvelope, error)
+	TerminateWorkflow(ctx context.Context, in *WorkflowInstanceEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	RaiseEventWorkflow(ctx context.Context, in *RaiseEventWorkflowEnvelope, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type daprWorkflowsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprWorkflowsClient(cc *grpc.ClientConn) DaprWorkflowsClient {
+	return &daprWorkflowsClient{cc}
+}
+
+func (c *daprWorkflowsClient) StartWorkflow(ctx context.Context, in *StartWorkflowEnvelope, opts ...grpc.CallOption) (*WorkflowInstanceEnvelope, error) {
+	out := new(WorkflowInstanceEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprWorkflows/StartWorkflow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprWorkflowsClient) GetWorkflow(ctx context.Context, in *WorkflowInstanceEnvelope, opts ...grpc.CallOption) (*GetWorkflowResponseEnvelope, error) {
+	out := new(GetWorkflowResponseEnvelope)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprWorkflows/GetWorkflow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprWorkflowsClient) TerminateWorkflow(ctx context.Context, in *WorkflowInstanceEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprWorkflows/TerminateWorkflow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daprWorkflowsClient) RaiseEventWorkflow(ctx context.Context, in *RaiseEventWorkflowEnvelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.dapr.v1.DaprWorkflows/RaiseEventWorkflow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaprWorkflowsServer is the server API for DaprWorkflows service.
+type DaprWorkflowsServer interface {
+	StartWorkflow(context.Context, *StartWorkflowEnvelope) (*WorkflowInstanceEnvelope, error)
+	GetWorkflow(context.Context, *WorkflowInstanceEnvelope) (*GetWorkflowResponseEnvelope, error)
+	TerminateWorkflow(context.Context, *WorkflowInstanceEnvelope) (*empty.Empty, error)
+	RaiseEventWorkflow(context.Context, *RaiseEventWorkflowEnvelope) (*empty.Empty, error)
+}
+
+// UnimplementedDaprWorkflowsServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprWorkflowsServer struct {
+}
+
+func (*UnimplementedDaprWorkflowsServer) StartWorkflow(ctx context.Context, req *StartWorkflowEnvelope) (*WorkflowInstanceEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartWorkflow not implemented")
+}
+func (*UnimplementedDaprWorkflowsServer) GetWorkflow(ctx context.Context, req *WorkflowInstanceEnvelope) (*GetWorkflowResponseEnvelope, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflow not implemented")
+}
+func (*UnimplementedDaprWorkflowsServer) TerminateWorkflow(ctx context.Context, req *WorkflowInstanceEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateWorkflow not implemented")
+}
+func (*UnimplementedDaprWorkflowsServer) RaiseEventWorkflow(ctx context.Context, req *RaiseEventWorkflowEnvelope) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RaiseEventWorkflow not implemented")
+}
+
+func RegisterDaprWorkflowsServer(s *grpc.Server, srv DaprWorkflowsServer) {
+	s.RegisterService(&_DaprWorkflows_serviceDesc, srv)
+}
+
+func _DaprWorkflows_StartWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartWorkflowEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprWorkflowsServer).StartWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprWorkflows/StartWorkflow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprWorkflowsServer).StartWorkflow(ctx, req.(*StartWorkflowEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprWorkflows_GetWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowInstanceEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprWorkflowsServer).GetWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprWorkflows/GetWorkflow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprWorkflowsServer).GetWorkflow(ctx, req.(*WorkflowInstanceEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprWorkflows_TerminateWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowInstanceEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprWorkflowsServer).TerminateWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprWorkflows/TerminateWorkflow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprWorkflowsServer).TerminateWorkflow(ctx, req.(*WorkflowInstanceEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaprWorkflows_RaiseEventWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaiseEventWorkflowEnvelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaprWorkflowsServer).RaiseEventWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.dapr.v1.DaprWorkflows/RaiseEventWorkflow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaprWorkflowsServer).RaiseEventWorkflow(ctx, req.(*RaiseEventWorkflowEnvelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DaprWorkflows_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.dapr.v1.DaprWorkflows",
+	HandlerType: (*DaprWorkflowsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartWorkflow",
+			Handler:    _DaprWorkflows_StartWorkflow_Handler,
+		},
+		{
+			MethodName: "GetWorkflow",
+			Handler:    _DaprWorkflows_GetWorkflow_Handler,
+		},
+		{
+			MethodName: "TerminateWorkflow",
+			Handler:    _DaprWorkflows_TerminateWorkflow_Handler,
+		},
+		{
+			MethodName: "RaiseEventWorkflow",
+			Handler:    _DaprWorkflows_RaiseEventWorkflow_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/dapr/v1/workflows.proto",
+}
@@ -0,0 +1,151 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/daprinternal/v1/actorstreaming.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("dapr/proto/daprinternal/v1/actorstreaming.proto", fileDescriptor_8e58ace1432a2205)
+}
+
+var fileDescriptor_8e58ace1432a2205 = []byte{
+	// 172 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0x4f, 0x49, 0x2c, 0x28,
+	0xd2, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x07, 0x33, 0x33, 0xf3, 0x4a, 0x52, 0x8b, 0xf2, 0x12, 0x73,
+	0xf4, 0xcb, 0x0c, 0xf5, 0x13, 0x93, 0x4b, 0xf2, 0x8b, 0x8a, 0x4b, 0x8a, 0x52, 0x13, 0x73, 0x33,
+	0xf3, 0xd2, 0xf5, 0xc0, 0x8a, 0x84, 0xa4, 0x40, 0xaa, 0x20, 0x6c, 0x3d, 0x64, 0x0d, 0x7a, 0x65,
+	0x86, 0x52, 0xba, 0x78, 0x0c, 0x43, 0x51, 0x0b, 0x56, 0x62, 0x34, 0x81, 0x91, 0x4b, 0xc8, 0x25,
+	0xb1, 0xa0, 0xc8, 0x11, 0x64, 0x4f, 0x30, 0xcc, 0x1e, 0xa1, 0x2a, 0x2e, 0x7e, 0xe7, 0xc4, 0x9c,
+	0x1c, 0x24, 0x51, 0x21, 0x43, 0x3d, 0xdc, 0xb6, 0xea, 0x79, 0x42, 0xd9, 0x9e, 0x79, 0x65, 0xf9,
+	0xd9, 0xa9, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5, 0x25, 0x52, 0x46, 0xa4, 0x68, 0x29, 0x2e, 0xc8,
+	0xcf, 0x2b, 0x4e, 0x35, 0x60, 0x74, 0x32, 0x88, 0xd2, 0x4b, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2,
+	0x4b, 0xce, 0xcf, 0x85, 0x84, 0x0d, 0x98, 0x28, 0xc8, 0x4e, 0xc7, 0xee, 0xaf, 0x24, 0x36, 0xb0,
+	0xb0, 0x31, 0x60, 0x00, 0xfb, 0x35, 0x7f, 0x0d, 0x49, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaprActorStreamingClient is the client API for DaprActorStreaming service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaprActorStreamingClient interface {
+	CallActorStream(ctx context.Context, in *InternalInvokeRequest, opts ...grpc.CallOption) (DaprActorStreaming_CallActorStreamClient, error)
+}
+
+type daprActorStreamingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaprActorStreamingClient(cc *grpc.ClientConn) DaprActorStreamingClient {
+	return &daprActorStreamingClient{cc}
+}
+
+func (c *daprActorStreamingClient) CallActorStream(ctx context.Context, in *InternalInvokeRequest, opts ...grpc.CallOption) (DaprActorStreaming_CallActorStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DaprActorStreaming_serviceDesc.Streams[0], "/dapr.proto.daprinternal.v1.DaprActorStreaming/CallActorStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daprActorStreamingCallActorStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DaprActorStreaming_CallActorStreamClient interface {
+	Recv() (*InternalInvokeResponse, error)
+	grpc.ClientStream
+}
+
+type daprActorStreamingCallActorStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *daprActorStreamingCallActorStreamClient) Recv() (*InternalInvokeResponse, error) {
+	m := new(InternalInvokeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DaprActorStreamingServer is the server API for DaprActorStreaming service.
+type DaprActorStreamingServer interface {
+	CallActorStream(*InternalInvokeRequest, DaprActorStreaming_CallActorStreamServer) error
+}
+
+// UnimplementedDaprActorStreamingServer can be embedded to have forward compatible implementations.
+type UnimplementedDaprActorStreamingServer struct {
+}
+
+func (*UnimplementedDaprActorStreamingServer) CallActorStream(req *InternalInvokeRequest, srv DaprActorStreaming_CallActorStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method CallActorStream not implemented")
+}
+
+func RegisterDaprActorStreamingServer(s *grpc.Server, srv DaprActorStreamingServer) {
+	s.RegisterService(&_DaprActorStreaming_serviceDesc, srv)
+}
+
+func _DaprActorStreaming_CallActorStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InternalInvokeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaprActorStreamingServer).CallActorStream(m, &daprActorStreamingCallActorStreamServer{stream})
+}
+
+type DaprActorStreaming_CallActorStreamServer interface {
+	Send(*InternalInvokeResponse) error
+	grpc.ServerStream
+}
+
+type daprActorStreamingCallActorStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *daprActorStreamingCallActorStreamServer) Send(m *InternalInvokeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _DaprActorStreaming_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.daprinternal.v1.DaprActorStreaming",
+	HandlerType: (*DaprActorStreamingServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CallActorStream",
+			Handler:       _DaprActorStreaming_CallActorStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/daprinternal/v1/actorstreaming.proto",
+}
@@ -0,0 +1,179 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/placement/v1/drain.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// DrainReport tells the placement service that a host deactivated the actors it lost in a tables version
+type DrainReport struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainReport) Reset()         { *m = DrainReport{} }
+func (m *DrainReport) String() string { return proto.CompactTextString(m) }
+func (*DrainReport) ProtoMessage()    {}
+func (*DrainReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aac4fe9ee8191746, []int{0}
+}
+
+func (m *DrainReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainReport.Unmarshal(m, b)
+}
+func (m *DrainReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainReport.Marshal(b, m, deterministic)
+}
+func (m *DrainReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainReport.Merge(m, src)
+}
+func (m *DrainReport) XXX_Size() int {
+	return xxx_messageInfo_DrainReport.Size(m)
+}
+func (m *DrainReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainReport proto.InternalMessageInfo
+
+func (m *DrainReport) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DrainReport) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*DrainReport)(nil), "dapr.proto.placement.v1.DrainReport")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/placement/v1/drain.proto", fileDescriptor_aac4fe9ee8191746)
+}
+
+var fileDescriptor_aac4fe9ee8191746 = []byte{
+	// 204 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x4e, 0x49, 0x2c, 0x28,
+	0xd2, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0xd7, 0x2f, 0xc8, 0x49, 0x4c, 0x4e, 0xcd, 0x4d, 0xcd, 0x2b,
+	0xd1, 0x2f, 0x33, 0xd4, 0x4f, 0x29, 0x4a, 0xcc, 0xcc, 0xd3, 0x03, 0x4b, 0x08, 0x89, 0x83, 0x14,
+	0x41, 0xd8, 0x7a, 0x70, 0x45, 0x7a, 0x65, 0x86, 0x52, 0xd2, 0xe9, 0xf9, 0xf9, 0xe9, 0x39, 0xa9,
+	0x10, 0xfd, 0x49, 0xa5, 0x69, 0xfa, 0xa9, 0xb9, 0x05, 0x25, 0x95, 0x10, 0x95, 0x4a, 0xd6, 0x5c,
+	0xdc, 0x2e, 0x20, 0x43, 0x82, 0x52, 0x0b, 0xf2, 0x8b, 0x4a, 0x84, 0x84, 0xb8, 0x58, 0xf2, 0x12,
+	0x73, 0x53, 0x25, 0x18, 0x15, 0x18, 0x35, 0x38, 0x83, 0xc0, 0x6c, 0x21, 0x09, 0x2e, 0xf6, 0xb2,
+	0xd4, 0xa2, 0xe2, 0xcc, 0xfc, 0x3c, 0x09, 0x26, 0xb0, 0x30, 0x8c, 0x6b, 0x14, 0xcf, 0xc5, 0x17,
+	0x00, 0xb3, 0x09, 0x6c, 0x8a, 0x90, 0x2f, 0x17, 0x2f, 0xc4, 0x24, 0x30, 0x37, 0x35, 0x45, 0x48,
+	0x45, 0x0f, 0x87, 0xb3, 0xf4, 0x90, 0xac, 0x95, 0x12, 0xd3, 0x83, 0xb8, 0x51, 0x0f, 0xe6, 0x46,
+	0x3d, 0x57, 0x90, 0x1b, 0x9d, 0x74, 0xa3, 0xb4, 0xd3, 0x33, 0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92,
+	0xf3, 0x73, 0xf5, 0xc1, 0xa1, 0x00, 0x26, 0x0a, 0xb2, 0xd3, 0xb1, 0x04, 0x47, 0x12, 0x1b, 0x58,
+	0xcc, 0x18, 0x30, 0x00, 0xd8, 0xf6, 0xbc, 0xe9, 0x30, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PlacementDrainClient is the client API for PlacementDrain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PlacementDrainClient interface {
+	ReportDrained(ctx context.Context, in *DrainReport, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type placementDrainClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPlacementDrainClient(cc *grpc.ClientConn) PlacementDrainClient {
+	return &placementDrainClient{cc}
+}
+
+func (c *placementDrainClient) ReportDrained(ctx context.Context, in *DrainReport, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/dapr.proto.placement.v1.PlacementDrain/ReportDrained", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlacementDrainServer is the server API for PlacementDrain service.
+type PlacementDrainServer interface {
+	ReportDrained(context.Context, *DrainReport) (*empty.Empty, error)
+}
+
+// UnimplementedPlacementDrainServer can be embedded to have forward compatible implementations.
+type UnimplementedPlacementDrainServer struct {
+}
+
+func (*UnimplementedPlacementDrainServer) ReportDrained(ctx context.Context, req *DrainReport) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportDrained not implemented")
+}
+
+func RegisterPlacementDrainServer(s *grpc.Server, srv PlacementDrainServer) {
+	s.RegisterService(&_PlacementDrain_serviceDesc, srv)
+}
+
+func _PlacementDrain_ReportDrained_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlacementDrainServer).ReportDrained(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.placement.v1.PlacementDrain/ReportDrained",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlacementDrainServer).ReportDrained(ctx, req.(*DrainReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PlacementDrain_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.placement.v1.PlacementDrain",
+	HandlerType: (*PlacementDrainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportDrained",
+			Handler:    _PlacementDrain_ReportDrained_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/placement/v1/drain.proto",
+}
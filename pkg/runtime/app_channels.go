@@ -0,0 +1,78 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/channel"
+)
+
+const (
+	// InvocationBuildingBlock is the service invocation building block, which calls the methods of the app
+	InvocationBuildingBlock = "invocation"
+	// PubSubBuildingBlock is the pub/sub building block, which reads the subscriptions of the app and delivers the
+	// messages to the app
+	PubSubBuildingBlock = "pubsub"
+	// BindingsBuildingBlock is the bindings building block, which delivers the events of input bindings to the app
+	BindingsBuildingBlock = "bindings"
+	// ActorsBuildingBlock is the actors building block, which calls the actors and workflows hosted by the app
+	ActorsBuildingBlock = "actors"
+)
+
+var appChannelBuildingBlocks = []string{InvocationBuildingBlock, PubSubBuildingBlock, BindingsBuildingBlock, ActorsBuildingBlock}
+
+// ParseAppChannelProtocols parses the protocols of the app channels used by building blocks, given as a comma
+// separated list of building block=protocol pairs such as invocation=grpc,pubsub=http
+func ParseAppChannelProtocols(s string) (map[string]Protocol, error) {
+	protocols := map[string]Protocol{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid app channel protocol %q, expected building block=protocol", pair)
+		}
+		block, protocol := strings.TrimSpace(parts[0]), Protocol(strings.TrimSpace(parts[1]))
+		if !isAppChannelBuildingBlock(block) {
+			return nil, fmt.Errorf("unknown building block %q, expected one of %s", block, strings.Join(appChannelBuildingBlocks, ", "))
+		}
+		if protocol != HTTPProtocol && protocol != GRPCProtocol {
+			return nil, fmt.Errorf("unknown protocol %q for building block %s, expected http or grpc", protocol, block)
+		}
+		protocols[block] = protocol
+	}
+	return protocols, nil
+}
+
+func isAppChannelBuildingBlock(block string) bool {
+	for _, b := range appChannelBuildingBlocks {
+		if b == block {
+			return true
+		}
+	}
+	return false
+}
+
+// appProtocol returns the protocol of the app channel used by the building block. Building blocks use the app
+// protocol unless another protocol is selected for them and the app has a channel of that protocol.
+func (a *DaprRuntime) appProtocol(block string) Protocol {
+	if protocol, ok := a.runtimeConfig.AppChannelProtocols[block]; ok && a.appChannels[protocol] != nil {
+		return protocol
+	}
+	return a.runtimeConfig.ApplicationProtocol
+}
+
+// appChannelFor returns the app channel used by the building block
+func (a *DaprRuntime) appChannelFor(block string) channel.AppChannel {
+	if ch, ok := a.appChannels[a.appProtocol(block)]; ok {
+		return ch
+	}
+	return a.appChannel
+}
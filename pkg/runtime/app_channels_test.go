@@ -0,0 +1,81 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"testing"
+
+	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAppChannelProtocols(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		protocols, err := ParseAppChannelProtocols("")
+		assert.NoError(t, err)
+		assert.Empty(t, protocols)
+	})
+
+	t.Run("protocols of building blocks", func(t *testing.T) {
+		protocols, err := ParseAppChannelProtocols("invocation=grpc, pubsub=http,actors=http")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]Protocol{
+			InvocationBuildingBlock: GRPCProtocol,
+			PubSubBuildingBlock:     HTTPProtocol,
+			ActorsBuildingBlock:     HTTPProtocol,
+		}, protocols)
+	})
+
+	t.Run("unknown building block", func(t *testing.T) {
+		_, err := ParseAppChannelProtocols("state=grpc")
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown protocol", func(t *testing.T) {
+		_, err := ParseAppChannelProtocols("invocation=websocket")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing protocol", func(t *testing.T) {
+		_, err := ParseAppChannelProtocols("invocation")
+		assert.Error(t, err)
+	})
+}
+
+func TestAppChannelFor(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	httpChannel := new(channelt.MockAppChannel)
+	rt.appChannel = httpChannel
+	rt.appChannels[HTTPProtocol] = httpChannel
+	rt.runtimeConfig.AppChannelProtocols = map[string]Protocol{
+		InvocationBuildingBlock: GRPCProtocol,
+		BindingsBuildingBlock:   HTTPProtocol,
+	}
+
+	// the app has no grpc channel, invocation uses the channel of the app protocol
+	assert.Equal(t, HTTPProtocol, rt.appProtocol(InvocationBuildingBlock))
+	assert.Equal(t, httpChannel, rt.appChannelFor(InvocationBuildingBlock))
+
+	grpcChannel := new(channelt.MockAppChannel)
+	rt.appChannels[GRPCProtocol] = grpcChannel
+	assert.Equal(t, GRPCProtocol, rt.appProtocol(InvocationBuildingBlock))
+	assert.Equal(t, grpcChannel, rt.appChannelFor(InvocationBuildingBlock))
+	assert.Equal(t, HTTPProtocol, rt.appProtocol(BindingsBuildingBlock))
+	assert.Equal(t, httpChannel, rt.appChannelFor(BindingsBuildingBlock))
+	assert.Equal(t, HTTPProtocol, rt.appProtocol(PubSubBuildingBlock))
+	assert.Equal(t, httpChannel, rt.appChannelFor(PubSubBuildingBlock))
+}
+
+func TestAppPort(t *testing.T) {
+	c := &Config{ApplicationProtocol: HTTPProtocol, ApplicationPort: 3000, AppHTTPPort: 3001, AppGRPCPort: 50051}
+	assert.Equal(t, 3000, c.AppPort(HTTPProtocol))
+	assert.Equal(t, 50051, c.AppPort(GRPCProtocol))
+
+	c = &Config{ApplicationProtocol: GRPCProtocol, AppGRPCPort: 50051}
+	assert.Equal(t, 50051, c.AppPort(GRPCProtocol))
+	assert.Equal(t, 0, c.AppPort(HTTPProtocol))
+}
@@ -0,0 +1,147 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/dapr/dapr/pkg/health"
+)
+
+const (
+	// AppHealthHealthy is the health of an app passing the app health check
+	AppHealthHealthy = "healthy"
+	// AppHealthUnhealthy is the health of an app failing the app health check
+	AppHealthUnhealthy = "unhealthy"
+)
+
+// appHealth holds the health of the app reported by the app health check. The app is healthy until the check
+// reports otherwise.
+type appHealth struct {
+	lock    sync.Mutex
+	checked bool
+	// recovered is closed when the app is healthy again, it is nil while the app is healthy
+	recovered chan struct{}
+}
+
+// enable records that the health of the app is checked
+func (h *appHealth) enable() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.checked = true
+}
+
+// set records the health of the app, it returns false if the health didn't change
+func (h *appHealth) set(healthy bool) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if healthy == (h.recovered == nil) {
+		return false
+	}
+	if healthy {
+		close(h.recovered)
+		h.recovered = nil
+	} else {
+		h.recovered = make(chan struct{})
+	}
+	return true
+}
+
+// status returns the health of the app, or an empty string if it is not checked
+func (h *appHealth) status() string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	switch {
+	case !h.checked:
+		return ""
+	case h.recovered != nil:
+		return AppHealthUnhealthy
+	default:
+		return AppHealthHealthy
+	}
+}
+
+// wait blocks while the app is unhealthy
+func (h *appHealth) wait() {
+	h.lock.Lock()
+	recovered := h.recovered
+	h.lock.Unlock()
+	if recovered != nil {
+		<-recovered
+	}
+}
+
+// AppHealth returns the health of the app reported by the app health check, or an empty string if the health of
+// the app is not checked
+func (a *DaprRuntime) AppHealth() string {
+	return a.appHealth.status()
+}
+
+// startAppHealthCheck probes the health of the app. HTTP apps are probed on the app health check path, gRPC apps
+// with the gRPC health checking protocol.
+func (a *DaprRuntime) startAppHealthCheck() {
+	if !a.runtimeConfig.EnableAppHealthCheck || a.appChannel == nil {
+		return
+	}
+
+	opts := []health.Option{}
+	if a.runtimeConfig.AppHealthProbeInterval > 0 {
+		opts = append(opts, health.WithInterval(a.runtimeConfig.AppHealthProbeInterval))
+	}
+	if a.runtimeConfig.AppHealthThreshold > 0 {
+		opts = append(opts, health.WithFailureThreshold(a.runtimeConfig.AppHealthThreshold))
+	}
+	if a.appTLSConfig != nil {
+		opts = append(opts, health.WithTLSConfig(a.appTLSConfig))
+	}
+
+	if a.runtimeConfig.AppUnixDomainSocket != "" && a.runtimeConfig.ApplicationProtocol != GRPCProtocol {
+		log.Warnf("app health check over a unix domain socket is only supported for grpc apps, the health of the app is not checked")
+		return
+	}
+
+	var ch chan bool
+	if a.runtimeConfig.ApplicationProtocol == GRPCProtocol {
+		ch = health.StartGRPCHealthCheck(a.grpc.AppClient, opts...)
+	} else {
+		path := a.runtimeConfig.AppHealthCheckPath
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		ch = health.StartEndpointHealthCheck(a.appChannel.GetBaseAddress()+path, opts...)
+	}
+	a.appHealth.enable()
+	go func() {
+		for healthy := range ch {
+			a.setAppHealthy(healthy)
+		}
+	}()
+}
+
+// setAppHealthy pauses or resumes the delivery of the messages of all subscribed topics and of the input binding
+// events, and the activation of actors
+func (a *DaprRuntime) setAppHealthy(healthy bool) {
+	changed := a.appHealth.set(healthy)
+	for k := range a.topicRoutes {
+		if healthy {
+			changed = a.unhealthyTopics.Resume(k.topic) || changed
+		} else {
+			changed = a.unhealthyTopics.Pause(k.topic) || changed
+		}
+	}
+	if !changed {
+		return
+	}
+	if a.actor != nil {
+		a.actor.SetAppHealthy(healthy)
+	}
+	if healthy {
+		log.Infof("app is healthy, resuming the delivery of messages and events and the activation of actors")
+	} else {
+		log.Warnf("app is unhealthy, pausing the delivery of messages and events and the activation of actors")
+	}
+}
@@ -31,17 +31,39 @@ func FromFlags() (*DaprRuntime, error) {
 	appPort := flag.String("app-port", "", "The port the application is listening on")
 	profilePort := flag.String("profile-port", fmt.Sprintf("%v", DefaultProfilePort), "The port for the profile server")
 	appProtocol := flag.String("protocol", string(HTTPProtocol), "Protocol for the application: grpc or http")
+	appHTTPPort := flag.String("app-http-port", "", "The HTTP port the application is listening on when the protocol of the application is grpc, so Dapr can reach the application over both protocols")
+	appGRPCPort := flag.String("app-grpc-port", "", "The gRPC port the application is listening on when the protocol of the application is http, so Dapr can reach the application over both protocols")
+	unixDomainSocket := flag.String("unix-domain-socket", "", "Path to a directory where Dapr creates the Unix domain sockets of its HTTP and gRPC APIs, named dapr-http-<app-id>.socket and dapr-grpc-<app-id>.socket, instead of listening on the dapr-http-port and dapr-grpc-port")
+	appUnixDomainSocket := flag.String("app-unix-domain-socket", "", "Path to the Unix domain socket the application is listening on, used instead of app-port to reach the application over its protocol")
+	appChannelProtocols := flag.String("app-channel-protocols", "", "Protocol used by each building block to reach the application, such as invocation=grpc,pubsub=http. The building blocks are invocation, pubsub, bindings and actors, and use the protocol of the application when not listed")
 	componentsPath := flag.String("components-path", DefaultComponentsPath, "Path for components directory. Standalone mode only")
 	config := flag.String("config", "", "Path to config file, or name of a configuration object")
 	appID := flag.String("app-id", "", "A unique ID for Dapr. Used for Service Discovery and state")
 	controlPlaneAddress := flag.String("control-plane-address", "", "Address for a Dapr control plane")
-	sentryAddress := flag.String("sentry-address", "", "Address for the Sentry CA service")
+	sentryAddress := flag.String("sentry-address", "", "Address for the Sentry CA service, or comma separated addresses of the Sentry replicas which are tried in turn when a replica is unavailable")
 	placementServiceAddress := flag.String("placement-address", "", "Address for the Dapr placement service")
 	allowedOrigins := flag.String("allowed-origins", DefaultAllowedOrigins, "Allowed HTTP origins")
 	enableProfiling := flag.Bool("enable-profiling", false, "Enable profiling")
 	runtimeVersion := flag.Bool("version", false, "Prints the runtime version")
 	maxConcurrency := flag.Int("max-concurrency", -1, "Controls the concurrency level when forwarding requests to user code")
 	enableMTLS := flag.Bool("enable-mtls", false, "Enables automatic mTLS for daprd to daprd communication channels")
+	enableAppHealthCheck := flag.Bool("enable-app-health-check", false, "Probes the health of the app, and pauses the delivery of pub/sub messages and input binding events and the activation of actors while the app is unhealthy")
+	appHealthCheckPath := flag.String("app-health-check-path", DefaultAppHealthCheckPath, "HTTP path of the app probed by the app health check. gRPC apps are probed with the gRPC health checking protocol")
+	appHealthProbeIntervalSeconds := flag.Int("app-health-probe-interval-seconds", DefaultAppHealthProbeIntervalSeconds, "Interval in seconds of the probes of the app health check")
+	appHealthThreshold := flag.Int("app-health-threshold", DefaultAppHealthThreshold, "Number of consecutive failed probes after which the app is unhealthy")
+	appReadyPath := flag.String("app-ready-path", "", "HTTP path of the app which must respond with 200 before Dapr reads the subscriptions of the app and serves traffic. The app port is probed over TCP when empty")
+	appReadyTimeoutSeconds := flag.Int("app-ready-timeout-seconds", 0, "Time in seconds Dapr waits for the app to be ready before failing to start, 0 waits indefinitely")
+	maxRequestBodySize := flag.Int("dapr-http-max-request-size", DefaultMaxRequestBodySize, "Maximum size in MB of the body of the requests to the Dapr HTTP API")
+	grpcMaxRecvMsgSize := flag.Int("dapr-grpc-max-recv-msg-size", DefaultGRPCMaxMsgSize, "Maximum size in MB of the gRPC messages received by the Dapr gRPC APIs, the gRPC app channel and the connections to other Dapr instances")
+	grpcMaxSendMsgSize := flag.Int("dapr-grpc-max-send-msg-size", DefaultGRPCMaxMsgSize, "Maximum size in MB of the gRPC messages sent by the Dapr gRPC APIs, the gRPC app channel and the connections to other Dapr instances")
+	gracefulShutdownSeconds := flag.Int("dapr-graceful-shutdown-seconds", DefaultGracefulShutdownSeconds, "Grace period in seconds for the operations in progress to complete when Dapr shuts down")
+	enableAPITLS := flag.Bool("enable-api-tls", false, "Serves the public Dapr HTTP API over HTTPS and the public Dapr gRPC API with TLS, with the api-tls-cert-file and api-tls-key-file certificate or the certificate signed by sentry when mTLS is enabled")
+	apiTLSCertFile := flag.String("api-tls-cert-file", "", "Path to the PEM encoded certificate of the TLS of the public Dapr APIs")
+	apiTLSKeyFile := flag.String("api-tls-key-file", "", "Path to the PEM encoded private key of the TLS of the public Dapr APIs")
+	enableAppTLS := flag.Bool("enable-app-tls", false, "Connects to the app port over HTTPS or gRPC with TLS, presenting the app-tls-cert-file and app-tls-key-file certificate or the certificate signed by sentry when mTLS is enabled")
+	appTLSCAFile := flag.String("app-tls-ca-file", "", "Path to the PEM encoded CA certificates verifying the certificate of the app, required unless mTLS is enabled, which verifies the certificate of the app with the trust anchors of sentry")
+	appTLSCertFile := flag.String("app-tls-cert-file", "", "Path to the PEM encoded certificate presented to the app")
+	appTLSKeyFile := flag.String("app-tls-key-file", "", "Path to the PEM encoded private key of the certificate presented to the app")
 
 	loggerOptions := logger.DefaultOptions()
 	loggerOptions.AttachCmdFlags(flag.StringVar, flag.BoolVar)
@@ -111,8 +133,68 @@ func FromFlags() (*DaprRuntime, error) {
 		}
 	}
 
-	runtimeConfig := NewRuntimeConfig(*appID, *placementServiceAddress, *controlPlaneAddress, *allowedOrigins, *config, *componentsPath,
-		*appProtocol, *mode, daprHTTP, daprInternalGRPC, daprAPIGRPC, applicationPort, profPort, *enableProfiling, *maxConcurrency, *enableMTLS, *sentryAddress)
+	var applicationHTTPPort int
+	if *appHTTPPort != "" {
+		applicationHTTPPort, err = strconv.Atoi(*appHTTPPort)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing app-http-port: %s", err)
+		}
+	}
+
+	var applicationGRPCPort int
+	if *appGRPCPort != "" {
+		applicationGRPCPort, err = strconv.Atoi(*appGRPCPort)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing app-grpc-port: %s", err)
+		}
+	}
+
+	channelProtocols, err := ParseAppChannelProtocols(*appChannelProtocols)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing app-channel-protocols: %s", err)
+	}
+
+	runtimeConfig := NewRuntimeConfig(NewRuntimeConfigOpts{
+		ID:                            *appID,
+		PlacementServiceAddress:       *placementServiceAddress,
+		ControlPlaneAddress:           *controlPlaneAddress,
+		AllowedOrigins:                *allowedOrigins,
+		GlobalConfig:                  *config,
+		ComponentsPath:                *componentsPath,
+		AppProtocol:                   *appProtocol,
+		Mode:                          *mode,
+		HTTPPort:                      daprHTTP,
+		InternalGRPCPort:              daprInternalGRPC,
+		APIGRPCPort:                   daprAPIGRPC,
+		AppPort:                       applicationPort,
+		ProfilePort:                   profPort,
+		EnableProfiling:               *enableProfiling,
+		MaxConcurrency:                *maxConcurrency,
+		MTLSEnabled:                   *enableMTLS,
+		SentryAddress:                 *sentryAddress,
+		EnableAppHealthCheck:          *enableAppHealthCheck,
+		GracefulShutdownSeconds:       *gracefulShutdownSeconds,
+		AppReadyPath:                  *appReadyPath,
+		AppReadyTimeoutSeconds:        *appReadyTimeoutSeconds,
+		AppHealthCheckPath:            *appHealthCheckPath,
+		AppHealthProbeIntervalSeconds: *appHealthProbeIntervalSeconds,
+		AppHealthThreshold:            *appHealthThreshold,
+		AppHTTPPort:                   applicationHTTPPort,
+		AppGRPCPort:                   applicationGRPCPort,
+		AppChannelProtocols:           channelProtocols,
+		UnixDomainSocket:              *unixDomainSocket,
+		AppUnixDomainSocket:           *appUnixDomainSocket,
+		MaxRequestBodySize:            *maxRequestBodySize,
+		GRPCMaxRecvMsgSize:            *grpcMaxRecvMsgSize,
+		GRPCMaxSendMsgSize:            *grpcMaxSendMsgSize,
+		EnableAPITLS:                  *enableAPITLS,
+		APITLSCertFile:                *apiTLSCertFile,
+		APITLSKeyFile:                 *apiTLSKeyFile,
+		EnableAppTLS:                  *enableAppTLS,
+		AppTLSCAFile:                  *appTLSCAFile,
+		AppTLSCertFile:                *appTLSCertFile,
+		AppTLSKeyFile:                 *appTLSKeyFile,
+	})
 
 	var globalConfig *global_config.Configuration
 	var configErr error
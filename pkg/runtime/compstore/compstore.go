@@ -0,0 +1,249 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package compstore
+
+import (
+	"sync"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/configuration"
+	"github.com/dapr/dapr/pkg/crypto"
+	"github.com/dapr/dapr/pkg/state/keyprefix"
+)
+
+// ComponentStore holds the initialized components served by the Dapr APIs. The components are added and removed
+// while the APIs serve requests when the components are reloaded, so they are only accessed through the store.
+type ComponentStore struct {
+	lock sync.RWMutex
+
+	stateStores         map[string]state.Store
+	stateKeyPrefixes    map[string]keyprefix.Prefix
+	stateConsistency    map[string][]string
+	statePrefixDeletes  map[string]bool
+	secretStores        map[string]secretstores.SecretStore
+	configurationStores map[string]configuration.Store
+	keyVaults           map[string]crypto.KeyVault
+}
+
+// New returns an empty ComponentStore
+func New() *ComponentStore {
+	return &ComponentStore{
+		stateStores:         map[string]state.Store{},
+		stateKeyPrefixes:    map[string]keyprefix.Prefix{},
+		stateConsistency:    map[string][]string{},
+		statePrefixDeletes:  map[string]bool{},
+		secretStores:        map[string]secretstores.SecretStore{},
+		configurationStores: map[string]configuration.Store{},
+		keyVaults:           map[string]crypto.KeyVault{},
+	}
+}
+
+// AddStateStore adds a state store, the keys of the app are saved as they are unless a key prefix is set
+func (s *ComponentStore) AddStateStore(name string, store state.Store) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stateStores[name] = store
+}
+
+// GetStateStore returns the state store with the given name
+func (s *ComponentStore) GetStateStore(name string) (state.Store, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	store, ok := s.stateStores[name]
+	return store, ok
+}
+
+// ListStateStores returns a copy of the state stores by name
+func (s *ComponentStore) ListStateStores() map[string]state.Store {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	stores := make(map[string]state.Store, len(s.stateStores))
+	for name, store := range s.stateStores {
+		stores[name] = store
+	}
+	return stores
+}
+
+// StateStoresLen returns the number of state stores
+func (s *ComponentStore) StateStoresLen() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.stateStores)
+}
+
+// DeleteStateStore removes a state store along with its settings
+func (s *ComponentStore) DeleteStateStore(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.stateStores, name)
+	delete(s.stateKeyPrefixes, name)
+	delete(s.stateConsistency, name)
+	delete(s.statePrefixDeletes, name)
+}
+
+// SetStateKeyPrefix sets the prefix of the keys the app saves in a state store
+func (s *ComponentStore) SetStateKeyPrefix(name string, prefix keyprefix.Prefix) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stateKeyPrefixes[name] = prefix
+}
+
+// GetStateKeyPrefix returns the prefix of the keys of a state store, ok is false if the store has no prefix
+func (s *ComponentStore) GetStateKeyPrefix(name string) (keyprefix.Prefix, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	prefix, ok := s.stateKeyPrefixes[name]
+	return prefix, ok
+}
+
+// SetStateConsistency sets the consistency levels supported by a state store
+func (s *ComponentStore) SetStateConsistency(name string, consistency []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stateConsistency[name] = consistency
+}
+
+// GetStateConsistency returns the consistency levels supported by a state store
+func (s *ComponentStore) GetStateConsistency(name string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.stateConsistency[name]
+}
+
+// SetStatePrefixDelete sets whether the app can delete the keys of a state store by prefix
+func (s *ComponentStore) SetStatePrefixDelete(name string, allowed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.statePrefixDeletes[name] = allowed
+}
+
+// IsStatePrefixDeleteAllowed returns whether the app can delete the keys of a state store by prefix
+func (s *ComponentStore) IsStatePrefixDeleteAllowed(name string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.statePrefixDeletes[name]
+}
+
+// AddSecretStore adds a secret store
+func (s *ComponentStore) AddSecretStore(name string, store secretstores.SecretStore) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.secretStores[name] = store
+}
+
+// GetSecretStore returns the secret store with the given name
+func (s *ComponentStore) GetSecretStore(name string) (secretstores.SecretStore, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	store, ok := s.secretStores[name]
+	return store, ok
+}
+
+// ListSecretStores returns a copy of the secret stores by name
+func (s *ComponentStore) ListSecretStores() map[string]secretstores.SecretStore {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	stores := make(map[string]secretstores.SecretStore, len(s.secretStores))
+	for name, store := range s.secretStores {
+		stores[name] = store
+	}
+	return stores
+}
+
+// SecretStoresLen returns the number of secret stores
+func (s *ComponentStore) SecretStoresLen() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.secretStores)
+}
+
+// DeleteSecretStore removes a secret store
+func (s *ComponentStore) DeleteSecretStore(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.secretStores, name)
+}
+
+// AddConfigurationStore adds a configuration store
+func (s *ComponentStore) AddConfigurationStore(name string, store configuration.Store) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.configurationStores[name] = store
+}
+
+// GetConfigurationStore returns the configuration store with the given name
+func (s *ComponentStore) GetConfigurationStore(name string) (configuration.Store, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	store, ok := s.configurationStores[name]
+	return store, ok
+}
+
+// ListConfigurationStores returns a copy of the configuration stores by name
+func (s *ComponentStore) ListConfigurationStores() map[string]configuration.Store {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	stores := make(map[string]configuration.Store, len(s.configurationStores))
+	for name, store := range s.configurationStores {
+		stores[name] = store
+	}
+	return stores
+}
+
+// ConfigurationStoresLen returns the number of configuration stores
+func (s *ComponentStore) ConfigurationStoresLen() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.configurationStores)
+}
+
+// DeleteConfigurationStore removes a configuration store
+func (s *ComponentStore) DeleteConfigurationStore(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.configurationStores, name)
+}
+
+// AddKeyVault adds a key vault
+func (s *ComponentStore) AddKeyVault(name string, vault crypto.KeyVault) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keyVaults[name] = vault
+}
+
+// GetKeyVault returns the key vault with the given name
+func (s *ComponentStore) GetKeyVault(name string) (crypto.KeyVault, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	vault, ok := s.keyVaults[name]
+	return vault, ok
+}
+
+// ListKeyVaults returns a copy of the key vaults by name
+func (s *ComponentStore) ListKeyVaults() map[string]crypto.KeyVault {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	vaults := make(map[string]crypto.KeyVault, len(s.keyVaults))
+	for name, vault := range s.keyVaults {
+		vaults[name] = vault
+	}
+	return vaults
+}
+
+// KeyVaultsLen returns the number of key vaults
+func (s *ComponentStore) KeyVaultsLen() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.keyVaults)
+}
+
+// DeleteKeyVault removes a key vault
+func (s *ComponentStore) DeleteKeyVault(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.keyVaults, name)
+}
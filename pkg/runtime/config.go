@@ -6,6 +6,10 @@
 package runtime
 
 import (
+	"fmt"
+	"path/filepath"
+	"time"
+
 	config "github.com/dapr/dapr/pkg/config/modes"
 	"github.com/dapr/dapr/pkg/credentials"
 	"github.com/dapr/dapr/pkg/modes"
@@ -31,6 +35,18 @@ const (
 	DefaultComponentsPath = "./components"
 	// DefaultAllowedOrigins is the default origins allowed for the Dapr HTTP servers
 	DefaultAllowedOrigins = "*"
+	// DefaultGracefulShutdownSeconds is the default grace period for the operations in progress when Dapr shuts down
+	DefaultGracefulShutdownSeconds = 5
+	// DefaultAppHealthCheckPath is the default HTTP path of the app probed by the app health check
+	DefaultAppHealthCheckPath = "/healthz"
+	// DefaultAppHealthProbeIntervalSeconds is the default interval of the probes of the app health check
+	DefaultAppHealthProbeIntervalSeconds = 5
+	// DefaultAppHealthThreshold is the default number of failed probes after which the app is unhealthy
+	DefaultAppHealthThreshold = 2
+	// DefaultMaxRequestBodySize is the default maximum size in MB of the body of the requests to the HTTP API
+	DefaultMaxRequestBodySize = 4
+	// DefaultGRPCMaxMsgSize is the default maximum size in MB of the gRPC messages received and sent by Dapr
+	DefaultGRPCMaxMsgSize = 4
 )
 
 // Config holds the Dapr Runtime configuration
@@ -53,31 +69,164 @@ type Config struct {
 	mtlsEnabled             bool
 	SentryServiceAddress    string
 	CertChain               *credentials.CertChain
+	EnableAppHealthCheck    bool
+	// GracefulShutdownDuration bounds the wait for the operations in progress when the runtime stops
+	GracefulShutdownDuration time.Duration
+	// AppReadyPath is the HTTP path of the app probed before the runtime reads the subscriptions of the app,
+	// the app port is probed over TCP when it is empty
+	AppReadyPath string
+	// AppReadyTimeout bounds the wait for the app to be ready, the runtime waits indefinitely when it is zero
+	AppReadyTimeout time.Duration
+	// AppHealthCheckPath is the HTTP path probed by the app health check of HTTP apps, gRPC apps are probed with
+	// the gRPC health checking protocol
+	AppHealthCheckPath string
+	// AppHealthProbeInterval is the interval of the probes of the app health check
+	AppHealthProbeInterval time.Duration
+	// AppHealthThreshold is the number of consecutive failed probes after which the app is unhealthy
+	AppHealthThreshold int
+	// AppHTTPPort and AppGRPCPort are the ports of the app channels of the protocol other than the app protocol,
+	// so the app can be reached over both protocols
+	AppHTTPPort int
+	AppGRPCPort int
+	// AppChannelProtocols selects the protocol of the app channel used by each building block, the building blocks
+	// which are not listed use the app protocol
+	AppChannelProtocols map[string]Protocol
+	// UnixDomainSocket is the directory of the Unix domain sockets the HTTP and gRPC APIs listen on instead of
+	// their ports
+	UnixDomainSocket string
+	// AppUnixDomainSocket is the Unix domain socket the app listens on for the app protocol, instead of the app port
+	AppUnixDomainSocket string
+	// MaxRequestBodySize is the maximum size in MB of the body of the requests to the HTTP API
+	MaxRequestBodySize int
+	// GRPCMaxRecvMsgSize and GRPCMaxSendMsgSize are the maximum sizes in MB of the gRPC messages received and sent
+	// by the gRPC servers, the gRPC app channel and the connections to other Dapr instances
+	GRPCMaxRecvMsgSize int
+	GRPCMaxSendMsgSize int
+	// EnableAPITLS serves the public HTTP and gRPC APIs with TLS, using the certificate and key files when they are
+	// given or the workload certificate signed by sentry otherwise
+	EnableAPITLS   bool
+	APITLSCertFile string
+	APITLSKeyFile  string
+	// EnableAppTLS connects to the app with TLS, verifying the app with the CA file when it's given and presenting
+	// the certificate and key files or the workload certificate signed by sentry to the app
+	EnableAppTLS   bool
+	AppTLSCAFile   string
+	AppTLSCertFile string
+	AppTLSKeyFile  string
+}
+
+// NewRuntimeConfigOpts are the settings of a runtime config, read from the flags of the sidecar
+type NewRuntimeConfigOpts struct {
+	ID                            string
+	PlacementServiceAddress       string
+	ControlPlaneAddress           string
+	AllowedOrigins                string
+	GlobalConfig                  string
+	ComponentsPath                string
+	AppProtocol                   string
+	Mode                          string
+	HTTPPort                      int
+	InternalGRPCPort              int
+	APIGRPCPort                   int
+	AppPort                       int
+	ProfilePort                   int
+	EnableProfiling               bool
+	MaxConcurrency                int
+	MTLSEnabled                   bool
+	SentryAddress                 string
+	EnableAppHealthCheck          bool
+	GracefulShutdownSeconds       int
+	AppReadyPath                  string
+	AppReadyTimeoutSeconds        int
+	AppHealthCheckPath            string
+	AppHealthProbeIntervalSeconds int
+	AppHealthThreshold            int
+	AppHTTPPort                   int
+	AppGRPCPort                   int
+	AppChannelProtocols           map[string]Protocol
+	UnixDomainSocket              string
+	AppUnixDomainSocket           string
+	MaxRequestBodySize            int
+	GRPCMaxRecvMsgSize            int
+	GRPCMaxSendMsgSize            int
+	EnableAPITLS                  bool
+	APITLSCertFile                string
+	APITLSKeyFile                 string
+	EnableAppTLS                  bool
+	AppTLSCAFile                  string
+	AppTLSCertFile                string
+	AppTLSKeyFile                 string
 }
 
 // NewRuntimeConfig returns a new runtime config
-func NewRuntimeConfig(id, placementServiceAddress, controlPlaneAddress, allowedOrigins, globalConfig, componentsPath, appProtocol, mode string, httpPort, internalGRPCPort, apiGRPCPort, appPort, profilePort int, enableProfiling bool, maxConcurrency int, mtlsEnabled bool, sentryAddress string) *Config {
+func NewRuntimeConfig(opts NewRuntimeConfigOpts) *Config {
 	return &Config{
-		ID:                      id,
-		HTTPPort:                httpPort,
-		InternalGRPCPort:        internalGRPCPort,
-		APIGRPCPort:             apiGRPCPort,
-		ApplicationPort:         appPort,
-		ProfilePort:             profilePort,
-		ApplicationProtocol:     Protocol(appProtocol),
-		Mode:                    modes.DaprMode(mode),
-		PlacementServiceAddress: placementServiceAddress,
-		GlobalConfig:            globalConfig,
-		AllowedOrigins:          allowedOrigins,
+		ID:                      opts.ID,
+		HTTPPort:                opts.HTTPPort,
+		InternalGRPCPort:        opts.InternalGRPCPort,
+		APIGRPCPort:             opts.APIGRPCPort,
+		ApplicationPort:         opts.AppPort,
+		ProfilePort:             opts.ProfilePort,
+		ApplicationProtocol:     Protocol(opts.AppProtocol),
+		Mode:                    modes.DaprMode(opts.Mode),
+		PlacementServiceAddress: opts.PlacementServiceAddress,
+		GlobalConfig:            opts.GlobalConfig,
+		AllowedOrigins:          opts.AllowedOrigins,
 		Standalone: config.StandaloneConfig{
-			ComponentsPath: componentsPath,
+			ComponentsPath: opts.ComponentsPath,
 		},
 		Kubernetes: config.KubernetesConfig{
-			ControlPlaneAddress: controlPlaneAddress,
+			ControlPlaneAddress: opts.ControlPlaneAddress,
 		},
-		EnableProfiling:      enableProfiling,
-		MaxConcurrency:       maxConcurrency,
-		mtlsEnabled:          mtlsEnabled,
-		SentryServiceAddress: sentryAddress,
+		EnableProfiling:          opts.EnableProfiling,
+		MaxConcurrency:           opts.MaxConcurrency,
+		mtlsEnabled:              opts.MTLSEnabled,
+		SentryServiceAddress:     opts.SentryAddress,
+		EnableAppHealthCheck:     opts.EnableAppHealthCheck,
+		GracefulShutdownDuration: time.Duration(opts.GracefulShutdownSeconds) * time.Second,
+		AppReadyPath:             opts.AppReadyPath,
+		AppReadyTimeout:          time.Duration(opts.AppReadyTimeoutSeconds) * time.Second,
+		AppHealthCheckPath:       opts.AppHealthCheckPath,
+		AppHealthProbeInterval:   time.Duration(opts.AppHealthProbeIntervalSeconds) * time.Second,
+		AppHealthThreshold:       opts.AppHealthThreshold,
+		AppHTTPPort:              opts.AppHTTPPort,
+		AppGRPCPort:              opts.AppGRPCPort,
+		AppChannelProtocols:      opts.AppChannelProtocols,
+		UnixDomainSocket:         opts.UnixDomainSocket,
+		AppUnixDomainSocket:      opts.AppUnixDomainSocket,
+		MaxRequestBodySize:       opts.MaxRequestBodySize,
+		GRPCMaxRecvMsgSize:       opts.GRPCMaxRecvMsgSize,
+		GRPCMaxSendMsgSize:       opts.GRPCMaxSendMsgSize,
+		EnableAPITLS:             opts.EnableAPITLS,
+		APITLSCertFile:           opts.APITLSCertFile,
+		APITLSKeyFile:            opts.APITLSKeyFile,
+		EnableAppTLS:             opts.EnableAppTLS,
+		AppTLSCAFile:             opts.AppTLSCAFile,
+		AppTLSCertFile:           opts.AppTLSCertFile,
+		AppTLSKeyFile:            opts.AppTLSKeyFile,
+	}
+}
+
+// unixDomainSocketPath returns the path of the Unix domain socket of an API of the sidecar, or an empty string
+// if the APIs listen on their ports
+func (c *Config) unixDomainSocketPath(api string) string {
+	if c.UnixDomainSocket == "" {
+		return ""
+	}
+	return filepath.Join(c.UnixDomainSocket, fmt.Sprintf("dapr-%s-%s.socket", api, c.ID))
+}
+
+// AppPort returns the port of the app channel of the protocol, or 0 if the app has no channel of the protocol.
+// The app port is the port of the app protocol.
+func (c *Config) AppPort(protocol Protocol) int {
+	if protocol == c.ApplicationProtocol && c.ApplicationPort > 0 {
+		return c.ApplicationPort
+	}
+	switch protocol {
+	case HTTPProtocol:
+		return c.AppHTTPPort
+	case GRPCProtocol:
+		return c.AppGRPCPort
 	}
+	return 0
 }
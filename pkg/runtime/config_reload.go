@@ -0,0 +1,139 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+	"github.com/dapr/dapr/pkg/fswatcher"
+	"github.com/dapr/dapr/pkg/modes"
+)
+
+// configurationPollInterval is the interval at which the configuration is read from the operator in Kubernetes
+// mode, the operator doesn't stream the updates of configurations
+const configurationPollInterval = time.Second * 30
+
+// SecretScopes returns the secret scopes by secret store name of the configuration applied last
+func (a *DaprRuntime) SecretScopes() map[string]config.SecretsScope {
+	if scopes, ok := a.secretScopes.Load().(map[string]config.SecretsScope); ok {
+		return scopes
+	}
+	return a.globalConfig.SecretScopes()
+}
+
+// beginConfigurationUpdates applies the changes of the configuration without restarting the sidecar when the
+// HotReload feature is enabled. The configuration is read from the operator in Kubernetes mode and watched in its
+// file in self-hosted mode.
+func (a *DaprRuntime) beginConfigurationUpdates() {
+	if a.runtimeConfig.GlobalConfig == "" || !a.globalConfig.IsFeatureEnabled(config.HotReload) {
+		return
+	}
+	switch a.runtimeConfig.Mode {
+	case modes.KubernetesMode:
+		if a.operatorClient != nil {
+			go a.pollOperatorConfiguration()
+		}
+	case modes.StandaloneMode:
+		go a.watchConfigurationFile()
+	}
+}
+
+func (a *DaprRuntime) pollOperatorConfiguration() {
+	ticker := time.NewTicker(configurationPollInterval)
+	defer ticker.Stop()
+
+	current := a.globalConfig
+	for range ticker.C {
+		conf, err := config.LoadKubernetesConfiguration(a.runtimeConfig.GlobalConfig, a.namespace, a.operatorClient)
+		if err != nil {
+			log.Warnf("error reloading configuration %s: %s", a.runtimeConfig.GlobalConfig, err)
+			continue
+		}
+		current = a.onConfigurationUpdated(current, conf)
+	}
+}
+
+// watchConfigurationFile reloads the configuration whenever a file of its directory changes
+func (a *DaprRuntime) watchConfigurationFile() {
+	path := a.runtimeConfig.GlobalConfig
+	events := make(chan struct{})
+	go func() {
+		defer close(events)
+		if err := fswatcher.WatchChanges(context.Background(), filepath.Dir(path), events); err != nil {
+			log.Errorf("error watching the configuration %s: %s", path, err)
+		}
+	}()
+
+	current := a.globalConfig
+	for range events {
+		conf, err := config.LoadStandaloneConfiguration(path)
+		if err != nil {
+			log.Warnf("error reloading configuration %s: %s", path, err)
+			continue
+		}
+		current = a.onConfigurationUpdated(current, conf)
+	}
+}
+
+// onConfigurationUpdated applies the tracing sampling rate and the secret scopes of an updated configuration, and
+// warns about the other changes which are only applied when the sidecar restarts. It returns the configuration to
+// compare the next update with.
+func (a *DaprRuntime) onConfigurationUpdated(current, updated *config.Configuration) *config.Configuration {
+	if reflect.DeepEqual(current.Spec, updated.Spec) {
+		return current
+	}
+
+	if current.Spec.TracingSpec != updated.Spec.TracingSpec {
+		diag.SetSamplingRate(updated.Spec.TracingSpec.SamplingRate)
+		log.Infof("applied tracing sampling rate %q of configuration %s", updated.Spec.TracingSpec.SamplingRate, a.runtimeConfig.GlobalConfig)
+	}
+	if !reflect.DeepEqual(current.Spec.Secrets, updated.Spec.Secrets) {
+		a.secretScopes.Store(updated.SecretScopes())
+		log.Infof("applied secret scopes of configuration %s", a.runtimeConfig.GlobalConfig)
+	}
+	if changed := restartRequiredChanges(current.Spec, updated.Spec); len(changed) > 0 {
+		log.Warnf("changes to %v of configuration %s are applied when dapr restarts", changed, a.runtimeConfig.GlobalConfig)
+	}
+	return updated
+}
+
+// restartRequiredChanges returns the changed settings of the configuration which can't be applied at runtime
+func restartRequiredChanges(current, updated config.ConfigurationSpec) []string {
+	changed := []string{}
+	if !reflect.DeepEqual(current.HTTPPipelineSpec, updated.HTTPPipelineSpec) {
+		changed = append(changed, "httpPipeline")
+	}
+	if current.MTLSSpec != updated.MTLSSpec {
+		changed = append(changed, "mtls")
+	}
+	if current.MetadataSpec != updated.MetadataSpec {
+		changed = append(changed, "metadata")
+	}
+	if current.InvocationSpec != updated.InvocationSpec {
+		changed = append(changed, "serviceInvocation")
+	}
+	if current.PubSubSpec != updated.PubSubSpec {
+		changed = append(changed, "pubsub")
+	}
+	if !reflect.DeepEqual(current.Features, updated.Features) {
+		changed = append(changed, "features")
+	}
+	if !reflect.DeepEqual(current.AccessControlSpec, updated.AccessControlSpec) {
+		changed = append(changed, "accessControl")
+	}
+	if !reflect.DeepEqual(current.APISpec, updated.APISpec) {
+		changed = append(changed, "api")
+	}
+	if current.AuditSpec != updated.AuditSpec {
+		changed = append(changed, "audit")
+	}
+	return changed
+}
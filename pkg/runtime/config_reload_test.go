@@ -0,0 +1,118 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnConfigurationUpdated(t *testing.T) {
+	current := &config.Configuration{
+		Spec: config.ConfigurationSpec{
+			Secrets: config.SecretsSpec{
+				Scopes: []config.SecretsScope{{StoreName: "store1", DefaultAccess: config.DenyAccess}},
+			},
+		},
+	}
+
+	t.Run("secret scopes are swapped", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.globalConfig = current
+		assert.Equal(t, config.DenyAccess, rt.SecretScopes()["store1"].DefaultAccess)
+
+		updated := &config.Configuration{
+			Spec: config.ConfigurationSpec{
+				Secrets: config.SecretsSpec{
+					Scopes: []config.SecretsScope{{StoreName: "store1", DefaultAccess: config.AllowAccess}},
+				},
+			},
+		}
+		applied := rt.onConfigurationUpdated(current, updated)
+		assert.Equal(t, updated, applied)
+		assert.Equal(t, config.AllowAccess, rt.SecretScopes()["store1"].DefaultAccess)
+		// the configuration loaded at startup is kept
+		assert.Equal(t, config.DenyAccess, rt.globalConfig.SecretScopes()["store1"].DefaultAccess)
+	})
+
+	t.Run("unchanged configuration", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.globalConfig = current
+		same := &config.Configuration{Spec: current.Spec}
+		assert.Equal(t, current, rt.onConfigurationUpdated(current, same))
+		assert.Nil(t, rt.secretScopes.Load())
+	})
+}
+
+func TestRestartRequiredChanges(t *testing.T) {
+	current := config.ConfigurationSpec{
+		MetadataSpec: config.MetadataSpec{MaxTotalSize: 1024},
+	}
+	updated := config.ConfigurationSpec{
+		MetadataSpec:   config.MetadataSpec{MaxTotalSize: 2048},
+		InvocationSpec: config.InvocationSpec{HedgingDelay: "100ms"},
+		Secrets: config.SecretsSpec{
+			Scopes: []config.SecretsScope{{StoreName: "store1"}},
+		},
+	}
+	assert.Equal(t, []string{"metadata", "serviceInvocation"}, restartRequiredChanges(current, updated))
+
+	updated = config.ConfigurationSpec{
+		MetadataSpec: current.MetadataSpec,
+		Features:     []config.FeatureSpec{{Name: config.HotReload, Enabled: true}},
+	}
+	assert.Equal(t, []string{"features"}, restartRequiredChanges(current, updated))
+	assert.Empty(t, restartRequiredChanges(current, current))
+}
+
+func TestWatchConfigurationFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dapr-config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`spec:
+  secrets:
+    scopes:
+    - storeName: store1
+      defaultAccess: deny
+  features:
+  - name: HotReload
+    enabled: true
+`), 0600))
+	conf, err := config.LoadStandaloneConfiguration(path)
+	assert.NoError(t, err)
+
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.globalConfig = conf
+	rt.runtimeConfig.GlobalConfig = path
+	rt.beginConfigurationUpdates()
+	// lets the watcher start before the file changes
+	time.Sleep(100 * time.Millisecond)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`spec:
+  secrets:
+    scopes:
+    - storeName: store1
+      defaultAccess: allow
+  features:
+  - name: HotReload
+    enabled: true
+`), 0600))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for rt.SecretScopes()["store1"].DefaultAccess != config.AllowAccess && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.Equal(t, config.AllowAccess, rt.SecretScopes()["store1"].DefaultAccess)
+}
@@ -22,20 +22,29 @@ func GetHostAddress() (string, error) {
 	// Any IP can be used, since connection is not established, but we used a known DNS IP.
 	conn, err := net.Dial("udp", "8.8.8.8:80")
 	if err != nil {
-		// Could not find one via a  UDP connection, so we fallback to the "old" way: try first non-loopback IPv4:
+		// Could not find one via a  UDP connection, so we fallback to the "old" way: try first non-loopback IPv4
+		// and then the first global IPv6 address for IPv6-only hosts:
 		addrs, err := net.InterfaceAddrs()
 		if err != nil {
 			return "", fmt.Errorf("error getting interface IP addresses: %s", err)
 		}
 
+		var ipv6 string
 		for _, addr := range addrs {
 			if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
 				if ipnet.IP.To4() != nil {
 					return ipnet.IP.String(), nil
 				}
+				if ipv6 == "" && ipnet.IP.IsGlobalUnicast() {
+					ipv6 = ipnet.IP.String()
+				}
 			}
 		}
 
+		if ipv6 != "" {
+			return ipv6, nil
+		}
+
 		return "", errors.New("could not determine host IP address")
 	}
 
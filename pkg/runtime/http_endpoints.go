@@ -0,0 +1,168 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/dapr/components-contrib/secretstores"
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/channel"
+	http_channel "github.com/dapr/dapr/pkg/channel/http"
+	"github.com/dapr/dapr/pkg/components"
+	"github.com/dapr/dapr/pkg/modes"
+)
+
+// initHTTPEndpoints loads the HTTP endpoint resources and creates a channel for each of them.
+// HTTP endpoints are currently supported in standalone mode only.
+func (a *DaprRuntime) initHTTPEndpoints() error {
+	if a.runtimeConfig.Mode != modes.StandaloneMode {
+		return nil
+	}
+
+	loader := components.NewStandaloneComponents(a.runtimeConfig.Standalone)
+	endpoints, err := loader.LoadHTTPEndpoints()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range endpoints {
+		if !a.isInNamespace(e.ObjectMeta.Namespace) || !a.isAppInScopes(e.Scopes) {
+			continue
+		}
+		if a.isAppTarget(e.ObjectMeta.Name) {
+			// the endpoint would capture the invocations of the app calling itself
+			log.Warnf("failed to init http endpoint %s: the name of an http endpoint can't be the id of the app", e.ObjectMeta.Name)
+			continue
+		}
+
+		ch, err := a.createHTTPEndpointChannel(e)
+		if err != nil {
+			log.Warnf("failed to init http endpoint %s: %s", e.ObjectMeta.Name, err)
+			continue
+		}
+		a.externalChannels[e.ObjectMeta.Name] = ch
+		log.Infof("found http endpoint %s (%s)", e.ObjectMeta.Name, e.Spec.BaseURL)
+	}
+	return nil
+}
+
+func (a *DaprRuntime) createHTTPEndpointChannel(endpoint components_v1alpha1.HTTPEndpoint) (channel.AppChannel, error) {
+	if endpoint.Spec.BaseURL == "" {
+		return nil, errors.New("baseUrl is required")
+	}
+
+	headers := map[string]string{}
+	for _, h := range endpoint.Spec.Headers {
+		value := h.Value
+		if h.EnvRef != "" {
+			v, err := a.lookupComponentEnv(h.EnvRef)
+			if err != nil {
+				return nil, err
+			}
+			value = v
+		} else if h.SecretKeyRef.Name != "" {
+			v, err := a.getSecretKeyRefValue(endpoint.Auth.SecretStore, h.SecretKeyRef, endpoint.ObjectMeta.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			value = v
+		}
+		headers[h.Name] = value
+	}
+
+	tlsConfig, err := getHTTPEndpointTLSConfig(endpoint.Spec.ClientTLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return http_channel.CreateExternalChannel(endpoint.Spec.BaseURL, tlsConfig, headers, a.globalConfig.Spec.TracingSpec)
+}
+
+// getSecretKeyRefValue returns the value of a secret reference, read from the secret store named by the reference
+// or else from the given secret store
+func (a *DaprRuntime) getSecretKeyRefValue(secretStoreName string, ref components_v1alpha1.SecretKeyRef, namespace string) (string, error) {
+	if ref.SecretStore != "" {
+		secretStoreName = ref.SecretStore
+	}
+	secretStore := a.getSecretStore(secretStoreName)
+	if secretStore == nil {
+		return "", fmt.Errorf("secret store %s not found", secretStoreName)
+	}
+
+	resp, err := secretStore.GetSecret(secretstores.GetSecretRequest{
+		Name: ref.Name,
+		Metadata: map[string]string{
+			"namespace": namespace,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Use the SecretKeyRef.Name key if SecretKeyRef.Key is not given
+	secretKeyName := ref.Key
+	if secretKeyName == "" {
+		secretKeyName = ref.Name
+	}
+	value, ok := resp.Data[secretKeyName]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s of secret store %s", secretKeyName, ref.Name, secretStoreName)
+	}
+	return value, nil
+}
+
+// isAppTarget returns true if invoking the target invokes the app itself
+func (a *DaprRuntime) isAppTarget(target string) bool {
+	if target == a.runtimeConfig.ID {
+		return true
+	}
+	return a.isNamespaceIsolated() && target == a.runtimeConfig.ID+"."+a.namespace
+}
+
+func (a *DaprRuntime) isAppInScopes(scopes []string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, s := range scopes {
+		if s == a.runtimeConfig.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// getHTTPEndpointTLSConfig builds the client TLS configuration of an HTTP endpoint
+func getHTTPEndpointTLSConfig(spec *components_v1alpha1.TLSSpec) (*tls.Config, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	// nolint:gosec
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: spec.InsecureSkipVerify,
+	}
+
+	if spec.RootCA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(spec.RootCA)) {
+			return nil, errors.New("failed to parse rootCA")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if spec.Certificate != "" || spec.PrivateKey != "" {
+		cert, err := tls.X509KeyPair([]byte(spec.Certificate), []byte(spec.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
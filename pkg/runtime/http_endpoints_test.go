@@ -0,0 +1,95 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"testing"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHTTPEndpointTLSConfig(t *testing.T) {
+	t.Run("no tls spec", func(t *testing.T) {
+		tlsConfig, err := getHTTPEndpointTLSConfig(nil)
+		assert.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		tlsConfig, err := getHTTPEndpointTLSConfig(&components_v1alpha1.TLSSpec{InsecureSkipVerify: true})
+		assert.NoError(t, err)
+		assert.True(t, tlsConfig.InsecureSkipVerify)
+	})
+
+	t.Run("invalid root ca", func(t *testing.T) {
+		_, err := getHTTPEndpointTLSConfig(&components_v1alpha1.TLSSpec{RootCA: "invalid"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid client certificate", func(t *testing.T) {
+		_, err := getHTTPEndpointTLSConfig(&components_v1alpha1.TLSSpec{Certificate: "invalid", PrivateKey: "invalid"})
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateHTTPEndpointChannel(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+
+	t.Run("missing base url", func(t *testing.T) {
+		_, err := rt.createHTTPEndpointChannel(components_v1alpha1.HTTPEndpoint{})
+		assert.Error(t, err)
+	})
+
+	t.Run("valid endpoint", func(t *testing.T) {
+		ch, err := rt.createHTTPEndpointChannel(components_v1alpha1.HTTPEndpoint{
+			Spec: components_v1alpha1.HTTPEndpointSpec{
+				BaseURL: "http://localhost:8080/",
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080", ch.GetBaseAddress())
+	})
+
+	rt.compStore.AddSecretStore("store1", &fakeSecretStore{
+		secrets:    map[string]map[string]string{"api": {"token": "s3cr3t"}},
+		properties: map[string]string{},
+	})
+	endpointWithSecretHeader := func(key string) components_v1alpha1.HTTPEndpoint {
+		return components_v1alpha1.HTTPEndpoint{
+			Spec: components_v1alpha1.HTTPEndpointSpec{
+				BaseURL: "http://localhost:8080/",
+				Headers: []components_v1alpha1.MetadataItem{
+					{Name: "Authorization", SecretKeyRef: components_v1alpha1.SecretKeyRef{Name: "api", Key: key}},
+				},
+			},
+			Auth: components_v1alpha1.Auth{SecretStore: "store1"},
+		}
+	}
+
+	t.Run("header from a secret", func(t *testing.T) {
+		_, err := rt.createHTTPEndpointChannel(endpointWithSecretHeader("token"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing secret key", func(t *testing.T) {
+		_, err := rt.createHTTPEndpointChannel(endpointWithSecretHeader("tokn"))
+		assert.Error(t, err)
+	})
+}
+
+func TestIsAppTarget(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.namespace = "ns1"
+	assert.True(t, rt.isAppTarget(TestRuntimeConfigID))
+	assert.False(t, rt.isAppTarget("other"))
+	assert.False(t, rt.isAppTarget(TestRuntimeConfigID+".ns1"))
+
+	rt.globalConfig.Spec.AccessControlSpec.NamespaceIsolation = true
+	assert.True(t, rt.isAppTarget(TestRuntimeConfigID+".ns1"))
+	assert.False(t, rt.isAppTarget(TestRuntimeConfigID+".ns2"))
+}
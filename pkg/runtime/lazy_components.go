@@ -0,0 +1,182 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/dapr/components-contrib/bindings"
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/components"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	"github.com/dapr/dapr/pkg/configuration"
+	"github.com/dapr/dapr/pkg/crypto"
+)
+
+// lazyInit initializes a component on the first call to one of its operations. A failed initialization
+// is tried again on the next call, with a new instance of the component.
+type lazyInit struct {
+	lock     sync.Mutex
+	instance interface{}
+	init     func() (interface{}, error)
+}
+
+// ensureInitialized returns the initialized instance of the component
+func (l *lazyInit) ensureInitialized() (interface{}, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.instance != nil {
+		return l.instance, nil
+	}
+	instance, err := l.init()
+	if err != nil {
+		return nil, err
+	}
+	l.instance = instance
+	return instance, nil
+}
+
+// close closes the component if it was initialized
+func (l *lazyInit) close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if closer, ok := l.instance.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// lazyComponentInit returns the function initializing a lazy component on its first use, which records the
+// status and the capabilities of the component like the components initialized at startup. The first attempt
+// initializes instance, the following ones initialize new instances returned by create.
+func (a *DaprRuntime) lazyComponentInit(c components_v1alpha1.Component, instance interface{}, create func() (interface{}, error), init func(instance interface{}) error, capabilities ...string) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		initialized, err := a.runInitPolicy(c, instance, create, init)
+		// a failed attempt may leave the instance half initialized
+		instance = nil
+		if err != nil {
+			log.Warnf("failed to init component %s (%s) on first use: %s", c.ObjectMeta.Name, c.Spec.Type, err)
+			a.componentInitFailed(c, "init", err)
+			return nil, err
+		}
+		log.Infof("component %s (%s) initialized on first use", c.ObjectMeta.Name, c.Spec.Type)
+		a.componentInitialized(c, capabilities...)
+		return initialized, nil
+	}
+}
+
+// lazyOutputBinding is an output binding initialized on its first invocation
+type lazyOutputBinding struct {
+	lazyInit
+}
+
+func newLazyOutputBinding(init func() (interface{}, error)) *lazyOutputBinding {
+	return &lazyOutputBinding{lazyInit: lazyInit{init: init}}
+}
+
+// Init does nothing, the binding is initialized on its first invocation
+func (l *lazyOutputBinding) Init(metadata bindings.Metadata) error {
+	return nil
+}
+
+func (l *lazyOutputBinding) Write(req *bindings.WriteRequest) error {
+	binding, err := l.ensureInitialized()
+	if err != nil {
+		return err
+	}
+	return binding.(bindings.OutputBinding).Write(req)
+}
+
+func (l *lazyOutputBinding) Invoke(req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) {
+	binding, err := l.ensureInitialized()
+	if err != nil {
+		return nil, err
+	}
+	if invokable, ok := binding.(bindings_loader.InvokableOutputBinding); ok {
+		return invokable.Invoke(req)
+	}
+	return nil, binding.(bindings.OutputBinding).Write(req)
+}
+
+func (l *lazyOutputBinding) Close() error {
+	return l.close()
+}
+
+// lazyConfigurationStore is a configuration store initialized on its first read
+type lazyConfigurationStore struct {
+	lazyInit
+}
+
+func newLazyConfigurationStore(init func() (interface{}, error)) *lazyConfigurationStore {
+	return &lazyConfigurationStore{lazyInit: lazyInit{init: init}}
+}
+
+// Init does nothing, the store is initialized on its first read
+func (l *lazyConfigurationStore) Init(metadata configuration.Metadata) error {
+	return nil
+}
+
+func (l *lazyConfigurationStore) Get(ctx context.Context, req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	store, err := l.ensureInitialized()
+	if err != nil {
+		return nil, err
+	}
+	return store.(configuration.Store).Get(ctx, req)
+}
+
+func (l *lazyConfigurationStore) Subscribe(ctx context.Context, req *configuration.SubscribeRequest, handler func(*configuration.UpdateEvent) error) error {
+	store, err := l.ensureInitialized()
+	if err != nil {
+		return err
+	}
+	return store.(configuration.Store).Subscribe(ctx, req, handler)
+}
+
+func (l *lazyConfigurationStore) Close() error {
+	return l.close()
+}
+
+// lazyKeyVault is a key vault initialized on its first use
+type lazyKeyVault struct {
+	lazyInit
+}
+
+func newLazyKeyVault(init func() (interface{}, error)) *lazyKeyVault {
+	return &lazyKeyVault{lazyInit: lazyInit{init: init}}
+}
+
+// Init does nothing, the vault is initialized on its first use
+func (l *lazyKeyVault) Init(metadata crypto.Metadata) error {
+	return nil
+}
+
+func (l *lazyKeyVault) WrapKey(ctx context.Context, key []byte, keyName, algorithm string) ([]byte, error) {
+	vault, err := l.ensureInitialized()
+	if err != nil {
+		return nil, err
+	}
+	return vault.(crypto.KeyVault).WrapKey(ctx, key, keyName, algorithm)
+}
+
+func (l *lazyKeyVault) UnwrapKey(ctx context.Context, wrappedKey []byte, keyName, algorithm string) ([]byte, error) {
+	vault, err := l.ensureInitialized()
+	if err != nil {
+		return nil, err
+	}
+	return vault.(crypto.KeyVault).UnwrapKey(ctx, wrappedKey, keyName, algorithm)
+}
+
+func (l *lazyKeyVault) Close() error {
+	return l.close()
+}
+
+// registerLazyComponent records a lazy component as pending until its first use
+func (a *DaprRuntime) registerLazyComponent(c components_v1alpha1.Component) {
+	log.Infof("component %s (%s) is initialized on first use", c.ObjectMeta.Name, c.Spec.Type)
+	a.setComponentStatus(c, components.StatusPending, nil)
+}
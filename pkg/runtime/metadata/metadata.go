@@ -0,0 +1,54 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package metadata
+
+import (
+	"sort"
+	"sync"
+)
+
+// App describes how the sidecar reaches the app
+type App struct {
+	Port     int
+	Protocol string
+}
+
+// Attribute is a custom attribute of the sidecar
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// Attributes holds the custom attributes set through the metadata APIs, so tools can tag the sidecar. The
+// attributes are shared by the HTTP and gRPC APIs.
+type Attributes struct {
+	lock   sync.RWMutex
+	values map[string]string
+}
+
+// NewAttributes returns empty attributes
+func NewAttributes() *Attributes {
+	return &Attributes{values: map[string]string{}}
+}
+
+// Set sets the value of an attribute
+func (a *Attributes) Set(key, value string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.values[key] = value
+}
+
+// List returns the attributes sorted by key
+func (a *Attributes) List() []Attribute {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	list := make([]Attribute, 0, len(a.values))
+	for k, v := range a.values {
+		list = append(list, Attribute{Key: k, Value: v})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list
+}
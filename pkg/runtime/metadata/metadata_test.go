@@ -0,0 +1,22 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributes(t *testing.T) {
+	attributes := NewAttributes()
+	assert.Empty(t, attributes.List())
+
+	attributes.Set("b", "1")
+	attributes.Set("a", "2")
+	attributes.Set("b", "3")
+	assert.Equal(t, []Attribute{{Key: "a", Value: "2"}, {Key: "b", Value: "3"}}, attributes.List())
+}
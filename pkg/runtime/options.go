@@ -1,7 +1,10 @@
 package runtime
 
 import (
+	"github.com/dapr/dapr/pkg/actors"
 	"github.com/dapr/dapr/pkg/components/bindings"
+	"github.com/dapr/dapr/pkg/components/configuration"
+	"github.com/dapr/dapr/pkg/components/crypto"
 	"github.com/dapr/dapr/pkg/components/exporters"
 	"github.com/dapr/dapr/pkg/components/middleware/http"
 	"github.com/dapr/dapr/pkg/components/pubsub"
@@ -15,12 +18,15 @@ type (
 	runtimeOpts struct {
 		secretStores     []secretstores.SecretStore
 		states           []state.State
+		configurations   []configuration.Configuration
+		keyVaults        []crypto.KeyVault
 		pubsubs          []pubsub.PubSub
 		exporters        []exporters.Exporter
 		serviceDiscovery []servicediscovery.ServiceDiscovery
 		inputBindings    []bindings.InputBinding
 		outputBindings   []bindings.OutputBinding
 		httpMiddleware   []http.Middleware
+		placementClient  actors.PlacementClient
 	}
 
 	// Option is a function that customizes the runtime.
@@ -41,6 +47,20 @@ func WithStates(states ...state.State) Option {
 	}
 }
 
+// WithConfigurations adds configuration store components to the runtime.
+func WithConfigurations(configurations ...configuration.Configuration) Option {
+	return func(o *runtimeOpts) {
+		o.configurations = append(o.configurations, configurations...)
+	}
+}
+
+// WithKeyVaults adds key vault components to the runtime.
+func WithKeyVaults(keyVaults ...crypto.KeyVault) Option {
+	return func(o *runtimeOpts) {
+		o.keyVaults = append(o.keyVaults, keyVaults...)
+	}
+}
+
 // WithPubSubs adds pubsub store components to the runtime.
 func WithPubSubs(pubsubs ...pubsub.PubSub) Option {
 	return func(o *runtimeOpts) {
@@ -82,3 +102,10 @@ func WithHTTPMiddleware(httpMiddleware ...http.Middleware) Option {
 		o.httpMiddleware = append(o.httpMiddleware, httpMiddleware...)
 	}
 }
+
+// WithPlacementClient sets the membership backend of the actor runtime, which defaults to the Dapr placement service.
+func WithPlacementClient(placementClient actors.PlacementClient) Option {
+	return func(o *runtimeOpts) {
+		o.placementClient = placementClient
+	}
+}
@@ -0,0 +1,48 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AppResponseStatusHeader is the gRPC header or trailer used by apps to return the processing status of a topic event
+const AppResponseStatusHeader = "dapr-topic-event-status"
+
+var (
+	// ErrMessageDropped is returned when the app asks for a message to be dropped
+	ErrMessageDropped = errors.New("app requested the message to be dropped")
+	// ErrMessageRetry is returned when the app asks for a message to be redelivered
+	ErrMessageRetry = errors.New("app requested the message to be retried")
+)
+
+// AppResponse is the body an app may return to report the processing status of a delivered message
+type AppResponse struct {
+	Status string `json:"status"`
+}
+
+// AppResponseError converts the status returned by the app to the error of the delivery.
+// An empty status means success, which keeps apps that do not return a status working.
+func AppResponseError(status string) error {
+	switch strings.ToUpper(strings.TrimSpace(status)) {
+	case "", AppResponseStatusSuccess:
+		return nil
+	case AppResponseStatusRetry:
+		return ErrMessageRetry
+	case AppResponseStatusDrop:
+		return ErrMessageDropped
+	default:
+		return fmt.Errorf("unknown status %q returned from app while processing pub/sub event", status)
+	}
+}
+
+// AppResponseStatusFromBody returns the status of an HTTP app response body.
+// Bodies which are not a JSON object holding a status return an empty status.
+func AppResponseStatusFromBody(body []byte) string {
+	var resp AppResponse
+	if len(body) == 0 || json.Unmarshal(body, &resp) != nil {
+		return ""
+	}
+	return resp.Status
+}
@@ -0,0 +1,23 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppResponseError(t *testing.T) {
+	assert.NoError(t, AppResponseError(""))
+	assert.NoError(t, AppResponseError("SUCCESS"))
+	assert.NoError(t, AppResponseError("success"))
+	assert.Equal(t, ErrMessageRetry, AppResponseError("RETRY"))
+	assert.Equal(t, ErrMessageDropped, AppResponseError("drop"))
+	assert.Error(t, AppResponseError("UNKNOWN"))
+}
+
+func TestAppResponseStatusFromBody(t *testing.T) {
+	assert.Equal(t, "", AppResponseStatusFromBody(nil))
+	assert.Equal(t, "", AppResponseStatusFromBody([]byte("OK")))
+	assert.Equal(t, "", AppResponseStatusFromBody([]byte(`{"result": "done"}`)))
+	assert.Equal(t, "RETRY", AppResponseStatusFromBody([]byte(`{"status": "RETRY"}`)))
+}
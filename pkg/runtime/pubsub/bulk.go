@@ -0,0 +1,79 @@
+package pubsub
+
+import (
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// BulkPublishSucceeded is the status of a bulk publish entry that was published successfully
+	BulkPublishSucceeded = "SUCCESS"
+	// BulkPublishFailed is the status of a bulk publish entry that failed to publish
+	BulkPublishFailed = "FAILED"
+)
+
+// BulkPublishRequestEntry is a single event in a bulk publish request
+type BulkPublishRequestEntry struct {
+	EntryID string `json:"entryId"`
+	Data    []byte `json:"data"`
+}
+
+// BulkPublishRequest is a request to publish a batch of events to a topic
+type BulkPublishRequest struct {
+	PubSubName string                    `json:"pubsubName,omitempty"`
+	Topic      string                    `json:"topic"`
+	Entries    []BulkPublishRequestEntry `json:"entries"`
+}
+
+// BulkPublishResponseEntry is the publish status of a single entry in a bulk publish request
+type BulkPublishResponseEntry struct {
+	EntryID string `json:"entryId"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkPublisher is implemented by pub/sub components with native batch publishing
+type BulkPublisher interface {
+	BulkPublish(req *BulkPublishRequest) ([]BulkPublishResponseEntry, error)
+}
+
+// PublishBulk publishes all entries of the request to the given pub/sub component. The component's
+// native batch capability is used when available, otherwise entries are published one at a time.
+func PublishBulk(ps pubsub.PubSub, req *BulkPublishRequest) []BulkPublishResponseEntry {
+	if bp, ok := ps.(BulkPublisher); ok {
+		statuses, err := bp.BulkPublish(req)
+		if err == nil {
+			return statuses
+		}
+		return failedEntries(req.Entries, err)
+	}
+
+	statuses := make([]BulkPublishResponseEntry, 0, len(req.Entries))
+	for _, e := range req.Entries {
+		status := BulkPublishResponseEntry{
+			EntryID: e.EntryID,
+			Status:  BulkPublishSucceeded,
+		}
+		err := ps.Publish(&pubsub.PublishRequest{
+			Topic: req.Topic,
+			Data:  e.Data,
+		})
+		if err != nil {
+			status.Status = BulkPublishFailed
+			status.Error = err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func failedEntries(entries []BulkPublishRequestEntry, err error) []BulkPublishResponseEntry {
+	statuses := make([]BulkPublishResponseEntry, 0, len(entries))
+	for _, e := range entries {
+		statuses = append(statuses, BulkPublishResponseEntry{
+			EntryID: e.EntryID,
+			Status:  BulkPublishFailed,
+			Error:   err.Error(),
+		})
+	}
+	return statuses
+}
@@ -0,0 +1,181 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// DefaultBulkSubscribeMaxMessagesCount is the default maximum number of messages delivered to the app in one batch
+	DefaultBulkSubscribeMaxMessagesCount = 100
+	// DefaultBulkSubscribeMaxAwaitDurationMs is the default time to wait for a batch to fill up before delivering it
+	DefaultBulkSubscribeMaxAwaitDurationMs = 1000
+
+	// AppResponseStatusSuccess means the app processed the message successfully
+	AppResponseStatusSuccess = "SUCCESS"
+	// AppResponseStatusRetry means the app asks for the message to be redelivered
+	AppResponseStatusRetry = "RETRY"
+	// AppResponseStatusDrop means the app asks for the message to be dropped without redelivery
+	AppResponseStatusDrop = "DROP"
+)
+
+// BulkSubscribeSpec configures batched delivery of messages to the app for a subscription
+type BulkSubscribeSpec struct {
+	Enabled            bool `json:"enabled"`
+	MaxMessagesCount   int  `json:"maxMessagesCount,omitempty"`
+	MaxAwaitDurationMs int  `json:"maxAwaitDurationMs,omitempty"`
+}
+
+// BulkSubscribeMessage is a batch of messages delivered to the app
+type BulkSubscribeMessage struct {
+	Topic   string                      `json:"topic"`
+	Entries []BulkSubscribeMessageEntry `json:"entries"`
+}
+
+// BulkSubscribeMessageEntry is a single message of a batch delivered to the app
+type BulkSubscribeMessageEntry struct {
+	EntryID string          `json:"entryId"`
+	Event   json.RawMessage `json:"event"`
+}
+
+// BulkSubscribeResponse is the app response to a batch of messages
+type BulkSubscribeResponse struct {
+	Statuses []BulkSubscribeResponseEntry `json:"statuses"`
+}
+
+// BulkSubscribeResponseEntry is the processing status of a single message of a batch
+type BulkSubscribeResponseEntry struct {
+	EntryID string `json:"entryId"`
+	Status  string `json:"status"`
+}
+
+// BulkDeliverFn delivers a batch of messages of a topic to the app and returns an error per message
+type BulkDeliverFn func(topic string, msgs []*pubsub.NewMessage) []error
+
+// BulkSubscriber groups messages arriving on a topic and delivers them to the app in batches.
+// Each message handler blocks until the batch containing its message was delivered, so the
+// pub/sub component acknowledges or redelivers every message individually.
+//
+// A component which hands over its messages one at a time never fills a batch, so once a batch times out with
+// a single message and no other message handed over, the messages are delivered as they arrive, until a
+// message is handed over while another one is being delivered.
+type BulkSubscriber struct {
+	topic     string
+	maxCount  int
+	maxAwait  time.Duration
+	deliverFn BulkDeliverFn
+
+	lock    sync.Mutex
+	pending []*bulkItem
+	timer   *time.Timer
+	// inFlight is the number of the messages handed over which wait for their delivery
+	inFlight int
+	// serial is whether the component hands over its messages one at a time
+	serial bool
+}
+
+type bulkItem struct {
+	msg    *pubsub.NewMessage
+	result chan error
+}
+
+// NewBulkSubscriber returns a new BulkSubscriber for the given topic
+func NewBulkSubscriber(topic string, spec BulkSubscribeSpec, deliverFn BulkDeliverFn) *BulkSubscriber {
+	maxCount := spec.MaxMessagesCount
+	if maxCount <= 0 {
+		maxCount = DefaultBulkSubscribeMaxMessagesCount
+	}
+	maxAwait := spec.MaxAwaitDurationMs
+	if maxAwait <= 0 {
+		maxAwait = DefaultBulkSubscribeMaxAwaitDurationMs
+	}
+
+	return &BulkSubscriber{
+		topic:     topic,
+		maxCount:  maxCount,
+		maxAwait:  time.Duration(maxAwait) * time.Millisecond,
+		deliverFn: deliverFn,
+	}
+}
+
+// Handle adds the message to the current batch and waits for the result of its delivery
+func (b *BulkSubscriber) Handle(msg *pubsub.NewMessage) error {
+	item := &bulkItem{
+		msg:    msg,
+		result: make(chan error, 1),
+	}
+
+	b.lock.Lock()
+	b.inFlight++
+	defer func() {
+		b.lock.Lock()
+		b.inFlight--
+		b.lock.Unlock()
+	}()
+	if b.inFlight > 1 {
+		b.serial = false
+	}
+	if b.serial {
+		b.lock.Unlock()
+		b.deliver([]*bulkItem{item})
+		return <-item.result
+	}
+
+	b.pending = append(b.pending, item)
+	if len(b.pending) >= b.maxCount {
+		batch := b.takePending()
+		b.lock.Unlock()
+		go b.deliver(batch)
+	} else {
+		if len(b.pending) == 1 {
+			b.timer = time.AfterFunc(b.maxAwait, b.flush)
+		}
+		b.lock.Unlock()
+	}
+
+	return <-item.result
+}
+
+func (b *BulkSubscriber) flush() {
+	b.lock.Lock()
+	batch := b.takePending()
+	if len(batch) == 1 && b.inFlight == 1 {
+		b.serial = true
+	}
+	b.lock.Unlock()
+
+	if len(batch) > 0 {
+		b.deliver(batch)
+	}
+}
+
+// takePending must be called while holding the lock
+func (b *BulkSubscriber) takePending() []*bulkItem {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	batch := b.pending
+	b.pending = nil
+	return batch
+}
+
+func (b *BulkSubscriber) deliver(batch []*bulkItem) {
+	msgs := make([]*pubsub.NewMessage, len(batch))
+	for i, item := range batch {
+		msgs[i] = item.msg
+	}
+
+	errs := b.deliverFn(b.topic, msgs)
+	for i, item := range batch {
+		if i < len(errs) {
+			item.result <- errs[i]
+		} else {
+			item.result <- errors.New("missing delivery result for message")
+		}
+	}
+}
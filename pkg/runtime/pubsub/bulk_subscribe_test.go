@@ -0,0 +1,129 @@
+package pubsub
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkSubscriber(t *testing.T) {
+	t.Run("delivers when max count is reached", func(t *testing.T) {
+		var batches [][]*pubsub.NewMessage
+		var lock sync.Mutex
+		deliverFn := func(topic string, msgs []*pubsub.NewMessage) []error {
+			lock.Lock()
+			defer lock.Unlock()
+			batches = append(batches, msgs)
+			errs := make([]error, len(msgs))
+			for i, m := range msgs {
+				if string(m.Data) == "bad" {
+					errs[i] = errors.New("retry")
+				}
+			}
+			return errs
+		}
+		b := NewBulkSubscriber("topic1", BulkSubscribeSpec{Enabled: true, MaxMessagesCount: 2, MaxAwaitDurationMs: 60000}, deliverFn)
+
+		var wg sync.WaitGroup
+		results := make([]error, 2)
+		for i, data := range []string{"good", "bad"} {
+			wg.Add(1)
+			go func(i int, data string) {
+				defer wg.Done()
+				results[i] = b.Handle(&pubsub.NewMessage{Topic: "topic1", Data: []byte(data)})
+			}(i, data)
+		}
+		wg.Wait()
+
+		assert.Len(t, batches, 1)
+		assert.Len(t, batches[0], 2)
+		assert.Equal(t, 1, countErrors(results))
+	})
+
+	t.Run("delivers partial batch after max await", func(t *testing.T) {
+		delivered := 0
+		deliverFn := func(topic string, msgs []*pubsub.NewMessage) []error {
+			delivered += len(msgs)
+			return make([]error, len(msgs))
+		}
+		b := NewBulkSubscriber("topic1", BulkSubscribeSpec{Enabled: true, MaxMessagesCount: 10, MaxAwaitDurationMs: 10}, deliverFn)
+
+		err := b.Handle(&pubsub.NewMessage{Topic: "topic1", Data: []byte("data")})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+	})
+
+	t.Run("messages handed over one at a time are delivered as they arrive", func(t *testing.T) {
+		var batches []int
+		var lock sync.Mutex
+		release := make(chan struct{})
+		deliverFn := func(topic string, msgs []*pubsub.NewMessage) []error {
+			if string(msgs[0].Data) == "slow" {
+				<-release
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			batches = append(batches, len(msgs))
+			return make([]error, len(msgs))
+		}
+		b := NewBulkSubscriber("topic1", BulkSubscribeSpec{Enabled: true, MaxMessagesCount: 10, MaxAwaitDurationMs: 100}, deliverFn)
+
+		// the first message waits for the batch to time out
+		assert.NoError(t, b.Handle(&pubsub.NewMessage{Topic: "topic1", Data: []byte("data")}))
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, b.Handle(&pubsub.NewMessage{Topic: "topic1", Data: []byte("data")}))
+		}
+		assert.True(t, time.Since(start) < 100*time.Millisecond)
+		assert.Equal(t, []int{1, 1, 1, 1, 1, 1}, batches)
+
+		// the messages handed over while another one is delivered are batched again
+		done := make(chan error)
+		go func() {
+			done <- b.Handle(&pubsub.NewMessage{Topic: "topic1", Data: []byte("slow")})
+		}()
+		assert.Eventually(t, func() bool {
+			b.lock.Lock()
+			defer b.lock.Unlock()
+			return b.inFlight == 1
+		}, time.Second, time.Millisecond)
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, b.Handle(&pubsub.NewMessage{Topic: "topic1", Data: []byte("data")}))
+			}()
+		}
+		wg.Wait()
+		close(release)
+		assert.NoError(t, <-done)
+		lock.Lock()
+		defer lock.Unlock()
+		assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 2, 1}, batches)
+	})
+
+	t.Run("missing result is an error", func(t *testing.T) {
+		deliverFn := func(topic string, msgs []*pubsub.NewMessage) []error {
+			return nil
+		}
+		b := NewBulkSubscriber("topic1", BulkSubscribeSpec{Enabled: true, MaxMessagesCount: 1}, deliverFn)
+
+		err := b.Handle(&pubsub.NewMessage{Topic: "topic1", Data: []byte("data")})
+		assert.Error(t, err)
+	})
+}
+
+func countErrors(errs []error) int {
+	n := 0
+	for _, err := range errs {
+		if err != nil {
+			n++
+		}
+	}
+	return n
+}
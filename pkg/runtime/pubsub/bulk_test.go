@@ -0,0 +1,75 @@
+package pubsub
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePubSub struct {
+	published []*pubsub.PublishRequest
+}
+
+func (f *fakePubSub) Init(metadata pubsub.Metadata) error {
+	return nil
+}
+
+func (f *fakePubSub) Publish(req *pubsub.PublishRequest) error {
+	if string(req.Data) == "fail" {
+		return errors.New("publish failed")
+	}
+	f.published = append(f.published, req)
+	return nil
+}
+
+func (f *fakePubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	return nil
+}
+
+type fakeBulkPubSub struct {
+	fakePubSub
+	err error
+}
+
+func (f *fakeBulkPubSub) BulkPublish(req *BulkPublishRequest) ([]BulkPublishResponseEntry, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []BulkPublishResponseEntry{{EntryID: "native", Status: BulkPublishSucceeded}}, nil
+}
+
+func TestPublishBulk(t *testing.T) {
+	req := &BulkPublishRequest{
+		Topic: "topic1",
+		Entries: []BulkPublishRequestEntry{
+			{EntryID: "1", Data: []byte("ok")},
+			{EntryID: "2", Data: []byte("fail")},
+		},
+	}
+
+	t.Run("fan out to component", func(t *testing.T) {
+		ps := &fakePubSub{}
+		statuses := PublishBulk(ps, req)
+		assert.Len(t, statuses, 2)
+		assert.Equal(t, BulkPublishSucceeded, statuses[0].Status)
+		assert.Equal(t, BulkPublishFailed, statuses[1].Status)
+		assert.Equal(t, "publish failed", statuses[1].Error)
+		assert.Len(t, ps.published, 1)
+	})
+
+	t.Run("native batch", func(t *testing.T) {
+		statuses := PublishBulk(&fakeBulkPubSub{}, req)
+		assert.Len(t, statuses, 1)
+		assert.Equal(t, "native", statuses[0].EntryID)
+	})
+
+	t.Run("native batch error fails all entries", func(t *testing.T) {
+		statuses := PublishBulk(&fakeBulkPubSub{err: errors.New("broker down")}, req)
+		assert.Len(t, statuses, 2)
+		for _, s := range statuses {
+			assert.Equal(t, BulkPublishFailed, s.Status)
+		}
+	})
+}
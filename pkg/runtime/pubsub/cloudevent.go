@@ -0,0 +1,257 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	daprclientv1pb "github.com/dapr/dapr/pkg/proto/daprclient/v1"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+const (
+	// CloudEventsSpecVersion is the CloudEvents spec version of the envelopes created by Dapr
+	CloudEventsSpecVersion = "1.0"
+	// DefaultCloudEventType is the default event type of a Dapr published event
+	DefaultCloudEventType = "com.dapr.event.sent"
+	// CloudEventsContentType is the content type of a structured mode CloudEvent
+	CloudEventsContentType = "application/cloudevents+json"
+	// CloudEventExtensionHeaderPrefix is the prefix of the gRPC headers carrying CloudEvent extension attributes to the app
+	CloudEventExtensionHeaderPrefix = "ce-"
+
+	// cloudEventMetadataPrefix is the prefix of publish metadata keys which are added as CloudEvent attributes
+	cloudEventMetadataPrefix = "cloudevent."
+	// traceIDAttribute is the extension attribute holding the trace id of the publisher
+	traceIDAttribute = "traceid"
+	// expirationAttribute is the extension attribute holding the time after which the event is dropped
+	expirationAttribute = "expiration"
+	// partitionKeyAttribute is the extension attribute holding the partition key of the event
+	partitionKeyAttribute = "partitionkey"
+
+	// TTLMetadataKey is the publish metadata key holding the time to live of an event in seconds
+	TTLMetadataKey = "ttlInSeconds"
+	// PartitionKeyMetadataKey is the publish metadata key holding the partition key of an event.
+	// Events sharing a partition key are delivered to the app one at a time, in the order they are received.
+	PartitionKeyMetadataKey = "partitionKey"
+)
+
+// cloudEventCoreAttributes are the CloudEvent context attributes and data fields defined by the spec
+var cloudEventCoreAttributes = map[string]bool{
+	"id":              true,
+	"source":          true,
+	"specversion":     true,
+	"type":            true,
+	"datacontenttype": true,
+	"dataschema":      true,
+	"subject":         true,
+	"time":            true,
+	"data":            true,
+	"data_base64":     true,
+}
+
+// protectedCloudEventAttributes cannot be overridden through publish metadata
+var protectedCloudEventAttributes = map[string]bool{
+	"specversion": true,
+	"data":        true,
+	"data_base64": true,
+}
+
+// NewCloudEvent wraps the data in a serialized CloudEvents 1.0 envelope.
+// If the content type is application/cloudevents+json the data is treated as a pre-formed envelope,
+// only missing required attributes are filled in. Metadata entries prefixed with "cloudevent."
+// are added as attributes, which allows publishers to set extension attributes.
+func NewCloudEvent(id, source, traceID, contentType string, data []byte, metadata map[string]string) ([]byte, error) {
+	event := map[string]interface{}{}
+	if strings.HasPrefix(contentType, CloudEventsContentType) {
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("error deserializing cloud event: %s", err)
+		}
+	} else {
+		setCloudEventData(event, contentType, data)
+	}
+
+	setDefaultAttribute(event, "id", id)
+	setDefaultAttribute(event, "source", source)
+	setDefaultAttribute(event, "type", DefaultCloudEventType)
+	setDefaultAttribute(event, "specversion", CloudEventsSpecVersion)
+	now := time.Now().UTC()
+	setDefaultAttribute(event, "time", now.Format(time.RFC3339))
+	if traceID != "" {
+		setDefaultAttribute(event, traceIDAttribute, traceID)
+	}
+
+	ttl, err := getTTL(metadata)
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		event[expirationAttribute] = now.Add(ttl).Format(time.RFC3339)
+	}
+
+	deliverAt, err := GetDeliverAt(metadata, now)
+	if err != nil {
+		return nil, err
+	}
+	if !deliverAt.IsZero() {
+		event[deliverAtAttribute] = deliverAt.Format(time.RFC3339)
+	}
+
+	for k, v := range metadata {
+		if strings.EqualFold(k, PartitionKeyMetadataKey) && v != "" {
+			event[partitionKeyAttribute] = v
+		}
+	}
+
+	for k, v := range metadata {
+		if !strings.HasPrefix(k, cloudEventMetadataPrefix) {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(k, cloudEventMetadataPrefix))
+		if name == "" || protectedCloudEventAttributes[name] {
+			continue
+		}
+		event[name] = v
+	}
+
+	return json.Marshal(event)
+}
+
+// HasExpired returns true if the serialized CloudEvent has an expiration attribute in the past
+func HasExpired(cloudEvent []byte) bool {
+	var event struct {
+		Expiration string `json:"expiration"`
+	}
+	if err := json.Unmarshal(cloudEvent, &event); err != nil || event.Expiration == "" {
+		return false
+	}
+
+	expiration, err := time.Parse(time.RFC3339, event.Expiration)
+	if err != nil {
+		return false
+	}
+	return time.Now().UTC().After(expiration)
+}
+
+// PartitionKey returns the partition key of a serialized CloudEvent, or an empty string if it has none
+func PartitionKey(cloudEvent []byte) string {
+	var event struct {
+		PartitionKey string `json:"partitionkey"`
+	}
+	if err := json.Unmarshal(cloudEvent, &event); err != nil {
+		return ""
+	}
+	return event.PartitionKey
+}
+
+func getTTL(metadata map[string]string) (time.Duration, error) {
+	for k, v := range metadata {
+		if !strings.EqualFold(k, TTLMetadataKey) {
+			continue
+		}
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || seconds < 0 {
+			return 0, fmt.Errorf("invalid %s value %q", TTLMetadataKey, v)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, nil
+}
+
+// CloudEventExtensions returns the extension attributes of a serialized CloudEvent
+func CloudEventExtensions(cloudEvent []byte) (map[string]string, error) {
+	var event map[string]interface{}
+	if err := json.Unmarshal(cloudEvent, &event); err != nil {
+		return nil, err
+	}
+
+	extensions := map[string]string{}
+	for k, v := range event {
+		if cloudEventCoreAttributes[k] {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			extensions[k] = s
+		} else {
+			b, _ := json.Marshal(v)
+			extensions[k] = string(b)
+		}
+	}
+	return extensions, nil
+}
+
+func setCloudEventData(event map[string]interface{}, contentType string, data []byte) {
+	switch {
+	case json.Valid(data) && (contentType == "" || strings.Contains(contentType, "json")):
+		event["data"] = json.RawMessage(data)
+		if contentType == "" {
+			contentType = "application/json"
+		}
+	case utf8.Valid(data):
+		event["data"] = string(data)
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+	default:
+		event["data_base64"] = data
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+	}
+	event["datacontenttype"] = contentType
+}
+
+func setDefaultAttribute(event map[string]interface{}, name, value string) {
+	if v, ok := event[name]; !ok || v == "" {
+		event[name] = value
+	}
+}
+
+// structuredCloudEvent holds the attributes of a serialized CloudEvent delivered to gRPC apps
+type structuredCloudEvent struct {
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	SpecVersion     string          `json:"specversion"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+	DataBase64      []byte          `json:"data_base64"`
+}
+
+// NewTopicEventEnvelope converts a serialized CloudEvent to the envelope delivered to gRPC apps
+// and returns the extension attributes of the event, which do not have a field in the envelope.
+func NewTopicEventEnvelope(topic string, cloudEvent []byte) (*daprclientv1pb.CloudEventEnvelope, map[string]string, error) {
+	var event structuredCloudEvent
+	if err := json.Unmarshal(cloudEvent, &event); err != nil {
+		return nil, nil, fmt.Errorf("error deserializing cloud event: %s", err)
+	}
+
+	envelope := &daprclientv1pb.CloudEventEnvelope{
+		Id:              event.ID,
+		Source:          event.Source,
+		DataContentType: event.DataContentType,
+		Type:            event.Type,
+		SpecVersion:     event.SpecVersion,
+		Topic:           topic,
+	}
+
+	switch {
+	case event.DataBase64 != nil:
+		envelope.Data = &any.Any{Value: event.DataBase64}
+	case len(event.Data) > 0 && string(event.Data) != "null":
+		var s string
+		if !strings.Contains(event.DataContentType, "json") && json.Unmarshal(event.Data, &s) == nil {
+			envelope.Data = &any.Any{Value: []byte(s)}
+		} else {
+			envelope.Data = &any.Any{Value: event.Data}
+		}
+	}
+
+	extensions, err := CloudEventExtensions(cloudEvent)
+	if err != nil {
+		return nil, nil, err
+	}
+	return envelope, extensions, nil
+}
@@ -0,0 +1,166 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCloudEvent(t *testing.T) {
+	t.Run("json data", func(t *testing.T) {
+		b, err := NewCloudEvent("1", "app1", "trace1", "", []byte(`{"order": 1}`), nil)
+		assert.NoError(t, err)
+
+		var event map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b, &event))
+		assert.Equal(t, "1", event["id"])
+		assert.Equal(t, "app1", event["source"])
+		assert.Equal(t, DefaultCloudEventType, event["type"])
+		assert.Equal(t, CloudEventsSpecVersion, event["specversion"])
+		assert.Equal(t, "application/json", event["datacontenttype"])
+		assert.Equal(t, "trace1", event["traceid"])
+		assert.NotEmpty(t, event["time"])
+		assert.Equal(t, map[string]interface{}{"order": float64(1)}, event["data"])
+	})
+
+	t.Run("text data", func(t *testing.T) {
+		b, err := NewCloudEvent("1", "app1", "", "", []byte("hello"), nil)
+		assert.NoError(t, err)
+
+		var event map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b, &event))
+		assert.Equal(t, "text/plain", event["datacontenttype"])
+		assert.Equal(t, "hello", event["data"])
+		assert.NotContains(t, event, "traceid")
+	})
+
+	t.Run("binary data", func(t *testing.T) {
+		b, err := NewCloudEvent("1", "app1", "", "", []byte{0xff, 0xfe}, nil)
+		assert.NoError(t, err)
+
+		var event map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b, &event))
+		assert.Equal(t, "application/octet-stream", event["datacontenttype"])
+		assert.Equal(t, "//4=", event["data_base64"])
+	})
+
+	t.Run("extension attributes from metadata", func(t *testing.T) {
+		metadata := map[string]string{
+			"cloudevent.partitionkey": "p1",
+			"cloudevent.type":         "order.created",
+			"cloudevent.specversion":  "0.3",
+			"ttlInSeconds":            "10",
+		}
+		b, err := NewCloudEvent("1", "app1", "", "", []byte("hello"), metadata)
+		assert.NoError(t, err)
+
+		var event map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b, &event))
+		assert.Equal(t, "p1", event["partitionkey"])
+		assert.Equal(t, "order.created", event["type"])
+		assert.Equal(t, CloudEventsSpecVersion, event["specversion"])
+		assert.NotContains(t, event, "ttlInSeconds")
+	})
+
+	t.Run("pre-formed envelope", func(t *testing.T) {
+		data := []byte(`{"id": "custom", "type": "my.type", "myext": "value", "data": "hello", "datacontenttype": "text/plain"}`)
+		b, err := NewCloudEvent("1", "app1", "", CloudEventsContentType, data, nil)
+		assert.NoError(t, err)
+
+		var event map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b, &event))
+		assert.Equal(t, "custom", event["id"])
+		assert.Equal(t, "my.type", event["type"])
+		assert.Equal(t, "value", event["myext"])
+		assert.Equal(t, "app1", event["source"])
+		assert.Equal(t, CloudEventsSpecVersion, event["specversion"])
+	})
+
+	t.Run("invalid pre-formed envelope", func(t *testing.T) {
+		_, err := NewCloudEvent("1", "app1", "", CloudEventsContentType, []byte("not json"), nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewTopicEventEnvelope(t *testing.T) {
+	t.Run("extensions are returned", func(t *testing.T) {
+		b, _ := NewCloudEvent("1", "app1", "trace1", "", []byte(`{"order": 1}`), map[string]string{"cloudevent.myext": "value"})
+		envelope, extensions, err := NewTopicEventEnvelope("topic1", b)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", envelope.Id)
+		assert.Equal(t, "topic1", envelope.Topic)
+		assert.Equal(t, CloudEventsSpecVersion, envelope.SpecVersion)
+		assert.JSONEq(t, `{"order": 1}`, string(envelope.Data.Value))
+		assert.Equal(t, map[string]string{"myext": "value", "traceid": "trace1"}, extensions)
+	})
+
+	t.Run("text data", func(t *testing.T) {
+		b, _ := NewCloudEvent("1", "app1", "", "", []byte("hello"), nil)
+		envelope, _, err := NewTopicEventEnvelope("topic1", b)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(envelope.Data.Value))
+	})
+
+	t.Run("binary data", func(t *testing.T) {
+		b, _ := NewCloudEvent("1", "app1", "", "", []byte{0xff, 0xfe}, nil)
+		envelope, _, err := NewTopicEventEnvelope("topic1", b)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0xfe}, envelope.Data.Value)
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		_, _, err := NewTopicEventEnvelope("topic1", []byte("not json"))
+		assert.Error(t, err)
+	})
+}
+
+func TestCloudEventTTL(t *testing.T) {
+	t.Run("expiration is set from ttl", func(t *testing.T) {
+		b, err := NewCloudEvent("1", "app1", "", "", []byte("hello"), map[string]string{"ttlInSeconds": "60"})
+		assert.NoError(t, err)
+
+		var event map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b, &event))
+		assert.NotEmpty(t, event["expiration"])
+		assert.False(t, HasExpired(b))
+	})
+
+	t.Run("gRPC metadata keys are lower case", func(t *testing.T) {
+		b, err := NewCloudEvent("1", "app1", "", "", []byte("hello"), map[string]string{"ttlinseconds": "60"})
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), "expiration")
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		_, err := NewCloudEvent("1", "app1", "", "", []byte("hello"), map[string]string{"ttlInSeconds": "abc"})
+		assert.Error(t, err)
+	})
+
+	t.Run("expired event", func(t *testing.T) {
+		assert.True(t, HasExpired([]byte(`{"expiration": "2000-01-01T00:00:00Z"}`)))
+		assert.False(t, HasExpired([]byte(`{"id": "1"}`)))
+		assert.False(t, HasExpired([]byte("raw")))
+	})
+}
+
+func TestCloudEventPartitionKey(t *testing.T) {
+	t.Run("partition key is added as an attribute", func(t *testing.T) {
+		b, err := NewCloudEvent("1", "app1", "", "", []byte("hello"), map[string]string{"partitionKey": "order-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "order-1", PartitionKey(b))
+	})
+
+	t.Run("gRPC metadata keys are lower case", func(t *testing.T) {
+		b, err := NewCloudEvent("1", "app1", "", "", []byte("hello"), map[string]string{"partitionkey": "order-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "order-1", PartitionKey(b))
+	})
+
+	t.Run("no partition key", func(t *testing.T) {
+		b, err := NewCloudEvent("1", "app1", "", "", []byte("hello"), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, PartitionKey(b))
+		assert.Empty(t, PartitionKey([]byte("raw")))
+	})
+}
@@ -0,0 +1,113 @@
+package pubsub
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// ConcurrencyMetadataKey is the subscription metadata key used by gRPC apps to set the delivery concurrency of a topic
+	ConcurrencyMetadataKey = "concurrency"
+	// MaxConcurrencyMetadataKey is the subscription metadata key used by gRPC apps to set the max in-flight messages of a topic
+	MaxConcurrencyMetadataKey = "maxConcurrency"
+
+	// ConcurrencySingle delivers the messages of a topic one at a time, in the order they are received from the component
+	ConcurrencySingle = "single"
+	// ConcurrencyParallel delivers the messages of a topic concurrently, up to the max concurrency if one is set
+	ConcurrencyParallel = "parallel"
+)
+
+// ValidateConcurrency checks the concurrency settings of a subscription
+func ValidateConcurrency(concurrency string, maxConcurrency int) error {
+	switch concurrency {
+	case "", ConcurrencySingle, ConcurrencyParallel:
+	default:
+		return fmt.Errorf("concurrency must be %s or %s, got %q", ConcurrencySingle, ConcurrencyParallel, concurrency)
+	}
+	if maxConcurrency < 0 {
+		return fmt.Errorf("max concurrency must not be negative")
+	}
+	return nil
+}
+
+// ParseConcurrency returns the concurrency settings found in the subscription metadata
+func ParseConcurrency(metadata map[string]string) (string, int, error) {
+	concurrency := strings.ToLower(metadata[ConcurrencyMetadataKey])
+	maxConcurrency := 0
+	if val, ok := metadata[MaxConcurrencyMetadataKey]; ok && val != "" {
+		i, err := strconv.Atoi(val)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid %s value %q", MaxConcurrencyMetadataKey, val)
+		}
+		maxConcurrency = i
+	}
+	if err := ValidateConcurrency(concurrency, maxConcurrency); err != nil {
+		return "", 0, err
+	}
+	return concurrency, maxConcurrency, nil
+}
+
+// LimitConcurrency returns a handler which lets at most the configured number of messages through
+// to the given handler at the same time. Single concurrency allows one message at a time, parallel
+// concurrency allows up to the max concurrency. The handler is returned unchanged when no limit is set,
+// in which case the concurrency is up to the pub/sub component.
+func LimitConcurrency(handler func(msg *pubsub.NewMessage) error, concurrency string, maxConcurrency int) func(msg *pubsub.NewMessage) error {
+	limit := maxConcurrency
+	if concurrency == ConcurrencySingle {
+		limit = 1
+	}
+	if limit <= 0 {
+		return handler
+	}
+
+	slots := make(chan struct{}, limit)
+	return func(msg *pubsub.NewMessage) error {
+		slots <- struct{}{}
+		defer func() { <-slots }()
+		return handler(msg)
+	}
+}
+
+// OrderByPartitionKey returns a handler which delivers the messages sharing a partition key one at a time,
+// in the order they are received from the component. Messages without a partition key are not ordered.
+func OrderByPartitionKey(handler func(msg *pubsub.NewMessage) error) func(msg *pubsub.NewMessage) error {
+	var lock sync.Mutex
+	keys := map[string]*partitionLock{}
+
+	return func(msg *pubsub.NewMessage) error {
+		key := PartitionKey(msg.Data)
+		if key == "" {
+			return handler(msg)
+		}
+
+		lock.Lock()
+		l, ok := keys[key]
+		if !ok {
+			l = &partitionLock{}
+			keys[key] = l
+		}
+		l.refs++
+		lock.Unlock()
+
+		l.Lock()
+		defer func() {
+			l.Unlock()
+			lock.Lock()
+			l.refs--
+			if l.refs == 0 {
+				delete(keys, key)
+			}
+			lock.Unlock()
+		}()
+		return handler(msg)
+	}
+}
+
+type partitionLock struct {
+	sync.Mutex
+	refs int
+}
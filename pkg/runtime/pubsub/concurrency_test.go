@@ -0,0 +1,118 @@
+package pubsub
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConcurrency(t *testing.T) {
+	t.Run("valid settings", func(t *testing.T) {
+		concurrency, maxConcurrency, err := ParseConcurrency(map[string]string{
+			ConcurrencyMetadataKey:    "Parallel",
+			MaxConcurrencyMetadataKey: "5",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, ConcurrencyParallel, concurrency)
+		assert.Equal(t, 5, maxConcurrency)
+	})
+
+	t.Run("no settings", func(t *testing.T) {
+		concurrency, maxConcurrency, err := ParseConcurrency(map[string]string{})
+		assert.NoError(t, err)
+		assert.Empty(t, concurrency)
+		assert.Equal(t, 0, maxConcurrency)
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		_, _, err := ParseConcurrency(map[string]string{ConcurrencyMetadataKey: "fast"})
+		assert.Error(t, err)
+		_, _, err = ParseConcurrency(map[string]string{MaxConcurrencyMetadataKey: "a"})
+		assert.Error(t, err)
+		_, _, err = ParseConcurrency(map[string]string{MaxConcurrencyMetadataKey: "-1"})
+		assert.Error(t, err)
+	})
+}
+
+func TestLimitConcurrency(t *testing.T) {
+	run := func(concurrency string, maxConcurrency int) int32 {
+		var inFlight, maxInFlight int32
+		handler := LimitConcurrency(func(msg *pubsub.NewMessage) error {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return nil
+		}, concurrency, maxConcurrency)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, handler(&pubsub.NewMessage{Topic: "topic1"}))
+			}()
+		}
+		wg.Wait()
+		return atomic.LoadInt32(&maxInFlight)
+	}
+
+	t.Run("single", func(t *testing.T) {
+		assert.Equal(t, int32(1), run(ConcurrencySingle, 0))
+	})
+
+	t.Run("parallel with max", func(t *testing.T) {
+		assert.True(t, run(ConcurrencyParallel, 3) <= 3)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		assert.True(t, run("", 0) > 1)
+	})
+}
+
+func TestOrderByPartitionKey(t *testing.T) {
+	var lock sync.Mutex
+	inFlight := map[string]int{}
+	maxInFlight := map[string]int{}
+	handler := OrderByPartitionKey(func(msg *pubsub.NewMessage) error {
+		key := PartitionKey(msg.Data)
+		lock.Lock()
+		inFlight[key]++
+		if inFlight[key] > maxInFlight[key] {
+			maxInFlight[key] = inFlight[key]
+		}
+		lock.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		lock.Lock()
+		inFlight[key]--
+		lock.Unlock()
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, data := range []string{`{"partitionkey": "a"}`, `{"partitionkey": "b"}`, `{"id": "1"}`} {
+			wg.Add(1)
+			go func(data string) {
+				defer wg.Done()
+				assert.NoError(t, handler(&pubsub.NewMessage{Topic: "topic1", Data: []byte(data)}))
+			}(data)
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, maxInFlight["a"])
+	assert.Equal(t, 1, maxInFlight["b"])
+	assert.True(t, maxInFlight[""] > 1)
+}
@@ -0,0 +1,80 @@
+package pubsub
+
+import (
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/components"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+// DeclarativeSubscription is a subscription loaded from a Subscription resource along with the namespace
+// and the apps it is scoped to
+type DeclarativeSubscription struct {
+	Subscription
+	Namespace string
+	Scopes    []string
+}
+
+// GetDeclarativeSubscriptions loads the declarative subscriptions using the given loader
+func GetDeclarativeSubscriptions(loader components.SubscriptionLoader, log logger.Logger) []DeclarativeSubscription {
+	resources, err := loader.LoadSubscriptions()
+	if err != nil {
+		log.Warnf("failed to load declarative subscriptions: %s", err)
+		return nil
+	}
+
+	subscriptions := []DeclarativeSubscription{}
+	for _, r := range resources {
+		if r.Spec.Topic == "" {
+			log.Warnf("subscription %s is missing a topic. skipping", r.ObjectMeta.Name)
+			continue
+		}
+		s := fromSubscriptionResource(r)
+		if err := normalizeSubscription(&s); err != nil {
+			log.Warnf("subscription %s %s. skipping", r.ObjectMeta.Name, err)
+			continue
+		}
+		subscriptions = append(subscriptions, DeclarativeSubscription{
+			Subscription: s,
+			Namespace:    r.ObjectMeta.Namespace,
+			Scopes:       r.Scopes,
+		})
+	}
+	return subscriptions
+}
+
+func fromSubscriptionResource(r components_v1alpha1.Subscription) Subscription {
+	s := Subscription{
+		Topic:           r.Spec.Topic,
+		PubSubName:      r.Spec.PubSubName,
+		Route:           r.Spec.Route,
+		Metadata:        r.Spec.Metadata,
+		DeadLetterTopic: r.Spec.DeadLetterTopic,
+		Concurrency:     r.Spec.Concurrency,
+		MaxConcurrency:  r.Spec.MaxConcurrency,
+	}
+	if r.Spec.BulkSubscribe != nil {
+		s.BulkSubscribe = BulkSubscribeSpec{
+			Enabled:            r.Spec.BulkSubscribe.Enabled,
+			MaxMessagesCount:   r.Spec.BulkSubscribe.MaxMessagesCount,
+			MaxAwaitDurationMs: r.Spec.BulkSubscribe.MaxAwaitDurationMs,
+		}
+	}
+	if r.Spec.RetryPolicy != nil {
+		s.RetryPolicy = &RetryPolicy{
+			MaxRetries:        r.Spec.RetryPolicy.MaxRetries,
+			InitialIntervalMs: r.Spec.RetryPolicy.InitialIntervalMs,
+			MaxIntervalMs:     r.Spec.RetryPolicy.MaxIntervalMs,
+			Jitter:            r.Spec.RetryPolicy.Jitter,
+		}
+	}
+	if r.Spec.Routes != nil {
+		s.Routes.Default = r.Spec.Routes.Default
+		for _, rule := range r.Spec.Routes.Rules {
+			s.Routes.Rules = append(s.Routes.Rules, RouteRule{
+				Match: rule.Match,
+				Path:  rule.Path,
+			})
+		}
+	}
+	return s
+}
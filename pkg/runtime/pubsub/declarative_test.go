@@ -0,0 +1,58 @@
+package pubsub
+
+import (
+	"errors"
+	"testing"
+
+	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeSubscriptionLoader struct {
+	subscriptions []components_v1alpha1.Subscription
+	err           error
+}
+
+func (f *fakeSubscriptionLoader) LoadSubscriptions() ([]components_v1alpha1.Subscription, error) {
+	return f.subscriptions, f.err
+}
+
+func TestGetDeclarativeSubscriptions(t *testing.T) {
+	t.Run("valid subscriptions are converted", func(t *testing.T) {
+		loader := &fakeSubscriptionLoader{
+			subscriptions: []components_v1alpha1.Subscription{
+				{
+					ObjectMeta: meta_v1.ObjectMeta{Namespace: "ns1"},
+					Spec: components_v1alpha1.SubscriptionSpec{
+						Topic:           "orders",
+						Route:           "/orders",
+						DeadLetterTopic: "poison",
+						BulkSubscribe:   &components_v1alpha1.BulkSubscribeSpec{Enabled: true, MaxMessagesCount: 5},
+					},
+					Scopes: []string{"app1"},
+				},
+				{
+					Spec: components_v1alpha1.SubscriptionSpec{
+						Topic: "noroute",
+					},
+				},
+			},
+		}
+
+		subs := GetDeclarativeSubscriptions(loader, log)
+		assert.Len(t, subs, 1)
+		assert.Equal(t, "orders", subs[0].Topic)
+		assert.Equal(t, "/orders", subs[0].Route)
+		assert.Equal(t, "poison", subs[0].DeadLetterTopic)
+		assert.True(t, subs[0].BulkSubscribe.Enabled)
+		assert.Equal(t, 5, subs[0].BulkSubscribe.MaxMessagesCount)
+		assert.Equal(t, []string{"app1"}, subs[0].Scopes)
+		assert.Equal(t, "ns1", subs[0].Namespace)
+	})
+
+	t.Run("loader error", func(t *testing.T) {
+		subs := GetDeclarativeSubscriptions(&fakeSubscriptionLoader{err: errors.New("error")}, log)
+		assert.Empty(t, subs)
+	})
+}
@@ -0,0 +1,111 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+const (
+	// DeduplicationStoreMetadataKey is the pub/sub component metadata key naming the state store used to deduplicate messages
+	DeduplicationStoreMetadataKey = "deduplicationStore"
+	// DeduplicationTTLMetadataKey is the pub/sub component metadata key holding how long processed message ids are kept in seconds
+	DeduplicationTTLMetadataKey = "deduplicationTTLInSeconds"
+	// DefaultDeduplicationTTL is the default time processed message ids are kept
+	DefaultDeduplicationTTL = time.Hour
+
+	deduplicationKeyPrefix = "dedup"
+	daprSeparator          = "||"
+)
+
+// Deduplicator records the ids of processed CloudEvents of a pub/sub component in a state store, so that
+// redelivered messages can be acknowledged without invoking the app again
+type Deduplicator struct {
+	appID      string
+	pubSubName string
+	store      state.Store
+	ttl        time.Duration
+}
+
+type processedMessage struct {
+	Expiration string `json:"expiration"`
+}
+
+// NewDeduplicator returns a Deduplicator keeping the processed message ids of the pub/sub component in the store
+// for the given duration
+func NewDeduplicator(appID, pubSubName string, store state.Store, ttl time.Duration) *Deduplicator {
+	return &Deduplicator{
+		appID:      appID,
+		pubSubName: pubSubName,
+		store:      store,
+		ttl:        ttl,
+	}
+}
+
+// ParseDeduplicationSpec returns the state store name and TTL configured in the pub/sub component metadata.
+// An empty store name means deduplication is disabled.
+func ParseDeduplicationSpec(metadata map[string]string) (string, time.Duration, error) {
+	ttl := DefaultDeduplicationTTL
+	if val, ok := metadata[DeduplicationTTLMetadataKey]; ok && val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil || seconds <= 0 {
+			return "", 0, fmt.Errorf("invalid %s value %q", DeduplicationTTLMetadataKey, val)
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+	return metadata[DeduplicationStoreMetadataKey], ttl, nil
+}
+
+// IsDuplicate returns true if the CloudEvent was already processed.
+// Messages without an id, or whose state cannot be read, are never considered duplicates.
+func (d *Deduplicator) IsDuplicate(topic string, cloudEvent []byte) bool {
+	key, ok := d.key(topic, cloudEvent)
+	if !ok {
+		return false
+	}
+
+	resp, err := d.store.Get(&state.GetRequest{Key: key})
+	if err != nil || resp == nil || len(resp.Data) == 0 {
+		return false
+	}
+
+	var processed processedMessage
+	if err := json.Unmarshal(resp.Data, &processed); err != nil {
+		return false
+	}
+	// The expiration is checked as well since not every state store supports TTLs
+	expiration, err := time.Parse(time.RFC3339, processed.Expiration)
+	return err == nil && time.Now().UTC().Before(expiration)
+}
+
+// MarkProcessed records the CloudEvent as processed
+func (d *Deduplicator) MarkProcessed(topic string, cloudEvent []byte) error {
+	key, ok := d.key(topic, cloudEvent)
+	if !ok {
+		return nil
+	}
+
+	return d.store.Set(&state.SetRequest{
+		Key: key,
+		Value: processedMessage{
+			Expiration: time.Now().UTC().Add(d.ttl).Format(time.RFC3339),
+		},
+		Metadata: map[string]string{
+			TTLMetadataKey: strconv.Itoa(int(d.ttl.Seconds())),
+		},
+	})
+}
+
+func (d *Deduplicator) key(topic string, cloudEvent []byte) (string, bool) {
+	var event struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(cloudEvent, &event); err != nil || event.ID == "" {
+		return "", false
+	}
+	// the same topic name on two pub/sub components holds different messages
+	return d.appID + daprSeparator + deduplicationKeyPrefix + daprSeparator + d.pubSubName + daprSeparator + topic + daprSeparator + event.ID, true
+}
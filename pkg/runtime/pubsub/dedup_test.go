@@ -0,0 +1,110 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStateStore is a state store with etags, which are the versions of the keys
+type fakeStateStore struct {
+	items    map[string][]byte
+	versions map[string]int
+}
+
+func (f *fakeStateStore) Init(metadata state.Metadata) error {
+	return nil
+}
+
+func (f *fakeStateStore) Delete(req *state.DeleteRequest) error {
+	if req.ETag != "" && req.ETag != strconv.Itoa(f.versions[req.Key]) {
+		return errors.New("etag mismatch")
+	}
+	delete(f.items, req.Key)
+	return nil
+}
+
+func (f *fakeStateStore) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	if _, ok := f.items[req.Key]; !ok {
+		return &state.GetResponse{}, nil
+	}
+	return &state.GetResponse{Data: f.items[req.Key], ETag: strconv.Itoa(f.versions[req.Key])}, nil
+}
+
+func (f *fakeStateStore) Set(req *state.SetRequest) error {
+	if f.versions == nil {
+		f.versions = map[string]int{}
+	}
+	if req.ETag != "" && req.ETag != strconv.Itoa(f.versions[req.Key]) {
+		return errors.New("etag mismatch")
+	}
+	b, _ := json.Marshal(req.Value)
+	f.items[req.Key] = b
+	f.versions[req.Key]++
+	return nil
+}
+
+func (f *fakeStateStore) BulkSet(req []state.SetRequest) error {
+	return nil
+}
+
+func TestDeduplicator(t *testing.T) {
+	event := []byte(`{"id": "event1", "data": "hello"}`)
+
+	t.Run("processed message is a duplicate", func(t *testing.T) {
+		d := NewDeduplicator("app1", "pubsub1", &fakeStateStore{items: map[string][]byte{}}, time.Hour)
+		assert.False(t, d.IsDuplicate("topic1", event))
+		assert.NoError(t, d.MarkProcessed("topic1", event))
+		assert.True(t, d.IsDuplicate("topic1", event))
+		assert.False(t, d.IsDuplicate("topic2", event))
+	})
+
+	t.Run("message of another pub sub is not a duplicate", func(t *testing.T) {
+		store := &fakeStateStore{items: map[string][]byte{}}
+		d := NewDeduplicator("app1", "pubsub1", store, time.Hour)
+		assert.NoError(t, d.MarkProcessed("topic1", event))
+		other := NewDeduplicator("app1", "pubsub2", store, time.Hour)
+		assert.False(t, other.IsDuplicate("topic1", event))
+	})
+
+	t.Run("expired record is not a duplicate", func(t *testing.T) {
+		store := &fakeStateStore{items: map[string][]byte{
+			"app1||dedup||pubsub1||topic1||event1": []byte(`{"expiration": "2000-01-01T00:00:00Z"}`),
+		}}
+		d := NewDeduplicator("app1", "pubsub1", store, time.Hour)
+		assert.False(t, d.IsDuplicate("topic1", event))
+	})
+
+	t.Run("message without id is never a duplicate", func(t *testing.T) {
+		d := NewDeduplicator("app1", "pubsub1", &fakeStateStore{items: map[string][]byte{}}, time.Hour)
+		assert.NoError(t, d.MarkProcessed("topic1", []byte("raw")))
+		assert.False(t, d.IsDuplicate("topic1", []byte("raw")))
+	})
+}
+
+func TestParseDeduplicationSpec(t *testing.T) {
+	store, ttl, err := ParseDeduplicationSpec(map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", store)
+	assert.Equal(t, DefaultDeduplicationTTL, ttl)
+
+	store, ttl, err = ParseDeduplicationSpec(map[string]string{
+		DeduplicationStoreMetadataKey: "statestore",
+		DeduplicationTTLMetadataKey:   "60",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "statestore", store)
+	assert.Equal(t, time.Minute, ttl)
+
+	_, _, err = ParseDeduplicationSpec(map[string]string{DeduplicationTTLMetadataKey: "-1"})
+	assert.Error(t, err)
+}
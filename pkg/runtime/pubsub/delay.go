@@ -0,0 +1,189 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/state/partitions"
+	"github.com/google/uuid"
+)
+
+const (
+	// DelaySecondsMetadataKey is the publish metadata key holding the number of seconds to wait before delivering an event
+	DelaySecondsMetadataKey = "delaySeconds"
+	// DeliverAtMetadataKey is the publish metadata key holding the RFC3339 time at which an event is delivered
+	DeliverAtMetadataKey = "deliverAt"
+	// DelayQueueStoreMetadataKey is the pub/sub component metadata key naming the state store holding delayed events
+	DelayQueueStoreMetadataKey = "delayQueueStore"
+	// DefaultDelayQueueSweepInterval is the default interval at which due delayed events are published
+	DefaultDelayQueueSweepInterval = time.Second
+
+	// deliverAtAttribute is the extension attribute holding the time before which the event is not published
+	deliverAtAttribute = "deliverat"
+	delayQueueKeyPart  = "delayqueue"
+)
+
+// ErrDelayedDeliveryNotConfigured is returned when a delayed event is published but the pub/sub component has no delay queue
+var ErrDelayedDeliveryNotConfigured = errors.New("delayed delivery requires the pub/sub component to set " + DelayQueueStoreMetadataKey)
+
+// DelayedMessage is an event waiting in the delay queue
+type DelayedMessage struct {
+	ID        string `json:"id,omitempty"`
+	Topic     string `json:"topic"`
+	Data      []byte `json:"data"`
+	DeliverAt string `json:"deliverAt"`
+}
+
+// DelayQueue keeps delayed events in a state store and publishes them once they are due.
+// Each event is a record of the delay queue partitions of the app, so the replicas of the app only conflict
+// when they enqueue or publish events of the same partition at the same time.
+type DelayQueue struct {
+	partitions *partitions.Partitions
+	publishFn  func(*pubsub.PublishRequest) error
+	log        logger.Logger
+
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDelayQueue returns a DelayQueue storing events in the given state store
+func NewDelayQueue(appID string, store state.Store, publishFn func(*pubsub.PublishRequest) error, log logger.Logger) *DelayQueue {
+	return &DelayQueue{
+		partitions: partitions.New(store, partitions.DefaultCount, delayQueueKeyPart, appID),
+		publishFn:  publishFn,
+		log:        log,
+		done:       make(chan struct{}),
+	}
+}
+
+// GetDeliverAt returns the delivery time requested by the publish metadata, or the zero time if the event is not delayed
+func GetDeliverAt(metadata map[string]string, now time.Time) (time.Time, error) {
+	var deliverAt time.Time
+	for k, v := range metadata {
+		switch {
+		case strings.EqualFold(k, DelaySecondsMetadataKey):
+			seconds, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || seconds < 0 {
+				return time.Time{}, fmt.Errorf("invalid %s value %q", DelaySecondsMetadataKey, v)
+			}
+			if !deliverAt.IsZero() {
+				return time.Time{}, fmt.Errorf("only one of %s and %s can be set", DelaySecondsMetadataKey, DeliverAtMetadataKey)
+			}
+			deliverAt = now.Add(time.Duration(seconds) * time.Second)
+		case strings.EqualFold(k, DeliverAtMetadataKey):
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid %s value %q", DeliverAtMetadataKey, v)
+			}
+			if !deliverAt.IsZero() {
+				return time.Time{}, fmt.Errorf("only one of %s and %s can be set", DelaySecondsMetadataKey, DeliverAtMetadataKey)
+			}
+			deliverAt = t.UTC()
+		}
+	}
+	return deliverAt, nil
+}
+
+// DeliverAt returns the delivery time of a serialized CloudEvent, if it has one
+func DeliverAt(cloudEvent []byte) (time.Time, bool) {
+	var event struct {
+		DeliverAt string `json:"deliverat"`
+	}
+	if err := json.Unmarshal(cloudEvent, &event); err != nil || event.DeliverAt == "" {
+		return time.Time{}, false
+	}
+
+	deliverAt, err := time.Parse(time.RFC3339, event.DeliverAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return deliverAt, true
+}
+
+// Enqueue stores the event until it is due
+func (q *DelayQueue) Enqueue(req *pubsub.PublishRequest, deliverAt time.Time) error {
+	message := DelayedMessage{
+		ID:        uuid.New().String(),
+		Topic:     req.Topic,
+		Data:      req.Data,
+		DeliverAt: deliverAt.UTC().Format(time.RFC3339),
+	}
+	return q.partitions.Put(message.ID, message)
+}
+
+// Start creates the partitions of the delay queue and publishes the due events at the given interval
+func (q *DelayQueue) Start(interval time.Duration) {
+	if err := q.partitions.Create(); err != nil {
+		q.log.Warnf("error creating the delay queue partitions: %s", err)
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				q.PublishDue(time.Now().UTC())
+			case <-q.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops publishing the due events, which stay in the queue
+func (q *DelayQueue) Stop() {
+	q.stopOnce.Do(func() { close(q.done) })
+}
+
+// PublishDue publishes the events due at the given time. Events which fail to be published stay
+// in the queue and are published again by the next sweep, which gives at-least-once delivery.
+func (q *DelayQueue) PublishDue(now time.Time) {
+	for partition := 0; partition < q.partitions.Count(); partition++ {
+		q.publishDue(partition, now)
+	}
+}
+
+// publishDue publishes the events of the partition due at the given time and removes them from the partition
+func (q *DelayQueue) publishDue(partition int, now time.Time) {
+	records, _, err := q.partitions.Get(partition)
+	if err != nil {
+		q.log.Warnf("failed to get delayed events: %s", err)
+		return
+	}
+
+	done := []string{}
+	for id, b := range records {
+		var m DelayedMessage
+		if err := json.Unmarshal(b, &m); err != nil {
+			q.log.Warnf("dropping delayed event %s which can't be deserialized: %s", id, err)
+			done = append(done, id)
+			continue
+		}
+		deliverAt, err := time.Parse(time.RFC3339, m.DeliverAt)
+		if err == nil && deliverAt.After(now) {
+			continue
+		}
+		if err := q.publishFn(&pubsub.PublishRequest{Topic: m.Topic, Data: m.Data}); err != nil {
+			q.log.Debugf("failed to publish delayed event on topic %s, will retry: %s", m.Topic, err)
+			continue
+		}
+		done = append(done, id)
+	}
+	if len(done) == 0 {
+		return
+	}
+
+	// The events enqueued by other replicas since the read are kept
+	if err := q.partitions.Remove(partition, done...); err != nil {
+		// The published events stay in the queue and will be published again by the next sweep
+		q.log.Warnf("failed to update delayed events: %s", err)
+	}
+}
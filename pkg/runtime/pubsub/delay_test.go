@@ -0,0 +1,163 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/state/keyprefix"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeliverAt(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("delay seconds", func(t *testing.T) {
+		deliverAt, err := GetDeliverAt(map[string]string{"delayseconds": "30"}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, now.Add(30*time.Second), deliverAt)
+	})
+
+	t.Run("deliver at", func(t *testing.T) {
+		deliverAt, err := GetDeliverAt(map[string]string{DeliverAtMetadataKey: "2020-01-02T00:00:00Z"}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, now.Add(24*time.Hour), deliverAt)
+	})
+
+	t.Run("not delayed", func(t *testing.T) {
+		deliverAt, err := GetDeliverAt(map[string]string{}, now)
+		assert.NoError(t, err)
+		assert.True(t, deliverAt.IsZero())
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		_, err := GetDeliverAt(map[string]string{DelaySecondsMetadataKey: "-1"}, now)
+		assert.Error(t, err)
+		_, err = GetDeliverAt(map[string]string{DeliverAtMetadataKey: "tomorrow"}, now)
+		assert.Error(t, err)
+		_, err = GetDeliverAt(map[string]string{DelaySecondsMetadataKey: "1", DeliverAtMetadataKey: "2020-01-02T00:00:00Z"}, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("cloud event carries the delivery time", func(t *testing.T) {
+		b, err := NewCloudEvent("id1", "app1", "", "text/plain", []byte("hello"), map[string]string{DelaySecondsMetadataKey: "60"})
+		assert.NoError(t, err)
+		deliverAt, ok := DeliverAt(b)
+		assert.True(t, ok)
+		assert.True(t, deliverAt.After(time.Now()))
+	})
+}
+
+// pendingMessages returns the messages of every partition of the queue
+func pendingMessages(t *testing.T, q *DelayQueue) []DelayedMessage {
+	messages := []DelayedMessage{}
+	for partition := 0; partition < q.partitions.Count(); partition++ {
+		records, _, err := q.partitions.Get(partition)
+		assert.NoError(t, err)
+		for _, b := range records {
+			var m DelayedMessage
+			assert.NoError(t, json.Unmarshal(b, &m))
+			messages = append(messages, m)
+		}
+	}
+	return messages
+}
+
+func TestDelayQueue(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("due events are published", func(t *testing.T) {
+		published := []string{}
+		q := NewDelayQueue("app1", &fakeStateStore{items: map[string][]byte{}}, func(req *pubsub.PublishRequest) error {
+			published = append(published, string(req.Data))
+			return nil
+		}, log)
+
+		assert.NoError(t, q.Enqueue(&pubsub.PublishRequest{Topic: "topic1", Data: []byte("early")}, now.Add(time.Minute)))
+		assert.NoError(t, q.Enqueue(&pubsub.PublishRequest{Topic: "topic1", Data: []byte("late")}, now.Add(time.Hour)))
+
+		q.PublishDue(now)
+		assert.Empty(t, published)
+
+		q.PublishDue(now.Add(2 * time.Minute))
+		assert.Equal(t, []string{"early"}, published)
+
+		q.PublishDue(now.Add(2 * time.Hour))
+		assert.Equal(t, []string{"early", "late"}, published)
+
+		q.PublishDue(now.Add(3 * time.Hour))
+		assert.Equal(t, []string{"early", "late"}, published)
+	})
+
+	t.Run("failed events stay in the queue", func(t *testing.T) {
+		fail := true
+		published := 0
+		q := NewDelayQueue("app1", &fakeStateStore{items: map[string][]byte{}}, func(req *pubsub.PublishRequest) error {
+			if fail {
+				return errors.New("broker unavailable")
+			}
+			published++
+			return nil
+		}, log)
+
+		assert.NoError(t, q.Enqueue(&pubsub.PublishRequest{Topic: "topic1", Data: []byte("hello")}, now))
+		q.PublishDue(now)
+		assert.Equal(t, 0, published)
+
+		fail = false
+		q.PublishDue(now)
+		assert.Equal(t, 1, published)
+	})
+
+	t.Run("events enqueued by other replicas are kept", func(t *testing.T) {
+		store := &fakeStateStore{items: map[string][]byte{}}
+		replica := NewDelayQueue("app1", store, nil, log)
+		published := 0
+		q := NewDelayQueue("app1", store, func(req *pubsub.PublishRequest) error {
+			published++
+			assert.NoError(t, replica.Enqueue(&pubsub.PublishRequest{Topic: "topic1", Data: []byte("late")}, now.Add(time.Hour)))
+			return nil
+		}, log)
+
+		assert.NoError(t, q.Enqueue(&pubsub.PublishRequest{Topic: "topic1", Data: []byte("early")}, now))
+		q.PublishDue(now)
+		assert.Equal(t, 1, published)
+		pending := pendingMessages(t, q)
+		assert.Len(t, pending, 1)
+		assert.Equal(t, []byte("late"), pending[0].Data)
+	})
+
+	t.Run("events are spread over the partitions", func(t *testing.T) {
+		store := &fakeStateStore{items: map[string][]byte{}}
+		q := NewDelayQueue("app1", store, nil, log)
+		for i := 0; i < 50; i++ {
+			assert.NoError(t, q.Enqueue(&pubsub.PublishRequest{Topic: "topic1", Data: []byte("hello")}, now.Add(time.Hour)))
+		}
+
+		assert.Len(t, pendingMessages(t, q), 50)
+		assert.True(t, len(store.items) > 1)
+		assert.NotContains(t, store.items, keyprefix.InternalKey("delayqueue", "app1"))
+	})
+
+	t.Run("the key of the app isn't read", func(t *testing.T) {
+		store := &fakeStateStore{items: map[string][]byte{}}
+		assert.NoError(t, store.Set(&state.SetRequest{
+			Key:   "app1||delayqueue",
+			Value: []DelayedMessage{{Topic: "topic1", Data: []byte("hello"), DeliverAt: now.Format(time.RFC3339)}},
+		}))
+		published := 0
+		q := NewDelayQueue("app1", store, func(req *pubsub.PublishRequest) error {
+			published++
+			return nil
+		}, log)
+		q.Start(time.Hour)
+		defer q.Stop()
+		q.PublishDue(now)
+
+		assert.Zero(t, published)
+		assert.Contains(t, store.items, "app1||delayqueue")
+	})
+}
@@ -0,0 +1,31 @@
+package pubsub
+
+import "fmt"
+
+// NotAllowedError is returned when the scopes of the pub/sub component do not allow an app to use a topic
+type NotAllowedError struct {
+	Topic string
+	AppID string
+}
+
+func (e NotAllowedError) Error() string {
+	return fmt.Sprintf("topic %s is not allowed for app id %s", e.Topic, e.AppID)
+}
+
+// SubscriptionNotFoundError is returned when a request names a topic the app is not subscribed to
+type SubscriptionNotFoundError struct {
+	Topic string
+}
+
+func (e SubscriptionNotFoundError) Error() string {
+	return fmt.Sprintf("app is not subscribed to topic %s", e.Topic)
+}
+
+// NotFoundError is returned when a request names a pub/sub component which is not loaded
+type NotFoundError struct {
+	PubSubName string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("pub sub %s not found", e.PubSubName)
+}
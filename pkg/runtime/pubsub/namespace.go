@@ -0,0 +1,73 @@
+package pubsub
+
+import (
+	"io"
+	"strings"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// namespacedPubSub scopes the topics of a pub/sub component to a namespace, so the apps of namespaces sharing the
+// component neither receive nor publish each other's events
+type namespacedPubSub struct {
+	pubsub.PubSub
+	namespace string
+}
+
+// namespacedBulkPubSub is a namespaced pub/sub component with native batch publishing
+type namespacedBulkPubSub struct {
+	*namespacedPubSub
+	bulkPublisher BulkPublisher
+}
+
+// NewNamespacedPubSub returns the pub/sub component publishing and subscribing to the topics of the app as
+// <namespace>.<topic>
+func NewNamespacedPubSub(ps pubsub.PubSub, namespace string) pubsub.PubSub {
+	n := &namespacedPubSub{
+		PubSub:    ps,
+		namespace: namespace,
+	}
+	if bp, ok := ps.(BulkPublisher); ok {
+		return &namespacedBulkPubSub{namespacedPubSub: n, bulkPublisher: bp}
+	}
+	return n
+}
+
+// NamespacedTopic returns the name of the topic of a namespace
+func NamespacedTopic(namespace, topic string) string {
+	return namespace + "." + topic
+}
+
+func (n *namespacedPubSub) Publish(req *pubsub.PublishRequest) error {
+	namespaced := *req
+	namespaced.Topic = NamespacedTopic(n.namespace, req.Topic)
+	return n.PubSub.Publish(&namespaced)
+}
+
+func (n *namespacedPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	prefix := NamespacedTopic(n.namespace, "")
+	req.Topic = prefix + req.Topic
+	return n.PubSub.Subscribe(req, func(msg *pubsub.NewMessage) error {
+		msg.Topic = strings.TrimPrefix(msg.Topic, prefix)
+		return handler(msg)
+	})
+}
+
+// Unsubscribe stops consuming the topic of the namespace when the component supports it
+func (n *namespacedPubSub) Unsubscribe(topic string) error {
+	return Unsubscribe(n.PubSub, NamespacedTopic(n.namespace, topic))
+}
+
+// Close closes the component when it can be closed
+func (n *namespacedPubSub) Close() error {
+	if closer, ok := n.PubSub.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (n *namespacedBulkPubSub) BulkPublish(req *BulkPublishRequest) ([]BulkPublishResponseEntry, error) {
+	namespaced := *req
+	namespaced.Topic = NamespacedTopic(n.namespace, req.Topic)
+	return n.bulkPublisher.BulkPublish(&namespaced)
+}
@@ -0,0 +1,74 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTopicPubSub struct {
+	published  []string
+	subscribed []string
+	handler    func(msg *pubsub.NewMessage) error
+}
+
+func (f *fakeTopicPubSub) Init(metadata pubsub.Metadata) error {
+	return nil
+}
+
+func (f *fakeTopicPubSub) Publish(req *pubsub.PublishRequest) error {
+	f.published = append(f.published, req.Topic)
+	return nil
+}
+
+func (f *fakeTopicPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	f.subscribed = append(f.subscribed, req.Topic)
+	f.handler = handler
+	return nil
+}
+
+func TestNamespacedPubSub(t *testing.T) {
+	fake := &fakeTopicPubSub{}
+	ps := NewNamespacedPubSub(fake, "ns1")
+
+	req := &pubsub.PublishRequest{Topic: "orders"}
+	assert.NoError(t, ps.Publish(req))
+	assert.Equal(t, []string{"ns1.orders"}, fake.published)
+	assert.Equal(t, "orders", req.Topic)
+
+	var received string
+	assert.NoError(t, ps.Subscribe(pubsub.SubscribeRequest{Topic: "orders"}, func(msg *pubsub.NewMessage) error {
+		received = msg.Topic
+		return nil
+	}))
+	assert.Equal(t, []string{"ns1.orders"}, fake.subscribed)
+	assert.NoError(t, fake.handler(&pubsub.NewMessage{Topic: "ns1.orders"}))
+	assert.Equal(t, "orders", received)
+
+	_, ok := ps.(BulkPublisher)
+	assert.False(t, ok)
+}
+
+type unsubscribingTopicPubSub struct {
+	fakeTopicPubSub
+	unsubscribed []string
+}
+
+func (u *unsubscribingTopicPubSub) Unsubscribe(topic string) error {
+	u.unsubscribed = append(u.unsubscribed, topic)
+	return nil
+}
+
+func TestNamespacedPubSubUnsubscribe(t *testing.T) {
+	t.Run("unsubscribe from the topic of the namespace", func(t *testing.T) {
+		fake := &unsubscribingTopicPubSub{}
+		assert.NoError(t, Unsubscribe(NewNamespacedPubSub(fake, "ns1"), "orders"))
+		assert.Equal(t, []string{"ns1.orders"}, fake.unsubscribed)
+	})
+
+	t.Run("component without unsubscribe", func(t *testing.T) {
+		err := Unsubscribe(NewNamespacedPubSub(&fakeTopicPubSub{}, "ns1"), "orders")
+		assert.Equal(t, ErrUnsubscribeNotSupported, err)
+	})
+}
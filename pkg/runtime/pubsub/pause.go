@@ -0,0 +1,106 @@
+package pubsub
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// SubscriptionStatus is a subscription of the app along with the pub/sub component serving it
+type SubscriptionStatus struct {
+	PubSubName string
+	Topic      string
+	Route      string
+	Paused     bool
+}
+
+// SubscriptionManager lists the subscriptions of the app and pauses or resumes their consumption
+type SubscriptionManager interface {
+	Subscriptions() []SubscriptionStatus
+	PauseSubscription(topic string) error
+	ResumeSubscription(topic string) error
+}
+
+// Pauser holds the paused topics. The messages of a paused topic wait until the topic is resumed
+// instead of being delivered to the app, so they are neither acknowledged nor retried meanwhile.
+type Pauser struct {
+	lock   sync.Mutex
+	paused map[string]chan struct{}
+}
+
+// NewPauser returns a Pauser with no paused topics
+func NewPauser() *Pauser {
+	return &Pauser{
+		paused: map[string]chan struct{}{},
+	}
+}
+
+// Pause stops the delivery of the messages of the topic. It returns false if the topic was already paused.
+func (p *Pauser) Pause(topic string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.paused[topic]; ok {
+		return false
+	}
+	p.paused[topic] = make(chan struct{})
+	return true
+}
+
+// Resume releases the messages waiting on the topic. It returns false if the topic was not paused.
+func (p *Pauser) Resume(topic string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	resumed, ok := p.paused[topic]
+	if !ok {
+		return false
+	}
+	delete(p.paused, topic)
+	close(resumed)
+	return true
+}
+
+// IsPaused returns true if the topic is paused
+func (p *Pauser) IsPaused(topic string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, ok := p.paused[topic]
+	return ok
+}
+
+// Paused returns the paused topics
+func (p *Pauser) Paused() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	topics := make([]string, 0, len(p.paused))
+	for t := range p.paused {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Wait blocks while the topic is paused
+func (p *Pauser) Wait(topic string) {
+	for {
+		p.lock.Lock()
+		resumed, ok := p.paused[topic]
+		p.lock.Unlock()
+		if !ok {
+			return
+		}
+		<-resumed
+	}
+}
+
+// Gate returns a handler which waits for the topic of each message to be resumed before calling the given handler
+func (p *Pauser) Gate(handler func(msg *pubsub.NewMessage) error) func(msg *pubsub.NewMessage) error {
+	return func(msg *pubsub.NewMessage) error {
+		p.Wait(msg.Topic)
+		return handler(msg)
+	}
+}
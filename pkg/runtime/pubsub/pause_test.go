@@ -0,0 +1,48 @@
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauser(t *testing.T) {
+	p := NewPauser()
+	delivered := make(chan string, 10)
+	handler := p.Gate(func(msg *pubsub.NewMessage) error {
+		delivered <- msg.Topic
+		return nil
+	})
+
+	t.Run("messages of paused topics wait", func(t *testing.T) {
+		assert.True(t, p.Pause("topic1"))
+		assert.False(t, p.Pause("topic1"))
+		assert.True(t, p.IsPaused("topic1"))
+		assert.Equal(t, []string{"topic1"}, p.Paused())
+
+		go handler(&pubsub.NewMessage{Topic: "topic1"})
+		assert.NoError(t, handler(&pubsub.NewMessage{Topic: "topic2"}))
+		assert.Equal(t, "topic2", <-delivered)
+
+		select {
+		case <-delivered:
+			t.Error("message of a paused topic was delivered")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("resume releases waiting messages", func(t *testing.T) {
+		assert.True(t, p.Resume("topic1"))
+		assert.False(t, p.Resume("topic1"))
+		assert.False(t, p.IsPaused("topic1"))
+
+		select {
+		case topic := <-delivered:
+			assert.Equal(t, "topic1", topic)
+		case <-time.After(5 * time.Second):
+			t.Error("message was not delivered after the topic was resumed")
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package pubsub
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+const (
+	// RetryMaxRetriesMetadataKey is the metadata key holding the number of redeliveries of a failed message
+	RetryMaxRetriesMetadataKey = "retryMaxRetries"
+	// RetryInitialIntervalMetadataKey is the metadata key holding the wait before the first redelivery in milliseconds
+	RetryInitialIntervalMetadataKey = "retryInitialIntervalMs"
+	// RetryMaxIntervalMetadataKey is the metadata key holding the maximum wait between redeliveries in milliseconds
+	RetryMaxIntervalMetadataKey = "retryMaxIntervalMs"
+	// RetryJitterMetadataKey is the metadata key holding the fraction of the wait which is randomized
+	RetryJitterMetadataKey = "retryJitter"
+
+	// DefaultRetryMaxRetries is the default number of redeliveries of a message the app failed to process
+	DefaultRetryMaxRetries = 2
+	// DefaultRetryInitialIntervalMs is the default wait before the first redelivery
+	DefaultRetryInitialIntervalMs = 100
+	// DefaultRetryMaxIntervalMs is the default maximum wait between redeliveries
+	DefaultRetryMaxIntervalMs = 10000
+)
+
+// RetryPolicy configures the redelivery of messages the app failed to process.
+// The wait between redeliveries starts at the initial interval and doubles on every retry up to the max interval.
+// Jitter randomly shortens each wait by up to the given fraction.
+type RetryPolicy struct {
+	MaxRetries        int     `json:"maxRetries"`
+	InitialIntervalMs int     `json:"initialIntervalMs,omitempty"`
+	MaxIntervalMs     int     `json:"maxIntervalMs,omitempty"`
+	Jitter            float64 `json:"jitter,omitempty"`
+}
+
+// DefaultRetryPolicy returns the retry policy used when neither the component nor the subscription configure one
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:        DefaultRetryMaxRetries,
+		InitialIntervalMs: DefaultRetryInitialIntervalMs,
+		MaxIntervalMs:     DefaultRetryMaxIntervalMs,
+	}
+}
+
+// Validate checks the values of the retry policy
+func (p RetryPolicy) Validate() error {
+	if p.MaxRetries < 0 {
+		return fmt.Errorf("max retries must not be negative")
+	}
+	if p.InitialIntervalMs < 0 || p.MaxIntervalMs < 0 {
+		return fmt.Errorf("retry intervals must not be negative")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// Backoff returns the wait before the given retry, starting at 1
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	interval := time.Duration(p.InitialIntervalMs) * time.Millisecond
+	maxInterval := time.Duration(p.MaxIntervalMs) * time.Millisecond
+	for i := 1; i < retry && (maxInterval == 0 || interval < maxInterval); i++ {
+		interval *= 2
+	}
+	if maxInterval > 0 && interval > maxInterval {
+		interval = maxInterval
+	}
+	if p.Jitter > 0 {
+		// nolint:gosec
+		interval -= time.Duration(rand.Float64() * p.Jitter * float64(interval))
+	}
+	return interval
+}
+
+// ParseRetryPolicy applies the retry settings found in the metadata to the base policy.
+// The returned bool is false if the metadata holds no retry settings.
+func ParseRetryPolicy(metadata map[string]string, base RetryPolicy) (RetryPolicy, bool, error) {
+	found := false
+	policy := base
+
+	for key, target := range map[string]*int{
+		RetryMaxRetriesMetadataKey:      &policy.MaxRetries,
+		RetryInitialIntervalMetadataKey: &policy.InitialIntervalMs,
+		RetryMaxIntervalMetadataKey:     &policy.MaxIntervalMs,
+	} {
+		if val, ok := metadata[key]; ok && val != "" {
+			i, err := strconv.Atoi(val)
+			if err != nil {
+				return base, false, fmt.Errorf("invalid %s value %q", key, val)
+			}
+			*target = i
+			found = true
+		}
+	}
+	if val, ok := metadata[RetryJitterMetadataKey]; ok && val != "" {
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return base, false, fmt.Errorf("invalid %s value %q", RetryJitterMetadataKey, val)
+		}
+		policy.Jitter = f
+		found = true
+	}
+
+	if err := policy.Validate(); err != nil {
+		return base, false, err
+	}
+	return policy, found, nil
+}
@@ -0,0 +1,54 @@
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, InitialIntervalMs: 100, MaxIntervalMs: 300}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 300*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, 300*time.Millisecond, policy.Backoff(10))
+
+	t.Run("jitter shortens the wait", func(t *testing.T) {
+		policy.Jitter = 0.5
+		for i := 0; i < 10; i++ {
+			backoff := policy.Backoff(1)
+			assert.True(t, backoff > 50*time.Millisecond && backoff <= 100*time.Millisecond)
+		}
+	})
+}
+
+func TestParseRetryPolicy(t *testing.T) {
+	t.Run("no retry settings", func(t *testing.T) {
+		policy, found, err := ParseRetryPolicy(map[string]string{"other": "value"}, DefaultRetryPolicy())
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, DefaultRetryPolicy(), policy)
+	})
+
+	t.Run("overrides base policy", func(t *testing.T) {
+		policy, found, err := ParseRetryPolicy(map[string]string{
+			RetryMaxRetriesMetadataKey: "5",
+			RetryJitterMetadataKey:     "0.2",
+		}, DefaultRetryPolicy())
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, 5, policy.MaxRetries)
+		assert.Equal(t, 0.2, policy.Jitter)
+		assert.Equal(t, DefaultRetryInitialIntervalMs, policy.InitialIntervalMs)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		_, _, err := ParseRetryPolicy(map[string]string{RetryMaxRetriesMetadataKey: "many"}, DefaultRetryPolicy())
+		assert.Error(t, err)
+
+		_, _, err = ParseRetryPolicy(map[string]string{RetryJitterMetadataKey: "2"}, DefaultRetryPolicy())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,192 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const eventIdentifierPrefix = "event."
+
+// RoutesSpec holds the content-based routing rules of a subscription
+type RoutesSpec struct {
+	Rules   []RouteRule `json:"rules,omitempty"`
+	Default string      `json:"default,omitempty"`
+}
+
+// RouteRule delivers events matching the expression to the given path.
+// Expressions compare CloudEvent attributes with string literals, for example:
+// event.type == "order.created" && event.source != "legacy"
+type RouteRule struct {
+	Match string `json:"match"`
+	Path  string `json:"path"`
+}
+
+// ValidateRoutes checks that all match expressions of the routing rules are valid
+func ValidateRoutes(routes RoutesSpec) error {
+	for _, r := range routes.Rules {
+		if r.Path == "" {
+			return fmt.Errorf("routing rule %q has an empty path", r.Match)
+		}
+		if _, err := parseMatchExpression(r.Match); err != nil {
+			return fmt.Errorf("invalid match expression %q: %s", r.Match, err)
+		}
+	}
+	return nil
+}
+
+// MatchRoute returns the path of the first routing rule matching the CloudEvent,
+// or the default route of the subscription if no rule matches.
+func (s Subscription) MatchRoute(cloudEvent []byte) (string, error) {
+	if len(s.Routes.Rules) == 0 {
+		return s.Route, nil
+	}
+
+	var event map[string]interface{}
+	if err := json.Unmarshal(cloudEvent, &event); err != nil {
+		return "", fmt.Errorf("error deserializing cloud event for routing: %s", err)
+	}
+
+	for _, r := range s.Routes.Rules {
+		expr, err := parseMatchExpression(r.Match)
+		if err != nil {
+			return "", err
+		}
+		if expr.eval(event) {
+			return r.Path, nil
+		}
+	}
+	return s.Route, nil
+}
+
+// matchExpression is a disjunction of conjunctions of attribute comparisons
+type matchExpression [][]comparison
+
+type comparison struct {
+	path   []string
+	equals bool
+	value  string
+}
+
+func (m matchExpression) eval(event map[string]interface{}) bool {
+	for _, and := range m {
+		matched := true
+		for _, c := range and {
+			if !c.eval(event) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparison) eval(event map[string]interface{}) bool {
+	var current interface{} = event
+	for _, p := range c.path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return !c.equals
+		}
+		if current, ok = m[p]; !ok {
+			return !c.equals
+		}
+	}
+	return (fmt.Sprintf("%v", current) == c.value) == c.equals
+}
+
+func parseMatchExpression(expr string) (matchExpression, error) {
+	tokens, err := tokenizeMatchExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	result := matchExpression{}
+	and := []comparison{}
+	for i := 0; i < len(tokens); {
+		if len(tokens)-i < 3 {
+			return nil, fmt.Errorf("incomplete comparison")
+		}
+		ident, op, literal := tokens[i], tokens[i+1], tokens[i+2]
+		if !strings.HasPrefix(ident.value, eventIdentifierPrefix) || ident.literal {
+			return nil, fmt.Errorf("expected an event attribute, found %q", ident.value)
+		}
+		if op.value != "==" && op.value != "!=" {
+			return nil, fmt.Errorf("expected == or !=, found %q", op.value)
+		}
+		if !literal.literal {
+			return nil, fmt.Errorf("expected a string literal, found %q", literal.value)
+		}
+		and = append(and, comparison{
+			path:   strings.Split(strings.TrimPrefix(ident.value, eventIdentifierPrefix), "."),
+			equals: op.value == "==",
+			value:  literal.value,
+		})
+		i += 3
+
+		if i == len(tokens) {
+			break
+		}
+		switch tokens[i].value {
+		case "&&":
+		case "||":
+			result = append(result, and)
+			and = []comparison{}
+		default:
+			return nil, fmt.Errorf("expected && or ||, found %q", tokens[i].value)
+		}
+		i++
+		if i == len(tokens) {
+			return nil, fmt.Errorf("expression ends with an operator")
+		}
+	}
+	return append(result, and), nil
+}
+
+type matchToken struct {
+	value   string
+	literal bool
+}
+
+func tokenizeMatchExpression(expr string) ([]matchToken, error) {
+	tokens := []matchToken{}
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string literal")
+			}
+			tokens = append(tokens, matchToken{value: expr[i+1 : i+1+end], literal: true})
+			i += end + 2
+		case strings.HasPrefix(expr[i:], "==") || strings.HasPrefix(expr[i:], "!=") ||
+			strings.HasPrefix(expr[i:], "&&") || strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, matchToken{value: expr[i : i+2]})
+			i += 2
+		default:
+			start := i
+			for i < len(expr) && isIdentifierChar(expr[i]) {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+			tokens = append(tokens, matchToken{value: expr[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '.' || c == '_' || c == '-' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,74 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMatchExpression(t *testing.T) {
+	valid := []string{
+		`event.type == "order.created"`,
+		`event.type == 'order.created' && event.source != "legacy"`,
+		`event.type == "a" || event.type == "b" && event.data.kind == "c"`,
+	}
+	for _, expr := range valid {
+		_, err := parseMatchExpression(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	invalid := []string{
+		``,
+		`event.type`,
+		`event.type = "a"`,
+		`type == "a"`,
+		`event.type == other`,
+		`event.type == "a" &&`,
+		`event.type == "a`,
+		`event.type == "a" event.source == "b"`,
+	}
+	for _, expr := range invalid {
+		_, err := parseMatchExpression(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestMatchRoute(t *testing.T) {
+	sub := Subscription{
+		Topic: "orders",
+		Route: "/default",
+		Routes: RoutesSpec{
+			Rules: []RouteRule{
+				{Match: `event.type == "order.created" && event.source != "legacy"`, Path: "/created"},
+				{Match: `event.type == "order.deleted" || event.data.kind == "delete"`, Path: "/deleted"},
+			},
+		},
+	}
+
+	tests := []struct {
+		event string
+		route string
+	}{
+		{`{"type": "order.created", "source": "shop"}`, "/created"},
+		{`{"type": "order.created", "source": "legacy"}`, "/default"},
+		{`{"type": "order.deleted"}`, "/deleted"},
+		{`{"type": "other", "data": {"kind": "delete"}}`, "/deleted"},
+		{`{"type": "other"}`, "/default"},
+	}
+	for _, tt := range tests {
+		route, err := sub.MatchRoute([]byte(tt.event))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.route, route, tt.event)
+	}
+
+	t.Run("invalid event", func(t *testing.T) {
+		_, err := sub.MatchRoute([]byte("not json"))
+		assert.Error(t, err)
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		route, err := Subscription{Route: "/orders"}.MatchRoute([]byte("not json"))
+		assert.NoError(t, err)
+		assert.Equal(t, "/orders", route)
+	})
+}
@@ -0,0 +1,81 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SchemaMetadataPrefix is the prefix of the pub/sub component metadata keys holding the JSON Schema of a topic,
+// for example "schema.orders" holds the schema the events published to the orders topic must match
+const SchemaMetadataPrefix = "schema."
+
+// SchemaValidationError is returned when a published event does not match the JSON Schema of its topic
+type SchemaValidationError struct {
+	Topic  string
+	Errors []string
+}
+
+func (e SchemaValidationError) Error() string {
+	return fmt.Sprintf("event does not match the schema of topic %s: %s", e.Topic, strings.Join(e.Errors, "; "))
+}
+
+// SchemaValidator validates published events against the JSON Schemas registered for their topic
+type SchemaValidator struct {
+	schemas map[string]*gojsonschema.Schema
+}
+
+// NewSchemaValidator loads the topic schemas found in the pub/sub component metadata.
+// It returns nil if no schema is registered.
+func NewSchemaValidator(metadata map[string]string) (*SchemaValidator, error) {
+	schemas := map[string]*gojsonschema.Schema{}
+	for k, v := range metadata {
+		if !strings.HasPrefix(k, SchemaMetadataPrefix) {
+			continue
+		}
+		topic := strings.TrimPrefix(k, SchemaMetadataPrefix)
+		schema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema for topic %s: %s", topic, err)
+		}
+		schemas[topic] = schema
+	}
+	if len(schemas) == 0 {
+		return nil, nil
+	}
+	return &SchemaValidator{schemas: schemas}, nil
+}
+
+// Validate checks the event published to the topic against the schema of the topic, if it has one.
+// The data of CloudEvents is validated, raw payloads are validated as a whole.
+func (v *SchemaValidator) Validate(topic string, data []byte) error {
+	schema, ok := v.schemas[topic]
+	if !ok {
+		return nil
+	}
+
+	payload := data
+	var event map[string]json.RawMessage
+	if json.Unmarshal(data, &event) == nil && event["specversion"] != nil {
+		if event["data"] == nil {
+			return SchemaValidationError{Topic: topic, Errors: []string{"event has no JSON data"}}
+		}
+		payload = event["data"]
+	}
+
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(payload))
+	if err != nil {
+		return SchemaValidationError{Topic: topic, Errors: []string{err.Error()}}
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	errs := make([]string, 0, len(result.Errors()))
+	for _, e := range result.Errors() {
+		errs = append(errs, e.String())
+	}
+	return SchemaValidationError{Topic: topic, Errors: errs}
+}
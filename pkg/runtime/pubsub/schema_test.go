@@ -0,0 +1,56 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testOrderSchema = `{
+	"type": "object",
+	"properties": {"id": {"type": "integer"}},
+	"required": ["id"]
+}`
+
+func TestNewSchemaValidator(t *testing.T) {
+	t.Run("no schemas", func(t *testing.T) {
+		v, err := NewSchemaValidator(map[string]string{"host": "localhost"})
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		_, err := NewSchemaValidator(map[string]string{"schema.orders": "{"})
+		assert.Error(t, err)
+	})
+}
+
+func TestSchemaValidatorValidate(t *testing.T) {
+	v, err := NewSchemaValidator(map[string]string{"schema.orders": testOrderSchema})
+	assert.NoError(t, err)
+
+	t.Run("valid payload", func(t *testing.T) {
+		assert.NoError(t, v.Validate("orders", []byte(`{"id": 1}`)))
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		err := v.Validate("orders", []byte(`{"id": "a"}`))
+		schemaErr, ok := err.(SchemaValidationError)
+		assert.True(t, ok)
+		assert.Equal(t, "orders", schemaErr.Topic)
+		assert.Len(t, schemaErr.Errors, 1)
+	})
+
+	t.Run("validates the data of cloud events", func(t *testing.T) {
+		assert.NoError(t, v.Validate("orders", []byte(`{"specversion": "1.0", "id": "a", "data": {"id": 1}}`)))
+		assert.Error(t, v.Validate("orders", []byte(`{"specversion": "1.0", "id": "a", "data": {}}`)))
+	})
+
+	t.Run("topic without schema", func(t *testing.T) {
+		assert.NoError(t, v.Validate("payments", []byte("not json")))
+	})
+
+	t.Run("payload is not json", func(t *testing.T) {
+		assert.Error(t, v.Validate("orders", []byte("not json")))
+	})
+}
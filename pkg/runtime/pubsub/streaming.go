@@ -0,0 +1,90 @@
+package pubsub
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// ErrNoStreamSubscribers is returned when a message arrives on a topic which has no connected stream subscribers
+var ErrNoStreamSubscribers = errors.New("no stream subscribers are connected for the topic")
+
+// StreamSubscriptions dispatches the messages of topics subscribed over gRPC streams to the connected streams.
+// Streams subscribed to the same topic are competing consumers, each message is delivered to one of them.
+type StreamSubscriptions struct {
+	lock   sync.Mutex
+	topics map[string]*streamTopic
+	nextID int
+}
+
+type streamTopic struct {
+	subscribers []streamSubscriber
+	next        int
+}
+
+type streamSubscriber struct {
+	id      int
+	handler func(msg *pubsub.NewMessage) error
+}
+
+// NewStreamSubscriptions returns an empty StreamSubscriptions
+func NewStreamSubscriptions() *StreamSubscriptions {
+	return &StreamSubscriptions{
+		topics: map[string]*streamTopic{},
+	}
+}
+
+// Add registers the handler of a stream for the topic and returns the function removing it
+func (s *StreamSubscriptions) Add(topic string, handler func(msg *pubsub.NewMessage) error) func() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	t, ok := s.topics[topic]
+	if !ok {
+		t = &streamTopic{}
+		s.topics[topic] = t
+	}
+	s.nextID++
+	id := s.nextID
+	t.subscribers = append(t.subscribers, streamSubscriber{id: id, handler: handler})
+
+	return func() { s.remove(topic, id) }
+}
+
+// HasTopic returns true if the topic was subscribed over a stream
+func (s *StreamSubscriptions) HasTopic(topic string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, ok := s.topics[topic]
+	return ok
+}
+
+// Deliver sends the message to one of the streams subscribed to its topic
+func (s *StreamSubscriptions) Deliver(msg *pubsub.NewMessage) error {
+	s.lock.Lock()
+	t, ok := s.topics[msg.Topic]
+	if !ok || len(t.subscribers) == 0 {
+		s.lock.Unlock()
+		return ErrNoStreamSubscribers
+	}
+	t.next = (t.next + 1) % len(t.subscribers)
+	handler := t.subscribers[t.next].handler
+	s.lock.Unlock()
+
+	return handler(msg)
+}
+
+func (s *StreamSubscriptions) remove(topic string, id int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	t := s.topics[topic]
+	for i, sub := range t.subscribers {
+		if sub.id == id {
+			t.subscribers = append(t.subscribers[:i], t.subscribers[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamSubscriptions(t *testing.T) {
+	s := NewStreamSubscriptions()
+
+	t.Run("no subscribers", func(t *testing.T) {
+		err := s.Deliver(&pubsub.NewMessage{Topic: "topic1"})
+		assert.Equal(t, ErrNoStreamSubscribers, err)
+		assert.False(t, s.HasTopic("topic1"))
+	})
+
+	counts := map[string]int{}
+	removeA := s.Add("topic1", func(msg *pubsub.NewMessage) error {
+		counts["a"]++
+		return nil
+	})
+	s.Add("topic1", func(msg *pubsub.NewMessage) error {
+		counts["b"]++
+		return nil
+	})
+
+	t.Run("messages are spread across subscribers", func(t *testing.T) {
+		assert.True(t, s.HasTopic("topic1"))
+		for i := 0; i < 4; i++ {
+			assert.NoError(t, s.Deliver(&pubsub.NewMessage{Topic: "topic1"}))
+		}
+		assert.Equal(t, 2, counts["a"])
+		assert.Equal(t, 2, counts["b"])
+	})
+
+	t.Run("removed subscribers do not receive messages", func(t *testing.T) {
+		removeA()
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, s.Deliver(&pubsub.NewMessage{Topic: "topic1"}))
+		}
+		assert.Equal(t, 2, counts["a"])
+		assert.Equal(t, 4, counts["b"])
+	})
+}
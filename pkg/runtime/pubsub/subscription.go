@@ -1,7 +1,60 @@
 package pubsub
 
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DeadLetterTopicMetadataKey is the subscription metadata key used by gRPC apps to set a dead-letter topic
+	DeadLetterTopicMetadataKey = "deadLetterTopic"
+	// RawPayloadMetadataKey is the metadata key used to publish or subscribe to events without CloudEvents wrapping
+	RawPayloadMetadataKey = "rawPayload"
+	// PubSubNameMetadataKey is the metadata key naming the pub/sub component to publish or subscribe to.
+	// The default pub/sub component is used when it is not set.
+	PubSubNameMetadataKey = "pubsubName"
+)
+
 type Subscription struct {
-	Topic    string            `json:"topic"`
-	Route    string            `json:"route"`
-	Metadata map[string]string `json:"metadata"`
+	Topic           string            `json:"topic"`
+	PubSubName      string            `json:"pubsubName,omitempty"`
+	Route           string            `json:"route"`
+	Metadata        map[string]string `json:"metadata"`
+	DeadLetterTopic string            `json:"deadLetterTopic,omitempty"`
+	BulkSubscribe   BulkSubscribeSpec `json:"bulkSubscribe,omitempty"`
+	Routes          RoutesSpec        `json:"routes,omitempty"`
+	RetryPolicy     *RetryPolicy      `json:"retryPolicy,omitempty"`
+	Concurrency     string            `json:"concurrency,omitempty"`
+	MaxConcurrency  int               `json:"maxConcurrency,omitempty"`
+}
+
+// IsRawPayload returns true if the metadata sets the raw payload flag, in which case
+// published events are not wrapped in and delivered events are not parsed as CloudEvents
+func IsRawPayload(metadata map[string]string) bool {
+	for k, v := range metadata {
+		if strings.EqualFold(k, RawPayloadMetadataKey) {
+			raw, _ := strconv.ParseBool(v)
+			return raw
+		}
+	}
+	return false
+}
+
+// GetPubSubName returns the name of the pub/sub component set in the metadata, or an empty string for the default component
+func GetPubSubName(metadata map[string]string) string {
+	for k, v := range metadata {
+		if strings.EqualFold(k, PubSubNameMetadataKey) {
+			return v
+		}
+	}
+	return ""
+}
+
+// RawPayloadContentType returns the content type used to deliver a raw payload to the app
+func RawPayloadContentType(data []byte) string {
+	if json.Valid(data) {
+		return "application/json"
+	}
+	return "application/octet-stream"
 }
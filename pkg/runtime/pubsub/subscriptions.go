@@ -3,7 +3,10 @@ package pubsub
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/dapr/dapr/pkg/channel"
 	"github.com/dapr/dapr/pkg/logger"
@@ -48,14 +51,37 @@ func GetSubscriptionsHTTP(channel channel.AppChannel, log logger.Logger) []Subsc
 
 func filterSubscriptions(subscriptions []Subscription, log logger.Logger) []Subscription {
 	for i := len(subscriptions) - 1; i >= 0; i-- {
-		if subscriptions[i].Route == "" {
-			log.Warnf("topic %s has an empty route. removing from subscriptions list", subscriptions[i].Topic)
+		if err := normalizeSubscription(&subscriptions[i]); err != nil {
+			log.Warnf("topic %s %s. removing from subscriptions list", subscriptions[i].Topic, err)
 			subscriptions = append(subscriptions[:i], subscriptions[i+1:]...)
 		}
 	}
 	return subscriptions
 }
 
+// normalizeSubscription falls back to the default route of the routing rules and validates the routes
+func normalizeSubscription(s *Subscription) error {
+	if s.Route == "" {
+		s.Route = s.Routes.Default
+	}
+	if s.Route == "" && len(s.Routes.Rules) == 0 {
+		return errors.New("has an empty route")
+	}
+	if err := ValidateRoutes(s.Routes); err != nil {
+		return fmt.Errorf("has invalid routing rules: %s", err)
+	}
+	if s.RetryPolicy != nil {
+		if err := s.RetryPolicy.Validate(); err != nil {
+			return fmt.Errorf("has an invalid retry policy: %s", err)
+		}
+	}
+	s.Concurrency = strings.ToLower(s.Concurrency)
+	if err := ValidateConcurrency(s.Concurrency, s.MaxConcurrency); err != nil {
+		return fmt.Errorf("has invalid concurrency settings: %s", err)
+	}
+	return nil
+}
+
 func GetSubscriptionsGRPC(channel daprclientv1pb.DaprClientClient, log logger.Logger) []Subscription {
 	var subscriptions []Subscription
 
@@ -67,10 +93,26 @@ func GetSubscriptionsGRPC(channel daprclientv1pb.DaprClientClient, log logger.Lo
 			log.Debug(noSubscriptionsError)
 		} else {
 			for _, s := range resp.Subscriptions {
-				subscriptions = append(subscriptions, Subscription{
-					Topic:    s.GetTopic(),
-					Metadata: s.GetMetadata(),
-				})
+				subscription := Subscription{
+					Topic:           s.GetTopic(),
+					PubSubName:      GetPubSubName(s.GetMetadata()),
+					Metadata:        s.GetMetadata(),
+					DeadLetterTopic: s.GetMetadata()[DeadLetterTopicMetadataKey],
+				}
+				policy, found, err := ParseRetryPolicy(s.GetMetadata(), DefaultRetryPolicy())
+				if err != nil {
+					log.Warnf("topic %s has an invalid retry policy: %s. using the component retry policy", s.GetTopic(), err)
+				} else if found {
+					subscription.RetryPolicy = &policy
+				}
+				concurrency, maxConcurrency, err := ParseConcurrency(s.GetMetadata())
+				if err != nil {
+					log.Warnf("topic %s has invalid concurrency settings: %s. using the component concurrency", s.GetTopic(), err)
+				} else {
+					subscription.Concurrency = concurrency
+					subscription.MaxConcurrency = maxConcurrency
+				}
+				subscriptions = append(subscriptions, subscription)
 			}
 		}
 	}
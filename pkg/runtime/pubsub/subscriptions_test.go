@@ -30,3 +30,42 @@ func TestFilterSubscriptions(t *testing.T) {
 	assert.Equal(t, "topic1", subs[1].Topic)
 	assert.Equal(t, "custom/topic1", subs[1].Route)
 }
+
+func TestFilterSubscriptionsWithRoutes(t *testing.T) {
+	subs := []Subscription{
+		{
+			Topic:  "topic0",
+			Routes: RoutesSpec{Default: "default0"},
+		},
+		{
+			Topic: "topic1",
+			Routes: RoutesSpec{
+				Rules: []RouteRule{{Match: `event.type == "a"`, Path: "a"}},
+			},
+		},
+		{
+			Topic: "topic2",
+			Routes: RoutesSpec{
+				Rules: []RouteRule{{Match: `event.type = "a"`, Path: "a"}},
+			},
+		},
+	}
+
+	subs = filterSubscriptions(subs, log)
+	assert.Len(t, subs, 2)
+	assert.Equal(t, "default0", subs[0].Route)
+	assert.Equal(t, "topic1", subs[1].Topic)
+	assert.Equal(t, "", subs[1].Route)
+}
+
+func TestIsRawPayload(t *testing.T) {
+	assert.True(t, IsRawPayload(map[string]string{"rawPayload": "true"}))
+	assert.True(t, IsRawPayload(map[string]string{"rawpayload": "True"}))
+	assert.False(t, IsRawPayload(map[string]string{"rawPayload": "false"}))
+	assert.False(t, IsRawPayload(nil))
+}
+
+func TestRawPayloadContentType(t *testing.T) {
+	assert.Equal(t, "application/json", RawPayloadContentType([]byte(`{"a": 1}`)))
+	assert.Equal(t, "application/octet-stream", RawPayloadContentType([]byte("plain")))
+}
@@ -0,0 +1,25 @@
+package pubsub
+
+import (
+	"errors"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// ErrUnsubscribeNotSupported is returned when a pub/sub component can't stop consuming a topic without being closed
+var ErrUnsubscribeNotSupported = errors.New("the pub sub component doesn't support unsubscribing")
+
+// Unsubscriber is implemented by pub/sub components which can stop consuming a topic without being closed
+type Unsubscriber interface {
+	Unsubscribe(topic string) error
+}
+
+// Unsubscribe stops the subscription of the component to the topic. It returns ErrUnsubscribeNotSupported when the
+// component can only stop its subscriptions by being closed.
+func Unsubscribe(ps pubsub.PubSub, topic string) error {
+	u, ok := ps.(Unsubscriber)
+	if !ok {
+		return ErrUnsubscribeNotSupported
+	}
+	return u.Unsubscribe(topic)
+}
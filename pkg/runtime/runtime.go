@@ -7,14 +7,19 @@ package runtime
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	nethttp "net/http"
@@ -28,66 +33,149 @@ import (
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/actors"
 	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/audit"
 	"github.com/dapr/dapr/pkg/channel"
 	http_channel "github.com/dapr/dapr/pkg/channel/http"
 	"github.com/dapr/dapr/pkg/components"
 	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	configuration_loader "github.com/dapr/dapr/pkg/components/configuration"
+	crypto_loader "github.com/dapr/dapr/pkg/components/crypto"
 	exporter_loader "github.com/dapr/dapr/pkg/components/exporters"
 	http_middleware_loader "github.com/dapr/dapr/pkg/components/middleware/http"
+	"github.com/dapr/dapr/pkg/components/pluggable"
 	pubsub_loader "github.com/dapr/dapr/pkg/components/pubsub"
 	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
 	servicediscovery_loader "github.com/dapr/dapr/pkg/components/servicediscovery"
 	state_loader "github.com/dapr/dapr/pkg/components/state"
 	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/configuration"
+	"github.com/dapr/dapr/pkg/crypto"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/discovery"
+	"github.com/dapr/dapr/pkg/fswatcher"
 	"github.com/dapr/dapr/pkg/grpc"
+	"github.com/dapr/dapr/pkg/health"
 	"github.com/dapr/dapr/pkg/http"
+	"github.com/dapr/dapr/pkg/jobs"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/messaging"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	http_middleware "github.com/dapr/dapr/pkg/middleware/http"
 	"github.com/dapr/dapr/pkg/modes"
 	"github.com/dapr/dapr/pkg/operator/client"
+	"github.com/dapr/dapr/pkg/outbox"
 	daprclientv1pb "github.com/dapr/dapr/pkg/proto/daprclient/v1"
 	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	runtime_metadata "github.com/dapr/dapr/pkg/runtime/metadata"
 	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
 	"github.com/dapr/dapr/pkg/runtime/security"
 	"github.com/dapr/dapr/pkg/scopes"
+	secrets_cache "github.com/dapr/dapr/pkg/secretstores/cache"
+	secrets_watch "github.com/dapr/dapr/pkg/secretstores/watch"
+	"github.com/dapr/dapr/pkg/state/bulk"
+	"github.com/dapr/dapr/pkg/state/cache"
+	"github.com/dapr/dapr/pkg/state/consistency"
+	"github.com/dapr/dapr/pkg/state/encryption"
+	"github.com/dapr/dapr/pkg/state/keyprefix"
+	"github.com/dapr/dapr/pkg/state/mirror"
+	"github.com/dapr/dapr/pkg/state/query"
+	"github.com/dapr/dapr/pkg/state/ttl"
+	"github.com/dapr/dapr/pkg/workflows"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
+	grpc_go "google.golang.org/grpc"
+	grpc_metadata "google.golang.org/grpc/metadata"
 )
 
 const (
 	appConfigEndpoint   = "dapr/config"
 	parallelConcurrency = "parallel"
 	actorStateStore     = "actorStateStore"
+	jobStateStore       = "jobStateStore"
+	// jobMethodPrefix prefixes the name of a job in the app method invoked when the job is due
+	jobMethodPrefix = "job/"
+	// secretMethodPrefix prefixes the secret store and secret names in the app method invoked when a watched secret changes
+	secretMethodPrefix = "secrets/"
+	// deadLetterBindingMetadataKey is the metadata key of an input binding naming the output binding
+	// the events which failed to be delivered after all retries are written to
+	deadLetterBindingMetadataKey = "deadLetterBinding"
+	// bindingDirectionMetadataKey is the metadata key of a binding declaring whether it is used as an input binding,
+	// an output binding or both. Bindings which don't declare their direction are used as both.
+	bindingDirectionMetadataKey = "direction"
+	bindingDirectionInput       = "input"
+	bindingDirectionOutput      = "output"
+	bindingDirectionBoth        = "both"
+	// componentEnvVarPrefix prefixes the environment variables of the runtime the components can reference
+	componentEnvVarPrefix = config.ComponentEnvVarPrefix
+	// bindingConcurrencyMetadataKey is the metadata key of an input binding holding the number of its events
+	// delivered to the app at the same time. The events are acknowledged once dispatched, so the concurrency
+	// requires a dead-letter binding keeping the events which fail.
+	bindingConcurrencyMetadataKey = "concurrency"
+	// componentInitConcurrency is the number of components of a building block initialized at the same time at startup
+	componentInitConcurrency = 8
+	// appReadyProbeInterval is the interval of the probes of the ready path of the app at startup
+	appReadyProbeInterval = 100 * time.Millisecond
+	// operatorReconnectBackoff is the wait before the first reconnection to the operator once the stream of the
+	// component updates fails, the wait doubles with every failed reconnection up to operatorReconnectMaxBackoff
+	operatorReconnectBackoff    = time.Second
+	operatorReconnectMaxBackoff = time.Minute
+	// pluggableDiscoveryInterval is the interval at which the sockets folder is searched for the component
+	// processes started after the runtime
+	pluggableDiscoveryInterval = time.Second * 10
 )
 
 var log = logger.NewLogger("dapr.runtime")
 
+// inputBindingConfig holds the runtime settings of an input binding, read from the metadata of its component
+type inputBindingConfig struct {
+	retryPolicy       runtime_pubsub.RetryPolicy
+	deadLetterBinding string
+	concurrency       int
+}
+
+// secretChangeEvent is the body of the notifications of the changes of the watched secrets.
+// The values of the secret aren't sent, the app reads them through the secrets API.
+type secretChangeEvent struct {
+	StoreName string `json:"storeName"`
+	Name      string `json:"name"`
+}
+
 // DaprRuntime holds all the core components of the runtime
 type DaprRuntime struct {
-	runtimeConfig            *Config
-	globalConfig             *config.Configuration
-	components               []components_v1alpha1.Component
-	grpc                     *grpc.Manager
-	appChannel               channel.AppChannel
+	runtimeConfig *Config
+	globalConfig  *config.Configuration
+	components    []components_v1alpha1.Component
+	grpc          *grpc.Manager
+	appChannel    channel.AppChannel
+	// appChannels holds the app channel of each protocol the app listens on, appChannel is the one of the app protocol
+	appChannels              map[Protocol]channel.AppChannel
 	appConfig                config.ApplicationConfig
 	directMessaging          messaging.DirectMessaging
 	stateStoreRegistry       state_loader.Registry
 	secretStoresRegistry     secretstores_loader.Registry
+	configurationRegistry    configuration_loader.Registry
+	keyVaultRegistry         crypto_loader.Registry
 	exporterRegistry         exporter_loader.Registry
 	serviceDiscoveryRegistry servicediscovery_loader.Registry
-	stateStores              map[string]state.Store
 	actor                    actors.Actors
 	bindingsRegistry         bindings_loader.Registry
 	inputBindings            map[string]bindings.InputBinding
+	inputBindingConfigs      map[string]inputBindingConfig
+	bindingDirections        map[string]string
 	outputBindings           map[string]bindings.OutputBinding
-	secretStores             map[string]secretstores.SecretStore
+	compStore                *compstore.ComponentStore
+	secretWatchers           map[string]*secrets_watch.Watcher
 	pubSubRegistry           pubsub_loader.Registry
 	pubSub                   pubsub.PubSub
+	pubSubs                  map[string]pubSubComponent
+	defaultPubSubName        string
+	outbox                   outbox.Outbox
+	jobs                     jobs.Scheduler
+	workflows                workflows.Engine
+	jobStateStoreName        string
 	servicediscoveryResolver servicediscovery.Resolver
 	json                     jsoniter.API
 	httpMiddlewareRegistry   http_middleware_loader.Registry
@@ -95,12 +183,39 @@ type DaprRuntime struct {
 	actorStateStoreName      string
 	actorStateStoreCount     int
 	authenticator            security.Authenticator
+	apiTLSConfig             *tls.Config
+	appTLSConfig             *tls.Config
 	namespace                string
 	scopedPublishings        []string
 	allowedTopics            []string
 	daprHTTPAPI              http.API
 	operatorClient           operatorv1pb.OperatorClient
-	topicRoutes              map[string]string
+	topicRoutes              map[subscriptionKey]string
+	subscriptions            map[subscriptionKey]runtime_pubsub.Subscription
+	scopedSubscriptions      []string
+	streamSubscriptions      *runtime_pubsub.StreamSubscriptions
+	pausedTopics             *runtime_pubsub.Pauser
+	unhealthyTopics          *runtime_pubsub.Pauser
+	streamSubscriptionsLock  sync.Mutex
+	subscribedTopics         []subscribedTopic
+	subscribedTopicsLock     sync.Mutex
+	externalChannels         map[string]channel.AppChannel
+	componentStatuses        map[string]components.Status
+	componentStatusesLock    sync.RWMutex
+	// componentsLock guards the pub/sub and binding maps, which are written while the components are initialized
+	// concurrently or reloaded and read while the APIs serve requests
+	componentsLock     sync.RWMutex
+	httpServer         http.Server
+	apiGRPCServer      grpc.Server
+	internalGRPCServer grpc.Server
+	exporters          []exporters.Exporter
+	inflight           inflightOperations
+	appHealth          appHealth
+	secretScopes       atomic.Value // map[string]config.SecretsScope, swapped when the configuration is reloaded
+	metadataAttributes *runtime_metadata.Attributes
+	auditLog           *audit.Logger
+	shutdownRequested  chan struct{}
+	shutdownOnce       sync.Once
 }
 
 // NewDaprRuntime returns a new runtime with the given runtime config and global config
@@ -111,17 +226,31 @@ func NewDaprRuntime(runtimeConfig *Config, globalConfig *config.Configuration) *
 		grpc:                     grpc.NewGRPCManager(runtimeConfig.Mode),
 		json:                     jsoniter.ConfigFastest,
 		inputBindings:            map[string]bindings.InputBinding{},
+		inputBindingConfigs:      map[string]inputBindingConfig{},
+		bindingDirections:        map[string]string{},
 		outputBindings:           map[string]bindings.OutputBinding{},
-		secretStores:             map[string]secretstores.SecretStore{},
-		stateStores:              map[string]state.Store{},
+		compStore:                compstore.New(),
+		secretWatchers:           map[string]*secrets_watch.Watcher{},
+		componentStatuses:        map[string]components.Status{},
+		shutdownRequested:        make(chan struct{}),
+		metadataAttributes:       runtime_metadata.NewAttributes(),
 		stateStoreRegistry:       state_loader.NewRegistry(),
 		bindingsRegistry:         bindings_loader.NewRegistry(),
 		pubSubRegistry:           pubsub_loader.NewRegistry(),
 		secretStoresRegistry:     secretstores_loader.NewRegistry(),
+		configurationRegistry:    configuration_loader.NewRegistry(),
+		keyVaultRegistry:         crypto_loader.NewRegistry(),
 		exporterRegistry:         exporter_loader.NewRegistry(),
 		serviceDiscoveryRegistry: servicediscovery_loader.NewRegistry(),
 		httpMiddlewareRegistry:   http_middleware_loader.NewRegistry(),
-		topicRoutes:              map[string]string{},
+		topicRoutes:              map[subscriptionKey]string{},
+		subscriptions:            map[subscriptionKey]runtime_pubsub.Subscription{},
+		pubSubs:                  map[string]pubSubComponent{},
+		streamSubscriptions:      runtime_pubsub.NewStreamSubscriptions(),
+		pausedTopics:             runtime_pubsub.NewPauser(),
+		unhealthyTopics:          runtime_pubsub.NewPauser(),
+		externalChannels:         map[string]channel.AppChannel{},
+		appChannels:              map[Protocol]channel.AppChannel{},
 	}
 }
 
@@ -146,6 +275,13 @@ func (a *DaprRuntime) Run(opts ...Option) error {
 		log.Warn(err)
 	}
 
+	// the updates of the components are applied once the components are initialized
+	err = a.beginComponentsUpdates()
+	if err != nil {
+		log.Warnf("failed to watch component updates: %s", err)
+	}
+	a.beginConfigurationUpdates()
+
 	d := time.Since(start).Seconds() * 1000
 	log.Infof("dapr initialized. Status: Running. Init Elapsed %vms", d)
 
@@ -172,27 +308,141 @@ func (a *DaprRuntime) getOperatorClient() (operatorv1pb.OperatorClient, error) {
 	return nil, nil
 }
 
+// registerPluggableComponents registers the components served by the component processes listening in the
+// sockets folder, then keeps registering the components of the processes started later. A pluggable component
+// named like a component built into the runtime is ignored.
+func (a *DaprRuntime) registerPluggableComponents(opts *runtimeOpts) {
+	builtIn := map[string]bool{}
+	for _, c := range opts.states {
+		builtIn["state."+c.Name] = true
+	}
+	for _, c := range opts.pubsubs {
+		builtIn["pubsub."+c.Name] = true
+	}
+	for _, c := range opts.inputBindings {
+		builtIn["bindings.input."+c.Name] = true
+	}
+	for _, c := range opts.outputBindings {
+		builtIn["bindings.output."+c.Name] = true
+	}
+	for _, c := range opts.secretStores {
+		builtIn["secretstores."+c.Name] = true
+	}
+
+	discoverer := pluggable.NewDiscoverer(pluggable.SocketsFolder())
+	a.registerDiscoveredComponents(discoverer, builtIn)
+	go func() {
+		ticker := time.NewTicker(pluggableDiscoveryInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.registerDiscoveredComponents(discoverer, builtIn)
+		}
+	}()
+}
+
+func (a *DaprRuntime) registerDiscoveredComponents(discoverer *pluggable.Discoverer, builtIn map[string]bool) {
+	discovered, err := discoverer.Discover()
+	if err != nil {
+		log.Warnf("failed to discover pluggable components: %s", err)
+		return
+	}
+	for _, c := range discovered.States {
+		if builtIn["state."+c.Name] {
+			log.Warnf("ignoring pluggable state store %s, a built-in state store has the same name", c.Name)
+			continue
+		}
+		a.stateStoreRegistry.Register(c)
+	}
+	for _, c := range discovered.PubSubs {
+		if builtIn["pubsub."+c.Name] {
+			log.Warnf("ignoring pluggable pub/sub %s, a built-in pub/sub has the same name", c.Name)
+			continue
+		}
+		a.pubSubRegistry.Register(c)
+	}
+	for _, c := range discovered.InputBindings {
+		if builtIn["bindings.input."+c.Name] {
+			log.Warnf("ignoring pluggable input binding %s, a built-in input binding has the same name", c.Name)
+			continue
+		}
+		a.bindingsRegistry.RegisterInputBindings(c)
+	}
+	for _, c := range discovered.OutputBindings {
+		if builtIn["bindings.output."+c.Name] {
+			log.Warnf("ignoring pluggable output binding %s, a built-in output binding has the same name", c.Name)
+			continue
+		}
+		a.bindingsRegistry.RegisterOutputBindings(c)
+	}
+	for _, c := range discovered.SecretStores {
+		if builtIn["secretstores."+c.Name] {
+			log.Warnf("ignoring pluggable secret store %s, a built-in secret store has the same name", c.Name)
+			continue
+		}
+		a.secretStoresRegistry.Register(c)
+	}
+}
+
 func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
+	if features := a.globalConfig.EnabledFeatures(); len(features) > 0 {
+		log.Infof("enabled preview features: %s", strings.Join(features, ", "))
+	}
+	a.grpc.SetMaxMessageSize(a.runtimeConfig.GRPCMaxRecvMsgSize, a.runtimeConfig.GRPCMaxSendMsgSize)
 	err := a.establishSecurity(a.runtimeConfig.SentryServiceAddress)
 	if err != nil {
 		return err
 	}
+	if a.runtimeConfig.EnableAPITLS {
+		a.apiTLSConfig, err = security.GetAPITLSConfig(a.runtimeConfig.APITLSCertFile, a.runtimeConfig.APITLSKeyFile, a.authenticator)
+		if err != nil {
+			return err
+		}
+		log.Info("the public Dapr APIs are served with TLS")
+	}
+	if a.runtimeConfig.EnableAppTLS {
+		a.appTLSConfig, err = security.GetAppTLSConfig(a.runtimeConfig.AppTLSCAFile, a.runtimeConfig.AppTLSCertFile, a.runtimeConfig.AppTLSKeyFile, a.authenticator)
+		if err != nil {
+			return err
+		}
+		a.grpc.SetAppTLSConfig(a.appTLSConfig)
+		log.Info("the app is reached with TLS")
+	}
 	a.namespace = a.getNamespace()
+	if a.isNamespaceIsolated() {
+		log.Infof("app is isolated in namespace %s", a.namespace)
+		if !a.runtimeConfig.mtlsEnabled {
+			log.Warn("namespace isolation denies all the calls to the app without mTLS, as the identity of the callers is not verified")
+		}
+	} else if a.globalConfig.Spec.AccessControlSpec.NamespaceIsolation {
+		log.Warn("namespace isolation is disabled, the namespace of the app is not set")
+	}
+	if a.globalConfig.Spec.AccessControlSpec.IsInvocationACLEnabled() && !a.runtimeConfig.mtlsEnabled {
+		log.Warn("the access control policies deny all the invocations of the app without mTLS, as the identity of the callers is not verified")
+	}
+	a.auditLog, err = a.getAuditLogger()
+	if err != nil {
+		return err
+	}
 	a.operatorClient, err = a.getOperatorClient()
 	if err != nil {
 		return err
 	}
 
+	// the pluggable secret stores are registered before the secret stores are initialized with the components
+	a.registerPluggableComponents(opts)
 	err = a.loadComponents(opts)
 	if err != nil {
 		log.Warnf("failed to load components: %s", err)
 	}
-	err = a.beginComponentsUpdates()
+	err = a.initHTTPEndpoints()
 	if err != nil {
-		log.Warnf("failed to watch component updates: %s", err)
+		log.Warnf("failed to load http endpoints: %s", err)
 	}
 
-	a.blockUntilAppIsReady()
+	err = a.blockUntilAppIsReady()
+	if err != nil {
+		return err
+	}
 
 	a.hostAddress, err = GetHostAddress()
 	if err != nil {
@@ -213,12 +463,23 @@ func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
 		log.Warnf("failed to init state: %s", err)
 	}
 
+	// Register and initialize configuration stores
+	a.configurationRegistry.Register(opts.configurations...)
+	a.initConfiguration()
+
+	// Register and initialize key vaults
+	a.keyVaultRegistry.Register(opts.keyVaults...)
+	a.initKeyVaults()
+
 	// Register and initialize pub/sub
 	a.pubSubRegistry.Register(opts.pubsubs...)
 	err = a.initPubSub()
 	if err != nil {
 		log.Warnf("failed to init pubsub: %s", err)
 	}
+	a.initOutbox()
+	a.initJobs()
+	a.initSecretWatchers()
 
 	// Register and initialize exporters
 	a.exporterRegistry.Register(opts.exporters...)
@@ -238,12 +499,16 @@ func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
 	a.bindingsRegistry.RegisterInputBindings(opts.inputBindings...)
 	a.bindingsRegistry.RegisterOutputBindings(opts.outputBindings...)
 	a.initBindings()
+	if err = a.componentsInitError(); err != nil {
+		return err
+	}
 	a.initDirectMessaging(a.servicediscoveryResolver)
 
-	err = a.initActors()
+	err = a.initActors(opts.placementClient)
 	if err != nil {
 		log.Warnf("failed to init actors: %s", err)
 	}
+	a.startAppHealthCheck()
 
 	// Register and initialize HTTP middleware
 	a.httpMiddlewareRegistry.Register(opts.httpMiddleware...)
@@ -258,7 +523,11 @@ func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
 	if err != nil {
 		log.Fatalf("failed to start API gRPC server: %s", err)
 	}
-	log.Infof("API gRPC server is running on port %v", a.runtimeConfig.APIGRPCPort)
+	if socket := a.runtimeConfig.unixDomainSocketPath("grpc"); socket != "" {
+		log.Infof("API gRPC server is running on socket %s", socket)
+	} else {
+		log.Infof("API gRPC server is running on port %v", a.runtimeConfig.APIGRPCPort)
+	}
 
 	err = a.startGRPCInternalServer(grpcAPI, a.runtimeConfig.InternalGRPCPort)
 	if err != nil {
@@ -268,7 +537,11 @@ func (a *DaprRuntime) initRuntime(opts *runtimeOpts) error {
 
 	// Start HTTP Server
 	a.startHTTPServer(a.runtimeConfig.HTTPPort, a.runtimeConfig.ProfilePort, a.runtimeConfig.AllowedOrigins, pipeline)
-	log.Infof("http server is running on port %v", a.runtimeConfig.HTTPPort)
+	if socket := a.runtimeConfig.unixDomainSocketPath("http"); socket != "" {
+		log.Infof("http server is running on socket %s", socket)
+	} else {
+		log.Infof("http server is running on port %v", a.runtimeConfig.HTTPPort)
+	}
 
 	// Announce presence to local network if self-hosted
 	err = a.announceSelf()
@@ -304,6 +577,8 @@ func (a *DaprRuntime) buildHTTPPipeline() (http_middleware.Pipeline, error) {
 }
 
 func (a *DaprRuntime) initBindings() {
+	a.initBindingDirections()
+
 	err := a.initOutputBindings(a.bindingsRegistry)
 	if err != nil {
 		log.Errorf("failed to init output bindings: %s", err)
@@ -315,566 +590,2050 @@ func (a *DaprRuntime) initBindings() {
 	}
 }
 
+// initBindingDirections reads the directions the binding components declare. The components declaring an invalid
+// direction are not initialized.
+func (a *DaprRuntime) initBindingDirections() {
+	for _, c := range a.components {
+		if strings.Index(c.Spec.Type, "bindings") != 0 {
+			continue
+		}
+		direction, err := getBindingDirection(c)
+		if err != nil {
+			log.Errorf("failed to init binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+			a.componentInitFailed(c, "direction", err)
+			continue
+		}
+		a.setBindingDirection(c.ObjectMeta.Name, direction)
+	}
+}
+
+// getBindingDirection returns the direction declared in the metadata of a binding component, both if it declares none
+func getBindingDirection(c components_v1alpha1.Component) (string, error) {
+	for _, m := range c.Spec.Metadata {
+		if m.Name != bindingDirectionMetadataKey {
+			continue
+		}
+		switch direction := strings.ToLower(strings.TrimSpace(m.Value)); direction {
+		case bindingDirectionInput, bindingDirectionOutput, bindingDirectionBoth:
+			return direction, nil
+		case "":
+			return bindingDirectionBoth, nil
+		default:
+			return "", fmt.Errorf("invalid direction %q, the direction must be %s, %s or %s", m.Value, bindingDirectionInput, bindingDirectionOutput, bindingDirectionBoth)
+		}
+	}
+	return bindingDirectionBoth, nil
+}
+
+// isBindingDirection returns whether the binding is used in the direction
+func (a *DaprRuntime) isBindingDirection(name, direction string) bool {
+	a.componentsLock.RLock()
+	defer a.componentsLock.RUnlock()
+	d, ok := a.bindingDirections[name]
+	return ok && (d == bindingDirectionBoth || d == direction)
+}
+
+// setBindingDirection records the direction a binding component declares
+func (a *DaprRuntime) setBindingDirection(name, direction string) {
+	a.componentsLock.Lock()
+	defer a.componentsLock.Unlock()
+	a.bindingDirections[name] = direction
+}
+
+// getInputBinding returns the input binding with the given name along with its settings
+func (a *DaprRuntime) getInputBinding(name string) (bindings.InputBinding, inputBindingConfig, bool) {
+	a.componentsLock.RLock()
+	defer a.componentsLock.RUnlock()
+	binding, ok := a.inputBindings[name]
+	return binding, a.inputBindingConfigs[name], ok
+}
+
+// getOutputBinding returns the output binding with the given name
+func (a *DaprRuntime) getOutputBinding(name string) (bindings.OutputBinding, bool) {
+	a.componentsLock.RLock()
+	defer a.componentsLock.RUnlock()
+	binding, ok := a.outputBindings[name]
+	return binding, ok
+}
+
 func (a *DaprRuntime) beginReadInputBindings() error {
+	a.componentsLock.RLock()
+	inputBindings := make(map[string]bindings.InputBinding, len(a.inputBindings))
 	for key, b := range a.inputBindings {
-		go func(name string, binding bindings.InputBinding) {
-			err := a.readFromBinding(name, binding)
-			if err != nil {
-				log.Errorf("error reading from input binding %s: %s", name, err)
-			}
-		}(key, b)
+		inputBindings[key] = b
+	}
+	a.componentsLock.RUnlock()
+
+	for key, b := range inputBindings {
+		a.beginReadInputBinding(key, b)
 	}
 
 	return nil
 }
 
+func (a *DaprRuntime) beginReadInputBinding(name string, binding bindings.InputBinding) {
+	go func() {
+		err := a.readFromBinding(name, binding)
+		if err != nil {
+			log.Errorf("error reading from input binding %s: %s", name, err)
+		}
+	}()
+}
+
 func (a *DaprRuntime) initDirectMessaging(resolver servicediscovery.Resolver) {
+	var hedgingDelay time.Duration
+	if d := a.globalConfig.Spec.InvocationSpec.HedgingDelay; d != "" {
+		var err error
+		hedgingDelay, err = time.ParseDuration(d)
+		if err != nil {
+			log.Warnf("invalid hedging delay %s, hedging is disabled: %s", d, err)
+		}
+	}
+
 	a.directMessaging = messaging.NewDirectMessaging(
 		a.runtimeConfig.ID,
 		a.namespace,
 		a.runtimeConfig.InternalGRPCPort,
 		a.runtimeConfig.Mode,
-		a.appChannel,
+		a.appChannelFor(InvocationBuildingBlock),
 		a.grpc.GetGRPCConnection,
 		resolver,
-		a.globalConfig.Spec.TracingSpec)
+		a.globalConfig.Spec.TracingSpec,
+		a.metadataLimits(),
+		a.externalChannels,
+		hedgingDelay,
+		a.globalConfig.Spec.AccessControlSpec.TrustDomain,
+		a.isNamespaceIsolated())
 }
 
+// beginComponentsUpdates applies the changes of the components without restarting the sidecar. The components are
+// watched through the operator in Kubernetes mode and in the components directory in self-hosted mode.
 func (a *DaprRuntime) beginComponentsUpdates() error {
-	if a.runtimeConfig.Mode != modes.KubernetesMode {
-		return nil
+	switch a.runtimeConfig.Mode {
+	case modes.KubernetesMode:
+		go a.watchOperatorComponents()
+	case modes.StandaloneMode:
+		go a.watchComponentsDirectory()
 	}
+	return nil
+}
+
+// watchOperatorComponents applies the component updates streamed by the operator. The stream is reopened when it
+// fails, and the components are reloaded from the operator to apply the updates sent while it was closed.
+func (a *DaprRuntime) watchOperatorComponents() {
+	backoff := operatorReconnectBackoff
+	for reconnect := false; ; reconnect = true {
+		if reconnect {
+			log.Infof("reconnecting to the operator in %s", backoff)
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > operatorReconnectMaxBackoff {
+				backoff = operatorReconnectMaxBackoff
+			}
+		}
 
-	go func() {
 		stream, err := a.operatorClient.ComponentUpdate(context.Background(), &empty.Empty{})
 		if err != nil {
 			log.Errorf("error from operator stream: %s", err)
-			return
+			continue
 		}
-		for {
-			c, err := stream.Recv()
-			if err != nil {
-				log.Errorf("error from operator stream: %s", err)
-				return
-			}
-			log.Debug("received component update")
-
-			var component components_v1alpha1.Component
-			err = json.Unmarshal(c.Component.Value, &component)
+		if reconnect {
+			comps, err := components.NewKubernetesComponents(a.runtimeConfig.Kubernetes, a.operatorClient).LoadComponents()
 			if err != nil {
-				log.Warnf("error deserializing component: %s", err)
+				log.Errorf("error reloading the components from the operator: %s", err)
 				continue
 			}
-			a.onComponentUpdated(component)
-		}
-	}()
-	return nil
-}
-
-func (a *DaprRuntime) onComponentUpdated(component components_v1alpha1.Component) {
-	update := false
-
-	for i, c := range a.components {
-		if c.Spec.Type == component.Spec.Type && c.ObjectMeta.Name == component.ObjectMeta.Name {
-			if reflect.DeepEqual(c.Spec.Metadata, component.Spec.Metadata) {
-				return
-			}
-
-			a.components[i] = component
-			update = true
-			break
+			a.onComponentsReloaded(comps)
 		}
-	}
+		backoff = operatorReconnectBackoff
 
-	if !update {
-		a.components = append(a.components, component)
+		err = a.receiveOperatorComponents(stream)
+		log.Errorf("error from operator stream: %s", err)
 	}
+}
 
-	if strings.Index(component.Spec.Type, "state") == 0 {
-		store, err := a.stateStoreRegistry.CreateStateStore(component.Spec.Type)
+// receiveOperatorComponents applies the component updates of the operator stream until the stream fails
+func (a *DaprRuntime) receiveOperatorComponents(stream operatorv1pb.Operator_ComponentUpdateClient) error {
+	for {
+		c, err := stream.Recv()
 		if err != nil {
-			log.Errorf("error creating state store: %s", err)
-			return
+			return err
 		}
+		log.Debug("received component update")
 
-		err = store.Init(state.Metadata{
-			Properties: a.convertMetadataItemsToProperties(component.Spec.Metadata),
-		})
-		if err != nil {
-			log.Errorf("error on init state store: %s", err)
-		} else {
-			a.stateStores[component.ObjectMeta.Name] = store
-		}
-	} else if strings.Index(component.Spec.Type, "bindings") == 0 {
-		//TODO: implement update for input bindings too
-		binding, err := a.bindingsRegistry.CreateOutputBinding(component.Spec.Type)
+		var component components_v1alpha1.Component
+		err = json.Unmarshal(c.Component.Value, &component)
 		if err != nil {
-			log.Errorf("failed to create output binding: %s", err)
-			return
+			log.Warnf("error deserializing component: %s", err)
+			continue
 		}
-
-		err = binding.Init(bindings.Metadata{
-			Properties: a.convertMetadataItemsToProperties(component.Spec.Metadata),
-			Name:       component.ObjectMeta.Name,
-		})
-		if err == nil {
-			a.outputBindings[component.ObjectMeta.Name] = binding
+		// the operator sends the deleted components with their deletion time set
+		if component.ObjectMeta.DeletionTimestamp != nil {
+			a.onComponentDeleted(component)
+			continue
 		}
+		a.onComponentUpdated(component)
 	}
 }
 
-func (a *DaprRuntime) sendBatchOutputBindingsParallel(to []string, data []byte) {
-	for _, dst := range to {
-		go func(name string) {
-			err := a.sendToOutputBinding(name, &bindings.WriteRequest{
-				Data: data,
-			})
-			if err != nil {
-				log.Error(err)
-			}
-		}(dst)
-	}
-}
+// watchComponentsDirectory reloads the components of the components directory whenever one of its files changes
+func (a *DaprRuntime) watchComponentsDirectory() {
+	dir := a.runtimeConfig.Standalone.ComponentsPath
+	events := make(chan struct{})
+	go func() {
+		defer close(events)
+		if err := fswatcher.WatchChanges(context.Background(), dir, events); err != nil {
+			log.Errorf("error watching the components directory %s: %s", dir, err)
+		}
+	}()
 
-func (a *DaprRuntime) sendBatchOutputBindingsSequential(to []string, data []byte) error {
-	for _, dst := range to {
-		err := a.sendToOutputBinding(dst, &bindings.WriteRequest{
-			Data: data,
-		})
+	loader := components.NewStandaloneComponents(a.runtimeConfig.Standalone)
+	for range events {
+		comps, err := loader.LoadComponents()
 		if err != nil {
-			return err
+			log.Warnf("error reloading the components of %s: %s", dir, err)
+			continue
 		}
+		a.onComponentsReloaded(comps)
 	}
-	return nil
 }
 
-func (a *DaprRuntime) sendToOutputBinding(name string, req *bindings.WriteRequest) error {
-	if binding, ok := a.outputBindings[name]; ok {
-		err := binding.Write(req)
-		return err
+// onComponentsReloaded applies the components reloaded from the components directory. The components which are no
+// longer declared are removed, the new and changed components are initialized.
+func (a *DaprRuntime) onComponentsReloaded(comps []components_v1alpha1.Component) {
+	declared := map[string]bool{}
+	for _, c := range comps {
+		declared[componentKey(c)] = true
+	}
+	for _, c := range append([]components_v1alpha1.Component{}, a.components...) {
+		if !declared[componentKey(c)] {
+			a.onComponentDeleted(c)
+		}
+	}
+	for _, c := range comps {
+		a.onComponentUpdated(c)
 	}
-	return fmt.Errorf("couldn't find output binding %s", name)
 }
 
-func (a *DaprRuntime) onAppResponse(response *bindings.AppResponse) error {
-	if len(response.State) > 0 {
-		go func(reqs []state.SetRequest) {
-			if a.stateStores != nil {
-				err := a.stateStores[response.StoreName].BulkSet(reqs)
-				if err != nil {
-					log.Errorf("error saving state from app response: %s", err)
-				}
-			}
-		}(response.State)
+// onComponentUpdated applies a created or updated component. The instance of an updated component is closed and
+// replaced by a new instance initialized with the new metadata.
+func (a *DaprRuntime) onComponentUpdated(component components_v1alpha1.Component) {
+	if !a.isInNamespace(component.ObjectMeta.Namespace) {
+		// the operator streams the components of all namespaces
+		return
+	}
+	if len(a.getAuthorizedComponents([]components_v1alpha1.Component{component})) == 0 {
+		// the component is removed if its scopes no longer include this app
+		a.onComponentDeleted(component)
+		return
 	}
+	component = a.processComponentSecrets(component)
 
-	if len(response.To) > 0 {
-		b, err := a.json.Marshal(&response.Data)
-		if err != nil {
-			return err
+	i := a.componentIndex(component)
+	if i < 0 {
+		a.components = append(a.components, component)
+		log.Infof("adding component %s (%s)", component.ObjectMeta.Name, component.Spec.Type)
+	} else {
+		if reflect.DeepEqual(a.components[i].Spec, component.Spec) {
+			return
 		}
-
-		if response.Concurrency == parallelConcurrency {
-			a.sendBatchOutputBindingsParallel(response.To, b)
-		} else {
-			return a.sendBatchOutputBindingsSequential(response.To, b)
+		if !a.isComponentReloadable(a.components[i]) {
+			return
 		}
+		a.closeComponent(a.components[i])
+		a.components[i] = component
+		log.Infof("updating component %s (%s)", component.ObjectMeta.Name, component.Spec.Type)
 	}
-
-	return nil
+	a.initComponent(component)
 }
 
-func (a *DaprRuntime) sendBindingEventToApp(bindingName string, data []byte, metadata map[string]string) error {
-	var response bindings.AppResponse
-
-	if a.runtimeConfig.ApplicationProtocol == GRPCProtocol {
-		client := daprclientv1pb.NewDaprClientClient(a.grpc.AppClient)
-		resp, err := client.OnBindingEvent(context.Background(), &daprclientv1pb.BindingEventEnvelope{
-			Name: bindingName,
-			Data: &any.Any{
-				Value: data,
-			},
-			Metadata: metadata,
-		})
-		if err != nil {
-			return fmt.Errorf("error invoking app: %s", err)
-		}
-		if resp != nil {
-			response.Concurrency = resp.Concurrency
-			response.To = resp.To
-
-			if resp.Data != nil {
-				var d interface{}
-				err := a.json.Unmarshal(resp.Data.Value, &d)
-				if err == nil {
-					response.Data = d
-				}
-			}
+// onComponentDeleted closes and removes a deleted component
+func (a *DaprRuntime) onComponentDeleted(component components_v1alpha1.Component) {
+	if !a.isInNamespace(component.ObjectMeta.Namespace) {
+		return
+	}
+	i := a.componentIndex(component)
+	if i < 0 || !a.isComponentReloadable(a.components[i]) {
+		return
+	}
+	a.closeComponent(a.components[i])
+	a.components = append(a.components[:i], a.components[i+1:]...)
+	log.Infof("removed component %s (%s)", component.ObjectMeta.Name, component.Spec.Type)
+}
 
-			for _, s := range resp.State {
-				var i interface{}
-				a.json.Unmarshal(s.Value.Value, &i)
+// componentCategory returns the building block of a component type, such as state for state.redis
+func componentCategory(componentType string) string {
+	return strings.SplitN(componentType, ".", 2)[0]
+}
 
-				response.State = append(response.State, state.SetRequest{
-					Key:   s.Key,
-					Value: i,
-				})
-			}
-		}
-	} else if a.runtimeConfig.ApplicationProtocol == HTTPProtocol {
-		req := invokev1.NewInvokeMethodRequest(bindingName)
-		req.WithHTTPExtension(nethttp.MethodPost, "")
-		req.WithRawData(data, invokev1.JSONContentType)
-		// TODO: Propagate Context
-		ctx := context.Background()
-		resp, err := a.appChannel.InvokeMethod(ctx, req)
-		if err != nil {
-			return fmt.Errorf("error invoking app: %s", err)
-		}
+// componentKey identifies a component, the components of different building blocks and namespaces may have the
+// same name
+func componentKey(c components_v1alpha1.Component) string {
+	return componentCategory(c.Spec.Type) + "/" + c.ObjectMeta.Namespace + "/" + c.ObjectMeta.Name
+}
 
-		if resp.Status().Code != nethttp.StatusOK {
-			return fmt.Errorf("fails to send binding event to http app channel, status code: %d", resp.Status().Code)
+// componentsOfCategory returns the loaded components of a building block, in the order they are declared
+func (a *DaprRuntime) componentsOfCategory(category string) []components_v1alpha1.Component {
+	found := []components_v1alpha1.Component{}
+	for _, c := range a.components {
+		if componentCategory(c.Spec.Type) == category {
+			found = append(found, c)
 		}
+	}
+	return found
+}
 
-		// TODO: Do we need to check content-type?
-		if err := a.json.Unmarshal(resp.Message().Data.Value, &response); err != nil {
-			log.Debugf("error deserializing app response: %s", err)
-		}
+// initConcurrently initializes the components on a bounded pool of workers and waits for all of them, so a slow
+// component doesn't hold up the others at startup. The init functions hold the components lock while they
+// save the component in the maps of the runtime. The secret stores are initialized before the other components.
+func (a *DaprRuntime) initConcurrently(components []components_v1alpha1.Component, init func(c components_v1alpha1.Component)) {
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, componentInitConcurrency)
+	for _, c := range components {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(c components_v1alpha1.Component) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			init(c)
+		}(c)
 	}
+	wg.Wait()
+}
 
-	if len(response.State) > 0 || len(response.To) > 0 {
-		if err := a.onAppResponse(&response); err != nil {
-			log.Errorf("error executing app response: %s", err)
-		}
+// initComponentInstance initializes an instance of a component following the init policy of the component and
+// returns the initialized instance. The retries initialize new instances returned by create.
+// Only the output bindings, configuration stores and key vaults can be initialized on first use.
+func (a *DaprRuntime) initComponentInstance(c components_v1alpha1.Component, instance interface{}, create func() (interface{}, error), init func(instance interface{}) error) (interface{}, error) {
+	if components.IsLazy(c) {
+		log.Warnf("component %s (%s) can't be initialized on first use, it is initialized now", c.ObjectMeta.Name, c.Spec.Type)
 	}
-	return nil
+	return a.runInitPolicy(c, instance, create, init)
 }
 
-func (a *DaprRuntime) readFromBinding(name string, binding bindings.InputBinding) error {
-	err := binding.Read(func(resp *bindings.ReadResponse) error {
+// runInitPolicy initializes an instance of a component with the timeout and retries of the component
+func (a *DaprRuntime) runInitPolicy(c components_v1alpha1.Component, instance interface{}, create func() (interface{}, error), init func(instance interface{}) error) (interface{}, error) {
+	policy, err := components.GetInitPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	initialized, err := policy.Init(instance, create, init)
+	diag.DefaultMonitoring.ComponentInitDuration(c.Spec.Type, time.Since(start))
+	return initialized, err
+}
+
+// componentInitialized records the successful initialization of a component and the capabilities of its instance
+func (a *DaprRuntime) componentInitialized(c components_v1alpha1.Component, capabilities ...string) {
+	diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+	a.setComponentStatus(c, components.StatusInitialized, nil, capabilities...)
+}
+
+// componentInitFailed records the failed initialization of a component
+func (a *DaprRuntime) componentInitFailed(c components_v1alpha1.Component, reason string, err error) {
+	diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, reason)
+	a.setComponentStatus(c, components.StatusFailed, err)
+}
+
+// setComponentStatus records the status of a component. The capabilities of the instances of a component initialized
+// twice, such as a binding used in both directions, are merged.
+func (a *DaprRuntime) setComponentStatus(c components_v1alpha1.Component, status string, err error, capabilities ...string) {
+	componentStatus := components.Status{
+		Name:            c.ObjectMeta.Name,
+		Type:            c.Spec.Type,
+		Version:         c.Spec.Version,
+		Status:          status,
+		FailOnInitError: c.Spec.FailOnInitError,
+		Capabilities:    capabilities,
+	}
+	if err != nil {
+		componentStatus.Error = err.Error()
+	}
+
+	a.componentStatusesLock.Lock()
+	defer a.componentStatusesLock.Unlock()
+	key := componentKey(c)
+	if previous, ok := a.componentStatuses[key]; ok && previous.Status == components.StatusInitialized && status == components.StatusInitialized {
+		componentStatus.Capabilities = mergeCapabilities(previous.Capabilities, capabilities)
+	}
+	a.componentStatuses[key] = componentStatus
+}
+
+// mergeCapabilities returns the capabilities of both lists without duplicates, sorted
+func mergeCapabilities(a, b []string) []string {
+	set := map[string]bool{}
+	for _, c := range append(append([]string{}, a...), b...) {
+		set[c] = true
+	}
+	merged := make([]string, 0, len(set))
+	for c := range set {
+		merged = append(merged, c)
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// ComponentStatuses returns the initialization status of the components, sorted by type and name
+func (a *DaprRuntime) ComponentStatuses() []components.Status {
+	a.componentStatusesLock.RLock()
+	statuses := make([]components.Status, 0, len(a.componentStatuses))
+	for _, status := range a.componentStatuses {
+		statuses = append(statuses, status)
+	}
+	a.componentStatusesLock.RUnlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Type != statuses[j].Type {
+			return statuses[i].Type < statuses[j].Type
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// componentsInitError returns an error naming the components which failed to initialize with failOnInitError
+func (a *DaprRuntime) componentsInitError() error {
+	failed := []string{}
+	for _, status := range a.ComponentStatuses() {
+		if status.Status == components.StatusFailed && status.FailOnInitError {
+			failed = append(failed, fmt.Sprintf("%s (%s): %s", status.Name, status.Type, status.Error))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to init components: %s", strings.Join(failed, "; "))
+}
+
+// componentIndex returns the index of the loaded component with the name and building block of the component, or -1
+func (a *DaprRuntime) componentIndex(component components_v1alpha1.Component) int {
+	key := componentKey(component)
+	for i, c := range a.components {
+		if componentKey(c) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// isComponentReloadable returns whether the changes of a component can be applied without restarting the sidecar.
+// The state stores of actors and jobs are held by the actor runtime and the job scheduler.
+func (a *DaprRuntime) isComponentReloadable(c components_v1alpha1.Component) bool {
+	if componentCategory(c.Spec.Type) == "state" && (c.ObjectMeta.Name == a.actorStateStoreName || c.ObjectMeta.Name == a.jobStateStoreName) {
+		log.Warnf("state store %s is used by actors or jobs, its changes are applied when the sidecar restarts", c.ObjectMeta.Name)
+		return false
+	}
+	return true
+}
+
+// initComponent initializes a created or updated component and wires it to the features of the runtime using it
+func (a *DaprRuntime) initComponent(c components_v1alpha1.Component) {
+	name := c.ObjectMeta.Name
+	switch componentCategory(c.Spec.Type) {
+	case "state":
+		a.initStateStore(a.stateStoreRegistry, c)
+		if _, ok := a.compStore.GetStateStore(name); ok {
+			a.selectStateStoreRoles(c)
+		}
+	case "pubsub":
+		if !a.initPubSubComponent(c) {
+			return
+		}
+		a.componentsLock.Lock()
+		isDefault := a.defaultPubSubName == "" || a.defaultPubSubName == name || a.globalConfig.Spec.PubSubSpec.DefaultComponent == name
+		if isDefault {
+			a.defaultPubSubName = name
+		}
+		c := a.pubSubs[name]
+		a.componentsLock.Unlock()
+		if isDefault {
+			a.initDefaultPubSub(name, c)
+		}
+		a.resubscribeTopics(name)
+	case "bindings":
+		direction, err := getBindingDirection(c)
+		if err != nil {
+			log.Errorf("failed to init binding %s (%s): %s", name, c.Spec.Type, err)
+			a.componentInitFailed(c, "direction", err)
+			return
+		}
+		a.setBindingDirection(name, direction)
+		if a.isBindingDirection(name, bindingDirectionOutput) {
+			a.initOutputBinding(a.bindingsRegistry, c)
+		}
+		if a.isBindingDirection(name, bindingDirectionInput) && a.appChannel != nil {
+			bindingsList := []string{}
+			if a.appProtocol(BindingsBuildingBlock) == GRPCProtocol {
+				bindingsList = a.getSubscribedBindingsGRPC()
+			}
+			if a.initInputBinding(a.bindingsRegistry, c, bindingsList) {
+				binding, _, _ := a.getInputBinding(name)
+				a.beginReadInputBinding(name, binding)
+			}
+		}
+	case "secretstores":
+		a.initSecretStore(c)
+		if w, ok := a.secretWatchers[name]; ok {
+			w.Start()
+		}
+	case "configuration":
+		a.initConfigurationStore(c)
+	case "crypto":
+		a.initKeyVault(c)
+	default:
+		log.Warnf("changes of %s components are applied when the sidecar restarts", c.Spec.Type)
+	}
+}
+
+// resubscribeTopics subscribes to the topics of the app served by the pub/sub component
+func (a *DaprRuntime) resubscribeTopics(pubSubName string) {
+	if a.appChannel == nil {
+		return
+	}
+	if len(a.topicRoutes) == 0 {
+		// the app may have subscriptions which were not read as no pub/sub was loaded
+		a.topicRoutes = a.getTopicRoutes()
+	}
+	for k := range a.topicRoutes {
+		if k.pubSubName == pubSubName || (k.pubSubName == "" && pubSubName == a.defaultPubSubName) {
+			a.subscribeTopic(k)
+		}
+	}
+}
+
+// closeComponent closes the instance of a component and removes it from the runtime
+func (a *DaprRuntime) closeComponent(c components_v1alpha1.Component) {
+	name := c.ObjectMeta.Name
+	a.componentStatusesLock.Lock()
+	delete(a.componentStatuses, componentKey(c))
+	a.componentStatusesLock.Unlock()
+	switch componentCategory(c.Spec.Type) {
+	case "state":
+		if name == a.jobStateStoreName && a.jobs != nil {
+			a.jobs.Stop()
+		}
+		store, _ := a.compStore.GetStateStore(name)
+		closeComponentInstance(c, store)
+		a.compStore.DeleteStateStore(name)
+	case "pubsub":
+		a.componentsLock.Lock()
+		pubSub := a.pubSubs[name].pubSub
+		settings := a.pubSubs[name].settings
+		delete(a.pubSubs, name)
+		if name == a.defaultPubSubName {
+			a.pubSub = nil
+			a.defaultPubSubName = ""
+		}
+		a.componentsLock.Unlock()
+		settings.stop()
+		closeComponentInstance(c, pubSub)
+	case "bindings":
+		a.componentsLock.Lock()
+		inputBinding := a.inputBindings[name]
+		outputBinding := a.outputBindings[name]
+		delete(a.inputBindings, name)
+		delete(a.inputBindingConfigs, name)
+		delete(a.outputBindings, name)
+		delete(a.bindingDirections, name)
+		a.componentsLock.Unlock()
+		closeComponentInstance(c, inputBinding)
+		closeComponentInstance(c, outputBinding)
+	case "secretstores":
+		if w, ok := a.secretWatchers[name]; ok {
+			w.Stop()
+			delete(a.secretWatchers, name)
+		}
+		store, _ := a.compStore.GetSecretStore(name)
+		closeComponentInstance(c, store)
+		a.compStore.DeleteSecretStore(name)
+	case "configuration":
+		store, _ := a.compStore.GetConfigurationStore(name)
+		closeComponentInstance(c, store)
+		a.compStore.DeleteConfigurationStore(name)
+	case "crypto":
+		vault, _ := a.compStore.GetKeyVault(name)
+		closeComponentInstance(c, vault)
+		a.compStore.DeleteKeyVault(name)
+	}
+}
+
+// closeComponentInstance closes the connections of a component instance which can be closed. The subscriptions and
+// reads of input bindings of the instances which can't be closed last until the sidecar restarts.
+func closeComponentInstance(c components_v1alpha1.Component, instance interface{}) {
+	closer, ok := instance.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		log.Warnf("error closing component %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+	}
+}
+
+// sendBatchOutputBindingsParallel invokes the output bindings at the same time and returns
+// the first error once all of them are invoked
+func (a *DaprRuntime) sendBatchOutputBindingsParallel(to []string, data []byte) error {
+	errs := make(chan error, len(to))
+	for _, dst := range to {
+		go func(name string) {
+			errs <- a.sendToOutputBinding(name, &bindings.WriteRequest{
+				Data: data,
+			})
+		}(dst)
+	}
+
+	var err error
+	for range to {
+		if e := <-errs; e != nil {
+			log.Error(e)
+			if err == nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
+
+func (a *DaprRuntime) sendBatchOutputBindingsSequential(to []string, data []byte) error {
+	for _, dst := range to {
+		err := a.sendToOutputBinding(dst, &bindings.WriteRequest{
+			Data: data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *DaprRuntime) sendToOutputBinding(name string, req *bindings.WriteRequest) error {
+	_, err := a.invokeOutputBinding(name, req)
+	return err
+}
+
+// invokeOutputBinding invokes an output binding and returns the response of the binding,
+// which is nil if the binding doesn't return the response of its operations
+func (a *DaprRuntime) invokeOutputBinding(name string, req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) {
+	if binding, ok := a.getOutputBinding(name); ok {
+		if invokable, ok := binding.(bindings_loader.InvokableOutputBinding); ok {
+			return invokable.Invoke(req)
+		}
+		return nil, binding.Write(req)
+	}
+	if a.isBindingDirection(name, bindingDirectionInput) && !a.isBindingDirection(name, bindingDirectionOutput) {
+		return nil, fmt.Errorf("binding %s is an input binding and can't be invoked, its direction must be %s or %s to invoke it", name, bindingDirectionOutput, bindingDirectionBoth)
+	}
+	return nil, fmt.Errorf("couldn't find output binding %s", name)
+}
+
+// onAppResponse executes the operations the app requested in its response to a binding event.
+// The state is saved first, in a single transaction on transactional state stores, and the output bindings are only
+// invoked once the state is saved. The event isn't acknowledged if an operation fails, so it is retried.
+func (a *DaprRuntime) onAppResponse(response *bindings.AppResponse) error {
+	if len(response.State) > 0 {
+		if err := a.saveAppResponseState(response.StoreName, response.State); err != nil {
+			return fmt.Errorf("error saving state from app response: %s", err)
+		}
+	}
+
+	if len(response.To) > 0 {
+		b, err := a.json.Marshal(&response.Data)
+		if err != nil {
+			return err
+		}
+
+		if response.Concurrency == parallelConcurrency {
+			return a.sendBatchOutputBindingsParallel(response.To, b)
+		}
+		return a.sendBatchOutputBindingsSequential(response.To, b)
+	}
+
+	return nil
+}
+
+// saveAppResponseState saves the state of an app response with the keys prefixed like the keys saved by the state API.
+// The state store can be omitted when a single state store is configured.
+func (a *DaprRuntime) saveAppResponseState(storeName string, reqs []state.SetRequest) error {
+	if stores := a.compStore.ListStateStores(); storeName == "" && len(stores) == 1 {
+		for name := range stores {
+			storeName = name
+		}
+	}
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		return fmt.Errorf("state store %s not found", storeName)
+	}
+
+	prefix, _ := a.compStore.GetStateKeyPrefix(storeName)
+	for i := range reqs {
+		reqs[i].Key = prefix.Key(reqs[i].Key)
+	}
+
+	if transactionalStore, ok := store.(state.TransactionalStore); ok {
+		operations := make([]state.TransactionalRequest, 0, len(reqs))
+		for _, req := range reqs {
+			operations = append(operations, state.TransactionalRequest{
+				Operation: state.Upsert,
+				Request:   req,
+			})
+		}
+		return transactionalStore.Multi(operations)
+	}
+	return store.BulkSet(reqs)
+}
+
+func (a *DaprRuntime) sendBindingEventToApp(bindingName string, data []byte, metadata map[string]string) error {
+	var response bindings.AppResponse
+
+	if a.appProtocol(BindingsBuildingBlock) == GRPCProtocol {
+		client := daprclientv1pb.NewDaprClientClient(a.grpc.AppClient)
+		resp, err := client.OnBindingEvent(context.Background(), &daprclientv1pb.BindingEventEnvelope{
+			Name: bindingName,
+			Data: &any.Any{
+				Value: data,
+			},
+			Metadata: metadata,
+		})
+		if err != nil {
+			return fmt.Errorf("error invoking app: %s", err)
+		}
 		if resp != nil {
-			err := a.sendBindingEventToApp(name, resp.Data, resp.Metadata)
+			response.Concurrency = resp.Concurrency
+			response.To = resp.To
+
+			if resp.Data != nil {
+				var d interface{}
+				err := a.json.Unmarshal(resp.Data.Value, &d)
+				if err == nil {
+					response.Data = d
+				}
+			}
+
+			for _, s := range resp.State {
+				var i interface{}
+				if s.Value != nil {
+					a.json.Unmarshal(s.Value.Value, &i)
+				}
+
+				response.State = append(response.State, state.SetRequest{
+					Key:      s.Key,
+					Value:    i,
+					ETag:     s.Etag,
+					Metadata: s.Metadata,
+				})
+			}
+		}
+	} else if a.appProtocol(BindingsBuildingBlock) == HTTPProtocol {
+		req := invokev1.NewInvokeMethodRequest(bindingName)
+		req.WithHTTPExtension(nethttp.MethodPost, "")
+		req.WithRawData(data, invokev1.JSONContentType)
+		// TODO: Propagate Context
+		ctx := context.Background()
+		resp, err := a.appChannelFor(BindingsBuildingBlock).InvokeMethod(ctx, req)
+		if err != nil {
+			return fmt.Errorf("error invoking app: %s", err)
+		}
+
+		if resp.Status().Code != nethttp.StatusOK {
+			return fmt.Errorf("fails to send binding event to http app channel, status code: %d", resp.Status().Code)
+		}
+
+		// TODO: Do we need to check content-type?
+		if err := a.json.Unmarshal(resp.Message().Data.Value, &response); err != nil {
+			log.Debugf("error deserializing app response: %s", err)
+		}
+	}
+
+	if len(response.State) > 0 || len(response.To) > 0 {
+		if err := a.onAppResponse(&response); err != nil {
+			return fmt.Errorf("error executing app response: %s", err)
+		}
+	}
+	return nil
+}
+
+func (a *DaprRuntime) readFromBinding(name string, binding bindings.InputBinding) error {
+	handler := func(resp *bindings.ReadResponse) error {
+		if resp != nil {
+			a.appHealth.wait()
+			if !a.inflight.begin() {
+				return errShuttingDown
+			}
+			defer a.inflight.end()
+
+			err := a.sendBindingEventWithRetries(name, resp)
 			if err != nil {
 				log.Debugf("error from app consumer for binding [%s]: %s", name, err)
 				return err
 			}
 		}
 		return nil
-	})
+	}
+	if _, c, _ := a.getInputBinding(name); c.concurrency > 1 {
+		handler = dispatchBindingEvents(name, handler, c.concurrency)
+	}
+	err := binding.Read(handler)
 	return err
 }
 
+// dispatchBindingEvents returns a handler delivering up to concurrency events of an input binding to the app at the
+// same time. The events are acknowledged to the input binding once they are dispatched, so the events which still fail
+// after the retries are kept by the dead-letter binding the concurrency requires. The handler blocks while all the
+// slots are taken, which stops the input binding from reading more events.
+func dispatchBindingEvents(name string, handler func(*bindings.ReadResponse) error, concurrency int) func(*bindings.ReadResponse) error {
+	slots := make(chan struct{}, concurrency)
+	return func(resp *bindings.ReadResponse) error {
+		slots <- struct{}{}
+		go func() {
+			defer func() { <-slots }()
+			if err := handler(resp); err != nil {
+				log.Errorf("failed to deliver event of input binding %s, the event is dropped: %s", name, err)
+			}
+		}()
+		return nil
+	}
+}
+
+// sendBindingEventWithRetries retries the events the app failed to process according to the retry policy of the
+// input binding. If all retries fail and the input binding has a dead-letter binding, the event is written to the
+// dead-letter binding and acknowledged to the input binding instead of being redelivered by the component.
+func (a *DaprRuntime) sendBindingEventWithRetries(name string, resp *bindings.ReadResponse) error {
+	_, c, _ := a.getInputBinding(name)
+
+	err := a.sendBindingEventToApp(name, resp.Data, resp.Metadata)
+	for retry := 1; err != nil && retry <= c.retryPolicy.MaxRetries; retry++ {
+		backoff := c.retryPolicy.Backoff(retry)
+		log.Debugf("failed to deliver event of input binding %s, retrying in %s: %s", name, backoff, err)
+		diag.DefaultMonitoring.BindingEventRetried(name)
+		time.Sleep(backoff)
+		err = a.sendBindingEventToApp(name, resp.Data, resp.Metadata)
+	}
+
+	if err == nil {
+		return nil
+	}
+	diag.DefaultMonitoring.BindingEventRetriesExhausted(name)
+
+	if c.deadLetterBinding == "" || c.deadLetterBinding == name {
+		return err
+	}
+
+	log.Warnf("failed to deliver event of input binding %s, writing to dead-letter binding %s: %s", name, c.deadLetterBinding, err)
+	dlErr := a.sendToOutputBinding(c.deadLetterBinding, &bindings.WriteRequest{
+		Data:     resp.Data,
+		Metadata: resp.Metadata,
+	})
+	if dlErr != nil {
+		return fmt.Errorf("error writing event to dead-letter binding %s: %s", c.deadLetterBinding, dlErr)
+	}
+	return nil
+}
+
+// getInputBindingConfig reads the retry policy, the dead-letter binding and the concurrency of an input binding from its metadata.
+// Input bindings without retry policy leave the redelivery of failed events to their component.
+func (a *DaprRuntime) getInputBindingConfig(name string, properties map[string]string) inputBindingConfig {
+	base := runtime_pubsub.DefaultRetryPolicy()
+	base.MaxRetries = 0
+	policy, _, err := runtime_pubsub.ParseRetryPolicy(properties, base)
+	if err != nil {
+		log.Warnf("invalid retry policy for input binding %s, events are not retried: %s", name, err)
+	}
+	c := inputBindingConfig{
+		retryPolicy:       policy,
+		deadLetterBinding: properties[deadLetterBindingMetadataKey],
+		concurrency:       1,
+	}
+	if val := properties[bindingConcurrencyMetadataKey]; val != "" {
+		concurrency, err := strconv.Atoi(val)
+		switch {
+		case err != nil || concurrency < 1:
+			log.Warnf("invalid concurrency %q for input binding %s, events are delivered one at a time", val, name)
+		case concurrency > 1 && (c.deadLetterBinding == "" || c.deadLetterBinding == name):
+			// the events are acknowledged before the app handles them, the failed events would be lost
+			log.Warnf("concurrency of input binding %s requires %s, events are delivered one at a time", name, deadLetterBindingMetadataKey)
+		default:
+			c.concurrency = concurrency
+		}
+	}
+	return c
+}
+
 func (a *DaprRuntime) startHTTPServer(port, profilePort int, allowedOrigins string, pipeline http_middleware.Pipeline) {
-	a.daprHTTPAPI = http.NewAPI(a.runtimeConfig.ID, a.appChannel, a.directMessaging, a.stateStores, a.secretStores, a.getPublishAdapter(), a.actor, a.sendToOutputBinding, a.globalConfig.Spec.TracingSpec)
-	serverConf := http.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port, profilePort, allowedOrigins, a.runtimeConfig.EnableProfiling)
+	a.daprHTTPAPI = http.NewAPI(http.APIOpts{
+		AppID:                 a.runtimeConfig.ID,
+		AppChannel:            a.appChannelFor(InvocationBuildingBlock),
+		DirectMessaging:       a.directMessaging,
+		CompStore:             a.compStore,
+		SecretScopesFn:        a.SecretScopes,
+		PublishFn:             a.getPublishToAdapter(),
+		BulkPublishFn:         a.getBulkPublishAdapter(),
+		StateOutbox:           a.outbox,
+		SubscriptionManager:   a,
+		Actor:                 a.actor,
+		SendToOutputBindingFn: a.invokeOutputBinding,
+		JobScheduler:          a.jobs,
+		WorkflowEngine:        a.workflows,
+		ComponentStatusesFn:   a.ComponentStatuses,
+		ShutdownFn:            a.RequestShutdown,
+		AppHealthFn:           a.AppHealth,
+		App:                   a.appMetadata(),
+		Attributes:            a.metadataAttributes,
+		APISpec:               a.globalConfig.Spec.APISpec,
+		AuditLog:              a.auditLog,
+		TracingSpec:           a.globalConfig.Spec.TracingSpec,
+	})
+	serverConf := http.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port, profilePort, allowedOrigins, a.runtimeConfig.EnableProfiling, a.runtimeConfig.unixDomainSocketPath("http"), a.runtimeConfig.MaxRequestBodySize, a.apiTLSConfig)
 
-	server := http.NewServer(a.daprHTTPAPI, serverConf, a.globalConfig.Spec.TracingSpec, pipeline)
-	server.StartNonBlocking()
+	a.httpServer = http.NewServer(a.daprHTTPAPI, serverConf, a.globalConfig.Spec.TracingSpec, pipeline)
+	a.httpServer.StartNonBlocking()
 }
 
 func (a *DaprRuntime) startGRPCInternalServer(api grpc.API, port int) error {
-	serverConf := grpc.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port)
-	server := grpc.NewInternalServer(api, serverConf, a.globalConfig.Spec.TracingSpec, a.authenticator)
-	err := server.StartNonBlocking()
+	serverConf := grpc.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port, "", a.runtimeConfig.GRPCMaxRecvMsgSize, a.runtimeConfig.GRPCMaxSendMsgSize, nil)
+	a.internalGRPCServer = grpc.NewInternalServer(api, serverConf, a.globalConfig.Spec.TracingSpec, a.authenticator)
+	err := a.internalGRPCServer.StartNonBlocking()
 	return err
 }
 
-func (a *DaprRuntime) startGRPCAPIServer(api grpc.API, port int) error {
-	serverConf := grpc.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port)
-	server := grpc.NewAPIServer(api, serverConf, a.globalConfig.Spec.TracingSpec)
-	err := server.StartNonBlocking()
-	return err
-}
+func (a *DaprRuntime) startGRPCAPIServer(api grpc.API, port int) error {
+	serverConf := grpc.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port, a.runtimeConfig.unixDomainSocketPath("grpc"), a.runtimeConfig.GRPCMaxRecvMsgSize, a.runtimeConfig.GRPCMaxSendMsgSize, a.apiTLSConfig)
+	a.apiGRPCServer = grpc.NewAPIServer(api, serverConf, a.globalConfig.Spec.APISpec, a.globalConfig.Spec.TracingSpec)
+	err := a.apiGRPCServer.StartNonBlocking()
+	return err
+}
+
+// appMetadata describes how the sidecar reaches the app, for the metadata APIs
+func (a *DaprRuntime) appMetadata() runtime_metadata.App {
+	return runtime_metadata.App{
+		Port:     a.runtimeConfig.ApplicationPort,
+		Protocol: string(a.runtimeConfig.ApplicationProtocol),
+	}
+}
+
+// getAuditLogger returns the audit log of the secrets API calls and the state deletes of the app,
+// or nil when the audit log isn't enabled in the configuration
+func (a *DaprRuntime) getAuditLogger() (*audit.Logger, error) {
+	spec := a.globalConfig.Spec.AuditSpec
+	if !spec.Enabled {
+		return nil, nil
+	}
+	sink, err := audit.NewSink(spec.Sink, spec.BindingName, a.invokeOutputBinding)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the audit log: %s", err)
+	}
+	if spec.Sink == audit.BindingSink {
+		log.Infof("audit events are sent to the output binding %s", spec.BindingName)
+	} else {
+		log.Info("audit events are written to the log")
+	}
+	return audit.NewLogger(a.runtimeConfig.ID, sink, spec.BlockWhenFull), nil
+}
+
+func (a *DaprRuntime) getGRPCAPI() grpc.API {
+	return grpc.NewAPI(grpc.APIOpts{
+		AppID:                 a.runtimeConfig.ID,
+		AppChannel:            a.appChannelFor(InvocationBuildingBlock),
+		CompStore:             a.compStore,
+		SecretScopesFn:        a.SecretScopes,
+		PublishFn:             a.getPublishToAdapter(),
+		BulkPublishFn:         a.getBulkPublishAdapter(),
+		SubscribeStreamFn:     a.getSubscribeStreamAdapter(),
+		DirectMessaging:       a.directMessaging,
+		Actor:                 a.actor,
+		SendToOutputBindingFn: a.invokeOutputBinding,
+		JobScheduler:          a.jobs,
+		WorkflowEngine:        a.workflows,
+		ShutdownFn:            a.RequestShutdown,
+		ComponentStatusesFn:   a.ComponentStatuses,
+		SubscriptionManager:   a,
+		App:                   a.appMetadata(),
+		Attributes:            a.metadataAttributes,
+		Namespace:             a.namespace,
+		AccessControl:         a.globalConfig.Spec.AccessControlSpec,
+		AuditLog:              a.auditLog,
+		TracingSpec:           a.globalConfig.Spec.TracingSpec,
+		MetadataLimits:        a.metadataLimits(),
+	})
+}
+
+// metadataLimits returns the size limits of the metadata of service invocation
+func (a *DaprRuntime) metadataLimits() invokev1.MetadataLimits {
+	return invokev1.NewMetadataLimits(a.globalConfig.Spec.MetadataSpec.MaxTotalSize, a.globalConfig.Spec.MetadataSpec.MaxValueLength)
+}
+
+// initOutbox creates the outbox publishing the events of state transactions once they are committed
+func (a *DaprRuntime) initOutbox() {
+	if a.compStore.StateStoresLen() == 0 {
+		return
+	}
+	a.outbox = outbox.NewOutbox(a.runtimeConfig.ID, a.compStore, a.getPublishAdapter())
+	a.outbox.Start(outbox.DefaultSweepInterval)
+}
+
+// initJobs creates the scheduler of the jobs of the app, which are persisted in the state store
+// with the jobStateStore metadata
+func (a *DaprRuntime) initJobs() {
+	store, ok := a.compStore.GetStateStore(a.jobStateStoreName)
+	if !ok {
+		return
+	}
+	a.jobs = jobs.NewScheduler(a.runtimeConfig.ID, store, a.invokeJob)
+	a.jobs.Start(jobs.DefaultPollInterval)
+}
+
+// invokeJob invokes the job/<name> method of the app with the data of a due job
+func (a *DaprRuntime) invokeJob(job *jobs.Job) error {
+	if a.appChannel == nil {
+		return errors.New("app channel not initialized")
+	}
+
+	req := invokev1.NewInvokeMethodRequest(jobMethodPrefix + job.Name)
+	req.WithHTTPExtension(nethttp.MethodPost, "")
+	req.WithRawData(job.Data, invokev1.JSONContentType)
+
+	// TODO Propagate context
+	ctx := context.Background()
+	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	if err != nil {
+		return fmt.Errorf("error invoking app: %s", err)
+	}
+
+	status := resp.Status()
+	if resp.IsHTTPResponse() {
+		return invokev1.ErrorFromHTTPResponseCode(int(status.Code), status.Message)
+	} else if status.Code != 0 {
+		return invokev1.ErrorFromInternalStatus(status)
+	}
+	return nil
+}
+
+// initSecretWatchers starts polling the secrets watched by the app, which are listed in the watchSecrets
+// metadata of the secret stores
+func (a *DaprRuntime) initSecretWatchers() {
+	for _, w := range a.secretWatchers {
+		w.Start()
+	}
+}
+
+// notifySecretChange flushes the cached secrets of the store, so that the app reads the new values,
+// and invokes the secrets/<store>/<name> method of the app
+func (a *DaprRuntime) notifySecretChange(storeName, name string) error {
+	store, _ := a.compStore.GetSecretStore(storeName)
+	if flusher, ok := store.(secrets_cache.Flusher); ok {
+		flusher.Flush()
+	}
+	if a.appChannel == nil {
+		return errors.New("app channel not initialized")
+	}
+
+	b, err := a.json.Marshal(&secretChangeEvent{StoreName: storeName, Name: name})
+	if err != nil {
+		return err
+	}
+	req := invokev1.NewInvokeMethodRequest(secretMethodPrefix + storeName + "/" + name)
+	req.WithHTTPExtension(nethttp.MethodPost, "")
+	req.WithRawData(b, invokev1.JSONContentType)
+
+	// TODO Propagate context
+	ctx := context.Background()
+	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	if err != nil {
+		return fmt.Errorf("error invoking app: %s", err)
+	}
+
+	status := resp.Status()
+	if resp.IsHTTPResponse() {
+		return invokev1.ErrorFromHTTPResponseCode(int(status.Code), status.Message)
+	} else if status.Code != 0 {
+		return invokev1.ErrorFromInternalStatus(status)
+	}
+	return nil
+}
+
+func (a *DaprRuntime) getPublishAdapter() func(*pubsub.PublishRequest) error {
+	if a.pubSub == nil {
+		return nil
+	}
+	return a.Publish
+}
+
+func (a *DaprRuntime) getPublishToAdapter() func(string, *pubsub.PublishRequest) error {
+	if a.pubSub == nil {
+		return nil
+	}
+	return a.PublishTo
+}
+
+func (a *DaprRuntime) getBulkPublishAdapter() func(*runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error) {
+	if a.pubSub == nil {
+		return nil
+	}
+	return a.PublishBulk
+}
+
+func (a *DaprRuntime) getSubscribedBindingsGRPC() []string {
+	client := daprclientv1pb.NewDaprClientClient(a.grpc.AppClient)
+	resp, err := client.GetBindingsSubscriptions(context.Background(), &empty.Empty{})
+	bindings := []string{}
+
+	if err == nil && resp != nil {
+		bindings = resp.Bindings
+	}
+	return bindings
+}
+
+func (a *DaprRuntime) isAppSubscribedToBinding(binding string, bindingsList []string) bool {
+	// if gRPC, looks for the binding in the list of bindings returned from the app
+	if a.appProtocol(BindingsBuildingBlock) == GRPCProtocol {
+		for _, b := range bindingsList {
+			if b == binding {
+				return true
+			}
+		}
+	} else if a.appProtocol(BindingsBuildingBlock) == HTTPProtocol {
+		// if HTTP, check if there's an endpoint listening for that binding
+		req := invokev1.NewInvokeMethodRequest(binding)
+		req.WithHTTPExtension(nethttp.MethodOptions, "")
+		req.WithRawData(nil, invokev1.JSONContentType)
+
+		// TODO: Propagate Context
+		ctx := context.Background()
+		resp, err := a.appChannelFor(BindingsBuildingBlock).InvokeMethod(ctx, req)
+		return err == nil && resp.Status().Code != nethttp.StatusNotFound
+	}
+	return false
+}
+
+func (a *DaprRuntime) initInputBindings(registry bindings_loader.Registry) error {
+	if a.appChannel == nil {
+		return fmt.Errorf("app channel not initialized")
+	}
+
+	inputBindings := []components_v1alpha1.Component{}
+	for _, c := range a.components {
+		if strings.Index(c.Spec.Type, "bindings") == 0 && a.isBindingDirection(c.ObjectMeta.Name, bindingDirectionInput) {
+			inputBindings = append(inputBindings, c)
+		}
+	}
+	if len(inputBindings) == 0 {
+		return nil
+	}
+
+	bindingsList := []string{}
+	if a.appProtocol(BindingsBuildingBlock) == GRPCProtocol {
+		bindingsList = a.getSubscribedBindingsGRPC()
+	}
+
+	a.initConcurrently(inputBindings, func(c components_v1alpha1.Component) {
+		a.initInputBinding(registry, c, bindingsList)
+	})
+	return nil
+}
+
+// initInputBinding initializes an input binding the app is subscribed to, bindingsList holds the input bindings
+// the gRPC app is subscribed to
+func (a *DaprRuntime) initInputBinding(registry bindings_loader.Registry, c components_v1alpha1.Component, bindingsList []string) bool {
+	subscribed := a.isAppSubscribedToBinding(c.ObjectMeta.Name, bindingsList)
+	if !subscribed {
+		if a.isBindingDirection(c.ObjectMeta.Name, bindingDirectionInput) && !a.isBindingDirection(c.ObjectMeta.Name, bindingDirectionOutput) {
+			log.Warnf("input binding %s (%s) is not read, the app isn't subscribed to it", c.ObjectMeta.Name, c.Spec.Type)
+		}
+		return false
+	}
+
+	binding, err := registry.CreateInputBinding(c.Spec.Type)
+	if err != nil {
+		log.Errorf("failed to create input binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+		a.componentInitFailed(c, "creation", err)
+		return false
+	}
+	props := a.convertMetadataItemsToProperties(c.Spec.Metadata)
+	instance, err := a.initComponentInstance(c, binding, func() (interface{}, error) {
+		return registry.CreateInputBinding(c.Spec.Type)
+	}, func(instance interface{}) error {
+		return instance.(bindings.InputBinding).Init(bindings.Metadata{
+			Properties: props,
+			Name:       c.ObjectMeta.Name,
+		})
+	})
+	if err != nil {
+		log.Errorf("failed to init input binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+		a.componentInitFailed(c, "init", err)
+		return false
+	}
+	binding = instance.(bindings.InputBinding)
+
+	log.Infof("successful init for input binding %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
+	a.componentsLock.Lock()
+	a.inputBindings[c.ObjectMeta.Name] = binding
+	a.inputBindingConfigs[c.ObjectMeta.Name] = a.getInputBindingConfig(c.ObjectMeta.Name, props)
+	a.componentsLock.Unlock()
+	a.componentInitialized(c, components.CapabilityInputBinding)
+	return true
+}
+
+func (a *DaprRuntime) initOutputBindings(registry bindings_loader.Registry) error {
+	outputBindings := []components_v1alpha1.Component{}
+	for _, c := range a.components {
+		if strings.Index(c.Spec.Type, "bindings") == 0 && a.isBindingDirection(c.ObjectMeta.Name, bindingDirectionOutput) {
+			outputBindings = append(outputBindings, c)
+		}
+	}
+	a.initConcurrently(outputBindings, func(c components_v1alpha1.Component) {
+		a.initOutputBinding(registry, c)
+	})
+	return nil
+}
+
+func (a *DaprRuntime) initOutputBinding(registry bindings_loader.Registry, c components_v1alpha1.Component) {
+	binding, err := registry.CreateOutputBinding(c.Spec.Type)
+	if err != nil {
+		log.Errorf("failed to create output binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+		a.componentInitFailed(c, "creation", err)
+		return
+	}
+	if binding == nil {
+		return
+	}
+
+	create := func() (interface{}, error) {
+		return registry.CreateOutputBinding(c.Spec.Type)
+	}
+	init := func(instance interface{}) error {
+		return instance.(bindings.OutputBinding).Init(bindings.Metadata{
+			Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
+			Name:       c.ObjectMeta.Name,
+		})
+	}
+	if components.IsLazy(c) {
+		a.componentsLock.Lock()
+		a.outputBindings[c.ObjectMeta.Name] = newLazyOutputBinding(a.lazyComponentInit(c, binding, create, init, outputBindingCapabilities(binding)...))
+		a.componentsLock.Unlock()
+		a.registerLazyComponent(c)
+		return
+	}
+
+	instance, err := a.initComponentInstance(c, binding, create, init)
+	if err != nil {
+		log.Errorf("failed to init output binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
+		a.componentInitFailed(c, "init", err)
+		return
+	}
+	binding = instance.(bindings.OutputBinding)
+	log.Infof("successful init for output binding %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
+	a.componentsLock.Lock()
+	a.outputBindings[c.ObjectMeta.Name] = binding
+	a.componentsLock.Unlock()
+	a.componentInitialized(c, outputBindingCapabilities(binding)...)
+}
+
+// Refer for state store api decision  https://github.com/dapr/dapr/blob/master/docs/decision_records/api/API-008-multi-state-store-api-design.md
+func (a *DaprRuntime) initState(registry state_loader.Registry) error {
+	stateStores := a.componentsOfCategory("state")
+	a.initConcurrently(stateStores, func(s components_v1alpha1.Component) {
+		a.initStateStore(registry, s)
+	})
+
+	// the stores are initialized concurrently, the roles go to the first declared stores
+	for _, s := range stateStores {
+		if _, ok := a.compStore.GetStateStore(s.ObjectMeta.Name); ok {
+			a.selectStateStoreRoles(s)
+		}
+	}
+
+	if a.actorStateStoreName == "" || a.actorStateStoreCount != 1 {
+		log.Warnf("either no actor state store or multiple actor state stores are specified in the configuration, actor stores specified: %d", a.actorStateStoreCount)
+	}
+
+	return nil
+}
+
+// initStateStore initializes a state store component along with the features configured in its metadata
+func (a *DaprRuntime) initStateStore(registry state_loader.Registry, s components_v1alpha1.Component) {
+	store, err := registry.CreateStateStore(s.Spec.Type)
+	if err != nil {
+		log.Warnf("error creating state store %s: %s", s.Spec.Type, err)
+		a.componentInitFailed(s, "creation", err)
+		return
+	}
+	if store == nil {
+		return
+	}
+
+	props := a.convertMetadataItemsToProperties(s.Spec.Metadata)
+	instance, err := a.initComponentInstance(s, store, func() (interface{}, error) {
+		return registry.CreateStateStore(s.Spec.Type)
+	}, func(instance interface{}) error {
+		return instance.(state.Store).Init(state.Metadata{
+			Properties: props,
+		})
+	})
+	if err != nil {
+		a.componentInitFailed(s, "init", err)
+		log.Warnf("error initializing state store %s: %s", s.Spec.Type, err)
+		return
+	}
+	store = instance.(state.Store)
+
+	keyPrefix, err := keyprefix.New(props, a.runtimeConfig.ID, s.ObjectMeta.Name, a.namespace)
+	if err != nil {
+		a.componentInitFailed(s, "init", err)
+		log.Warnf("error initializing the key prefix of state store %s: %s", s.Spec.Type, err)
+		return
+	}
+
+	supportedConsistency, err := consistency.GetSupported(props)
+	if err != nil {
+		a.componentInitFailed(s, "init", err)
+		log.Warnf("error loading the consistency modes of state store %s: %s", s.Spec.Type, err)
+		return
+	}
+
+	cacheConfig, err := cache.GetConfig(props)
+	if err != nil {
+		a.componentInitFailed(s, "init", err)
+		log.Warnf("error loading the read cache configuration of state store %s: %s", s.Spec.Type, err)
+		return
+	}
+
+	mirrorConfig, err := mirror.GetConfig(props, s.ObjectMeta.Name)
+	if err != nil {
+		a.componentInitFailed(s, "init", err)
+		log.Warnf("error loading the mirroring configuration of state store %s: %s", s.Spec.Type, err)
+		return
+	}
 
-func (a *DaprRuntime) getGRPCAPI() grpc.API {
-	return grpc.NewAPI(a.runtimeConfig.ID, a.appChannel, a.stateStores, a.secretStores, a.getPublishAdapter(), a.directMessaging, a.actor, a.sendToOutputBinding, a.globalConfig.Spec.TracingSpec)
-}
+	encryptionKeys, err := encryption.GetComponentEncryptionKeys(props)
+	if err != nil {
+		a.componentInitFailed(s, "init", err)
+		log.Warnf("error loading the encryption keys of state store %s: %s", s.Spec.Type, err)
+		return
+	}
+	if encryptionKeys != nil {
+		store = encryption.NewStore(store, encryptionKeys)
+	}
 
-func (a *DaprRuntime) getPublishAdapter() func(*pubsub.PublishRequest) error {
-	if a.pubSub == nil {
-		return nil
+	ttlSupported := props[ttl.NativeTTLMetadataKey] == "true"
+	if !ttlSupported && props[ttl.EmulateTTLMetadataKey] == "true" {
+		if _, ok := store.(query.Querier); ok {
+			log.Warnf("the expiry of keys can't be emulated on state store %s as it supports queries", s.ObjectMeta.Name)
+		} else {
+			// emulate the expiry of keys saved with a TTL on stores which don't expire keys natively
+			expiringStore := ttl.NewStore(a.runtimeConfig.ID, store, log)
+			expiringStore.Start(ttl.DefaultSweepInterval)
+			store = expiringStore
+			ttlSupported = true
+		}
 	}
-	return a.Publish
-}
 
-func (a *DaprRuntime) getSubscribedBindingsGRPC() []string {
-	client := daprclientv1pb.NewDaprClientClient(a.grpc.AppClient)
-	resp, err := client.GetBindingsSubscriptions(context.Background(), &empty.Empty{})
-	bindings := []string{}
+	if cacheConfig != nil {
+		store = cache.NewStore(store, *cacheConfig)
+	}
 
-	if err == nil && resp != nil {
-		bindings = resp.Bindings
+	if mirrorConfig != nil {
+		// the secondary store may be declared after this one, it is resolved when the writes are mirrored
+		mirroredStore := mirror.NewStore(store, &stateMirrorTarget{runtime: a, name: mirrorConfig.Target, source: keyPrefix}, *mirrorConfig, log)
+		mirroredStore.Start(mirror.DefaultRetryInterval)
+		store = mirroredStore
 	}
-	return bindings
-}
 
-func (a *DaprRuntime) isAppSubscribedToBinding(binding string, bindingsList []string) bool {
-	// if gRPC, looks for the binding in the list of bindings returned from the app
-	if a.runtimeConfig.ApplicationProtocol == GRPCProtocol {
-		for _, b := range bindingsList {
-			if b == binding {
-				return true
-			}
-		}
-	} else if a.runtimeConfig.ApplicationProtocol == HTTPProtocol {
-		// if HTTP, check if there's an endpoint listening for that binding
-		req := invokev1.NewInvokeMethodRequest(binding)
-		req.WithHTTPExtension(nethttp.MethodOptions, "")
-		req.WithRawData(nil, invokev1.JSONContentType)
+	a.componentsLock.Lock()
+	a.compStore.AddStateStore(s.ObjectMeta.Name, store)
+	a.compStore.SetStateKeyPrefix(s.ObjectMeta.Name, keyPrefix)
+	a.compStore.SetStateConsistency(s.ObjectMeta.Name, supportedConsistency)
+	a.compStore.SetStatePrefixDelete(s.ObjectMeta.Name, props[bulk.AllowPrefixDeleteMetadataKey] == "true")
+	a.componentsLock.Unlock()
 
-		// TODO: Propagate Context
-		ctx := context.Background()
-		resp, err := a.appChannel.InvokeMethod(ctx, req)
-		return err == nil && resp.Status().Code != nethttp.StatusNotFound
+	capabilities := stateStoreCapabilities(store, props)
+	if ttlSupported {
+		capabilities = append(capabilities, components.CapabilityTTL)
 	}
-	return false
+	a.componentInitialized(s, capabilities...)
 }
 
-func (a *DaprRuntime) initInputBindings(registry bindings_loader.Registry) error {
-	if a.appChannel == nil {
-		return fmt.Errorf("app channel not initialized")
+// stateStoreCapabilities returns the capabilities of a state store as seen by the APIs, once wrapped by the
+// features of the runtime
+func stateStoreCapabilities(store state.Store, props map[string]string) []string {
+	capabilities := []string{}
+	if _, ok := store.(state.TransactionalStore); ok {
+		capabilities = append(capabilities, components.CapabilityTransactional)
 	}
-
-	bindingsList := []string{}
-	if a.runtimeConfig.ApplicationProtocol == GRPCProtocol {
-		bindingsList = a.getSubscribedBindingsGRPC()
+	if _, ok := store.(query.Querier); ok {
+		capabilities = append(capabilities, components.CapabilityQueryAPI)
+	}
+	if _, ok := store.(bulk.Getter); ok {
+		capabilities = append(capabilities, components.CapabilityBulkGet)
 	}
+	if props[bulk.AllowPrefixDeleteMetadataKey] == "true" {
+		capabilities = append(capabilities, components.CapabilityPrefixDelete)
+	}
+	return capabilities
+}
 
-	for _, c := range a.components {
-		if strings.Index(c.Spec.Type, "bindings") == 0 {
-			subscribed := a.isAppSubscribedToBinding(c.ObjectMeta.Name, bindingsList)
-			if !subscribed {
-				continue
-			}
+// outputBindingCapabilities returns the capabilities of an output binding
+func outputBindingCapabilities(binding bindings.OutputBinding) []string {
+	capabilities := []string{components.CapabilityOutputBinding}
+	if _, ok := binding.(bindings_loader.InvokableOutputBinding); ok {
+		capabilities = append(capabilities, components.CapabilityInvokeResponse)
+	}
+	return capabilities
+}
 
-			binding, err := registry.CreateInputBinding(c.Spec.Type)
-			if err != nil {
-				log.Errorf("failed to create input binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
-				continue
-			}
-			err = binding.Init(bindings.Metadata{
-				Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
-				Name:       c.ObjectMeta.Name,
-			})
-			if err != nil {
-				log.Errorf("failed to init input binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
-				continue
-			}
+// selectStateStoreRoles makes an initialized state store the job or actor state store, as declared in its metadata
+func (a *DaprRuntime) selectStateStoreRoles(s components_v1alpha1.Component) {
+	props := a.convertMetadataItemsToProperties(s.Spec.Metadata)
+	if props[jobStateStore] == "true" && a.jobStateStoreName == "" {
+		a.jobStateStoreName = s.ObjectMeta.Name
+	}
 
-			log.Infof("successful init for input binding %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
-			a.inputBindings[c.ObjectMeta.Name] = binding
-			diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+	// set specified actor store if "actorStateStore" is true in the spec.
+	actorStoreSpecified := props[actorStateStore]
+	if actorStoreSpecified == "true" {
+		if a.actorStateStoreCount++; a.actorStateStoreCount == 1 {
+			a.actorStateStoreName = s.ObjectMeta.Name
 		}
 	}
-	return nil
 }
 
-func (a *DaprRuntime) initOutputBindings(registry bindings_loader.Registry) error {
-	for _, c := range a.components {
-		if strings.Index(c.Spec.Type, "bindings") == 0 {
-			binding, err := registry.CreateOutputBinding(c.Spec.Type)
-			if err != nil {
-				log.Errorf("failed to create output binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
-				continue
-			}
+// initConfiguration initializes the configuration store components
+func (a *DaprRuntime) initConfiguration() {
+	a.initConcurrently(a.componentsOfCategory("configuration"), a.initConfigurationStore)
+}
 
-			if binding != nil {
-				err := binding.Init(bindings.Metadata{
-					Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
-					Name:       c.ObjectMeta.Name,
-				})
-				if err != nil {
-					log.Errorf("failed to init output binding %s (%s): %s", c.ObjectMeta.Name, c.Spec.Type, err)
-					diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
-					continue
-				}
-				log.Infof("successful init for output binding %s (%s)", c.ObjectMeta.Name, c.Spec.Type)
-				a.outputBindings[c.ObjectMeta.Name] = binding
-				diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
-			}
-		}
+func (a *DaprRuntime) initConfigurationStore(c components_v1alpha1.Component) {
+	store, err := a.configurationRegistry.Create(c.Spec.Type)
+	if err != nil {
+		log.Warnf("error creating configuration store %s: %s", c.Spec.Type, err)
+		a.componentInitFailed(c, "creation", err)
+		return
+	}
+	create := func() (interface{}, error) {
+		return a.configurationRegistry.Create(c.Spec.Type)
+	}
+	init := func(instance interface{}) error {
+		return instance.(configuration.Store).Init(configuration.Metadata{
+			Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
+		})
+	}
+	if components.IsLazy(c) {
+		a.compStore.AddConfigurationStore(c.ObjectMeta.Name, newLazyConfigurationStore(a.lazyComponentInit(c, store, create, init)))
+		a.registerLazyComponent(c)
+		return
 	}
-	return nil
-}
 
-// Refer for state store api decision  https://github.com/dapr/dapr/blob/master/docs/decision_records/api/API-008-multi-state-store-api-design.md
-func (a *DaprRuntime) initState(registry state_loader.Registry) error {
-	for _, s := range a.components {
-		if strings.Index(s.Spec.Type, "state") == 0 {
-			store, err := registry.CreateStateStore(s.Spec.Type)
-			if err != nil {
-				log.Warnf("error creating state store %s: %s", s.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "creation")
-				continue
-			}
-			if store != nil {
-				props := a.convertMetadataItemsToProperties(s.Spec.Metadata)
-				err := store.Init(state.Metadata{
-					Properties: props,
-				})
-				if err != nil {
-					diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "init")
-					log.Warnf("error initializing state store %s: %s", s.Spec.Type, err)
-					continue
-				}
+	instance, err := a.initComponentInstance(c, store, create, init)
+	if err != nil {
+		log.Warnf("error initializing configuration store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
+		a.componentInitFailed(c, "init", err)
+		return
+	}
+	a.compStore.AddConfigurationStore(c.ObjectMeta.Name, instance.(configuration.Store))
+	a.componentInitialized(c)
+}
 
-				a.stateStores[s.ObjectMeta.Name] = store
+// initKeyVaults initializes the key vault components of the crypto API
+func (a *DaprRuntime) initKeyVaults() {
+	a.initConcurrently(a.componentsOfCategory("crypto"), a.initKeyVault)
+}
 
-				// set specified actor store if "actorStateStore" is true in the spec.
-				actorStoreSpecified := props[actorStateStore]
-				if actorStoreSpecified == "true" {
-					if a.actorStateStoreCount++; a.actorStateStoreCount == 1 {
-						a.actorStateStoreName = s.ObjectMeta.Name
-					}
-				}
-				diag.DefaultMonitoring.ComponentInitialized(s.Spec.Type)
-			}
-		}
+func (a *DaprRuntime) initKeyVault(c components_v1alpha1.Component) {
+	vault, err := a.keyVaultRegistry.Create(c.Spec.Type)
+	if err != nil {
+		log.Warnf("error creating key vault %s: %s", c.Spec.Type, err)
+		a.componentInitFailed(c, "creation", err)
+		return
+	}
+	create := func() (interface{}, error) {
+		return a.keyVaultRegistry.Create(c.Spec.Type)
+	}
+	init := func(instance interface{}) error {
+		return instance.(crypto.KeyVault).Init(crypto.Metadata{
+			Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
+		})
+	}
+	if components.IsLazy(c) {
+		a.compStore.AddKeyVault(c.ObjectMeta.Name, newLazyKeyVault(a.lazyComponentInit(c, vault, create, init)))
+		a.registerLazyComponent(c)
+		return
 	}
 
-	if a.actorStateStoreName == "" || a.actorStateStoreCount != 1 {
-		log.Warnf("either no actor state store or multiple actor state stores are specified in the configuration, actor stores specified: %d", a.actorStateStoreCount)
+	instance, err := a.initComponentInstance(c, vault, create, init)
+	if err != nil {
+		log.Warnf("error initializing key vault %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
+		a.componentInitFailed(c, "init", err)
+		return
 	}
+	a.compStore.AddKeyVault(c.ObjectMeta.Name, instance.(crypto.KeyVault))
+	a.componentInitialized(c)
+}
 
-	return nil
+// subscriptionKey identifies a subscription of the app by the name of its pub/sub component, empty for the default
+// component, and its topic. The same topic name on two pub/sub components is two different subscriptions.
+type subscriptionKey struct {
+	pubSubName string
+	topic      string
 }
 
-func (a *DaprRuntime) getTopicRoutes() map[string]string {
-	topicRoutes := map[string]string{}
+func (a *DaprRuntime) getTopicRoutes() map[subscriptionKey]string {
+	topicRoutes := map[subscriptionKey]string{}
 	if a.appChannel == nil {
 		return topicRoutes
 	}
 
 	var subscriptions []runtime_pubsub.Subscription
-	if a.runtimeConfig.ApplicationProtocol == HTTPProtocol {
-		subscriptions = runtime_pubsub.GetSubscriptionsHTTP(a.appChannel, log)
-	} else if a.runtimeConfig.ApplicationProtocol == GRPCProtocol {
+	if a.appProtocol(PubSubBuildingBlock) == HTTPProtocol {
+		subscriptions = runtime_pubsub.GetSubscriptionsHTTP(a.appChannelFor(PubSubBuildingBlock), log)
+	} else if a.appProtocol(PubSubBuildingBlock) == GRPCProtocol {
 		client := daprclientv1pb.NewDaprClientClient(a.grpc.AppClient)
 		subscriptions = runtime_pubsub.GetSubscriptionsGRPC(client, log)
 	}
 
+	for _, s := range a.getDeclarativeSubscriptions() {
+		k := subscriptionKey{pubSubName: s.PubSubName, topic: s.Topic}
+		topicRoutes[k] = s.Route
+		a.subscriptions[k] = s
+	}
+
 	for _, s := range subscriptions {
-		topicRoutes[s.Topic] = s.Route
+		k := subscriptionKey{pubSubName: s.PubSubName, topic: s.Topic}
+		if _, ok := topicRoutes[k]; ok {
+			log.Infof("programmatic subscription to topic %s overrides the declarative subscription", s.Topic)
+		}
+		topicRoutes[k] = s.Route
+		a.subscriptions[k] = s
 	}
 
 	if len(topicRoutes) > 0 {
 		topics := []string{}
-		for t := range topicRoutes {
-			topics = append(topics, t)
+		for k := range topicRoutes {
+			if k.pubSubName == "" {
+				topics = append(topics, k.topic)
+			} else {
+				topics = append(topics, k.pubSubName+"/"+k.topic)
+			}
 		}
 		log.Infof("app is subscribed to the following topics: %v", topics)
 	}
 	return topicRoutes
 }
 
+// getDeclarativeSubscriptions returns the declarative subscriptions scoped to this app
+func (a *DaprRuntime) getDeclarativeSubscriptions() []runtime_pubsub.Subscription {
+	var loader components.SubscriptionLoader
+	switch a.runtimeConfig.Mode {
+	case modes.KubernetesMode:
+		if a.operatorClient == nil {
+			return nil
+		}
+		loader = components.NewKubernetesComponents(a.runtimeConfig.Kubernetes, a.operatorClient)
+	case modes.StandaloneMode:
+		loader = components.NewStandaloneComponents(a.runtimeConfig.Standalone)
+	default:
+		return nil
+	}
+
+	subscriptions := []runtime_pubsub.Subscription{}
+	for _, s := range runtime_pubsub.GetDeclarativeSubscriptions(loader, log) {
+		if !a.isInNamespace(s.Namespace) || !a.isAppInScopes(s.Scopes) {
+			continue
+		}
+		subscriptions = append(subscriptions, s.Subscription)
+	}
+	return subscriptions
+}
+
 func (a *DaprRuntime) initExporters() error {
 	for _, c := range a.components {
 		if strings.Index(c.Spec.Type, "exporter") == 0 {
 			exporter, err := a.exporterRegistry.Create(c.Spec.Type)
 			if err != nil {
 				log.Warnf("error creating exporter %s: %s", c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
+				a.componentInitFailed(c, "creation", err)
 				continue
 			}
 
 			properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
 
-			err = exporter.Init(a.runtimeConfig.ID, a.hostAddress, exporters.Metadata{
-				Properties: properties,
+			instance, err := a.initComponentInstance(c, exporter, func() (interface{}, error) {
+				return a.exporterRegistry.Create(c.Spec.Type)
+			}, func(instance interface{}) error {
+				return instance.(exporters.Exporter).Init(a.runtimeConfig.ID, a.hostAddress, exporters.Metadata{
+					Properties: properties,
+				})
 			})
 			if err != nil {
 				log.Warnf("error initializing exporter %s: %s", c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+				a.componentInitFailed(c, "init", err)
 				continue
 			}
-			diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+			a.exporters = append(a.exporters, instance.(exporters.Exporter))
+			a.componentInitialized(c)
 		}
 	}
 	return nil
 }
 
+// pubSubSettings are the retries, deduplication and delayed delivery configured in the metadata of a pub/sub
+// component. They apply to the messages of the component only, and are replaced as a whole, under the
+// components lock, when the component is reloaded.
+type pubSubSettings struct {
+	retryPolicy  runtime_pubsub.RetryPolicy
+	deduplicator *runtime_pubsub.Deduplicator
+	delayQueue   *runtime_pubsub.DelayQueue
+}
+
+func defaultPubSubSettings() pubSubSettings {
+	return pubSubSettings{retryPolicy: runtime_pubsub.DefaultRetryPolicy()}
+}
+
+// stop stops the delay queue of the settings replaced, the events it keeps are published by the delay queue of
+// the settings replacing them when both use the same state store
+func (s *pubSubSettings) stop() {
+	if s != nil && s.delayQueue != nil {
+		s.delayQueue.Stop()
+	}
+}
+
+// pubSubComponent is a loaded pub/sub component along with the topic scopes read from its metadata
+type pubSubComponent struct {
+	pubSub              pubsub.PubSub
+	properties          map[string]string
+	scopedSubscriptions []string
+	scopedPublishings   []string
+	allowedTopics       []string
+	schemaValidator     *runtime_pubsub.SchemaValidator
+	// settings are nil for a component using the default settings
+	settings *pubSubSettings
+}
+
 func (a *DaprRuntime) initPubSub() error {
-	var scopedSubscriptions []string
+	pubSubs := a.componentsOfCategory("pubsub")
+	a.initConcurrently(pubSubs, func(c components_v1alpha1.Component) {
+		a.initPubSubComponent(c)
+	})
 
-	for _, c := range a.components {
-		if strings.Index(c.Spec.Type, "pubsub") == 0 {
-			pubSub, err := a.pubSubRegistry.Create(c.Spec.Type)
-			if err != nil {
-				log.Warnf("error creating pub sub %s: %s", c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
-				continue
-			}
+	a.componentsLock.Lock()
+	// the pub subs are initialized concurrently, the first declared pub sub is the default one
+	for _, c := range pubSubs {
+		if _, ok := a.pubSubs[c.ObjectMeta.Name]; ok && a.defaultPubSubName == "" {
+			a.defaultPubSubName = c.ObjectMeta.Name
+		}
+	}
+
+	if name := a.globalConfig.Spec.PubSubSpec.DefaultComponent; name != "" {
+		if _, ok := a.pubSubs[name]; ok {
+			a.defaultPubSubName = name
+		} else if len(a.pubSubs) > 0 {
+			log.Warnf("default pub sub %s not found, using pub sub %s", name, a.defaultPubSubName)
+		}
+	}
+	if len(a.pubSubs) > 1 {
+		log.Infof("multiple pub subs loaded, %s is the default pub sub", a.defaultPubSubName)
+	}
+	name := a.defaultPubSubName
+	c, ok := a.pubSubs[name]
+	a.componentsLock.Unlock()
+	if ok {
+		a.initDefaultPubSub(name, c)
+	}
+
+	if a.pubSub != nil && a.appChannel != nil {
+		a.topicRoutes = a.getTopicRoutes()
+
+		for k := range a.topicRoutes {
+			a.subscribeTopic(k)
+		}
+	}
+	return nil
+}
+
+// initPubSubComponent initializes a pub/sub component, it returns false if the component fails to initialize
+func (a *DaprRuntime) initPubSubComponent(c components_v1alpha1.Component) bool {
+	pubSub, err := a.pubSubRegistry.Create(c.Spec.Type)
+	if err != nil {
+		log.Warnf("error creating pub sub %s: %s", c.Spec.Type, err)
+		a.componentInitFailed(c, "creation", err)
+		return false
+	}
+
+	properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
+	properties["consumerID"] = a.runtimeConfig.ID
+	if a.isNamespaceIsolated() {
+		// apps with the same id in different namespaces are different consumers
+		properties["consumerID"] = a.namespace + "." + a.runtimeConfig.ID
+	}
+
+	instance, err := a.initComponentInstance(c, pubSub, func() (interface{}, error) {
+		return a.pubSubRegistry.Create(c.Spec.Type)
+	}, func(instance interface{}) error {
+		return instance.(pubsub.PubSub).Init(pubsub.Metadata{
+			Properties: properties,
+		})
+	})
+	if err != nil {
+		log.Warnf("error initializing pub sub %s: %s", c.Spec.Type, err)
+		a.componentInitFailed(c, "init", err)
+		return false
+	}
+	pubSub = instance.(pubsub.PubSub)
+
+	schemaValidator, err := runtime_pubsub.NewSchemaValidator(properties)
+	if err != nil {
+		log.Warnf("error loading the topic schemas of pub sub %s: %s", c.Spec.Type, err)
+		a.componentInitFailed(c, "init", err)
+		return false
+	}
+	if a.isNamespaceIsolated() {
+		pubSub = runtime_pubsub.NewNamespacedPubSub(pubSub, a.namespace)
+	}
+
+	a.setPubSubComponent(c.ObjectMeta.Name, pubSubComponent{
+		pubSub:              pubSub,
+		properties:          properties,
+		scopedSubscriptions: scopes.GetScopedTopics(scopes.SubscriptionScopes, a.runtimeConfig.ID, properties),
+		scopedPublishings:   scopes.GetScopedTopics(scopes.PublishingScopes, a.runtimeConfig.ID, properties),
+		allowedTopics:       scopes.GetAllowedTopics(properties),
+		schemaValidator:     schemaValidator,
+		settings:            a.getPubSubSettingsOf(c.ObjectMeta.Name, pubSub, properties),
+	})
+
+	capabilities := []string{}
+	if _, ok := pubSub.(runtime_pubsub.BulkPublisher); ok {
+		capabilities = append(capabilities, components.CapabilityBulkPublish)
+	}
+	a.componentInitialized(c, capabilities...)
+	return true
+}
+
+// setPubSubComponent adds the pub/sub component, or replaces it when it is reloaded. The delay queue of the
+// replaced component is stopped.
+func (a *DaprRuntime) setPubSubComponent(name string, c pubSubComponent) {
+	a.componentsLock.Lock()
+	previous := a.pubSubs[name].settings
+	a.pubSubs[name] = c
+	a.componentsLock.Unlock()
+	previous.stop()
+}
+
+// getPublishFunc returns the function delivering the messages of the subscription to the app over its protocol
+func (a *DaprRuntime) getPublishFunc(sub runtime_pubsub.Subscription) func(msg *pubsub.NewMessage) error {
+	switch a.appProtocol(PubSubBuildingBlock) {
+	case HTTPProtocol:
+		return func(msg *pubsub.NewMessage) error {
+			return a.publishMessageHTTP(msg, sub)
+		}
+	case GRPCProtocol:
+		return func(msg *pubsub.NewMessage) error {
+			return a.publishMessageGRPC(msg, sub)
+		}
+	}
+	return nil
+}
+
+// subscribeTopic subscribes to a topic of the app on the pub/sub component of its subscription
+func (a *DaprRuntime) subscribeTopic(k subscriptionKey) {
+	t := k.topic
+	sub := a.subscriptions[k]
+	component, ok := a.getPubSubComponent(sub.PubSubName)
+	if !ok {
+		log.Warnf("pub sub %s of the subscription to topic %s not found", sub.PubSubName, t)
+		return
+	}
+	allowed := isTopicAllowed(t, component.allowedTopics, component.scopedSubscriptions)
+	if !allowed {
+		log.Warnf("subscription to topic %s is not allowed", t)
+		return
+	}
+
+	handler := a.getPublishFunc(sub)
+	if bulk := sub.BulkSubscribe; bulk.Enabled {
+		if a.appProtocol(PubSubBuildingBlock) == HTTPProtocol {
+			handler = runtime_pubsub.NewBulkSubscriber(t, bulk, func(topic string, msgs []*pubsub.NewMessage) []error {
+				return a.publishMessagesHTTPBulk(sub, msgs)
+			}).Handle
+		} else {
+			log.Warnf("bulk subscribe is only supported for http apps, delivering messages of topic %s one by one", t)
+		}
+	}
+	if sub.BulkSubscribe.Enabled && sub.Concurrency == runtime_pubsub.ConcurrencySingle {
+		log.Warnf("topic %s uses single concurrency, bulk subscribe will deliver batches of one message", t)
+	}
+
+	// The concurrency limit covers retries, so the messages of a single concurrency topic stay ordered.
+	// Messages wait for the app to be healthy, for their topic to be resumed and for their partition key
+	// before taking a concurrency slot.
+	err := component.pubSub.Subscribe(pubsub.SubscribeRequest{
+		Topic: t,
+	}, a.unhealthyTopics.Gate(a.pausedTopics.Gate(runtime_pubsub.OrderByPartitionKey(runtime_pubsub.LimitConcurrency(func(msg *pubsub.NewMessage) error {
+		return a.deliverMessage(msg, sub, handler)
+	}, sub.Concurrency, sub.MaxConcurrency)))))
+	if err != nil {
+		log.Warnf("failed to subscribe to topic %s: %s", t, err)
+		return
+	}
+	a.addSubscribedTopic(component.pubSub, t)
+}
+
+// initDefaultPubSub applies the scopes of the default pub/sub component, which serves the requests
+// and subscriptions not naming a pub/sub
+func (a *DaprRuntime) initDefaultPubSub(name string, c pubSubComponent) {
+	a.componentsLock.Lock()
+	a.pubSub = c.pubSub
+	a.scopedSubscriptions = c.scopedSubscriptions
+	a.scopedPublishings = c.scopedPublishings
+	a.allowedTopics = c.allowedTopics
+	a.componentsLock.Unlock()
+}
+
+// getPubSubSettingsOf returns the retries, deduplication and delayed delivery configured in the metadata of the
+// pub/sub component, or nil if the component uses the default settings
+func (a *DaprRuntime) getPubSubSettingsOf(name string, pubSub pubsub.PubSub, properties map[string]string) *pubSubSettings {
+	settings := defaultPubSubSettings()
+	policy, found, err := runtime_pubsub.ParseRetryPolicy(properties, runtime_pubsub.DefaultRetryPolicy())
+	if err != nil {
+		log.Warnf("invalid retry policy for pub sub %s, using the default retry policy: %s", name, err)
+	} else {
+		settings.retryPolicy = policy
+	}
+	settings.deduplicator = a.getDeduplicator(name, properties)
+	settings.delayQueue = a.getDelayQueue(name, pubSub, properties)
+	if !found && settings.deduplicator == nil && settings.delayQueue == nil {
+		return nil
+	}
+	return &settings
+}
+
+// getPubSubComponent returns the pub/sub component with the given name, or the default component if the name is empty
+func (a *DaprRuntime) getPubSubComponent(name string) (pubSubComponent, bool) {
+	a.componentsLock.RLock()
+	defer a.componentsLock.RUnlock()
+	if name == "" || name == a.defaultPubSubName {
+		c := a.pubSubs[a.defaultPubSubName]
+		c.pubSub = a.pubSub
+		c.scopedSubscriptions = a.scopedSubscriptions
+		c.scopedPublishings = a.scopedPublishings
+		c.allowedTopics = a.allowedTopics
+		return c, a.pubSub != nil
+	}
+	c, ok := a.pubSubs[name]
+	return c, ok
+}
+
+// getPubSubSettings returns the settings of the pub/sub component with the given name, or of the default component
+// if the name is empty
+func (a *DaprRuntime) getPubSubSettings(name string) pubSubSettings {
+	if c, ok := a.getPubSubComponent(name); ok && c.settings != nil {
+		return *c.settings
+	}
+	return defaultPubSubSettings()
+}
+
+// Subscriptions returns the subscriptions of the app along with the pub/sub component serving each of them
+func (a *DaprRuntime) Subscriptions() []runtime_pubsub.SubscriptionStatus {
+	subscriptions := []runtime_pubsub.SubscriptionStatus{}
+	for k, route := range a.topicRoutes {
+		pubSubName := k.pubSubName
+		if pubSubName == "" {
+			pubSubName = a.defaultPubSubName
+		}
+		subscriptions = append(subscriptions, runtime_pubsub.SubscriptionStatus{
+			PubSubName: pubSubName,
+			Topic:      k.topic,
+			Route:      route,
+			Paused:     a.pausedTopics.IsPaused(k.topic),
+		})
+	}
+	return subscriptions
+}
+
+// isTopicSubscribed returns true if the app subscribes to the topic on the pub/sub component, or on any
+// component if the name is empty
+func (a *DaprRuntime) isTopicSubscribed(pubSubName, topic string) bool {
+	for k := range a.topicRoutes {
+		if k.topic != topic {
+			continue
+		}
+		if name := k.pubSubName; pubSubName == "" || name == pubSubName || (name == "" && pubSubName == a.defaultPubSubName) {
+			return true
+		}
+	}
+	return false
+}
+
+// PauseSubscription stops delivering the messages of the topic to the app until the subscription is resumed.
+// The subscriptions to the topic on every pub/sub component are paused.
+func (a *DaprRuntime) PauseSubscription(topic string) error {
+	if !a.isTopicSubscribed("", topic) {
+		return runtime_pubsub.SubscriptionNotFoundError{Topic: topic}
+	}
+	if a.pausedTopics.Pause(topic) {
+		log.Infof("paused subscription to topic %s", topic)
+	}
+	return nil
+}
+
+// ResumeSubscription resumes delivering the messages of the topic to the app
+func (a *DaprRuntime) ResumeSubscription(topic string) error {
+	if !a.isTopicSubscribed("", topic) {
+		return runtime_pubsub.SubscriptionNotFoundError{Topic: topic}
+	}
+	if a.pausedTopics.Resume(topic) {
+		log.Infof("resumed subscription to topic %s", topic)
+	}
+	return nil
+}
+
+// SubscribeStream subscribes a gRPC stream of the app to a topic and returns the function unsubscribing it.
+// The component subscription of a topic is created when the first stream subscribes to it and is kept
+// afterwards. Messages arriving while no stream is connected are redelivered by the component.
+func (a *DaprRuntime) SubscribeStream(topic string, handler func(msg *pubsub.NewMessage) error) (func(), error) {
+	if a.pubSub == nil {
+		return nil, errors.New("pub sub is not configured")
+	}
+	if !a.isPubSubOperationAllowed(topic, a.scopedSubscriptions) {
+		return nil, runtime_pubsub.NotAllowedError{Topic: topic, AppID: a.runtimeConfig.ID}
+	}
+	if a.isTopicSubscribed(a.defaultPubSubName, topic) {
+		return nil, fmt.Errorf("topic %s is already subscribed by the app", topic)
+	}
+
+	a.streamSubscriptionsLock.Lock()
+	defer a.streamSubscriptionsLock.Unlock()
+
+	if !a.streamSubscriptions.HasTopic(topic) {
+		err := a.pubSub.Subscribe(pubsub.SubscribeRequest{
+			Topic: topic,
+		}, func(msg *pubsub.NewMessage) error {
+			return a.deliverMessage(msg, runtime_pubsub.Subscription{Topic: topic}, a.streamSubscriptions.Deliver)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to topic %s: %s", topic, err)
+		}
+		a.addSubscribedTopic(a.pubSub, topic)
+		log.Infof("app subscribed to topic %s over a stream", topic)
+	}
+	return a.streamSubscriptions.Add(topic, handler), nil
+}
+
+// getSubscribeStreamAdapter returns the function subscribing gRPC streams to topics, or nil if there is no pub/sub component
+func (a *DaprRuntime) getSubscribeStreamAdapter() func(topic string, handler func(msg *pubsub.NewMessage) error) (func(), error) {
+	if a.pubSub == nil {
+		return nil
+	}
+	return a.SubscribeStream
+}
 
-			properties := a.convertMetadataItemsToProperties(c.Spec.Metadata)
-			properties["consumerID"] = a.runtimeConfig.ID
+// stateMirrorTarget is a secondary state store the writes of a state store are mirrored to
+type stateMirrorTarget struct {
+	runtime *DaprRuntime
+	name    string
+	// source is the key prefix of the mirrored state store
+	source keyprefix.Prefix
+}
 
-			err = pubSub.Init(pubsub.Metadata{
-				Properties: properties,
-			})
-			if err != nil {
-				log.Warnf("error initializing pub sub %s: %s", c.Spec.Type, err)
-				diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
-				continue
-			}
+func (t *stateMirrorTarget) Store() (state.Store, bool) {
+	store, ok := t.runtime.compStore.GetStateStore(t.name)
+	return store, ok
+}
 
-			scopedSubscriptions = scopes.GetScopedTopics(scopes.SubscriptionScopes, a.runtimeConfig.ID, properties)
-			a.scopedPublishings = scopes.GetScopedTopics(scopes.PublishingScopes, a.runtimeConfig.ID, properties)
-			a.allowedTopics = scopes.GetAllowedTopics(properties)
+// Key returns the key of the secondary store saving the same key of the app
+func (t *stateMirrorTarget) Key(key string) string {
+	prefix, _ := t.runtime.compStore.GetStateKeyPrefix(t.name)
+	return prefix.Key(t.source.OriginalKey(key))
+}
 
-			a.pubSub = pubSub
-			diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
-			break
-		}
+// getDeduplicator returns the deduplicator configured in the pub/sub component metadata, or nil if deduplication is disabled
+func (a *DaprRuntime) getDeduplicator(componentType string, properties map[string]string) *runtime_pubsub.Deduplicator {
+	storeName, ttl, err := runtime_pubsub.ParseDeduplicationSpec(properties)
+	if err != nil {
+		log.Warnf("invalid deduplication configuration for pub sub %s, deduplication is disabled: %s", componentType, err)
+		return nil
 	}
-
-	var publishFunc func(msg *pubsub.NewMessage) error
-	switch a.runtimeConfig.ApplicationProtocol {
-	case HTTPProtocol:
-		publishFunc = a.publishMessageHTTP
-	case GRPCProtocol:
-		publishFunc = a.publishMessageGRPC
+	if storeName == "" {
+		return nil
+	}
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		log.Warnf("deduplication state store %s of pub sub %s not found, deduplication is disabled", storeName, componentType)
+		return nil
 	}
+	return runtime_pubsub.NewDeduplicator(a.runtimeConfig.ID, componentType, store, ttl)
+}
 
-	if a.pubSub != nil && a.appChannel != nil {
-		a.topicRoutes = a.getTopicRoutes()
+// getDelayQueue starts the delay queue of the delayed events of the pub/sub component if its metadata names a state
+// store for it, it returns nil otherwise
+func (a *DaprRuntime) getDelayQueue(componentType string, pubSub pubsub.PubSub, properties map[string]string) *runtime_pubsub.DelayQueue {
+	storeName := properties[runtime_pubsub.DelayQueueStoreMetadataKey]
+	if storeName == "" {
+		return nil
+	}
+	store, ok := a.compStore.GetStateStore(storeName)
+	if !ok {
+		log.Warnf("delay queue state store %s of pub sub %s not found, delayed delivery is disabled", storeName, componentType)
+		return nil
+	}
+	delayQueue := runtime_pubsub.NewDelayQueue(a.runtimeConfig.ID, store, pubSub.Publish, log)
+	delayQueue.Start(runtime_pubsub.DefaultDelayQueueSweepInterval)
+	return delayQueue
+}
 
-		for t := range a.topicRoutes {
-			allowed := a.isPubSubOperationAllowed(t, scopedSubscriptions)
-			if !allowed {
-				log.Warnf("subscription to topic %s is not allowed", t)
-				continue
-			}
+// Publish forwards the publish request to the default Pub/Sub component
+func (a *DaprRuntime) Publish(req *pubsub.PublishRequest) error {
+	return a.PublishTo("", req)
+}
 
-			err := a.pubSub.Subscribe(pubsub.SubscribeRequest{
-				Topic: t,
-			}, publishFunc)
-			if err != nil {
-				log.Warnf("failed to subscribe to topic %s: %s", t, err)
-			}
+// PublishTo is an adapter method for the runtime to pre-validate publish requests
+// And then forward them to the named Pub/Sub component, or the default one if the name is empty.
+// Events delayed to a future time are kept in the delay queue until they are due.
+// This method is used by the HTTP and gRPC APIs.
+func (a *DaprRuntime) PublishTo(pubSubName string, req *pubsub.PublishRequest) error {
+	component, ok := a.getPubSubComponent(pubSubName)
+	if !ok {
+		return runtime_pubsub.NotFoundError{PubSubName: pubSubName}
+	}
+	if allowed := isTopicAllowed(req.Topic, component.allowedTopics, component.scopedPublishings); !allowed {
+		return runtime_pubsub.NotAllowedError{Topic: req.Topic, AppID: a.runtimeConfig.ID}
+	}
+	if component.schemaValidator != nil {
+		if err := component.schemaValidator.Validate(req.Topic, req.Data); err != nil {
+			return err
 		}
 	}
-	return nil
+	if deliverAt, ok := runtime_pubsub.DeliverAt(req.Data); ok && deliverAt.After(time.Now()) {
+		// The delay queue of the component publishes to it
+		if component.settings == nil || component.settings.delayQueue == nil {
+			return runtime_pubsub.ErrDelayedDeliveryNotConfigured
+		}
+		return component.settings.delayQueue.Enqueue(req, deliverAt)
+	}
+	return component.pubSub.Publish(req)
 }
 
-// Publish is an adapter method for the runtime to pre-validate publish requests
-// And then forward them to the Pub/Sub component.
-// This method is used by the HTTP and gRPC APIs.
-func (a *DaprRuntime) Publish(req *pubsub.PublishRequest) error {
-	if allowed := a.isPubSubOperationAllowed(req.Topic, a.scopedPublishings); !allowed {
-		return fmt.Errorf("topic %s is not allowed for app id %s", req.Topic, a.runtimeConfig.ID)
+// PublishBulk is an adapter method for the runtime to pre-validate bulk publish requests
+// and then forward them to the Pub/Sub component.
+func (a *DaprRuntime) PublishBulk(req *runtime_pubsub.BulkPublishRequest) ([]runtime_pubsub.BulkPublishResponseEntry, error) {
+	component, ok := a.getPubSubComponent(req.PubSubName)
+	if !ok {
+		return nil, runtime_pubsub.NotFoundError{PubSubName: req.PubSubName}
 	}
-	return a.pubSub.Publish(req)
+	if allowed := isTopicAllowed(req.Topic, component.allowedTopics, component.scopedPublishings); !allowed {
+		return nil, runtime_pubsub.NotAllowedError{Topic: req.Topic, AppID: a.runtimeConfig.ID}
+	}
+	return runtime_pubsub.PublishBulk(component.pubSub, req), nil
 }
 
 func (a *DaprRuntime) isPubSubOperationAllowed(topic string, scopedTopics []string) bool {
+	return isTopicAllowed(topic, a.allowedTopics, scopedTopics)
+}
+
+func isTopicAllowed(topic string, allowedTopics, scopedTopics []string) bool {
 	inAllowedTopics := false
 
 	// first check if allowedTopics contain it
-	if len(a.allowedTopics) > 0 {
-		for _, t := range a.allowedTopics {
+	if len(allowedTopics) > 0 {
+		for _, t := range allowedTopics {
 			if t == topic {
 				inAllowedTopics = true
 				break
@@ -923,58 +2682,244 @@ func (a *DaprRuntime) initServiceDiscovery() error {
 	return nil
 }
 
-func (a *DaprRuntime) publishMessageHTTP(msg *pubsub.NewMessage) error {
-	route := a.topicRoutes[msg.Topic]
+// deliverMessage sends a message of the subscription to the app, dropping it if its TTL expired. If deduplication
+// is enabled on the pub/sub component of the subscription, messages which were already processed are acknowledged
+// without being delivered again.
+func (a *DaprRuntime) deliverMessage(msg *pubsub.NewMessage, sub runtime_pubsub.Subscription, publishFunc func(msg *pubsub.NewMessage) error) error {
+	if !a.inflight.begin() {
+		// the message is left to the pub/sub component, which redelivers it once the app restarts
+		return errShuttingDown
+	}
+	defer a.inflight.end()
+
+	rawPayload := runtime_pubsub.IsRawPayload(sub.Metadata)
+	if !rawPayload && runtime_pubsub.HasExpired(msg.Data) {
+		log.Warnf("dropping expired message on topic %s", msg.Topic)
+		return nil
+	}
+
+	settings := a.getPubSubSettings(sub.PubSubName)
+	deduplicate := settings.deduplicator != nil && !rawPayload
+	if deduplicate && settings.deduplicator.IsDuplicate(msg.Topic, msg.Data) {
+		log.Debugf("skipping already processed message on topic %s", msg.Topic)
+		diag.DefaultMonitoring.PubsubMessageDeduplicated(msg.Topic)
+		return nil
+	}
+
+	err := a.deliverMessageWithRetries(msg, sub, settings.retryPolicy, publishFunc)
+	if err == nil && deduplicate {
+		if markErr := settings.deduplicator.MarkProcessed(msg.Topic, msg.Data); markErr != nil {
+			log.Warnf("failed to record processed message on topic %s: %s", msg.Topic, markErr)
+		}
+	}
+	return err
+}
+
+// deliverMessageWithRetries retries failed deliveries according to the retry policy of the subscription, or of
+// the component if the subscription has none. Messages the app asks to drop are acknowledged. If all retries fail
+// and the subscription has a dead-letter topic, the message is forwarded to the dead-letter topic instead of
+// being redelivered by the component.
+func (a *DaprRuntime) deliverMessageWithRetries(msg *pubsub.NewMessage, sub runtime_pubsub.Subscription, policy runtime_pubsub.RetryPolicy, publishFunc func(msg *pubsub.NewMessage) error) error {
+	if sub.RetryPolicy != nil {
+		policy = *sub.RetryPolicy
+	}
+
+	err := publishFunc(msg)
+	for retry := 1; err != nil && !errors.Is(err, runtime_pubsub.ErrMessageDropped) && retry <= policy.MaxRetries; retry++ {
+		backoff := policy.Backoff(retry)
+		log.Debugf("failed to deliver message on topic %s, retrying in %s: %s", msg.Topic, backoff, err)
+		diag.DefaultMonitoring.PubsubDeliveryRetried(msg.Topic)
+		time.Sleep(backoff)
+		// Retries wait for the app to be healthy again instead of failing against it
+		a.unhealthyTopics.Wait(msg.Topic)
+		err = publishFunc(msg)
+	}
+
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, runtime_pubsub.ErrMessageDropped) {
+		log.Warnf("app dropped message on topic %s", msg.Topic)
+		diag.DefaultMonitoring.PubsubMessageDropped(msg.Topic)
+		return nil
+	}
+	diag.DefaultMonitoring.PubsubDeliveryRetriesExhausted(msg.Topic)
+
+	deadLetterTopic := sub.DeadLetterTopic
+	if deadLetterTopic == "" || deadLetterTopic == msg.Topic {
+		return err
+	}
+
+	log.Warnf("failed to deliver message on topic %s, forwarding to dead-letter topic %s: %s", msg.Topic, deadLetterTopic, err)
+	component, _ := a.getPubSubComponent(sub.PubSubName)
+	dlErr := component.pubSub.Publish(&pubsub.PublishRequest{
+		Topic: deadLetterTopic,
+		Data:  msg.Data,
+	})
+	if dlErr != nil {
+		return fmt.Errorf("error publishing message to dead-letter topic %s: %s", deadLetterTopic, dlErr)
+	}
+	return nil
+}
+
+func (a *DaprRuntime) publishMessageHTTP(msg *pubsub.NewMessage, sub runtime_pubsub.Subscription) error {
+	route := sub.Route
+	rawPayload := runtime_pubsub.IsRawPayload(sub.Metadata)
+	if !rawPayload && len(sub.Routes.Rules) > 0 {
+		var err error
+		route, err = sub.MatchRoute(msg.Data)
+		if err != nil {
+			return err
+		}
+		if route == "" {
+			log.Debugf("no route matched event on topic %s and no default route is set. dropping event", msg.Topic)
+			return nil
+		}
+	}
+	contentType := pubsub.ContentType
+	if rawPayload {
+		contentType = runtime_pubsub.RawPayloadContentType(msg.Data)
+	}
+
 	req := invokev1.NewInvokeMethodRequest(route)
 	req.WithHTTPExtension(nethttp.MethodPost, "")
-	req.WithRawData(msg.Data, pubsub.ContentType)
+	req.WithRawData(msg.Data, contentType)
 
 	// TODO Propagate Context
 	ctx := context.Background()
-	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	resp, err := a.appChannelFor(PubSubBuildingBlock).InvokeMethod(ctx, req)
 	if err != nil {
 		return fmt.Errorf("error from app channel while sending pub/sub event to app: %s", err)
 	}
 
-	if resp.Status().Code != nethttp.StatusOK {
-		_, errorMsg := resp.RawData()
-		return fmt.Errorf("error returned from app while processing pub/sub event: %s. status code returned: %v", errorMsg, resp.Status().Code)
+	_, body := resp.RawData()
+	code := resp.Status().Code
+	switch {
+	case code == nethttp.StatusNotFound:
+		// The app does not serve the route, redelivering the event would fail again
+		log.Warnf("app returned not found for pub/sub event on topic %s. dropping event", msg.Topic)
+		return runtime_pubsub.ErrMessageDropped
+	case code < nethttp.StatusOK || code >= nethttp.StatusMultipleChoices:
+		return fmt.Errorf("error returned from app while processing pub/sub event: %s. status code returned: %v", body, code)
 	}
 
-	return nil
+	return runtime_pubsub.AppResponseError(runtime_pubsub.AppResponseStatusFromBody(body))
 }
 
-func (a *DaprRuntime) publishMessageGRPC(msg *pubsub.NewMessage) error {
-	var cloudEvent pubsub.CloudEventsEnvelope
-	err := a.json.Unmarshal(msg.Data, &cloudEvent)
+// publishMessagesHTTPBulk delivers a batch of messages of the subscription to the app in a single request.
+// Messages the app marks as SUCCESS or DROP are acknowledged, all others are redelivered.
+func (a *DaprRuntime) publishMessagesHTTPBulk(sub runtime_pubsub.Subscription, msgs []*pubsub.NewMessage) []error {
+	topic := sub.Topic
+	errs := make([]error, len(msgs))
+	bulkMsg := runtime_pubsub.BulkSubscribeMessage{
+		Topic:   topic,
+		Entries: make([]runtime_pubsub.BulkSubscribeMessageEntry, len(msgs)),
+	}
+	for i, msg := range msgs {
+		event := msg.Data
+		if !json.Valid(event) {
+			// Raw payloads which are not JSON are delivered as JSON strings
+			event, _ = json.Marshal(string(msg.Data))
+		}
+		bulkMsg.Entries[i] = runtime_pubsub.BulkSubscribeMessageEntry{
+			EntryID: strconv.Itoa(i),
+			Event:   event,
+		}
+	}
+
+	failAll := func(err error) []error {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	body, err := a.json.Marshal(bulkMsg)
 	if err != nil {
-		log.Debugf("error deserializing cloud events proto: %s", err)
-		return err
+		return failAll(fmt.Errorf("error serializing bulk pub/sub event: %s", err))
+	}
+
+	req := invokev1.NewInvokeMethodRequest(sub.Route)
+	req.WithHTTPExtension(nethttp.MethodPost, "")
+	req.WithRawData(body, invokev1.JSONContentType)
+
+	// TODO Propagate Context
+	ctx := context.Background()
+	resp, err := a.appChannelFor(PubSubBuildingBlock).InvokeMethod(ctx, req)
+	if err != nil {
+		return failAll(fmt.Errorf("error from app channel while sending bulk pub/sub event to app: %s", err))
 	}
 
-	envelope := &daprclientv1pb.CloudEventEnvelope{
-		Id:              cloudEvent.ID,
-		Source:          cloudEvent.Source,
-		DataContentType: cloudEvent.DataContentType,
-		Type:            cloudEvent.Type,
-		SpecVersion:     cloudEvent.SpecVersion,
-		Topic:           msg.Topic,
+	_, respBody := resp.RawData()
+	if code := resp.Status().Code; code < nethttp.StatusOK || code >= nethttp.StatusMultipleChoices {
+		return failAll(fmt.Errorf("error returned from app while processing bulk pub/sub event: %s. status code returned: %v", respBody, code))
 	}
 
-	if cloudEvent.Data != nil {
-		var b []byte
-		if cloudEvent.DataContentType == "text/plain" {
-			b = []byte(cloudEvent.Data.(string))
-		} else if cloudEvent.DataContentType == "application/json" {
-			b, _ = a.json.Marshal(cloudEvent.Data)
+	var bulkResp runtime_pubsub.BulkSubscribeResponse
+	if err = a.json.Unmarshal(respBody, &bulkResp); err != nil {
+		return failAll(fmt.Errorf("error deserializing bulk pub/sub response from app: %s", err))
+	}
+
+	statuses := make(map[string]string, len(bulkResp.Statuses))
+	for _, s := range bulkResp.Statuses {
+		statuses[s.EntryID] = s.Status
+	}
+	for i, e := range bulkMsg.Entries {
+		switch strings.ToUpper(statuses[e.EntryID]) {
+		case runtime_pubsub.AppResponseStatusSuccess:
+		case runtime_pubsub.AppResponseStatusDrop:
+			log.Warnf("app dropped message %s of topic %s", e.EntryID, topic)
+			diag.DefaultMonitoring.PubsubMessageDropped(topic)
+		default:
+			errs[i] = fmt.Errorf("app requested redelivery of message %s of topic %s", e.EntryID, topic)
 		}
-		envelope.Data = &any.Any{
-			Value: b,
+	}
+	return errs
+}
+
+func (a *DaprRuntime) publishMessageGRPC(msg *pubsub.NewMessage, sub runtime_pubsub.Subscription) error {
+	if runtime_pubsub.IsRawPayload(sub.Metadata) {
+		envelope := &daprclientv1pb.CloudEventEnvelope{
+			Id:              uuid.New().String(),
+			DataContentType: runtime_pubsub.RawPayloadContentType(msg.Data),
+			Topic:           msg.Topic,
+			Data: &any.Any{
+				Value: msg.Data,
+			},
 		}
+		return a.sendTopicEventGRPC(envelope, nil)
+	}
+
+	envelope, extensions, err := runtime_pubsub.NewTopicEventEnvelope(msg.Topic, msg.Data)
+	if err != nil {
+		log.Debugf("error deserializing cloud events proto: %s", err)
+		return err
+	}
+
+	return a.sendTopicEventGRPC(envelope, extensions)
+}
+
+// sendTopicEventGRPC delivers the event to the app. CloudEvent extension attributes are sent as "ce-" prefixed headers.
+func (a *DaprRuntime) sendTopicEventGRPC(envelope *daprclientv1pb.CloudEventEnvelope, extensions map[string]string) error {
+	ctx := context.Background()
+	for k, v := range extensions {
+		ctx = grpc_metadata.AppendToOutgoingContext(ctx, runtime_pubsub.CloudEventExtensionHeaderPrefix+k, v)
 	}
 
+	var header, trailer grpc_metadata.MD
 	clientV1 := daprclientv1pb.NewDaprClientClient(a.grpc.AppClient)
-	if _, err = clientV1.OnTopicEvent(context.Background(), envelope); err != nil {
+	_, err := clientV1.OnTopicEvent(ctx, envelope, grpc_go.Header(&header), grpc_go.Trailer(&trailer))
+
+	// An explicit status returned by the app takes precedence over the error
+	status := header.Get(runtime_pubsub.AppResponseStatusHeader)
+	if len(status) == 0 {
+		status = trailer.Get(runtime_pubsub.AppResponseStatusHeader)
+	}
+	if len(status) > 0 {
+		return runtime_pubsub.AppResponseError(status[0])
+	}
+
+	if err != nil {
 		err = fmt.Errorf("error from app while processing pub/sub event: %s", err)
 		log.Debug(err)
 		return err
@@ -982,12 +2927,48 @@ func (a *DaprRuntime) publishMessageGRPC(msg *pubsub.NewMessage) error {
 	return nil
 }
 
-func (a *DaprRuntime) initActors() error {
-	actorConfig := actors.NewConfig(a.hostAddress, a.runtimeConfig.ID, a.runtimeConfig.PlacementServiceAddress, a.appConfig.Entities,
-		a.runtimeConfig.InternalGRPCPort, a.appConfig.ActorScanInterval, a.appConfig.ActorIdleTimeout, a.appConfig.DrainOngoingCallTimeout, a.appConfig.DrainRebalancedActors)
-	act := actors.NewActors(a.stateStores[a.actorStateStoreName], a.appChannel, a.grpc.GetGRPCConnection, actorConfig, a.runtimeConfig.CertChain, a.globalConfig.Spec.TracingSpec)
+// actorReentrancy returns the reentrancy config of the app, reentrancy is disabled unless the Actor.Reentrancy
+// feature is enabled
+func (a *DaprRuntime) actorReentrancy() config.ReentrancyConfig {
+	reentrancy := a.appConfig.Reentrancy
+	if reentrancy.Enabled && !a.globalConfig.IsFeatureEnabled(config.ActorReentrancy) {
+		log.Warnf("actor reentrancy is enabled by the app but the %s feature is not enabled, actor reentrancy is disabled", config.ActorReentrancy)
+		reentrancy.Enabled = false
+	}
+	return reentrancy
+}
+
+// initActors initializes the actor runtime, which also hosts the workflow instances of the app
+// in an internal actor type
+func (a *DaprRuntime) initActors(placementClient actors.PlacementClient) error {
+	engine := workflows.NewActorEngine(a.runtimeConfig.ID, a.appChannelFor(ActorsBuildingBlock))
+	hostedActorTypes := append(append([]string{}, a.appConfig.Entities...), engine.ActorType())
+	actorConfig := actors.NewConfig(actors.NewConfigOpts{
+		HostAddress:                  a.hostAddress,
+		AppID:                        a.runtimeConfig.ID,
+		PlacementAddress:             a.runtimeConfig.PlacementServiceAddress,
+		HostedActorTypes:             hostedActorTypes,
+		Port:                         a.runtimeConfig.InternalGRPCPort,
+		ActorScanInterval:            a.appConfig.ActorScanInterval,
+		ActorIdleTimeout:             a.appConfig.ActorIdleTimeout,
+		DrainOngoingCallTimeout:      a.appConfig.DrainOngoingCallTimeout,
+		DrainRebalancedActors:        a.appConfig.DrainRebalancedActors,
+		Reentrancy:                   a.actorReentrancy(),
+		RemindersStoragePartitions:   a.appConfig.RemindersStoragePartitions,
+		EntitiesConfig:               a.appConfig.EntitiesConfig,
+		LifecycleCallbacks:           a.appConfig.ActorLifecycleCallbacks,
+		MaxConcurrentReminderFirings: a.appConfig.MaxConcurrentReminderFirings,
+		ExternalAppHealth:            a.runtimeConfig.EnableAppHealthCheck,
+		Namespace:                    a.actorsNamespace(),
+	})
+	actorStateStore, _ := a.compStore.GetStateStore(a.actorStateStoreName)
+	act := actors.NewActors(actorStateStore, engine.AppChannel(), a.grpc.GetGRPCConnection, actorConfig, a.runtimeConfig.CertChain, a.globalConfig.Spec.TracingSpec, placementClient)
 	err := act.Init()
 	a.actor = act
+	if err == nil {
+		engine.SetActors(act)
+		a.workflows = engine
+	}
 	return err
 }
 
@@ -995,20 +2976,10 @@ func (a *DaprRuntime) getAuthorizedComponents(components []components_v1alpha1.C
 	authorized := []components_v1alpha1.Component{}
 
 	for _, c := range components {
-		if a.namespace == "" || (a.namespace != "" && c.ObjectMeta.Namespace == a.namespace) {
+		if a.isInNamespace(c.ObjectMeta.Namespace) {
 			// scopes are defined, make sure this runtime ID is authorized
-			if len(c.Scopes) > 0 {
-				found := false
-				for _, s := range c.Scopes {
-					if s == a.runtimeConfig.ID {
-						found = true
-						break
-					}
-				}
-
-				if !found {
-					continue
-				}
+			if !a.isAppInScopes(c.Scopes) {
+				continue
 			}
 			authorized = append(authorized, c)
 		}
@@ -1016,6 +2987,28 @@ func (a *DaprRuntime) getAuthorizedComponents(components []components_v1alpha1.C
 	return authorized
 }
 
+// actorsNamespace returns the namespace the actor types of the app are scoped to in the placement tables, actor
+// types aren't scoped unless namespaces are isolated
+func (a *DaprRuntime) actorsNamespace() string {
+	if !a.isNamespaceIsolated() {
+		return ""
+	}
+	return a.namespace
+}
+
+// isNamespaceIsolated returns whether the app is isolated from the apps of other namespaces sharing the same
+// infrastructure
+func (a *DaprRuntime) isNamespaceIsolated() bool {
+	return a.globalConfig.Spec.AccessControlSpec.NamespaceIsolation && a.namespace != ""
+}
+
+// isInNamespace returns whether the resources of a namespace are loaded by the runtime. The runtimes reading
+// the NAMESPACE environment variable only load the resources of their namespace and the resources without a
+// namespace, so self-hosted runtimes of several namespaces can share a components folder.
+func (a *DaprRuntime) isInNamespace(namespace string) bool {
+	return a.namespace == "" || namespace == "" || namespace == a.namespace
+}
+
 func (a *DaprRuntime) loadComponents(opts *runtimeOpts) error {
 	var loader components.ComponentLoader
 
@@ -1057,25 +3050,52 @@ func (a *DaprRuntime) loadComponents(opts *runtimeOpts) error {
 	return nil
 }
 
-// Stop allows for a graceful shutdown of all runtime internal operations or components
-func (a *DaprRuntime) Stop() {
-	log.Info("stop command issued. Shutting down all operations")
+// lookupComponentEnv returns the value of an environment variable referenced by a component. Only the variables
+// prefixed with DAPR_COMPONENT_ENV_ can be referenced, so the components don't read the other variables of the
+// runtime. The components delivered by the operator in Kubernetes mode can't reference any variable, as they are
+// written by other users than the ones deploying the runtime.
+func (a *DaprRuntime) lookupComponentEnv(name string) (string, error) {
+	if a.runtimeConfig.Mode == modes.KubernetesMode {
+		return "", fmt.Errorf("environment variable %s can't be referenced in %s mode", name, modes.KubernetesMode)
+	}
+	if !strings.HasPrefix(name, componentEnvVarPrefix) {
+		return "", fmt.Errorf("environment variable %s can't be referenced, only the variables prefixed with %s can", name, componentEnvVarPrefix)
+	}
+	val, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return val, nil
 }
 
+// processComponentSecrets resolves the metadata items of the component which reference an environment variable
+// or a secret. A secret is read from the secret store named by its reference, or else from the secret store of
+// the component. The items whose reference can't be resolved keep their value.
 func (a *DaprRuntime) processComponentSecrets(component components_v1alpha1.Component) components_v1alpha1.Component {
 	cache := map[string]secretstores.GetSecretResponse{}
 
 	for i, m := range component.Spec.Metadata {
+		if m.EnvRef != "" {
+			val, err := a.lookupComponentEnv(m.EnvRef)
+			if err != nil {
+				log.Warnf("failed to resolve metadata %s of component %s: %s", m.Name, component.ObjectMeta.Name, err)
+				continue
+			}
+			component.Spec.Metadata[i].Value = val
+			continue
+		}
 		if m.SecretKeyRef.Name == "" {
 			continue
 		}
 
-		secretStore := a.getSecretStore(component.Auth.SecretStore)
+		storeName := secretRefStoreName(component, m.SecretKeyRef)
+		secretStore := a.getSecretStore(storeName)
 		if secretStore == nil {
 			continue
 		}
 
-		resp, ok := cache[m.SecretKeyRef.Name]
+		cacheKey := storeName + "/" + m.SecretKeyRef.Name
+		resp, ok := cache[cacheKey]
 		if !ok {
 			r, err := secretStore.GetSecret(secretstores.GetSecretRequest{
 				Name: m.SecretKeyRef.Name,
@@ -1101,11 +3121,19 @@ func (a *DaprRuntime) processComponentSecrets(component components_v1alpha1.Comp
 			component.Spec.Metadata[i].Value = val
 		}
 
-		cache[m.SecretKeyRef.Name] = resp
+		cache[cacheKey] = resp
 	}
 	return component
 }
 
+// secretRefStoreName returns the name of the secret store a secret reference of the component is read from
+func secretRefStoreName(component components_v1alpha1.Component, ref components_v1alpha1.SecretKeyRef) string {
+	if ref.SecretStore != "" {
+		return ref.SecretStore
+	}
+	return component.Auth.SecretStore
+}
+
 func (a *DaprRuntime) getSecretStore(storeName string) secretstores.SecretStore {
 	if storeName == "" {
 		switch a.runtimeConfig.Mode {
@@ -1115,27 +3143,88 @@ func (a *DaprRuntime) getSecretStore(storeName string) secretstores.SecretStore
 			return nil
 		}
 	}
-	return a.secretStores[storeName]
+	store, _ := a.compStore.GetSecretStore(storeName)
+	return store
 }
 
-func (a *DaprRuntime) blockUntilAppIsReady() {
-	if a.runtimeConfig.ApplicationPort <= 0 {
-		return
+// blockUntilAppIsReady waits for the app to listen on its ports, or to respond on its ready path when it has one,
+// so the subscriptions of the app are read and traffic is served once the app is ready. It returns an error if
+// the app isn't ready within the ready timeout.
+func (a *DaprRuntime) blockUntilAppIsReady() error {
+	ports := map[Protocol]int{}
+	for _, protocol := range []Protocol{HTTPProtocol, GRPCProtocol} {
+		if port := a.runtimeConfig.AppPort(protocol); port > 0 {
+			ports[protocol] = port
+		}
+	}
+	socket := a.runtimeConfig.AppUnixDomainSocket
+	if socket != "" {
+		// the app protocol is served on the socket
+		delete(ports, a.runtimeConfig.ApplicationProtocol)
+	}
+	if len(ports) == 0 && socket == "" {
+		return nil
+	}
+
+	timeout := a.runtimeConfig.AppReadyTimeout
+	start := time.Now()
+	readyPath := a.runtimeConfig.AppReadyPath
+	if readyPath != "" && ports[HTTPProtocol] == 0 {
+		if socket != "" && a.runtimeConfig.ApplicationProtocol == HTTPProtocol {
+			log.Warnf("app ready path is not supported over a unix domain socket, waiting on the socket of the app instead")
+		} else {
+			log.Warnf("app ready path is only supported for http apps, waiting on the ports of the app instead")
+		}
+		readyPath = ""
 	}
 
-	log.Infof("application protocol: %s. waiting on port %v.  This will block until the app is listening on that port.", string(a.runtimeConfig.ApplicationProtocol), a.runtimeConfig.ApplicationPort)
+	if readyPath != "" {
+		scheme := "http"
+		if a.appTLSConfig != nil {
+			scheme = "https"
+		}
+		address := fmt.Sprintf("%s://localhost:%v/%s", scheme, ports[HTTPProtocol], strings.TrimPrefix(readyPath, "/"))
+		log.Infof("application protocol: %s. waiting for %s to respond. This will block until the app is ready.", string(a.runtimeConfig.ApplicationProtocol), address)
+		if err := health.WaitForEndpoint(address, timeout, health.WithInterval(appReadyProbeInterval), health.WithTLSConfig(a.appTLSConfig)); err != nil {
+			return fmt.Errorf("app is not ready: %s", err)
+		}
+		log.Infof("application ready on %s", address)
+		delete(ports, HTTPProtocol)
+	}
 
-	for {
-		conn, _ := net.DialTimeout("tcp", net.JoinHostPort("localhost", fmt.Sprintf("%v", a.runtimeConfig.ApplicationPort)), time.Millisecond*500)
-		if conn != nil {
-			conn.Close()
-			break
+	if socket != "" {
+		log.Infof("application protocol: %s. waiting on socket %s. This will block until the app is listening on that socket.", string(a.runtimeConfig.ApplicationProtocol), socket)
+		for {
+			conn, _ := net.DialTimeout("unix", socket, time.Millisecond*500)
+			if conn != nil {
+				conn.Close()
+				break
+			}
+			if timeout > 0 && time.Since(start) >= timeout {
+				return fmt.Errorf("app is not listening on socket %s after %s", socket, timeout)
+			}
+			time.Sleep(time.Millisecond * 50)
 		}
-		// prevents overwhelming the OS with open connections
-		time.Sleep(time.Millisecond * 50)
+		log.Infof("application discovered on socket %s", socket)
 	}
 
-	log.Infof("application discovered on port %v", a.runtimeConfig.ApplicationPort)
+	for protocol, port := range ports {
+		log.Infof("application protocol: %s. waiting on port %v.  This will block until the app is listening on that port.", string(protocol), port)
+		for {
+			conn, _ := net.DialTimeout("tcp", net.JoinHostPort("localhost", fmt.Sprintf("%v", port)), time.Millisecond*500)
+			if conn != nil {
+				conn.Close()
+				break
+			}
+			if timeout > 0 && time.Since(start) >= timeout {
+				return fmt.Errorf("app is not listening on port %v after %s", port, timeout)
+			}
+			// prevents overwhelming the OS with open connections
+			time.Sleep(time.Millisecond * 50)
+		}
+		log.Infof("application discovered on port %v", port)
+	}
+	return nil
 }
 
 func (a *DaprRuntime) loadAppConfiguration() {
@@ -1201,29 +3290,81 @@ func (a *DaprRuntime) getConfigurationGRPC() (*config.ApplicationConfig, error)
 	return nil, nil
 }
 
+// appHeaderLimits returns the limits of the headers forwarded to an HTTP app
+func (a *DaprRuntime) appHeaderLimits() invokev1.AppHeaderLimits {
+	return invokev1.NewAppHeaderLimits(a.globalConfig.Spec.MetadataSpec.MaxAppHeaderCount, a.globalConfig.Spec.MetadataSpec.MaxAppHeaderSize)
+}
+
+// createAppChannel opens the app channel of the app protocol over the app port or the Unix domain socket of the app,
+// and the app channel of the other protocol when the app listens on both protocols
 func (a *DaprRuntime) createAppChannel() error {
-	if a.runtimeConfig.ApplicationPort > 0 {
-		var channelCreatorFn func(port, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error)
+	protocol := a.runtimeConfig.ApplicationProtocol
+	if a.runtimeConfig.ApplicationPort > 0 && protocol != HTTPProtocol && protocol != GRPCProtocol {
+		return fmt.Errorf("cannot create app channel for protocol %s", string(protocol))
+	}
 
-		switch a.runtimeConfig.ApplicationProtocol {
+	if socket := a.runtimeConfig.AppUnixDomainSocket; socket != "" {
+		var channelCreatorFn func(socket string, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error)
+
+		switch protocol {
 		case GRPCProtocol:
-			channelCreatorFn = a.grpc.CreateLocalChannel
+			channelCreatorFn = a.grpc.CreateUnixChannel
 		case HTTPProtocol:
-			channelCreatorFn = http_channel.CreateLocalChannel
+			channelCreatorFn = func(socket string, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error) {
+				return http_channel.CreateUnixChannel(socket, maxConcurrency, a.appHeaderLimits(), spec)
+			}
 		default:
-			return fmt.Errorf("cannot create app channel for protocol %s", string(a.runtimeConfig.ApplicationProtocol))
+			return fmt.Errorf("cannot create app channel for protocol %s", string(protocol))
 		}
 
-		ch, err := channelCreatorFn(a.runtimeConfig.ApplicationPort, a.runtimeConfig.MaxConcurrency, a.globalConfig.Spec.TracingSpec)
+		ch, err := channelCreatorFn(socket, a.runtimeConfig.MaxConcurrency, a.globalConfig.Spec.TracingSpec)
 		if err != nil {
 			return err
 		}
-		if a.runtimeConfig.MaxConcurrency > 0 {
-			log.Infof("app max concurrency set to %v", a.runtimeConfig.MaxConcurrency)
+		a.appChannels[protocol] = ch
+	}
+
+	for _, protocol := range []Protocol{HTTPProtocol, GRPCProtocol} {
+		port := a.runtimeConfig.AppPort(protocol)
+		if port <= 0 || a.appChannels[protocol] != nil {
+			continue
+		}
+		var channelCreatorFn func(port, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error)
+
+		switch protocol {
+		case GRPCProtocol:
+			channelCreatorFn = a.grpc.CreateLocalChannel
+		case HTTPProtocol:
+			channelCreatorFn = func(port, maxConcurrency int, spec config.TracingSpec) (channel.AppChannel, error) {
+				return http_channel.CreateLocalChannel(port, maxConcurrency, a.appHeaderLimits(), a.appTLSConfig, spec)
+			}
+		}
+
+		ch, err := channelCreatorFn(port, a.runtimeConfig.MaxConcurrency, a.globalConfig.Spec.TracingSpec)
+		if err != nil {
+			return err
 		}
-		a.appChannel = ch
+		a.appChannels[protocol] = ch
+	}
+	if len(a.appChannels) == 0 {
+		return nil
 	}
 
+	ch, ok := a.appChannels[a.runtimeConfig.ApplicationProtocol]
+	if !ok {
+		return fmt.Errorf("cannot create app channel for protocol %s", string(a.runtimeConfig.ApplicationProtocol))
+	}
+	a.appChannel = ch
+	if a.runtimeConfig.MaxConcurrency > 0 {
+		log.Infof("app max concurrency set to %v", a.runtimeConfig.MaxConcurrency)
+	}
+	for block, protocol := range a.runtimeConfig.AppChannelProtocols {
+		if a.appChannels[protocol] == nil {
+			log.Warnf("app has no %s port, %s uses the %s channel", protocol, block, a.runtimeConfig.ApplicationProtocol)
+		} else {
+			log.Infof("%s uses the %s channel of the app", block, protocol)
+		}
+	}
 	return nil
 }
 
@@ -1252,39 +3393,116 @@ func (a *DaprRuntime) initSecretStores() error {
 			return err
 		}
 
-		a.secretStores["kubernetes"] = kubeSecretStore
+		a.compStore.AddSecretStore("kubernetes", kubeSecretStore)
 	}
 
-	// Initialize all secretstore components
+	pending := []components_v1alpha1.Component{}
 	for _, c := range a.components {
-		if !strings.Contains(c.Spec.Type, "secretstores") {
-			continue
+		if strings.Contains(c.Spec.Type, "secretstores") {
+			pending = append(pending, c)
 		}
+	}
 
-		// Look up the secrets to authenticate this secretstore from K8S secret store
-		a.processComponentSecrets(c)
+	// A secret store may reference the secrets of secret stores declared before or after it, so the secret stores
+	// are initialized in passes. Each pass initializes, in the order they are declared, the stores which don't
+	// reference a store left to initialize, until all are initialized or a pass initializes none of them, which
+	// happens when stores reference each other.
+	for len(pending) > 0 {
+		declared := map[string]bool{}
+		for _, c := range pending {
+			declared[c.ObjectMeta.Name] = true
+		}
 
-		secretStore, err := a.secretStoresRegistry.Create(c.Spec.Type)
-		if err != nil {
-			log.Warnf("failed creating state store %s: %s", c.Spec.Type, err)
-			diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "creation")
-			continue
+		remaining := []components_v1alpha1.Component{}
+		for _, c := range pending {
+			if a.referencesPendingSecretStore(c, declared) {
+				remaining = append(remaining, c)
+				continue
+			}
+			a.initSecretStore(c)
 		}
 
-		err = secretStore.Init(secretstores.Metadata{
-			Properties: a.convertMetadataItemsToProperties(c.Spec.Metadata),
-		})
-		if err != nil {
-			log.Warnf("failed to init state store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
-			diag.DefaultMonitoring.ComponentInitFailed(c.Spec.Type, "init")
+		if len(remaining) == len(pending) {
+			for _, c := range remaining {
+				err := errors.New("it references the secrets of secret stores which reference its own secrets")
+				log.Warnf("failed to init secret store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
+				a.componentInitFailed(c, "init", err)
+			}
+			break
+		}
+		pending = remaining
+	}
+
+	return nil
+}
+
+// referencesPendingSecretStore returns whether the metadata of the secret store component references the secrets
+// of a declared secret store which isn't initialized yet
+func (a *DaprRuntime) referencesPendingSecretStore(c components_v1alpha1.Component, declared map[string]bool) bool {
+	for _, m := range c.Spec.Metadata {
+		if m.SecretKeyRef.Name == "" || m.EnvRef != "" {
 			continue
 		}
+		name := secretRefStoreName(c, m.SecretKeyRef)
+		if _, ok := a.compStore.GetSecretStore(name); declared[name] && !ok {
+			return true
+		}
+	}
+	return false
+}
 
-		a.secretStores[c.ObjectMeta.Name] = secretStore
-		diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
+func (a *DaprRuntime) initSecretStore(c components_v1alpha1.Component) {
+	// Look up the secrets to authenticate this secretstore from the secret stores it references
+	c = a.processComponentSecrets(c)
+
+	secretStore, err := a.secretStoresRegistry.Create(c.Spec.Type)
+	if err != nil {
+		log.Warnf("failed creating state store %s: %s", c.Spec.Type, err)
+		a.componentInitFailed(c, "creation", err)
+		return
 	}
 
-	return nil
+	props := a.convertMetadataItemsToProperties(c.Spec.Metadata)
+	cacheTTL, err := secrets_cache.GetTTL(props)
+	if err != nil {
+		log.Warnf("error loading the cache configuration of secret store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
+		a.componentInitFailed(c, "init", err)
+		return
+	}
+	watchConfig, err := secrets_watch.GetConfig(props)
+	if err != nil {
+		log.Warnf("error loading the watch configuration of secret store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
+		a.componentInitFailed(c, "init", err)
+		return
+	}
+
+	instance, err := a.initComponentInstance(c, secretStore, func() (interface{}, error) {
+		return a.secretStoresRegistry.Create(c.Spec.Type)
+	}, func(instance interface{}) error {
+		return instance.(secretstores.SecretStore).Init(secretstores.Metadata{
+			Properties: props,
+		})
+	})
+	if err != nil {
+		log.Warnf("failed to init state store %s named %s: %s", c.Spec.Type, c.ObjectMeta.Name, err)
+		a.componentInitFailed(c, "init", err)
+		return
+	}
+	secretStore = instance.(secretstores.SecretStore)
+
+	if watchConfig != nil {
+		// the watcher reads the secrets from the store itself, as the cached secrets would hide their changes
+		a.secretWatchers[c.ObjectMeta.Name] = secrets_watch.NewWatcher(c.ObjectMeta.Name, secretStore, *watchConfig, a.notifySecretChange)
+	}
+	capabilities := []string{}
+	if _, ok := secretStore.(bulk.Getter); ok {
+		capabilities = append(capabilities, components.CapabilityBulkGet)
+	}
+	if cacheTTL > 0 {
+		secretStore = secrets_cache.NewStore(secretStore, cacheTTL)
+	}
+	a.compStore.AddSecretStore(c.ObjectMeta.Name, secretStore)
+	a.componentInitialized(c, capabilities...)
 }
 
 func (a *DaprRuntime) convertMetadataItemsToProperties(items []components_v1alpha1.MetadataItem) map[string]string {
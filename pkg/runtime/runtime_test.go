@@ -6,27 +6,47 @@
 package runtime
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/components-contrib/state"
 	components_v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
 	channelt "github.com/dapr/dapr/pkg/channel/testing"
+	"github.com/dapr/dapr/pkg/components"
+	bindings_loader "github.com/dapr/dapr/pkg/components/bindings"
+	configuration_loader "github.com/dapr/dapr/pkg/components/configuration"
+	crypto_loader "github.com/dapr/dapr/pkg/components/crypto"
 	pubsub_loader "github.com/dapr/dapr/pkg/components/pubsub"
 	secretstores_loader "github.com/dapr/dapr/pkg/components/secretstores"
 	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/configuration"
+	"github.com/dapr/dapr/pkg/crypto"
+	"github.com/dapr/dapr/pkg/jobs"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	"github.com/dapr/dapr/pkg/modes"
 	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
 	"github.com/dapr/dapr/pkg/runtime/security"
 	"github.com/dapr/dapr/pkg/scopes"
+	secrets_cache "github.com/dapr/dapr/pkg/secretstores/cache"
 	"github.com/dapr/dapr/pkg/sentry/certs"
+	"github.com/dapr/dapr/pkg/state/bulk"
+	"github.com/dapr/dapr/pkg/state/keyprefix"
+	"github.com/dapr/dapr/pkg/state/partitions"
 	daprt "github.com/dapr/dapr/pkg/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -70,6 +90,50 @@ func NewMockKubernetesStore() secretstores.SecretStore {
 	return &MockKubernetesStateStore{}
 }
 
+type fakeStateStore struct {
+	items map[string][]byte
+}
+
+func (f *fakeStateStore) Init(metadata state.Metadata) error {
+	return nil
+}
+
+func (f *fakeStateStore) Delete(req *state.DeleteRequest) error {
+	delete(f.items, req.Key)
+	return nil
+}
+
+func (f *fakeStateStore) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeStateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return &state.GetResponse{Data: f.items[req.Key]}, nil
+}
+
+func (f *fakeStateStore) Set(req *state.SetRequest) error {
+	b, _ := json.Marshal(req.Value)
+	f.items[req.Key] = b
+	return nil
+}
+
+func (f *fakeStateStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		f.Set(&req[i])
+	}
+	return nil
+}
+
+type fakeTransactionalStateStore struct {
+	fakeStateStore
+	operations []state.TransactionalRequest
+}
+
+func (f *fakeTransactionalStateStore) Multi(reqs []state.TransactionalRequest) error {
+	f.operations = reqs
+	return nil
+}
+
 func TestNewRuntime(t *testing.T) {
 	// act
 	r := NewDaprRuntime(&Config{}, &config.Configuration{})
@@ -463,7 +527,7 @@ func TestInitSecretStores(t *testing.T) {
 		})
 
 		rt.initSecretStores()
-		assert.NotNil(t, rt.secretStores["kubernetesMock"])
+		assert.NotNil(t, rt.compStore.ListSecretStores()["kubernetesMock"])
 	})
 
 	t.Run("get secret store", func(t *testing.T) {
@@ -488,6 +552,48 @@ func TestInitSecretStores(t *testing.T) {
 		s := rt.getSecretStore("kubernetesMock")
 		assert.NotNil(t, s)
 	})
+
+	t.Run("secret store with a cache and watched secrets", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		m := NewMockKubernetesStore()
+		rt.secretStoresRegistry.Register(
+			secretstores_loader.New("kubernetesMock", func() secretstores.SecretStore {
+				return m
+			}),
+		)
+
+		rt.components = append(rt.components, components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: "kubernetesMock",
+			},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type: "secretstores.kubernetesMock",
+				Metadata: []components_v1alpha1.MetadataItem{
+					{Name: "cacheTTL", Value: "1m"},
+					{Name: "watchSecrets", Value: "db"},
+				},
+			},
+		})
+
+		rt.initSecretStores()
+		assert.Implements(t, (*secrets_cache.Flusher)(nil), rt.compStore.ListSecretStores()["kubernetesMock"])
+		assert.Len(t, rt.secretWatchers, 1)
+	})
+}
+
+func TestNotifySecretChange(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	okResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	failedResp := invokev1.NewInvokeMethodResponse(500, "Internal Error", nil)
+	mockAppChannel := new(channelt.MockAppChannel)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.MatchedBy(func(req *invokev1.InvokeMethodRequest) bool {
+		return req.Message().Method == "secrets/store1/db"
+	})).Return(okResp, nil)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(failedResp, nil)
+	rt.appChannel = mockAppChannel
+
+	assert.NoError(t, rt.notifySecretChange("store1", "db"))
+	assert.Error(t, rt.notifySecretChange("store1", "queue"))
 }
 
 func TestMetadataItemsToPropertiesConversion(t *testing.T) {
@@ -503,6 +609,83 @@ func TestMetadataItemsToPropertiesConversion(t *testing.T) {
 	assert.Equal(t, "b", m["a"])
 }
 
+// fakeSecretStore returns its secrets once initialized and records the metadata it is initialized with
+type fakeSecretStore struct {
+	secrets    map[string]map[string]string
+	properties map[string]string
+}
+
+func (f *fakeSecretStore) Init(metadata secretstores.Metadata) error {
+	f.properties = metadata.Properties
+	return nil
+}
+
+func (f *fakeSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	if f.properties == nil {
+		return secretstores.GetSecretResponse{}, errors.New("secret store not initialized")
+	}
+	return secretstores.GetSecretResponse{Data: f.secrets[req.Name]}, nil
+}
+
+func secretStoreComponent(name string, metadata ...components_v1alpha1.MetadataItem) components_v1alpha1.Component {
+	return components_v1alpha1.Component{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: components_v1alpha1.ComponentSpec{
+			Type:     "secretstores." + name,
+			Metadata: metadata,
+		},
+	}
+}
+
+func TestInitChainedSecretStores(t *testing.T) {
+	t.Run("a secret store references the secrets of a store declared after it", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		vault := &fakeSecretStore{}
+		local := &fakeSecretStore{secrets: map[string]map[string]string{"vault": {"token": "s3cr3t"}}}
+		rt.secretStoresRegistry.Register(
+			secretstores_loader.New("vault", func() secretstores.SecretStore { return vault }),
+			secretstores_loader.New("local", func() secretstores.SecretStore { return local }),
+		)
+		rt.components = append(rt.components,
+			secretStoreComponent("vault", components_v1alpha1.MetadataItem{
+				Name:         "token",
+				SecretKeyRef: components_v1alpha1.SecretKeyRef{Name: "vault", Key: "token", SecretStore: "local"},
+			}),
+			secretStoreComponent("local"),
+		)
+
+		assert.NoError(t, rt.initSecretStores())
+		assert.Len(t, rt.compStore.ListSecretStores(), 2)
+		assert.Equal(t, "s3cr3t", vault.properties["token"])
+	})
+
+	t.Run("secret stores referencing each other are not initialized", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.secretStoresRegistry.Register(
+			secretstores_loader.New("store1", func() secretstores.SecretStore { return &fakeSecretStore{} }),
+			secretstores_loader.New("store2", func() secretstores.SecretStore { return &fakeSecretStore{} }),
+			secretstores_loader.New("store3", func() secretstores.SecretStore { return &fakeSecretStore{} }),
+		)
+		rt.components = append(rt.components,
+			secretStoreComponent("store1", components_v1alpha1.MetadataItem{
+				Name:         "token",
+				SecretKeyRef: components_v1alpha1.SecretKeyRef{Name: "token", SecretStore: "store2"},
+			}),
+			secretStoreComponent("store2", components_v1alpha1.MetadataItem{
+				Name:         "token",
+				SecretKeyRef: components_v1alpha1.SecretKeyRef{Name: "token", SecretStore: "store1"},
+			}),
+			secretStoreComponent("store3"),
+		)
+
+		assert.NoError(t, rt.initSecretStores())
+		assert.Len(t, rt.compStore.ListSecretStores(), 1)
+		assert.NotNil(t, rt.compStore.ListSecretStores()["store3"])
+	})
+}
+
 func TestProcessComponentSecrets(t *testing.T) {
 	mockBinding := components_v1alpha1.Component{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -604,6 +787,57 @@ func TestProcessComponentSecrets(t *testing.T) {
 		mod := rt.processComponentSecrets(mockBinding)
 		assert.Equal(t, "value1", mod.Spec.Metadata[0].Value)
 	})
+
+	t.Run("secret store named by the reference", func(t *testing.T) {
+		mockBinding.Spec.Metadata[0].Value = ""
+		mockBinding.Spec.Metadata[0].SecretKeyRef = components_v1alpha1.SecretKeyRef{
+			Key:         "password",
+			Name:        "db",
+			SecretStore: "local",
+		}
+
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.compStore.AddSecretStore("local", &fakeSecretStore{
+			secrets:    map[string]map[string]string{"db": {"password": "s3cr3t"}},
+			properties: map[string]string{},
+		})
+
+		mod := rt.processComponentSecrets(mockBinding)
+		assert.Equal(t, "s3cr3t", mod.Spec.Metadata[0].Value)
+	})
+
+	os.Setenv("DAPR_COMPONENT_ENV_TEST_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("DAPR_COMPONENT_ENV_TEST_PASSWORD")
+	os.Setenv("DAPR_TEST_BINDING_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("DAPR_TEST_BINDING_PASSWORD")
+	envComponent := func() components_v1alpha1.Component {
+		return components_v1alpha1.Component{
+			Spec: components_v1alpha1.ComponentSpec{
+				Type: "bindings.mock",
+				Metadata: []components_v1alpha1.MetadataItem{
+					{Name: "password", EnvRef: "DAPR_COMPONENT_ENV_TEST_PASSWORD"},
+					{Name: "user", Value: "admin", EnvRef: "DAPR_COMPONENT_ENV_TEST_UNSET"},
+					{Name: "token", Value: "none", EnvRef: "DAPR_TEST_BINDING_PASSWORD"},
+				},
+			},
+		}
+	}
+
+	t.Run("environment variable", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mod := rt.processComponentSecrets(envComponent())
+		assert.Equal(t, "s3cr3t", mod.Spec.Metadata[0].Value)
+		assert.Equal(t, "admin", mod.Spec.Metadata[1].Value)
+		// only the variables with the prefix can be referenced
+		assert.Equal(t, "none", mod.Spec.Metadata[2].Value)
+	})
+
+	t.Run("environment variable in kubernetes mode", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.KubernetesMode)
+		mod := rt.processComponentSecrets(envComponent())
+		assert.Equal(t, "", mod.Spec.Metadata[0].Value)
+		assert.Equal(t, "admin", mod.Spec.Metadata[1].Value)
+	})
 }
 
 // Test InitSecretStore if secretstore.* refers to Kubernetes secret store
@@ -671,7 +905,7 @@ func TestOnNewPublishedMessage(t *testing.T) {
 		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
 
 		// act
-		err := rt.publishMessageHTTP(testPubSubMessage)
+		err := rt.publishMessageHTTP(testPubSubMessage, runtime_pubsub.Subscription{Topic: "topic1", Route: "topic1"})
 
 		// assert
 		assert.Nil(t, err)
@@ -689,7 +923,7 @@ func TestOnNewPublishedMessage(t *testing.T) {
 		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
 
 		// act
-		err := rt.publishMessageHTTP(testPubSubMessage)
+		err := rt.publishMessageHTTP(testPubSubMessage, runtime_pubsub.Subscription{Topic: "topic1", Route: "topic1"})
 
 		// assert
 		expectedClientError := fmt.Errorf("error returned from app while processing pub/sub event: Internal Error. status code returned: 500")
@@ -698,166 +932,672 @@ func TestOnNewPublishedMessage(t *testing.T) {
 	})
 }
 
-func getFakeProperties() map[string]string {
-	return map[string]string{
-		"host":                    "localhost",
-		"password":                "fakePassword",
-		"consumerID":              TestRuntimeConfigID,
-		scopes.SubscriptionScopes: fmt.Sprintf("%s=topic0,topic1", TestRuntimeConfigID),
-		scopes.PublishingScopes:   fmt.Sprintf("%s=topic0,topic1", TestRuntimeConfigID),
+func TestDeliverMessageDeadLetter(t *testing.T) {
+	testPubSubMessage := &pubsub.NewMessage{
+		Topic: "topic1",
+		Data:  []byte("Test Message"),
 	}
-}
-
-func getFakeMetadataItems() []components_v1alpha1.MetadataItem {
-	return []components_v1alpha1.MetadataItem{
-		{
-			Name:  "host",
-			Value: "localhost",
-		},
-		{
-			Name:  "password",
-			Value: "fakePassword",
-		},
-		{
-			Name:  "consumerID",
-			Value: TestRuntimeConfigID,
-		},
-		{
-			Name:  scopes.SubscriptionScopes,
-			Value: fmt.Sprintf("%s=topic0,topic1", TestRuntimeConfigID),
-		},
-		{
-			Name:  scopes.PublishingScopes,
-			Value: fmt.Sprintf("%s=topic0,topic1", TestRuntimeConfigID),
-		},
+	failingPublish := func(msg *pubsub.NewMessage) error {
+		return errors.New("app error")
 	}
-}
 
-func NewTestDaprRuntime(mode modes.DaprMode) *DaprRuntime {
-	testRuntimeConfig := NewRuntimeConfig(
-		TestRuntimeConfigID,
-		"10.10.10.12",
-		"10.10.10.11",
-		DefaultAllowedOrigins,
-		"globalConfig",
-		DefaultComponentsPath,
-		string(HTTPProtocol),
-		string(mode),
-		DefaultDaprHTTPPort,
-		0,
-		DefaultDaprAPIGRPCPort,
-		1024,
-		DefaultProfilePort,
-		false,
-		-1,
-		false,
-		"")
+	t.Run("forwards to dead-letter topic on failure", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		sub := runtime_pubsub.Subscription{Topic: "topic1", DeadLetterTopic: "poison"}
+		mockPubSub := new(daprt.MockPubSub)
+		mockPubSub.On("Publish", &pubsub.PublishRequest{Topic: "poison", Data: testPubSubMessage.Data}).Return(nil)
+		rt.pubSub = mockPubSub
 
-	rt := NewDaprRuntime(testRuntimeConfig, &config.Configuration{})
-	rt.topicRoutes["topic1"] = "topic1"
+		err := rt.deliverMessage(testPubSubMessage, sub, failingPublish)
+		assert.NoError(t, err)
+		mockPubSub.AssertNumberOfCalls(t, "Publish", 1)
+	})
 
-	rt.components = []components_v1alpha1.Component{
-		{
-			ObjectMeta: meta_v1.ObjectMeta{
-				Name: "Components",
-			},
-			Spec: components_v1alpha1.ComponentSpec{
-				Type:     "pubsub.mockPubSub",
-				Metadata: getFakeMetadataItems(),
-			},
-		},
-	}
+	t.Run("returns error without dead-letter topic", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockPubSub := new(daprt.MockPubSub)
+		rt.pubSub = mockPubSub
 
-	return rt
-}
+		err := rt.deliverMessage(testPubSubMessage, runtime_pubsub.Subscription{Topic: "topic1"}, failingPublish)
+		assert.Error(t, err)
+		mockPubSub.AssertNumberOfCalls(t, "Publish", 0)
+	})
 
-func TestMTLS(t *testing.T) {
-	t.Run("with mTLS enabled", func(t *testing.T) {
+	t.Run("expired message is dropped", func(t *testing.T) {
 		rt := NewTestDaprRuntime(modes.StandaloneMode)
-		rt.runtimeConfig.mtlsEnabled = true
-		rt.runtimeConfig.SentryServiceAddress = "1.1.1.1"
+		expired := &pubsub.NewMessage{
+			Topic: "topic1",
+			Data:  []byte(`{"id": "1", "expiration": "2000-01-01T00:00:00Z"}`),
+		}
 
-		os.Setenv(certs.TrustAnchorsEnvVar, testCertRoot)
-		os.Setenv(certs.CertChainEnvVar, "a")
-		os.Setenv(certs.CertKeyEnvVar, "b")
-		defer os.Clearenv()
+		err := rt.deliverMessage(expired, runtime_pubsub.Subscription{Topic: "topic1"}, failingPublish)
+		assert.NoError(t, err)
+	})
 
-		certChain, err := security.GetCertChain()
-		assert.Nil(t, err)
-		rt.runtimeConfig.CertChain = certChain
+	t.Run("retries message when app requests retry", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		calls := 0
+		retryOnce := func(msg *pubsub.NewMessage) error {
+			calls++
+			if calls == 1 {
+				return runtime_pubsub.ErrMessageRetry
+			}
+			return nil
+		}
 
-		err = rt.establishSecurity(rt.runtimeConfig.SentryServiceAddress)
-		assert.Nil(t, err)
-		assert.NotNil(t, rt.authenticator)
+		err := rt.deliverMessage(testPubSubMessage, runtime_pubsub.Subscription{Topic: "topic1"}, retryOnce)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
 	})
 
-	t.Run("with mTLS disabled", func(t *testing.T) {
+	t.Run("uses retry policy of the subscription", func(t *testing.T) {
 		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		sub := runtime_pubsub.Subscription{
+			Topic:       "topic1",
+			RetryPolicy: &runtime_pubsub.RetryPolicy{MaxRetries: 3, InitialIntervalMs: 1},
+		}
+		calls := 0
+		failing := func(msg *pubsub.NewMessage) error {
+			calls++
+			return errors.New("app error")
+		}
 
-		err := rt.establishSecurity(rt.runtimeConfig.SentryServiceAddress)
-		assert.Nil(t, err)
-		assert.Nil(t, rt.authenticator)
+		err := rt.deliverMessage(testPubSubMessage, sub, failing)
+		assert.Error(t, err)
+		assert.Equal(t, 4, calls)
 	})
-}
 
-type mockBinding struct {
-	hasError bool
-	data     string
-}
+	t.Run("message dropped by app is acknowledged", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		sub := runtime_pubsub.Subscription{Topic: "topic1", DeadLetterTopic: "poison"}
+		mockPubSub := new(daprt.MockPubSub)
+		rt.pubSub = mockPubSub
+		drop := func(msg *pubsub.NewMessage) error {
+			return runtime_pubsub.ErrMessageDropped
+		}
 
-func (b *mockBinding) Init(metadata bindings.Metadata) error {
-	return nil
-}
+		err := rt.deliverMessage(testPubSubMessage, sub, drop)
+		assert.NoError(t, err)
+		mockPubSub.AssertNumberOfCalls(t, "Publish", 0)
+	})
 
-func (b *mockBinding) Read(handler func(*bindings.ReadResponse) error) error {
-	b.data = "test"
+	t.Run("already processed message is not delivered again", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.pubSubs["pubsub1"] = pubSubComponent{pubSub: new(daprt.MockPubSub), settings: &pubSubSettings{
+			retryPolicy:  runtime_pubsub.DefaultRetryPolicy(),
+			deduplicator: runtime_pubsub.NewDeduplicator(TestRuntimeConfigID, "pubsub1", &fakeStateStore{items: map[string][]byte{}}, time.Hour),
+		}}
+		rt.defaultPubSubName = "pubsub1"
+		rt.initDefaultPubSub("pubsub1", rt.pubSubs["pubsub1"])
+		event := &pubsub.NewMessage{
+			Topic: "topic1",
+			Data:  []byte(`{"id": "event1", "data": "hello"}`),
+		}
+		calls := 0
+		publish := func(msg *pubsub.NewMessage) error {
+			calls++
+			return nil
+		}
 
-	err := handler(&bindings.ReadResponse{
-		Metadata: map[string]string{},
-		Data:     []byte(b.data),
+		assert.NoError(t, rt.deliverMessage(event, runtime_pubsub.Subscription{Topic: "topic1"}, publish))
+		assert.NoError(t, rt.deliverMessage(event, runtime_pubsub.Subscription{Topic: "topic1"}, publish))
+		assert.Equal(t, 1, calls)
 	})
-	b.hasError = err != nil
-	return nil
-}
 
-func TestReadInputBindings(t *testing.T) {
-	t.Run("app acknowledge, no retry", func(t *testing.T) {
+	t.Run("returns error when dead-letter publish fails", func(t *testing.T) {
 		rt := NewTestDaprRuntime(modes.StandaloneMode)
-		mockAppChannel := new(channelt.MockAppChannel)
-		rt.appChannel = mockAppChannel
+		sub := runtime_pubsub.Subscription{Topic: "topic1", DeadLetterTopic: "poison"}
+		mockPubSub := new(daprt.MockPubSub)
+		mockPubSub.On("Publish", mock.Anything).Return(errors.New("broker down"))
+		rt.pubSub = mockPubSub
 
-		fakeReq := invokev1.NewInvokeMethodRequest("test")
-		fakeReq.WithHTTPExtension(http.MethodPost, "")
-		fakeReq.WithRawData([]byte("test"), "application/json")
+		err := rt.deliverMessage(testPubSubMessage, sub, failingPublish)
+		assert.Error(t, err)
+	})
+}
 
-		// User App subscribes 1 topics via http app channel
-		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
-		fakeResp.WithRawData([]byte("OK"), "application/json")
+func TestReloadDefaultPubSub(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	mockPubSub := new(daprt.MockPubSub)
+	mockPubSub.On("Publish", mock.Anything).Return(nil)
+	rt.pubSubs["pubsub1"] = pubSubComponent{pubSub: mockPubSub}
+	rt.defaultPubSubName = "pubsub1"
+	rt.initDefaultPubSub("pubsub1", rt.pubSubs["pubsub1"])
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			msg := &pubsub.NewMessage{Topic: "topic1", Data: []byte(`{"id": "1"}`)}
+			assert.NoError(t, rt.deliverMessage(msg, runtime_pubsub.Subscription{Topic: "topic1"}, func(msg *pubsub.NewMessage) error { return nil }))
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		properties := map[string]string{runtime_pubsub.RetryMaxRetriesMetadataKey: strconv.Itoa(i)}
+		rt.setPubSubComponent("pubsub1", pubSubComponent{
+			pubSub:     mockPubSub,
+			properties: properties,
+			settings:   rt.getPubSubSettingsOf("pubsub1", mockPubSub, properties),
+		})
+	}
+	wg.Wait()
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+	assert.Equal(t, 9, rt.getPubSubSettings("").retryPolicy.MaxRetries)
+}
 
-		rt.appChannel = mockAppChannel
+func TestPublishToMultiplePubSubs(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	defaultPubSub := new(daprt.MockPubSub)
+	defaultPubSub.On("Publish", mock.Anything).Return(nil)
+	otherPubSub := new(daprt.MockPubSub)
+	otherPubSub.On("Publish", mock.Anything).Return(nil)
+	rt.pubSubs["pubsub1"] = pubSubComponent{pubSub: defaultPubSub}
+	rt.pubSubs["pubsub2"] = pubSubComponent{pubSub: otherPubSub, scopedPublishings: []string{"topic2"}}
+	rt.defaultPubSubName = "pubsub1"
+	rt.initDefaultPubSub("pubsub1", rt.pubSubs["pubsub1"])
+
+	t.Run("empty name selects the default pub sub", func(t *testing.T) {
+		c, ok := rt.getPubSubComponent("")
+		assert.True(t, ok)
+		assert.Same(t, defaultPubSub, c.pubSub)
+	})
 
-		b := mockBinding{}
-		rt.readFromBinding("test", &b)
+	t.Run("named pub sub", func(t *testing.T) {
+		c, ok := rt.getPubSubComponent("pubsub2")
+		assert.True(t, ok)
+		assert.Same(t, otherPubSub, c.pubSub)
+	})
 
-		assert.False(t, b.hasError)
+	t.Run("scopes of the named pub sub apply", func(t *testing.T) {
+		assert.NoError(t, rt.PublishTo("pubsub2", &pubsub.PublishRequest{Topic: "topic2"}))
+		err := rt.PublishTo("pubsub2", &pubsub.PublishRequest{Topic: "topic1"})
+		assert.Equal(t, runtime_pubsub.NotAllowedError{Topic: "topic1", AppID: TestRuntimeConfigID}, err)
+		assert.NoError(t, rt.PublishTo("", &pubsub.PublishRequest{Topic: "topic1"}))
+		otherPubSub.AssertNumberOfCalls(t, "Publish", 1)
+		defaultPubSub.AssertNumberOfCalls(t, "Publish", 1)
 	})
 
-	t.Run("app returns error", func(t *testing.T) {
-		rt := NewTestDaprRuntime(modes.StandaloneMode)
-		mockAppChannel := new(channelt.MockAppChannel)
-		rt.appChannel = mockAppChannel
+	t.Run("unknown pub sub", func(t *testing.T) {
+		err := rt.PublishTo("pubsub3", &pubsub.PublishRequest{Topic: "topic1"})
+		assert.Equal(t, runtime_pubsub.NotFoundError{PubSubName: "pubsub3"}, err)
+	})
+}
 
-		fakeReq := invokev1.NewInvokeMethodRequest("test")
-		fakeReq.WithHTTPExtension(http.MethodPost, "")
-		fakeReq.WithRawData([]byte("test"), "application/json")
+func TestPublishSchemaValidation(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	mockPubSub := new(daprt.MockPubSub)
+	mockPubSub.On("Publish", mock.Anything).Return(nil)
+	validator, err := runtime_pubsub.NewSchemaValidator(map[string]string{
+		"schema.orders": `{"type": "object", "required": ["id"]}`,
+	})
+	assert.NoError(t, err)
+	rt.pubSubs["pubsub1"] = pubSubComponent{pubSub: mockPubSub, schemaValidator: validator}
+	rt.defaultPubSubName = "pubsub1"
+	rt.initDefaultPubSub("pubsub1", rt.pubSubs["pubsub1"])
+
+	assert.NoError(t, rt.Publish(&pubsub.PublishRequest{Topic: "orders", Data: []byte(`{"id": 1}`)}))
+	err = rt.Publish(&pubsub.PublishRequest{Topic: "orders", Data: []byte(`{}`)})
+	assert.IsType(t, runtime_pubsub.SchemaValidationError{}, err)
+	assert.NoError(t, rt.Publish(&pubsub.PublishRequest{Topic: "payments", Data: []byte(`{}`)}))
+	mockPubSub.AssertNumberOfCalls(t, "Publish", 2)
+}
 
-		// User App subscribes 1 topics via http app channel
-		fakeResp := invokev1.NewInvokeMethodResponse(500, "Internal Error", nil)
-		fakeResp.WithRawData([]byte("Internal Error"), "application/json")
+func TestStateMirrorTarget(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	source, _ := keyprefix.New(map[string]string{}, "app1", "store1", "")
+	target := &stateMirrorTarget{runtime: rt, name: "store2", source: source}
+
+	_, ok := target.Store()
+	assert.False(t, ok)
+
+	secondary := &fakeStateStore{}
+	rt.compStore.AddStateStore("store2", secondary)
+	prefix, _ := keyprefix.New(map[string]string{keyprefix.MetadataKey: keyprefix.Name}, "app1", "store2", "")
+	rt.compStore.SetStateKeyPrefix("store2", prefix)
+	store, ok := target.Store()
+	assert.True(t, ok)
+	assert.Equal(t, secondary, store)
+	assert.Equal(t, "store2||key1", target.Key("app1||key1"))
+}
 
-		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+func TestSetAppHealthy(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.topicRoutes = map[subscriptionKey]string{{topic: "topic1"}: "orders", {topic: "topic2"}: "payments"}
+	assert.NoError(t, rt.PauseSubscription("topic2"))
+	mockActors := new(daprt.MockActors)
+	mockActors.On("SetAppHealthy", false)
+	mockActors.On("SetAppHealthy", true)
+	rt.actor = mockActors
+	assert.Equal(t, "", rt.AppHealth())
+	rt.appHealth.enable()
+	assert.Equal(t, AppHealthHealthy, rt.AppHealth())
+
+	rt.setAppHealthy(false)
+	assert.Equal(t, AppHealthUnhealthy, rt.AppHealth())
+	assert.True(t, rt.unhealthyTopics.IsPaused("topic1"))
+	assert.True(t, rt.unhealthyTopics.IsPaused("topic2"))
+	mockActors.AssertCalled(t, "SetAppHealthy", false)
+
+	// Input binding events wait for the app to recover
+	delivered := make(chan struct{})
+	go func() {
+		rt.appHealth.wait()
+		close(delivered)
+	}()
+	select {
+	case <-delivered:
+		assert.Fail(t, "event delivered while the app is unhealthy")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	rt.setAppHealthy(true)
+	<-delivered
+	assert.Equal(t, AppHealthHealthy, rt.AppHealth())
+	assert.Empty(t, rt.unhealthyTopics.Paused())
+	// Topics paused through the API stay paused when the app recovers
+	assert.True(t, rt.pausedTopics.IsPaused("topic2"))
+	mockActors.AssertCalled(t, "SetAppHealthy", true)
+
+	// A repeated status changes nothing
+	rt.setAppHealthy(true)
+	mockActors.AssertNumberOfCalls(t, "SetAppHealthy", 2)
+}
+
+func TestSubscriptionsOfSeveralPubSubs(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	mockAppChannel := new(channelt.MockAppChannel)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil)
+	rt.appChannel = mockAppChannel
+
+	pubSub1 := &subscribingPubSub{handlers: map[string]func(msg *pubsub.NewMessage) error{}}
+	pubSub2 := &subscribingPubSub{handlers: map[string]func(msg *pubsub.NewMessage) error{}}
+	rt.setPubSubComponent("pubsub1", pubSubComponent{pubSub: pubSub1})
+	rt.setPubSubComponent("pubsub2", pubSubComponent{pubSub: pubSub2, settings: &pubSubSettings{
+		retryPolicy:  runtime_pubsub.DefaultRetryPolicy(),
+		deduplicator: runtime_pubsub.NewDeduplicator(TestRuntimeConfigID, "pubsub2", &fakeStateStore{items: map[string][]byte{}}, time.Hour),
+	}})
+	rt.defaultPubSubName = "pubsub1"
+	rt.initDefaultPubSub("pubsub1", rt.pubSubs["pubsub1"])
+
+	rt.topicRoutes = map[subscriptionKey]string{
+		{topic: "orders"}:                        "orders1",
+		{pubSubName: "pubsub2", topic: "orders"}: "orders2",
+	}
+	rt.subscriptions = map[subscriptionKey]runtime_pubsub.Subscription{
+		{topic: "orders"}:                        {Topic: "orders", Route: "orders1"},
+		{pubSubName: "pubsub2", topic: "orders"}: {PubSubName: "pubsub2", Topic: "orders", Route: "orders2"},
+	}
+	rt.resubscribeTopics("pubsub1")
+	rt.resubscribeTopics("pubsub2")
+
+	routes := func() []string {
+		routes := []string{}
+		for _, call := range mockAppChannel.Calls {
+			routes = append(routes, call.Arguments.Get(1).(*invokev1.InvokeMethodRequest).Message().GetMethod())
+		}
+		return routes
+	}
+
+	t.Run("both subscriptions are subscribed", func(t *testing.T) {
+		assert.Contains(t, pubSub1.handlers, "orders")
+		assert.Contains(t, pubSub2.handlers, "orders")
+		assert.Len(t, rt.Subscriptions(), 2)
+	})
+
+	t.Run("messages are delivered to the route of their pub sub", func(t *testing.T) {
+		assert.NoError(t, pubSub1.handlers["orders"](&pubsub.NewMessage{Topic: "orders", Data: []byte(`{"id": "event1"}`)}))
+		assert.NoError(t, pubSub2.handlers["orders"](&pubsub.NewMessage{Topic: "orders", Data: []byte(`{"id": "event1"}`)}))
+		assert.Equal(t, []string{"orders1", "orders2"}, routes())
+	})
+
+	t.Run("settings of a pub sub apply to its messages only", func(t *testing.T) {
+		assert.NoError(t, pubSub1.handlers["orders"](&pubsub.NewMessage{Topic: "orders", Data: []byte(`{"id": "event1"}`)}))
+		assert.NoError(t, pubSub2.handlers["orders"](&pubsub.NewMessage{Topic: "orders", Data: []byte(`{"id": "event1"}`)}))
+		assert.Equal(t, []string{"orders1", "orders2", "orders1"}, routes())
+	})
+}
+
+func TestPauseSubscription(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.defaultPubSubName = "pubsub1"
+	rt.topicRoutes = map[subscriptionKey]string{{topic: "topic1"}: "orders"}
+
+	assert.NoError(t, rt.PauseSubscription("topic1"))
+	assert.Equal(t, []runtime_pubsub.SubscriptionStatus{
+		{PubSubName: "pubsub1", Topic: "topic1", Route: "orders", Paused: true},
+	}, rt.Subscriptions())
+
+	assert.NoError(t, rt.ResumeSubscription("topic1"))
+	assert.False(t, rt.Subscriptions()[0].Paused)
+
+	err := rt.PauseSubscription("topic2")
+	assert.Equal(t, runtime_pubsub.SubscriptionNotFoundError{Topic: "topic2"}, err)
+}
+
+func TestPublishDelayed(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.pubSubs["pubsub1"] = pubSubComponent{pubSub: &mockPublishPubSub{}}
+	rt.defaultPubSubName = "pubsub1"
+	rt.initDefaultPubSub("pubsub1", rt.pubSubs["pubsub1"])
+	event, err := runtime_pubsub.NewCloudEvent("event1", TestRuntimeConfigID, "", "text/plain", []byte("hello"), map[string]string{
+		runtime_pubsub.DelaySecondsMetadataKey: "60",
+	})
+	assert.NoError(t, err)
+
+	t.Run("delay queue not configured", func(t *testing.T) {
+		err := rt.Publish(&pubsub.PublishRequest{Topic: "topic1", Data: event})
+		assert.Equal(t, runtime_pubsub.ErrDelayedDeliveryNotConfigured, err)
+	})
+
+	t.Run("delayed event is queued", func(t *testing.T) {
+		store := &fakeStateStore{items: map[string][]byte{}}
+		rt.setPubSubComponent("pubsub1", pubSubComponent{pubSub: rt.pubSub, settings: &pubSubSettings{
+			retryPolicy: runtime_pubsub.DefaultRetryPolicy(),
+			delayQueue:  runtime_pubsub.NewDelayQueue(TestRuntimeConfigID, store, rt.pubSub.Publish, log),
+		}})
+
+		err := rt.Publish(&pubsub.PublishRequest{Topic: "topic1", Data: event})
+		assert.NoError(t, err)
+		queued := partitions.New(store, partitions.DefaultCount, "delayqueue", TestRuntimeConfigID)
+		var saved string
+		for partition := 0; partition < queued.Count(); partition++ {
+			saved += string(store.items[queued.Key(partition)])
+		}
+		assert.Contains(t, saved, "topic1")
+	})
+}
+
+func TestPublishMessageHTTPAppResponse(t *testing.T) {
+	testPubSubMessage := &pubsub.NewMessage{
+		Topic: "topic1",
+		Data:  []byte("Test Message"),
+	}
+
+	testCases := []struct {
+		name     string
+		code     int
+		body     string
+		expected error
+	}{
+		{name: "success status", code: 200, body: `{"status": "SUCCESS"}`},
+		{name: "no status", code: 204},
+		{name: "retry status", code: 200, body: `{"status": "RETRY"}`, expected: runtime_pubsub.ErrMessageRetry},
+		{name: "drop status", code: 200, body: `{"status": "DROP"}`, expected: runtime_pubsub.ErrMessageDropped},
+		{name: "not found", code: 404, expected: runtime_pubsub.ErrMessageDropped},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rt := NewTestDaprRuntime(modes.StandaloneMode)
+			mockAppChannel := new(channelt.MockAppChannel)
+			rt.appChannel = mockAppChannel
+
+			fakeResp := invokev1.NewInvokeMethodResponse(int32(tc.code), "", nil)
+			fakeResp.WithRawData([]byte(tc.body), "application/json")
+			mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(fakeResp, nil)
+
+			err := rt.publishMessageHTTP(testPubSubMessage, runtime_pubsub.Subscription{Topic: "topic1", Route: "topic1"})
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestPublishMessageHTTPRoutingRules(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	sub := runtime_pubsub.Subscription{
+		Topic: "topic1",
+		Routes: runtime_pubsub.RoutesSpec{
+			Rules: []runtime_pubsub.RouteRule{{Match: `event.type == "order.created"`, Path: "orders/created"}},
+		},
+	}
+	mockAppChannel := new(channelt.MockAppChannel)
+	rt.appChannel = mockAppChannel
+
+	fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.MatchedBy(func(req *invokev1.InvokeMethodRequest) bool {
+		return req.Message().GetMethod() == "orders/created"
+	})).Return(fakeResp, nil)
+
+	t.Run("matching rule", func(t *testing.T) {
+		err := rt.publishMessageHTTP(&pubsub.NewMessage{Topic: "topic1", Data: []byte(`{"type": "order.created"}`)}, sub)
+		assert.NoError(t, err)
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+	})
+
+	t.Run("no matching rule without default route drops event", func(t *testing.T) {
+		err := rt.publishMessageHTTP(&pubsub.NewMessage{Topic: "topic1", Data: []byte(`{"type": "other"}`)}, sub)
+		assert.NoError(t, err)
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+	})
+}
+
+func TestPublishMessageHTTPRawPayload(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	sub := runtime_pubsub.Subscription{
+		Topic:    "topic1",
+		Route:    "topic1",
+		Metadata: map[string]string{"rawPayload": "true"},
+	}
+	mockAppChannel := new(channelt.MockAppChannel)
+	rt.appChannel = mockAppChannel
+
+	fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.MatchedBy(func(req *invokev1.InvokeMethodRequest) bool {
+		contentType, data := req.RawData()
+		return contentType == "application/octet-stream" && string(data) == "raw data"
+	})).Return(fakeResp, nil)
+
+	err := rt.publishMessageHTTP(&pubsub.NewMessage{Topic: "topic1", Data: []byte("raw data")}, sub)
+	assert.NoError(t, err)
+	mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+}
+
+func TestPublishMessagesHTTPBulk(t *testing.T) {
+	msgs := []*pubsub.NewMessage{
+		{Topic: "topic1", Data: []byte(`"first"`)},
+		{Topic: "topic1", Data: []byte(`"second"`)},
+		{Topic: "topic1", Data: []byte(`"third"`)},
+	}
+
+	t.Run("per message statuses", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		rt.appChannel = mockAppChannel
+
+		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+		fakeResp.WithRawData([]byte(`{"statuses":[{"entryId":"0","status":"SUCCESS"},{"entryId":"1","status":"RETRY"},{"entryId":"2","status":"DROP"}]}`), "application/json")
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.AnythingOfType("*v1.InvokeMethodRequest")).Return(fakeResp, nil)
+
+		errs := rt.publishMessagesHTTPBulk(runtime_pubsub.Subscription{Topic: "topic1", Route: "topic1"}, msgs)
+		assert.Len(t, errs, 3)
+		assert.NoError(t, errs[0])
+		assert.Error(t, errs[1])
+		assert.NoError(t, errs[2])
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+	})
+
+	t.Run("app error fails all messages", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		rt.appChannel = mockAppChannel
+
+		fakeResp := invokev1.NewInvokeMethodResponse(500, "Internal Error", nil)
+		fakeResp.WithRawData([]byte("error"), "application/json")
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.AnythingOfType("*v1.InvokeMethodRequest")).Return(fakeResp, nil)
+
+		errs := rt.publishMessagesHTTPBulk(runtime_pubsub.Subscription{Topic: "topic1", Route: "topic1"}, msgs)
+		for _, err := range errs {
+			assert.Error(t, err)
+		}
+	})
+}
+
+func getFakeProperties() map[string]string {
+	return map[string]string{
+		"host":                    "localhost",
+		"password":                "fakePassword",
+		"consumerID":              TestRuntimeConfigID,
+		scopes.SubscriptionScopes: fmt.Sprintf("%s=topic0,topic1", TestRuntimeConfigID),
+		scopes.PublishingScopes:   fmt.Sprintf("%s=topic0,topic1", TestRuntimeConfigID),
+	}
+}
+
+func getFakeMetadataItems() []components_v1alpha1.MetadataItem {
+	return []components_v1alpha1.MetadataItem{
+		{
+			Name:  "host",
+			Value: "localhost",
+		},
+		{
+			Name:  "password",
+			Value: "fakePassword",
+		},
+		{
+			Name:  "consumerID",
+			Value: TestRuntimeConfigID,
+		},
+		{
+			Name:  scopes.SubscriptionScopes,
+			Value: fmt.Sprintf("%s=topic0,topic1", TestRuntimeConfigID),
+		},
+		{
+			Name:  scopes.PublishingScopes,
+			Value: fmt.Sprintf("%s=topic0,topic1", TestRuntimeConfigID),
+		},
+	}
+}
+
+func NewTestDaprRuntime(mode modes.DaprMode) *DaprRuntime {
+	testRuntimeConfig := NewRuntimeConfig(NewRuntimeConfigOpts{
+		ID:                            TestRuntimeConfigID,
+		PlacementServiceAddress:       "10.10.10.12",
+		ControlPlaneAddress:           "10.10.10.11",
+		AllowedOrigins:                DefaultAllowedOrigins,
+		GlobalConfig:                  "globalConfig",
+		ComponentsPath:                DefaultComponentsPath,
+		AppProtocol:                   string(HTTPProtocol),
+		Mode:                          string(mode),
+		HTTPPort:                      DefaultDaprHTTPPort,
+		APIGRPCPort:                   DefaultDaprAPIGRPCPort,
+		AppPort:                       1024,
+		ProfilePort:                   DefaultProfilePort,
+		MaxConcurrency:                -1,
+		GracefulShutdownSeconds:       DefaultGracefulShutdownSeconds,
+		AppHealthCheckPath:            DefaultAppHealthCheckPath,
+		AppHealthProbeIntervalSeconds: DefaultAppHealthProbeIntervalSeconds,
+		AppHealthThreshold:            DefaultAppHealthThreshold,
+		MaxRequestBodySize:            DefaultMaxRequestBodySize,
+		GRPCMaxRecvMsgSize:            DefaultGRPCMaxMsgSize,
+		GRPCMaxSendMsgSize:            DefaultGRPCMaxMsgSize,
+	})
+
+	rt := NewDaprRuntime(testRuntimeConfig, &config.Configuration{})
+	rt.topicRoutes[subscriptionKey{topic: "topic1"}] = "topic1"
+	rt.subscriptions[subscriptionKey{topic: "topic1"}] = runtime_pubsub.Subscription{Topic: "topic1", Route: "topic1"}
+
+	rt.components = []components_v1alpha1.Component{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: "Components",
+			},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type:     "pubsub.mockPubSub",
+				Metadata: getFakeMetadataItems(),
+			},
+		},
+	}
+
+	return rt
+}
+
+func TestMTLS(t *testing.T) {
+	t.Run("with mTLS enabled", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.runtimeConfig.mtlsEnabled = true
+		rt.runtimeConfig.SentryServiceAddress = "1.1.1.1"
+
+		os.Setenv(certs.TrustAnchorsEnvVar, testCertRoot)
+		os.Setenv(certs.CertChainEnvVar, "a")
+		os.Setenv(certs.CertKeyEnvVar, "b")
+		defer os.Clearenv()
+
+		certChain, err := security.GetCertChain()
+		assert.Nil(t, err)
+		rt.runtimeConfig.CertChain = certChain
+
+		err = rt.establishSecurity(rt.runtimeConfig.SentryServiceAddress)
+		assert.Nil(t, err)
+		assert.NotNil(t, rt.authenticator)
+	})
+
+	t.Run("with mTLS disabled", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+
+		err := rt.establishSecurity(rt.runtimeConfig.SentryServiceAddress)
+		assert.Nil(t, err)
+		assert.Nil(t, rt.authenticator)
+	})
+}
+
+type mockBinding struct {
+	hasError bool
+	data     string
+}
+
+func (b *mockBinding) Init(metadata bindings.Metadata) error {
+	return nil
+}
+
+func (b *mockBinding) Read(handler func(*bindings.ReadResponse) error) error {
+	b.data = "test"
+
+	err := handler(&bindings.ReadResponse{
+		Metadata: map[string]string{},
+		Data:     []byte(b.data),
+	})
+	b.hasError = err != nil
+	return nil
+}
+
+func TestReadInputBindings(t *testing.T) {
+	t.Run("app acknowledge, no retry", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		rt.appChannel = mockAppChannel
+
+		fakeReq := invokev1.NewInvokeMethodRequest("test")
+		fakeReq.WithHTTPExtension(http.MethodPost, "")
+		fakeReq.WithRawData([]byte("test"), "application/json")
+
+		// User App subscribes 1 topics via http app channel
+		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+		fakeResp.WithRawData([]byte("OK"), "application/json")
+
+		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
+
+		rt.appChannel = mockAppChannel
+
+		b := mockBinding{}
+		rt.readFromBinding("test", &b)
+
+		assert.False(t, b.hasError)
+	})
+
+	t.Run("app returns error", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		rt.appChannel = mockAppChannel
+
+		fakeReq := invokev1.NewInvokeMethodRequest("test")
+		fakeReq.WithHTTPExtension(http.MethodPost, "")
+		fakeReq.WithRawData([]byte("test"), "application/json")
+
+		// User App subscribes 1 topics via http app channel
+		fakeResp := invokev1.NewInvokeMethodResponse(500, "Internal Error", nil)
+		fakeResp.WithRawData([]byte("Internal Error"), "application/json")
+
+		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.emptyCtx"), fakeReq).Return(fakeResp, nil)
 
 		rt.appChannel = mockAppChannel
 
@@ -890,6 +1630,435 @@ func TestReadInputBindings(t *testing.T) {
 	})
 }
 
+type mockOutputBinding struct {
+	requests []*bindings.WriteRequest
+}
+
+func (b *mockOutputBinding) Init(metadata bindings.Metadata) error {
+	return nil
+}
+
+func (b *mockOutputBinding) Write(req *bindings.WriteRequest) error {
+	b.requests = append(b.requests, req)
+	return nil
+}
+
+// mockInvokableOutputBinding is an output binding returning the response of its operations
+type mockInvokableOutputBinding struct {
+	mockOutputBinding
+	response *bindings_loader.InvokeResponse
+}
+
+func (b *mockInvokableOutputBinding) Invoke(req *bindings.WriteRequest) (*bindings_loader.InvokeResponse, error) {
+	b.requests = append(b.requests, req)
+	return b.response, nil
+}
+
+func TestInvokeOutputBinding(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	invokable := &mockInvokableOutputBinding{
+		response: &bindings_loader.InvokeResponse{
+			Data:     []byte("1"),
+			Metadata: map[string]string{"statusCode": "201"},
+		},
+	}
+	writer := &mockOutputBinding{}
+	rt.outputBindings["invokable"] = invokable
+	rt.outputBindings["writer"] = writer
+
+	t.Run("binding returning responses", func(t *testing.T) {
+		resp, err := rt.invokeOutputBinding("invokable", &bindings.WriteRequest{Data: []byte("a")})
+		assert.NoError(t, err)
+		assert.Equal(t, invokable.response, resp)
+		assert.Len(t, invokable.requests, 1)
+	})
+
+	t.Run("binding without responses", func(t *testing.T) {
+		resp, err := rt.invokeOutputBinding("writer", &bindings.WriteRequest{Data: []byte("a")})
+		assert.NoError(t, err)
+		assert.Nil(t, resp)
+		assert.Len(t, writer.requests, 1)
+	})
+
+	t.Run("unknown binding", func(t *testing.T) {
+		_, err := rt.invokeOutputBinding("unknown", &bindings.WriteRequest{})
+		assert.Error(t, err)
+	})
+}
+
+func TestBindingEventAppResponse(t *testing.T) {
+	newRuntime := func(appResponse string) (*DaprRuntime, *mockOutputBinding) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		resp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+		resp.WithRawData([]byte(appResponse), "application/json")
+		mockAppChannel := new(channelt.MockAppChannel)
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(resp, nil)
+		rt.appChannel = mockAppChannel
+		out := &mockOutputBinding{}
+		rt.outputBindings["out"] = out
+		return rt, out
+	}
+
+	t.Run("state is saved before the follow-up binding is invoked", func(t *testing.T) {
+		rt, out := newRuntime(`{"storeName": "store1", "state": [{"key": "k1", "value": "v1"}], "to": ["out"], "data": "k1 saved"}`)
+		store := &fakeStateStore{items: map[string][]byte{}}
+		rt.compStore.AddStateStore("store1", store)
+		prefix, _ := keyprefix.New(nil, "app1", "store1", "")
+		rt.compStore.SetStateKeyPrefix("store1", prefix)
+
+		err := rt.sendBindingEventToApp("test", []byte("event"), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"app1||k1": []byte(`"v1"`)}, store.items)
+		assert.Len(t, out.requests, 1)
+		assert.Equal(t, []byte(`"k1 saved"`), out.requests[0].Data)
+	})
+
+	t.Run("state is saved in a transaction", func(t *testing.T) {
+		rt, _ := newRuntime(`{"state": [{"key": "k1", "value": "v1"}, {"key": "k2", "value": "v2"}]}`)
+		store := &fakeTransactionalStateStore{}
+		rt.compStore.AddStateStore("store1", store)
+
+		err := rt.sendBindingEventToApp("test", []byte("event"), nil)
+		assert.NoError(t, err)
+		assert.Len(t, store.operations, 2)
+		assert.Equal(t, state.Upsert, store.operations[0].Operation)
+		assert.Equal(t, "k2", store.operations[1].Request.(state.SetRequest).Key)
+	})
+
+	t.Run("state store not found", func(t *testing.T) {
+		rt, out := newRuntime(`{"storeName": "unknown", "state": [{"key": "k1", "value": "v1"}], "to": ["out"]}`)
+
+		err := rt.sendBindingEventToApp("test", []byte("event"), nil)
+		assert.Error(t, err)
+		assert.Empty(t, out.requests)
+	})
+
+	t.Run("follow-up bindings invoked in parallel", func(t *testing.T) {
+		rt, out := newRuntime(`{"to": ["out", "unknown"], "concurrency": "parallel"}`)
+
+		err := rt.sendBindingEventToApp("test", []byte("event"), nil)
+		assert.Error(t, err)
+		assert.Len(t, out.requests, 1)
+	})
+}
+
+func TestJobs(t *testing.T) {
+	t.Run("jobs without job state store", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.compStore.AddStateStore("store1", &fakeStateStore{items: map[string][]byte{}})
+		rt.initJobs()
+		assert.Nil(t, rt.jobs)
+	})
+
+	t.Run("jobs are saved in the job state store", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		store := &fakeStateStore{items: map[string][]byte{}}
+		rt.compStore.AddStateStore("store1", store)
+		rt.jobStateStoreName = "store1"
+		rt.initJobs()
+
+		assert.NoError(t, rt.jobs.ScheduleJob(jobs.Job{Name: "report", Schedule: "@daily"}))
+		saved := partitions.New(store, partitions.DefaultCount, "jobs", rt.runtimeConfig.ID)
+		assert.Contains(t, store.items, saved.Key(saved.Of("report")))
+		listed, err := rt.jobs.ListJobs()
+		assert.NoError(t, err)
+		assert.Len(t, listed, 1)
+	})
+
+	t.Run("due jobs invoke the app", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		okResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+		failedResp := invokev1.NewInvokeMethodResponse(500, "Internal Error", nil)
+		mockAppChannel := new(channelt.MockAppChannel)
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.MatchedBy(func(req *invokev1.InvokeMethodRequest) bool {
+			return req.Message().Method == "job/report"
+		})).Return(okResp, nil)
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(failedResp, nil)
+		rt.appChannel = mockAppChannel
+
+		assert.NoError(t, rt.invokeJob(&jobs.Job{Name: "report", Data: []byte(`"sales"`)}))
+		assert.Error(t, rt.invokeJob(&jobs.Job{Name: "cleanup"}))
+	})
+}
+
+func TestReadInputBindingsWithRetries(t *testing.T) {
+	failedResp := invokev1.NewInvokeMethodResponse(500, "Internal Error", nil)
+	failedResp.WithRawData([]byte("Internal Error"), "application/json")
+	okResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	okResp.WithRawData([]byte("OK"), "application/json")
+	policy := runtime_pubsub.RetryPolicy{MaxRetries: 2, InitialIntervalMs: 1}
+
+	t.Run("app acknowledges a retried event", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(failedResp, nil).Once()
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(okResp, nil).Once()
+		rt.appChannel = mockAppChannel
+		rt.inputBindingConfigs["test"] = inputBindingConfig{retryPolicy: policy}
+
+		b := mockBinding{}
+		rt.readFromBinding("test", &b)
+
+		assert.False(t, b.hasError)
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 2)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(failedResp, nil)
+		rt.appChannel = mockAppChannel
+		rt.inputBindingConfigs["test"] = inputBindingConfig{retryPolicy: policy}
+
+		b := mockBinding{}
+		rt.readFromBinding("test", &b)
+
+		assert.True(t, b.hasError)
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 3)
+	})
+
+	t.Run("retries exhausted with dead-letter binding", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(failedResp, nil)
+		rt.appChannel = mockAppChannel
+		rt.inputBindingConfigs["test"] = inputBindingConfig{retryPolicy: policy, deadLetterBinding: "dlq"}
+		dlq := &mockOutputBinding{}
+		rt.outputBindings["dlq"] = dlq
+
+		b := mockBinding{}
+		rt.readFromBinding("test", &b)
+
+		assert.False(t, b.hasError)
+		assert.Len(t, dlq.requests, 1)
+		assert.Equal(t, []byte("test"), dlq.requests[0].Data)
+	})
+
+	t.Run("missing dead-letter binding", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		mockAppChannel := new(channelt.MockAppChannel)
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(failedResp, nil)
+		rt.appChannel = mockAppChannel
+		rt.inputBindingConfigs["test"] = inputBindingConfig{deadLetterBinding: "dlq"}
+
+		b := mockBinding{}
+		rt.readFromBinding("test", &b)
+
+		assert.True(t, b.hasError)
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+	})
+}
+
+type mockBatchBinding struct {
+	events int
+}
+
+func (b *mockBatchBinding) Init(metadata bindings.Metadata) error {
+	return nil
+}
+
+func (b *mockBatchBinding) Read(handler func(*bindings.ReadResponse) error) error {
+	for i := 0; i < b.events; i++ {
+		handler(&bindings.ReadResponse{
+			Metadata: map[string]string{},
+			Data:     []byte("test"),
+		})
+	}
+	return nil
+}
+
+func TestReadInputBindingsConcurrently(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	okResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	okResp.WithRawData([]byte("OK"), "application/json")
+
+	var lock sync.Mutex
+	inFlight, delivered := 0, 0
+	allInFlight := make(chan struct{})
+	mockAppChannel := new(channelt.MockAppChannel)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		lock.Lock()
+		inFlight++
+		if inFlight == 3 {
+			close(allInFlight)
+		}
+		lock.Unlock()
+		// the events are only delivered once the three of them are in flight
+		<-allInFlight
+		lock.Lock()
+		delivered++
+		lock.Unlock()
+	}).Return(okResp, nil)
+	rt.appChannel = mockAppChannel
+	rt.inputBindingConfigs["test"] = inputBindingConfig{concurrency: 3, deadLetterBinding: "dlq"}
+
+	rt.readFromBinding("test", &mockBatchBinding{events: 3})
+
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return delivered == 3
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestGetInputBindingConfig(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+
+	t.Run("no retries by default", func(t *testing.T) {
+		c := rt.getInputBindingConfig("test", map[string]string{})
+		assert.Equal(t, 0, c.retryPolicy.MaxRetries)
+		assert.Empty(t, c.deadLetterBinding)
+	})
+
+	t.Run("retry policy and dead-letter binding", func(t *testing.T) {
+		c := rt.getInputBindingConfig("test", map[string]string{
+			runtime_pubsub.RetryMaxRetriesMetadataKey: "3",
+			deadLetterBindingMetadataKey:              "dlq",
+		})
+		assert.Equal(t, 3, c.retryPolicy.MaxRetries)
+		assert.Equal(t, runtime_pubsub.DefaultRetryInitialIntervalMs, c.retryPolicy.InitialIntervalMs)
+		assert.Equal(t, "dlq", c.deadLetterBinding)
+	})
+
+	t.Run("invalid retry policy", func(t *testing.T) {
+		c := rt.getInputBindingConfig("test", map[string]string{runtime_pubsub.RetryMaxRetriesMetadataKey: "-1"})
+		assert.Equal(t, 0, c.retryPolicy.MaxRetries)
+	})
+
+	t.Run("concurrency", func(t *testing.T) {
+		assert.Equal(t, 1, rt.getInputBindingConfig("test", map[string]string{}).concurrency)
+		assert.Equal(t, 10, rt.getInputBindingConfig("test", map[string]string{
+			bindingConcurrencyMetadataKey: "10",
+			deadLetterBindingMetadataKey:  "dlq",
+		}).concurrency)
+		assert.Equal(t, 1, rt.getInputBindingConfig("test", map[string]string{bindingConcurrencyMetadataKey: "0"}).concurrency)
+		// the events of concurrent input bindings are acknowledged before they are handled
+		assert.Equal(t, 1, rt.getInputBindingConfig("test", map[string]string{bindingConcurrencyMetadataKey: "10"}).concurrency)
+	})
+}
+
+func getBindingComponent(name, direction string) components_v1alpha1.Component {
+	c := components_v1alpha1.Component{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name},
+		Spec:       components_v1alpha1.ComponentSpec{Type: "bindings.mock"},
+	}
+	if direction != "" {
+		c.Spec.Metadata = []components_v1alpha1.MetadataItem{{Name: bindingDirectionMetadataKey, Value: direction}}
+	}
+	return c
+}
+
+func TestGetBindingDirection(t *testing.T) {
+	for value, expected := range map[string]string{
+		"":       bindingDirectionBoth,
+		"input":  bindingDirectionInput,
+		"Output": bindingDirectionOutput,
+		"both":   bindingDirectionBoth,
+	} {
+		direction, err := getBindingDirection(getBindingComponent("b", value))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, direction)
+	}
+
+	_, err := getBindingDirection(getBindingComponent("b", "sideways"))
+	assert.Error(t, err)
+}
+
+func TestBindingDirections(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.components = []components_v1alpha1.Component{
+		getBindingComponent("in", bindingDirectionInput),
+		getBindingComponent("out", bindingDirectionOutput),
+		getBindingComponent("invalid", "sideways"),
+	}
+	registry := bindings_loader.NewRegistry()
+	registry.RegisterInputBindings(bindings_loader.NewInput("mock", func() bindings.InputBinding {
+		return &mockBinding{}
+	}))
+	registry.RegisterOutputBindings(bindings_loader.NewOutput("mock", func() bindings.OutputBinding {
+		return &mockOutputBinding{}
+	}))
+	rt.bindingsRegistry = registry
+	mockAppChannel := new(channelt.MockAppChannel)
+	okResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(okResp, nil)
+	rt.appChannel = mockAppChannel
+
+	rt.initBindings()
+
+	assert.Equal(t, map[string]string{"in": bindingDirectionInput, "out": bindingDirectionOutput}, rt.bindingDirections)
+	assert.Contains(t, rt.inputBindings, "in")
+	assert.NotContains(t, rt.inputBindings, "out")
+	assert.Contains(t, rt.outputBindings, "out")
+	assert.NotContains(t, rt.outputBindings, "in")
+	// only the input binding is checked for a subscription
+	mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+
+	t.Run("input binding can't be invoked", func(t *testing.T) {
+		err := rt.sendToOutputBinding("in", &bindings.WriteRequest{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is an input binding")
+	})
+
+	t.Run("output binding is invoked", func(t *testing.T) {
+		assert.NoError(t, rt.sendToOutputBinding("out", &bindings.WriteRequest{}))
+	})
+}
+
+func TestComponentCapabilities(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	both := getBindingComponent("both", "")
+	both.Spec.Version = "v1"
+	rt.components = []components_v1alpha1.Component{
+		both,
+		getBindingComponent("out", bindingDirectionOutput),
+	}
+	registry := bindings_loader.NewRegistry()
+	registry.RegisterInputBindings(bindings_loader.NewInput("mock", func() bindings.InputBinding {
+		return &mockBinding{}
+	}))
+	registry.RegisterOutputBindings(bindings_loader.NewOutput("mock", func() bindings.OutputBinding {
+		return &mockInvokableOutputBinding{}
+	}))
+	rt.bindingsRegistry = registry
+	mockAppChannel := new(channelt.MockAppChannel)
+	okResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(okResp, nil)
+	rt.appChannel = mockAppChannel
+
+	rt.initBindings()
+
+	statuses := rt.ComponentStatuses()
+	assert.Len(t, statuses, 2)
+
+	t.Run("capabilities of both directions are merged", func(t *testing.T) {
+		assert.Equal(t, "both", statuses[0].Name)
+		assert.Equal(t, "v1", statuses[0].Version)
+		assert.Equal(t, []string{
+			components.CapabilityInputBinding,
+			components.CapabilityInvokeResponse,
+			components.CapabilityOutputBinding,
+		}, statuses[0].Capabilities)
+	})
+
+	t.Run("capabilities of an output binding", func(t *testing.T) {
+		assert.Equal(t, "out", statuses[1].Name)
+		assert.Empty(t, statuses[1].Version)
+		assert.Equal(t, []string{components.CapabilityOutputBinding, components.CapabilityInvokeResponse}, statuses[1].Capabilities)
+	})
+}
+
+func TestStateStoreCapabilities(t *testing.T) {
+	t.Run("store without features", func(t *testing.T) {
+		assert.Empty(t, stateStoreCapabilities(&fakeStateStore{}, map[string]string{}))
+	})
+
+	t.Run("transactional store allowing prefix deletes", func(t *testing.T) {
+		capabilities := stateStoreCapabilities(&fakeTransactionalStateStore{}, map[string]string{bulk.AllowPrefixDeleteMetadataKey: "true"})
+		assert.Equal(t, []string{components.CapabilityTransactional, components.CapabilityPrefixDelete}, capabilities)
+	})
+}
+
 func TestNamespace(t *testing.T) {
 	t.Run("empty namespace", func(t *testing.T) {
 		rt := NewTestDaprRuntime(modes.StandaloneMode)
@@ -905,30 +2074,53 @@ func TestNamespace(t *testing.T) {
 		rt := NewTestDaprRuntime(modes.StandaloneMode)
 		ns := rt.getNamespace()
 
-		assert.Equal(t, "a", ns)
-	})
-}
+		assert.Equal(t, "a", ns)
+	})
+}
+
+func TestAuthorizedComponents(t *testing.T) {
+	name := "test"
+
+	t.Run("standalone mode, no namespce", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		component := components_v1alpha1.Component{}
+		component.ObjectMeta.Name = name
+
+		comps := rt.getAuthorizedComponents([]components_v1alpha1.Component{component})
+		assert.True(t, len(comps) == 1)
+		assert.Equal(t, name, comps[0].Name)
+	})
+
+	t.Run("namespace mismatch", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.namespace = "a"
+
+		component := components_v1alpha1.Component{}
+		component.ObjectMeta.Name = "test"
+		component.ObjectMeta.Namespace = "b"
 
-func TestAuthorizedComponents(t *testing.T) {
-	name := "test"
+		comps := rt.getAuthorizedComponents([]components_v1alpha1.Component{component})
+		assert.True(t, len(comps) == 0)
+	})
 
-	t.Run("standalone mode, no namespce", func(t *testing.T) {
+	t.Run("no component namespace", func(t *testing.T) {
 		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.namespace = "a"
+
 		component := components_v1alpha1.Component{}
 		component.ObjectMeta.Name = name
 
 		comps := rt.getAuthorizedComponents([]components_v1alpha1.Component{component})
 		assert.True(t, len(comps) == 1)
-		assert.Equal(t, name, comps[0].Name)
 	})
 
-	t.Run("namespace mismatch", func(t *testing.T) {
+	t.Run("no component namespace, not in scope", func(t *testing.T) {
 		rt := NewTestDaprRuntime(modes.StandaloneMode)
 		rt.namespace = "a"
 
 		component := components_v1alpha1.Component{}
-		component.ObjectMeta.Name = "test"
-		component.ObjectMeta.Namespace = "b"
+		component.ObjectMeta.Name = name
+		component.Scopes = []string{"other"}
 
 		comps := rt.getAuthorizedComponents([]components_v1alpha1.Component{component})
 		assert.True(t, len(comps) == 0)
@@ -1016,3 +2208,678 @@ func (m *mockPublishPubSub) Publish(req *pubsub.PublishRequest) error {
 func (m *mockPublishPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
 	return nil
 }
+
+type fakeConfigurationStore struct {
+	metadata configuration.Metadata
+}
+
+func (f *fakeConfigurationStore) Init(metadata configuration.Metadata) error {
+	if metadata.Properties["fail"] == "true" {
+		return errors.New("init failed")
+	}
+	f.metadata = metadata
+	return nil
+}
+
+func (f *fakeConfigurationStore) Get(ctx context.Context, req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	return &configuration.GetResponse{}, nil
+}
+
+func (f *fakeConfigurationStore) Subscribe(ctx context.Context, req *configuration.SubscribeRequest, handler func(*configuration.UpdateEvent) error) error {
+	return nil
+}
+
+func TestInitConfiguration(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	store := &fakeConfigurationStore{}
+	rt.configurationRegistry.Register(
+		configuration_loader.New("fake", func() configuration.Store {
+			return store
+		}))
+
+	rt.components = append(rt.components,
+		components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "config1"},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type:     "configuration.fake",
+				Metadata: []components_v1alpha1.MetadataItem{{Name: "host", Value: "localhost"}},
+			},
+		},
+		components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "config2"},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type:     "configuration.fake",
+				Metadata: []components_v1alpha1.MetadataItem{{Name: "fail", Value: "true"}},
+			},
+		},
+		components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "config3"},
+			Spec:       components_v1alpha1.ComponentSpec{Type: "configuration.notregistered"},
+		})
+
+	rt.initConfiguration()
+
+	assert.Len(t, rt.compStore.ListConfigurationStores(), 1)
+	assert.Equal(t, store, rt.compStore.ListConfigurationStores()["config1"])
+	assert.Equal(t, "localhost", store.metadata.Properties["host"])
+}
+
+type fakeKeyVault struct {
+	metadata crypto.Metadata
+}
+
+func (f *fakeKeyVault) Init(metadata crypto.Metadata) error {
+	if metadata.Properties["fail"] == "true" {
+		return errors.New("init failed")
+	}
+	f.metadata = metadata
+	return nil
+}
+
+func (f *fakeKeyVault) WrapKey(ctx context.Context, key []byte, keyName, algorithm string) ([]byte, error) {
+	return key, nil
+}
+
+func (f *fakeKeyVault) UnwrapKey(ctx context.Context, wrappedKey []byte, keyName, algorithm string) ([]byte, error) {
+	return wrappedKey, nil
+}
+
+func TestInitKeyVaults(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	vault := &fakeKeyVault{}
+	rt.keyVaultRegistry.Register(
+		crypto_loader.New("fake", func() crypto.KeyVault {
+			return vault
+		}))
+
+	rt.components = append(rt.components,
+		components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "vault1"},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type:     "crypto.fake",
+				Metadata: []components_v1alpha1.MetadataItem{{Name: "vaultURL", Value: "https://vault"}},
+			},
+		},
+		components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "vault2"},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type:     "crypto.fake",
+				Metadata: []components_v1alpha1.MetadataItem{{Name: "fail", Value: "true"}},
+			},
+		},
+		components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "vault3"},
+			Spec:       components_v1alpha1.ComponentSpec{Type: "crypto.notregistered"},
+		})
+
+	rt.initKeyVaults()
+
+	assert.Len(t, rt.compStore.ListKeyVaults(), 1)
+	assert.Equal(t, vault, rt.compStore.ListKeyVaults()["vault1"])
+	assert.Equal(t, "https://vault", vault.metadata.Properties["vaultURL"])
+}
+
+type closableConfigurationStore struct {
+	fakeConfigurationStore
+	closed bool
+}
+
+func (c *closableConfigurationStore) Close() error {
+	c.closed = true
+	return nil
+}
+
+func configurationComponent(name, host string) components_v1alpha1.Component {
+	return components_v1alpha1.Component{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name},
+		Spec: components_v1alpha1.ComponentSpec{
+			Type:     "configuration.fake",
+			Metadata: []components_v1alpha1.MetadataItem{{Name: "host", Value: host}},
+		},
+	}
+}
+
+func newReloadTestRuntime() (*DaprRuntime, *[]*closableConfigurationStore) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.components = nil
+	created := []*closableConfigurationStore{}
+	rt.configurationRegistry.Register(
+		configuration_loader.New("fake", func() configuration.Store {
+			store := &closableConfigurationStore{}
+			created = append(created, store)
+			return store
+		}))
+	return rt, &created
+}
+
+func TestOnComponentUpdated(t *testing.T) {
+	rt, created := newReloadTestRuntime()
+
+	t.Run("adds a new component", func(t *testing.T) {
+		rt.onComponentUpdated(configurationComponent("config1", "host1"))
+
+		assert.Len(t, rt.components, 1)
+		assert.Len(t, *created, 1)
+		assert.Equal(t, (*created)[0], rt.compStore.ListConfigurationStores()["config1"])
+	})
+
+	t.Run("ignores an unchanged component", func(t *testing.T) {
+		rt.onComponentUpdated(configurationComponent("config1", "host1"))
+
+		assert.Len(t, *created, 1)
+		assert.False(t, (*created)[0].closed)
+	})
+
+	t.Run("replaces a changed component", func(t *testing.T) {
+		rt.onComponentUpdated(configurationComponent("config1", "host2"))
+
+		assert.Len(t, rt.components, 1)
+		assert.Len(t, *created, 2)
+		assert.True(t, (*created)[0].closed)
+		assert.Equal(t, (*created)[1], rt.compStore.ListConfigurationStores()["config1"])
+		assert.Equal(t, "host2", (*created)[1].metadata.Properties["host"])
+	})
+
+	t.Run("removes a component out of its scopes", func(t *testing.T) {
+		c := configurationComponent("config1", "host2")
+		c.Scopes = []string{"otherapp"}
+		rt.onComponentUpdated(c)
+
+		assert.Len(t, rt.components, 0)
+		assert.True(t, (*created)[1].closed)
+		assert.NotContains(t, rt.compStore.ListConfigurationStores(), "config1")
+	})
+}
+
+func TestOnComponentUpdatedIgnoresOtherNamespaces(t *testing.T) {
+	rt, created := newReloadTestRuntime()
+	rt.namespace = "a"
+	mine := configurationComponent("config1", "host1")
+	mine.ObjectMeta.Namespace = "a"
+	rt.onComponentUpdated(mine)
+
+	other := configurationComponent("config1", "host2")
+	other.ObjectMeta.Namespace = "b"
+
+	t.Run("update of another namespace", func(t *testing.T) {
+		rt.onComponentUpdated(other)
+
+		assert.Len(t, rt.components, 1)
+		assert.Len(t, *created, 1)
+		assert.False(t, (*created)[0].closed)
+	})
+
+	t.Run("deletion of another namespace", func(t *testing.T) {
+		rt.onComponentDeleted(other)
+
+		assert.Len(t, rt.components, 1)
+		assert.False(t, (*created)[0].closed)
+		assert.Contains(t, rt.compStore.ListConfigurationStores(), "config1")
+	})
+}
+
+func TestOnComponentsReloaded(t *testing.T) {
+	rt, created := newReloadTestRuntime()
+	rt.onComponentUpdated(configurationComponent("config1", "host1"))
+	rt.onComponentUpdated(configurationComponent("config2", "host1"))
+
+	rt.onComponentsReloaded([]components_v1alpha1.Component{
+		configurationComponent("config2", "host2"),
+		configurationComponent("config3", "host1"),
+	})
+
+	assert.Len(t, rt.components, 2)
+	assert.Len(t, *created, 4)
+	assert.True(t, (*created)[0].closed)
+	assert.True(t, (*created)[1].closed)
+	assert.NotContains(t, rt.compStore.ListConfigurationStores(), "config1")
+	assert.Equal(t, "host2", rt.compStore.ListConfigurationStores()["config2"].(*closableConfigurationStore).metadata.Properties["host"])
+	assert.Contains(t, rt.compStore.ListConfigurationStores(), "config3")
+}
+
+func TestOnComponentDeletedKeepsActorStateStore(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	store := &fakeStateStore{items: map[string][]byte{}}
+	c := components_v1alpha1.Component{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "actorstore"},
+		Spec:       components_v1alpha1.ComponentSpec{Type: "state.mock"},
+	}
+	rt.components = []components_v1alpha1.Component{c}
+	rt.compStore.AddStateStore("actorstore", store)
+	rt.actorStateStoreName = "actorstore"
+
+	rt.onComponentDeleted(c)
+
+	assert.Len(t, rt.components, 1)
+	assert.Equal(t, store, rt.compStore.ListStateStores()["actorstore"])
+}
+
+func TestComponentInitStatus(t *testing.T) {
+	rt, _ := newReloadTestRuntime()
+
+	failing := configurationComponent("config2", "host2")
+	failing.Spec.Metadata = append(failing.Spec.Metadata, components_v1alpha1.MetadataItem{Name: "fail", Value: "true"})
+	failing.Spec.InitRetries = 1
+	failing.Spec.InitRetryBackoff = "1ms"
+	failFast := failing
+	failFast.ObjectMeta.Name = "config3"
+	failFast.Spec.FailOnInitError = true
+
+	rt.initConfigurationStore(configurationComponent("config1", "host1"))
+	rt.initConfigurationStore(failing)
+
+	t.Run("failures don't fail the runtime by default", func(t *testing.T) {
+		assert.NoError(t, rt.componentsInitError())
+	})
+
+	rt.initConfigurationStore(failFast)
+
+	t.Run("reports the status of each component", func(t *testing.T) {
+		statuses := rt.ComponentStatuses()
+		assert.Len(t, statuses, 3)
+		assert.Equal(t, "config1", statuses[0].Name)
+		assert.Equal(t, components.StatusInitialized, statuses[0].Status)
+		assert.Equal(t, "config2", statuses[1].Name)
+		assert.Equal(t, components.StatusFailed, statuses[1].Status)
+		assert.Equal(t, "init failed", statuses[1].Error)
+		assert.Equal(t, components.StatusFailed, statuses[2].Status)
+	})
+
+	t.Run("failures of fail fast components fail the runtime", func(t *testing.T) {
+		err := rt.componentsInitError()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "config3")
+		assert.NotContains(t, err.Error(), "config2")
+	})
+}
+
+// barrierConfigurationStore fails to initialize unless all the stores sharing its barrier are initialized at the same time
+type barrierConfigurationStore struct {
+	fakeConfigurationStore
+	barrier *sync.WaitGroup
+}
+
+func (b *barrierConfigurationStore) Init(metadata configuration.Metadata) error {
+	b.barrier.Done()
+	done := make(chan struct{})
+	go func() {
+		b.barrier.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return b.fakeConfigurationStore.Init(metadata)
+	case <-time.After(5 * time.Second):
+		return errors.New("not initialized concurrently")
+	}
+}
+
+func TestInitComponentsConcurrently(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	barrier := &sync.WaitGroup{}
+	barrier.Add(3)
+	rt.configurationRegistry.Register(
+		configuration_loader.New("fake", func() configuration.Store {
+			return &barrierConfigurationStore{barrier: barrier}
+		}))
+	rt.components = []components_v1alpha1.Component{
+		configurationComponent("config1", "host1"),
+		configurationComponent("config2", "host2"),
+		configurationComponent("config3", "host3"),
+	}
+
+	rt.initConfiguration()
+
+	assert.Len(t, rt.compStore.ListConfigurationStores(), 3)
+	assert.NoError(t, rt.componentsInitError())
+}
+
+// flakyConfigurationStore fails its first initialization
+type flakyConfigurationStore struct {
+	closableConfigurationStore
+	attempts int
+}
+
+func (f *flakyConfigurationStore) Init(metadata configuration.Metadata) error {
+	if f.attempts++; f.attempts == 1 {
+		return errors.New("unreachable")
+	}
+	return f.closableConfigurationStore.Init(metadata)
+}
+
+func TestLazyComponentInit(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	store := &flakyConfigurationStore{}
+	rt.configurationRegistry.Register(
+		configuration_loader.New("fake", func() configuration.Store {
+			return store
+		}))
+	c := configurationComponent("config1", "host1")
+	c.Spec.InitOn = components.InitOnLazy
+
+	rt.initConfigurationStore(c)
+
+	t.Run("not initialized at startup", func(t *testing.T) {
+		assert.Contains(t, rt.compStore.ListConfigurationStores(), "config1")
+		assert.Equal(t, 0, store.attempts)
+		assert.Equal(t, components.StatusPending, rt.ComponentStatuses()[0].Status)
+	})
+
+	t.Run("failed init on first use", func(t *testing.T) {
+		_, err := rt.compStore.ListConfigurationStores()["config1"].Get(context.Background(), &configuration.GetRequest{})
+		assert.Error(t, err)
+		assert.Equal(t, components.StatusFailed, rt.ComponentStatuses()[0].Status)
+	})
+
+	t.Run("init retried on next use", func(t *testing.T) {
+		_, err := rt.compStore.ListConfigurationStores()["config1"].Get(context.Background(), &configuration.GetRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, "host1", store.metadata.Properties["host"])
+		assert.Equal(t, components.StatusInitialized, rt.ComponentStatuses()[0].Status)
+
+		_, err = rt.compStore.ListConfigurationStores()["config1"].Get(context.Background(), &configuration.GetRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, store.attempts)
+	})
+
+	t.Run("closed with the component", func(t *testing.T) {
+		rt.closeComponent(c)
+		assert.True(t, store.closed)
+	})
+}
+
+func TestInflightOperations(t *testing.T) {
+	t.Run("wait for the operations in progress", func(t *testing.T) {
+		var o inflightOperations
+		assert.True(t, o.begin())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			o.end()
+		}()
+		assert.True(t, o.wait(time.Second))
+		assert.False(t, o.begin())
+	})
+
+	t.Run("wait is bounded by the timeout", func(t *testing.T) {
+		var o inflightOperations
+		assert.True(t, o.begin())
+		assert.False(t, o.wait(10*time.Millisecond))
+	})
+
+	t.Run("nothing in progress", func(t *testing.T) {
+		var o inflightOperations
+		assert.True(t, o.wait(0))
+	})
+}
+
+// closableInputBinding records whether it is closed
+type closableInputBinding struct {
+	mockBinding
+	closed bool
+}
+
+func (b *closableInputBinding) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestGracefulShutdown(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	rt.runtimeConfig.GracefulShutdownDuration = time.Second
+	binding := &closableInputBinding{}
+	store := &flakyConfigurationStore{}
+	rt.components = []components_v1alpha1.Component{
+		getBindingComponent("in", bindingDirectionInput),
+		configurationComponent("config1", "host1"),
+	}
+	rt.inputBindings["in"] = binding
+	rt.compStore.AddConfigurationStore("config1", store)
+
+	// a message being delivered to the app
+	assert.True(t, rt.inflight.begin())
+	delivered := make(chan struct{})
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(delivered)
+		rt.inflight.end()
+	}()
+
+	rt.Stop()
+
+	t.Run("deliveries in progress complete", func(t *testing.T) {
+		select {
+		case <-delivered:
+		default:
+			assert.Fail(t, "stopped before the delivery in progress completed")
+		}
+	})
+
+	t.Run("new deliveries are rejected", func(t *testing.T) {
+		err := rt.deliverMessage(&pubsub.NewMessage{Topic: "topic1"}, runtime_pubsub.Subscription{Topic: "topic1"}, func(msg *pubsub.NewMessage) error {
+			return nil
+		})
+		assert.Equal(t, errShuttingDown, err)
+	})
+
+	t.Run("components are closed", func(t *testing.T) {
+		assert.True(t, binding.closed)
+		assert.True(t, store.closed)
+		assert.NotContains(t, rt.compStore.ListConfigurationStores(), "config1")
+	})
+}
+
+// unsubscribingPubSub records the topics it unsubscribes from
+type unsubscribingPubSub struct {
+	mockPublishPubSub
+	unsubscribed []string
+}
+
+func (u *unsubscribingPubSub) Unsubscribe(topic string) error {
+	u.unsubscribed = append(u.unsubscribed, topic)
+	return nil
+}
+
+// subscribingPubSub keeps the handler of each topic it subscribes to
+type subscribingPubSub struct {
+	mockPublishPubSub
+	handlers map[string]func(msg *pubsub.NewMessage) error
+}
+
+func (s *subscribingPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	s.handlers[req.Topic] = handler
+	return nil
+}
+
+// closablePubSub can't unsubscribe from a topic, it records whether it is closed
+type closablePubSub struct {
+	mockPublishPubSub
+	closed bool
+}
+
+func (c *closablePubSub) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestShutdownUnsubscribesTopics(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	unsubscribing := &unsubscribingPubSub{}
+	closable := &closablePubSub{}
+	rt.components = []components_v1alpha1.Component{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "pubsub1"}, Spec: components_v1alpha1.ComponentSpec{Type: "pubsub.mockPubSub"}},
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "pubsub2"}, Spec: components_v1alpha1.ComponentSpec{Type: "pubsub.mockPubSub"}},
+	}
+	rt.pubSubs["pubsub1"] = pubSubComponent{pubSub: unsubscribing}
+	rt.pubSubs["pubsub2"] = pubSubComponent{pubSub: closable}
+	rt.addSubscribedTopic(unsubscribing, "topic1")
+	rt.addSubscribedTopic(closable, "topic2")
+
+	rt.stopConsuming()
+	assert.Equal(t, []string{"topic1"}, unsubscribing.unsubscribed)
+	assert.False(t, closable.closed)
+
+	rt.closeSubscribedPubSubs()
+	assert.True(t, closable.closed)
+	assert.NotContains(t, rt.pubSubs, "pubsub2")
+	assert.Contains(t, rt.pubSubs, "pubsub1")
+}
+
+func TestRequestShutdown(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+
+	select {
+	case <-rt.ShutdownRequested():
+		assert.Fail(t, "shutdown requested before the request")
+	default:
+	}
+
+	rt.RequestShutdown()
+	// requesting the shutdown twice doesn't panic
+	rt.RequestShutdown()
+
+	select {
+	case <-rt.ShutdownRequested():
+	default:
+		assert.Fail(t, "shutdown not requested")
+	}
+}
+
+func TestBlockUntilAppIsReady(t *testing.T) {
+	ready := false
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ready" && ready {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer app.Close()
+	port := app.Listener.Addr().(*net.TCPAddr).Port
+
+	t.Run("app listening on its port", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.runtimeConfig.ApplicationPort = port
+		assert.NoError(t, rt.blockUntilAppIsReady())
+	})
+
+	t.Run("app not ready on its ready path", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.runtimeConfig.ApplicationPort = port
+		rt.runtimeConfig.AppReadyPath = "/ready"
+		rt.runtimeConfig.AppReadyTimeout = 200 * time.Millisecond
+		err := rt.blockUntilAppIsReady()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "app is not ready")
+	})
+
+	t.Run("app ready on its ready path", func(t *testing.T) {
+		ready = true
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.runtimeConfig.ApplicationPort = port
+		rt.runtimeConfig.AppReadyPath = "ready"
+		rt.runtimeConfig.AppReadyTimeout = time.Second
+		assert.NoError(t, rt.blockUntilAppIsReady())
+	})
+
+	t.Run("app not listening", func(t *testing.T) {
+		lis, _ := net.Listen("tcp", "localhost:0")
+		unused := lis.Addr().(*net.TCPAddr).Port
+		lis.Close()
+
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.runtimeConfig.ApplicationPort = unused
+		rt.runtimeConfig.AppReadyTimeout = 100 * time.Millisecond
+		assert.Error(t, rt.blockUntilAppIsReady())
+	})
+
+	t.Run("app not listening on its grpc port", func(t *testing.T) {
+		lis, _ := net.Listen("tcp", "localhost:0")
+		unused := lis.Addr().(*net.TCPAddr).Port
+		lis.Close()
+
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.runtimeConfig.ApplicationPort = port
+		rt.runtimeConfig.AppGRPCPort = unused
+		rt.runtimeConfig.AppReadyTimeout = 100 * time.Millisecond
+		err := rt.blockUntilAppIsReady()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("port %v", unused))
+	})
+
+	t.Run("app listening on its unix domain socket", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "dapr-uds")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+		socket := filepath.Join(dir, "app.socket")
+
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.runtimeConfig.ApplicationPort = 0
+		rt.runtimeConfig.AppUnixDomainSocket = socket
+		rt.runtimeConfig.AppReadyTimeout = 100 * time.Millisecond
+		err = rt.blockUntilAppIsReady()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "socket "+socket)
+
+		lis, err := net.Listen("unix", socket)
+		assert.NoError(t, err)
+		defer lis.Close()
+		assert.NoError(t, rt.blockUntilAppIsReady())
+	})
+}
+
+func TestUnixDomainSocketPath(t *testing.T) {
+	rt := NewTestDaprRuntime(modes.StandaloneMode)
+	assert.Equal(t, "", rt.runtimeConfig.unixDomainSocketPath("http"))
+
+	rt.runtimeConfig.UnixDomainSocket = "/tmp"
+	assert.Equal(t, filepath.Join("/tmp", "dapr-http-"+TestRuntimeConfigID+".socket"), rt.runtimeConfig.unixDomainSocketPath("http"))
+	assert.Equal(t, filepath.Join("/tmp", "dapr-grpc-"+TestRuntimeConfigID+".socket"), rt.runtimeConfig.unixDomainSocketPath("grpc"))
+}
+
+func TestActorReentrancy(t *testing.T) {
+	t.Run("reentrancy without the feature", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.appConfig.Reentrancy = config.ReentrancyConfig{Enabled: true}
+		assert.False(t, rt.actorReentrancy().Enabled)
+	})
+
+	t.Run("reentrancy with the feature", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.globalConfig.Spec.Features = []config.FeatureSpec{{Name: config.ActorReentrancy, Enabled: true}}
+		depth := 4
+		rt.appConfig.Reentrancy = config.ReentrancyConfig{Enabled: true, MaxStackDepth: &depth}
+		reentrancy := rt.actorReentrancy()
+		assert.True(t, reentrancy.Enabled)
+		assert.Equal(t, 4, *reentrancy.MaxStackDepth)
+	})
+
+	t.Run("feature without reentrancy", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.globalConfig.Spec.Features = []config.FeatureSpec{{Name: config.ActorReentrancy, Enabled: true}}
+		assert.False(t, rt.actorReentrancy().Enabled)
+	})
+}
+
+func TestGetAuditLogger(t *testing.T) {
+	t.Run("audit log disabled", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		auditLog, err := rt.getAuditLogger()
+		assert.NoError(t, err)
+		assert.Nil(t, auditLog)
+	})
+
+	t.Run("log sink", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.globalConfig.Spec.AuditSpec = config.AuditSpec{Enabled: true}
+		auditLog, err := rt.getAuditLogger()
+		assert.NoError(t, err)
+		assert.NotNil(t, auditLog)
+	})
+
+	t.Run("binding sink without binding name", func(t *testing.T) {
+		rt := NewTestDaprRuntime(modes.StandaloneMode)
+		rt.globalConfig.Spec.AuditSpec = config.AuditSpec{Enabled: true, Sink: "binding"}
+		_, err := rt.getAuditLogger()
+		assert.Error(t, err)
+	})
+}
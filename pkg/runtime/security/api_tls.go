@@ -0,0 +1,83 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package security
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// GetAPITLSConfig returns the TLS config of the public Dapr APIs. The APIs are served with the certificate and key
+// files when they are given, or with the workload certificate signed by sentry otherwise.
+func GetAPITLSConfig(certFile, keyFile string, authenticator Authenticator) (*tls.Config, error) {
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("both the certificate and the key files of the API TLS are required")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the API TLS certificate: %s", err)
+		}
+		return &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		}, nil
+	}
+
+	if authenticator == nil {
+		return nil, errors.New("the API TLS requires certificate and key files, or mTLS to serve the certificate signed by sentry")
+	}
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: NewWorkloadCertificates(authenticator).GetCertificate,
+	}, nil
+}
+
+// WorkloadCertificates serves the current workload certificate signed by sentry in the TLS handshakes. The
+// certificate is rotated before it expires, the new connections use the rotated certificate as soon as it's signed
+// and the connections in progress keep the certificate of their handshake.
+type WorkloadCertificates struct {
+	authenticator Authenticator
+	lock          sync.Mutex
+	signedCert    *SignedCertificate
+	tlsCert       *tls.Certificate
+}
+
+// NewWorkloadCertificates returns the workload certificates of an authenticator
+func NewWorkloadCertificates(authenticator Authenticator) *WorkloadCertificates {
+	return &WorkloadCertificates{authenticator: authenticator}
+}
+
+// GetCertificate returns the current workload certificate for the handshakes of a server
+func (w *WorkloadCertificates) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return w.current()
+}
+
+// GetClientCertificate returns the current workload certificate for the handshakes of a client
+func (w *WorkloadCertificates) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return w.current()
+}
+
+func (w *WorkloadCertificates) current() (*tls.Certificate, error) {
+	signedCert := w.authenticator.GetCurrentSignedCert()
+	if signedCert == nil {
+		return nil, errors.New("the workload certificate is not signed yet")
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if signedCert != w.signedCert {
+		tlsCert, err := tls.X509KeyPair(signedCert.WorkloadCert, signedCert.PrivateKeyPem)
+		if err != nil {
+			return nil, fmt.Errorf("error creating x509 Key Pair: %s", err)
+		}
+		w.signedCert = signedCert
+		w.tlsCert = &tlsCert
+	}
+	return w.tlsCert, nil
+}
@@ -0,0 +1,97 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateTestCert(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: certType, Bytes: certDER}), pem.EncodeToMemory(&pem.Block{Type: ecPKType, Bytes: keyDER})
+}
+
+func TestGetAPITLSConfig(t *testing.T) {
+	certPem, keyPem := generateTestCert(t)
+
+	t.Run("certificate files", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "dapr-api-tls")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+		certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+		assert.NoError(t, ioutil.WriteFile(certFile, certPem, 0600))
+		assert.NoError(t, ioutil.WriteFile(keyFile, keyPem, 0600))
+
+		tlsConfig, err := GetAPITLSConfig(certFile, keyFile, nil)
+		assert.NoError(t, err)
+		assert.Len(t, tlsConfig.Certificates, 1)
+
+		_, err = GetAPITLSConfig(certFile, "", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("workload certificate", func(t *testing.T) {
+		a := getTestAuthenticator()
+		tlsConfig, err := GetAPITLSConfig("", "", a)
+		assert.NoError(t, err)
+
+		_, err = tlsConfig.GetCertificate(nil)
+		assert.Error(t, err)
+
+		a.(*authenticator).currentSignedCert = &SignedCertificate{WorkloadCert: certPem, PrivateKeyPem: keyPem}
+		cert, err := tlsConfig.GetCertificate(nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, cert)
+		again, _ := tlsConfig.GetCertificate(nil)
+		assert.True(t, cert == again)
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		_, err := GetAPITLSConfig("", "", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestWorkloadCertificates(t *testing.T) {
+	a := getTestAuthenticator()
+	w := NewWorkloadCertificates(a)
+
+	certPem, keyPem := generateTestCert(t)
+	a.(*authenticator).currentSignedCert = &SignedCertificate{WorkloadCert: certPem, PrivateKeyPem: keyPem}
+	cert, err := w.GetClientCertificate(nil)
+	assert.NoError(t, err)
+
+	t.Run("rotated certificate", func(t *testing.T) {
+		rotatedPem, rotatedKeyPem := generateTestCert(t)
+		a.(*authenticator).currentSignedCert = &SignedCertificate{WorkloadCert: rotatedPem, PrivateKeyPem: rotatedKeyPem}
+
+		rotated, err := w.GetCertificate(nil)
+		assert.NoError(t, err)
+		assert.NotEqual(t, cert.Certificate[0], rotated.Certificate[0])
+		client, err := w.GetClientCertificate(nil)
+		assert.NoError(t, err)
+		assert.True(t, rotated == client)
+	})
+}
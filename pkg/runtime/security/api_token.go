@@ -0,0 +1,141 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package security
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMaxTokenFailures is the number of invalid API tokens a client can send within the failure window
+	// before it's locked out
+	defaultMaxTokenFailures     = 5
+	defaultTokenFailureWindow   = time.Minute
+	defaultTokenLockoutDuration = time.Minute * 5
+	// maxTrackedTokenClients is the number of clients above which the expired failures are pruned
+	maxTrackedTokenClients = 10000
+)
+
+var (
+	// ErrAPITokenInvalid is returned when the API token of a request is missing or invalid
+	ErrAPITokenInvalid = errors.New("invalid API token")
+	// ErrAPITokenLockedOut is returned when the client is locked out after too many invalid API tokens
+	ErrAPITokenLockedOut = errors.New("too many invalid API tokens")
+)
+
+// APITokenVerifier checks the API tokens of the requests in constant time, and locks out the clients which send too
+// many invalid tokens so the API token can't be brute forced. All the requests of a locked out client are rejected
+// until the lockout expires, without checking their token, so the responses don't tell whether a guess is right.
+// The valid tokens don't reset the failures of a client, which expire with their failure window.
+type APITokenVerifier struct {
+	token   string
+	lockout *tokenLockout
+}
+
+// tokenLockout counts the invalid tokens sent by the clients and locks out the clients which send too many
+type tokenLockout struct {
+	maxFailures     int
+	failureWindow   time.Duration
+	lockoutDuration time.Duration
+	now             func() time.Time
+
+	lock    sync.Mutex
+	clients map[string]*tokenFailures
+}
+
+// tokenFailures are the invalid tokens sent by a client since the start of its failure window
+type tokenFailures struct {
+	count       int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+var (
+	// sharedLockouts are the lockouts of the tokens checked by the runtime, by token, so the invalid tokens a client
+	// sends to the HTTP and gRPC APIs count toward the same lockout, and the lockout of the admin token is its own
+	sharedLockouts     = map[string]*tokenLockout{}
+	sharedLockoutsLock sync.Mutex
+)
+
+// SharedAPITokenVerifier returns a verifier of the token whose clients are locked out along with the clients of the
+// other verifiers of the same token
+func SharedAPITokenVerifier(token string) *APITokenVerifier {
+	sharedLockoutsLock.Lock()
+	defer sharedLockoutsLock.Unlock()
+
+	lockout, ok := sharedLockouts[token]
+	if !ok {
+		lockout = newTokenLockout()
+		sharedLockouts[token] = lockout
+	}
+	return &APITokenVerifier{
+		token:   token,
+		lockout: lockout,
+	}
+}
+
+// NewAPITokenVerifier returns a verifier of the API token with its own lockout
+func NewAPITokenVerifier(token string) *APITokenVerifier {
+	return &APITokenVerifier{
+		token:   token,
+		lockout: newTokenLockout(),
+	}
+}
+
+func newTokenLockout() *tokenLockout {
+	return &tokenLockout{
+		maxFailures:     defaultMaxTokenFailures,
+		failureWindow:   defaultTokenFailureWindow,
+		lockoutDuration: defaultTokenLockoutDuration,
+		now:             time.Now,
+		clients:         map[string]*tokenFailures{},
+	}
+}
+
+// Verify checks the token sent by the client, which is identified by its address. It returns ErrAPITokenLockedOut
+// when the client is locked out, whatever the token, or ErrAPITokenInvalid when the token isn't the API token.
+func (v *APITokenVerifier) Verify(client, token string) error {
+	l := v.lockout
+	now := l.now()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	failures := l.clients[client]
+	if failures != nil && now.Before(failures.lockedUntil) {
+		return ErrAPITokenLockedOut
+	}
+	if IsAPITokenValid(v.token, token) {
+		return nil
+	}
+
+	if failures == nil || now.Sub(failures.windowStart) > l.failureWindow {
+		if failures == nil && len(l.clients) >= maxTrackedTokenClients {
+			l.prune(now)
+		}
+		failures = &tokenFailures{windowStart: now}
+		l.clients[client] = failures
+	}
+	failures.count++
+	if failures.count >= l.maxFailures {
+		log.Warnf("client %s is locked out for %s after %d invalid API tokens", client, l.lockoutDuration, failures.count)
+		failures.lockedUntil = now.Add(l.lockoutDuration)
+		failures.windowStart = failures.lockedUntil
+		failures.count = 0
+	}
+	return ErrAPITokenInvalid
+}
+
+// prune forgets the clients which aren't locked out and whose failure window is over
+func (l *tokenLockout) prune(now time.Time) {
+	for client, failures := range l.clients {
+		if !now.Before(failures.lockedUntil) && now.Sub(failures.windowStart) > l.failureWindow {
+			delete(l.clients, client)
+		}
+	}
+}
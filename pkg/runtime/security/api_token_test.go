@@ -0,0 +1,92 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPITokenVerifier(t *testing.T) {
+	now := time.Now()
+	newVerifier := func() *APITokenVerifier {
+		v := NewAPITokenVerifier("token1")
+		v.lockout.now = func() time.Time { return now }
+		return v
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		v := newVerifier()
+		assert.NoError(t, v.Verify("10.0.0.1", "token1"))
+		assert.Equal(t, ErrAPITokenInvalid, v.Verify("10.0.0.1", "token2"))
+		assert.Equal(t, ErrAPITokenInvalid, v.Verify("10.0.0.1", ""))
+	})
+
+	t.Run("lockout after repeated failures", func(t *testing.T) {
+		v := newVerifier()
+		for i := 0; i < defaultMaxTokenFailures; i++ {
+			assert.Equal(t, ErrAPITokenInvalid, v.Verify("10.0.0.1", "token2"))
+		}
+		assert.Equal(t, ErrAPITokenLockedOut, v.Verify("10.0.0.1", "token2"))
+		// the valid tokens of a locked out client are rejected too, so its guesses can't be told apart
+		assert.Equal(t, ErrAPITokenLockedOut, v.Verify("10.0.0.1", "token1"))
+		// the other clients aren't locked out
+		assert.Equal(t, ErrAPITokenInvalid, v.Verify("10.0.0.2", "token2"))
+		assert.NoError(t, v.Verify("10.0.0.2", "token1"))
+
+		now = now.Add(defaultTokenLockoutDuration)
+		assert.NoError(t, v.Verify("10.0.0.1", "token1"))
+	})
+
+	t.Run("valid token doesn't reset the failures", func(t *testing.T) {
+		v := newVerifier()
+		for i := 0; i < defaultMaxTokenFailures-1; i++ {
+			assert.Equal(t, ErrAPITokenInvalid, v.Verify("10.0.0.1", "token2"))
+			assert.NoError(t, v.Verify("10.0.0.1", "token1"))
+		}
+		assert.Equal(t, ErrAPITokenInvalid, v.Verify("10.0.0.1", "token2"))
+		assert.Equal(t, ErrAPITokenLockedOut, v.Verify("10.0.0.1", "token1"))
+	})
+
+	t.Run("failures expire after the failure window", func(t *testing.T) {
+		v := newVerifier()
+		for i := 0; i < defaultMaxTokenFailures-1; i++ {
+			assert.Equal(t, ErrAPITokenInvalid, v.Verify("10.0.0.1", "token2"))
+		}
+		now = now.Add(defaultTokenFailureWindow + time.Second)
+		assert.Equal(t, ErrAPITokenInvalid, v.Verify("10.0.0.1", "token2"))
+		assert.NoError(t, v.Verify("10.0.0.1", "token1"))
+	})
+
+	t.Run("shared verifiers lock out the clients of the same token", func(t *testing.T) {
+		httpToken := SharedAPITokenVerifier("token1")
+		grpcToken := SharedAPITokenVerifier("token1")
+		adminToken := SharedAPITokenVerifier("admin1")
+		for i := 0; i < defaultMaxTokenFailures; i++ {
+			assert.Equal(t, ErrAPITokenInvalid, httpToken.Verify("10.0.0.3", "token2"))
+		}
+		assert.Equal(t, ErrAPITokenLockedOut, grpcToken.Verify("10.0.0.3", "token1"))
+		assert.NoError(t, grpcToken.Verify("10.0.0.4", "token1"))
+		// the admin token has its own lockout, so the valid calls with the API token can't be used to guess it
+		assert.NoError(t, adminToken.Verify("10.0.0.3", "admin1"))
+		for i := 0; i < defaultMaxTokenFailures; i++ {
+			assert.Equal(t, ErrAPITokenInvalid, adminToken.Verify("10.0.0.5", "admin2"))
+		}
+		assert.Equal(t, ErrAPITokenLockedOut, adminToken.Verify("10.0.0.5", "admin1"))
+		assert.NoError(t, httpToken.Verify("10.0.0.5", "token1"))
+	})
+
+	t.Run("expired failures are pruned", func(t *testing.T) {
+		v := newVerifier()
+		v.lockout.clients["10.0.0.1"] = &tokenFailures{count: 1, windowStart: now.Add(-defaultTokenFailureWindow * 2)}
+		v.lockout.clients["10.0.0.2"] = &tokenFailures{count: 1, windowStart: now}
+		v.lockout.prune(now)
+		assert.NotContains(t, v.lockout.clients, "10.0.0.1")
+		assert.Contains(t, v.lockout.clients, "10.0.0.2")
+	})
+}
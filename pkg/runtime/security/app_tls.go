@@ -0,0 +1,53 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package security
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// GetAppTLSConfig returns the TLS config of the connections to the app. The certificate of the app is verified with
+// the CA file when it's given, or else with the trust anchors of sentry when mTLS is enabled. A CA file is required
+// without mTLS, as the connections would be encrypted without authenticating the app. Dapr presents the certificate
+// and key files to the app when they are given, or the workload certificate signed by sentry when mTLS is enabled,
+// so the app can verify the connections come from its sidecar.
+func GetAppTLSConfig(caFile, certFile, keyFile string, authenticator Authenticator) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile != "" {
+		caPem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the app TLS CA file: %s", err)
+		}
+		tlsConfig.RootCAs, err = CertPool(caPem)
+		if err != nil {
+			return nil, err
+		}
+	} else if authenticator != nil {
+		tlsConfig.RootCAs = authenticator.GetTrustAnchors()
+	} else {
+		return nil, errors.New("the app TLS CA file is required when mTLS is disabled")
+	}
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("both the certificate and the key files of the app TLS are required")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the app TLS certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	} else if authenticator != nil {
+		tlsConfig.GetClientCertificate = NewWorkloadCertificates(authenticator).GetClientCertificate
+	}
+	return tlsConfig, nil
+}
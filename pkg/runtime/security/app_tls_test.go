@@ -0,0 +1,68 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package security
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAppTLSConfig(t *testing.T) {
+	certPem, keyPem := generateTestCert(t)
+	dir, err := ioutil.TempDir("", "dapr-app-tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	caFile, certFile, keyFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	assert.NoError(t, ioutil.WriteFile(caFile, certPem, 0600))
+	assert.NoError(t, ioutil.WriteFile(certFile, certPem, 0600))
+	assert.NoError(t, ioutil.WriteFile(keyFile, keyPem, 0600))
+
+	t.Run("without CA file", func(t *testing.T) {
+		_, err := GetAppTLSConfig("", "", "", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("sentry trust anchors without CA file", func(t *testing.T) {
+		authenticator := getTestAuthenticator()
+		tlsConfig, err := GetAppTLSConfig("", "", "", authenticator)
+		assert.NoError(t, err)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+		assert.Equal(t, authenticator.GetTrustAnchors(), tlsConfig.RootCAs)
+	})
+
+	t.Run("with CA file", func(t *testing.T) {
+		tlsConfig, err := GetAppTLSConfig(caFile, "", "", nil)
+		assert.NoError(t, err)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+		assert.NotNil(t, tlsConfig.RootCAs)
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		_, err := GetAppTLSConfig(filepath.Join(dir, "missing.crt"), "", "", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("client certificate files", func(t *testing.T) {
+		tlsConfig, err := GetAppTLSConfig(caFile, certFile, keyFile, getTestAuthenticator())
+		assert.NoError(t, err)
+		assert.Len(t, tlsConfig.Certificates, 1)
+		assert.Nil(t, tlsConfig.GetClientCertificate)
+
+		_, err = GetAppTLSConfig(caFile, certFile, "", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("workload certificate", func(t *testing.T) {
+		tlsConfig, err := GetAppTLSConfig("", "", "", getTestAuthenticator())
+		assert.NoError(t, err)
+		assert.Len(t, tlsConfig.Certificates, 0)
+		assert.NotNil(t, tlsConfig.GetClientCertificate)
+	})
+}
@@ -4,20 +4,23 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	dapr_credentials "github.com/dapr/dapr/pkg/credentials"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	sentryv1pb "github.com/dapr/dapr/pkg/proto/sentry/v1"
 	"github.com/golang/protobuf/ptypes"
-	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -26,6 +29,10 @@ const (
 	certType          = "CERTIFICATE"
 	kubeTknPath       = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 	sentryMaxRetries  = 100
+	// SentryTokenFileEnvVar is the environment variable holding the path of the token sent to sentry with the CSRs
+	SentryTokenFileEnvVar = "DAPR_SENTRY_TOKEN_FILE"
+	// sentryRetryBackoff is the delay before sending the CSR again, to the next replica of sentry
+	sentryRetryBackoff = time.Millisecond * 100
 )
 
 type Authenticator interface {
@@ -39,7 +46,9 @@ type authenticator struct {
 	certChainPem      []byte
 	keyPem            []byte
 	genCSRFunc        func(id string) ([]byte, []byte, error)
-	sentryAddress     string
+	sentryAddresses   []string
+	sentryIndex       int32
+	signFunc          func(address string, req *sentryv1pb.SignCertificateRequest) (*sentryv1pb.SignCertificateResponse, error)
 	currentSignedCert *SignedCertificate
 	certMutex         *sync.RWMutex
 }
@@ -51,15 +60,25 @@ type SignedCertificate struct {
 	TrustChain    *x509.CertPool
 }
 
+// newAuthenticator returns an authenticator requesting the certificates from the sentry address, or from the comma
+// separated addresses of the replicas of sentry
 func newAuthenticator(sentryAddress string, trustAnchors *x509.CertPool, certChainPem, keyPem []byte, genCSRFunc func(id string) ([]byte, []byte, error)) Authenticator {
-	return &authenticator{
-		trustAnchors:  trustAnchors,
-		certChainPem:  certChainPem,
-		keyPem:        keyPem,
-		genCSRFunc:    genCSRFunc,
-		sentryAddress: sentryAddress,
-		certMutex:     &sync.RWMutex{},
+	addresses := []string{}
+	for _, address := range strings.Split(sentryAddress, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	a := &authenticator{
+		trustAnchors:    trustAnchors,
+		certChainPem:    certChainPem,
+		keyPem:          keyPem,
+		genCSRFunc:      genCSRFunc,
+		sentryAddresses: addresses,
+		certMutex:       &sync.RWMutex{},
 	}
+	a.signFunc = a.signWithSentry
+	return a
 }
 
 // GetTrustAnchors returns the extracted root cert that serves as the trust anchor.
@@ -83,36 +102,11 @@ func (a *authenticator) CreateSignedWorkloadCert(id string) (*SignedCertificate,
 	}
 	certPem := pem.EncodeToMemory(&pem.Block{Type: certType, Bytes: csrb})
 
-	config, err := dapr_credentials.TLSConfigFromCertAndKey(a.certChainPem, a.keyPem, TLSServerName, a.trustAnchors)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create tls config from cert and key: %s", err)
-	}
-
-	unaryClientInterceptor := grpc_retry.UnaryClientInterceptor()
-
-	if diag.DefaultGRPCMonitoring.IsEnabled() {
-		unaryClientInterceptor = grpc_middleware.ChainUnaryClient(
-			unaryClientInterceptor,
-			diag.DefaultGRPCMonitoring.UnaryClientInterceptor(),
-		)
-	}
-
-	conn, err := grpc.Dial(
-		a.sentryAddress,
-		grpc.WithTransportCredentials(credentials.NewTLS(config)),
-		grpc.WithUnaryInterceptor(unaryClientInterceptor))
-	if err != nil {
-		diag.DefaultMonitoring.MTLSWorkLoadCertRotationFailed("sentry_conn")
-		return nil, fmt.Errorf("error establishing connection to sentry: %s", err)
-	}
-	defer conn.Close()
-
-	c := sentryv1pb.NewCAClient(conn)
-	resp, err := c.SignCertificate(context.Background(), &sentryv1pb.SignCertificateRequest{
+	resp, err := a.signCertificate(&sentryv1pb.SignCertificateRequest{
 		CertificateSigningRequest: certPem,
 		Id:                        getSentryIdentifier(id),
 		Token:                     getToken(),
-	}, grpc_retry.WithMax(sentryMaxRetries), grpc_retry.WithPerRetryTimeout(sentrySignTimeout))
+	})
 	if err != nil {
 		diag.DefaultMonitoring.MTLSWorkLoadCertRotationFailed("sign")
 		return nil, fmt.Errorf("error from sentry SignCertificate: %s", err)
@@ -126,6 +120,11 @@ func (a *authenticator) CreateSignedWorkloadCert(id string) (*SignedCertificate,
 		return nil, fmt.Errorf("error parsing ValidUntil: %s", err)
 	}
 
+	if err = validateWorkloadCert(workloadCert, time.Now()); err != nil {
+		diag.DefaultMonitoring.MTLSWorkLoadCertRotationFailed("not_valid")
+		return nil, err
+	}
+
 	trustChain := x509.NewCertPool()
 	for _, c := range resp.GetTrustChainCertificates() {
 		ok := trustChain.AppendCertsFromPEM(c)
@@ -149,9 +148,95 @@ func (a *authenticator) CreateSignedWorkloadCert(id string) (*SignedCertificate,
 	return signedCert, nil
 }
 
-// currently we support Kubernetes identities
+// validateWorkloadCert checks the workload cert is valid on the clock of the host. Sentry signs the certs from the
+// allowed clock skew before it signs them, a cert which isn't valid yet or anymore means that the clock of the host
+// drifts from the clock of sentry more than the allowed clock skew.
+func validateWorkloadCert(workloadCert []byte, now time.Time) error {
+	block, _ := pem.Decode(workloadCert)
+	if block == nil {
+		return errors.New("invalid PEM workload cert")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("error parsing workload cert: %s", err)
+	}
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return fmt.Errorf("the workload cert is valid from %s to %s, the clock of the host drifts from the clock of sentry more than the allowed clock skew", cert.NotBefore, cert.NotAfter)
+	}
+	return nil
+}
+
+// signCertificate sends the CSR to the replicas of sentry, starting with the replica which signed the last
+// certificate. The CSR is sent to the next replica when a replica is unavailable, so the certificates are signed as
+// long as a replica is running.
+func (a *authenticator) signCertificate(req *sentryv1pb.SignCertificateRequest) (*sentryv1pb.SignCertificateResponse, error) {
+	if len(a.sentryAddresses) == 0 {
+		return nil, errors.New("no sentry address")
+	}
+
+	start := int(atomic.LoadInt32(&a.sentryIndex))
+	var err error
+	for attempt := 0; attempt < sentryMaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(sentryRetryBackoff)
+		}
+		index := (start + attempt) % len(a.sentryAddresses)
+		var resp *sentryv1pb.SignCertificateResponse
+		resp, err = a.signFunc(a.sentryAddresses[index], req)
+		if err == nil {
+			atomic.StoreInt32(&a.sentryIndex, int32(index))
+			return resp, nil
+		}
+		if !isSentryUnavailable(err) {
+			return nil, err
+		}
+		log.Warnf("sentry %s unavailable: %s", a.sentryAddresses[index], err)
+	}
+	return nil, err
+}
+
+// signWithSentry sends the CSR to a replica of sentry
+func (a *authenticator) signWithSentry(address string, req *sentryv1pb.SignCertificateRequest) (*sentryv1pb.SignCertificateResponse, error) {
+	config, err := dapr_credentials.TLSConfigFromCertAndKey(a.certChainPem, a.keyPem, TLSServerName, a.trustAnchors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tls config from cert and key: %s", err)
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}
+	if diag.DefaultGRPCMonitoring.IsEnabled() {
+		opts = append(opts, grpc.WithUnaryInterceptor(diag.DefaultGRPCMonitoring.UnaryClientInterceptor()))
+	}
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		diag.DefaultMonitoring.MTLSWorkLoadCertRotationFailed("sentry_conn")
+		return nil, fmt.Errorf("error establishing connection to sentry: %s", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), sentrySignTimeout)
+	defer cancel()
+	return sentryv1pb.NewCAClient(conn).SignCertificate(ctx, req)
+}
+
+// isSentryUnavailable returns true for the errors of a replica of sentry which can't sign the certificate now, the
+// CSR is sent to the next replica
+func isSentryUnavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// getToken returns the token identifying the sidecar to sentry, which is the service account token of the pod in
+// Kubernetes, or the token of the file of DAPR_SENTRY_TOKEN_FILE, such as the OIDC token of a VM or an edge device
 func getToken() string {
-	b, _ := ioutil.ReadFile(kubeTknPath)
+	path := os.Getenv(SentryTokenFileEnvVar)
+	if path == "" {
+		path = kubeTknPath
+	}
+	b, _ := ioutil.ReadFile(path)
 	return string(b)
 }
 
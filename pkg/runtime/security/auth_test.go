@@ -2,9 +2,16 @@ package security
 
 import (
 	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	sentryv1pb "github.com/dapr/dapr/pkg/proto/sentry/v1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func mockGenCSR(id string) ([]byte, []byte, error) {
@@ -27,3 +34,73 @@ func TestGetCurrentSignedCert(t *testing.T) {
 	c := a.GetCurrentSignedCert()
 	assert.NotNil(t, c)
 }
+
+func TestSignCertificateFailover(t *testing.T) {
+	a := newAuthenticator("sentry-a:50001, sentry-b:50001", x509.NewCertPool(), nil, nil, mockGenCSR).(*authenticator)
+	assert.Equal(t, []string{"sentry-a:50001", "sentry-b:50001"}, a.sentryAddresses)
+
+	signed := []string{}
+	a.signFunc = func(address string, req *sentryv1pb.SignCertificateRequest) (*sentryv1pb.SignCertificateResponse, error) {
+		signed = append(signed, address)
+		if address == "sentry-a:50001" {
+			return nil, status.Error(codes.Unavailable, "connection refused")
+		}
+		return &sentryv1pb.SignCertificateResponse{}, nil
+	}
+
+	t.Run("unavailable replica", func(t *testing.T) {
+		_, err := a.signCertificate(&sentryv1pb.SignCertificateRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"sentry-a:50001", "sentry-b:50001"}, signed)
+	})
+
+	t.Run("replica of the last certificate first", func(t *testing.T) {
+		signed = []string{}
+		_, err := a.signCertificate(&sentryv1pb.SignCertificateRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"sentry-b:50001"}, signed)
+	})
+
+	t.Run("rejected csr", func(t *testing.T) {
+		signed = []string{}
+		a.signFunc = func(address string, req *sentryv1pb.SignCertificateRequest) (*sentryv1pb.SignCertificateResponse, error) {
+			signed = append(signed, address)
+			return nil, status.Error(codes.PermissionDenied, "invalid token")
+		}
+		_, err := a.signCertificate(&sentryv1pb.SignCertificateRequest{})
+		assert.Error(t, err)
+		assert.Len(t, signed, 1)
+	})
+}
+
+func TestValidateWorkloadCert(t *testing.T) {
+	certPem, _ := generateTestCert(t)
+
+	t.Run("valid cert", func(t *testing.T) {
+		assert.NoError(t, validateWorkloadCert(certPem, time.Now().Add(time.Minute)))
+	})
+
+	t.Run("clock behind sentry", func(t *testing.T) {
+		assert.Error(t, validateWorkloadCert(certPem, time.Now().Add(-time.Minute)))
+	})
+
+	t.Run("clock ahead of sentry", func(t *testing.T) {
+		assert.Error(t, validateWorkloadCert(certPem, time.Now().Add(time.Hour*2)))
+	})
+
+	t.Run("invalid cert", func(t *testing.T) {
+		assert.Error(t, validateWorkloadCert([]byte("cert"), time.Now()))
+	})
+}
+
+func TestGetToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dapr-sentry-token")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("oidc-token"), 0600))
+
+	os.Setenv(SentryTokenFileEnvVar, path)
+	defer os.Unsetenv(SentryTokenFileEnvVar)
+	assert.Equal(t, "oidc-token", getToken())
+}
@@ -0,0 +1,67 @@
+package security
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const spiffeScheme = "spiffe"
+
+// Identity is the verified identity of a caller, derived from its mTLS workload certificate
+type Identity struct {
+	AppID       string
+	Namespace   string
+	TrustDomain string
+}
+
+// SPIFFEID returns the SPIFFE ID of the identity, or the app id when the certificate of the caller has no SPIFFE ID
+func (i *Identity) SPIFFEID() string {
+	if i.Namespace == "" {
+		return i.AppID
+	}
+	return fmt.Sprintf("%s://%s/ns/%s/%s", spiffeScheme, i.TrustDomain, i.Namespace, i.AppID)
+}
+
+// IdentityFromContext returns the verified identity of the peer of a gRPC call.
+// It returns false if the connection is not authenticated with a client certificate.
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return nil, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	return identityFromCertificate(tlsInfo.State.VerifiedChains[0][0]), true
+}
+
+// identityFromCertificate extracts the identity from a workload certificate.
+// SPIFFE IDs in the form of spiffe://<trust-domain>/ns/<namespace>/<app-id> take
+// precedence, otherwise the app id is read from the certificate subject.
+func identityFromCertificate(cert *x509.Certificate) *Identity {
+	for _, u := range cert.URIs {
+		if u.Scheme != spiffeScheme {
+			continue
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) == 3 && parts[0] == "ns" {
+			return &Identity{
+				AppID:       parts[2],
+				Namespace:   parts[1],
+				TrustDomain: u.Host,
+			}
+		}
+	}
+
+	return &Identity{
+		AppID: cert.Subject.CommonName,
+	}
+}
@@ -0,0 +1,50 @@
+package security
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityFromCertificate(t *testing.T) {
+	t.Run("spiffe id", func(t *testing.T) {
+		u, _ := url.Parse("spiffe://public/ns/default/app1")
+		cert := &x509.Certificate{
+			Subject: pkix.Name{CommonName: "app1"},
+			URIs:    []*url.URL{u},
+		}
+
+		identity := identityFromCertificate(cert)
+		assert.Equal(t, "app1", identity.AppID)
+		assert.Equal(t, "default", identity.Namespace)
+		assert.Equal(t, "public", identity.TrustDomain)
+	})
+
+	t.Run("common name", func(t *testing.T) {
+		cert := &x509.Certificate{
+			Subject: pkix.Name{CommonName: "app1"},
+		}
+
+		identity := identityFromCertificate(cert)
+		assert.Equal(t, "app1", identity.AppID)
+		assert.Empty(t, identity.Namespace)
+		assert.Empty(t, identity.TrustDomain)
+	})
+}
+
+func TestSPIFFEID(t *testing.T) {
+	identity := &Identity{AppID: "app1", Namespace: "default", TrustDomain: "public"}
+	assert.Equal(t, "spiffe://public/ns/default/app1", identity.SPIFFEID())
+
+	identity = &Identity{AppID: "app1"}
+	assert.Equal(t, "app1", identity.SPIFFEID())
+}
+
+func TestIdentityFromContextWithoutPeer(t *testing.T) {
+	_, ok := IdentityFromContext(context.Background())
+	assert.False(t, ok)
+}
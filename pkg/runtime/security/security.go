@@ -2,6 +2,8 @@ package security
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
@@ -17,10 +19,35 @@ import (
 
 const (
 	ecPKType = "EC PRIVATE KEY"
+	// APITokenEnvVar is the environment variable holding the token the Dapr APIs require when it's set
+	APITokenEnvVar = "DAPR_API_TOKEN"
+	// AppAPITokenEnvVar is the environment variable holding the token sent to the app on the calls from Dapr, so the
+	// app can check the calls come from its sidecar
+	AppAPITokenEnvVar = "APP_API_TOKEN"
+	// APITokenHeader is the HTTP header and gRPC metadata key carrying the API token of a request
+	APITokenHeader = "dapr-api-token"
 )
 
 var log = logger.NewLogger("dapr.runtime.security")
 
+// GetAPIToken returns the token the Dapr APIs require, or an empty string when the APIs aren't authenticated
+func GetAPIToken() string {
+	return os.Getenv(APITokenEnvVar)
+}
+
+// GetAppToken returns the token sent to the app, or an empty string when the calls to the app have no token
+func GetAppToken() string {
+	return os.Getenv(AppAPITokenEnvVar)
+}
+
+// IsAPITokenValid checks in constant time that a token is the API token. The digests of the tokens are compared,
+// so the time of the comparison doesn't depend on the length of the API token either.
+func IsAPITokenValid(apiToken, token string) bool {
+	expected := sha256.Sum256([]byte(apiToken))
+	actual := sha256.Sum256([]byte(token))
+	return subtle.ConstantTimeCompare(expected[:], actual[:]) == 1
+}
+
 func CertPool(certPem []byte) (*x509.CertPool, error) {
 	cp := x509.NewCertPool()
 	ok := cp.AppendCertsFromPEM(certPem)
@@ -66,3 +66,14 @@ func TestGenerateSidecarCSR(t *testing.T) {
 		assert.True(t, len(pk) > 0)
 	})
 }
+
+func TestGetAPIToken(t *testing.T) {
+	os.Setenv(APITokenEnvVar, "token1")
+	defer os.Unsetenv(APITokenEnvVar)
+
+	assert.Equal(t, "token1", GetAPIToken())
+	assert.True(t, IsAPITokenValid("token1", "token1"))
+	assert.False(t, IsAPITokenValid("token1", "token2"))
+	assert.False(t, IsAPITokenValid("token1", ""))
+	assert.False(t, IsAPITokenValid("token1", "token10"))
+}
@@ -0,0 +1,257 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package runtime
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	runtime_pubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
+)
+
+// errShuttingDown is returned for the messages and binding events received once the runtime stopped consuming them
+var errShuttingDown = errors.New("dapr is shutting down")
+
+// inflightOperations counts the messages and binding events being delivered to the app. Once closed, no delivery
+// starts and the shutdown waits for the deliveries in progress.
+type inflightOperations struct {
+	lock    sync.Mutex
+	count   int
+	closed  bool
+	drained chan struct{}
+}
+
+// begin records the start of a delivery, it returns false once the operations are closed
+func (o *inflightOperations) begin() bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if o.closed {
+		return false
+	}
+	o.count++
+	return true
+}
+
+// end records the end of a delivery
+func (o *inflightOperations) end() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.count--
+	if o.closed && o.count == 0 {
+		close(o.drained)
+	}
+}
+
+// close stops new deliveries from starting
+func (o *inflightOperations) close() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if o.closed {
+		return
+	}
+	o.closed = true
+	o.drained = make(chan struct{})
+	if o.count == 0 {
+		close(o.drained)
+	}
+}
+
+// wait closes the operations and waits for the deliveries in progress until the timeout. It returns false if
+// deliveries are still in progress.
+func (o *inflightOperations) wait(timeout time.Duration) bool {
+	o.close()
+	select {
+	case <-o.drained:
+		return true
+	default:
+	}
+	select {
+	case <-o.drained:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// subscribedTopic is a topic the runtime subscribed to on a pub/sub component
+type subscribedTopic struct {
+	pubSub pubsub.PubSub
+	topic  string
+}
+
+func (a *DaprRuntime) addSubscribedTopic(ps pubsub.PubSub, topic string) {
+	a.subscribedTopicsLock.Lock()
+	defer a.subscribedTopicsLock.Unlock()
+	a.subscribedTopics = append(a.subscribedTopics, subscribedTopic{pubSub: ps, topic: topic})
+}
+
+// RequestShutdown asks the process running the runtime to stop it, such as when a job-style app is done. The
+// runtime is stopped by the process once ShutdownRequested is closed.
+func (a *DaprRuntime) RequestShutdown() {
+	a.shutdownOnce.Do(func() {
+		log.Info("shutdown requested by the app")
+		close(a.shutdownRequested)
+	})
+}
+
+// ShutdownRequested returns a channel closed once the shutdown of the runtime is requested through the Dapr API
+func (a *DaprRuntime) ShutdownRequested() <-chan struct{} {
+	return a.shutdownRequested
+}
+
+// Stop shuts the runtime down gracefully. The public APIs stop first, then the runtime stops consuming topics and
+// input bindings and waits for the invocations, actor calls and deliveries in progress. The actors are deactivated
+// next, then the audit events and the telemetry are flushed and the components are closed. The waits are bounded by
+// the graceful shutdown duration of the runtime config.
+func (a *DaprRuntime) Stop() {
+	gracePeriod := a.runtimeConfig.GracefulShutdownDuration
+	deadline := time.Now().Add(gracePeriod)
+	log.Infof("dapr shutting down, waiting up to %s for the operations in progress", gracePeriod)
+
+	a.stopPublicAPIs(deadline)
+	a.stopConsuming()
+	a.drainOperations(deadline)
+	a.closeSubscribedPubSubs()
+	if a.actor != nil {
+		log.Info("deactivating actors")
+		a.actor.Stop()
+	}
+	if a.outbox != nil {
+		a.outbox.Stop()
+	}
+	a.auditLog.Close()
+	a.flushTelemetry()
+	a.closeComponents()
+	log.Info("dapr shut down")
+}
+
+// stopPublicAPIs stops the HTTP and gRPC APIs of the app, waiting for the requests in progress
+func (a *DaprRuntime) stopPublicAPIs(deadline time.Time) {
+	var wg sync.WaitGroup
+	if a.httpServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.httpServer.Shutdown(time.Until(deadline)); err != nil {
+				log.Warnf("error stopping the HTTP API: %s", err)
+			}
+		}()
+	}
+	if a.apiGRPCServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.apiGRPCServer.Shutdown(time.Until(deadline))
+		}()
+	}
+	wg.Wait()
+}
+
+// stopConsuming stops the delivery of new messages and binding events to the app, and closes the input bindings so
+// they stop reading events. The pub/sub components keep the messages which are not delivered until they are closed.
+func (a *DaprRuntime) stopConsuming() {
+	a.inflight.close()
+	a.unsubscribeTopics()
+	for _, c := range a.components {
+		if componentCategory(c.Spec.Type) != "bindings" {
+			continue
+		}
+		a.componentsLock.Lock()
+		binding, ok := a.inputBindings[c.ObjectMeta.Name]
+		delete(a.inputBindings, c.ObjectMeta.Name)
+		a.componentsLock.Unlock()
+		if ok {
+			closeComponentInstance(c, binding)
+		}
+	}
+}
+
+// unsubscribeTopics stops the subscriptions of the components which can unsubscribe from a topic, so the pub/sub
+// stops handing messages to the runtime instead of having them rejected
+func (a *DaprRuntime) unsubscribeTopics() {
+	a.subscribedTopicsLock.Lock()
+	defer a.subscribedTopicsLock.Unlock()
+
+	remaining := a.subscribedTopics[:0]
+	for _, s := range a.subscribedTopics {
+		err := runtime_pubsub.Unsubscribe(s.pubSub, s.topic)
+		switch {
+		case err == nil:
+			log.Debugf("unsubscribed from topic %s", s.topic)
+		case errors.Is(err, runtime_pubsub.ErrUnsubscribeNotSupported):
+			remaining = append(remaining, s)
+		default:
+			log.Warnf("error unsubscribing from topic %s: %s", s.topic, err)
+		}
+	}
+	a.subscribedTopics = remaining
+}
+
+// closeSubscribedPubSubs closes the pub/sub components which can't unsubscribe from a topic once the deliveries in
+// progress are drained, which ends their subscriptions before the actors are deactivated
+func (a *DaprRuntime) closeSubscribedPubSubs() {
+	a.subscribedTopicsLock.Lock()
+	subscribed := a.subscribedTopics
+	a.subscribedTopics = nil
+	a.subscribedTopicsLock.Unlock()
+
+	closed := map[pubsub.PubSub]bool{}
+	for _, s := range subscribed {
+		if closed[s.pubSub] {
+			continue
+		}
+		closed[s.pubSub] = true
+		for _, c := range a.components {
+			if componentCategory(c.Spec.Type) != "pubsub" {
+				continue
+			}
+			a.componentsLock.Lock()
+			matches := a.pubSubs[c.ObjectMeta.Name].pubSub == s.pubSub
+			if matches {
+				delete(a.pubSubs, c.ObjectMeta.Name)
+			}
+			a.componentsLock.Unlock()
+			if matches {
+				closeComponentInstance(c, s.pubSub)
+			}
+		}
+	}
+}
+
+// drainOperations waits for the invocations and actor calls of the other Dapr instances, and for the deliveries to
+// the app in progress
+func (a *DaprRuntime) drainOperations(deadline time.Time) {
+	if a.internalGRPCServer != nil {
+		a.internalGRPCServer.Shutdown(time.Until(deadline))
+	}
+	if !a.inflight.wait(time.Until(deadline)) {
+		log.Warn("timed out waiting for the messages and binding events being delivered to the app")
+	}
+}
+
+// flushTelemetry flushes the spans buffered by the exporters which support it
+func (a *DaprRuntime) flushTelemetry() {
+	for _, exporter := range a.exporters {
+		switch e := exporter.(type) {
+		case interface{ Flush() }:
+			e.Flush()
+		case io.Closer:
+			if err := e.Close(); err != nil {
+				log.Warnf("error flushing exporter: %s", err)
+			}
+		}
+	}
+}
+
+// closeComponents closes the components in the reverse order of their declaration
+func (a *DaprRuntime) closeComponents() {
+	for i := len(a.components) - 1; i >= 0; i-- {
+		a.closeComponent(a.components[i])
+	}
+}
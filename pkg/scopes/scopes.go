@@ -19,28 +19,37 @@ func GetScopedTopics(scope, appID string, metadata map[string]string) []string {
 	if val, ok := metadata[scope]; ok && val != "" {
 		apps := strings.Split(val, appsSeperator)
 		for _, a := range apps {
-			appTopics := strings.Split(a, appSeperator)
-			if len(appTopics) == 0 {
+			appTopics := strings.SplitN(a, appSeperator, 2)
+			if len(appTopics) != 2 {
 				continue
 			}
 
-			app := appTopics[0]
+			app := strings.TrimSpace(appTopics[0])
 			if app != appID {
 				continue
 			}
 
-			topics = strings.Split(appTopics[1], topicSeperator)
+			topics = splitTopics(appTopics[1])
 			break
 		}
 	}
 	return topics
 }
 
+// GetAllowedTopics returns the list of topics a Pub/Sub component is restricted to
 func GetAllowedTopics(metadata map[string]string) []string {
 	topics := []string{}
 
 	if val, ok := metadata[AllowedTopics]; ok && val != "" {
-		topics = strings.Split(val, topicSeperator)
+		topics = splitTopics(val)
+	}
+	return topics
+}
+
+func splitTopics(val string) []string {
+	topics := strings.Split(val, topicSeperator)
+	for i := range topics {
+		topics[i] = strings.TrimSpace(topics[i])
 	}
 	return topics
 }
@@ -52,6 +52,16 @@ func TestAllowedTopics(t *testing.T) {
 		assert.Len(t, topics, 0)
 	})
 
+	t.Run("subscriptions: malformed entries are skipped", func(t *testing.T) {
+		topics := GetScopedTopics(SubscriptionScopes, "test", map[string]string{SubscriptionScopes: "test1;test=topic1"})
+		assert.Equal(t, []string{"topic1"}, topics)
+	})
+
+	t.Run("publications: whitespace is trimmed", func(t *testing.T) {
+		topics := GetScopedTopics(PublishingScopes, "test", map[string]string{PublishingScopes: "test1=topic1; test = topic2, topic3"})
+		assert.Equal(t, []string{"topic2", "topic3"}, topics)
+	})
+
 	t.Run("get 2 allowed topics", func(t *testing.T) {
 		topics := GetAllowedTopics(map[string]string{AllowedTopics: "topic1,topic2"})
 		assert.Len(t, topics, 2)
@@ -0,0 +1,97 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dapr/components-contrib/secretstores"
+)
+
+// DefaultParallelism is the number of concurrent secret reads of a bulk get on stores which can't get all their secrets at once
+const DefaultParallelism = 10
+
+// ErrBulkGetNotSupported is returned when all the secrets are requested from a secret store which can't list its secrets
+var ErrBulkGetNotSupported = errors.New("secret store does not support getting all its secrets, the names of the secrets are required")
+
+// Getter is implemented by secret stores which can natively return all their secrets at once
+type Getter interface {
+	BulkGetSecret(req Request) (Response, error)
+}
+
+// Request is a request to get all the secrets of a secret store
+type Request struct {
+	Metadata map[string]string
+}
+
+// Response holds the secrets of a secret store by name. Each secret is a map of string values.
+type Response struct {
+	Data map[string]map[string]string
+}
+
+// Get returns the secrets with the given names, or all the secrets of the store when no names are given.
+// Stores which implement Getter are called once, other stores are called once per name with at most
+// DefaultParallelism concurrent reads. Secrets which don't exist are left out of the response.
+func Get(store secretstores.SecretStore, names []string, metadata map[string]string) (map[string]map[string]string, error) {
+	if getter, ok := store.(Getter); ok {
+		resp, err := getter.BulkGetSecret(Request{Metadata: metadata})
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return resp.Data, nil
+		}
+		secrets := map[string]map[string]string{}
+		for _, name := range names {
+			if secret, ok := resp.Data[name]; ok {
+				secrets[name] = secret
+			}
+		}
+		return secrets, nil
+	}
+	if len(names) == 0 {
+		return nil, ErrBulkGetNotSupported
+	}
+
+	var lock sync.Mutex
+	var firstErr error
+	secrets := map[string]map[string]string{}
+	limit := make(chan struct{}, DefaultParallelism)
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(name string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			resp, err := store.GetSecret(secretstores.GetSecretRequest{
+				Name:     name,
+				Metadata: metadata,
+			})
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed getting secret %s: %s", name, err)
+				}
+				return
+			}
+			if resp.Data != nil {
+				secrets[name] = resp.Data
+			}
+		}(name)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return secrets, nil
+}
@@ -0,0 +1,73 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bulk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSecretStore struct {
+	secrets map[string]map[string]string
+}
+
+func (f *fakeSecretStore) Init(metadata secretstores.Metadata) error {
+	return nil
+}
+
+func (f *fakeSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	if req.Name == "broken" {
+		return secretstores.GetSecretResponse{}, errors.New("access denied")
+	}
+	return secretstores.GetSecretResponse{Data: f.secrets[req.Name]}, nil
+}
+
+type fakeBulkSecretStore struct {
+	fakeSecretStore
+}
+
+func (f *fakeBulkSecretStore) BulkGetSecret(req Request) (Response, error) {
+	return Response{Data: f.secrets}, nil
+}
+
+func TestGet(t *testing.T) {
+	secrets := map[string]map[string]string{
+		"db":    {"password": "1"},
+		"queue": {"key": "2"},
+	}
+
+	t.Run("native bulk get", func(t *testing.T) {
+		store := &fakeBulkSecretStore{fakeSecretStore{secrets: secrets}}
+		resp, err := Get(store, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, secrets, resp)
+
+		resp, err = Get(store, []string{"db", "unknown"}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]map[string]string{"db": {"password": "1"}}, resp)
+	})
+
+	t.Run("secrets are read one by one", func(t *testing.T) {
+		store := &fakeSecretStore{secrets: secrets}
+		resp, err := Get(store, []string{"db", "queue", "unknown"}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, secrets, resp)
+	})
+
+	t.Run("all secrets of a store which can't list them", func(t *testing.T) {
+		_, err := Get(&fakeSecretStore{secrets: secrets}, nil, nil)
+		assert.Equal(t, ErrBulkGetNotSupported, err)
+	})
+
+	t.Run("secret read fails", func(t *testing.T) {
+		_, err := Get(&fakeSecretStore{secrets: secrets}, []string{"db", "broken"}, nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "broken")
+	})
+}
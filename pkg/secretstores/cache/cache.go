@@ -0,0 +1,186 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cache
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/secretstores/bulk"
+)
+
+// TTLMetadataKey is the secret store component metadata key holding how long a secret is served
+// from the cache, as a duration such as "5m". The cache is disabled if it is not set.
+const TTLMetadataKey = "cacheTTL"
+
+// bulkKey is the cache key of the secrets returned by a bulk get, secret names can't contain it
+const bulkKey = "||bulk"
+
+// Flusher is implemented by the secret stores which cache secrets
+type Flusher interface {
+	// Flush drops all the cached secrets, so that the next reads get them from the secret store
+	Flush()
+}
+
+// GetTTL returns the cache TTL set in the secret store component metadata, or zero if the store has no cache
+func GetTTL(metadata map[string]string) (time.Duration, error) {
+	val := metadata[TTLMetadataKey]
+	if val == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(val)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid %s value %q", TTLMetadataKey, val)
+	}
+	return ttl, nil
+}
+
+type entry struct {
+	secrets   map[string]map[string]string
+	expiresAt time.Time
+}
+
+type cachedStore struct {
+	store secretstores.SecretStore
+	ttl   time.Duration
+
+	lock    sync.Mutex
+	entries map[string]entry
+	// generation is incremented by every flush, so that a secret read before
+	// a flush is not cached after it
+	generation uint64
+}
+
+type bulkCachedStore struct {
+	*cachedStore
+	getter bulk.Getter
+}
+
+// NewStore returns a secret store serving the secrets read from the given store from an in-memory
+// cache until their TTL expires. Errors and missing secrets are not cached. The returned store
+// implements Flusher, and gets all the secrets at once if the given store does.
+func NewStore(store secretstores.SecretStore, ttl time.Duration) secretstores.SecretStore {
+	s := &cachedStore{
+		store:   store,
+		ttl:     ttl,
+		entries: map[string]entry{},
+	}
+	if getter, ok := store.(bulk.Getter); ok {
+		return &bulkCachedStore{
+			cachedStore: s,
+			getter:      getter,
+		}
+	}
+	return s
+}
+
+func (s *cachedStore) Init(metadata secretstores.Metadata) error {
+	return s.store.Init(metadata)
+}
+
+// Close closes the given store if it can be closed
+func (s *cachedStore) Close() error {
+	if closer, ok := s.store.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (s *cachedStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	key := cacheKey(req.Name, req.Metadata)
+	secrets, generation, ok := s.get(key)
+	if ok {
+		return secretstores.GetSecretResponse{Data: secrets[req.Name]}, nil
+	}
+
+	resp, err := s.store.GetSecret(req)
+	if err != nil || resp.Data == nil {
+		return resp, err
+	}
+	s.put(key, generation, map[string]map[string]string{req.Name: resp.Data})
+	return resp, nil
+}
+
+// BulkGetSecret caches all the secrets of the store under a single entry
+func (s *bulkCachedStore) BulkGetSecret(req bulk.Request) (bulk.Response, error) {
+	key := cacheKey(bulkKey, req.Metadata)
+	secrets, generation, ok := s.get(key)
+	if ok {
+		return bulk.Response{Data: secrets}, nil
+	}
+
+	resp, err := s.getter.BulkGetSecret(req)
+	if err != nil {
+		return resp, err
+	}
+	s.put(key, generation, resp.Data)
+	return resp, nil
+}
+
+func (s *cachedStore) Flush() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.generation++
+	s.entries = map[string]entry{}
+}
+
+// get returns a copy of the cached secrets of the key if they have not expired, and the generation
+// of the cache which the secrets read from the store on a miss must be put with
+func (s *cachedStore) get(key string) (map[string]map[string]string, uint64, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, s.generation, false
+	}
+	if !time.Now().Before(e.expiresAt) {
+		delete(s.entries, key)
+		return nil, s.generation, false
+	}
+	return copySecrets(e.secrets), s.generation, true
+}
+
+// put caches a copy of the secrets unless the cache was flushed since they were read
+func (s *cachedStore) put(key string, generation uint64, secrets map[string]map[string]string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.generation != generation {
+		return
+	}
+	s.entries[key] = entry{secrets: copySecrets(secrets), expiresAt: time.Now().Add(s.ttl)}
+}
+
+// cacheKey returns the cache key of a read, which includes its metadata as it may select
+// a different version of the secret
+func cacheKey(name string, metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return name
+	}
+	pairs := make([]string, 0, len(metadata))
+	for k, v := range metadata {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return name + "?" + strings.Join(pairs, "&")
+}
+
+// copySecrets copies the secrets, so that the callers modifying them don't modify the cache
+func copySecrets(secrets map[string]map[string]string) map[string]map[string]string {
+	copied := make(map[string]map[string]string, len(secrets))
+	for name, secret := range secrets {
+		values := make(map[string]string, len(secret))
+		for k, v := range secret {
+			values[k] = v
+		}
+		copied[name] = values
+	}
+	return copied
+}
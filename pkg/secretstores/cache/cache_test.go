@@ -0,0 +1,126 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/secretstores/bulk"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	secrets map[string]map[string]string
+	gets    int
+	err     error
+}
+
+func (f *fakeStore) Init(metadata secretstores.Metadata) error {
+	return nil
+}
+
+func (f *fakeStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	f.gets++
+	if f.err != nil {
+		return secretstores.GetSecretResponse{}, f.err
+	}
+	return secretstores.GetSecretResponse{Data: f.secrets[req.Name]}, nil
+}
+
+type fakeBulkStore struct {
+	fakeStore
+}
+
+func (f *fakeBulkStore) BulkGetSecret(req bulk.Request) (bulk.Response, error) {
+	f.gets++
+	return bulk.Response{Data: f.secrets}, nil
+}
+
+func TestGetTTL(t *testing.T) {
+	ttl, err := GetTTL(map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	ttl, err = GetTTL(map[string]string{TTLMetadataKey: "5m"})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, ttl)
+
+	_, err = GetTTL(map[string]string{TTLMetadataKey: "-1s"})
+	assert.Error(t, err)
+}
+
+func TestGetSecret(t *testing.T) {
+	t.Run("secrets are cached until they expire", func(t *testing.T) {
+		inner := &fakeStore{secrets: map[string]map[string]string{"db": {"password": "1"}}}
+		store := NewStore(inner, 50*time.Millisecond)
+
+		for i := 0; i < 2; i++ {
+			resp, err := store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]string{"password": "1"}, resp.Data)
+		}
+		assert.Equal(t, 1, inner.gets)
+
+		time.Sleep(60 * time.Millisecond)
+		inner.secrets["db"]["password"] = "2"
+		resp, _ := store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		assert.Equal(t, map[string]string{"password": "2"}, resp.Data)
+		assert.Equal(t, 2, inner.gets)
+	})
+
+	t.Run("reads with different metadata are cached separately", func(t *testing.T) {
+		inner := &fakeStore{secrets: map[string]map[string]string{"db": {"password": "1"}}}
+		store := NewStore(inner, time.Minute)
+
+		store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		store.GetSecret(secretstores.GetSecretRequest{Name: "db", Metadata: map[string]string{"version": "1"}})
+		store.GetSecret(secretstores.GetSecretRequest{Name: "db", Metadata: map[string]string{"version": "1"}})
+		assert.Equal(t, 2, inner.gets)
+	})
+
+	t.Run("errors and missing secrets are not cached", func(t *testing.T) {
+		inner := &fakeStore{secrets: map[string]map[string]string{}, err: errors.New("unavailable")}
+		store := NewStore(inner, time.Minute)
+
+		_, err := store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		assert.Error(t, err)
+		inner.err = nil
+		store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		assert.Equal(t, 3, inner.gets)
+	})
+
+	t.Run("flush", func(t *testing.T) {
+		inner := &fakeStore{secrets: map[string]map[string]string{"db": {"password": "1"}}}
+		store := NewStore(inner, time.Minute)
+
+		store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		store.(Flusher).Flush()
+		store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		assert.Equal(t, 2, inner.gets)
+	})
+}
+
+func TestBulkGetSecret(t *testing.T) {
+	inner := &fakeBulkStore{fakeStore{secrets: map[string]map[string]string{
+		"db":    {"password": "1"},
+		"queue": {"key": "2"},
+	}}}
+	store := NewStore(inner, time.Minute)
+
+	secrets, err := bulk.Get(store, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, secrets, 2)
+	delete(secrets, "db")
+
+	secrets, err = bulk.Get(store, []string{"db"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"db": {"password": "1"}}, secrets)
+	assert.Equal(t, 1, inner.gets)
+}
@@ -0,0 +1,164 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package watch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	// SecretsMetadataKey is the secret store component metadata key holding the comma separated names
+	// of the secrets the app is notified of the changes of. No secret is watched if it is not set.
+	SecretsMetadataKey = "watchSecrets"
+	// IntervalMetadataKey is the secret store component metadata key holding the interval at which
+	// the watched secrets are read, as a duration such as "30s"
+	IntervalMetadataKey = "watchInterval"
+	// DefaultInterval is the interval at which the watched secrets of stores which don't set one are read
+	DefaultInterval = time.Minute
+)
+
+var log = logger.NewLogger("dapr.runtime.secrets")
+
+// Config is the watch configuration of a secret store
+type Config struct {
+	Secrets  []string
+	Interval time.Duration
+}
+
+// GetConfig returns the watch configuration set in the secret store component metadata,
+// or nil if none of its secrets are watched
+func GetConfig(metadata map[string]string) (*Config, error) {
+	secrets, interval := metadata[SecretsMetadataKey], metadata[IntervalMetadataKey]
+	config := &Config{Interval: DefaultInterval}
+	for _, s := range strings.Split(secrets, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			config.Secrets = append(config.Secrets, s)
+		}
+	}
+	if len(config.Secrets) == 0 {
+		if interval != "" {
+			return nil, fmt.Errorf("%s requires %s", IntervalMetadataKey, SecretsMetadataKey)
+		}
+		return nil, nil
+	}
+	if interval != "" {
+		var err error
+		if config.Interval, err = time.ParseDuration(interval); err != nil || config.Interval <= 0 {
+			return nil, fmt.Errorf("invalid %s value %q", IntervalMetadataKey, interval)
+		}
+	}
+	return config, nil
+}
+
+// Watcher polls the watched secrets of a secret store and notifies the app when their values change
+type Watcher struct {
+	storeName string
+	store     secretstores.SecretStore
+	config    Config
+	notifyFn  func(storeName, name string) error
+
+	lock      sync.Mutex
+	done      chan struct{}
+	closeOnce sync.Once
+	// versions holds the hash of the values of each watched secret the app was last notified of,
+	// an empty version means the secret doesn't exist
+	versions map[string]string
+}
+
+// NewWatcher returns a Watcher of the secrets of the store listed in the config. notifyFn is called
+// with the name of each secret whose values changed, including secrets which were created or deleted.
+// The secrets are read from the given store, which should not cache them.
+func NewWatcher(storeName string, store secretstores.SecretStore, config Config, notifyFn func(storeName, name string) error) *Watcher {
+	return &Watcher{
+		storeName: storeName,
+		store:     store,
+		config:    config,
+		notifyFn:  notifyFn,
+		done:      make(chan struct{}),
+	}
+}
+
+// Start records the current versions of the watched secrets and then polls them at the configured interval
+func (w *Watcher) Start() {
+	w.poll()
+	ticker := time.NewTicker(w.config.Interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				w.poll()
+			case <-w.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops polling the watched secrets
+func (w *Watcher) Stop() {
+	w.closeOnce.Do(func() { close(w.done) })
+}
+
+// poll reads the watched secrets and notifies the app of the changed ones. The first poll only records
+// the versions of the secrets. A secret which can't be read, or whose notification fails, keeps its
+// previous version, so that the change is notified by a later poll.
+func (w *Watcher) poll() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	first := w.versions == nil
+	if first {
+		w.versions = map[string]string{}
+	}
+	for _, name := range w.config.Secrets {
+		resp, err := w.store.GetSecret(secretstores.GetSecretRequest{Name: name})
+		if err != nil {
+			log.Warnf("failed to get watched secret %s from secret store %s: %s", name, w.storeName, err)
+			continue
+		}
+		v := version(resp.Data)
+		previous, known := w.versions[name]
+		if first || !known {
+			w.versions[name] = v
+			continue
+		}
+		if v == previous {
+			continue
+		}
+		if err := w.notifyFn(w.storeName, name); err != nil {
+			log.Warnf("failed to notify the app of the change of secret %s of secret store %s: %s", name, w.storeName, err)
+			continue
+		}
+		w.versions[name] = v
+	}
+}
+
+// version returns a hash of the values of a secret, so that the values aren't kept in memory
+func version(data map[string]string) string {
+	if data == nil {
+		return ""
+	}
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%d:%s%d:%s", len(k), k, len(data[k]), data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
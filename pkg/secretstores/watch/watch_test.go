@@ -0,0 +1,106 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package watch
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	secrets map[string]map[string]string
+	err     error
+}
+
+func (f *fakeStore) Init(metadata secretstores.Metadata) error {
+	return nil
+}
+
+func (f *fakeStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	if f.err != nil {
+		return secretstores.GetSecretResponse{}, f.err
+	}
+	return secretstores.GetSecretResponse{Data: f.secrets[req.Name]}, nil
+}
+
+func TestGetConfig(t *testing.T) {
+	config, err := GetConfig(map[string]string{})
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = GetConfig(map[string]string{SecretsMetadataKey: "db, queue"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{Secrets: []string{"db", "queue"}, Interval: DefaultInterval}, config)
+
+	config, err = GetConfig(map[string]string{SecretsMetadataKey: "db", IntervalMetadataKey: "10s"})
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, config.Interval)
+
+	_, err = GetConfig(map[string]string{IntervalMetadataKey: "10s"})
+	assert.Error(t, err)
+
+	_, err = GetConfig(map[string]string{SecretsMetadataKey: "db", IntervalMetadataKey: "soon"})
+	assert.Error(t, err)
+}
+
+func TestPoll(t *testing.T) {
+	store := &fakeStore{secrets: map[string]map[string]string{
+		"db":    {"password": "1"},
+		"queue": {"key": "1"},
+	}}
+	notified := []string{}
+	var notifyErr error
+	w := NewWatcher("store1", store, Config{Secrets: []string{"db", "queue", "token"}, Interval: time.Minute}, func(storeName, name string) error {
+		assert.Equal(t, "store1", storeName)
+		if notifyErr != nil {
+			return notifyErr
+		}
+		notified = append(notified, name)
+		return nil
+	})
+
+	t.Run("the first poll records the versions", func(t *testing.T) {
+		w.poll()
+		assert.Empty(t, notified)
+	})
+
+	t.Run("unchanged secrets are not notified", func(t *testing.T) {
+		w.poll()
+		assert.Empty(t, notified)
+	})
+
+	t.Run("changed, created and deleted secrets are notified", func(t *testing.T) {
+		store.secrets["db"] = map[string]string{"password": "2"}
+		store.secrets["token"] = map[string]string{"token": "1"}
+		delete(store.secrets, "queue")
+		w.poll()
+		assert.Equal(t, []string{"db", "queue", "token"}, notified)
+	})
+
+	t.Run("read errors are ignored", func(t *testing.T) {
+		notified = []string{}
+		store.err = errors.New("unavailable")
+		w.poll()
+		store.err = nil
+		w.poll()
+		assert.Empty(t, notified)
+	})
+
+	t.Run("failed notifications are retried", func(t *testing.T) {
+		store.secrets["db"] = map[string]string{"password": "3"}
+		notifyErr = errors.New("app unavailable")
+		w.poll()
+		assert.Empty(t, notified)
+
+		notifyErr = nil
+		w.poll()
+		assert.Equal(t, []string{"db"}, notified)
+	})
+}
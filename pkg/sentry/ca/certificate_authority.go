@@ -9,7 +9,9 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -24,6 +26,8 @@ const (
 	caOrg                      = "dapr.io/sentry"
 	caCommonName               = "cluster.local"
 	selfSignedRootCertLifetime = time.Hour * 8760
+	// defaultWorkloadNamespace is the namespace of the SPIFFE ID of the apps without a namespace, such as self-hosted apps
+	defaultWorkloadNamespace = "default"
 )
 
 var log = logger.NewLogger("dapr.sentry.ca")
@@ -35,6 +39,7 @@ type CertificateAuthority interface {
 	LoadOrStoreTrustBundle() error
 	GetCACertBundle() TrustRootBundler
 	SignCSR(csrPem []byte, subject string, ttl time.Duration, isCA bool) (*SignedCertificate, error)
+	SignWorkloadCSR(csrPem []byte, appID, namespace string) (*SignedCertificate, error)
 	ValidateCSR(csr *x509.CertificateRequest) error
 }
 
@@ -82,6 +87,27 @@ func (c *defaultCA) GetCACertBundle() TrustRootBundler {
 // If isCA is set to true, a CA cert will be issued. If isCA is set to false, a workload
 // Certificate will be issued instead.
 func (c *defaultCA) SignCSR(csrPem []byte, subject string, ttl time.Duration, isCA bool) (*SignedCertificate, error) {
+	return c.signCSR(csrPem, subject, ttl, isCA, nil)
+}
+
+// SignWorkloadCSR signs the CSR of the sidecar of an app with the workload cert TTL. The certificate holds the SPIFFE
+// ID spiffe://<trust-domain>/ns/<namespace>/<app-id>, which identifies the app, its namespace and trust domain to
+// the apps it calls and to SPIFFE compliant meshes. The apps without a namespace are in the default namespace.
+func (c *defaultCA) SignWorkloadCSR(csrPem []byte, appID, namespace string) (*SignedCertificate, error) {
+	if namespace == "" {
+		namespace = defaultWorkloadNamespace
+	}
+	uris := []*url.URL{
+		{
+			Scheme: "spiffe",
+			Host:   c.config.TrustDomain,
+			Path:   fmt.Sprintf("/ns/%s/%s", namespace, appID),
+		},
+	}
+	return c.signCSR(csrPem, appID, -1, false, uris)
+}
+
+func (c *defaultCA) signCSR(csrPem []byte, subject string, ttl time.Duration, isCA bool, uris []*url.URL) (*SignedCertificate, error) {
 	c.issuerLock.RLock()
 	defer c.issuerLock.RUnlock()
 
@@ -95,12 +121,24 @@ func (c *defaultCA) SignCSR(csrPem []byte, subject string, ttl time.Duration, is
 	signingCert := c.bundle.issuerCreds.Certificate
 	signingKey := c.bundle.issuerCreds.PrivateKey
 
+	// the certificates issued by an external CA can't outlive its issuer, which is usually short lived and rotated
+	// by the enterprise PKI
+	if c.config.ExternalCA {
+		untilIssuerExpiry := time.Until(signingCert.NotAfter)
+		if untilIssuerExpiry <= 0 {
+			return nil, fmt.Errorf("error signing csr: the issuer certificate expired at %s", signingCert.NotAfter)
+		}
+		if certLifetime > untilIssuerExpiry {
+			certLifetime = untilIssuerExpiry
+		}
+	}
+
 	cert, err := certs.ParsePemCSR(csrPem)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing csr pem: %s", err)
 	}
 
-	crtb, err := csr.GenerateCSRCertificate(cert, subject, signingCert, cert.PublicKey, signingKey.Key, certLifetime, isCA)
+	crtb, err := csr.GenerateCSRCertificate(cert, subject, signingCert, cert.PublicKey, signingKey.Key, certLifetime, isCA, uris, c.config.AllowedClockSkew)
 	if err != nil {
 		return nil, fmt.Errorf("error signing csr: %s", err)
 	}
@@ -165,6 +203,16 @@ func (c *defaultCA) validateAndBuildTrustBundle() (*trustRootBundle, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error reading issuer cert from disk: %s", err)
 		}
+
+		if c.config.ExternalCA {
+			if err = validateExternalCA(rootCertBytes, issuerCertBytes, time.Now()); err != nil {
+				return nil, fmt.Errorf("invalid external CA: %s", err)
+			}
+			log.Info("external CA loaded")
+		}
+	} else if c.config.ExternalCA {
+		// the credentials of an external CA are provided by the operator, they are never generated
+		return nil, fmt.Errorf("root and issuer certs of the external CA not found in %s", filepath.Dir(c.config.IssuerCertPath))
 	} else {
 		// create self signed root and issuer certs
 		log.Info("root and issuer certs not found: generating self signed CA")
@@ -192,6 +240,58 @@ func (c *defaultCA) validateAndBuildTrustBundle() (*trustRootBundle, error) {
 	}, nil
 }
 
+// validateExternalCA checks the root and issuer certs provided by an enterprise PKI. The root certs must be CAs, and
+// the issuer cert must be a valid CA which chains to the root certs, through the intermediate certs which follow it
+// in the issuer PEM.
+func validateExternalCA(rootCertPem, issuerCertPem []byte, now time.Time) error {
+	roots, err := certs.DecodePEMCertificates(rootCertPem)
+	if err != nil {
+		return fmt.Errorf("error parsing root certs: %s", err)
+	}
+	if len(roots) == 0 {
+		return errors.New("no root certs found")
+	}
+	for _, root := range roots {
+		if !root.IsCA {
+			return fmt.Errorf("root cert %q is not a CA", root.Subject.CommonName)
+		}
+	}
+
+	chain, err := certs.DecodePEMCertificates(issuerCertPem)
+	if err != nil {
+		return fmt.Errorf("error parsing issuer certs: %s", err)
+	}
+	if len(chain) == 0 {
+		return errors.New("no issuer cert found")
+	}
+	issuer := chain[0]
+	if !issuer.IsCA || issuer.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return fmt.Errorf("issuer cert %q is not allowed to sign certificates", issuer.Subject.CommonName)
+	}
+	if now.Before(issuer.NotBefore) || now.After(issuer.NotAfter) {
+		return fmt.Errorf("issuer cert %q is only valid from %s to %s", issuer.Subject.CommonName, issuer.NotBefore, issuer.NotAfter)
+	}
+
+	rootPool := x509.NewCertPool()
+	for _, root := range roots {
+		rootPool.AddCert(root)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = issuer.Verify(x509.VerifyOptions{
+		Roots:         rootPool,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("issuer cert %q doesn't chain to the root certs: %s", issuer.Subject.CommonName, err)
+	}
+	return nil
+}
+
 // GenerateSidecarCertificate generates a keypair and returns a new certificate
 func (c *defaultCA) GenerateSidecarCertificate(subject string) (*certs.Credentials, error) {
 	pk, err := certs.GenerateECPrivateKey()
@@ -273,22 +373,16 @@ func (c *defaultCA) generateRootAndIssuerCerts() (*certs.Credentials, []byte, []
 	}
 	issuerKeyPem := pem.EncodeToMemory(&pem.Block{Type: certs.ECPrivateKey, Bytes: encodedKey})
 
-	issuerCert, err := x509.ParseCertificate(issuerCertBytes)
+	// store credentials so that next time sentry restarts it'll load normally. The other replicas of sentry may have
+	// stored their credentials first, the stored credentials are used so all the replicas issue with the same issuer.
+	rootCertPem, issuerCertPem, issuerKeyPem, err = certs.StoreCredentials(c.config, rootCertPem, issuerCertPem, issuerKeyPem)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	// store credentials so that next time sentry restarts it'll load normally
-	err = certs.StoreCredentials(c.config, rootCertPem, issuerCertPem, issuerKeyPem)
+	issuerCreds, err := certs.PEMCredentialsFromFiles(issuerCertPem, issuerKeyPem)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, fmt.Errorf("error reading PEM credentials: %s", err)
 	}
-
-	return &certs.Credentials{
-		PrivateKey: &certs.PrivateKey{
-			Type: certs.ECPrivateKey,
-			Key:  issuerKey,
-		},
-		Certificate: issuerCert,
-	}, rootCertPem, issuerCertPem, nil
+	return issuerCreds, rootCertPem, issuerCertPem, nil
 }
@@ -9,12 +9,14 @@ import (
 	"encoding/pem"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/dapr/dapr/pkg/sentry/certs"
 	"github.com/dapr/dapr/pkg/sentry/config"
+	"github.com/dapr/dapr/pkg/sentry/csr"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -134,3 +136,135 @@ func TestSignCSR(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestSignWorkloadCSR(t *testing.T) {
+	writeTestCredentialsToDisk()
+	defer cleanupCredentials()
+
+	csr := getTestCSR("app1")
+	pk, _ := getECDSAPrivateKey()
+	csrb, _ := x509.CreateCertificateRequest(rand.Reader, csr, pk)
+	certPem := pem.EncodeToMemory(&pem.Block{Type: certs.Certificate, Bytes: csrb})
+
+	certAuth := getTestCertAuth()
+	certAuth.LoadOrStoreTrustBundle()
+
+	t.Run("app of a namespace", func(t *testing.T) {
+		resp, err := certAuth.SignWorkloadCSR(certPem, "app1", "ns1")
+		assert.Nil(t, err)
+		assert.Equal(t, "app1", resp.Certificate.Subject.CommonName)
+		assert.Len(t, resp.Certificate.URIs, 1)
+		assert.Equal(t, "spiffe://"+certAuth.GetCACertBundle().GetTrustDomain()+"/ns/ns1/app1", resp.Certificate.URIs[0].String())
+	})
+
+	t.Run("app without a namespace", func(t *testing.T) {
+		resp, err := certAuth.SignWorkloadCSR(certPem, "app1", "")
+		assert.Nil(t, err)
+		assert.Len(t, resp.Certificate.URIs, 1)
+		assert.Equal(t, "spiffe://"+certAuth.GetCACertBundle().GetTrustDomain()+"/ns/default/app1", resp.Certificate.URIs[0].String())
+	})
+
+	t.Run("valid from the allowed clock skew before now", func(t *testing.T) {
+		resp, err := certAuth.SignWorkloadCSR(certPem, "app1", "ns1")
+		assert.Nil(t, err)
+		assert.WithinDuration(t, time.Now().Add(-certAuth.(*defaultCA).config.AllowedClockSkew), resp.Certificate.NotBefore, time.Minute)
+	})
+}
+
+// generateTestCACert returns a CA cert signed by the parent cert, or a self signed root cert without a parent
+func generateTestCACert(t *testing.T, cn string, ttl time.Duration, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	key, err := getECDSAPrivateKey()
+	assert.NoError(t, err)
+	template, err := csr.GenerateIssuerCertCSR(cn, &key.PublicKey, ttl)
+	assert.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	assert.NoError(t, err)
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: certs.Certificate, Bytes: certBytes})
+}
+
+func TestValidateExternalCA(t *testing.T) {
+	root, rootKey, rootPem := generateTestCACert(t, "root", time.Hour*24, nil, nil)
+	intermediate, intermediateKey, intermediatePem := generateTestCACert(t, "intermediate", time.Hour*12, root, rootKey)
+	_, _, issuerPem := generateTestCACert(t, "issuer", time.Hour, intermediate, intermediateKey)
+	_, _, otherRootPem := generateTestCACert(t, "other root", time.Hour*24, nil, nil)
+
+	t.Run("issuer signed by the root", func(t *testing.T) {
+		_, _, rootIssuerPem := generateTestCACert(t, "issuer", time.Hour, root, rootKey)
+		assert.NoError(t, validateExternalCA(rootPem, rootIssuerPem, time.Now()))
+	})
+
+	t.Run("issuer followed by its intermediate", func(t *testing.T) {
+		chain := append(append([]byte{}, issuerPem...), intermediatePem...)
+		assert.NoError(t, validateExternalCA(rootPem, chain, time.Now()))
+	})
+
+	t.Run("missing intermediate", func(t *testing.T) {
+		assert.Error(t, validateExternalCA(rootPem, issuerPem, time.Now()))
+	})
+
+	t.Run("issuer of another root", func(t *testing.T) {
+		chain := append(append([]byte{}, issuerPem...), intermediatePem...)
+		assert.Error(t, validateExternalCA(otherRootPem, chain, time.Now()))
+	})
+
+	t.Run("expired issuer", func(t *testing.T) {
+		chain := append(append([]byte{}, issuerPem...), intermediatePem...)
+		assert.Error(t, validateExternalCA(rootPem, chain, time.Now().Add(time.Hour*2)))
+	})
+
+	t.Run("no root certs", func(t *testing.T) {
+		assert.Error(t, validateExternalCA([]byte{}, issuerPem, time.Now()))
+	})
+}
+
+func TestExternalCA(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sentry-external-ca")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf, _ := config.FromConfigName("")
+	conf.RootCertPath = filepath.Join(dir, "ca.crt")
+	conf.IssuerCertPath = filepath.Join(dir, "tls.crt")
+	conf.IssuerKeyPath = filepath.Join(dir, "tls.key")
+	conf.ExternalCA = true
+
+	t.Run("credentials not found", func(t *testing.T) {
+		certAuth, _ := NewCertificateAuthority(conf)
+		assert.Error(t, certAuth.LoadOrStoreTrustBundle())
+		_, err := os.Stat(conf.RootCertPath)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("workload certs don't outlive the issuer", func(t *testing.T) {
+		root, rootKey, rootPem := generateTestCACert(t, "root", time.Hour*24, nil, nil)
+		issuer, issuerKey, issuerPem := generateTestCACert(t, "issuer", time.Hour, root, rootKey)
+		keyBytes, err := x509.MarshalECPrivateKey(issuerKey)
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(conf.RootCertPath, rootPem, 0600))
+		assert.NoError(t, ioutil.WriteFile(conf.IssuerCertPath, issuerPem, 0600))
+		assert.NoError(t, ioutil.WriteFile(conf.IssuerKeyPath, pem.EncodeToMemory(&pem.Block{Type: certs.ECPrivateKey, Bytes: keyBytes}), 0600))
+
+		certAuth, _ := NewCertificateAuthority(conf)
+		assert.NoError(t, certAuth.LoadOrStoreTrustBundle())
+
+		pk, _ := getECDSAPrivateKey()
+		csrb, _ := x509.CreateCertificateRequest(rand.Reader, getTestCSR("app1"), pk)
+		csrPem := pem.EncodeToMemory(&pem.Block{Type: certs.Certificate, Bytes: csrb})
+		resp, err := certAuth.SignWorkloadCSR(csrPem, "app1", "ns1")
+		assert.NoError(t, err)
+		assert.False(t, resp.Certificate.NotAfter.After(issuer.NotAfter))
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		_, _, otherRootPem := generateTestCACert(t, "other root", time.Hour*24, nil, nil)
+		assert.NoError(t, ioutil.WriteFile(conf.RootCertPath, otherRootPem, 0600))
+
+		certAuth, _ := NewCertificateAuthority(conf)
+		assert.Error(t, certAuth.LoadOrStoreTrustBundle())
+	})
+}
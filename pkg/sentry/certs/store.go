@@ -6,54 +6,78 @@ import (
 	"os"
 
 	"github.com/dapr/dapr/pkg/credentials"
+	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/sentry/config"
 	"github.com/dapr/dapr/pkg/sentry/kubernetes"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
 )
 
 const (
 	defaultSecretNamespace = "default"
 )
 
-// StoreCredentials saves the trust bundle in a Kubernetes secret store or locally on disk, depending on the hosting platform
-func StoreCredentials(conf config.SentryConfig, rootCertPem, issuerCertPem, issuerKeyPem []byte) error {
+var log = logger.NewLogger("dapr.sentry.certs")
+
+// StoreCredentials saves the trust bundle in a Kubernetes secret store or locally on disk, depending on the hosting platform.
+// It returns the stored trust bundle: in Kubernetes, the replicas of sentry share the trust bundle of the secret, so the
+// trust bundle of the first replica storing its credentials is kept and returned to the other replicas.
+func StoreCredentials(conf config.SentryConfig, rootCertPem, issuerCertPem, issuerKeyPem []byte) ([]byte, []byte, []byte, error) {
 	if config.IsKubernetesHosted() {
-		return storeKubernetes(rootCertPem, issuerCertPem, issuerKeyPem)
-	}
-	return storeSelfhosted(rootCertPem, issuerCertPem, issuerKeyPem, conf.RootCertPath, conf.IssuerCertPath, conf.IssuerKeyPath)
-}
+		namespace := os.Getenv("NAMESPACE")
+		if namespace == "" {
+			namespace = defaultSecretNamespace
+		}
 
-func storeKubernetes(rootCertPem, issuerCertPem, issuerCertKey []byte) error {
-	namespace := os.Getenv("NAMESPACE")
-	if namespace == "" {
-		namespace = defaultSecretNamespace
+		kubeClient, err := kubernetes.GetClient()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return storeKubernetes(kubeClient, namespace, rootCertPem, issuerCertPem, issuerKeyPem)
 	}
+	err := storeSelfhosted(rootCertPem, issuerCertPem, issuerKeyPem, conf.RootCertPath, conf.IssuerCertPath, conf.IssuerKeyPath)
+	return rootCertPem, issuerCertPem, issuerKeyPem, err
+}
 
-	kubeClient, err := kubernetes.GetClient()
+func storeKubernetes(kubeClient k8s.Interface, namespace string, rootCertPem, issuerCertPem, issuerCertKey []byte) ([]byte, []byte, []byte, error) {
+	// sentry expects a secret to already exist
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(KubeScrtName, metav1.GetOptions{})
 	if err != nil {
-		return err
+		return nil, nil, nil, fmt.Errorf("failed getting secret from kubernetes: %s", err)
+	}
+	if hasCredentials(secret) {
+		log.Info("trust bundle already stored by another sentry replica")
+		return secret.Data[credentials.RootCertFilename], secret.Data[credentials.IssuerCertFilename], secret.Data[credentials.IssuerKeyFilename], nil
 	}
 
-	secret := &v1.Secret{
-		Data: map[string][]byte{
-			credentials.RootCertFilename:   rootCertPem,
-			credentials.IssuerCertFilename: issuerCertPem,
-			credentials.IssuerKeyFilename:  issuerCertKey,
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      KubeScrtName,
-			Namespace: namespace,
-		},
-		Type: v1.SecretTypeOpaque,
+	secret.Data = map[string][]byte{
+		credentials.RootCertFilename:   rootCertPem,
+		credentials.IssuerCertFilename: issuerCertPem,
+		credentials.IssuerKeyFilename:  issuerCertKey,
 	}
+	secret.Type = v1.SecretTypeOpaque
 
-	// We update and not create because sentry expects a secret to already exist
+	// the update fails with a conflict when another replica stored its trust bundle since the secret was read
 	_, err = kubeClient.CoreV1().Secrets(namespace).Update(secret)
+	if errors.IsConflict(err) {
+		secret, err = kubeClient.CoreV1().Secrets(namespace).Get(KubeScrtName, metav1.GetOptions{})
+		if err == nil && hasCredentials(secret) {
+			log.Info("trust bundle stored concurrently by another sentry replica")
+			return secret.Data[credentials.RootCertFilename], secret.Data[credentials.IssuerCertFilename], secret.Data[credentials.IssuerKeyFilename], nil
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("failed saving secret to kubernetes: %s", err)
+		return nil, nil, nil, fmt.Errorf("failed saving secret to kubernetes: %s", err)
 	}
-	return nil
+	return rootCertPem, issuerCertPem, issuerCertKey, nil
+}
+
+func hasCredentials(secret *v1.Secret) bool {
+	return len(secret.Data[credentials.RootCertFilename]) > 0 &&
+		len(secret.Data[credentials.IssuerCertFilename]) > 0 &&
+		len(secret.Data[credentials.IssuerKeyFilename]) > 0
 }
 
 func storeSelfhosted(rootCertPem, issuerCertPem, issuerKeyPem []byte, rootCertPath, issuerCertPath, issuerKeyPath string) error {
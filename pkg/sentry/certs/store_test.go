@@ -0,0 +1,76 @@
+package certs
+
+import (
+	"testing"
+
+	"github.com/dapr/dapr/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fake "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+func getTestSecret(data map[string][]byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: KubeScrtName, Namespace: "dapr-system"},
+		Data:       data,
+	}
+}
+
+func getTestCredentials(name string) map[string][]byte {
+	return map[string][]byte{
+		credentials.RootCertFilename:   []byte(name + "-root"),
+		credentials.IssuerCertFilename: []byte(name + "-issuer"),
+		credentials.IssuerKeyFilename:  []byte(name + "-key"),
+	}
+}
+
+func TestStoreKubernetes(t *testing.T) {
+	t.Run("first replica", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset(getTestSecret(nil))
+
+		root, issuer, key, err := storeKubernetes(kubeClient, "dapr-system", []byte("a-root"), []byte("a-issuer"), []byte("a-key"))
+		assert.NoError(t, err)
+		assert.Equal(t, "a-root", string(root))
+		assert.Equal(t, "a-issuer", string(issuer))
+		assert.Equal(t, "a-key", string(key))
+
+		secret, _ := kubeClient.CoreV1().Secrets("dapr-system").Get(KubeScrtName, metav1.GetOptions{})
+		assert.Equal(t, getTestCredentials("a"), secret.Data)
+	})
+
+	t.Run("credentials stored by another replica", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset(getTestSecret(getTestCredentials("b")))
+
+		root, issuer, key, err := storeKubernetes(kubeClient, "dapr-system", []byte("a-root"), []byte("a-issuer"), []byte("a-key"))
+		assert.NoError(t, err)
+		assert.Equal(t, "b-root", string(root))
+		assert.Equal(t, "b-issuer", string(issuer))
+		assert.Equal(t, "b-key", string(key))
+	})
+
+	t.Run("credentials stored concurrently by another replica", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset(getTestSecret(nil))
+		kubeClient.PrependReactor("update", "secrets", func(action core.Action) (bool, runtime.Object, error) {
+			// another replica stores its credentials between the read and the update of the secret
+			err := kubeClient.Tracker().Update(v1.SchemeGroupVersion.WithResource("secrets"), getTestSecret(getTestCredentials("b")), "dapr-system")
+			assert.NoError(t, err)
+			return true, nil, errors.NewConflict(schema.GroupResource{Resource: "secrets"}, KubeScrtName, nil)
+		})
+
+		root, _, _, err := storeKubernetes(kubeClient, "dapr-system", []byte("a-root"), []byte("a-issuer"), []byte("a-key"))
+		assert.NoError(t, err)
+		assert.Equal(t, "b-root", string(root))
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset()
+
+		_, _, _, err := storeKubernetes(kubeClient, "dapr-system", []byte("a-root"), []byte("a-issuer"), []byte("a-key"))
+		assert.Error(t, err)
+	})
+}
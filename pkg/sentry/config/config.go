@@ -22,6 +22,13 @@ const (
 	defaultWorkloadCertTTL      = time.Hour * 24
 	defaultAllowedClockSkew     = time.Minute * 15
 	defaultConfigName           = "default"
+
+	// KubernetesValidator validates the CSR tokens as the service account tokens of the pods
+	KubernetesValidator = "kubernetes"
+	// JWTValidator validates the CSR tokens as the JWT tokens of an OIDC issuer
+	JWTValidator = "jwt"
+	// InsecureValidator doesn't validate the CSR tokens, for development
+	InsecureValidator = "insecure"
 )
 
 var log = logger.NewLogger("dapr.sentry.config")
@@ -36,6 +43,15 @@ type SentryConfig struct {
 	RootCertPath     string
 	IssuerCertPath   string
 	IssuerKeyPath    string
+	// ExternalCA loads the root and issuer certs of an enterprise PKI, which are validated and never generated
+	ExternalCA bool
+	// TokenValidator validates the tokens of the CSRs, it's the Kubernetes validator in Kubernetes and the insecure
+	// validator otherwise when it's empty
+	TokenValidator string
+	// JWTIssuer, JWTAudience and JWKSURL configure the JWT validator
+	JWTIssuer   string
+	JWTAudience string
+	JWKSURL     string
 }
 
 var configGetters = map[string]func(string) (SentryConfig, error){
@@ -134,20 +150,32 @@ func getSelfhostedConfig(configName string) (SentryConfig, error) {
 }
 
 func parseConfiguration(conf SentryConfig, daprConfig *dapr_config.Configuration) (SentryConfig, error) {
-	if daprConfig.Spec.MTLSSpec.WorkloadCertTTL != "" {
-		d, err := time.ParseDuration(daprConfig.Spec.MTLSSpec.WorkloadCertTTL)
+	return WithCertificateDurations(conf, daprConfig.Spec.MTLSSpec.WorkloadCertTTL, daprConfig.Spec.MTLSSpec.AllowedClockSkew)
+}
+
+// WithCertificateDurations returns the configuration with the workload cert TTL and the allowed clock skew of the
+// durations which are set. The TTL must be positive and the clock skew can't be negative.
+func WithCertificateDurations(conf SentryConfig, workloadCertTTL, allowedClockSkew string) (SentryConfig, error) {
+	if workloadCertTTL != "" {
+		d, err := time.ParseDuration(workloadCertTTL)
 		if err != nil {
 			return conf, fmt.Errorf("error parsing WorkloadCertTTL duration: %s", err)
 		}
+		if d <= 0 {
+			return conf, fmt.Errorf("WorkloadCertTTL must be positive: %s", workloadCertTTL)
+		}
 
 		conf.WorkloadCertTTL = d
 	}
 
-	if daprConfig.Spec.MTLSSpec.AllowedClockSkew != "" {
-		d, err := time.ParseDuration(daprConfig.Spec.MTLSSpec.AllowedClockSkew)
+	if allowedClockSkew != "" {
+		d, err := time.ParseDuration(allowedClockSkew)
 		if err != nil {
 			return conf, fmt.Errorf("error parsing AllowedClockSkew duration: %s", err)
 		}
+		if d < 0 {
+			return conf, fmt.Errorf("AllowedClockSkew can't be negative: %s", allowedClockSkew)
+		}
 
 		conf.AllowedClockSkew = d
 	}
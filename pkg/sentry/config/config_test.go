@@ -39,4 +39,20 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, "5s", conf.WorkloadCertTTL.String())
 		assert.Equal(t, "1h0m0s", conf.AllowedClockSkew.String())
 	})
+
+	t.Run("certificate durations", func(t *testing.T) {
+		conf, err := WithCertificateDurations(getDefaultConfig(), "1h", "0s")
+		assert.Nil(t, err)
+		assert.Equal(t, "1h0m0s", conf.WorkloadCertTTL.String())
+		assert.Equal(t, "0s", conf.AllowedClockSkew.String())
+
+		conf, err = WithCertificateDurations(getDefaultConfig(), "", "")
+		assert.Nil(t, err)
+		assert.Equal(t, getDefaultConfig(), conf)
+
+		_, err = WithCertificateDurations(getDefaultConfig(), "0s", "")
+		assert.NotNil(t, err)
+		_, err = WithCertificateDurations(getDefaultConfig(), "", "-1m")
+		assert.NotNil(t, err)
+	})
 }
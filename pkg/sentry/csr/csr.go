@@ -9,6 +9,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
+	"net/url"
 	"time"
 
 	"github.com/dapr/dapr/pkg/sentry/certs"
@@ -104,13 +105,16 @@ func GenerateRootCertCSR(org, cn string, publicKey interface{}, ttl time.Duratio
 	return cert, nil
 }
 
-// GenerateCSRCertificate returns an x509 Certificate from a CSR, signing cert, public key, signing private key and duration.
+// GenerateCSRCertificate returns an x509 Certificate from a CSR, signing cert, public key, signing private key, duration
+// and URI SANs. The certificate is valid from the allowed clock skew before now, so the hosts with a clock behind
+// accept it.
 func GenerateCSRCertificate(csr *x509.CertificateRequest, subject string, signingCert *x509.Certificate, publicKey interface{}, signingKey crypto.PrivateKey,
-	ttl time.Duration, isCA bool) ([]byte, error) {
+	ttl time.Duration, isCA bool, uris []*url.URL, allowedClockSkew time.Duration) ([]byte, error) {
 	cert, err := generateBaseCert(ttl, publicKey)
 	if err != nil {
 		return nil, fmt.Errorf("error generating csr certificate: %s", err)
 	}
+	cert.NotBefore = cert.NotBefore.Add(-allowedClockSkew)
 	if isCA {
 		cert.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
 	} else {
@@ -125,6 +129,7 @@ func GenerateCSRCertificate(csr *x509.CertificateRequest, subject string, signin
 	cert.IsCA = isCA
 	cert.DNSNames = csr.DNSNames
 	cert.IPAddresses = csr.IPAddresses
+	cert.URIs = uris
 	cert.Extensions = csr.Extensions
 	cert.BasicConstraintsValid = true
 	cert.SignatureAlgorithm = csr.SignatureAlgorithm
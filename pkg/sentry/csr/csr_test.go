@@ -1,7 +1,12 @@
 package csr
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -13,3 +18,18 @@ func TestGenerateCSRTemplate(t *testing.T) {
 		assert.Equal(t, "test-org", tmpl.Subject.Organization[0])
 	})
 }
+
+func TestGenerateCSRCertificate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	issuer, err := GenerateIssuerCertCSR("issuer", &key.PublicKey, time.Hour)
+	assert.Nil(t, err)
+
+	crtb, err := GenerateCSRCertificate(&x509.CertificateRequest{}, "app1", issuer, &key.PublicKey, key, time.Hour, false, nil, time.Minute*15)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(crtb)
+	assert.Nil(t, err)
+
+	assert.WithinDuration(t, time.Now().Add(-time.Minute*15), cert.NotBefore, time.Minute)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cert.NotAfter, time.Minute)
+}
@@ -0,0 +1,162 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/dapr/pkg/sentry/identity"
+	jose "gopkg.in/square/go-jose.v2"
+	jose_jwt "gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	errPrefix = "csr validation failed"
+	// discoveryPath is the path of the OIDC discovery document of an issuer, which holds the URL of its signing keys
+	discoveryPath = "/.well-known/openid-configuration"
+	// keysRefreshInterval is the minimum interval between the requests of the signing keys, which are requested again
+	// when a token is signed with an unknown key
+	keysRefreshInterval = time.Minute
+	httpTimeout         = time.Second * 10
+)
+
+// NewValidator returns a validator of the JWT tokens of an OIDC issuer, such as the identity provider of the VMs or
+// edge devices joining the trust domain. The tokens are verified with the signing keys of the JWKS URL, or of the
+// OIDC discovery document of the issuer when the JWKS URL is empty. The token must be issued for the audience, and
+// its subject must be the id of the request. The subject is the app ID the certificate is issued for, and the
+// dapr.io/namespace claim, if any, is its namespace.
+func NewValidator(issuer, audience, jwksURL string) identity.Validator {
+	return &validator{
+		issuer:   issuer,
+		audience: audience,
+		jwksURL:  jwksURL,
+		client:   &http.Client{Timeout: httpTimeout},
+	}
+}
+
+type validator struct {
+	issuer    string
+	audience  string
+	jwksURL   string
+	client    *http.Client
+	lock      sync.Mutex
+	keys      *jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+func (v *validator) Validate(id, token string) (identity.Identity, error) {
+	if id == "" {
+		return identity.Identity{}, fmt.Errorf("%s: id field in request must not be empty", errPrefix)
+	}
+	if token == "" {
+		return identity.Identity{}, fmt.Errorf("%s: token field in request must not be empty", errPrefix)
+	}
+
+	parsed, err := jose_jwt.ParseSigned(token)
+	if err != nil {
+		return identity.Identity{}, fmt.Errorf("%s: invalid token: %s", errPrefix, err)
+	}
+	if len(parsed.Headers) != 1 {
+		return identity.Identity{}, fmt.Errorf("%s: invalid token: one signature is expected", errPrefix)
+	}
+	key, err := v.getKey(parsed.Headers[0].KeyID)
+	if err != nil {
+		return identity.Identity{}, fmt.Errorf("%s: %s", errPrefix, err)
+	}
+
+	claims := jose_jwt.Claims{}
+	daprClaims := struct {
+		Namespace string `json:"dapr.io/namespace"`
+	}{}
+	if err := parsed.Claims(key, &claims, &daprClaims); err != nil {
+		return identity.Identity{}, fmt.Errorf("%s: invalid token signature: %s", errPrefix, err)
+	}
+	expected := jose_jwt.Expected{
+		Issuer:   v.issuer,
+		Audience: jose_jwt.Audience{v.audience},
+		Time:     time.Now(),
+	}
+	if err := claims.ValidateWithLeeway(expected, jose_jwt.DefaultLeeway); err != nil {
+		return identity.Identity{}, fmt.Errorf("%s: invalid token: %s", errPrefix, err)
+	}
+
+	if id != claims.Subject {
+		return identity.Identity{}, fmt.Errorf("%s: token/id mismatch. received id: %s", errPrefix, id)
+	}
+	return identity.Identity{AppID: claims.Subject, Namespace: daprClaims.Namespace}, nil
+}
+
+// getKey returns the signing key of a token, the signing keys are requested again when the key is unknown
+func (v *validator) getKey(keyID string) (jose.JSONWebKey, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.keys == nil || (len(v.findKeys(keyID)) == 0 && time.Since(v.fetchedAt) > keysRefreshInterval) {
+		keys, err := v.fetchKeys()
+		if err != nil && v.keys == nil {
+			return jose.JSONWebKey{}, fmt.Errorf("error getting the token signing keys: %s", err)
+		}
+		if err == nil {
+			v.keys = keys
+			v.fetchedAt = time.Now()
+		}
+	}
+
+	keys := v.findKeys(keyID)
+	if len(keys) == 0 {
+		return jose.JSONWebKey{}, fmt.Errorf("unknown token signing key %q", keyID)
+	}
+	return keys[0], nil
+}
+
+// findKeys returns the keys with a key id, or the only key of the signing keys for the tokens without a key id
+func (v *validator) findKeys(keyID string) []jose.JSONWebKey {
+	if v.keys == nil {
+		return nil
+	}
+	if keyID == "" && len(v.keys.Keys) == 1 {
+		return v.keys.Keys
+	}
+	return v.keys.Key(keyID)
+}
+
+func (v *validator) fetchKeys() (*jose.JSONWebKeySet, error) {
+	jwksURL := v.jwksURL
+	if jwksURL == "" {
+		discovery := struct {
+			JWKSURI string `json:"jwks_uri"`
+		}{}
+		if err := v.getJSON(strings.TrimSuffix(v.issuer, "/")+discoveryPath, &discovery); err != nil {
+			return nil, err
+		}
+		if discovery.JWKSURI == "" {
+			return nil, fmt.Errorf("no jwks_uri in the discovery document of %s", v.issuer)
+		}
+		jwksURL = discovery.JWKSURI
+	}
+
+	keys := &jose.JSONWebKeySet{}
+	if err := v.getJSON(jwksURL, keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (v *validator) getJSON(url string, out interface{}) error {
+	resp, err := v.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding %s: %s", url, err)
+	}
+	return nil
+}
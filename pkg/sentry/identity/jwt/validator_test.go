@@ -0,0 +1,120 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/sentry/identity"
+	"github.com/stretchr/testify/assert"
+	jose "gopkg.in/square/go-jose.v2"
+	jose_jwt "gopkg.in/square/go-jose.v2/jwt"
+)
+
+func getTestIssuer(t *testing.T) (*httptest.Server, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"jwks_uri": server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "key1", Algorithm: string(jose.ES256), Use: "sig"},
+		}})
+	})
+	server = httptest.NewServer(mux)
+	return server, key
+}
+
+// validate returns the error of the validation of the token
+func validate(v identity.Validator, id, token string) error {
+	_, err := v.Validate(id, token)
+	return err
+}
+
+func getTestToken(t *testing.T, key *ecdsa.PrivateKey, keyID string, claims jose_jwt.Claims) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", keyID))
+	assert.NoError(t, err)
+	token, err := jose_jwt.Signed(signer).Claims(claims).CompactSerialize()
+	assert.NoError(t, err)
+	return token
+}
+
+func TestValidate(t *testing.T) {
+	server, key := getTestIssuer(t)
+	defer server.Close()
+
+	v := NewValidator(server.URL, "dapr.io/sentry", "")
+	claims := jose_jwt.Claims{
+		Issuer:   server.URL,
+		Subject:  "device1",
+		Audience: jose_jwt.Audience{"dapr.io/sentry"},
+		Expiry:   jose_jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		requester, err := v.Validate("device1", getTestToken(t, key, "key1", claims))
+		assert.NoError(t, err)
+		assert.Equal(t, identity.Identity{AppID: "device1"}, requester)
+	})
+
+	t.Run("namespace claim", func(t *testing.T) {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "key1"))
+		assert.NoError(t, err)
+		token, err := jose_jwt.Signed(signer).Claims(claims).Claims(map[string]interface{}{"dapr.io/namespace": "ns1"}).CompactSerialize()
+		assert.NoError(t, err)
+
+		requester, err := v.Validate("device1", token)
+		assert.NoError(t, err)
+		assert.Equal(t, identity.Identity{AppID: "device1", Namespace: "ns1"}, requester)
+	})
+
+	t.Run("token/id mismatch", func(t *testing.T) {
+		assert.Error(t, validate(v, "device2", getTestToken(t, key, "key1", claims)))
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		expired := claims
+		expired.Expiry = jose_jwt.NewNumericDate(time.Now().Add(-time.Hour))
+		assert.Error(t, validate(v, "device1", getTestToken(t, key, "key1", expired)))
+	})
+
+	t.Run("other audience", func(t *testing.T) {
+		other := claims
+		other.Audience = jose_jwt.Audience{"other"}
+		assert.Error(t, validate(v, "device1", getTestToken(t, key, "key1", other)))
+	})
+
+	t.Run("other signing key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		assert.Error(t, validate(v, "device1", getTestToken(t, otherKey, "key1", claims)))
+	})
+
+	t.Run("unknown key id", func(t *testing.T) {
+		assert.Error(t, validate(v, "device1", getTestToken(t, key, "key2", claims)))
+	})
+
+	t.Run("empty id or token", func(t *testing.T) {
+		assert.Error(t, validate(v, "", getTestToken(t, key, "key1", claims)))
+		assert.Error(t, validate(v, "device1", ""))
+	})
+
+	t.Run("jwks url", func(t *testing.T) {
+		v := NewValidator(server.URL, "dapr.io/sentry", server.URL+"/keys")
+		assert.NoError(t, validate(v, "device1", getTestToken(t, key, "key1", claims)))
+	})
+
+	t.Run("unreachable issuer", func(t *testing.T) {
+		v := NewValidator("http://127.0.0.1:0", "dapr.io/sentry", "")
+		assert.Error(t, validate(v, "device1", getTestToken(t, key, "key1", claims)))
+	})
+}
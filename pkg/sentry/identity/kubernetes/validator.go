@@ -26,35 +26,35 @@ type validator struct {
 	auth   kauth.AuthenticationV1Interface
 }
 
-func (v *validator) Validate(id, token string) error {
+func (v *validator) Validate(id, token string) (identity.Identity, error) {
 	if id == "" {
-		return fmt.Errorf("%s: id field in request must not be empty", errPrefix)
+		return identity.Identity{}, fmt.Errorf("%s: id field in request must not be empty", errPrefix)
 	}
 	if token == "" {
-		return fmt.Errorf("%s: token field in request must not be empty", errPrefix)
+		return identity.Identity{}, fmt.Errorf("%s: token field in request must not be empty", errPrefix)
 	}
 
 	review, err := v.auth.TokenReviews().Create(&kauthapi.TokenReview{Spec: kauthapi.TokenReviewSpec{Token: token}})
 	if err != nil {
-		return err
+		return identity.Identity{}, err
 	}
 
 	if review.Status.Error != "" {
-		return fmt.Errorf("%s: invalid token: %s", errPrefix, review.Status.Error)
+		return identity.Identity{}, fmt.Errorf("%s: invalid token: %s", errPrefix, review.Status.Error)
 	}
 	if !review.Status.Authenticated {
-		return fmt.Errorf("%s: authentication failed", errPrefix)
+		return identity.Identity{}, fmt.Errorf("%s: authentication failed", errPrefix)
 	}
 
 	prts := strings.Split(review.Status.User.Username, ":")
 	if len(prts) != 4 || prts[0] != "system" {
-		return fmt.Errorf("%s: provided token is not a properly structured service account token", errPrefix)
+		return identity.Identity{}, fmt.Errorf("%s: provided token is not a properly structured service account token", errPrefix)
 	}
 
 	podSa := prts[3]
 	podNs := prts[2]
 	if id != fmt.Sprintf("%s:%s", podSa, podNs) {
-		return fmt.Errorf("%s: token/id mismatch. received id: %s", errPrefix, id)
+		return identity.Identity{}, fmt.Errorf("%s: token/id mismatch. received id: %s", errPrefix, id)
 	}
-	return nil
+	return identity.Identity{Namespace: podNs}, nil
 }
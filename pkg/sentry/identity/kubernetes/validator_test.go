@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/dapr/dapr/pkg/sentry/identity"
 	"github.com/stretchr/testify/assert"
 	kauthapi "k8s.io/api/authentication/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -26,7 +27,7 @@ func TestValidate(t *testing.T) {
 			auth:   fakeClient.AuthenticationV1(),
 		}
 
-		err := v.Validate("a1:ns1", "a2:ns2")
+		_, err := v.Validate("a1:ns1", "a2:ns2")
 		assert.Equal(t, fmt.Errorf("%s: invalid token: bad token", errPrefix), err)
 	})
 
@@ -44,7 +45,7 @@ func TestValidate(t *testing.T) {
 			auth:   fakeClient.AuthenticationV1(),
 		}
 
-		err := v.Validate("a1:ns1", "a2:ns2")
+		_, err := v.Validate("a1:ns1", "a2:ns2")
 		assert.Equal(t, fmt.Errorf("%s: authentication failed", errPrefix), err)
 	})
 
@@ -62,7 +63,7 @@ func TestValidate(t *testing.T) {
 			auth:   fakeClient.AuthenticationV1(),
 		}
 
-		err := v.Validate("a1:ns1", "a2:ns2")
+		_, err := v.Validate("a1:ns1", "a2:ns2")
 		assert.Equal(t, fmt.Errorf("%s: provided token is not a properly structured service account token", errPrefix), err)
 	})
 
@@ -80,10 +81,29 @@ func TestValidate(t *testing.T) {
 			auth:   fakeClient.AuthenticationV1(),
 		}
 
-		err := v.Validate("a1:ns2", "a2:ns2")
+		_, err := v.Validate("a1:ns2", "a2:ns2")
 		assert.Equal(t, fmt.Errorf("%s: token/id mismatch. received id: a1:ns2", errPrefix), err)
 	})
 
+	t.Run("valid token", func(t *testing.T) {
+		fakeClient := &fake.Clientset{}
+		fakeClient.Fake.AddReactor(
+			"create",
+			"tokenreviews",
+			func(action core.Action) (bool, runtime.Object, error) {
+				return true, &kauthapi.TokenReview{Status: kauthapi.TokenReviewStatus{Authenticated: true, User: kauthapi.UserInfo{Username: "system:serviceaccount:ns1:sa1"}}}, nil
+			})
+
+		v := validator{
+			client: fakeClient,
+			auth:   fakeClient.AuthenticationV1(),
+		}
+
+		requester, err := v.Validate("sa1:ns1", "token")
+		assert.NoError(t, err)
+		assert.Equal(t, identity.Identity{Namespace: "ns1"}, requester)
+	})
+
 	t.Run("empty token", func(t *testing.T) {
 		fakeClient := fake.NewSimpleClientset()
 		v := validator{
@@ -91,7 +111,7 @@ func TestValidate(t *testing.T) {
 			auth:   fakeClient.AuthenticationV1(),
 		}
 
-		err := v.Validate("a1:ns1", "")
+		_, err := v.Validate("a1:ns1", "")
 		expectedErr := fmt.Errorf("%s: token field in request must not be empty", errPrefix)
 		assert.Equal(t, expectedErr, err)
 	})
@@ -103,7 +123,7 @@ func TestValidate(t *testing.T) {
 			auth:   fakeClient.AuthenticationV1(),
 		}
 
-		err := v.Validate("", "a1:ns1")
+		_, err := v.Validate("", "a1:ns1")
 		expectedErr := fmt.Errorf("%s: id field in request must not be empty", errPrefix)
 		assert.Equal(t, expectedErr, err)
 	})
@@ -1,6 +1,10 @@
 package selfhosted
 
-import "github.com/dapr/dapr/pkg/sentry/identity"
+import (
+	"strings"
+
+	"github.com/dapr/dapr/pkg/sentry/identity"
+)
 
 func NewValidator() identity.Validator {
 	return &validator{}
@@ -9,7 +13,11 @@ func NewValidator() identity.Validator {
 type validator struct {
 }
 
-// no validation for self hosted
-func (v *validator) Validate(id, token string) error {
-	return nil
+// no validation for self hosted, the namespace is taken from an id in the form of <service-account>:<namespace>
+func (v *validator) Validate(id, token string) (identity.Identity, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return identity.Identity{}, nil
+	}
+	return identity.Identity{Namespace: parts[1]}, nil
 }
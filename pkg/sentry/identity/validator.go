@@ -2,5 +2,13 @@ package identity
 
 // Validator is used to validate the identity of a certificate requester by using an ID and token.
 type Validator interface {
-	Validate(id, token string) error
+	Validate(id, token string) (Identity, error)
+}
+
+// Identity is the identity of a certificate requester established by the validation of its token
+type Identity struct {
+	// AppID is the app ID the certificate must be issued for, it is empty when the token doesn't hold it
+	AppID string
+	// Namespace is the namespace of the requester, it is empty outside of namespaces
+	Namespace string
 }
@@ -2,12 +2,14 @@ package sentry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dapr/dapr/pkg/sentry/ca"
 	"github.com/dapr/dapr/pkg/sentry/config"
 	"github.com/dapr/dapr/pkg/sentry/identity"
+	"github.com/dapr/dapr/pkg/sentry/identity/jwt"
 	"github.com/dapr/dapr/pkg/sentry/identity/kubernetes"
 	"github.com/dapr/dapr/pkg/sentry/identity/selfhosted"
 	k8s "github.com/dapr/dapr/pkg/sentry/kubernetes"
@@ -34,23 +36,33 @@ func NewSentryCA() CertificateAuthority {
 
 // Run loads the trust anchors and issuer certs, creates a new CA and runs the CA server.
 func (s *sentry) Run(ctx context.Context, conf config.SentryConfig, readyCh chan bool) {
-	// Create CA
+	certAuth, err := loadCertificateAuthority(conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.run(ctx, conf, certAuth, readyCh)
+}
+
+// loadCertificateAuthority creates a new CA and loads its trust bundle
+func loadCertificateAuthority(conf config.SentryConfig) (ca.CertificateAuthority, error) {
 	certAuth, err := ca.NewCertificateAuthority(conf)
 	if err != nil {
-		log.Fatalf("error getting certificate authority: %s", err)
+		return nil, fmt.Errorf("error getting certificate authority: %s", err)
 	}
 	log.Info("certificate authority loaded")
 
-	// Load the trust bundle
 	err = certAuth.LoadOrStoreTrustBundle()
 	if err != nil {
-		log.Fatalf("error loading trust root bundle: %s", err)
+		return nil, fmt.Errorf("error loading trust root bundle: %s", err)
 	}
 	log.Infof("trust root bundle loaded. issuer cert expiry: %s", certAuth.GetCACertBundle().GetIssuerCertExpiry().String())
 	monitoring.IssuerCertExpiry(certAuth.GetCACertBundle().GetIssuerCertExpiry())
+	return certAuth, nil
+}
 
+func (s *sentry) run(ctx context.Context, conf config.SentryConfig, certAuth ca.CertificateAuthority, readyCh chan bool) {
 	// Create identity validator
-	v, err := createValidator()
+	v, err := createValidator(conf)
 	if err != nil {
 		log.Fatalf("error creating validator: %s", err)
 	}
@@ -80,20 +92,45 @@ func (s *sentry) Run(ctx context.Context, conf config.SentryConfig, readyCh chan
 	}
 }
 
-func createValidator() (identity.Validator, error) {
-	if config.IsKubernetesHosted() {
-		// we're in Kubernetes, create client and init a new serviceaccount token validator
+func createValidator(conf config.SentryConfig) (identity.Validator, error) {
+	validator := conf.TokenValidator
+	if validator == "" {
+		validator = config.InsecureValidator
+		if config.IsKubernetesHosted() {
+			validator = config.KubernetesValidator
+		}
+	}
+
+	switch validator {
+	case config.KubernetesValidator:
+		// create client and init a new serviceaccount token validator
 		kubeClient, err := k8s.GetClient()
 		if err != nil {
 			return nil, fmt.Errorf("failed to create kubernetes client: %s", err)
 		}
 		return kubernetes.NewValidator(kubeClient), nil
+	case config.JWTValidator:
+		if conf.JWTIssuer == "" || conf.JWTAudience == "" {
+			return nil, errors.New("the jwt validator requires the issuer and the audience of the tokens")
+		}
+		return jwt.NewValidator(conf.JWTIssuer, conf.JWTAudience, conf.JWKSURL), nil
+	case config.InsecureValidator:
+		log.Warn("the tokens of the CSRs aren't validated, any requester gets certificates")
+		return selfhosted.NewValidator(), nil
+	default:
+		return nil, fmt.Errorf("unknown token validator %s", validator)
 	}
-	return selfhosted.NewValidator(), nil
 }
 
+// Restart loads the rotated issuer credentials and restarts the CA server with them. The CA server keeps running with
+// the current credentials when the rotated ones are invalid.
 func (s *sentry) Restart(ctx context.Context, conf config.SentryConfig) {
+	certAuth, err := loadCertificateAuthority(conf)
+	if err != nil {
+		log.Errorf("keeping the current issuer credentials: %s", err)
+		return
+	}
 	s.server.Shutdown()
 	close(s.doneCh)
-	go s.Run(ctx, conf, nil)
+	go s.run(ctx, conf, certAuth, nil)
 }
@@ -138,7 +138,7 @@ func (s *server) SignCertificate(ctx context.Context, req *sentryv1pb.SignCertif
 		return nil, err
 	}
 
-	err = s.validator.Validate(req.GetId(), req.GetToken())
+	requester, err := s.validator.Validate(req.GetId(), req.GetToken())
 	if err != nil {
 		err = fmt.Errorf("error validating requester identity: %s", err)
 		log.Error(err)
@@ -146,7 +146,16 @@ func (s *server) SignCertificate(ctx context.Context, req *sentryv1pb.SignCertif
 		return nil, err
 	}
 
-	signed, err := s.certAuth.SignCSR(csrPem, csr.Subject.CommonName, -1, false)
+	// the certificate is issued for the app ID of the CSR, which must be the app ID of the token when it holds one
+	appID := csr.Subject.CommonName
+	if requester.AppID != "" && requester.AppID != appID {
+		err = fmt.Errorf("error validating requester identity: csr common name %s doesn't match the app id of the token", appID)
+		log.Error(err)
+		monitoring.CertSignFailed("req_id_validation")
+		return nil, err
+	}
+
+	signed, err := s.certAuth.SignWorkloadCSR(csrPem, appID, requester.Namespace)
 	if err != nil {
 		err = fmt.Errorf("error signing csr: %s", err)
 		log.Error(err)
@@ -0,0 +1,201 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/state/query"
+)
+
+const (
+	// ParallelismMetadataKey is the request metadata key holding the number of concurrent operations of a bulk request
+	ParallelismMetadataKey = "parallelism"
+	// DefaultParallelism is the number of concurrent operations of bulk requests which don't set a parallelism
+	DefaultParallelism = 10
+	// MaxBatchSize is the maximum number of keys fetched in a single native bulk get
+	// or deleted in a single bulk delete of a prefix delete
+	MaxBatchSize = 100
+	// AllowPrefixDeleteMetadataKey is the state store component metadata key enabling the deletion
+	// of all the keys starting with a prefix, which is disabled by default
+	AllowPrefixDeleteMetadataKey = "allowPrefixDelete"
+)
+
+// ErrPrefixDeleteNotSupported is returned when deleting a prefix on a state store which can't
+// delete prefixes natively and does not support queries
+var ErrPrefixDeleteNotSupported = errors.New("state store does not support deleting key prefixes")
+
+// Getter is implemented by state stores which can natively fetch several keys at once
+type Getter interface {
+	BulkGet(req []state.GetRequest) ([]GetResponse, error)
+}
+
+// PrefixDeleter is implemented by state stores which can natively delete the keys starting with a prefix
+type PrefixDeleter interface {
+	DeleteWithPrefix(prefix string) (int, error)
+}
+
+// GetResponse is the state of a key fetched by a bulk get. The error is set if the key could not be fetched.
+type GetResponse struct {
+	Key   string `json:"key"`
+	Data  []byte `json:"data,omitempty"`
+	ETag  string `json:"etag,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// GetParallelism returns the parallelism requested by the metadata, or the default parallelism
+func GetParallelism(metadata map[string]string) (int, error) {
+	for k, v := range metadata {
+		if !strings.EqualFold(k, ParallelismMetadataKey) {
+			continue
+		}
+		parallelism, err := strconv.Atoi(v)
+		if err != nil || parallelism <= 0 {
+			return 0, fmt.Errorf("invalid %s value %q", ParallelismMetadataKey, v)
+		}
+		return parallelism, nil
+	}
+	return DefaultParallelism, nil
+}
+
+// Get fetches the keys with at most parallelism concurrent requests to the store.
+// Stores which implement Getter are called with batches of at most MaxBatchSize keys,
+// other stores are called once per key. The responses are in the order of the requests.
+func Get(store state.Store, reqs []state.GetRequest, parallelism int) []GetResponse {
+	responses := make([]GetResponse, len(reqs))
+	getter, native := store.(Getter)
+
+	batchSize := 1
+	if native {
+		batchSize = MaxBatchSize
+	}
+	run(len(reqs), batchSize, parallelism, func(start, end int) {
+		if native {
+			getBatch(getter, reqs[start:end], responses[start:end])
+			return
+		}
+
+		resp, err := store.Get(&reqs[start])
+		responses[start] = getResponse(reqs[start].Key, resp, err)
+	})
+	return responses
+}
+
+// DeleteWithPrefix deletes the keys starting with the prefix and returns the number of deleted keys.
+// Stores which implement PrefixDeleter delete the keys natively, on other stores the keys are listed
+// with queries and deleted with bulk deletes of at most MaxBatchSize keys.
+func DeleteWithPrefix(store state.Store, prefix string) (int, error) {
+	if deleter, ok := store.(PrefixDeleter); ok {
+		return deleter.DeleteWithPrefix(prefix)
+	}
+	querier, ok := store.(query.Querier)
+	if !ok {
+		return 0, ErrPrefixDeleteNotSupported
+	}
+
+	// list all the keys before deleting them, as deletes may change the pages of the query
+	var keys []string
+	seen := map[string]bool{}
+	token := ""
+	for {
+		resp, err := querier.Query(&query.Request{
+			Query:     query.Query{Page: query.Pagination{Token: token}},
+			KeyPrefix: prefix,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed listing the keys with prefix %s: %s", prefix, err)
+		}
+		for _, item := range resp.Results {
+			if !seen[item.Key] {
+				seen[item.Key] = true
+				keys = append(keys, item.Key)
+			}
+		}
+		if resp.Token == "" || resp.Token == token {
+			break
+		}
+		token = resp.Token
+	}
+
+	deleted := 0
+	for start := 0; start < len(keys); start += MaxBatchSize {
+		end := start + MaxBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		reqs := make([]state.DeleteRequest, 0, end-start)
+		for _, k := range keys[start:end] {
+			reqs = append(reqs, state.DeleteRequest{Key: k})
+		}
+		if err := store.BulkDelete(reqs); err != nil {
+			return deleted, fmt.Errorf("failed deleting the keys with prefix %s after %d keys: %s", prefix, deleted, err)
+		}
+		deleted += len(reqs)
+	}
+	return deleted, nil
+}
+
+func getBatch(getter Getter, reqs []state.GetRequest, responses []GetResponse) {
+	resps, err := getter.BulkGet(reqs)
+	if err != nil {
+		for i, r := range reqs {
+			responses[i] = GetResponse{Key: r.Key, Error: err.Error()}
+		}
+		return
+	}
+
+	byKey := make(map[string]GetResponse, len(resps))
+	for _, r := range resps {
+		byKey[r.Key] = r
+	}
+	for i, r := range reqs {
+		resp, ok := byKey[r.Key]
+		if !ok {
+			resp = GetResponse{Key: r.Key}
+		}
+		responses[i] = resp
+	}
+}
+
+func getResponse(key string, resp *state.GetResponse, err error) GetResponse {
+	if err != nil {
+		return GetResponse{Key: key, Error: err.Error()}
+	}
+	if resp == nil {
+		return GetResponse{Key: key}
+	}
+	return GetResponse{Key: key, Data: resp.Data, ETag: resp.ETag}
+}
+
+// run calls fn for each batch of [start, end) indexes, with at most parallelism concurrent calls
+func run(n, batchSize, parallelism int, fn func(start, end int)) {
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+	limit := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += batchSize {
+		end := start + batchSize
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(start, end int) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,217 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/state/query"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore records the maximum number of concurrent calls it received
+type fakeStore struct {
+	lock       sync.Mutex
+	items      map[string][]byte
+	running    int32
+	maxRunning int32
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{items: map[string][]byte{}}
+}
+
+func (f *fakeStore) enter() func() {
+	running := atomic.AddInt32(&f.running, 1)
+	f.lock.Lock()
+	if running > f.maxRunning {
+		f.maxRunning = running
+	}
+	f.lock.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	return func() { atomic.AddInt32(&f.running, -1) }
+}
+
+func (f *fakeStore) Init(metadata state.Metadata) error         { return nil }
+func (f *fakeStore) Delete(req *state.DeleteRequest) error      { return nil }
+func (f *fakeStore) BulkDelete(req []state.DeleteRequest) error { return nil }
+func (f *fakeStore) BulkSet(req []state.SetRequest) error       { return nil }
+
+func (f *fakeStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	defer f.enter()()
+	if req.Key == "failing" {
+		return nil, errors.New("get failed")
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return &state.GetResponse{Data: f.items[req.Key], ETag: "1"}, nil
+}
+
+func (f *fakeStore) Set(req *state.SetRequest) error {
+	defer f.enter()()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.items[req.Key] = req.Value.([]byte)
+	return nil
+}
+
+type fakeGetter struct {
+	*fakeStore
+	batches [][]string
+}
+
+func (f *fakeGetter) BulkGet(req []state.GetRequest) ([]GetResponse, error) {
+	f.lock.Lock()
+	keys := []string{}
+	for _, r := range req {
+		keys = append(keys, r.Key)
+	}
+	f.batches = append(f.batches, keys)
+	f.lock.Unlock()
+
+	// returned out of order and without the missing keys
+	resps := []GetResponse{}
+	for i := len(req) - 1; i >= 0; i-- {
+		if data, ok := f.items[req[i].Key]; ok {
+			resps = append(resps, GetResponse{Key: req[i].Key, Data: data})
+		}
+	}
+	return resps, nil
+}
+
+func getRequests(n int) []state.GetRequest {
+	reqs := []state.GetRequest{}
+	for i := 0; i < n; i++ {
+		reqs = append(reqs, state.GetRequest{Key: fmt.Sprintf("key%d", i)})
+	}
+	return reqs
+}
+
+func TestGetParallelism(t *testing.T) {
+	p, err := GetParallelism(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultParallelism, p)
+
+	p, err = GetParallelism(map[string]string{"parallelism": "3"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, p)
+
+	_, err = GetParallelism(map[string]string{"parallelism": "0"})
+	assert.Error(t, err)
+}
+
+func TestGet(t *testing.T) {
+	t.Run("parallel gets are limited", func(t *testing.T) {
+		store := newFakeStore()
+		store.items["key1"] = []byte("value1")
+		reqs := append(getRequests(20), state.GetRequest{Key: "failing"})
+
+		responses := Get(store, reqs, 4)
+		assert.Len(t, responses, 21)
+		assert.Equal(t, GetResponse{Key: "key1", Data: []byte("value1"), ETag: "1"}, responses[1])
+		assert.Equal(t, GetResponse{Key: "failing", Error: "get failed"}, responses[20])
+		assert.True(t, store.maxRunning > 1)
+		assert.True(t, store.maxRunning <= 4)
+	})
+
+	t.Run("native bulk get is called in batches", func(t *testing.T) {
+		store := &fakeGetter{fakeStore: newFakeStore()}
+		store.items["key0"] = []byte("value0")
+		store.items["key150"] = []byte("value150")
+
+		responses := Get(store, getRequests(250), 2)
+		assert.Len(t, responses, 250)
+		assert.Len(t, store.batches, 3)
+		assert.Equal(t, GetResponse{Key: "key0", Data: []byte("value0")}, responses[0])
+		assert.Equal(t, GetResponse{Key: "key1"}, responses[1])
+		assert.Equal(t, GetResponse{Key: "key150", Data: []byte("value150")}, responses[150])
+	})
+}
+
+// fakeQuerier lists the keys of the store two per page and records the bulk deletes
+type fakeQuerier struct {
+	*fakeStore
+	deletes [][]string
+}
+
+func (f *fakeQuerier) Query(req *query.Request) (*query.Response, error) {
+	keys := []string{}
+	for k := range f.items {
+		if strings.HasPrefix(k, req.KeyPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	start, _ := strconv.Atoi(req.Query.Page.Token)
+	resp := &query.Response{}
+	for i := start; i < len(keys) && i < start+2; i++ {
+		resp.Results = append(resp.Results, query.Item{Key: keys[i]})
+	}
+	if start+2 < len(keys) {
+		resp.Token = strconv.Itoa(start + 2)
+	}
+	return resp, nil
+}
+
+func (f *fakeQuerier) BulkDelete(req []state.DeleteRequest) error {
+	keys := []string{}
+	for _, r := range req {
+		keys = append(keys, r.Key)
+		delete(f.items, r.Key)
+	}
+	f.deletes = append(f.deletes, keys)
+	return nil
+}
+
+type fakePrefixDeleter struct {
+	*fakeStore
+	prefix string
+}
+
+func (f *fakePrefixDeleter) DeleteWithPrefix(prefix string) (int, error) {
+	f.prefix = prefix
+	return 3, nil
+}
+
+func TestDeleteWithPrefix(t *testing.T) {
+	t.Run("keys are listed and deleted in batches", func(t *testing.T) {
+		store := &fakeQuerier{fakeStore: newFakeStore()}
+		for i := 0; i < 150; i++ {
+			store.items[fmt.Sprintf("app1||order%03d", i)] = []byte("1")
+		}
+		store.items["app1||user1"] = []byte("1")
+
+		deleted, err := DeleteWithPrefix(store, "app1||order")
+		assert.NoError(t, err)
+		assert.Equal(t, 150, deleted)
+		assert.Len(t, store.deletes, 2)
+		assert.Len(t, store.deletes[0], MaxBatchSize)
+		assert.Equal(t, map[string][]byte{"app1||user1": []byte("1")}, store.items)
+	})
+
+	t.Run("native prefix delete", func(t *testing.T) {
+		store := &fakePrefixDeleter{fakeStore: newFakeStore()}
+		deleted, err := DeleteWithPrefix(store, "app1||order")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, deleted)
+		assert.Equal(t, "app1||order", store.prefix)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		_, err := DeleteWithPrefix(newFakeStore(), "app1||order")
+		assert.Equal(t, ErrPrefixDeleteNotSupported, err)
+	})
+}
@@ -0,0 +1,236 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+const (
+	// SizeMetadataKey is the state store component metadata key holding the maximum number of keys
+	// kept in the read cache. The cache is disabled if it is not set.
+	SizeMetadataKey = "cacheSize"
+	// TTLMetadataKey is the state store component metadata key holding how long a value is served
+	// from the read cache, as a duration such as "30s"
+	TTLMetadataKey = "cacheTTL"
+	// DefaultTTL is the TTL of the cached values of stores which don't set one
+	DefaultTTL = 10 * time.Second
+
+	strongConsistency = "strong"
+)
+
+// Config is the read cache configuration of a state store
+type Config struct {
+	Size int
+	TTL  time.Duration
+}
+
+// GetConfig returns the read cache configuration set in the state store component metadata,
+// or nil if the store has no read cache
+func GetConfig(metadata map[string]string) (*Config, error) {
+	size, ttl := metadata[SizeMetadataKey], metadata[TTLMetadataKey]
+	if size == "" {
+		if ttl != "" {
+			return nil, fmt.Errorf("%s requires %s", TTLMetadataKey, SizeMetadataKey)
+		}
+		return nil, nil
+	}
+
+	config := &Config{TTL: DefaultTTL}
+	var err error
+	if config.Size, err = strconv.Atoi(size); err != nil || config.Size <= 0 {
+		return nil, fmt.Errorf("invalid %s value %q", SizeMetadataKey, size)
+	}
+	if ttl != "" {
+		if config.TTL, err = time.ParseDuration(ttl); err != nil || config.TTL <= 0 {
+			return nil, fmt.Errorf("invalid %s value %q", TTLMetadataKey, ttl)
+		}
+	}
+	return config, nil
+}
+
+type entry struct {
+	key       string
+	resp      state.GetResponse
+	expiresAt time.Time
+}
+
+type cachedStore struct {
+	store  state.Store
+	config Config
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	// lru holds the entries from the most to the least recently used
+	lru *list.List
+	// generation is incremented by every write, so that a value read before
+	// a write is not cached after the write invalidated the key
+	generation uint64
+}
+
+type transactionalCachedStore struct {
+	*cachedStore
+	transactionalStore state.TransactionalStore
+}
+
+// NewStore returns a state store serving the values read from the given store from an in-memory
+// LRU cache until their TTL expires. Writes made through the returned store invalidate the cached
+// keys, writes made by other app instances are seen once the cached values expire.
+// Reads with strong consistency bypass the cache. The returned store supports transactions if
+// the given store does.
+func NewStore(store state.Store, config Config) state.Store {
+	s := &cachedStore{
+		store:   store,
+		config:  config,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+	if transactionalStore, ok := store.(state.TransactionalStore); ok {
+		return &transactionalCachedStore{
+			cachedStore:        s,
+			transactionalStore: transactionalStore,
+		}
+	}
+	return s
+}
+
+func (s *cachedStore) Init(metadata state.Metadata) error {
+	return s.store.Init(metadata)
+}
+
+// Close closes the given store if it can be closed
+func (s *cachedStore) Close() error {
+	if closer, ok := s.store.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (s *cachedStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	if req.Options.Consistency == strongConsistency {
+		return s.store.Get(req)
+	}
+
+	s.lock.Lock()
+	if resp, ok := s.get(req.Key); ok {
+		s.lock.Unlock()
+		return resp, nil
+	}
+	generation := s.generation
+	s.lock.Unlock()
+
+	resp, err := s.store.Get(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	s.lock.Lock()
+	if s.generation == generation {
+		s.put(req.Key, *resp)
+	}
+	s.lock.Unlock()
+	return resp, nil
+}
+
+func (s *cachedStore) Set(req *state.SetRequest) error {
+	defer s.invalidate(req.Key)
+	return s.store.Set(req)
+}
+
+func (s *cachedStore) BulkSet(req []state.SetRequest) error {
+	keys := make([]string, 0, len(req))
+	for _, r := range req {
+		keys = append(keys, r.Key)
+	}
+	defer s.invalidate(keys...)
+	return s.store.BulkSet(req)
+}
+
+func (s *cachedStore) Delete(req *state.DeleteRequest) error {
+	defer s.invalidate(req.Key)
+	return s.store.Delete(req)
+}
+
+func (s *cachedStore) BulkDelete(req []state.DeleteRequest) error {
+	keys := make([]string, 0, len(req))
+	for _, r := range req {
+		keys = append(keys, r.Key)
+	}
+	defer s.invalidate(keys...)
+	return s.store.BulkDelete(req)
+}
+
+// Multi executes the transaction and invalidates the keys of its operations
+func (s *transactionalCachedStore) Multi(reqs []state.TransactionalRequest) error {
+	keys := make([]string, 0, len(reqs))
+	for _, r := range reqs {
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			keys = append(keys, req.Key)
+		case state.DeleteRequest:
+			keys = append(keys, req.Key)
+		}
+	}
+	defer s.invalidate(keys...)
+	return s.transactionalStore.Multi(reqs)
+}
+
+// get returns a copy of the cached value of the key if it has not expired, s.lock must be held
+func (s *cachedStore) get(key string) (*state.GetResponse, bool) {
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !time.Now().Before(e.expiresAt) {
+		s.lru.Remove(el)
+		delete(s.entries, key)
+		return nil, false
+	}
+
+	s.lru.MoveToFront(el)
+	resp := e.resp
+	resp.Data = append([]byte(nil), e.resp.Data...)
+	return &resp, true
+}
+
+// put caches a copy of the value of the key and evicts the least recently used key if the cache is full,
+// s.lock must be held
+func (s *cachedStore) put(key string, resp state.GetResponse) {
+	resp.Data = append([]byte(nil), resp.Data...)
+	e := &entry{key: key, resp: resp, expiresAt: time.Now().Add(s.config.TTL)}
+	if el, ok := s.entries[key]; ok {
+		el.Value = e
+		s.lru.MoveToFront(el)
+		return
+	}
+
+	s.entries[key] = s.lru.PushFront(e)
+	if s.lru.Len() > s.config.Size {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*entry).key)
+	}
+}
+
+func (s *cachedStore) invalidate(keys ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.generation++
+	for _, k := range keys {
+		if el, ok := s.entries[k]; ok {
+			s.lru.Remove(el)
+			delete(s.entries, k)
+		}
+	}
+}
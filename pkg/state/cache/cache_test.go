@@ -0,0 +1,191 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	items map[string][]byte
+	gets  int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{items: map[string][]byte{}}
+}
+
+func (f *fakeStore) Init(metadata state.Metadata) error { return nil }
+
+func (f *fakeStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	f.gets++
+	data, ok := f.items[req.Key]
+	if !ok {
+		return nil, nil
+	}
+	return &state.GetResponse{Data: data, ETag: "1"}, nil
+}
+
+func (f *fakeStore) Set(req *state.SetRequest) error {
+	f.items[req.Key] = req.Value.([]byte)
+	return nil
+}
+
+func (f *fakeStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		f.Set(&req[i])
+	}
+	return nil
+}
+
+func (f *fakeStore) Delete(req *state.DeleteRequest) error {
+	delete(f.items, req.Key)
+	return nil
+}
+
+func (f *fakeStore) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		f.Delete(&req[i])
+	}
+	return nil
+}
+
+type fakeTransactionalStore struct {
+	*fakeStore
+}
+
+func (f fakeTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	for _, r := range reqs {
+		if req, ok := r.Request.(state.SetRequest); ok {
+			f.Set(&req)
+		}
+	}
+	return nil
+}
+
+func get(t *testing.T, store state.Store, key string) string {
+	resp, err := store.Get(&state.GetRequest{Key: key})
+	assert.NoError(t, err)
+	if resp == nil {
+		return ""
+	}
+	return string(resp.Data)
+}
+
+func TestGetConfig(t *testing.T) {
+	config, err := GetConfig(map[string]string{})
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = GetConfig(map[string]string{SizeMetadataKey: "100"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{Size: 100, TTL: DefaultTTL}, config)
+
+	config, err = GetConfig(map[string]string{SizeMetadataKey: "100", TTLMetadataKey: "1m"})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, config.TTL)
+
+	_, err = GetConfig(map[string]string{TTLMetadataKey: "1m"})
+	assert.Error(t, err)
+	_, err = GetConfig(map[string]string{SizeMetadataKey: "0"})
+	assert.Error(t, err)
+	_, err = GetConfig(map[string]string{SizeMetadataKey: "10", TTLMetadataKey: "forever"})
+	assert.Error(t, err)
+}
+
+func TestCachedStore(t *testing.T) {
+	t.Run("reads are cached", func(t *testing.T) {
+		inner := newFakeStore()
+		inner.items["key1"] = []byte("value1")
+		store := NewStore(inner, Config{Size: 10, TTL: time.Minute})
+
+		assert.Equal(t, "value1", get(t, store, "key1"))
+		assert.Equal(t, "value1", get(t, store, "key1"))
+		assert.Equal(t, 1, inner.gets)
+	})
+
+	t.Run("cached values are copies", func(t *testing.T) {
+		inner := newFakeStore()
+		inner.items["key1"] = []byte("value1")
+		store := NewStore(inner, Config{Size: 10, TTL: time.Minute})
+
+		resp, _ := store.Get(&state.GetRequest{Key: "key1"})
+		resp.Data[0] = 'X'
+		assert.Equal(t, "value1", get(t, store, "key1"))
+	})
+
+	t.Run("values expire", func(t *testing.T) {
+		inner := newFakeStore()
+		inner.items["key1"] = []byte("value1")
+		store := NewStore(inner, Config{Size: 10, TTL: time.Millisecond})
+
+		get(t, store, "key1")
+		time.Sleep(5 * time.Millisecond)
+		get(t, store, "key1")
+		assert.Equal(t, 2, inner.gets)
+	})
+
+	t.Run("strong consistency bypasses the cache", func(t *testing.T) {
+		inner := newFakeStore()
+		inner.items["key1"] = []byte("value1")
+		store := NewStore(inner, Config{Size: 10, TTL: time.Minute})
+
+		get(t, store, "key1")
+		store.Get(&state.GetRequest{Key: "key1", Options: state.GetStateOption{Consistency: "strong"}})
+		assert.Equal(t, 2, inner.gets)
+	})
+
+	t.Run("least recently used keys are evicted", func(t *testing.T) {
+		inner := newFakeStore()
+		inner.items["key1"] = []byte("value1")
+		inner.items["key2"] = []byte("value2")
+		inner.items["key3"] = []byte("value3")
+		store := NewStore(inner, Config{Size: 2, TTL: time.Minute})
+
+		get(t, store, "key1")
+		get(t, store, "key2")
+		get(t, store, "key1")
+		get(t, store, "key3")
+		assert.Equal(t, 3, inner.gets)
+
+		get(t, store, "key1")
+		assert.Equal(t, 3, inner.gets)
+		get(t, store, "key2")
+		assert.Equal(t, 4, inner.gets)
+	})
+
+	t.Run("writes invalidate the keys", func(t *testing.T) {
+		inner := newFakeStore()
+		inner.items["key1"] = []byte("value1")
+		inner.items["key2"] = []byte("value2")
+		store := NewStore(inner, Config{Size: 10, TTL: time.Minute})
+
+		get(t, store, "key1")
+		store.Set(&state.SetRequest{Key: "key1", Value: []byte("value1b")})
+		assert.Equal(t, "value1b", get(t, store, "key1"))
+
+		get(t, store, "key2")
+		store.BulkDelete([]state.DeleteRequest{{Key: "key2"}})
+		assert.Equal(t, "", get(t, store, "key2"))
+	})
+
+	t.Run("transactions invalidate the keys", func(t *testing.T) {
+		inner := newFakeStore()
+		inner.items["key1"] = []byte("value1")
+		store := NewStore(fakeTransactionalStore{inner}, Config{Size: 10, TTL: time.Minute})
+
+		get(t, store, "key1")
+		err := store.(state.TransactionalStore).Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "key1", Value: []byte("value1b")}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "value1b", get(t, store, "key1"))
+	})
+}
@@ -0,0 +1,113 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package consistency
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MetadataKey is the request metadata key holding the consistency of a state request,
+	// used when the request does not set the consistency option
+	MetadataKey = "consistency"
+	// ReadPreferenceMetadataKey is the request metadata key holding the replicas a read is served from.
+	// It is passed to the state store along with the other request metadata.
+	ReadPreferenceMetadataKey = "readPreference"
+	// SupportedMetadataKey is the state store component metadata key holding the comma separated
+	// consistency modes supported by the store. Stores which don't declare them support all the modes.
+	SupportedMetadataKey = "supportedConsistency"
+
+	// Strong consistency reads the latest write
+	Strong = "strong"
+	// Eventual consistency may read stale values
+	Eventual = "eventual"
+
+	// Primary reads from the primary replica only
+	Primary = "primary"
+	// PrimaryPreferred reads from the primary replica if it is available
+	PrimaryPreferred = "primaryPreferred"
+	// Secondary reads from secondary replicas only
+	Secondary = "secondary"
+	// SecondaryPreferred reads from secondary replicas if they are available
+	SecondaryPreferred = "secondaryPreferred"
+	// Nearest reads from the replica with the lowest latency
+	Nearest = "nearest"
+)
+
+var (
+	modes           = []string{Eventual, Strong}
+	readPreferences = []string{Primary, PrimaryPreferred, Secondary, SecondaryPreferred, Nearest}
+)
+
+// GetSupported returns the consistency modes supported by a state store from its component metadata
+func GetSupported(metadata map[string]string) ([]string, error) {
+	declared := metadata[SupportedMetadataKey]
+	if declared == "" {
+		return modes, nil
+	}
+
+	var supported []string
+	for _, m := range strings.Split(declared, ",") {
+		m = strings.ToLower(strings.TrimSpace(m))
+		if !contains(modes, m) {
+			return nil, fmt.Errorf("invalid %s value %q", SupportedMetadataKey, m)
+		}
+		if !contains(supported, m) {
+			supported = append(supported, m)
+		}
+	}
+	return supported, nil
+}
+
+// Resolve returns the consistency of a state request: the requested consistency option if set,
+// otherwise the consistency of the request metadata. It returns an error if the consistency is not
+// supported by the store or if the read preference of the metadata is invalid or conflicts with it.
+func Resolve(requested string, metadata map[string]string, supported []string) (string, error) {
+	c := requested
+	if c == "" {
+		c = getMetadata(metadata, MetadataKey)
+	}
+	c = strings.ToLower(c)
+	if c != "" {
+		if !contains(modes, c) {
+			return "", fmt.Errorf("invalid consistency %q, must be one of %s", c, strings.Join(modes, ", "))
+		}
+		if supported != nil && !contains(supported, c) {
+			return "", fmt.Errorf("state store does not support %s consistency", c)
+		}
+	}
+
+	readPreference := getMetadata(metadata, ReadPreferenceMetadataKey)
+	if readPreference == "" {
+		return c, nil
+	}
+	if !contains(readPreferences, readPreference) {
+		return "", fmt.Errorf("invalid %s %q, must be one of %s", ReadPreferenceMetadataKey, readPreference, strings.Join(readPreferences, ", "))
+	}
+	if c == Strong && readPreference != Primary && readPreference != PrimaryPreferred {
+		return "", fmt.Errorf("%s %s conflicts with strong consistency", ReadPreferenceMetadataKey, readPreference)
+	}
+	return c, nil
+}
+
+func getMetadata(metadata map[string]string, key string) string {
+	for k, v := range metadata {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package consistency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSupported(t *testing.T) {
+	t.Run("all modes by default", func(t *testing.T) {
+		supported, err := GetSupported(map[string]string{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{Eventual, Strong}, supported)
+	})
+
+	t.Run("declared modes", func(t *testing.T) {
+		supported, err := GetSupported(map[string]string{SupportedMetadataKey: "Eventual, eventual"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{Eventual}, supported)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		_, err := GetSupported(map[string]string{SupportedMetadataKey: "eventual,linearizable"})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestResolve(t *testing.T) {
+	eventualOnly := []string{Eventual}
+
+	t.Run("option is used before metadata", func(t *testing.T) {
+		c, err := Resolve(Strong, map[string]string{MetadataKey: Eventual}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, Strong, c)
+	})
+
+	t.Run("metadata is used without option", func(t *testing.T) {
+		c, err := Resolve("", map[string]string{"Consistency": "Eventual"}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, Eventual, c)
+	})
+
+	t.Run("no consistency", func(t *testing.T) {
+		c, err := Resolve("", nil, eventualOnly)
+		assert.Nil(t, err)
+		assert.Equal(t, "", c)
+	})
+
+	t.Run("invalid consistency", func(t *testing.T) {
+		_, err := Resolve("linearizable", nil, nil)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("unsupported consistency", func(t *testing.T) {
+		_, err := Resolve(Strong, nil, eventualOnly)
+		assert.EqualError(t, err, "state store does not support strong consistency")
+	})
+
+	t.Run("read preference", func(t *testing.T) {
+		c, err := Resolve(Eventual, map[string]string{ReadPreferenceMetadataKey: Nearest}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, Eventual, c)
+	})
+
+	t.Run("invalid read preference", func(t *testing.T) {
+		_, err := Resolve("", map[string]string{ReadPreferenceMetadataKey: "anywhere"}, nil)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("read preference conflicts with strong consistency", func(t *testing.T) {
+		_, err := Resolve(Strong, map[string]string{ReadPreferenceMetadataKey: Secondary}, nil)
+		assert.EqualError(t, err, "readPreference secondary conflicts with strong consistency")
+
+		_, err = Resolve(Strong, map[string]string{ReadPreferenceMetadataKey: Primary}, nil)
+		assert.Nil(t, err)
+	})
+}
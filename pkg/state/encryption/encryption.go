@@ -0,0 +1,273 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+const (
+	// PrimaryEncryptionKey is the state store component metadata key holding the hex encoded AES key
+	// used to encrypt the values. It is usually a reference to a secret.
+	PrimaryEncryptionKey = "primaryEncryptionKey"
+	// SecondaryEncryptionKey is the state store component metadata key holding the hex encoded AES key
+	// of a previous primary key, which is only used to decrypt the values saved before a key rotation
+	SecondaryEncryptionKey = "secondaryEncryptionKey"
+	// AllowUnencryptedValuesKey is the state store component metadata key allowing, while the values of the
+	// store are migrated, the values saved before the store was encrypted to be read as is, and the values
+	// encrypted before the keys of the store were authenticated to be decrypted
+	AllowUnencryptedValuesKey = "allowUnencryptedValues"
+
+	// encryptedMarker prefixes the encrypted values, it is followed by the id of the key, a separator,
+	// the nonce and the ciphertext
+	encryptedMarker = "\x00dapr-enc:"
+	keyIDSeparator  = ':'
+)
+
+// Key is an AES-GCM key. Its id, derived from the key, is stored along with the encrypted values
+// so that they can be decrypted after the keys are rotated.
+type Key struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// ComponentEncryptionKeys are the keys of a state store
+type ComponentEncryptionKeys struct {
+	Primary   *Key
+	Secondary *Key
+	// AllowUnencrypted allows the values saved before the store was encrypted to be read during the migration
+	AllowUnencrypted bool
+}
+
+// NewKey returns a key from its hex encoded form. The key must be 128, 192 or 256 bits long.
+func NewKey(hexKey string) (*Key, error) {
+	b, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, errors.New("encryption key must be hex encoded")
+	}
+	block, err := aes.NewCipher(b)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(b)
+	return &Key{id: hex.EncodeToString(sum[:4]), aead: aead}, nil
+}
+
+// GetComponentEncryptionKeys returns the keys set in the state store component metadata,
+// or nil if the values of the store are not encrypted
+func GetComponentEncryptionKeys(metadata map[string]string) (*ComponentEncryptionKeys, error) {
+	primary, secondary := metadata[PrimaryEncryptionKey], metadata[SecondaryEncryptionKey]
+	if primary == "" {
+		if secondary != "" {
+			return nil, fmt.Errorf("%s requires %s", SecondaryEncryptionKey, PrimaryEncryptionKey)
+		}
+		return nil, nil
+	}
+
+	keys := &ComponentEncryptionKeys{}
+	var err error
+	if v, ok := metadata[AllowUnencryptedValuesKey]; ok {
+		if keys.AllowUnencrypted, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", AllowUnencryptedValuesKey, err)
+		}
+	}
+	if keys.Primary, err = NewKey(primary); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", PrimaryEncryptionKey, err)
+	}
+	if secondary != "" {
+		if keys.Secondary, err = NewKey(secondary); err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", SecondaryEncryptionKey, err)
+		}
+	}
+	return keys, nil
+}
+
+// Encrypt encrypts the value of the state key with the primary key. The state key is authenticated along with
+// the value, so that the value can't be decrypted once copied to another key.
+func (k *ComponentEncryptionKeys) Encrypt(key string, value []byte) ([]byte, error) {
+	nonce := make([]byte, k.Primary.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	out := []byte(encryptedMarker + k.Primary.id)
+	out = append(out, keyIDSeparator)
+	out = append(out, nonce...)
+	return k.Primary.aead.Seal(out, nonce, value, []byte(key)), nil
+}
+
+// Decrypt decrypts the value of the state key encrypted with the primary or secondary key.
+// Values which are not encrypted are rejected, unless they are allowed during the migration of the store.
+func (k *ComponentEncryptionKeys) Decrypt(key string, value []byte) ([]byte, error) {
+	if !bytes.HasPrefix(value, []byte(encryptedMarker)) {
+		if k.AllowUnencrypted {
+			return value, nil
+		}
+		return nil, errors.New("value is not encrypted")
+	}
+	rest := value[len(encryptedMarker):]
+	i := bytes.IndexByte(rest, keyIDSeparator)
+	if i < 0 {
+		return nil, errors.New("malformed encrypted value")
+	}
+
+	id := string(rest[:i])
+	var encryptionKey *Key
+	switch {
+	case k.Primary.id == id:
+		encryptionKey = k.Primary
+	case k.Secondary != nil && k.Secondary.id == id:
+		encryptionKey = k.Secondary
+	default:
+		return nil, fmt.Errorf("value is encrypted with the unknown key %s", id)
+	}
+
+	rest = rest[i+1:]
+	nonceSize := encryptionKey.aead.NonceSize()
+	if len(rest) < nonceSize {
+		return nil, errors.New("malformed encrypted value")
+	}
+	nonce, ciphertext := rest[:nonceSize], rest[nonceSize:]
+	plaintext, err := encryptionKey.aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil && k.AllowUnencrypted {
+		// the value was encrypted before the state keys were authenticated
+		return encryptionKey.aead.Open(nil, nonce, ciphertext, nil)
+	}
+	return plaintext, err
+}
+
+type encryptedStore struct {
+	store state.Store
+	keys  *ComponentEncryptionKeys
+}
+
+type transactionalEncryptedStore struct {
+	*encryptedStore
+	transactionalStore state.TransactionalStore
+}
+
+// NewStore returns a state store encrypting the values before they are saved in the given store
+// and decrypting them on read. The returned store supports transactions if the given store does.
+func NewStore(store state.Store, keys *ComponentEncryptionKeys) state.Store {
+	s := &encryptedStore{
+		store: store,
+		keys:  keys,
+	}
+	if transactionalStore, ok := store.(state.TransactionalStore); ok {
+		return &transactionalEncryptedStore{
+			encryptedStore:     s,
+			transactionalStore: transactionalStore,
+		}
+	}
+	return s
+}
+
+func (s *encryptedStore) Init(metadata state.Metadata) error {
+	return s.store.Init(metadata)
+}
+
+// Close closes the given store if it can be closed
+func (s *encryptedStore) Close() error {
+	if closer, ok := s.store.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (s *encryptedStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	resp, err := s.store.Get(req)
+	if err != nil || resp == nil || len(resp.Data) == 0 {
+		return resp, err
+	}
+
+	data, err := s.keys.Decrypt(req.Key, resp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the value of key %s: %s", req.Key, err)
+	}
+	return &state.GetResponse{
+		Data:     data,
+		ETag:     resp.ETag,
+		Metadata: resp.Metadata,
+	}, nil
+}
+
+func (s *encryptedStore) Set(req *state.SetRequest) error {
+	encrypted, err := s.encrypt(*req)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(&encrypted)
+}
+
+func (s *encryptedStore) BulkSet(req []state.SetRequest) error {
+	reqs := make([]state.SetRequest, 0, len(req))
+	for _, r := range req {
+		encrypted, err := s.encrypt(r)
+		if err != nil {
+			return err
+		}
+		reqs = append(reqs, encrypted)
+	}
+	return s.store.BulkSet(reqs)
+}
+
+func (s *encryptedStore) Delete(req *state.DeleteRequest) error {
+	return s.store.Delete(req)
+}
+
+func (s *encryptedStore) BulkDelete(req []state.DeleteRequest) error {
+	return s.store.BulkDelete(req)
+}
+
+// Multi executes the transaction with the values of the upserts encrypted
+func (s *transactionalEncryptedStore) Multi(reqs []state.TransactionalRequest) error {
+	converted := make([]state.TransactionalRequest, 0, len(reqs))
+	for _, r := range reqs {
+		if setReq, ok := r.Request.(state.SetRequest); ok && r.Operation == state.Upsert {
+			encrypted, err := s.encrypt(setReq)
+			if err != nil {
+				return err
+			}
+			r.Request = encrypted
+		}
+		converted = append(converted, r)
+	}
+	return s.transactionalStore.Multi(converted)
+}
+
+func (s *encryptedStore) encrypt(req state.SetRequest) (state.SetRequest, error) {
+	value, ok := req.Value.([]byte)
+	if !ok {
+		var err error
+		if value, err = json.Marshal(req.Value); err != nil {
+			return req, err
+		}
+	}
+
+	encrypted, err := s.keys.Encrypt(req.Key, value)
+	if err != nil {
+		return req, fmt.Errorf("failed to encrypt the value of key %s: %s", req.Key, err)
+	}
+	req.Value = encrypted
+	return req, nil
+}
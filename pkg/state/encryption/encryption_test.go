@@ -0,0 +1,187 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package encryption
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testKey1 = "000102030405060708090a0b0c0d0e0f"
+	testKey2 = "101112131415161718191a1b1c1d1e1f101112131415161718191a1b1c1d1e1f"
+)
+
+// fakeStore is an in memory state store
+type fakeStore struct {
+	items map[string][]byte
+}
+
+func (f *fakeStore) Init(metadata state.Metadata) error         { return nil }
+func (f *fakeStore) Delete(req *state.DeleteRequest) error      { delete(f.items, req.Key); return nil }
+func (f *fakeStore) BulkDelete(req []state.DeleteRequest) error { return nil }
+
+func (f *fakeStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return &state.GetResponse{Data: f.items[req.Key], ETag: "1"}, nil
+}
+
+func (f *fakeStore) Set(req *state.SetRequest) error {
+	b, ok := req.Value.([]byte)
+	if !ok {
+		b, _ = json.Marshal(req.Value)
+	}
+	f.items[req.Key] = b
+	return nil
+}
+
+func (f *fakeStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		f.Set(&req[i])
+	}
+	return nil
+}
+
+type fakeTransactionalStore struct {
+	*fakeStore
+}
+
+func (f fakeTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	for _, r := range reqs {
+		if req, ok := r.Request.(state.SetRequest); ok {
+			f.Set(&req)
+		}
+	}
+	return nil
+}
+
+func TestGetComponentEncryptionKeys(t *testing.T) {
+	keys, err := GetComponentEncryptionKeys(map[string]string{})
+	assert.NoError(t, err)
+	assert.Nil(t, keys)
+
+	keys, err = GetComponentEncryptionKeys(map[string]string{PrimaryEncryptionKey: testKey1, SecondaryEncryptionKey: testKey2})
+	assert.NoError(t, err)
+	assert.NotNil(t, keys.Primary)
+	assert.NotNil(t, keys.Secondary)
+
+	for name, metadata := range map[string]map[string]string{
+		"not hex":                   {PrimaryEncryptionKey: "key"},
+		"invalid length":            {PrimaryEncryptionKey: "0001"},
+		"secondary without primary": {SecondaryEncryptionKey: testKey2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := GetComponentEncryptionKeys(metadata)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEncryptedStore(t *testing.T) {
+	fake := &fakeStore{items: map[string][]byte{}}
+	keys, _ := GetComponentEncryptionKeys(map[string]string{PrimaryEncryptionKey: testKey1})
+	store := NewStore(fake, keys)
+
+	t.Run("values are encrypted at rest", func(t *testing.T) {
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "key1", Value: []byte("value1")}))
+		assert.False(t, bytes.Contains(fake.items["key1"], []byte("value1")))
+
+		resp, err := store.Get(&state.GetRequest{Key: "key1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "value1", string(resp.Data))
+		assert.Equal(t, "1", resp.ETag)
+	})
+
+	t.Run("values which are not bytes are serialized", func(t *testing.T) {
+		assert.NoError(t, store.BulkSet([]state.SetRequest{{Key: "key2", Value: map[string]string{"a": "b"}}}))
+		resp, err := store.Get(&state.GetRequest{Key: "key2"})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":"b"}`, string(resp.Data))
+	})
+
+	t.Run("values which are not encrypted are rejected", func(t *testing.T) {
+		fake.items["plain"] = []byte("plain")
+		_, err := store.Get(&state.GetRequest{Key: "plain"})
+		assert.Error(t, err)
+	})
+
+	t.Run("values copied to another key fail to decrypt", func(t *testing.T) {
+		fake.items["copy"] = fake.items["key1"]
+		_, err := store.Get(&state.GetRequest{Key: "copy"})
+		assert.Error(t, err)
+	})
+
+	t.Run("values are decrypted with the secondary key after a rotation", func(t *testing.T) {
+		rotated, _ := GetComponentEncryptionKeys(map[string]string{PrimaryEncryptionKey: testKey2, SecondaryEncryptionKey: testKey1})
+		rotatedStore := NewStore(fake, rotated)
+		resp, err := rotatedStore.Get(&state.GetRequest{Key: "key1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "value1", string(resp.Data))
+
+		assert.NoError(t, rotatedStore.Set(&state.SetRequest{Key: "key3", Value: []byte("value3")}))
+		_, err = store.Get(&state.GetRequest{Key: "key3"})
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered values fail to decrypt", func(t *testing.T) {
+		data := fake.items["key1"]
+		data[len(data)-1] ^= 1
+		_, err := store.Get(&state.GetRequest{Key: "key1"})
+		assert.Error(t, err)
+	})
+}
+
+func TestMigration(t *testing.T) {
+	fake := &fakeStore{items: map[string][]byte{}}
+	keys, err := GetComponentEncryptionKeys(map[string]string{PrimaryEncryptionKey: testKey1, AllowUnencryptedValuesKey: "true"})
+	assert.NoError(t, err)
+	assert.True(t, keys.AllowUnencrypted)
+	store := NewStore(fake, keys)
+
+	t.Run("values saved before encryption are returned as is", func(t *testing.T) {
+		fake.items["plain"] = []byte("plain")
+		resp, err := store.Get(&state.GetRequest{Key: "plain"})
+		assert.NoError(t, err)
+		assert.Equal(t, "plain", string(resp.Data))
+	})
+
+	t.Run("values encrypted without their key are decrypted", func(t *testing.T) {
+		nonce := make([]byte, keys.Primary.aead.NonceSize())
+		out := append([]byte(encryptedMarker+keys.Primary.id+string(keyIDSeparator)), nonce...)
+		fake.items["legacy"] = keys.Primary.aead.Seal(out, nonce, []byte("legacy"), nil)
+		resp, err := store.Get(&state.GetRequest{Key: "legacy"})
+		assert.NoError(t, err)
+		assert.Equal(t, "legacy", string(resp.Data))
+	})
+
+	t.Run("invalid option", func(t *testing.T) {
+		_, err := GetComponentEncryptionKeys(map[string]string{PrimaryEncryptionKey: testKey1, AllowUnencryptedValuesKey: "maybe"})
+		assert.Error(t, err)
+	})
+}
+
+func TestTransactionalEncryptedStore(t *testing.T) {
+	fake := &fakeStore{items: map[string][]byte{}}
+	keys, _ := GetComponentEncryptionKeys(map[string]string{PrimaryEncryptionKey: testKey1})
+	store := NewStore(fakeTransactionalStore{fake}, keys)
+	transactionalStore, ok := store.(state.TransactionalStore)
+	assert.True(t, ok)
+
+	err := transactionalStore.Multi([]state.TransactionalRequest{
+		{Operation: state.Upsert, Request: state.SetRequest{Key: "key1", Value: []byte("value1")}},
+		{Operation: state.Delete, Request: state.DeleteRequest{Key: "key2"}},
+	})
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(fake.items["key1"], []byte("value1")))
+	resp, _ := store.Get(&state.GetRequest{Key: "key1"})
+	assert.Equal(t, "value1", string(resp.Data))
+
+	_, ok = NewStore(fake, keys).(state.TransactionalStore)
+	assert.False(t, ok)
+}
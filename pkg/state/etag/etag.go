@@ -0,0 +1,89 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package etag
+
+import (
+	"fmt"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+// MismatchError is returned when a key is saved or deleted with an ETag which is not the current ETag of the key.
+// CurrentETag is empty if the key does not exist.
+type MismatchError struct {
+	Key         string
+	ETag        string
+	CurrentETag string
+	err         error
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("etag mismatch for key %s: %s", e.Key, e.err)
+}
+
+func (e *MismatchError) Unwrap() error {
+	return e.err
+}
+
+// Check returns a MismatchError wrapping err if the save or delete of the key with the given ETag failed
+// because the ETag is not the current ETag of the key, otherwise err is returned as is.
+// As state stores don't report mismatches in a common way, the current ETag of the key is read
+// after the failure and compared with the requested one.
+func Check(store state.Store, err error, key, etag string) error {
+	if err == nil || etag == "" {
+		return err
+	}
+	if _, ok := err.(*MismatchError); ok {
+		return err
+	}
+
+	resp, getErr := store.Get(&state.GetRequest{Key: key})
+	if getErr != nil {
+		return err
+	}
+	current := ""
+	if resp != nil {
+		current = resp.ETag
+	}
+	if current == etag {
+		return err
+	}
+	return &MismatchError{
+		Key:         key,
+		ETag:        etag,
+		CurrentETag: current,
+		err:         err,
+	}
+}
+
+// CheckSet checks the ETags of the saves which failed with err, see Check
+func CheckSet(store state.Store, err error, reqs []state.SetRequest) error {
+	for i := 0; err != nil && i < len(reqs); i++ {
+		err = Check(store, err, reqs[i].Key, reqs[i].ETag)
+	}
+	return err
+}
+
+// CheckDelete checks the ETags of the deletes which failed with err, see Check
+func CheckDelete(store state.Store, err error, reqs []state.DeleteRequest) error {
+	for i := 0; err != nil && i < len(reqs); i++ {
+		err = Check(store, err, reqs[i].Key, reqs[i].ETag)
+	}
+	return err
+}
+
+// CheckTransaction checks the ETags of the operations of a transaction which failed with err, see Check
+func CheckTransaction(store state.Store, err error, reqs []state.TransactionalRequest) error {
+	for i := 0; err != nil && i < len(reqs); i++ {
+		switch r := reqs[i].Request.(type) {
+		case state.SetRequest:
+			err = Check(store, err, r.Key, r.ETag)
+		case state.DeleteRequest:
+			err = Check(store, err, r.Key, r.ETag)
+		}
+	}
+	return err
+}
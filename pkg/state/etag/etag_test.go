@@ -0,0 +1,89 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package etag
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	etags map[string]string
+}
+
+func (f *fakeStore) Init(metadata state.Metadata) error         { return nil }
+func (f *fakeStore) Delete(req *state.DeleteRequest) error      { return nil }
+func (f *fakeStore) BulkDelete(req []state.DeleteRequest) error { return nil }
+func (f *fakeStore) Set(req *state.SetRequest) error            { return nil }
+func (f *fakeStore) BulkSet(req []state.SetRequest) error       { return nil }
+
+func (f *fakeStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	etag, ok := f.etags[req.Key]
+	if !ok {
+		return nil, nil
+	}
+	return &state.GetResponse{Data: []byte("value"), ETag: etag}, nil
+}
+
+func TestCheck(t *testing.T) {
+	store := &fakeStore{etags: map[string]string{"key1": "2"}}
+	failure := errors.New("failed")
+
+	t.Run("no error", func(t *testing.T) {
+		assert.Nil(t, Check(store, nil, "key1", "1"))
+	})
+
+	t.Run("no etag", func(t *testing.T) {
+		assert.Equal(t, failure, Check(store, failure, "key1", ""))
+	})
+
+	t.Run("etag matches", func(t *testing.T) {
+		assert.Equal(t, failure, Check(store, failure, "key1", "2"))
+	})
+
+	t.Run("etag mismatch", func(t *testing.T) {
+		err := Check(store, failure, "key1", "1")
+		var mismatch *MismatchError
+		assert.True(t, errors.As(err, &mismatch))
+		assert.Equal(t, "key1", mismatch.Key)
+		assert.Equal(t, "1", mismatch.ETag)
+		assert.Equal(t, "2", mismatch.CurrentETag)
+		assert.True(t, errors.Is(err, failure))
+	})
+
+	t.Run("key does not exist", func(t *testing.T) {
+		err := Check(store, failure, "key2", "1")
+		var mismatch *MismatchError
+		assert.True(t, errors.As(err, &mismatch))
+		assert.Equal(t, "", mismatch.CurrentETag)
+	})
+}
+
+func TestCheckSet(t *testing.T) {
+	store := &fakeStore{etags: map[string]string{"key1": "1", "key2": "2"}}
+	err := CheckSet(store, errors.New("failed"), []state.SetRequest{
+		{Key: "key1", ETag: "1"},
+		{Key: "key2", ETag: "1"},
+	})
+	var mismatch *MismatchError
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, "key2", mismatch.Key)
+}
+
+func TestCheckTransaction(t *testing.T) {
+	store := &fakeStore{etags: map[string]string{"key1": "1", "key2": "2"}}
+	err := CheckTransaction(store, errors.New("failed"), []state.TransactionalRequest{
+		{Operation: state.Upsert, Request: state.SetRequest{Key: "key1", ETag: "1"}},
+		{Operation: state.Delete, Request: state.DeleteRequest{Key: "key2", ETag: "1"}},
+	})
+	var mismatch *MismatchError
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, "key2", mismatch.Key)
+	assert.Equal(t, "2", mismatch.CurrentETag)
+}
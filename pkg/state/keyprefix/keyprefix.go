@@ -0,0 +1,88 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package keyprefix
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MetadataKey is the state store component metadata key selecting how the keys saved by apps are prefixed
+	MetadataKey = "keyPrefix"
+
+	// AppID prefixes the keys with the id of the app, which isolates the state of each app. This is the default.
+	AppID = "appid"
+	// Name prefixes the keys with the name of the state store component, which shares the state
+	// between all the apps using the component
+	Name = "name"
+	// Namespace prefixes the keys with the namespace and the id of the app, which isolates
+	// the state of apps with the same id in different namespaces
+	Namespace = "namespace"
+	// None doesn't prefix the keys, which shares the state with all the apps and the other clients of the store
+	None = "none"
+
+	// Internal prefixes the keys the runtime saves in the state stores for its own use. The keys saved by
+	// apps are prefixed with one of the strategies above, so they only start with it if they aren't prefixed.
+	Internal = "dapr-internal" + separator
+
+	separator = "||"
+)
+
+// Prefix is the prefix of the keys saved in a state store
+type Prefix struct {
+	Strategy string
+	value    string
+}
+
+// New returns the key prefix selected by the state store component metadata.
+// The keys are prefixed with the app id if the metadata selects no strategy.
+func New(metadata map[string]string, appID, storeName, namespace string) (Prefix, error) {
+	strategy := strings.ToLower(metadata[MetadataKey])
+	if strategy == "" {
+		strategy = AppID
+	}
+
+	p := Prefix{Strategy: strategy}
+	switch strategy {
+	case AppID:
+		if appID != "" {
+			p.value = appID + separator
+		}
+	case Name:
+		p.value = storeName + separator
+	case Namespace:
+		if namespace == "" {
+			return Prefix{}, fmt.Errorf("%s %s requires the namespace of the app", MetadataKey, Namespace)
+		}
+		p.value = namespace + "." + appID + separator
+	case None:
+	default:
+		return Prefix{}, fmt.Errorf("invalid %s %s, must be one of %s, %s, %s or %s", MetadataKey, strategy, AppID, Name, Namespace, None)
+	}
+	return p, nil
+}
+
+// InternalKey returns the key saved by the runtime for its own use made of the given parts, such as the
+// name of the feature and the id of the app
+func InternalKey(parts ...string) string {
+	return Internal + strings.Join(parts, separator)
+}
+
+// Key returns the key saved in the state store for the key of the app
+func (p Prefix) Key(key string) string {
+	return p.value + key
+}
+
+// OriginalKey returns the key of the app for a key saved in the state store
+func (p Prefix) OriginalKey(key string) string {
+	return strings.TrimPrefix(key, p.value)
+}
+
+// Format describes the keys saved in the state store, which helps migrating the state when the strategy changes
+func (p Prefix) Format() string {
+	return p.value + "<key>"
+}
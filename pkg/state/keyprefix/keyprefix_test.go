@@ -0,0 +1,51 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package keyprefix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		strategy string
+		appID    string
+		key      string
+		format   string
+	}{
+		{strategy: "", appID: "app1", key: "app1||key1", format: "app1||<key>"},
+		{strategy: "appid", appID: "app1", key: "app1||key1", format: "app1||<key>"},
+		{strategy: "AppID", appID: "", key: "key1", format: "<key>"},
+		{strategy: "name", appID: "app1", key: "store1||key1", format: "store1||<key>"},
+		{strategy: "namespace", appID: "app1", key: "ns1.app1||key1", format: "ns1.app1||<key>"},
+		{strategy: "none", appID: "app1", key: "key1", format: "<key>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			p, err := New(map[string]string{MetadataKey: tt.strategy}, tt.appID, "store1", "ns1")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.key, p.Key("key1"))
+			assert.Equal(t, "key1", p.OriginalKey(tt.key))
+			assert.Equal(t, tt.format, p.Format())
+		})
+	}
+
+	t.Run("invalid strategy", func(t *testing.T) {
+		_, err := New(map[string]string{MetadataKey: "type"}, "app1", "store1", "ns1")
+		assert.Error(t, err)
+	})
+
+	t.Run("namespace strategy without namespace", func(t *testing.T) {
+		_, err := New(map[string]string{MetadataKey: "namespace"}, "app1", "store1", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestInternalKey(t *testing.T) {
+	assert.Equal(t, "dapr-internal||ttlindex||app1||3", InternalKey("ttlindex", "app1", "3"))
+}
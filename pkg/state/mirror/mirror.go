@@ -0,0 +1,309 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package mirror
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	// MetadataKey is the state store component metadata key holding the name of the secondary
+	// state store the writes are mirrored to. Writes are not mirrored if it is not set.
+	MetadataKey = "mirrorTo"
+	// QueueSizeMetadataKey is the state store component metadata key holding the maximum number
+	// of writes waiting to be mirrored
+	QueueSizeMetadataKey = "mirrorQueueSize"
+	// DefaultQueueSize is the queue size of stores which don't set one
+	DefaultQueueSize = 10000
+	// DefaultRetryInterval is the default interval after which a write which failed to be mirrored is replayed
+	DefaultRetryInterval = time.Second
+
+	maxRetryInterval = time.Minute
+)
+
+// Config is the mirroring configuration of a state store
+type Config struct {
+	Target    string
+	QueueSize int
+}
+
+// GetConfig returns the mirroring configuration set in the component metadata of the state store
+// with the given name, or nil if its writes are not mirrored
+func GetConfig(metadata map[string]string, storeName string) (*Config, error) {
+	target, size := metadata[MetadataKey], metadata[QueueSizeMetadataKey]
+	if target == "" {
+		if size != "" {
+			return nil, fmt.Errorf("%s requires %s", QueueSizeMetadataKey, MetadataKey)
+		}
+		return nil, nil
+	}
+	if target == storeName {
+		return nil, fmt.Errorf("state store %s can't be mirrored to itself", storeName)
+	}
+
+	config := &Config{Target: target, QueueSize: DefaultQueueSize}
+	if size != "" {
+		var err error
+		if config.QueueSize, err = strconv.Atoi(size); err != nil || config.QueueSize <= 0 {
+			return nil, fmt.Errorf("invalid %s value %q", QueueSizeMetadataKey, size)
+		}
+	}
+	return config, nil
+}
+
+// Target is the secondary state store the writes are mirrored to
+type Target interface {
+	// Store returns the secondary store, or false if it is not initialized yet
+	Store() (state.Store, bool)
+	// Key returns the key in the secondary store of a key of the primary store
+	Key(key string) string
+}
+
+// Store is a state store mirroring its writes to a secondary state store
+type Store interface {
+	state.Store
+	// Start mirrors the writes in the background. A write which fails to be mirrored is replayed
+	// after the given interval, which doubles with each failure of the same write.
+	Start(retryInterval time.Duration)
+	// Pending returns the number of writes which are not mirrored yet
+	Pending() int
+	// Close stops mirroring the writes and closes the given store if it can be closed.
+	// The writes which are not mirrored yet are dropped.
+	io.Closer
+}
+
+type mirroredStore struct {
+	store      state.Store
+	target     Target
+	targetName string
+	log        logger.Logger
+
+	lock  sync.Mutex
+	queue chan []state.TransactionalRequest
+	// pending is the number of queued writes which are not mirrored yet
+	pending   int
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type transactionalMirroredStore struct {
+	*mirroredStore
+	transactionalStore state.TransactionalStore
+}
+
+// NewStore returns a Store mirroring the successful writes made to the given store to the target,
+// in the order they were made. The writes are queued until Start is called. Writes made while the
+// queue is full are not mirrored and logged as errors, so that a slow or unavailable target never
+// blocks the app. The ETags of the writes are not mirrored as the ETags of the two stores differ.
+// The returned store supports transactions if the given store does.
+func NewStore(store state.Store, target Target, config Config, log logger.Logger) Store {
+	s := &mirroredStore{
+		store:      store,
+		target:     target,
+		targetName: config.Target,
+		log:        log,
+		queue:      make(chan []state.TransactionalRequest, config.QueueSize),
+		done:       make(chan struct{}),
+	}
+	if transactionalStore, ok := store.(state.TransactionalStore); ok {
+		return &transactionalMirroredStore{
+			mirroredStore:      s,
+			transactionalStore: transactionalStore,
+		}
+	}
+	return s
+}
+
+func (s *mirroredStore) Init(metadata state.Metadata) error {
+	return s.store.Init(metadata)
+}
+
+func (s *mirroredStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return s.store.Get(req)
+}
+
+func (s *mirroredStore) Set(req *state.SetRequest) error {
+	if err := s.store.Set(req); err != nil {
+		return err
+	}
+	s.enqueue(upsert(*req))
+	return nil
+}
+
+func (s *mirroredStore) BulkSet(req []state.SetRequest) error {
+	if err := s.store.BulkSet(req); err != nil {
+		return err
+	}
+	ops := make([]state.TransactionalRequest, 0, len(req))
+	for _, r := range req {
+		ops = append(ops, upsert(r))
+	}
+	s.enqueue(ops...)
+	return nil
+}
+
+func (s *mirroredStore) Delete(req *state.DeleteRequest) error {
+	if err := s.store.Delete(req); err != nil {
+		return err
+	}
+	s.enqueue(remove(*req))
+	return nil
+}
+
+func (s *mirroredStore) BulkDelete(req []state.DeleteRequest) error {
+	if err := s.store.BulkDelete(req); err != nil {
+		return err
+	}
+	ops := make([]state.TransactionalRequest, 0, len(req))
+	for _, r := range req {
+		ops = append(ops, remove(r))
+	}
+	s.enqueue(ops...)
+	return nil
+}
+
+// Multi executes the transaction and mirrors its operations, as a transaction if the target supports them
+func (s *transactionalMirroredStore) Multi(reqs []state.TransactionalRequest) error {
+	if err := s.transactionalStore.Multi(reqs); err != nil {
+		return err
+	}
+	ops := make([]state.TransactionalRequest, 0, len(reqs))
+	for _, r := range reqs {
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			ops = append(ops, upsert(req))
+		case state.DeleteRequest:
+			ops = append(ops, remove(req))
+		}
+	}
+	s.enqueue(ops...)
+	return nil
+}
+
+func (s *mirroredStore) Start(retryInterval time.Duration) {
+	go func() {
+		for {
+			select {
+			case ops := <-s.queue:
+				s.replay(ops, retryInterval)
+				s.lock.Lock()
+				s.pending -= len(ops)
+				s.lock.Unlock()
+			case <-s.done:
+				return
+			}
+		}
+	}()
+}
+
+func (s *mirroredStore) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	if closer, ok := s.store.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (s *mirroredStore) Pending() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.pending
+}
+
+func (s *mirroredStore) enqueue(ops ...state.TransactionalRequest) {
+	if len(ops) == 0 {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	select {
+	case s.queue <- ops:
+		s.pending += len(ops)
+	default:
+		s.log.Errorf("mirror queue is full, %d writes are not mirrored to state store %s", len(ops), s.targetName)
+	}
+}
+
+// replay mirrors the operations until it succeeds, which keeps the writes ordered.
+// Replaying operations which were partially mirrored is safe as they don't carry ETags.
+func (s *mirroredStore) replay(ops []state.TransactionalRequest, retryInterval time.Duration) {
+	interval := retryInterval
+	for {
+		err := s.mirror(ops)
+		if err == nil {
+			return
+		}
+		s.log.Warnf("error mirroring %d writes to state store %s, retrying in %s: %s", len(ops), s.targetName, interval, err)
+		select {
+		case <-time.After(interval):
+		case <-s.done:
+			s.log.Warnf("mirroring to state store %s is stopped, %d writes are not mirrored", s.targetName, len(ops))
+			return
+		}
+		if interval *= 2; interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+	}
+}
+
+func (s *mirroredStore) mirror(ops []state.TransactionalRequest) error {
+	store, ok := s.target.Store()
+	if !ok {
+		return fmt.Errorf("state store %s is not initialized", s.targetName)
+	}
+
+	ops = s.mapKeys(ops)
+	if transactionalStore, ok := store.(state.TransactionalStore); ok && len(ops) > 1 {
+		return transactionalStore.Multi(ops)
+	}
+	for _, op := range ops {
+		var err error
+		switch req := op.Request.(type) {
+		case state.SetRequest:
+			err = store.Set(&req)
+		case state.DeleteRequest:
+			err = store.Delete(&req)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *mirroredStore) mapKeys(ops []state.TransactionalRequest) []state.TransactionalRequest {
+	mapped := make([]state.TransactionalRequest, 0, len(ops))
+	for _, op := range ops {
+		switch req := op.Request.(type) {
+		case state.SetRequest:
+			req.Key = s.target.Key(req.Key)
+			op.Request = req
+		case state.DeleteRequest:
+			req.Key = s.target.Key(req.Key)
+			op.Request = req
+		}
+		mapped = append(mapped, op)
+	}
+	return mapped
+}
+
+func upsert(req state.SetRequest) state.TransactionalRequest {
+	req.ETag = ""
+	return state.TransactionalRequest{Operation: state.Upsert, Request: req}
+}
+
+func remove(req state.DeleteRequest) state.TransactionalRequest {
+	req.ETag = ""
+	return state.TransactionalRequest{Operation: state.Delete, Request: req}
+}
@@ -0,0 +1,220 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package mirror
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+var log = logger.NewLogger("dapr.test")
+
+type fakeStore struct {
+	lock  sync.Mutex
+	items map[string][]byte
+	etags map[string]string
+	// failures is the number of writes failing before the store recovers
+	failures int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{items: map[string][]byte{}, etags: map[string]string{}}
+}
+
+func (f *fakeStore) Init(metadata state.Metadata) error { return nil }
+
+func (f *fakeStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return &state.GetResponse{Data: f.items[req.Key]}, nil
+}
+
+func (f *fakeStore) Set(req *state.SetRequest) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("unavailable")
+	}
+	f.items[req.Key] = req.Value.([]byte)
+	f.etags[req.Key] = req.ETag
+	return nil
+}
+
+func (f *fakeStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		if err := f.Set(&req[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeStore) Delete(req *state.DeleteRequest) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.items, req.Key)
+	return nil
+}
+
+func (f *fakeStore) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		f.Delete(&req[i])
+	}
+	return nil
+}
+
+func (f *fakeStore) get(key string) (string, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	data, ok := f.items[key]
+	return string(data), ok
+}
+
+type fakeTransactionalStore struct {
+	*fakeStore
+	transactions int
+}
+
+func (f *fakeTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	f.transactions++
+	for _, r := range reqs {
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			f.Set(&req)
+		case state.DeleteRequest:
+			f.Delete(&req)
+		}
+	}
+	return nil
+}
+
+type fakeTarget struct {
+	store state.Store
+}
+
+func (t fakeTarget) Store() (state.Store, bool) {
+	return t.store, t.store != nil
+}
+
+func (t fakeTarget) Key(key string) string {
+	return "secondary||" + key
+}
+
+func waitMirrored(t *testing.T, store Store) {
+	deadline := time.Now().Add(time.Second)
+	for store.Pending() > 0 {
+		if time.Now().After(deadline) {
+			assert.Fail(t, "writes were not mirrored")
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	config, err := GetConfig(map[string]string{}, "store1")
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = GetConfig(map[string]string{MetadataKey: "store2"}, "store1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{Target: "store2", QueueSize: DefaultQueueSize}, config)
+
+	config, err = GetConfig(map[string]string{MetadataKey: "store2", QueueSizeMetadataKey: "10"}, "store1")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, config.QueueSize)
+
+	_, err = GetConfig(map[string]string{MetadataKey: "store1"}, "store1")
+	assert.Error(t, err)
+	_, err = GetConfig(map[string]string{QueueSizeMetadataKey: "10"}, "store1")
+	assert.Error(t, err)
+	_, err = GetConfig(map[string]string{MetadataKey: "store2", QueueSizeMetadataKey: "-1"}, "store1")
+	assert.Error(t, err)
+}
+
+func TestMirroredStore(t *testing.T) {
+	t.Run("writes are mirrored", func(t *testing.T) {
+		primary, secondary := newFakeStore(), newFakeStore()
+		store := NewStore(primary, fakeTarget{secondary}, Config{Target: "store2", QueueSize: 10}, log)
+		store.Start(time.Millisecond)
+
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "key1", Value: []byte("value1"), ETag: "1"}))
+		assert.NoError(t, store.BulkSet([]state.SetRequest{{Key: "key2", Value: []byte("value2")}}))
+		assert.NoError(t, store.Delete(&state.DeleteRequest{Key: "key2"}))
+		waitMirrored(t, store)
+
+		value, _ := secondary.get("secondary||key1")
+		assert.Equal(t, "value1", value)
+		assert.Equal(t, "", secondary.etags["secondary||key1"])
+		_, ok := secondary.get("secondary||key2")
+		assert.False(t, ok)
+		assert.Equal(t, "1", primary.etags["key1"])
+	})
+
+	t.Run("failed writes are not mirrored", func(t *testing.T) {
+		primary, secondary := newFakeStore(), newFakeStore()
+		primary.failures = 1
+		store := NewStore(primary, fakeTarget{secondary}, Config{Target: "store2", QueueSize: 10}, log)
+
+		assert.Error(t, store.Set(&state.SetRequest{Key: "key1", Value: []byte("value1")}))
+		assert.Equal(t, 0, store.Pending())
+	})
+
+	t.Run("writes are replayed until the target recovers", func(t *testing.T) {
+		secondary := newFakeStore()
+		secondary.failures = 3
+		store := NewStore(newFakeStore(), fakeTarget{secondary}, Config{Target: "store2", QueueSize: 10}, log)
+		store.Start(time.Millisecond)
+
+		store.Set(&state.SetRequest{Key: "key1", Value: []byte("value1")})
+		store.Set(&state.SetRequest{Key: "key1", Value: []byte("value2")})
+		waitMirrored(t, store)
+
+		value, _ := secondary.get("secondary||key1")
+		assert.Equal(t, "value2", value)
+	})
+
+	t.Run("writes are queued until the target is initialized", func(t *testing.T) {
+		store := NewStore(newFakeStore(), fakeTarget{}, Config{Target: "store2", QueueSize: 10}, log)
+		store.Start(time.Millisecond)
+
+		store.Set(&state.SetRequest{Key: "key1", Value: []byte("value1")})
+		time.Sleep(5 * time.Millisecond)
+		assert.Equal(t, 1, store.Pending())
+	})
+
+	t.Run("writes are dropped when the queue is full", func(t *testing.T) {
+		store := NewStore(newFakeStore(), fakeTarget{newFakeStore()}, Config{Target: "store2", QueueSize: 1}, log)
+
+		store.Set(&state.SetRequest{Key: "key1", Value: []byte("value1")})
+		store.Set(&state.SetRequest{Key: "key2", Value: []byte("value2")})
+		assert.Equal(t, 1, store.Pending())
+	})
+
+	t.Run("transactions are mirrored as transactions", func(t *testing.T) {
+		secondary := &fakeTransactionalStore{fakeStore: newFakeStore()}
+		store := NewStore(&fakeTransactionalStore{fakeStore: newFakeStore()}, fakeTarget{secondary}, Config{Target: "store2", QueueSize: 10}, log)
+		store.Start(time.Millisecond)
+
+		err := store.(state.TransactionalStore).Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "key1", Value: []byte("value1")}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "key2"}},
+		})
+		assert.NoError(t, err)
+		waitMirrored(t, store)
+
+		assert.Equal(t, 1, secondary.transactions)
+		value, _ := secondary.get("secondary||key1")
+		assert.Equal(t, "value1", value)
+	})
+}
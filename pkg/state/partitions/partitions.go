@@ -0,0 +1,192 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package partitions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/state/etag"
+	"github.com/dapr/dapr/pkg/state/keyprefix"
+)
+
+const (
+	// DefaultCount is the default number of partitions the records of a feature are spread over
+	DefaultCount = 16
+	// updateAttempts is the number of times the update of a partition is attempted when the partition is
+	// updated concurrently by another replica of the app
+	updateAttempts = 5
+)
+
+// Records are the records of a partition keyed by their id
+type Records map[string]json.RawMessage
+
+// Partitions keeps the records the runtime saves for one of its features, such as the pending outbox events,
+// in a fixed number of reserved keys of a state store. Each partition holds the records of the ids hashed to it,
+// and is updated with first-write concurrency, so the replicas of an app only conflict when they update the
+// same partition at the same time. The conflicting updates are attempted again on the partition read again.
+//
+// The state stores don't all support saving a key only if it doesn't exist: a save without ETag is unconditional.
+// So the records are only saved in a partition which exists, with its ETag, and a partition which doesn't exist is
+// first created without records. The features create their partitions when they start, with Create, so the
+// replicas of an app don't create a partition while another replica saves records in it.
+type Partitions struct {
+	store    state.Store
+	count    int
+	keyParts []string
+}
+
+// New returns the partitions of the store whose keys are the reserved keys made of the given parts
+func New(store state.Store, count int, keyParts ...string) *Partitions {
+	if count <= 0 {
+		count = DefaultCount
+	}
+	return &Partitions{
+		store:    store,
+		count:    count,
+		keyParts: keyParts,
+	}
+}
+
+// Count returns the number of partitions, which are numbered from 0
+func (p *Partitions) Count() int {
+	return p.count
+}
+
+// Of returns the partition of the record with the id
+func (p *Partitions) Of(id string) int {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return int(h.Sum32() % uint32(p.count))
+}
+
+// Key returns the key the partition is saved under
+func (p *Partitions) Key(partition int) string {
+	parts := append(append([]string{}, p.keyParts...), strconv.Itoa(partition))
+	return keyprefix.InternalKey(parts...)
+}
+
+// Get returns the records of the partition and its ETag, which is empty when the partition isn't saved yet
+func (p *Partitions) Get(partition int) (Records, string, error) {
+	records, etag, _, err := p.get(partition)
+	return records, etag, err
+}
+
+func (p *Partitions) get(partition int) (Records, string, bool, error) {
+	resp, err := p.store.Get(&state.GetRequest{Key: p.Key(partition)})
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error getting partition %d: %s", partition, err)
+	}
+
+	records := Records{}
+	if resp == nil || len(resp.Data) == 0 {
+		return records, "", false, nil
+	}
+	if err := json.Unmarshal(resp.Data, &records); err != nil {
+		return nil, "", false, fmt.Errorf("error deserializing partition %d: %s", partition, err)
+	}
+	return records, resp.ETag, true, nil
+}
+
+// Create creates the partitions which don't exist yet, without records
+func (p *Partitions) Create() error {
+	for partition := 0; partition < p.count; partition++ {
+		if _, _, err := p.GetForUpdate(partition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetForUpdate returns the records of the partition and its ETag, to save the records updated with SetRequest.
+// The partition is created first when it doesn't exist yet. The ETag is only empty for the state stores which
+// don't support ETags.
+func (p *Partitions) GetForUpdate(partition int) (Records, string, error) {
+	records, etag, exists, err := p.get(partition)
+	if err != nil || exists {
+		return records, etag, err
+	}
+	req := state.SetRequest{Key: p.Key(partition), Value: Records{}}
+	if err := p.store.Set(&req); err != nil {
+		return nil, "", fmt.Errorf("error creating partition %d: %s", partition, err)
+	}
+	return p.Get(partition)
+}
+
+// IsConflict returns whether the save of the partition failed with err because the partition was updated
+// concurrently, err is the error of the save checked with the etag package
+func (p *Partitions) IsConflict(partition int, err error) bool {
+	var mismatch *etag.MismatchError
+	return errors.As(err, &mismatch) && mismatch.Key == p.Key(partition)
+}
+
+// SetRequest returns the request saving the records of the partition with the ETag returned by GetForUpdate
+func (p *Partitions) SetRequest(partition int, records Records, etag string) state.SetRequest {
+	return state.SetRequest{
+		Key:     p.Key(partition),
+		Value:   records,
+		ETag:    etag,
+		Options: state.SetStateOption{Concurrency: state.FirstWrite},
+	}
+}
+
+// Update saves the records of the partition changed by updateFn, which returns whether it changed them.
+// updateFn is called again on the records read again when the partition was updated concurrently.
+func (p *Partitions) Update(partition int, updateFn func(Records) (bool, error)) error {
+	var err error
+	for attempt := 0; attempt < updateAttempts; attempt++ {
+		records, tag, getErr := p.GetForUpdate(partition)
+		if getErr != nil {
+			return getErr
+		}
+		changed, fnErr := updateFn(records)
+		if fnErr != nil {
+			return fnErr
+		}
+		if !changed {
+			return nil
+		}
+		req := p.SetRequest(partition, records, tag)
+		err = p.store.Set(&req)
+		if err == nil {
+			return nil
+		}
+		if err = etag.Check(p.store, err, req.Key, req.ETag); !p.IsConflict(partition, err) {
+			break
+		}
+	}
+	return fmt.Errorf("error saving partition %d: %s", partition, err)
+}
+
+// Put saves the record with the id in its partition, replacing the record with the same id
+func (p *Partitions) Put(id string, record interface{}) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return p.Update(p.Of(id), func(records Records) (bool, error) {
+		records[id] = b
+		return true, nil
+	})
+}
+
+// Remove deletes the records with the ids from the partition, removing records which don't exist is not an error
+func (p *Partitions) Remove(partition int, ids ...string) error {
+	return p.Update(partition, func(records Records) (bool, error) {
+		changed := false
+		for _, id := range ids {
+			if _, ok := records[id]; ok {
+				delete(records, id)
+				changed = true
+			}
+		}
+		return changed, nil
+	})
+}
@@ -0,0 +1,199 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package partitions
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/state/keyprefix"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore is a state store with etags, which are the versions of the keys. As the state stores of
+// components-contrib, it saves the keys unconditionally when the ETag is empty.
+type fakeStore struct {
+	items    map[string][]byte
+	versions map[string]int
+	// beforeSet is called before the keys are saved
+	beforeSet func()
+	// setErr fails the saves
+	setErr error
+	sets   int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{items: map[string][]byte{}, versions: map[string]int{}}
+}
+
+func (f *fakeStore) Init(metadata state.Metadata) error {
+	return nil
+}
+
+func (f *fakeStore) Delete(req *state.DeleteRequest) error {
+	delete(f.items, req.Key)
+	return nil
+}
+
+func (f *fakeStore) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	if _, ok := f.items[req.Key]; !ok {
+		return &state.GetResponse{}, nil
+	}
+	return &state.GetResponse{Data: f.items[req.Key], ETag: strconv.Itoa(f.versions[req.Key])}, nil
+}
+
+func (f *fakeStore) Set(req *state.SetRequest) error {
+	if f.beforeSet != nil {
+		f.beforeSet()
+	}
+	f.sets++
+	if f.setErr != nil {
+		return f.setErr
+	}
+	if req.ETag != "" && req.ETag != strconv.Itoa(f.versions[req.Key]) {
+		return errors.New("etag mismatch")
+	}
+	b, _ := json.Marshal(req.Value)
+	f.items[req.Key] = b
+	f.versions[req.Key]++
+	return nil
+}
+
+func (f *fakeStore) BulkSet(req []state.SetRequest) error {
+	return nil
+}
+
+func TestPartitions(t *testing.T) {
+	t.Run("records are saved in the partition of their id", func(t *testing.T) {
+		store := newFakeStore()
+		p := New(store, 4, "jobs", "app1")
+		assert.NoError(t, p.Put("job1", map[string]string{"name": "job1"}))
+
+		partition := p.Of("job1")
+		assert.Contains(t, store.items, keyprefix.InternalKey("jobs", "app1", strconv.Itoa(partition)))
+		records, etag, err := p.Get(partition)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, etag)
+		assert.JSONEq(t, `{"name":"job1"}`, string(records["job1"]))
+	})
+
+	t.Run("the ids are spread over the partitions", func(t *testing.T) {
+		p := New(newFakeStore(), 4, "jobs", "app1")
+		used := map[int]bool{}
+		for i := 0; i < 100; i++ {
+			partition := p.Of("job" + strconv.Itoa(i))
+			assert.True(t, partition >= 0 && partition < p.Count())
+			used[partition] = true
+		}
+		assert.Len(t, used, 4)
+	})
+
+	t.Run("default count", func(t *testing.T) {
+		assert.Equal(t, DefaultCount, New(newFakeStore(), 0, "jobs").Count())
+	})
+
+	t.Run("partitions are created without records", func(t *testing.T) {
+		store := newFakeStore()
+		p := New(store, 4, "jobs", "app1")
+		assert.NoError(t, p.Create())
+		assert.Len(t, store.items, 4)
+		for partition := 0; partition < p.Count(); partition++ {
+			records, etag, err := p.Get(partition)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, etag)
+			assert.Empty(t, records)
+		}
+
+		// the partitions which exist are kept
+		assert.NoError(t, p.Put("job1", "a"))
+		assert.NoError(t, New(store, 4, "jobs", "app1").Create())
+		records, _, err := p.Get(p.Of("job1"))
+		assert.NoError(t, err)
+		assert.Contains(t, records, "job1")
+	})
+
+	t.Run("records are saved with the etag of the partition", func(t *testing.T) {
+		store := newFakeStore()
+		p := New(store, 1, "outbox", "app1")
+		var etags []string
+		store.beforeSet = func() {
+			_, etag, _ := p.Get(0)
+			etags = append(etags, etag)
+		}
+
+		assert.NoError(t, p.Put("event1", "a"))
+		// the partition is created, then saved with the etag of the partition created
+		assert.Equal(t, []string{"", "1"}, etags)
+	})
+
+	t.Run("concurrent updates are attempted again", func(t *testing.T) {
+		store := newFakeStore()
+		p := New(store, 1, "outbox", "app1")
+		replica := New(store, 1, "outbox", "app1")
+		assert.NoError(t, p.Create())
+		store.beforeSet = func() {
+			store.beforeSet = nil
+			assert.NoError(t, replica.Put("event2", "b"))
+		}
+
+		assert.NoError(t, p.Put("event1", "a"))
+		records, _, err := p.Get(0)
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+	})
+
+	t.Run("updates fail once the attempts are exhausted", func(t *testing.T) {
+		store := newFakeStore()
+		p := New(store, 1, "outbox", "app1")
+		replica := New(store, 1, "outbox", "app1")
+		assert.NoError(t, p.Create())
+		var conflict func()
+		conflict = func() {
+			store.beforeSet = nil
+			assert.NoError(t, replica.Put("other", "b"))
+			store.beforeSet = conflict
+		}
+		store.beforeSet = conflict
+
+		assert.Error(t, p.Put("event1", "a"))
+	})
+
+	t.Run("failures other than conflicts aren't attempted again", func(t *testing.T) {
+		store := newFakeStore()
+		p := New(store, 1, "outbox", "app1")
+		assert.NoError(t, p.Create())
+		store.sets = 0
+		store.setErr = errors.New("unavailable")
+
+		assert.Error(t, p.Put("event1", "a"))
+		assert.Equal(t, 1, store.sets)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		store := newFakeStore()
+		p := New(store, 1, "outbox", "app1")
+		assert.NoError(t, p.Put("event1", "a"))
+		assert.NoError(t, p.Put("event2", "b"))
+		version := store.versions[p.Key(0)]
+
+		assert.NoError(t, p.Remove(0, "event1", "missing"))
+		records, _, err := p.Get(0)
+		assert.NoError(t, err)
+		assert.Len(t, records, 1)
+		assert.Contains(t, records, "event2")
+
+		// removing records which don't exist doesn't save the partition
+		assert.NoError(t, p.Remove(0, "missing"))
+		assert.Equal(t, version+1, store.versions[p.Key(0)])
+	})
+}
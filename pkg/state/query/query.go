@@ -0,0 +1,302 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ASC sorts the results in ascending order
+	ASC = "ASC"
+	// DESC sorts the results in descending order
+	DESC = "DESC"
+
+	eqOperator  = "EQ"
+	inOperator  = "IN"
+	andOperator = "AND"
+	orOperator  = "OR"
+)
+
+// Querier is implemented by state stores which natively support queries.
+// The store translates the filter, sorting and pagination of the query to its own query language.
+type Querier interface {
+	Query(req *Request) (*Response, error)
+}
+
+// Request is a query of a state store.
+// Only the entries whose keys start with the key prefix are queried.
+type Request struct {
+	Query     Query             `json:"query"`
+	KeyPrefix string            `json:"keyPrefix"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+// Response holds a page of query results and the token of the next page, which is empty on the last page
+type Response struct {
+	Results []Item `json:"results"`
+	Token   string `json:"token,omitempty"`
+}
+
+// Item is a state entry matching a query
+type Item struct {
+	Key  string `json:"key"`
+	Data []byte `json:"data"`
+	ETag string `json:"etag,omitempty"`
+}
+
+// Query is a filter on the values of the state entries, with sort keys and pagination.
+// Keys of filters and sort keys are dot separated paths in the JSON values.
+type Query struct {
+	Filter Filter
+	Sort   []Sorting
+	Page   Pagination
+}
+
+// Sorting is a sort key of a query
+type Sorting struct {
+	Key   string `json:"key"`
+	Order string `json:"order,omitempty"`
+}
+
+// Pagination limits the number of results of a query. The token of a response is passed
+// in the following query to get the next page.
+type Pagination struct {
+	Limit int    `json:"limit"`
+	Token string `json:"token,omitempty"`
+}
+
+// Filter is a condition on the values of the state entries.
+// It is one of *EQ, *IN, *AND or *OR.
+type Filter interface {
+	// Match returns true if the JSON value matches the filter
+	Match(value interface{}) bool
+}
+
+// EQ matches values whose field at the key equals the value
+type EQ struct {
+	Key string
+	Val interface{}
+}
+
+// IN matches values whose field at the key equals one of the values
+type IN struct {
+	Key  string
+	Vals []interface{}
+}
+
+// AND matches values matching all the filters
+type AND struct {
+	Filters []Filter
+}
+
+// OR matches values matching any of the filters
+type OR struct {
+	Filters []Filter
+}
+
+// Match returns true if the field at the key of the value equals the value of the filter
+func (f *EQ) Match(value interface{}) bool {
+	field, ok := getField(value, f.Key)
+	return ok && equal(field, f.Val)
+}
+
+// Match returns true if the field at the key of the value equals one of the values of the filter
+func (f *IN) Match(value interface{}) bool {
+	field, ok := getField(value, f.Key)
+	if !ok {
+		return false
+	}
+	for _, v := range f.Vals {
+		if equal(field, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns true if the value matches all the filters
+func (f *AND) Match(value interface{}) bool {
+	for _, filter := range f.Filters {
+		if !filter.Match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match returns true if the value matches any of the filters
+func (f *OR) Match(value interface{}) bool {
+	for _, filter := range f.Filters {
+		if filter.Match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+type jsonQuery struct {
+	Filter map[string]json.RawMessage `json:"filter"`
+	Sort   []Sorting                  `json:"sort"`
+	Page   Pagination                 `json:"page"`
+}
+
+// UnmarshalJSON parses a query of the form
+// {"filter": {"AND": [{"EQ": {"state": "CA"}}, {"IN": {"person.org": ["a", "b"]}}]}, "sort": [{"key": "state", "order": "DESC"}], "page": {"limit": 10}}
+func (q *Query) UnmarshalJSON(data []byte) error {
+	var jq jsonQuery
+	if err := json.Unmarshal(data, &jq); err != nil {
+		return err
+	}
+
+	*q = Query{Sort: jq.Sort, Page: jq.Page}
+	if len(jq.Filter) > 0 {
+		filter, err := parseFilter(jq.Filter)
+		if err != nil {
+			return err
+		}
+		q.Filter = filter
+	}
+	return q.validate()
+}
+
+// MarshalJSON serializes the query in the form parsed by UnmarshalJSON
+func (q Query) MarshalJSON() ([]byte, error) {
+	jq := struct {
+		Filter map[string]interface{} `json:"filter,omitempty"`
+		Sort   []Sorting              `json:"sort,omitempty"`
+		Page   Pagination             `json:"page"`
+	}{
+		Filter: filterJSON(q.Filter),
+		Sort:   q.Sort,
+		Page:   q.Page,
+	}
+	return json.Marshal(jq)
+}
+
+func filterJSON(filter Filter) map[string]interface{} {
+	switch f := filter.(type) {
+	case *EQ:
+		return map[string]interface{}{eqOperator: map[string]interface{}{f.Key: f.Val}}
+	case *IN:
+		return map[string]interface{}{inOperator: map[string]interface{}{f.Key: f.Vals}}
+	case *AND:
+		return map[string]interface{}{andOperator: filtersJSON(f.Filters)}
+	case *OR:
+		return map[string]interface{}{orOperator: filtersJSON(f.Filters)}
+	}
+	return nil
+}
+
+func filtersJSON(filters []Filter) []map[string]interface{} {
+	args := make([]map[string]interface{}, 0, len(filters))
+	for _, f := range filters {
+		args = append(args, filterJSON(f))
+	}
+	return args
+}
+
+func (q *Query) validate() error {
+	for i, s := range q.Sort {
+		if s.Key == "" {
+			return errors.New("sort key is required")
+		}
+		switch strings.ToUpper(s.Order) {
+		case "":
+			q.Sort[i].Order = ASC
+		case ASC, DESC:
+			q.Sort[i].Order = strings.ToUpper(s.Order)
+		default:
+			return fmt.Errorf("invalid sort order %s of key %s", s.Order, s.Key)
+		}
+	}
+	if q.Page.Limit < 0 {
+		return fmt.Errorf("invalid page limit %d", q.Page.Limit)
+	}
+	return nil
+}
+
+func parseFilter(filter map[string]json.RawMessage) (Filter, error) {
+	if len(filter) != 1 {
+		return nil, errors.New("a filter must have exactly one operator")
+	}
+
+	for op, arg := range filter {
+		switch strings.ToUpper(op) {
+		case eqOperator:
+			key, val, err := parseKeyValue(op, arg)
+			if err != nil {
+				return nil, err
+			}
+			return &EQ{Key: key, Val: val}, nil
+		case inOperator:
+			key, val, err := parseKeyValue(op, arg)
+			if err != nil {
+				return nil, err
+			}
+			vals, ok := val.([]interface{})
+			if !ok || len(vals) == 0 {
+				return nil, fmt.Errorf("%s filter of key %s must have a non empty array of values", inOperator, key)
+			}
+			return &IN{Key: key, Vals: vals}, nil
+		case andOperator, orOperator:
+			var args []map[string]json.RawMessage
+			if err := json.Unmarshal(arg, &args); err != nil || len(args) < 2 {
+				return nil, fmt.Errorf("%s filter must have an array of at least two filters", op)
+			}
+			filters := make([]Filter, 0, len(args))
+			for _, a := range args {
+				f, err := parseFilter(a)
+				if err != nil {
+					return nil, err
+				}
+				filters = append(filters, f)
+			}
+			if strings.ToUpper(op) == andOperator {
+				return &AND{Filters: filters}, nil
+			}
+			return &OR{Filters: filters}, nil
+		default:
+			return nil, fmt.Errorf("filter operator %s not supported", op)
+		}
+	}
+	return nil, nil
+}
+
+func parseKeyValue(op string, arg json.RawMessage) (string, interface{}, error) {
+	var kv map[string]interface{}
+	if err := json.Unmarshal(arg, &kv); err != nil || len(kv) != 1 {
+		return "", nil, fmt.Errorf("%s filter must have exactly one key", op)
+	}
+	for k, v := range kv {
+		return k, v, nil
+	}
+	return "", nil, nil
+}
+
+// getField returns the field at the dot separated path of a JSON value
+func getField(value interface{}, path string) (interface{}, bool) {
+	for _, k := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		value, ok = m[k]
+		if !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func equal(a, b interface{}) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
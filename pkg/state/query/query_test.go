@@ -0,0 +1,82 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryUnmarshal(t *testing.T) {
+	t.Run("filter, sort and page", func(t *testing.T) {
+		var q Query
+		err := json.Unmarshal([]byte(`{
+			"filter": {"AND": [{"EQ": {"state": "CA"}}, {"IN": {"person.org": ["a", "b"]}}]},
+			"sort": [{"key": "state", "order": "desc"}, {"key": "id"}],
+			"page": {"limit": 10, "token": "2"}
+		}`), &q)
+		assert.NoError(t, err)
+		assert.Equal(t, &AND{Filters: []Filter{
+			&EQ{Key: "state", Val: "CA"},
+			&IN{Key: "person.org", Vals: []interface{}{"a", "b"}},
+		}}, q.Filter)
+		assert.Equal(t, []Sorting{{Key: "state", Order: DESC}, {Key: "id", Order: ASC}}, q.Sort)
+		assert.Equal(t, Pagination{Limit: 10, Token: "2"}, q.Page)
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		var q Query
+		assert.NoError(t, json.Unmarshal([]byte(`{}`), &q))
+		assert.Nil(t, q.Filter)
+	})
+
+	for name, body := range map[string]string{
+		"unknown operator":         `{"filter": {"GT": {"a": 1}}}`,
+		"several operators":        `{"filter": {"EQ": {"a": 1}, "IN": {"a": [1]}}}`,
+		"EQ with several keys":     `{"filter": {"EQ": {"a": 1, "b": 2}}}`,
+		"IN without values":        `{"filter": {"IN": {"a": []}}}`,
+		"AND with a single filter": `{"filter": {"AND": [{"EQ": {"a": 1}}]}}`,
+		"invalid sort order":       `{"sort": [{"key": "a", "order": "up"}]}`,
+		"sort without key":         `{"sort": [{"order": "ASC"}]}`,
+		"negative limit":           `{"page": {"limit": -1}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var q Query
+			assert.Error(t, json.Unmarshal([]byte(body), &q))
+		})
+	}
+}
+
+func TestQueryMarshal(t *testing.T) {
+	var q Query
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"filter": {"OR": [{"EQ": {"state": "CA"}}, {"AND": [{"IN": {"person.org": ["a", "b"]}}, {"EQ": {"id": 1}}]}]},
+		"sort": [{"key": "state", "order": "desc"}],
+		"page": {"limit": 10, "token": "2"}
+	}`), &q))
+
+	b, err := json.Marshal(q)
+	assert.NoError(t, err)
+	var parsed Query
+	assert.NoError(t, json.Unmarshal(b, &parsed))
+	assert.Equal(t, q, parsed)
+}
+
+func TestFilterMatch(t *testing.T) {
+	var value interface{}
+	json.Unmarshal([]byte(`{"state": "CA", "count": 2, "person": {"org": "a"}}`), &value)
+
+	assert.True(t, (&EQ{Key: "state", Val: "CA"}).Match(value))
+	assert.True(t, (&EQ{Key: "count", Val: 2}).Match(value))
+	assert.False(t, (&EQ{Key: "state", Val: "WA"}).Match(value))
+	assert.False(t, (&EQ{Key: "missing", Val: "CA"}).Match(value))
+	assert.True(t, (&IN{Key: "person.org", Vals: []interface{}{"b", "a"}}).Match(value))
+	assert.False(t, (&IN{Key: "person.org.name", Vals: []interface{}{"a"}}).Match(value))
+	assert.True(t, (&OR{Filters: []Filter{&EQ{Key: "state", Val: "WA"}, &EQ{Key: "count", Val: 2}}}).Match(value))
+	assert.False(t, (&AND{Filters: []Filter{&EQ{Key: "state", Val: "WA"}, &EQ{Key: "count", Val: 2}}}).Match(value))
+}
@@ -0,0 +1,515 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package ttl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/dapr/dapr/pkg/state/keyprefix"
+	"github.com/dapr/dapr/pkg/state/partitions"
+	"github.com/google/uuid"
+)
+
+const (
+	// TTLMetadataKey is the save metadata key holding the number of seconds after which a key expires
+	TTLMetadataKey = "ttlInSeconds"
+	// NativeTTLMetadataKey is the state store component metadata key declaring that the store expires
+	// keys saved with ttlInSeconds metadata itself
+	NativeTTLMetadataKey = "nativeTTL"
+	// EmulateTTLMetadataKey is the state store component metadata key enabling the emulation by Dapr of the
+	// expiry of keys saved with ttlInSeconds metadata, for the stores which don't expire keys natively
+	EmulateTTLMetadataKey = "emulateTTL"
+	// DefaultSweepInterval is the default interval at which expired keys are deleted
+	DefaultSweepInterval = time.Minute
+
+	expiryKeyPart  = "ttl"
+	indexKeyPart   = "ttlindex"
+	sweeperKeyPart = "ttlsweeper"
+	// indexBucket is the span of the expiry times whose keys are indexed together. The buckets are swept in
+	// order and deleted once swept, so the index only holds the keys which weren't deleted yet.
+	indexBucket = time.Hour
+	// maxSweptBuckets is the largest number of buckets swept by a sweep, the next buckets are swept by the
+	// next sweeps, which limits the sweep catching up after the app was stopped for a long time
+	maxSweptBuckets = 24
+	// sweeperLeaseIntervals is the number of sweep intervals the lease of the sweep lasts, another app
+	// instance takes the sweep over once the lease of its holder expires
+	sweeperLeaseIntervals = 3
+)
+
+// Store is a state store emulating the expiry of keys saved with ttlInSeconds metadata.
+// The expiry time of a key saved with a TTL is saved in a separate record which is checked on read, so the
+// values are saved as is for the other clients of the store. The keys are also kept in an index bucketed by
+// expiry time from which the expired keys are deleted by a single app instance, which holds the lease of the sweep.
+type Store interface {
+	state.Store
+	// Start deletes the expired keys at the given interval
+	Start(interval time.Duration)
+	// Close stops the deletion of the expired keys and closes the given store if it can be closed
+	io.Closer
+}
+
+type expiringStore struct {
+	appID string
+	// instanceID identifies the app instance holding the lease of the sweep
+	instanceID    string
+	store         state.Store
+	log           logger.Logger
+	leaseDuration time.Duration
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type transactionalExpiringStore struct {
+	*expiringStore
+	transactionalStore state.TransactionalStore
+}
+
+// indexEntry is a key saved with a TTL
+type indexEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// sweeperLease is held by the app instance deleting the expired keys
+type sweeperLease struct {
+	Owner     string `json:"owner"`
+	ExpiresAt string `json:"expiresAt"`
+	// SweptUntil is the start of the first index bucket which wasn't swept entirely
+	SweptUntil string `json:"sweptUntil,omitempty"`
+}
+
+// NewStore returns a Store emulating key expiry on the given state store.
+// The returned store supports transactions if the given store does.
+func NewStore(appID string, store state.Store, log logger.Logger) Store {
+	s := &expiringStore{
+		appID:         appID,
+		instanceID:    uuid.New().String(),
+		store:         store,
+		log:           log,
+		leaseDuration: sweeperLeaseIntervals * DefaultSweepInterval,
+		done:          make(chan struct{}),
+	}
+	if transactionalStore, ok := store.(state.TransactionalStore); ok {
+		return &transactionalExpiringStore{
+			expiringStore:      s,
+			transactionalStore: transactionalStore,
+		}
+	}
+	return s
+}
+
+// GetTTL returns the TTL requested by the save metadata, or 0 if the key does not expire
+func GetTTL(metadata map[string]string) (time.Duration, error) {
+	for k, v := range metadata {
+		if !strings.EqualFold(k, TTLMetadataKey) {
+			continue
+		}
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || seconds <= 0 {
+			return 0, fmt.Errorf("invalid %s value %q", TTLMetadataKey, v)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, nil
+}
+
+func (s *expiringStore) Init(metadata state.Metadata) error {
+	return s.store.Init(metadata)
+}
+
+func (s *expiringStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	resp, err := s.store.Get(req)
+	if err != nil || resp == nil || len(resp.Data) == 0 {
+		return resp, err
+	}
+
+	expiresAt, _, err := s.getExpiry(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if !expiresAt.IsZero() && !time.Now().UTC().Before(expiresAt) {
+		return &state.GetResponse{}, nil
+	}
+	return resp, nil
+}
+
+func (s *expiringStore) Set(req *state.SetRequest) error {
+	return s.BulkSet([]state.SetRequest{*req})
+}
+
+// BulkSet saves the expiry times of the keys saved with a TTL before the values, so that a value saved again
+// isn't hidden by the expiry time of its previous save, and removes the expiry times of the keys saved without
+// a TTL after the values, so that an expired value isn't visible again
+func (s *expiringStore) BulkSet(req []state.SetRequest) error {
+	expiries, removed, entries, err := s.expiryRequests(req)
+	if err != nil {
+		return err
+	}
+	s.addToIndex(entries)
+	if len(expiries) > 0 {
+		if err := s.store.BulkSet(expiries); err != nil {
+			return err
+		}
+	}
+	if err := s.store.BulkSet(req); err != nil {
+		return err
+	}
+	if len(removed) > 0 {
+		return s.store.BulkDelete(removed)
+	}
+	return nil
+}
+
+func (s *expiringStore) Delete(req *state.DeleteRequest) error {
+	return s.BulkDelete([]state.DeleteRequest{*req})
+}
+
+func (s *expiringStore) BulkDelete(req []state.DeleteRequest) error {
+	if err := s.store.BulkDelete(req); err != nil {
+		return err
+	}
+	removed := make([]state.DeleteRequest, 0, len(req))
+	for _, r := range req {
+		removed = append(removed, state.DeleteRequest{Key: s.expiryKey(r.Key)})
+	}
+	return s.store.BulkDelete(removed)
+}
+
+// BulkSet saves the values along with the expiry times of their keys in a single transaction
+func (s *transactionalExpiringStore) BulkSet(req []state.SetRequest) error {
+	reqs := make([]state.TransactionalRequest, 0, len(req))
+	for _, r := range req {
+		reqs = append(reqs, state.TransactionalRequest{Operation: state.Upsert, Request: r})
+	}
+	return s.Multi(reqs)
+}
+
+func (s *transactionalExpiringStore) Set(req *state.SetRequest) error {
+	return s.BulkSet([]state.SetRequest{*req})
+}
+
+// Multi executes the transaction along with the saves and deletes of the expiry times of its keys
+func (s *transactionalExpiringStore) Multi(reqs []state.TransactionalRequest) error {
+	var upserts []state.SetRequest
+	var deleted []string
+	for _, r := range reqs {
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			if r.Operation == state.Upsert {
+				upserts = append(upserts, req)
+			}
+		case state.DeleteRequest:
+			if r.Operation == state.Delete {
+				deleted = append(deleted, req.Key)
+			}
+		}
+	}
+
+	expiries, removed, entries, err := s.expiryRequests(upserts)
+	if err != nil {
+		return err
+	}
+	for _, key := range deleted {
+		removed = append(removed, state.DeleteRequest{Key: s.expiryKey(key)})
+	}
+
+	converted := make([]state.TransactionalRequest, 0, len(reqs)+len(expiries)+len(removed))
+	for _, r := range expiries {
+		converted = append(converted, state.TransactionalRequest{Operation: state.Upsert, Request: r})
+	}
+	converted = append(converted, reqs...)
+	for _, r := range removed {
+		converted = append(converted, state.TransactionalRequest{Operation: state.Delete, Request: r})
+	}
+	s.addToIndex(entries)
+	return s.transactionalStore.Multi(converted)
+}
+
+// Start deletes the expired keys at the given interval
+func (s *expiringStore) Start(interval time.Duration) {
+	s.leaseDuration = sweeperLeaseIntervals * interval
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.deleteExpired(time.Now().UTC())
+			case <-s.done:
+				return
+			}
+		}
+	}()
+}
+
+func (s *expiringStore) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	if closer, ok := s.store.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// deleteExpired deletes the keys expired at the given time if this app instance holds the lease of the sweep.
+// Keys which were saved again without a TTL or with a later expiry are kept, keys which fail to be deleted are
+// deleted again by the next sweep.
+func (s *expiringStore) deleteExpired(now time.Time) {
+	sweptUntil, ok := s.acquireSweep(now)
+	if !ok {
+		return
+	}
+
+	current := now.Truncate(indexBucket)
+	next := sweptUntil
+	for i, bucket := 0, sweptUntil; i < maxSweptBuckets && !bucket.After(current); i, bucket = i+1, bucket.Add(indexBucket) {
+		// the current bucket may still get keys, so it is swept again by the next sweeps
+		if s.sweepBucket(bucket, now) && bucket.Equal(next) && bucket.Before(current) {
+			next = bucket.Add(indexBucket)
+		}
+	}
+	if next.After(sweptUntil) {
+		s.saveSweptUntil(next)
+	}
+}
+
+// acquireSweep returns whether this app instance holds the lease of the sweep, and the start of the first index
+// bucket to sweep. The lease is renewed by each sweep of its holder and taken over by another instance once it
+// expires.
+func (s *expiringStore) acquireSweep(now time.Time) (time.Time, bool) {
+	lease, etag, err := s.getLease()
+	if err == nil && etag == "" && lease == (sweeperLease{}) {
+		// the stores save the keys unconditionally without ETag, so the lease is created empty and taken with its ETag
+		if err = s.store.Set(&state.SetRequest{Key: s.leaseKey(), Value: sweeperLease{}}); err == nil {
+			lease, etag, err = s.getLease()
+		}
+	}
+	if err != nil {
+		s.log.Warnf("failed to get the lease of the sweep of expiring keys: %s", err)
+		return time.Time{}, false
+	}
+	if lease.Owner != "" && lease.Owner != s.instanceID {
+		expiresAt, err := time.Parse(time.RFC3339Nano, lease.ExpiresAt)
+		if err == nil && expiresAt.After(now) {
+			return time.Time{}, false
+		}
+	}
+
+	sweptUntil, err := time.Parse(time.RFC3339, lease.SweptUntil)
+	if err != nil {
+		// the keys are indexed from the first save, which doesn't precede the first sweep by more than a bucket
+		sweptUntil = now.Truncate(indexBucket).Add(-indexBucket)
+	}
+	lease.Owner = s.instanceID
+	lease.ExpiresAt = now.Add(s.leaseDuration).Format(time.RFC3339Nano)
+	lease.SweptUntil = sweptUntil.UTC().Format(time.RFC3339)
+	if err := s.saveLease(lease, etag); err != nil {
+		s.log.Debugf("lease of the sweep of expiring keys taken by another instance: %s", err)
+		return time.Time{}, false
+	}
+	return sweptUntil, true
+}
+
+// saveSweptUntil records the start of the first index bucket which wasn't swept entirely, unless another
+// instance took the lease of the sweep over meanwhile
+func (s *expiringStore) saveSweptUntil(sweptUntil time.Time) {
+	lease, etag, err := s.getLease()
+	if err == nil && lease.Owner == s.instanceID {
+		lease.SweptUntil = sweptUntil.UTC().Format(time.RFC3339)
+		err = s.saveLease(lease, etag)
+	}
+	if err != nil {
+		// the buckets are swept again by the next sweep
+		s.log.Debugf("failed to record the swept buckets of expiring keys: %s", err)
+	}
+}
+
+func (s *expiringStore) getLease() (sweeperLease, string, error) {
+	var lease sweeperLease
+	resp, err := s.store.Get(&state.GetRequest{Key: s.leaseKey()})
+	if err != nil {
+		return lease, "", err
+	}
+	if resp == nil || len(resp.Data) == 0 {
+		return lease, "", nil
+	}
+	// a lease which can't be deserialized is replaced
+	json.Unmarshal(resp.Data, &lease)
+	return lease, resp.ETag, nil
+}
+
+// saveLease saves the lease with the ETag read
+func (s *expiringStore) saveLease(lease sweeperLease, etag string) error {
+	return s.store.Set(&state.SetRequest{
+		Key:     s.leaseKey(),
+		Value:   lease,
+		ETag:    etag,
+		Options: state.SetStateOption{Concurrency: state.FirstWrite},
+	})
+}
+
+// sweepBucket deletes the keys of the index bucket expired at the given time, removes them from the bucket and
+// deletes the partitions of the bucket left empty. It returns whether all the keys of the bucket were deleted.
+func (s *expiringStore) sweepBucket(bucket time.Time, now time.Time) bool {
+	index := s.index(bucket)
+	complete := true
+	for partition := 0; partition < index.Count(); partition++ {
+		records, etag, err := index.Get(partition)
+		if err != nil {
+			s.log.Warnf("failed to get the index of expiring keys: %s", err)
+			complete = false
+			continue
+		}
+		if etag == "" {
+			continue
+		}
+
+		swept := []string{}
+		for key, b := range records {
+			var expiresAt time.Time
+			if err := json.Unmarshal(b, &expiresAt); err == nil && expiresAt.After(now) {
+				continue
+			}
+			if err := s.deleteIfExpired(key, now); err != nil {
+				s.log.Debugf("failed to delete expired key %s, will retry: %s", key, err)
+				continue
+			}
+			swept = append(swept, key)
+		}
+		if len(swept) < len(records) {
+			complete = false
+		}
+
+		if len(swept) == len(records) {
+			// the bucket is deleted with the ETag read, so a key indexed since is kept
+			if err = s.store.Delete(&state.DeleteRequest{Key: index.Key(partition), ETag: etag}); err == nil {
+				continue
+			}
+		}
+		if err = index.Remove(partition, swept...); err != nil {
+			// the swept keys stay in the index and are dropped by the next sweep
+			s.log.Warnf("failed to update the index of expiring keys: %s", err)
+			complete = false
+		}
+	}
+	return complete
+}
+
+// deleteIfExpired deletes the key and its expiry time if the key is expired. The deletes are made with the
+// ETags read, so that a key saved again meanwhile isn't deleted.
+func (s *expiringStore) deleteIfExpired(key string, now time.Time) error {
+	expiresAt, expiryETag, err := s.getExpiry(key)
+	if err != nil {
+		return err
+	}
+	if expiresAt.IsZero() || expiresAt.After(now) {
+		return nil
+	}
+
+	resp, err := s.store.Get(&state.GetRequest{Key: key})
+	if err != nil {
+		return err
+	}
+	if resp != nil && len(resp.Data) > 0 {
+		if err := s.store.Delete(&state.DeleteRequest{Key: key, ETag: resp.ETag}); err != nil {
+			return err
+		}
+	}
+	return s.store.Delete(&state.DeleteRequest{Key: s.expiryKey(key), ETag: expiryETag})
+}
+
+// expiryRequests returns the saves of the expiry times of the keys saved with a TTL, the deletes of the
+// expiry times of the keys saved without a TTL, and the index entries of the keys saved with a TTL
+func (s *expiringStore) expiryRequests(reqs []state.SetRequest) ([]state.SetRequest, []state.DeleteRequest, []indexEntry, error) {
+	now := time.Now().UTC()
+	var expiries []state.SetRequest
+	var removed []state.DeleteRequest
+	var entries []indexEntry
+	for _, r := range reqs {
+		ttl, err := GetTTL(r.Metadata)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if ttl == 0 {
+			removed = append(removed, state.DeleteRequest{Key: s.expiryKey(r.Key)})
+			continue
+		}
+		expiresAt := now.Add(ttl)
+		expiries = append(expiries, state.SetRequest{Key: s.expiryKey(r.Key), Value: []byte(expiresAt.Format(time.RFC3339Nano))})
+		entries = append(entries, indexEntry{key: r.Key, expiresAt: expiresAt})
+	}
+	return expiries, removed, entries, nil
+}
+
+// getExpiry returns the expiry time of the key and the ETag of its record, the time is zero if the key
+// doesn't expire
+func (s *expiringStore) getExpiry(key string) (time.Time, string, error) {
+	resp, err := s.store.Get(&state.GetRequest{Key: s.expiryKey(key)})
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("error getting the expiry time of key %s: %s", key, err)
+	}
+	if resp == nil || len(resp.Data) == 0 {
+		return time.Time{}, "", nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339Nano, string(resp.Data))
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("error parsing the expiry time of key %s: %s", key, err)
+	}
+	return expiresAt, resp.ETag, nil
+}
+
+// addToIndex adds the keys saved with a TTL to the index buckets of their expiry times. A key which fails to be
+// indexed still expires on read but isn't deleted by the sweep, which doesn't fail the save.
+func (s *expiringStore) addToIndex(entries []indexEntry) {
+	type partitionKey struct {
+		bucket    time.Time
+		partition int
+	}
+	added := map[partitionKey][]indexEntry{}
+	for _, e := range entries {
+		bucket := e.expiresAt.Truncate(indexBucket)
+		k := partitionKey{bucket: bucket, partition: s.index(bucket).Of(e.key)}
+		added[k] = append(added[k], e)
+	}
+
+	for k, entries := range added {
+		entries := entries
+		err := s.index(k.bucket).Update(k.partition, func(records partitions.Records) (bool, error) {
+			for _, e := range entries {
+				b, err := json.Marshal(e.expiresAt)
+				if err != nil {
+					return false, err
+				}
+				records[e.key] = b
+			}
+			return true, nil
+		})
+		if err != nil {
+			s.log.Warnf("failed to index expiring keys, they expire but aren't deleted: %s", err)
+		}
+	}
+}
+
+// index returns the partitions of the index bucket starting at the given time
+func (s *expiringStore) index(bucket time.Time) *partitions.Partitions {
+	return partitions.New(s.store, partitions.DefaultCount, indexKeyPart, s.appID, strconv.FormatInt(bucket.Unix(), 10))
+}
+
+func (s *expiringStore) leaseKey() string {
+	return keyprefix.InternalKey(sweeperKeyPart, s.appID)
+}
+
+func (s *expiringStore) expiryKey(key string) string {
+	return keyprefix.InternalKey(expiryKeyPart, key)
+}
@@ -0,0 +1,304 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package ttl
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEntry struct {
+	data []byte
+	etag int
+}
+
+// fakeStore is an in memory state store with etags
+type fakeStore struct {
+	items map[string]fakeEntry
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{items: map[string]fakeEntry{}}
+}
+
+func (f *fakeStore) Init(metadata state.Metadata) error { return nil }
+
+func (f *fakeStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	e, ok := f.items[req.Key]
+	if !ok {
+		return &state.GetResponse{}, nil
+	}
+	return &state.GetResponse{Data: e.data, ETag: strconv.Itoa(e.etag)}, nil
+}
+
+// Set fails if the ETag isn't the current one, the keys are saved unconditionally when the ETag is empty
+func (f *fakeStore) Set(req *state.SetRequest) error {
+	e := f.items[req.Key]
+	if req.ETag != "" && req.ETag != strconv.Itoa(e.etag) {
+		return errors.New("etag mismatch")
+	}
+	b, ok := req.Value.([]byte)
+	if !ok {
+		b, _ = json.Marshal(req.Value)
+	}
+	f.items[req.Key] = fakeEntry{data: b, etag: e.etag + 1}
+	return nil
+}
+
+func (f *fakeStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		f.Set(&req[i])
+	}
+	return nil
+}
+
+func (f *fakeStore) Delete(req *state.DeleteRequest) error {
+	if e, ok := f.items[req.Key]; ok && req.ETag != "" && req.ETag != strconv.Itoa(e.etag) {
+		return errors.New("etag mismatch")
+	}
+	delete(f.items, req.Key)
+	return nil
+}
+
+func (f *fakeStore) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		f.Delete(&req[i])
+	}
+	return nil
+}
+
+type fakeTransactionalStore struct {
+	*fakeStore
+}
+
+func (f fakeTransactionalStore) Multi(reqs []state.TransactionalRequest) error {
+	for _, r := range reqs {
+		switch req := r.Request.(type) {
+		case state.SetRequest:
+			f.Set(&req)
+		case state.DeleteRequest:
+			f.Delete(&req)
+		}
+	}
+	return nil
+}
+
+var testLogger = logger.NewLogger("dapr.state.ttl.test")
+
+func TestGetTTL(t *testing.T) {
+	ttl, err := GetTTL(map[string]string{"ttlInSeconds": "10"})
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, ttl)
+
+	ttl, err = GetTTL(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	_, err = GetTTL(map[string]string{"ttlInSeconds": "0"})
+	assert.Error(t, err)
+	_, err = GetTTL(map[string]string{"ttlInSeconds": "ten"})
+	assert.Error(t, err)
+}
+
+// indexed returns the keys of the index of the store
+func indexed(t *testing.T, s *expiringStore) []string {
+	keys := []string{}
+	now := time.Now().UTC()
+	for bucket := now.Truncate(indexBucket).Add(-indexBucket); bucket.Before(now.Add(2 * time.Hour)); bucket = bucket.Add(indexBucket) {
+		index := s.index(bucket)
+		for partition := 0; partition < index.Count(); partition++ {
+			records, _, err := index.Get(partition)
+			assert.NoError(t, err)
+			for key := range records {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+func TestExpiringStore(t *testing.T) {
+	fake := newFakeStore()
+	store := NewStore("app1", fake, testLogger).(*expiringStore)
+
+	t.Run("value saved with a TTL is returned until it expires", func(t *testing.T) {
+		err := store.Set(&state.SetRequest{Key: "key1", Value: []byte("value1"), Metadata: map[string]string{"ttlInSeconds": "60"}})
+		assert.NoError(t, err)
+		// the value is saved as is for the other clients of the store
+		assert.Equal(t, "value1", string(fake.items["key1"].data))
+
+		resp, err := store.Get(&state.GetRequest{Key: "key1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "value1", string(resp.Data))
+		assert.Equal(t, "1", resp.ETag)
+
+		fake.items["dapr-internal||ttl||key1"] = fakeEntry{
+			data: []byte(time.Now().UTC().Add(-time.Minute).Format(time.RFC3339Nano)),
+			etag: 2,
+		}
+		resp, err = store.Get(&state.GetRequest{Key: "key1"})
+		assert.NoError(t, err)
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("value saved without a TTL is returned as is", func(t *testing.T) {
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "key2", Value: map[string]string{"a": "b"}}))
+		resp, err := store.Get(&state.GetRequest{Key: "key2"})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a":"b"}`, string(resp.Data))
+	})
+
+	t.Run("invalid TTL", func(t *testing.T) {
+		err := store.Set(&state.SetRequest{Key: "key3", Value: "v", Metadata: map[string]string{"ttlInSeconds": "-1"}})
+		assert.Error(t, err)
+		assert.NotContains(t, fake.items, "key3")
+	})
+
+	t.Run("sweep deletes the expired keys", func(t *testing.T) {
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "key4", Value: "v", Metadata: map[string]string{"ttlInSeconds": "60"}}))
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "key5", Value: "v", Metadata: map[string]string{"ttlInSeconds": "600"}}))
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "key6", Value: "v", Metadata: map[string]string{"ttlInSeconds": "60"}}))
+		// saved again without a TTL, so it must not be deleted
+		assert.NoError(t, store.Set(&state.SetRequest{Key: "key6", Value: "v"}))
+		assert.NotContains(t, fake.items, "dapr-internal||ttl||key6")
+
+		store.deleteExpired(time.Now().UTC().Add(2 * time.Minute))
+		assert.NotContains(t, fake.items, "key1")
+		assert.NotContains(t, fake.items, "key4")
+		assert.NotContains(t, fake.items, "dapr-internal||ttl||key4")
+		assert.Contains(t, fake.items, "key5")
+		assert.Contains(t, fake.items, "key6")
+
+		assert.Equal(t, []string{"key5"}, indexed(t, store))
+	})
+
+	t.Run("only the holder of the lease sweeps", func(t *testing.T) {
+		other := NewStore("app1", fake, testLogger).(*expiringStore)
+		assert.NoError(t, other.Set(&state.SetRequest{Key: "key7", Value: "v", Metadata: map[string]string{"ttlInSeconds": "60"}}))
+
+		now := time.Now().UTC().Add(2 * time.Minute)
+		other.deleteExpired(now)
+		assert.Contains(t, fake.items, "key7")
+
+		// the lease is taken over once it expires
+		other.deleteExpired(now.Add(store.leaseDuration))
+		assert.NotContains(t, fake.items, "key7")
+	})
+}
+
+func TestLeaseIsTakenWithItsETag(t *testing.T) {
+	fake := newFakeStore()
+	store := NewStore("app1", fake, testLogger).(*expiringStore)
+	other := NewStore("app1", fake, testLogger).(*expiringStore)
+	now := time.Now().UTC()
+
+	_, ok := store.acquireSweep(now)
+	assert.True(t, ok)
+	// the lease is created empty, then taken with the ETag of the lease created
+	assert.Equal(t, 2, fake.items[store.leaseKey()].etag)
+	_, ok = other.acquireSweep(now)
+	assert.False(t, ok)
+}
+
+func TestSweepBuckets(t *testing.T) {
+	fake := newFakeStore()
+	store := NewStore("app1", fake, testLogger).(*expiringStore)
+	now := time.Now().UTC()
+	assert.NoError(t, store.Set(&state.SetRequest{Key: "key1", Value: "v", Metadata: map[string]string{"ttlInSeconds": "60"}}))
+	assert.NoError(t, store.Set(&state.SetRequest{Key: "key2", Value: "v", Metadata: map[string]string{"ttlInSeconds": "7200"}}))
+
+	t.Run("swept buckets are deleted and recorded", func(t *testing.T) {
+		store.deleteExpired(now.Add(time.Hour + time.Minute))
+		assert.NotContains(t, fake.items, "key1")
+		assert.Equal(t, []string{"key2"}, indexed(t, store))
+		for key := range fake.items {
+			if strings.HasPrefix(key, "dapr-internal||ttlindex||app1||"+strconv.FormatInt(now.Add(time.Minute).Truncate(indexBucket).Unix(), 10)) {
+				assert.Fail(t, "swept bucket not deleted", key)
+			}
+		}
+
+		lease, _, err := store.getLease()
+		assert.NoError(t, err)
+		sweptUntil, err := time.Parse(time.RFC3339, lease.SweptUntil)
+		assert.NoError(t, err)
+		assert.True(t, sweptUntil.After(now.Add(time.Minute)))
+	})
+
+	t.Run("a save doesn't fail when the index isn't saved", func(t *testing.T) {
+		failing := &failingIndexStore{fakeStore: fake}
+		s := NewStore("app1", failing, testLogger)
+		assert.NoError(t, s.Set(&state.SetRequest{Key: "key3", Value: "v", Metadata: map[string]string{"ttlInSeconds": "60"}}))
+		assert.Contains(t, fake.items, "key3")
+		assert.Contains(t, fake.items, "dapr-internal||ttl||key3")
+	})
+}
+
+// failingIndexStore fails to save the index of the expiring keys
+type failingIndexStore struct {
+	*fakeStore
+}
+
+func (f *failingIndexStore) Set(req *state.SetRequest) error {
+	if strings.HasPrefix(req.Key, "dapr-internal||ttlindex||") {
+		return errors.New("unavailable")
+	}
+	return f.fakeStore.Set(req)
+}
+
+func TestTransactionalExpiringStore(t *testing.T) {
+	fake := newFakeStore()
+	store := NewStore("app1", fakeTransactionalStore{fake}, testLogger)
+	transactionalStore, ok := store.(state.TransactionalStore)
+	assert.True(t, ok)
+
+	fake.items["dapr-internal||ttl||key2"] = fakeEntry{data: []byte(time.Now().UTC().Format(time.RFC3339Nano)), etag: 1}
+	err := transactionalStore.Multi([]state.TransactionalRequest{
+		{Operation: state.Upsert, Request: state.SetRequest{Key: "key1", Value: "v1", Metadata: map[string]string{"ttlInSeconds": "60"}}},
+		{Operation: state.Delete, Request: state.DeleteRequest{Key: "key2"}},
+		{Operation: state.Upsert, Request: state.SetRequest{Key: "key3", Value: "v3"}},
+	})
+	assert.NoError(t, err)
+
+	resp, _ := store.Get(&state.GetRequest{Key: "key1"})
+	assert.Equal(t, `"v1"`, string(resp.Data))
+	assert.Contains(t, fake.items, "dapr-internal||ttl||key1")
+	assert.NotContains(t, fake.items, "dapr-internal||ttl||key2")
+	assert.Equal(t, `"v3"`, string(fake.items["key3"].data))
+}
+
+func TestNonTransactionalStore(t *testing.T) {
+	_, ok := NewStore("app1", newFakeStore(), testLogger).(state.TransactionalStore)
+	assert.False(t, ok)
+}
+
+type closableStore struct {
+	*fakeStore
+	closed bool
+}
+
+func (c *closableStore) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestClose(t *testing.T) {
+	inner := &closableStore{fakeStore: newFakeStore()}
+	s := NewStore("app1", inner, testLogger)
+	s.Start(time.Hour)
+
+	assert.NoError(t, s.Close())
+	assert.True(t, inner.closed)
+	// closing twice is safe
+	assert.NoError(t, s.Close())
+}
@@ -0,0 +1,230 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/state/query"
+)
+
+const (
+	// PollIntervalMetadataKey is the subscription metadata key holding the interval at which
+	// the keys are polled on state stores without a change feed, as a duration such as "5s"
+	PollIntervalMetadataKey = "pollInterval"
+	// DefaultPollInterval is the poll interval of subscriptions which don't set one
+	DefaultPollInterval = time.Second
+)
+
+// ErrPrefixNotSupported is returned when subscribing to a key prefix on a state store
+// which has no change feed and does not support queries
+var ErrPrefixNotSupported = errors.New("state store does not support subscribing to key prefixes")
+
+// Request is a subscription to the changes of keys and of the keys starting with prefixes
+type Request struct {
+	Keys     []string
+	Prefixes []string
+	Metadata map[string]string
+}
+
+// Change is the new state of a key. Data and ETag are empty if the key was deleted.
+type Change struct {
+	Key     string `json:"key"`
+	Data    []byte `json:"data,omitempty"`
+	ETag    string `json:"etag,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// Watcher is implemented by state stores with a native change feed
+type Watcher interface {
+	// Watch calls handler for each change of the subscribed keys until ctx is done or handler fails
+	Watch(ctx context.Context, req *Request, handler func(Change) error) error
+}
+
+// GetPollInterval returns the poll interval requested by the metadata, or the default poll interval
+func GetPollInterval(metadata map[string]string) (time.Duration, error) {
+	for k, v := range metadata {
+		if !strings.EqualFold(k, PollIntervalMetadataKey) {
+			continue
+		}
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return 0, fmt.Errorf("invalid %s value %q", PollIntervalMetadataKey, v)
+		}
+		return interval, nil
+	}
+	return DefaultPollInterval, nil
+}
+
+// Validate returns an error if the subscription can't be served by the store
+func Validate(store state.Store, req *Request) error {
+	if len(req.Keys) == 0 && len(req.Prefixes) == 0 {
+		return errors.New("at least one key or prefix is required")
+	}
+	if _, ok := store.(Watcher); ok {
+		return nil
+	}
+	if _, ok := store.(query.Querier); !ok && len(req.Prefixes) > 0 {
+		return ErrPrefixNotSupported
+	}
+	_, err := GetPollInterval(req.Metadata)
+	return err
+}
+
+// Subscribe calls handler for each change of the subscribed keys until ctx is done, in which case
+// it returns nil, or until handler fails. Stores implementing Watcher are watched, other stores are polled:
+// the keys are read and the prefixes queried at the poll interval, and the keys whose value or ETag changed
+// since the previous poll are notified. Changes made between two polls are notified once.
+func Subscribe(ctx context.Context, store state.Store, req *Request, handler func(Change) error) error {
+	if err := Validate(store, req); err != nil {
+		return err
+	}
+	if watcher, ok := store.(Watcher); ok {
+		return watcher.Watch(ctx, req, handler)
+	}
+
+	interval, _ := GetPollInterval(req.Metadata)
+	p := &poller{store: store, req: req}
+	if err := p.init(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changes, err := p.poll()
+			if err != nil {
+				// the changes are notified by the next successful poll
+				continue
+			}
+			for _, c := range changes {
+				if err := handler(c); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// poller detects the changes of the subscribed keys by comparing their versions between polls
+type poller struct {
+	store state.Store
+	req   *Request
+	// versions are the ETags of the existing keys, or their values on stores without ETags
+	versions map[string]string
+}
+
+func (p *poller) init() error {
+	snapshot, err := p.snapshot()
+	if err != nil {
+		return err
+	}
+	p.versions = versions(snapshot)
+	return nil
+}
+
+// poll returns the changes since the previous poll, the deleted keys are last
+func (p *poller) poll() ([]Change, error) {
+	snapshot, err := p.snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	seen := map[string]bool{}
+	for _, c := range snapshot {
+		if seen[c.Key] {
+			continue
+		}
+		seen[c.Key] = true
+		if v, ok := p.versions[c.Key]; !ok || v != version(c) {
+			changes = append(changes, c)
+		}
+	}
+	var deleted []string
+	for k := range p.versions {
+		if !seen[k] {
+			deleted = append(deleted, k)
+		}
+	}
+	sort.Strings(deleted)
+	for _, k := range deleted {
+		changes = append(changes, Change{Key: k, Deleted: true})
+	}
+	p.versions = versions(snapshot)
+	return changes, nil
+}
+
+// snapshot returns the current state of the existing subscribed keys
+func (p *poller) snapshot() ([]Change, error) {
+	var snapshot []Change
+	for _, k := range p.req.Keys {
+		resp, err := p.store.Get(&state.GetRequest{Key: k, Metadata: p.req.Metadata})
+		if err != nil {
+			return nil, err
+		}
+		if resp != nil && (len(resp.Data) > 0 || resp.ETag != "") {
+			snapshot = append(snapshot, Change{Key: k, Data: resp.Data, ETag: resp.ETag})
+		}
+	}
+
+	for _, prefix := range p.req.Prefixes {
+		items, err := p.queryPrefix(prefix)
+		if err != nil {
+			return nil, err
+		}
+		snapshot = append(snapshot, items...)
+	}
+	return snapshot, nil
+}
+
+func (p *poller) queryPrefix(prefix string) ([]Change, error) {
+	querier := p.store.(query.Querier)
+	var items []Change
+	token := ""
+	for {
+		resp, err := querier.Query(&query.Request{
+			Query:     query.Query{Page: query.Pagination{Token: token}},
+			KeyPrefix: prefix,
+			Metadata:  p.req.Metadata,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Results {
+			items = append(items, Change{Key: item.Key, Data: item.Data, ETag: item.ETag})
+		}
+		if resp.Token == "" || resp.Token == token {
+			return items, nil
+		}
+		token = resp.Token
+	}
+}
+
+func versions(snapshot []Change) map[string]string {
+	v := make(map[string]string, len(snapshot))
+	for _, c := range snapshot {
+		v[c.Key] = version(c)
+	}
+	return v
+}
+
+func version(c Change) string {
+	if c.ETag != "" {
+		return c.ETag
+	}
+	return string(c.Data)
+}
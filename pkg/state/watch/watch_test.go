@@ -0,0 +1,276 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/state/query"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore struct {
+	lock    sync.Mutex
+	items   map[string][]byte
+	etags   map[string]int
+	noETags bool
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{items: map[string][]byte{}, etags: map[string]int{}}
+}
+
+func (m *memoryStore) Init(metadata state.Metadata) error { return nil }
+
+func (m *memoryStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	data, ok := m.items[req.Key]
+	if !ok {
+		return &state.GetResponse{}, nil
+	}
+	return &state.GetResponse{Data: data, ETag: m.etag(req.Key)}, nil
+}
+
+func (m *memoryStore) Set(req *state.SetRequest) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.items[req.Key] = req.Value.([]byte)
+	m.etags[req.Key]++
+	return nil
+}
+
+func (m *memoryStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		m.Set(&req[i])
+	}
+	return nil
+}
+
+func (m *memoryStore) Delete(req *state.DeleteRequest) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.items, req.Key)
+	return nil
+}
+
+func (m *memoryStore) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		m.Delete(&req[i])
+	}
+	return nil
+}
+
+func (m *memoryStore) etag(key string) string {
+	if m.noETags {
+		return ""
+	}
+	return strconv.Itoa(m.etags[key])
+}
+
+type querierStore struct {
+	*memoryStore
+}
+
+// Query returns a page of a single key, to exercise pagination
+func (q querierStore) Query(req *query.Request) (*query.Response, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	var keys []string
+	for k := range q.items {
+		if strings.HasPrefix(k, req.KeyPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	start := 0
+	if req.Query.Page.Token != "" {
+		start, _ = strconv.Atoi(req.Query.Page.Token)
+	}
+	resp := &query.Response{}
+	if start < len(keys) {
+		k := keys[start]
+		resp.Results = []query.Item{{Key: k, Data: q.items[k], ETag: q.etag(k)}}
+	}
+	if start+1 < len(keys) {
+		resp.Token = strconv.Itoa(start + 1)
+	}
+	return resp, nil
+}
+
+type watcherStore struct {
+	*memoryStore
+	req *Request
+}
+
+func (w *watcherStore) Watch(ctx context.Context, req *Request, handler func(Change) error) error {
+	w.req = req
+	return handler(Change{Key: "key1", Data: []byte("1")})
+}
+
+func set(store state.Store, key, value string) {
+	store.Set(&state.SetRequest{Key: key, Value: []byte(value)})
+}
+
+func TestGetPollInterval(t *testing.T) {
+	interval, err := GetPollInterval(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultPollInterval, interval)
+
+	interval, err = GetPollInterval(map[string]string{"pollinterval": "5s"})
+	assert.Nil(t, err)
+	assert.Equal(t, 5*time.Second, interval)
+
+	_, err = GetPollInterval(map[string]string{PollIntervalMetadataKey: "-1s"})
+	assert.NotNil(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	store := newMemoryStore()
+
+	assert.NotNil(t, Validate(store, &Request{}))
+	assert.Nil(t, Validate(store, &Request{Keys: []string{"key1"}}))
+	assert.Equal(t, ErrPrefixNotSupported, Validate(store, &Request{Prefixes: []string{"key"}}))
+	assert.Nil(t, Validate(querierStore{store}, &Request{Prefixes: []string{"key"}}))
+	assert.Nil(t, Validate(&watcherStore{memoryStore: store}, &Request{Prefixes: []string{"key"}}))
+}
+
+func TestPoll(t *testing.T) {
+	t.Run("keys", func(t *testing.T) {
+		store := newMemoryStore()
+		set(store, "key1", "1")
+		set(store, "key2", "2")
+		p := &poller{store: store, req: &Request{Keys: []string{"key1", "key2", "key3"}}}
+		assert.Nil(t, p.init())
+
+		changes, err := p.poll()
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+
+		set(store, "key1", "1")
+		set(store, "key3", "3")
+		store.Delete(&state.DeleteRequest{Key: "key2"})
+		changes, err = p.poll()
+		assert.Nil(t, err)
+		assert.Equal(t, []Change{
+			{Key: "key1", Data: []byte("1"), ETag: "2"},
+			{Key: "key3", Data: []byte("3"), ETag: "1"},
+			{Key: "key2", Deleted: true},
+		}, changes)
+	})
+
+	t.Run("store without etags", func(t *testing.T) {
+		store := newMemoryStore()
+		store.noETags = true
+		set(store, "key1", "1")
+		p := &poller{store: store, req: &Request{Keys: []string{"key1"}}}
+		assert.Nil(t, p.init())
+
+		set(store, "key1", "1")
+		changes, _ := p.poll()
+		assert.Empty(t, changes)
+
+		set(store, "key1", "2")
+		changes, _ = p.poll()
+		assert.Equal(t, []Change{{Key: "key1", Data: []byte("2")}}, changes)
+	})
+
+	t.Run("prefixes", func(t *testing.T) {
+		store := newMemoryStore()
+		set(store, "order1", "1")
+		set(store, "order2", "2")
+		set(store, "user1", "1")
+		p := &poller{store: querierStore{store}, req: &Request{Keys: []string{"order1"}, Prefixes: []string{"order"}}}
+		assert.Nil(t, p.init())
+
+		set(store, "order1", "1")
+		set(store, "order3", "3")
+		set(store, "user1", "1")
+		changes, err := p.poll()
+		assert.Nil(t, err)
+		assert.Equal(t, []Change{
+			{Key: "order1", Data: []byte("1"), ETag: "2"},
+			{Key: "order3", Data: []byte("3"), ETag: "1"},
+		}, changes)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Run("polling", func(t *testing.T) {
+		store := newMemoryStore()
+		req := &Request{Keys: []string{"key1"}, Metadata: map[string]string{PollIntervalMetadataKey: "10ms"}}
+		changes := make(chan Change, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- Subscribe(ctx, store, req, func(c Change) error {
+				changes <- c
+				return nil
+			})
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+		set(store, "key1", "1")
+		select {
+		case c := <-changes:
+			assert.Equal(t, "key1", c.Key)
+			assert.Equal(t, []byte("1"), c.Data)
+		case <-time.After(time.Second):
+			assert.Fail(t, "change was not notified")
+		}
+
+		cancel()
+		assert.Nil(t, <-done)
+	})
+
+	t.Run("handler fails", func(t *testing.T) {
+		store := newMemoryStore()
+		req := &Request{Keys: []string{"key1"}, Metadata: map[string]string{PollIntervalMetadataKey: "10ms"}}
+		failure := errors.New("disconnected")
+		done := make(chan error)
+		go func() {
+			done <- Subscribe(context.Background(), store, req, func(c Change) error {
+				return failure
+			})
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+		set(store, "key1", "1")
+		select {
+		case err := <-done:
+			assert.Equal(t, failure, err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "subscription did not stop")
+		}
+	})
+
+	t.Run("native change feed", func(t *testing.T) {
+		store := &watcherStore{memoryStore: newMemoryStore()}
+		req := &Request{Prefixes: []string{"key"}}
+		var changes []Change
+		err := Subscribe(context.Background(), store, req, func(c Change) error {
+			changes = append(changes, c)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, req, store.req)
+		assert.Equal(t, []Change{{Key: "key1", Data: []byte("1")}}, changes)
+	})
+
+	t.Run("prefix not supported", func(t *testing.T) {
+		err := Subscribe(context.Background(), newMemoryStore(), &Request{Prefixes: []string{"key"}}, nil)
+		assert.Equal(t, ErrPrefixNotSupported, err)
+	})
+}
@@ -9,6 +9,7 @@ package testing
 
 import (
 	"context"
+	"io"
 
 	actors "github.com/dapr/dapr/pkg/actors"
 	v1 "github.com/dapr/dapr/pkg/messaging/v1"
@@ -43,6 +44,38 @@ func (_m *MockActors) Call(ctx context.Context, req *v1.InvokeMethodRequest) (*v
 	return r0, r1
 }
 
+// CallStream provides a mock function with given fields: req
+func (_m *MockActors) CallStream(ctx context.Context, req *v1.InvokeMethodRequest) (*v1.InvokeMethodResponse, io.ReadCloser, error) {
+	ret := _m.Called(req)
+
+	var r0 *v1.InvokeMethodResponse
+	if rf, ok := ret.Get(0).(func(*v1.InvokeMethodRequest) *v1.InvokeMethodResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.InvokeMethodResponse)
+		}
+	}
+
+	var r1 io.ReadCloser
+	if rf, ok := ret.Get(1).(func(*v1.InvokeMethodRequest) io.ReadCloser); ok {
+		r1 = rf(req)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*v1.InvokeMethodRequest) error); ok {
+		r2 = rf(req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // CreateReminder provides a mock function with given fields: req
 func (_m *MockActors) CreateReminder(ctx context.Context, req *actors.CreateReminderRequest) error {
 	ret := _m.Called(req)
@@ -220,3 +253,36 @@ func (_m *MockActors) GetActiveActorsCount(ctx context.Context) []actors.ActiveA
 		},
 	}
 }
+
+// Stop provides a mock function
+func (_m *MockActors) Stop() {
+	_m.Called()
+}
+
+// SetAppHealthy provides a mock function with given fields: healthy
+func (_m *MockActors) SetAppHealthy(healthy bool) {
+	_m.Called(healthy)
+}
+
+// ListReminders provides a mock function with given fields: req
+func (_m *MockActors) ListReminders(ctx context.Context, req *actors.ListRemindersRequest) (*actors.ListRemindersResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *actors.ListRemindersResponse
+	if rf, ok := ret.Get(0).(func(*actors.ListRemindersRequest) *actors.ListRemindersResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*actors.ListRemindersResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*actors.ListRemindersRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,79 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package workflows
+
+import (
+	"context"
+	"io"
+	nethttp "net/http"
+	"strings"
+
+	"github.com/dapr/dapr/pkg/channel"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+)
+
+const (
+	actorsMethodPrefix = "actors/"
+	actorMethodSegment = "/method/"
+)
+
+// actorChannel serves the calls of the actor runtime to the workflow actors, and passes the other
+// calls to the app channel
+type actorChannel struct {
+	channel.AppChannel
+	engine *ActorEngine
+}
+
+// streamingActorChannel is the actorChannel of a streaming app channel
+type streamingActorChannel struct {
+	actorChannel
+}
+
+// AppChannel returns the app channel which the actor runtime hosting the workflow actors must use
+func (e *ActorEngine) AppChannel() channel.AppChannel {
+	c := actorChannel{AppChannel: e.appChannel, engine: e}
+	if _, ok := e.appChannel.(channel.StreamingAppChannel); ok {
+		return &streamingActorChannel{c}
+	}
+	return &c
+}
+
+func (c *actorChannel) GetBaseAddress() string {
+	if c.AppChannel == nil {
+		return ""
+	}
+	return c.AppChannel.GetBaseAddress()
+}
+
+func (c *actorChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	prefix := actorsMethodPrefix + c.engine.actorType + "/"
+	method := req.Message().Method
+	if !strings.HasPrefix(method, prefix) {
+		if c.AppChannel == nil {
+			return invokev1.NewInvokeMethodResponse(nethttp.StatusNotFound, "app channel not initialized", nil), nil
+		}
+		return c.AppChannel.InvokeMethod(ctx, req)
+	}
+
+	// the activation and deactivation calls are acknowledged, the state of the instances is loaded by every call
+	rest := strings.TrimPrefix(method, prefix)
+	i := strings.Index(rest, actorMethodSegment)
+	if i < 0 {
+		return invokev1.NewInvokeMethodResponse(nethttp.StatusOK, "", nil), nil
+	}
+
+	_, data := req.RawData()
+	b, err := c.engine.invokeActor(ctx, rest[:i], rest[i+len(actorMethodSegment):], data)
+	if err != nil {
+		resp := invokev1.NewInvokeMethodResponse(nethttp.StatusInternalServerError, err.Error(), nil)
+		return resp.WithRawData([]byte(err.Error()), "text/plain"), nil
+	}
+	return invokev1.NewInvokeMethodResponse(nethttp.StatusOK, "", nil).WithRawData(b, invokev1.JSONContentType), nil
+}
+
+func (c *streamingActorChannel) InvokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, io.ReadCloser, error) {
+	return c.AppChannel.(channel.StreamingAppChannel).InvokeMethodStream(ctx, req)
+}
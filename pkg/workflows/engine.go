@@ -0,0 +1,443 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"strings"
+	"time"
+
+	"github.com/dapr/dapr/pkg/actors"
+	"github.com/dapr/dapr/pkg/channel"
+	"github.com/dapr/dapr/pkg/logger"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/google/uuid"
+)
+
+const (
+	workflowMethodPrefix = "workflows/"
+	activityMethodPrefix = "activities/"
+
+	startMethod      = "start"
+	getMethod        = "get"
+	terminateMethod  = "terminate"
+	raiseEventMethod = "raiseEvent"
+	remindMethod     = "remind/"
+
+	stateKey            = "instance"
+	runReminderPrefix   = "run-"
+	timerReminderPrefix = "timer-"
+
+	// maxStepsPerRun is the number of times a workflow runs in an actor turn, so that a workflow calling
+	// activities for a long time doesn't keep the calls to its instance waiting
+	maxStepsPerRun = 100
+	// retryDueTime is when a workflow runs again after the app couldn't be reached
+	retryDueTime = "5s"
+)
+
+var log = logger.NewLogger("dapr.runtime.workflows")
+
+// instanceState is the state of a workflow instance, saved in the state of its actor
+type instanceState struct {
+	Instance
+	History []Event `json:"history"`
+}
+
+type startRequest struct {
+	WorkflowName string          `json:"workflowName"`
+	Input        json.RawMessage `json:"input,omitempty"`
+}
+
+type instanceRequest struct {
+	WorkflowName string          `json:"workflowName"`
+	EventName    string          `json:"eventName,omitempty"`
+	Data         json.RawMessage `json:"data,omitempty"`
+}
+
+// appError is returned when the app responds to a call with an error status
+type appError struct {
+	method string
+	body   string
+}
+
+func (e appError) Error() string {
+	return fmt.Sprintf("error from %s: %s", e.method, e.body)
+}
+
+// ActorEngine is the Engine which keeps every workflow instance in an actor of an internal actor type of
+// the app, so that the instance is run by a single replica of the app at a time and moves with the actors
+// when the replicas change. Workflows are run in reminders of the actors, so that the instances make
+// progress after the runtime restarts.
+type ActorEngine struct {
+	actorType  string
+	appChannel channel.AppChannel
+	actors     actors.Actors
+}
+
+// NewActorEngine returns an ActorEngine running the workflows of the app over its app channel.
+// The engine serves its actor type over the channel returned by AppChannel, which the actor
+// runtime must be created with, and calls actors once SetActors is called.
+func NewActorEngine(appID string, appChannel channel.AppChannel) *ActorEngine {
+	return &ActorEngine{
+		actorType:  fmt.Sprintf("dapr.internal.%s.workflow", appID),
+		appChannel: appChannel,
+	}
+}
+
+// ActorType returns the internal actor type of the workflow instances, which the runtime hosts
+func (e *ActorEngine) ActorType() string {
+	return e.actorType
+}
+
+// SetActors sets the actor runtime which hosts the workflow instances
+func (e *ActorEngine) SetActors(a actors.Actors) {
+	e.actors = a
+}
+
+// Start starts an instance of the workflow
+func (e *ActorEngine) Start(ctx context.Context, workflowName, instanceID string, input json.RawMessage) error {
+	b, err := json.Marshal(&startRequest{WorkflowName: workflowName, Input: input})
+	if err != nil {
+		return err
+	}
+	_, err = e.callActor(ctx, instanceID, startMethod, b)
+	return err
+}
+
+// Get returns the instance of the workflow, without its history
+func (e *ActorEngine) Get(ctx context.Context, workflowName, instanceID string) (*Instance, error) {
+	b, err := json.Marshal(&instanceRequest{WorkflowName: workflowName})
+	if err != nil {
+		return nil, err
+	}
+	b, err = e.callActor(ctx, instanceID, getMethod, b)
+	if err != nil {
+		return nil, err
+	}
+	var instance Instance
+	if err := json.Unmarshal(b, &instance); err != nil {
+		return nil, err
+	}
+	return &instance, nil
+}
+
+// Terminate stops an instance of the workflow
+func (e *ActorEngine) Terminate(ctx context.Context, workflowName, instanceID string) error {
+	b, err := json.Marshal(&instanceRequest{WorkflowName: workflowName})
+	if err != nil {
+		return err
+	}
+	_, err = e.callActor(ctx, instanceID, terminateMethod, b)
+	return err
+}
+
+// RaiseEvent adds an event to the history of an instance of the workflow
+func (e *ActorEngine) RaiseEvent(ctx context.Context, workflowName, instanceID, eventName string, data json.RawMessage) error {
+	b, err := json.Marshal(&instanceRequest{WorkflowName: workflowName, EventName: eventName, Data: data})
+	if err != nil {
+		return err
+	}
+	_, err = e.callActor(ctx, instanceID, raiseEventMethod, b)
+	return err
+}
+
+func (e *ActorEngine) callActor(ctx context.Context, instanceID, method string, data []byte) ([]byte, error) {
+	req := invokev1.NewInvokeMethodRequest(method)
+	req.WithActor(e.actorType, instanceID)
+	req.WithRawData(data, invokev1.JSONContentType)
+
+	resp, err := e.actors.Call(ctx, req)
+	if err != nil {
+		// the errors of the actor come back as text when it's hosted by another replica
+		for _, known := range []error{ErrInstanceNotFound, ErrInstanceExists} {
+			if strings.Contains(err.Error(), known.Error()) {
+				return nil, known
+			}
+		}
+		return nil, err
+	}
+	_, b := resp.RawData()
+	return b, nil
+}
+
+// invokeActor serves a method of the actor of a workflow instance, in a turn of the actor
+func (e *ActorEngine) invokeActor(ctx context.Context, instanceID, method string, data []byte) ([]byte, error) {
+	switch {
+	case method == startMethod:
+		var req startRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			return nil, err
+		}
+		return nil, e.start(ctx, instanceID, &req)
+	case method == getMethod || method == terminateMethod || method == raiseEventMethod:
+		var req instanceRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			return nil, err
+		}
+		s, err := e.loadState(ctx, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil || s.WorkflowName != req.WorkflowName {
+			return nil, ErrInstanceNotFound
+		}
+		switch method {
+		case getMethod:
+			return json.Marshal(&s.Instance)
+		case terminateMethod:
+			return nil, e.terminate(ctx, s)
+		default:
+			return nil, e.raiseEvent(ctx, s, &req)
+		}
+	case strings.HasPrefix(method, remindMethod):
+		var reminder actors.ReminderResponse
+		if err := json.Unmarshal(data, &reminder); err != nil {
+			return nil, err
+		}
+		timerName, _ := reminder.Data.(string)
+		return nil, e.run(ctx, instanceID, strings.TrimPrefix(method, remindMethod), timerName)
+	default:
+		return nil, fmt.Errorf("unknown workflow actor method %s", method)
+	}
+}
+
+func (e *ActorEngine) start(ctx context.Context, instanceID string, req *startRequest) error {
+	s, err := e.loadState(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	if s != nil && !s.IsDone() {
+		return ErrInstanceExists
+	}
+
+	now := time.Now().UTC()
+	s = &instanceState{
+		Instance: Instance{
+			ID:           instanceID,
+			WorkflowName: req.WorkflowName,
+			Status:       StatusRunning,
+			Input:        req.Input,
+			CreatedAt:    now,
+		},
+		History: []Event{},
+	}
+	if err := e.saveState(ctx, s); err != nil {
+		return err
+	}
+	return e.wake(ctx, instanceID, "0s", "")
+}
+
+func (e *ActorEngine) terminate(ctx context.Context, s *instanceState) error {
+	if s.IsDone() {
+		return nil
+	}
+	s.Status = StatusTerminated
+	return e.saveState(ctx, s)
+}
+
+func (e *ActorEngine) raiseEvent(ctx context.Context, s *instanceState, req *instanceRequest) error {
+	if s.IsDone() {
+		return nil
+	}
+	s.History = append(s.History, Event{
+		Type:      EventRaised,
+		Name:      req.EventName,
+		Data:      req.Data,
+		Timestamp: time.Now().UTC(),
+	})
+	if err := e.saveState(ctx, s); err != nil {
+		return err
+	}
+	return e.wake(ctx, s.ID, "0s", "")
+}
+
+// wake creates a reminder running the workflow of the instance. Every reminder gets a new name, because
+// the actor runtime deletes a reminder by name once it fired, which could delete a newer reminder.
+func (e *ActorEngine) wake(ctx context.Context, instanceID, dueTime, timerName string) error {
+	name := runReminderPrefix + uuid.New().String()
+	var data interface{}
+	if timerName != "" {
+		name = timerReminderPrefix + uuid.New().String()
+		data = timerName
+	}
+	return e.actors.CreateReminder(ctx, &actors.CreateReminderRequest{
+		Name:      name,
+		ActorType: e.actorType,
+		ActorID:   instanceID,
+		DueTime:   dueTime,
+		Data:      data,
+	})
+}
+
+// run runs the workflow of the instance until it waits for events or timers, or is done
+func (e *ActorEngine) run(ctx context.Context, instanceID, reminderName, timerName string) error {
+	s, err := e.loadState(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	if s == nil || s.IsDone() {
+		return nil
+	}
+	if strings.HasPrefix(reminderName, timerReminderPrefix) {
+		s.History = append(s.History, Event{Type: EventTimerFired, Name: timerName, Timestamp: time.Now().UTC()})
+		if err := e.saveState(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	for step := 0; step < maxStepsPerRun; step++ {
+		b, err := json.Marshal(&RunRequest{InstanceID: s.ID, Input: s.Input, History: s.History})
+		if err != nil {
+			return err
+		}
+		b, err = e.invokeApp(ctx, workflowMethodPrefix+s.WorkflowName, b)
+		if _, ok := err.(appError); ok {
+			return e.fail(ctx, s, err.Error())
+		} else if err != nil {
+			log.Warnf("error running workflow %s of instance %s, retrying in %s: %s", s.WorkflowName, s.ID, retryDueTime, err)
+			return e.wake(ctx, s.ID, retryDueTime, "")
+		}
+
+		var resp RunResponse
+		if err := json.Unmarshal(b, &resp); err != nil {
+			return e.fail(ctx, s, fmt.Sprintf("invalid response of workflow %s: %s", s.WorkflowName, err))
+		}
+		if resp.CustomStatus != nil {
+			s.CustomStatus = resp.CustomStatus
+		}
+		if resp.Error != "" {
+			return e.fail(ctx, s, resp.Error)
+		}
+		if resp.Completed {
+			s.Status = StatusCompleted
+			s.Output = resp.Output
+			return e.saveState(ctx, s)
+		}
+
+		for _, t := range resp.Timers {
+			if err := e.createTimer(ctx, s, t); err != nil {
+				return e.fail(ctx, s, err.Error())
+			}
+		}
+		if len(resp.Activities) == 0 {
+			return e.saveState(ctx, s)
+		}
+		for _, a := range resp.Activities {
+			event, err := e.callActivity(ctx, s, a)
+			if err != nil {
+				log.Warnf("error calling activity %s of instance %s, retrying in %s: %s", a.Name, s.ID, retryDueTime, err)
+				if err := e.saveState(ctx, s); err != nil {
+					return err
+				}
+				return e.wake(ctx, s.ID, retryDueTime, "")
+			}
+			s.History = append(s.History, *event)
+			if err := e.saveState(ctx, s); err != nil {
+				return err
+			}
+		}
+	}
+	return e.wake(ctx, s.ID, "0s", "")
+}
+
+func (e *ActorEngine) fail(ctx context.Context, s *instanceState, msg string) error {
+	s.Status = StatusFailed
+	s.Error = msg
+	return e.saveState(ctx, s)
+}
+
+func (e *ActorEngine) createTimer(ctx context.Context, s *instanceState, t Timer) error {
+	for _, event := range s.History {
+		if event.Type == EventTimerCreated && event.Name == t.Name {
+			return nil
+		}
+	}
+	if _, err := time.ParseDuration(t.DueTime); err != nil {
+		return fmt.Errorf("invalid due time of timer %s: %s", t.Name, err)
+	}
+	if err := e.wake(ctx, s.ID, t.DueTime, t.Name); err != nil {
+		return err
+	}
+	s.History = append(s.History, Event{Type: EventTimerCreated, Name: t.Name, Timestamp: time.Now().UTC()})
+	return nil
+}
+
+// callActivity returns the event of the result of the activity, or an error if the app couldn't be reached
+func (e *ActorEngine) callActivity(ctx context.Context, s *instanceState, a ActivityCall) (*Event, error) {
+	b, err := json.Marshal(&ActivityRequest{InstanceID: s.ID, Input: a.Input})
+	if err != nil {
+		return nil, err
+	}
+	event := &Event{Type: EventActivityCompleted, Name: a.Name}
+	b, err = e.invokeApp(ctx, activityMethodPrefix+a.Name, b)
+	if _, ok := err.(appError); ok {
+		event.Type = EventActivityFailed
+		event.Error = err.Error()
+	} else if err != nil {
+		return nil, err
+	} else if len(b) > 0 {
+		if !json.Valid(b) {
+			b, _ = json.Marshal(string(b))
+		}
+		event.Data = b
+	}
+	event.Timestamp = time.Now().UTC()
+	return event, nil
+}
+
+// invokeApp invokes a method of the app. An appError is returned when the app responds with an error status.
+func (e *ActorEngine) invokeApp(ctx context.Context, method string, data []byte) ([]byte, error) {
+	if e.appChannel == nil {
+		return nil, errors.New("app channel not initialized")
+	}
+	req := invokev1.NewInvokeMethodRequest(method)
+	req.WithHTTPExtension(nethttp.MethodPost, "")
+	req.WithRawData(data, invokev1.JSONContentType)
+
+	resp, err := e.appChannel.InvokeMethod(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	_, body := resp.RawData()
+	code := resp.Status().Code
+	if (resp.IsHTTPResponse() && (code < 200 || code > 299)) || (!resp.IsHTTPResponse() && code != 0) {
+		return nil, appError{method: method, body: string(body)}
+	}
+	return body, nil
+}
+
+func (e *ActorEngine) loadState(ctx context.Context, instanceID string) (*instanceState, error) {
+	resp, err := e.actors.GetState(ctx, &actors.GetStateRequest{
+		ActorType: e.actorType,
+		ActorID:   instanceID,
+		Key:       stateKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || len(resp.Data) == 0 {
+		return nil, nil
+	}
+	var s instanceState
+	if err := json.Unmarshal(resp.Data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (e *ActorEngine) saveState(ctx context.Context, s *instanceState) error {
+	s.LastUpdatedAt = time.Now().UTC()
+	return e.actors.SaveState(ctx, &actors.SaveStateRequest{
+		ActorType: e.actorType,
+		ActorID:   s.ID,
+		Key:       stateKey,
+		Value:     s,
+	})
+}
@@ -0,0 +1,290 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/actors"
+	"github.com/dapr/dapr/pkg/channel"
+	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeActors calls the actors over the app channel like the actor runtime does for local actors,
+// and keeps their state and reminders in memory
+type fakeActors struct {
+	actors.Actors
+	channel   channel.AppChannel
+	state     map[string][]byte
+	reminders []actors.CreateReminderRequest
+}
+
+func newFakeActors() *fakeActors {
+	return &fakeActors{state: map[string][]byte{}}
+}
+
+func (f *fakeActors) Call(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	actor := req.Actor()
+	req.Message().Method = fmt.Sprintf("actors/%s/%s/method/%s", actor.GetActorType(), actor.GetActorId(), req.Message().Method)
+	resp, err := f.channel.InvokeMethod(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status().Code != nethttp.StatusOK {
+		_, body := resp.RawData()
+		return nil, fmt.Errorf("error from actor service: %s", string(body))
+	}
+	return resp, nil
+}
+
+func (f *fakeActors) GetState(ctx context.Context, req *actors.GetStateRequest) (*actors.StateResponse, error) {
+	return &actors.StateResponse{Data: f.state[req.ActorType+"||"+req.ActorID+"||"+req.Key]}, nil
+}
+
+func (f *fakeActors) SaveState(ctx context.Context, req *actors.SaveStateRequest) error {
+	b, err := json.Marshal(req.Value)
+	f.state[req.ActorType+"||"+req.ActorID+"||"+req.Key] = b
+	return err
+}
+
+func (f *fakeActors) CreateReminder(ctx context.Context, req *actors.CreateReminderRequest) error {
+	f.reminders = append(f.reminders, *req)
+	return nil
+}
+
+// fire fires the pending reminders like one-shot reminders of the actor runtime
+func (f *fakeActors) fire(t *testing.T) {
+	reminders := f.reminders
+	f.reminders = nil
+	for _, r := range reminders {
+		b, _ := json.Marshal(&actors.ReminderResponse{Data: r.Data, DueTime: r.DueTime})
+		req := invokev1.NewInvokeMethodRequest(fmt.Sprintf("actors/%s/%s/method/remind/%s", r.ActorType, r.ActorID, r.Name))
+		req.WithRawData(b, invokev1.JSONContentType)
+		resp, err := f.channel.InvokeMethod(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(nethttp.StatusOK), resp.Status().Code)
+	}
+}
+
+type fakeAppChannel struct {
+	calls   []string
+	handler func(method string, data []byte) (int, []byte, error)
+}
+
+func (f *fakeAppChannel) GetBaseAddress() string {
+	return ""
+}
+
+func (f *fakeAppChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	method := req.Message().Method
+	f.calls = append(f.calls, method)
+	_, data := req.RawData()
+	code, body, err := f.handler(method, data)
+	if err != nil {
+		return nil, err
+	}
+	return invokev1.NewInvokeMethodResponse(int32(code), "", nil).WithRawData(body, invokev1.JSONContentType), nil
+}
+
+// orderWorkflow reserves the order, then waits for an approval event or a timeout
+func orderWorkflow(method string, data []byte) (int, []byte, error) {
+	switch method {
+	case "activities/reserve":
+		var req ActivityRequest
+		json.Unmarshal(data, &req)
+		return 200, []byte(`{"reserved":` + string(req.Input) + `}`), nil
+	case "activities/fail":
+		return 500, []byte("out of stock"), nil
+	case "workflows/order":
+		var req RunRequest
+		json.Unmarshal(data, &req)
+		resp := RunResponse{Activities: []ActivityCall{{Name: "reserve", Input: req.Input}}}
+		for _, e := range req.History {
+			switch e.Type {
+			case EventActivityCompleted:
+				resp = RunResponse{Timers: []Timer{{Name: "timeout", DueTime: "1h"}}, CustomStatus: json.RawMessage(`"waiting"`)}
+			case EventActivityFailed:
+				resp = RunResponse{Error: e.Error}
+			case EventRaised:
+				resp = RunResponse{Completed: true, Output: e.Data}
+			case EventTimerFired:
+				resp = RunResponse{Error: "approval timed out"}
+			}
+		}
+		b, _ := json.Marshal(&resp)
+		return 200, b, nil
+	case "workflows/failing":
+		b, _ := json.Marshal(&RunResponse{Activities: []ActivityCall{{Name: "fail"}}})
+		var req RunRequest
+		json.Unmarshal(data, &req)
+		if len(req.History) > 0 {
+			b, _ = json.Marshal(&RunResponse{Error: req.History[0].Error})
+		}
+		return 200, b, nil
+	}
+	return 404, nil, nil
+}
+
+func newTestEngine(handler func(method string, data []byte) (int, []byte, error)) (*ActorEngine, *fakeActors, *fakeAppChannel) {
+	app := &fakeAppChannel{handler: handler}
+	engine := NewActorEngine("app1", app)
+	a := newFakeActors()
+	a.channel = engine.AppChannel()
+	engine.SetActors(a)
+	return engine, a, app
+}
+
+func TestWorkflowCompletes(t *testing.T) {
+	engine, a, app := newTestEngine(orderWorkflow)
+	ctx := context.Background()
+	assert.Equal(t, "dapr.internal.app1.workflow", engine.ActorType())
+
+	assert.NoError(t, engine.Start(ctx, "order", "order1", json.RawMessage(`"book"`)))
+	assert.Equal(t, ErrInstanceExists, engine.Start(ctx, "order", "order1", nil))
+	assert.Len(t, a.reminders, 1)
+	assert.Empty(t, app.calls)
+
+	a.fire(t)
+	assert.Equal(t, []string{"workflows/order", "activities/reserve", "workflows/order"}, app.calls)
+	assert.Len(t, a.reminders, 1)
+	assert.Equal(t, "1h", a.reminders[0].DueTime)
+	assert.Equal(t, "timeout", a.reminders[0].Data)
+	timer := a.reminders
+	a.reminders = nil
+
+	instance, err := engine.Get(ctx, "order", "order1")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusRunning, instance.Status)
+	assert.Equal(t, `"waiting"`, string(instance.CustomStatus))
+	assert.Equal(t, `"book"`, string(instance.Input))
+
+	assert.NoError(t, engine.RaiseEvent(ctx, "order", "order1", "approval", json.RawMessage(`{"by":"alice"}`)))
+	a.fire(t)
+
+	instance, err = engine.Get(ctx, "order", "order1")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, instance.Status)
+	assert.Equal(t, `{"by":"alice"}`, string(instance.Output))
+
+	// timers of done instances are ignored
+	a.reminders = timer
+	a.fire(t)
+	instance, _ = engine.Get(ctx, "order", "order1")
+	assert.Equal(t, StatusCompleted, instance.Status)
+
+	// done instances can be started again
+	assert.NoError(t, engine.Start(ctx, "order", "order1", nil))
+}
+
+func TestWorkflowTimerFires(t *testing.T) {
+	engine, a, _ := newTestEngine(orderWorkflow)
+	ctx := context.Background()
+
+	assert.NoError(t, engine.Start(ctx, "order", "order1", json.RawMessage(`"book"`)))
+	a.fire(t)
+	a.fire(t)
+
+	instance, err := engine.Get(ctx, "order", "order1")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, instance.Status)
+	assert.Equal(t, "approval timed out", instance.Error)
+}
+
+func TestWorkflowActivityFails(t *testing.T) {
+	engine, a, _ := newTestEngine(orderWorkflow)
+	ctx := context.Background()
+
+	assert.NoError(t, engine.Start(ctx, "failing", "order1", nil))
+	a.fire(t)
+
+	instance, err := engine.Get(ctx, "failing", "order1")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, instance.Status)
+	assert.Equal(t, "error from activities/fail: out of stock", instance.Error)
+}
+
+func TestWorkflowNotFound(t *testing.T) {
+	engine, a, _ := newTestEngine(orderWorkflow)
+	ctx := context.Background()
+
+	_, err := engine.Get(ctx, "order", "order1")
+	assert.Equal(t, ErrInstanceNotFound, err)
+	assert.Equal(t, ErrInstanceNotFound, engine.Terminate(ctx, "order", "order1"))
+	assert.Equal(t, ErrInstanceNotFound, engine.RaiseEvent(ctx, "order", "order1", "approval", nil))
+
+	assert.NoError(t, engine.Start(ctx, "order", "order1", nil))
+	_, err = engine.Get(ctx, "other", "order1")
+	assert.Equal(t, ErrInstanceNotFound, err)
+
+	t.Run("workflow not registered by the app", func(t *testing.T) {
+		assert.NoError(t, engine.Start(ctx, "unknown", "order2", nil))
+		a.reminders = a.reminders[1:]
+		a.fire(t)
+		instance, err := engine.Get(ctx, "unknown", "order2")
+		assert.NoError(t, err)
+		assert.Equal(t, StatusFailed, instance.Status)
+	})
+}
+
+func TestWorkflowTerminate(t *testing.T) {
+	engine, a, app := newTestEngine(orderWorkflow)
+	ctx := context.Background()
+
+	assert.NoError(t, engine.Start(ctx, "order", "order1", nil))
+	assert.NoError(t, engine.Terminate(ctx, "order", "order1"))
+	a.fire(t)
+	assert.Empty(t, app.calls)
+
+	instance, err := engine.Get(ctx, "order", "order1")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusTerminated, instance.Status)
+}
+
+func TestWorkflowRetriesUnreachableApp(t *testing.T) {
+	unreachable := true
+	engine, a, _ := newTestEngine(func(method string, data []byte) (int, []byte, error) {
+		if unreachable {
+			return 0, nil, errors.New("connection refused")
+		}
+		return orderWorkflow(method, data)
+	})
+	ctx := context.Background()
+
+	assert.NoError(t, engine.Start(ctx, "order", "order1", nil))
+	a.fire(t)
+	assert.Len(t, a.reminders, 1)
+	assert.Equal(t, retryDueTime, a.reminders[0].DueTime)
+
+	unreachable = false
+	a.fire(t)
+	instance, err := engine.Get(ctx, "order", "order1")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusRunning, instance.Status)
+	assert.Equal(t, `"waiting"`, string(instance.CustomStatus))
+}
+
+func TestActorChannelPassesAppCalls(t *testing.T) {
+	engine, _, app := newTestEngine(func(method string, data []byte) (int, []byte, error) {
+		return 200, nil, nil
+	})
+
+	for _, method := range []string{"actors/myactor/1/method/foo", "mymethod"} {
+		req := invokev1.NewInvokeMethodRequest(method)
+		_, err := engine.AppChannel().InvokeMethod(context.Background(), req)
+		assert.NoError(t, err)
+	}
+	req := invokev1.NewInvokeMethodRequest("actors/dapr.internal.app1.workflow/1/deactivate")
+	resp, err := engine.AppChannel().InvokeMethod(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(200), resp.Status().Code)
+	assert.Equal(t, []string{"actors/myactor/1/method/foo", "mymethod"}, app.calls)
+}
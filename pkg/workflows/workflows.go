@@ -0,0 +1,119 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Runtime statuses of workflow instances
+const (
+	StatusRunning    = "RUNNING"
+	StatusCompleted  = "COMPLETED"
+	StatusFailed     = "FAILED"
+	StatusTerminated = "TERMINATED"
+)
+
+// Types of the events of the history of workflow instances
+const (
+	EventActivityCompleted = "activityCompleted"
+	EventActivityFailed    = "activityFailed"
+	EventTimerCreated      = "timerCreated"
+	EventTimerFired        = "timerFired"
+	EventRaised            = "eventRaised"
+)
+
+var (
+	// ErrInstanceNotFound is returned when a workflow instance doesn't exist
+	ErrInstanceNotFound = errors.New("workflow instance not found")
+	// ErrInstanceExists is returned when a workflow instance is started while an instance with the same ID is running
+	ErrInstanceExists = errors.New("workflow instance already running")
+)
+
+// Engine runs the workflows of the app. A workflow is code of the app which the engine runs every time
+// its instance makes progress, with the history of the instance. The workflow replays the history to
+// find where it stands, and returns what to do next: the activities to call, the timers to wait for,
+// or its output once it is done.
+type Engine interface {
+	// Start starts an instance of the workflow. The ID of the instance is chosen by the app.
+	Start(ctx context.Context, workflowName, instanceID string, input json.RawMessage) error
+	// Get returns the instance of the workflow, without its history
+	Get(ctx context.Context, workflowName, instanceID string) (*Instance, error)
+	// Terminate stops an instance of the workflow. The workflow isn't run again.
+	Terminate(ctx context.Context, workflowName, instanceID string) error
+	// RaiseEvent adds an event to the history of an instance of the workflow, and runs the workflow
+	RaiseEvent(ctx context.Context, workflowName, instanceID, eventName string, data json.RawMessage) error
+}
+
+// Instance is an instance of a workflow
+type Instance struct {
+	ID            string          `json:"instanceID"`
+	WorkflowName  string          `json:"workflowName"`
+	Status        string          `json:"runtimeStatus"`
+	Input         json.RawMessage `json:"input,omitempty"`
+	Output        json.RawMessage `json:"output,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	CustomStatus  json.RawMessage `json:"customStatus,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	LastUpdatedAt time.Time       `json:"lastUpdatedAt"`
+}
+
+// IsDone returns true once the instance completed, failed or was terminated
+func (i *Instance) IsDone() bool {
+	return i.Status != StatusRunning
+}
+
+// Event is an event of the history of a workflow instance. The events of activities carry the output
+// or the error of the activity, and raised events carry the data they were raised with.
+type Event struct {
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// RunRequest is the body of the workflows/<name> method of the app, which runs a workflow
+type RunRequest struct {
+	InstanceID string          `json:"instanceID"`
+	Input      json.RawMessage `json:"input,omitempty"`
+	History    []Event         `json:"history"`
+}
+
+// RunResponse is what a workflow does next. The activities are called in order, and their results are
+// added to the history before the workflow runs again. A timer adds a timerFired event to the history
+// once it is due, and is only created once per name. The workflow is done once it is completed or
+// returns an error.
+type RunResponse struct {
+	Activities   []ActivityCall  `json:"activities,omitempty"`
+	Timers       []Timer         `json:"timers,omitempty"`
+	CustomStatus json.RawMessage `json:"customStatus,omitempty"`
+	Completed    bool            `json:"completed,omitempty"`
+	Output       json.RawMessage `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// ActivityCall is a call of the activities/<name> method of the app
+type ActivityCall struct {
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input,omitempty"`
+}
+
+// Timer is a durable timer of a workflow. DueTime is a duration such as 1h30m.
+type Timer struct {
+	Name    string `json:"name"`
+	DueTime string `json:"dueTime"`
+}
+
+// ActivityRequest is the body of the activities/<name> method of the app. The body of the response
+// of the app is the output of the activity.
+type ActivityRequest struct {
+	InstanceID string          `json:"instanceID"`
+	Input      json.RawMessage `json:"input,omitempty"`
+}